@@ -120,6 +120,7 @@ func CreateOpenAIPromptConfig(
 
 	promptConfig, promptConfigCreateErr := db.GetQueries().
 		CreatePromptConfig(ctx, models.CreatePromptConfigParams{
+			Name:                      RandomString(10),
 			ModelType:                 models.ModelTypeGpt35Turbo,
 			ModelVendor:               models.ModelVendorOPENAI,
 			ModelParameters:           *modelParameters,
@@ -169,6 +170,7 @@ func CreateCoherePromptConfig(
 
 	promptConfig, promptConfigCreateErr := db.GetQueries().
 		CreatePromptConfig(ctx, models.CreatePromptConfigParams{
+			Name:                      RandomString(10),
 			ModelType:                 models.ModelTypeCommand,
 			ModelVendor:               models.ModelVendorCOHERE,
 			ModelParameters:           *modelParameters,
@@ -190,7 +192,8 @@ func CreatePromptRequestRecord(
 	promptConfigID pgtype.UUID,
 ) (*models.PromptRequestRecord, error) {
 	promptStartTime := time.Now()
-	promptFinishTime := promptStartTime.Add(10 * time.Second)
+	promptDuration := 10 * time.Second
+	promptFinishTime := promptStartTime.Add(promptDuration)
 
 	requestTokenCost := exc.MustResult(db.StringToNumeric("0.0000105"))
 	responseTokenCost := exc.MustResult(db.StringToNumeric("0.000036"))
@@ -204,8 +207,38 @@ func CreatePromptRequestRecord(
 			FinishReason:       models.PromptFinishReasonDONE,
 			StartTime:          pgtype.Timestamptz{Time: promptStartTime, Valid: true},
 			FinishTime:         pgtype.Timestamptz{Time: promptFinishTime, Valid: true},
-			DurationMs:         pgtype.Int4{Int32: 0, Valid: true},
+			DurationMs:         pgtype.Int4{Int32: int32(promptDuration.Milliseconds()), Valid: true},
 			PromptConfigID:     promptConfigID,
+			Attempts:           1,
+		})
+	if promptRequestRecordCreateErr != nil {
+		return nil, promptRequestRecordCreateErr
+	}
+
+	return &promptRequestRecord, nil
+}
+
+// CreateEmbeddingRequestRecord - creates the request record of an embeddings request, which has no prompt config.
+func CreateEmbeddingRequestRecord(
+	ctx context.Context,
+	applicationID pgtype.UUID,
+) (*models.PromptRequestRecord, error) {
+	startTime := time.Now()
+	duration := time.Second
+	finishTime := startTime.Add(duration)
+
+	requestTokenCost := exc.MustResult(db.StringToNumeric("0.0000465"))
+	promptRequestRecord, promptRequestRecordCreateErr := db.GetQueries().
+		CreatePromptRequestRecord(ctx, models.CreatePromptRequestRecordParams{
+			RequestTokens:      7,
+			RequestTokensCost:  *requestTokenCost,
+			ResponseTokensCost: *exc.MustResult(db.StringToNumeric("0")),
+			FinishReason:       models.PromptFinishReasonDONE,
+			StartTime:          pgtype.Timestamptz{Time: startTime, Valid: true},
+			FinishTime:         pgtype.Timestamptz{Time: finishTime, Valid: true},
+			DurationMs:         pgtype.Int4{Int32: int32(duration.Milliseconds()), Valid: true},
+			ApplicationID:      applicationID,
+			Attempts:           1,
 		})
 	if promptRequestRecordCreateErr != nil {
 		return nil, promptRequestRecordCreateErr
@@ -297,6 +330,41 @@ func CreateProviderPricingModels(
 			OutputTokenPrice: "0.60",
 			TokenUnitSize:    1_000_000,
 		},
+		{
+			ModelType:        models.ModelTypeTextEmbedding3Small,
+			ModelVendor:      models.ModelVendorOPENAI,
+			InputTokenPrice:  "0.02",
+			OutputTokenPrice: "0",
+			TokenUnitSize:    1_000_000,
+		},
+		{
+			ModelType:        models.ModelTypeTextEmbedding3Large,
+			ModelVendor:      models.ModelVendorOPENAI,
+			InputTokenPrice:  "0.13",
+			OutputTokenPrice: "0",
+			TokenUnitSize:    1_000_000,
+		},
+		{
+			ModelType:        models.ModelTypeTextEmbeddingAda002,
+			ModelVendor:      models.ModelVendorOPENAI,
+			InputTokenPrice:  "0.10",
+			OutputTokenPrice: "0",
+			TokenUnitSize:    1_000_000,
+		},
+		{
+			ModelType:        models.ModelTypeEmbedEnglishV30,
+			ModelVendor:      models.ModelVendorCOHERE,
+			InputTokenPrice:  "0.10",
+			OutputTokenPrice: "0",
+			TokenUnitSize:    1_000_000,
+		},
+		{
+			ModelType:        models.ModelTypeEmbedMultilingualV30,
+			ModelVendor:      models.ModelVendorCOHERE,
+			InputTokenPrice:  "0.10",
+			OutputTokenPrice: "0",
+			TokenUnitSize:    1_000_000,
+		},
 	} {
 		input, _ := db.StringToNumeric(modelType.InputTokenPrice)
 		output, _ := db.StringToNumeric(modelType.OutputTokenPrice)
@@ -9,6 +9,7 @@ import {
 	handleCreateAPIKey,
 	handleDeleteAPIKey,
 	handleRetrieveAPIKeys,
+	handleUpdateAPIKey,
 } from '@/api/index';
 import { HttpMethod } from '@/constants';
 
@@ -85,6 +86,46 @@ describe('API Keys API tests', () => {
 			);
 		});
 	});
+	describe('handleUpdateAPIKey', () => {
+		it('returns the updated API key', async () => {
+			const project = await ProjectFactory.build();
+			const application = await ApplicationFactory.build();
+			const apiKey = await APIKeyFactory.build();
+
+			mockFetch.mockResolvedValueOnce({
+				json: () => Promise.resolve(apiKey),
+				ok: true,
+			});
+
+			const body = {
+				rateLimitRequestsPerMinute: 10,
+				rateLimitTokensPerMinute: 1000,
+			};
+
+			const data = await handleUpdateAPIKey({
+				apiKeyId: apiKey.id,
+				applicationId: application.id,
+				data: body,
+				projectId: project.id,
+			});
+
+			expect(data).toEqual(apiKey);
+			expect(mockFetch).toHaveBeenCalledWith(
+				new URL(
+					`http://www.example.com/v1/projects/${project.id}/applications/${application.id}/apikeys/${apiKey.id}/`,
+				),
+				{
+					body: JSON.stringify(body),
+					headers: {
+						'Authorization': bearerToken,
+						'Content-Type': 'application/json',
+						'X-Request-Id': expect.any(String),
+					},
+					method: HttpMethod.Patch,
+				},
+			);
+		});
+	});
 	describe('handleDeleteAPIKey', () => {
 		it('returns undefined for delete API key', async () => {
 			const project = await ProjectFactory.build();
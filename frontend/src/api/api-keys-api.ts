@@ -1,6 +1,6 @@
 import { fetcher } from '@/api/fetcher';
 import { HttpMethod } from '@/constants';
-import { APIKey, APIKeyCreateBody } from '@/types';
+import { APIKey, APIKeyCreateBody, APIKeyUpdateBody } from '@/types';
 
 export async function handleCreateAPIKey({
 	applicationId,
@@ -31,6 +31,24 @@ export async function handleRetrieveAPIKeys({
 	});
 }
 
+export async function handleUpdateAPIKey({
+	applicationId,
+	projectId,
+	apiKeyId,
+	data,
+}: {
+	apiKeyId: string;
+	applicationId: string;
+	data: APIKeyUpdateBody;
+	projectId: string;
+}): Promise<APIKey> {
+	return await fetcher<APIKey>({
+		data,
+		method: HttpMethod.Patch,
+		url: `projects/${projectId}/applications/${applicationId}/apikeys/${apiKeyId}/`,
+	});
+}
+
 export async function handleDeleteAPIKey({
 	applicationId,
 	projectId,
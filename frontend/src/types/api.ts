@@ -2,12 +2,38 @@
 import { SupportTopic } from '@/constants/forms';
 import { AccessPermission, ModelVendor } from '@/types/enums';
 import {
+	EmbeddingModelType,
 	ModelParameters,
 	ModelType,
 	ProviderMessageType,
 } from '@/types/models';
 
+export type AnalyticsInterval = 'hour' | 'day' | 'week';
+
+export type AnalyticsGroupBy =
+	| 'application'
+	| 'promptConfig'
+	| 'modelType'
+	| 'modelVendor'
+	| 'finishReason';
+
+export interface AnalyticsTimeSeriesPoint {
+	bucket: string;
+	errorRate: number;
+	groupKey?: string;
+	groupName?: string;
+	p50DurationMs: number;
+	p95DurationMs: number;
+	p99DurationMs: number;
+	requestTokens: number;
+	responseTokens: number;
+	tokensCost: number;
+	totalErrors: number;
+	totalRequests: number;
+}
+
 export interface Analytics {
+	timeSeries?: AnalyticsTimeSeriesPoint[];
 	tokensCost: number;
 	totalRequests: number;
 }
@@ -34,17 +60,208 @@ export interface Application {
 	description?: string;
 	id: string;
 	name: string;
+	rateLimitRequestsPerMinute?: number;
+	rateLimitTokensPerMinute?: number;
 	updatedAt: string;
 }
 
-export type ApplicationCreateBody = Pick<Application, 'name' | 'description'>;
+export type ApplicationCreateBody = Pick<
+	Application,
+	| 'name'
+	| 'description'
+	| 'rateLimitRequestsPerMinute'
+	| 'rateLimitTokensPerMinute'
+>;
 export type ApplicationUpdateBody = Partial<ApplicationCreateBody>;
 
+export interface TrafficSplitVariant {
+	promptConfigId: string;
+	weight: number;
+}
+
+export interface TrafficSplit {
+	variants: TrafficSplitVariant[];
+}
+
+export interface TrafficSplitVariantAnalytics extends Analytics {
+	averageDurationMs: number;
+	promptConfigId: string;
+	promptConfigName: string;
+	totalErrors: number;
+	weight: number;
+}
+
+export type SpendBudgetPeriod = 'DAILY' | 'MONTHLY';
+export type SpendBudgetEnforcement = 'HARD' | 'SOFT';
+
+export interface SpendBudget {
+	applicationId?: string;
+	applicationName?: string;
+	createdAt: string;
+	currentSpend: number;
+	enforcement: SpendBudgetEnforcement;
+	id: string;
+	period: SpendBudgetPeriod;
+	periodStart: string;
+	spendLimit: number;
+	updatedAt: string;
+}
+
+export type SpendBudgetCreateBody = Pick<
+	SpendBudget,
+	'applicationId' | 'enforcement' | 'period' | 'spendLimit'
+>;
+export type SpendBudgetUpdateBody = Pick<
+	SpendBudget,
+	'enforcement' | 'spendLimit'
+>;
+
+export interface SpendBudgetAlert {
+	applicationId?: string;
+	applicationName?: string;
+	createdAt: string;
+	enforcement: SpendBudgetEnforcement;
+	id: string;
+	period: SpendBudgetPeriod;
+	periodStart: string;
+	spend: number;
+	spendBudgetId: string;
+	spendLimit: number;
+	threshold: number;
+}
+
+export interface CreditReconciliation {
+	credits: number;
+	drift: number;
+	isApplied: boolean;
+	ledgerBalance: number;
+	unrecordedAmount: number;
+	unrecordedDebits: number;
+}
+
+export type CreditLedgerEntryType = 'ADJUSTMENT' | 'DEBIT' | 'GRANT';
+
+export interface CreditGrantBody {
+	amount: number;
+	reason: string;
+}
+
+export interface CreditLedgerEntry {
+	amount: number;
+	createdAt: string;
+	createdBy?: string;
+	description: string;
+	entriesCount: number;
+	entryType: CreditLedgerEntryType;
+	id?: string;
+	paymentReference?: string;
+}
+
+export interface CreditLedgerPage {
+	entries: CreditLedgerEntry[];
+	nextOffset?: number;
+}
+
+export interface CreditConsumption {
+	consumption: number;
+	date: string;
+	requestsCount: number;
+}
+
+export interface CreditStatement {
+	closingBalance: number;
+	entries: CreditLedgerEntry[];
+	month: string;
+	openingBalance: number;
+	periodEnd: string;
+	periodStart: string;
+	totalAdjustments: number;
+	totalConsumption: number;
+	totalGrants: number;
+}
+
+export interface ProviderModelPricing<T extends ModelVendor> {
+	activeFromDate: string;
+	activeToDate?: string;
+	cachedInputTokenPrice?: number;
+	id: string;
+	imageTokenPrice?: number;
+	inputTokenPrice: number;
+	modelType: ModelType<T> | EmbeddingModelType<T>;
+	modelVendor: T;
+	outputTokenPrice: number;
+	projectId?: string;
+	reasoningTokenPrice?: number;
+	tokenUnitSize: number;
+}
+
+export type ProviderModelPricingCreateBody<T extends ModelVendor> = Omit<
+	ProviderModelPricing<T>,
+	'id'
+>;
+export interface ProviderModelPricingUpdateBody {
+	activeToDate: string | null;
+}
+
+export interface PayloadRedactionRule {
+	name: string;
+	pattern?: string;
+	preset?: 'EMAIL' | 'PHONE_NUMBER' | 'CREDIT_CARD' | 'IP_ADDRESS';
+	replacement?: string;
+}
+
+export interface PayloadLogging {
+	isEnabled: boolean;
+	redactionRules?: PayloadRedactionRule[];
+	retentionDays: number;
+}
+
+export interface PayloadMessage {
+	content: string;
+	role: string;
+}
+
+export interface PromptRequestPayload {
+	createdAt: string;
+	durationMs: number;
+	finishReason: string;
+	id: string;
+	isCacheHit: boolean;
+	isStreamResponse: boolean;
+	promptConfigId: string;
+	promptConfigName: string;
+	promptRequestRecordId: string;
+	requestMessages: PayloadMessage[];
+	requestTokens: number;
+	responseContent: string;
+	responseTokens: number;
+	templateVariables: Record<string, string>;
+}
+
+export interface PromptRequestPayloadPage {
+	nextOffset?: number;
+	payloads: PromptRequestPayload[];
+}
+
 // PromptConfig
 
+export interface FallbackModel<T extends ModelVendor> {
+	modelParameters: ModelParameters<T>;
+	modelType: ModelType<T>;
+	modelVendor: T;
+	promptMessages?: ProviderMessageType<T>[];
+}
+
+export interface Tool {
+	description?: string;
+	name: string;
+	parameters?: Record<string, any>;
+}
+
 export interface PromptConfig<T extends ModelVendor> {
 	createdAt: string;
 	expectedTemplateVariables: string[];
+	fallbackModels?: FallbackModel<ModelVendor>[];
 	id: string;
 	isDefault?: boolean;
 	modelParameters: ModelParameters<T>;
@@ -52,17 +269,61 @@ export interface PromptConfig<T extends ModelVendor> {
 	modelVendor: T;
 	name: string;
 	providerPromptMessages: ProviderMessageType<T>[];
+	responseCacheTtlSeconds?: number;
+	responseSchema?: Record<string, any>;
+	responseSchemaMaxRetries?: number;
+	tools?: Tool[];
+	trafficWeight?: number;
 	updatedAt: string;
+	version?: number;
+	versionId?: string;
 }
 
 export type PromptConfigCreateBody<T extends ModelVendor> = Pick<
 	PromptConfig<T>,
-	'name' | 'modelParameters' | 'modelType' | 'modelVendor'
+	| 'name'
+	| 'modelParameters'
+	| 'modelType'
+	| 'modelVendor'
+	| 'fallbackModels'
+	| 'responseCacheTtlSeconds'
+	| 'responseSchema'
+	| 'responseSchemaMaxRetries'
+	| 'tools'
 > & { promptMessages: ProviderMessageType<T>[] };
 
 export type PromptConfigUpdateBody<T extends ModelVendor> = Partial<
-	PromptConfigCreateBody<T>
->;
+	Omit<PromptConfigCreateBody<T>, 'responseSchema'>
+> & { responseSchema?: Record<string, any> | null };
+
+export interface PromptConfigVersion<T extends ModelVendor> {
+	createdAt: string;
+	createdByDisplayName?: string;
+	createdByUserId?: string;
+	expectedTemplateVariables: string[];
+	fallbackModels?: FallbackModel<ModelVendor>[];
+	id: string;
+	modelParameters: ModelParameters<T>;
+	modelType: ModelType<T>;
+	modelVendor: T;
+	providerPromptMessages: ProviderMessageType<T>[];
+	responseSchema?: Record<string, any>;
+	responseSchemaMaxRetries?: number;
+	tools?: Tool[];
+	version: number;
+}
+
+export interface PromptConfigVersionChange {
+	field: string;
+	from: unknown;
+	to: unknown;
+}
+
+export interface PromptConfigVersionDiff {
+	changes: PromptConfigVersionChange[];
+	fromVersion: number;
+	toVersion: number;
+}
 
 // APIKey
 
@@ -71,9 +332,18 @@ export interface APIKey {
 	hash?: string;
 	id: string;
 	name: string;
+	rateLimitRequestsPerMinute?: number;
+	rateLimitTokensPerMinute?: number;
 }
 
-export type APIKeyCreateBody = Pick<APIKey, 'name'>;
+export type APIKeyCreateBody = Pick<
+	APIKey,
+	'name' | 'rateLimitRequestsPerMinute' | 'rateLimitTokensPerMinute'
+>;
+export type APIKeyUpdateBody = Pick<
+	APIKey,
+	'rateLimitRequestsPerMinute' | 'rateLimitTokensPerMinute'
+>;
 
 // UserAccount
 
@@ -16,6 +16,17 @@ export enum CohereModelType {
 	CommandNightly = 'command-nightly',
 }
 
+export enum OpenAIEmbeddingModelType {
+	TextEmbedding3Large = 'text-embedding-3-large',
+	TextEmbedding3Small = 'text-embedding-3-small',
+	TextEmbeddingAda002 = 'text-embedding-ada-002',
+}
+
+export enum CohereEmbeddingModelType {
+	EmbedEnglishV3 = 'embed-english-v3.0',
+	EmbedMultilingualV3 = 'embed-multilingual-v3.0',
+}
+
 export enum AccessPermission {
 	ADMIN = 'ADMIN',
 	MEMBER = 'MEMBER',
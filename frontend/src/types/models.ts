@@ -3,8 +3,10 @@
 import { Record } from 'react-bootstrap-icons';
 
 import {
+	CohereEmbeddingModelType,
 	CohereModelType,
 	ModelVendor,
+	OpenAIEmbeddingModelType,
 	OpenAIModelType,
 	OpenAIPromptMessageRole,
 } from '@/types/enums';
@@ -56,6 +58,11 @@ export type ModelType<T extends ModelVendor> = T extends ModelVendor.OpenAI
 	? OpenAIModelType
 	: CohereModelType;
 
+export type EmbeddingModelType<T extends ModelVendor> =
+	T extends ModelVendor.OpenAI
+		? OpenAIEmbeddingModelType
+		: CohereEmbeddingModelType;
+
 export type ModelParameters<T extends ModelVendor> =
 	T extends ModelVendor.OpenAI
 		? OpenAIModelParameters
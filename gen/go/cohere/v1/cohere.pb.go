@@ -81,6 +81,120 @@ func (CohereModel) EnumDescriptor() ([]byte, []int) {
 	return file_cohere_v1_cohere_proto_rawDescGZIP(), []int{0}
 }
 
+// Type of Cohere Embedding Model
+type CohereEmbeddingModel int32
+
+const (
+	// Cohere Embedding Model is not specified
+	CohereEmbeddingModel_COHERE_EMBEDDING_MODEL_UNSPECIFIED CohereEmbeddingModel = 0
+	// Embed English v3 - the English embedding model.
+	CohereEmbeddingModel_COHERE_EMBEDDING_MODEL_EMBED_ENGLISH_V3 CohereEmbeddingModel = 1
+	// Embed Multilingual v3 - the multilingual embedding model.
+	CohereEmbeddingModel_COHERE_EMBEDDING_MODEL_EMBED_MULTILINGUAL_V3 CohereEmbeddingModel = 2
+)
+
+// Enum value maps for CohereEmbeddingModel.
+var (
+	CohereEmbeddingModel_name = map[int32]string{
+		0: "COHERE_EMBEDDING_MODEL_UNSPECIFIED",
+		1: "COHERE_EMBEDDING_MODEL_EMBED_ENGLISH_V3",
+		2: "COHERE_EMBEDDING_MODEL_EMBED_MULTILINGUAL_V3",
+	}
+	CohereEmbeddingModel_value = map[string]int32{
+		"COHERE_EMBEDDING_MODEL_UNSPECIFIED":           0,
+		"COHERE_EMBEDDING_MODEL_EMBED_ENGLISH_V3":      1,
+		"COHERE_EMBEDDING_MODEL_EMBED_MULTILINGUAL_V3": 2,
+	}
+)
+
+func (x CohereEmbeddingModel) Enum() *CohereEmbeddingModel {
+	p := new(CohereEmbeddingModel)
+	*p = x
+	return p
+}
+
+func (x CohereEmbeddingModel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CohereEmbeddingModel) Descriptor() protoreflect.EnumDescriptor {
+	return file_cohere_v1_cohere_proto_enumTypes[1].Descriptor()
+}
+
+func (CohereEmbeddingModel) Type() protoreflect.EnumType {
+	return &file_cohere_v1_cohere_proto_enumTypes[1]
+}
+
+func (x CohereEmbeddingModel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CohereEmbeddingModel.Descriptor instead.
+func (CohereEmbeddingModel) EnumDescriptor() ([]byte, []int) {
+	return file_cohere_v1_cohere_proto_rawDescGZIP(), []int{1}
+}
+
+// Type of the inputs of a Cohere embeddings request
+type CohereEmbeddingInputType int32
+
+const (
+	// Cohere Embedding Input Type is not specified
+	CohereEmbeddingInputType_COHERE_EMBEDDING_INPUT_TYPE_UNSPECIFIED CohereEmbeddingInputType = 0
+	// Documents stored in a vector database for search.
+	CohereEmbeddingInputType_COHERE_EMBEDDING_INPUT_TYPE_SEARCH_DOCUMENT CohereEmbeddingInputType = 1
+	// Search queries run against a vector database.
+	CohereEmbeddingInputType_COHERE_EMBEDDING_INPUT_TYPE_SEARCH_QUERY CohereEmbeddingInputType = 2
+	// Texts passed to a text classifier.
+	CohereEmbeddingInputType_COHERE_EMBEDDING_INPUT_TYPE_CLASSIFICATION CohereEmbeddingInputType = 3
+	// Texts passed to a clustering algorithm.
+	CohereEmbeddingInputType_COHERE_EMBEDDING_INPUT_TYPE_CLUSTERING CohereEmbeddingInputType = 4
+)
+
+// Enum value maps for CohereEmbeddingInputType.
+var (
+	CohereEmbeddingInputType_name = map[int32]string{
+		0: "COHERE_EMBEDDING_INPUT_TYPE_UNSPECIFIED",
+		1: "COHERE_EMBEDDING_INPUT_TYPE_SEARCH_DOCUMENT",
+		2: "COHERE_EMBEDDING_INPUT_TYPE_SEARCH_QUERY",
+		3: "COHERE_EMBEDDING_INPUT_TYPE_CLASSIFICATION",
+		4: "COHERE_EMBEDDING_INPUT_TYPE_CLUSTERING",
+	}
+	CohereEmbeddingInputType_value = map[string]int32{
+		"COHERE_EMBEDDING_INPUT_TYPE_UNSPECIFIED":     0,
+		"COHERE_EMBEDDING_INPUT_TYPE_SEARCH_DOCUMENT": 1,
+		"COHERE_EMBEDDING_INPUT_TYPE_SEARCH_QUERY":    2,
+		"COHERE_EMBEDDING_INPUT_TYPE_CLASSIFICATION":  3,
+		"COHERE_EMBEDDING_INPUT_TYPE_CLUSTERING":      4,
+	}
+)
+
+func (x CohereEmbeddingInputType) Enum() *CohereEmbeddingInputType {
+	p := new(CohereEmbeddingInputType)
+	*p = x
+	return p
+}
+
+func (x CohereEmbeddingInputType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CohereEmbeddingInputType) Descriptor() protoreflect.EnumDescriptor {
+	return file_cohere_v1_cohere_proto_enumTypes[2].Descriptor()
+}
+
+func (CohereEmbeddingInputType) Type() protoreflect.EnumType {
+	return &file_cohere_v1_cohere_proto_enumTypes[2]
+}
+
+func (x CohereEmbeddingInputType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CohereEmbeddingInputType.Descriptor instead.
+func (CohereEmbeddingInputType) EnumDescriptor() ([]byte, []int) {
+	return file_cohere_v1_cohere_proto_rawDescGZIP(), []int{2}
+}
+
 // Type of Cohere RAG Connector
 type CohereConnectorType int32
 
@@ -118,11 +232,11 @@ func (x CohereConnectorType) String() string {
 }
 
 func (CohereConnectorType) Descriptor() protoreflect.EnumDescriptor {
-	return file_cohere_v1_cohere_proto_enumTypes[1].Descriptor()
+	return file_cohere_v1_cohere_proto_enumTypes[3].Descriptor()
 }
 
 func (CohereConnectorType) Type() protoreflect.EnumType {
-	return &file_cohere_v1_cohere_proto_enumTypes[1]
+	return &file_cohere_v1_cohere_proto_enumTypes[3]
 }
 
 func (x CohereConnectorType) Number() protoreflect.EnumNumber {
@@ -131,7 +245,122 @@ func (x CohereConnectorType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use CohereConnectorType.Descriptor instead.
 func (CohereConnectorType) EnumDescriptor() ([]byte, []int) {
-	return file_cohere_v1_cohere_proto_rawDescGZIP(), []int{1}
+	return file_cohere_v1_cohere_proto_rawDescGZIP(), []int{3}
+}
+
+// Role of a Cohere chat history message author
+type CohereChatRole int32
+
+const (
+	// Cohere chat role is not specified
+	CohereChatRole_COHERE_CHAT_ROLE_UNSPECIFIED CohereChatRole = 0
+	// System message
+	CohereChatRole_COHERE_CHAT_ROLE_SYSTEM CohereChatRole = 1
+	// User message
+	CohereChatRole_COHERE_CHAT_ROLE_USER CohereChatRole = 2
+	// Chatbot message, i.e. a previous model response
+	CohereChatRole_COHERE_CHAT_ROLE_CHATBOT CohereChatRole = 3
+)
+
+// Enum value maps for CohereChatRole.
+var (
+	CohereChatRole_name = map[int32]string{
+		0: "COHERE_CHAT_ROLE_UNSPECIFIED",
+		1: "COHERE_CHAT_ROLE_SYSTEM",
+		2: "COHERE_CHAT_ROLE_USER",
+		3: "COHERE_CHAT_ROLE_CHATBOT",
+	}
+	CohereChatRole_value = map[string]int32{
+		"COHERE_CHAT_ROLE_UNSPECIFIED": 0,
+		"COHERE_CHAT_ROLE_SYSTEM":      1,
+		"COHERE_CHAT_ROLE_USER":        2,
+		"COHERE_CHAT_ROLE_CHATBOT":     3,
+	}
+)
+
+func (x CohereChatRole) Enum() *CohereChatRole {
+	p := new(CohereChatRole)
+	*p = x
+	return p
+}
+
+func (x CohereChatRole) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CohereChatRole) Descriptor() protoreflect.EnumDescriptor {
+	return file_cohere_v1_cohere_proto_enumTypes[4].Descriptor()
+}
+
+func (CohereChatRole) Type() protoreflect.EnumType {
+	return &file_cohere_v1_cohere_proto_enumTypes[4]
+}
+
+func (x CohereChatRole) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CohereChatRole.Descriptor instead.
+func (CohereChatRole) EnumDescriptor() ([]byte, []int) {
+	return file_cohere_v1_cohere_proto_rawDescGZIP(), []int{4}
+}
+
+// A Cohere chat history message
+type CohereChatMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The role of the message author
+	Role CohereChatRole `protobuf:"varint,1,opt,name=role,proto3,enum=cohere.v1.CohereChatRole" json:"role,omitempty"`
+	// The content of the message
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *CohereChatMessage) Reset() {
+	*x = CohereChatMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cohere_v1_cohere_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CohereChatMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CohereChatMessage) ProtoMessage() {}
+
+func (x *CohereChatMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_cohere_v1_cohere_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CohereChatMessage.ProtoReflect.Descriptor instead.
+func (*CohereChatMessage) Descriptor() ([]byte, []int) {
+	return file_cohere_v1_cohere_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CohereChatMessage) GetRole() CohereChatRole {
+	if x != nil {
+		return x.Role
+	}
+	return CohereChatRole_COHERE_CHAT_ROLE_UNSPECIFIED
+}
+
+func (x *CohereChatMessage) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
 }
 
 // Cohere API Request parameters
@@ -143,7 +372,7 @@ type CohereModelParameters struct {
 	// Temperature Sampling: Should be a non-negative float (0-5.0) that tunes the degree of randomness in generation.
 	// Lower temperatures mean less random generations.
 	Temperature *float32 `protobuf:"fixed32,1,opt,name=temperature,proto3,oneof" json:"temperature,omitempty"`
-	// Ensures only the top k most likely tokens are considered for generation at each step.
+	//Ensures only the top k most likely tokens are considered for generation at each step.
 	// Defaults to 0, min value of 0, max value of 500.
 	K *uint32 `protobuf:"varint,2,opt,name=k,proto3,oneof" json:"k,omitempty"`
 	// Ensures that only the most likely tokens, with total probability mass of p, are considered for generation at each step.
@@ -168,7 +397,7 @@ type CohereModelParameters struct {
 func (x *CohereModelParameters) Reset() {
 	*x = CohereModelParameters{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cohere_v1_cohere_proto_msgTypes[0]
+		mi := &file_cohere_v1_cohere_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -181,7 +410,7 @@ func (x *CohereModelParameters) String() string {
 func (*CohereModelParameters) ProtoMessage() {}
 
 func (x *CohereModelParameters) ProtoReflect() protoreflect.Message {
-	mi := &file_cohere_v1_cohere_proto_msgTypes[0]
+	mi := &file_cohere_v1_cohere_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -194,7 +423,7 @@ func (x *CohereModelParameters) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CohereModelParameters.ProtoReflect.Descriptor instead.
 func (*CohereModelParameters) Descriptor() ([]byte, []int) {
-	return file_cohere_v1_cohere_proto_rawDescGZIP(), []int{0}
+	return file_cohere_v1_cohere_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *CohereModelParameters) GetTemperature() float32 {
@@ -251,12 +480,14 @@ type CoherePromptRequest struct {
 	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 	// Cohere API Request parameters
 	Parameters *CohereModelParameters `protobuf:"bytes,3,opt,name=parameters,proto3" json:"parameters,omitempty"`
+	// The conversation history, following the prompt message
+	ChatHistory []*CohereChatMessage `protobuf:"bytes,4,rep,name=chat_history,json=chatHistory,proto3" json:"chat_history,omitempty"`
 }
 
 func (x *CoherePromptRequest) Reset() {
 	*x = CoherePromptRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cohere_v1_cohere_proto_msgTypes[1]
+		mi := &file_cohere_v1_cohere_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -269,7 +500,7 @@ func (x *CoherePromptRequest) String() string {
 func (*CoherePromptRequest) ProtoMessage() {}
 
 func (x *CoherePromptRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_cohere_v1_cohere_proto_msgTypes[1]
+	mi := &file_cohere_v1_cohere_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -282,7 +513,7 @@ func (x *CoherePromptRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CoherePromptRequest.ProtoReflect.Descriptor instead.
 func (*CoherePromptRequest) Descriptor() ([]byte, []int) {
-	return file_cohere_v1_cohere_proto_rawDescGZIP(), []int{1}
+	return file_cohere_v1_cohere_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *CoherePromptRequest) GetModel() CohereModel {
@@ -306,6 +537,13 @@ func (x *CoherePromptRequest) GetParameters() *CohereModelParameters {
 	return nil
 }
 
+func (x *CoherePromptRequest) GetChatHistory() []*CohereChatMessage {
+	if x != nil {
+		return x.ChatHistory
+	}
+	return nil
+}
+
 // The CoherePromptResponse contains the data that is returned from the Cohere API.
 type CoherePromptResponse struct {
 	state         protoimpl.MessageState
@@ -325,7 +563,7 @@ type CoherePromptResponse struct {
 func (x *CoherePromptResponse) Reset() {
 	*x = CoherePromptResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cohere_v1_cohere_proto_msgTypes[2]
+		mi := &file_cohere_v1_cohere_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -338,7 +576,7 @@ func (x *CoherePromptResponse) String() string {
 func (*CoherePromptResponse) ProtoMessage() {}
 
 func (x *CoherePromptResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_cohere_v1_cohere_proto_msgTypes[2]
+	mi := &file_cohere_v1_cohere_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -351,7 +589,7 @@ func (x *CoherePromptResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CoherePromptResponse.ProtoReflect.Descriptor instead.
 func (*CoherePromptResponse) Descriptor() ([]byte, []int) {
-	return file_cohere_v1_cohere_proto_rawDescGZIP(), []int{2}
+	return file_cohere_v1_cohere_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *CoherePromptResponse) GetContent() string {
@@ -401,7 +639,7 @@ type CohereStreamResponse struct {
 func (x *CohereStreamResponse) Reset() {
 	*x = CohereStreamResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cohere_v1_cohere_proto_msgTypes[3]
+		mi := &file_cohere_v1_cohere_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -414,7 +652,7 @@ func (x *CohereStreamResponse) String() string {
 func (*CohereStreamResponse) ProtoMessage() {}
 
 func (x *CohereStreamResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_cohere_v1_cohere_proto_msgTypes[3]
+	mi := &file_cohere_v1_cohere_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -427,7 +665,7 @@ func (x *CohereStreamResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CohereStreamResponse.ProtoReflect.Descriptor instead.
 func (*CohereStreamResponse) Descriptor() ([]byte, []int) {
-	return file_cohere_v1_cohere_proto_rawDescGZIP(), []int{3}
+	return file_cohere_v1_cohere_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *CohereStreamResponse) GetContent() string {
@@ -458,113 +696,360 @@ func (x *CohereStreamResponse) GetResponseTokensCount() uint32 {
 	return 0
 }
 
+// The CohereEmbeddingsRequest contains the data that will be sent to the Cohere embed API.
+type CohereEmbeddingsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Cohere Embedding Model identifier
+	Model CohereEmbeddingModel `protobuf:"varint,1,opt,name=model,proto3,enum=cohere.v1.CohereEmbeddingModel" json:"model,omitempty"`
+	// The inputs to embed
+	Inputs []string `protobuf:"bytes,2,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	// The type of the inputs, required by the v3 embedding models
+	InputType CohereEmbeddingInputType `protobuf:"varint,3,opt,name=input_type,json=inputType,proto3,enum=cohere.v1.CohereEmbeddingInputType" json:"input_type,omitempty"`
+}
+
+func (x *CohereEmbeddingsRequest) Reset() {
+	*x = CohereEmbeddingsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cohere_v1_cohere_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CohereEmbeddingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CohereEmbeddingsRequest) ProtoMessage() {}
+
+func (x *CohereEmbeddingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cohere_v1_cohere_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CohereEmbeddingsRequest.ProtoReflect.Descriptor instead.
+func (*CohereEmbeddingsRequest) Descriptor() ([]byte, []int) {
+	return file_cohere_v1_cohere_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CohereEmbeddingsRequest) GetModel() CohereEmbeddingModel {
+	if x != nil {
+		return x.Model
+	}
+	return CohereEmbeddingModel_COHERE_EMBEDDING_MODEL_UNSPECIFIED
+}
+
+func (x *CohereEmbeddingsRequest) GetInputs() []string {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *CohereEmbeddingsRequest) GetInputType() CohereEmbeddingInputType {
+	if x != nil {
+		return x.InputType
+	}
+	return CohereEmbeddingInputType_COHERE_EMBEDDING_INPUT_TYPE_UNSPECIFIED
+}
+
+// A Cohere embedding vector
+type CohereEmbedding struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The values of the embedding vector
+	Values []float32 `protobuf:"fixed32,1,rep,packed,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *CohereEmbedding) Reset() {
+	*x = CohereEmbedding{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cohere_v1_cohere_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CohereEmbedding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CohereEmbedding) ProtoMessage() {}
+
+func (x *CohereEmbedding) ProtoReflect() protoreflect.Message {
+	mi := &file_cohere_v1_cohere_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CohereEmbedding.ProtoReflect.Descriptor instead.
+func (*CohereEmbedding) Descriptor() ([]byte, []int) {
+	return file_cohere_v1_cohere_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CohereEmbedding) GetValues() []float32 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// The CohereEmbeddingsResponse contains the data that is returned from the Cohere embed API.
+type CohereEmbeddingsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The embedding vectors, in the order of the request inputs
+	Embeddings []*CohereEmbedding `protobuf:"bytes,1,rep,name=embeddings,proto3" json:"embeddings,omitempty"`
+	// Count of the request tokens, as returned by the Cohere API
+	RequestTokensCount uint32 `protobuf:"varint,2,opt,name=request_tokens_count,json=requestTokensCount,proto3" json:"request_tokens_count,omitempty"`
+}
+
+func (x *CohereEmbeddingsResponse) Reset() {
+	*x = CohereEmbeddingsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cohere_v1_cohere_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CohereEmbeddingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CohereEmbeddingsResponse) ProtoMessage() {}
+
+func (x *CohereEmbeddingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cohere_v1_cohere_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CohereEmbeddingsResponse.ProtoReflect.Descriptor instead.
+func (*CohereEmbeddingsResponse) Descriptor() ([]byte, []int) {
+	return file_cohere_v1_cohere_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CohereEmbeddingsResponse) GetEmbeddings() []*CohereEmbedding {
+	if x != nil {
+		return x.Embeddings
+	}
+	return nil
+}
+
+func (x *CohereEmbeddingsResponse) GetRequestTokensCount() uint32 {
+	if x != nil {
+		return x.RequestTokensCount
+	}
+	return 0
+}
+
 var File_cohere_v1_cohere_proto protoreflect.FileDescriptor
 
 var file_cohere_v1_cohere_proto_rawDesc = []byte{
 	0x0a, 0x16, 0x63, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x68, 0x65,
 	0x72, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x63, 0x6f, 0x68, 0x65, 0x72, 0x65,
-	0x2e, 0x76, 0x31, 0x22, 0xc0, 0x02, 0x0a, 0x15, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x4d, 0x6f,
-	0x64, 0x65, 0x6c, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x25, 0x0a,
-	0x0b, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x02, 0x48, 0x00, 0x52, 0x0b, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72,
-	0x65, 0x88, 0x01, 0x01, 0x12, 0x11, 0x0a, 0x01, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x48,
-	0x01, 0x52, 0x01, 0x6b, 0x88, 0x01, 0x01, 0x12, 0x11, 0x0a, 0x01, 0x70, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x02, 0x48, 0x02, 0x52, 0x01, 0x70, 0x88, 0x01, 0x01, 0x12, 0x30, 0x0a, 0x11, 0x66, 0x72,
-	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x70, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x02, 0x48, 0x03, 0x52, 0x10, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e,
-	0x63, 0x79, 0x50, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x88, 0x01, 0x01, 0x12, 0x2e, 0x0a, 0x10,
-	0x70, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x70, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x02, 0x48, 0x04, 0x52, 0x0f, 0x70, 0x72, 0x65, 0x73, 0x65, 0x6e,
-	0x63, 0x65, 0x50, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x88, 0x01, 0x01, 0x12, 0x22, 0x0a, 0x0a,
-	0x6d, 0x61, 0x78, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d,
-	0x48, 0x05, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x88, 0x01, 0x01,
-	0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65,
-	0x42, 0x04, 0x0a, 0x02, 0x5f, 0x6b, 0x42, 0x04, 0x0a, 0x02, 0x5f, 0x70, 0x42, 0x14, 0x0a, 0x12,
-	0x5f, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x70, 0x65, 0x6e, 0x61, 0x6c,
-	0x74, 0x79, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x70, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x5f,
-	0x70, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x6d, 0x61, 0x78, 0x5f,
-	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x22, 0x9f, 0x01, 0x0a, 0x13, 0x43, 0x6f, 0x68, 0x65, 0x72,
-	0x65, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c,
-	0x0a, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e,
-	0x63, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65,
-	0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x18, 0x0a, 0x07,
-	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x40, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65,
-	0x74, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6f, 0x68,
-	0x65, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x4d, 0x6f, 0x64,
-	0x65, 0x6c, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x52, 0x0a, 0x70, 0x61,
-	0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x22, 0xbb, 0x01, 0x0a, 0x14, 0x43, 0x6f, 0x68,
-	0x65, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x66,
-	0x69, 0x6e, 0x69, 0x73, 0x68, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0c, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e,
-	0x12, 0x30, 0x0a, 0x14, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
-	0x6e, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12,
-	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x43, 0x6f, 0x75,
-	0x6e, 0x74, 0x12, 0x32, 0x0a, 0x15, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x74,
-	0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x13, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
-	0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xa0, 0x02, 0x0a, 0x14, 0x43, 0x6f, 0x68, 0x65, 0x72,
-	0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x1d, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x48, 0x00, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x12, 0x28,
-	0x0a, 0x0d, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x0c, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x52,
-	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x35, 0x0a, 0x14, 0x72, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x02, 0x52, 0x12, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x12,
-	0x37, 0x0a, 0x15, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
-	0x6e, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x03,
+	0x2e, 0x76, 0x31, 0x22, 0x5c, 0x0a, 0x11, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x43, 0x68, 0x61,
+	0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2d, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x63, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x43, 0x68, 0x61, 0x74, 0x52, 0x6f, 0x6c,
+	0x65, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0xc0, 0x02, 0x0a, 0x15, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x4d, 0x6f, 0x64, 0x65,
+	0x6c, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x25, 0x0a, 0x0b, 0x74,
+	0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02,
+	0x48, 0x00, 0x52, 0x0b, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x88,
+	0x01, 0x01, 0x12, 0x11, 0x0a, 0x01, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x01, 0x52,
+	0x01, 0x6b, 0x88, 0x01, 0x01, 0x12, 0x11, 0x0a, 0x01, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02,
+	0x48, 0x02, 0x52, 0x01, 0x70, 0x88, 0x01, 0x01, 0x12, 0x30, 0x0a, 0x11, 0x66, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x70, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x02, 0x48, 0x03, 0x52, 0x10, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79,
+	0x50, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x88, 0x01, 0x01, 0x12, 0x2e, 0x0a, 0x10, 0x70, 0x72,
+	0x65, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x70, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x02, 0x48, 0x04, 0x52, 0x0f, 0x70, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x63, 0x65,
+	0x50, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x88, 0x01, 0x01, 0x12, 0x22, 0x0a, 0x0a, 0x6d, 0x61,
+	0x78, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x05,
+	0x52, 0x09, 0x6d, 0x61, 0x78, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x88, 0x01, 0x01, 0x42, 0x0e,
+	0x0a, 0x0c, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x42, 0x04,
+	0x0a, 0x02, 0x5f, 0x6b, 0x42, 0x04, 0x0a, 0x02, 0x5f, 0x70, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x66,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x70, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79,
+	0x42, 0x13, 0x0a, 0x11, 0x5f, 0x70, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x70, 0x65,
+	0x6e, 0x61, 0x6c, 0x74, 0x79, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x73, 0x22, 0xe0, 0x01, 0x0a, 0x13, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x50,
+	0x72, 0x6f, 0x6d, 0x70, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x05,
+	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x63, 0x6f,
+	0x68, 0x65, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x4d, 0x6f,
+	0x64, 0x65, 0x6c, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x40, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65,
+	0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6f, 0x68, 0x65, 0x72,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x6c,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x61,
+	0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x3f, 0x0a, 0x0c, 0x63, 0x68, 0x61, 0x74, 0x5f, 0x68,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63,
+	0x6f, 0x68, 0x65, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x43,
+	0x68, 0x61, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x0b, 0x63, 0x68, 0x61, 0x74,
+	0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x22, 0xbb, 0x01, 0x0a, 0x14, 0x43, 0x6f, 0x68, 0x65,
+	0x72, 0x65, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x69,
+	0x6e, 0x69, 0x73, 0x68, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12,
+	0x30, 0x0a, 0x14, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x32, 0x0a, 0x15, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d,
 	0x52, 0x13, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73,
-	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x63, 0x6f, 0x6e,
-	0x74, 0x65, 0x6e, 0x74, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x5f,
-	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x42, 0x17, 0x0a, 0x15, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x42,
-	0x18, 0x0a, 0x16, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x74, 0x6f, 0x6b,
-	0x65, 0x6e, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2a, 0xaf, 0x01, 0x0a, 0x0b, 0x43, 0x6f,
-	0x68, 0x65, 0x72, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x1c, 0x0a, 0x18, 0x43, 0x4f, 0x48,
-	0x45, 0x52, 0x45, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
-	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x18, 0x0a, 0x14, 0x43, 0x4f, 0x48, 0x45, 0x52,
-	0x45, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x5f, 0x43, 0x4f, 0x4d, 0x4d, 0x41, 0x4e, 0x44, 0x10,
-	0x01, 0x12, 0x1e, 0x0a, 0x1a, 0x43, 0x4f, 0x48, 0x45, 0x52, 0x45, 0x5f, 0x4d, 0x4f, 0x44, 0x45,
-	0x4c, 0x5f, 0x43, 0x4f, 0x4d, 0x4d, 0x41, 0x4e, 0x44, 0x5f, 0x4c, 0x49, 0x47, 0x48, 0x54, 0x10,
-	0x02, 0x12, 0x20, 0x0a, 0x1c, 0x43, 0x4f, 0x48, 0x45, 0x52, 0x45, 0x5f, 0x4d, 0x4f, 0x44, 0x45,
-	0x4c, 0x5f, 0x43, 0x4f, 0x4d, 0x4d, 0x41, 0x4e, 0x44, 0x5f, 0x4e, 0x49, 0x47, 0x48, 0x54, 0x4c,
-	0x59, 0x10, 0x03, 0x12, 0x26, 0x0a, 0x22, 0x43, 0x4f, 0x48, 0x45, 0x52, 0x45, 0x5f, 0x4d, 0x4f,
-	0x44, 0x45, 0x4c, 0x5f, 0x43, 0x4f, 0x4d, 0x4d, 0x41, 0x4e, 0x44, 0x5f, 0x4c, 0x49, 0x47, 0x48,
-	0x54, 0x5f, 0x4e, 0x49, 0x47, 0x48, 0x54, 0x4c, 0x59, 0x10, 0x04, 0x2a, 0x80, 0x01, 0x0a, 0x13,
-	0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x54,
-	0x79, 0x70, 0x65, 0x12, 0x25, 0x0a, 0x21, 0x43, 0x4f, 0x48, 0x45, 0x52, 0x45, 0x5f, 0x43, 0x4f,
-	0x4e, 0x4e, 0x45, 0x43, 0x54, 0x4f, 0x52, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53,
-	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x24, 0x0a, 0x20, 0x43, 0x4f,
-	0x48, 0x45, 0x52, 0x45, 0x5f, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x4f, 0x52, 0x5f, 0x54,
-	0x59, 0x50, 0x45, 0x5f, 0x57, 0x45, 0x42, 0x5f, 0x53, 0x45, 0x41, 0x52, 0x43, 0x48, 0x10, 0x01,
-	0x12, 0x1c, 0x0a, 0x18, 0x43, 0x4f, 0x48, 0x45, 0x52, 0x45, 0x5f, 0x43, 0x4f, 0x4e, 0x4e, 0x45,
-	0x43, 0x54, 0x4f, 0x52, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x49, 0x44, 0x10, 0x02, 0x32, 0xb7,
-	0x01, 0x0a, 0x0d, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x12, 0x51, 0x0a, 0x0c, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74,
-	0x12, 0x1e, 0x2e, 0x63, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x68,
-	0x65, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1f, 0x2e, 0x63, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x68,
-	0x65, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x12, 0x53, 0x0a, 0x0c, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x53, 0x74, 0x72,
-	0x65, 0x61, 0x6d, 0x12, 0x1e, 0x2e, 0x63, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e,
-	0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x63, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e,
-	0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x42, 0x98, 0x01, 0x0a, 0x0d, 0x63, 0x6f, 0x6d,
-	0x2e, 0x63, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x42, 0x0b, 0x43, 0x6f, 0x68, 0x65,
-	0x72, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x48, 0x03, 0x50, 0x01, 0x5a, 0x33, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x61, 0x73, 0x65, 0x6d, 0x69, 0x6e, 0x64,
-	0x2d, 0x61, 0x69, 0x2f, 0x6d, 0x6f, 0x6e, 0x6f, 0x72, 0x65, 0x70, 0x6f, 0x2f, 0x67, 0x65, 0x6e,
-	0x2f, 0x63, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72,
-	0xa2, 0x02, 0x03, 0x43, 0x58, 0x58, 0xaa, 0x02, 0x09, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x2e,
-	0x56, 0x31, 0xca, 0x02, 0x09, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x5c, 0x56, 0x31, 0xe2, 0x02,
-	0x15, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x0a, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x3a,
-	0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xa0, 0x02, 0x0a, 0x14, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d,
+	0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48,
+	0x00, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x12, 0x28, 0x0a,
+	0x0d, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x0c, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x52, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x35, 0x0a, 0x14, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x02, 0x52, 0x12, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x12, 0x37,
+	0x0a, 0x15, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x03, 0x52,
+	0x13, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x63, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x5f, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x42, 0x17, 0x0a, 0x15, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x18,
+	0x0a, 0x16, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xac, 0x01, 0x0a, 0x17, 0x43, 0x6f, 0x68,
+	0x65, 0x72, 0x65, 0x45, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x35, 0x0a, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x63, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x45, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x4d,
+	0x6f, 0x64, 0x65, 0x6c, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x69,
+	0x6e, 0x70, 0x75, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x69, 0x6e, 0x70,
+	0x75, 0x74, 0x73, 0x12, 0x42, 0x0a, 0x0a, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e, 0x63, 0x6f, 0x68, 0x65, 0x72, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x45, 0x6d, 0x62, 0x65, 0x64, 0x64,
+	0x69, 0x6e, 0x67, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x69, 0x6e,
+	0x70, 0x75, 0x74, 0x54, 0x79, 0x70, 0x65, 0x22, 0x29, 0x0a, 0x0f, 0x43, 0x6f, 0x68, 0x65, 0x72,
+	0x65, 0x45, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x02, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x73, 0x22, 0x88, 0x01, 0x0a, 0x18, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x45, 0x6d, 0x62,
+	0x65, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x3a, 0x0a, 0x0a, 0x65, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x45, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x52,
+	0x0a, 0x65, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x30, 0x0a, 0x14, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x5f, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x2a, 0xaf, 0x01,
+	0x0a, 0x0b, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x1c, 0x0a,
+	0x18, 0x43, 0x4f, 0x48, 0x45, 0x52, 0x45, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x18, 0x0a, 0x14, 0x43,
+	0x4f, 0x48, 0x45, 0x52, 0x45, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x5f, 0x43, 0x4f, 0x4d, 0x4d,
+	0x41, 0x4e, 0x44, 0x10, 0x01, 0x12, 0x1e, 0x0a, 0x1a, 0x43, 0x4f, 0x48, 0x45, 0x52, 0x45, 0x5f,
+	0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x5f, 0x43, 0x4f, 0x4d, 0x4d, 0x41, 0x4e, 0x44, 0x5f, 0x4c, 0x49,
+	0x47, 0x48, 0x54, 0x10, 0x02, 0x12, 0x20, 0x0a, 0x1c, 0x43, 0x4f, 0x48, 0x45, 0x52, 0x45, 0x5f,
+	0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x5f, 0x43, 0x4f, 0x4d, 0x4d, 0x41, 0x4e, 0x44, 0x5f, 0x4e, 0x49,
+	0x47, 0x48, 0x54, 0x4c, 0x59, 0x10, 0x03, 0x12, 0x26, 0x0a, 0x22, 0x43, 0x4f, 0x48, 0x45, 0x52,
+	0x45, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x5f, 0x43, 0x4f, 0x4d, 0x4d, 0x41, 0x4e, 0x44, 0x5f,
+	0x4c, 0x49, 0x47, 0x48, 0x54, 0x5f, 0x4e, 0x49, 0x47, 0x48, 0x54, 0x4c, 0x59, 0x10, 0x04, 0x2a,
+	0x9d, 0x01, 0x0a, 0x14, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x45, 0x6d, 0x62, 0x65, 0x64, 0x64,
+	0x69, 0x6e, 0x67, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x26, 0x0a, 0x22, 0x43, 0x4f, 0x48, 0x45,
+	0x52, 0x45, 0x5f, 0x45, 0x4d, 0x42, 0x45, 0x44, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x4d, 0x4f, 0x44,
+	0x45, 0x4c, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x2b, 0x0a, 0x27, 0x43, 0x4f, 0x48, 0x45, 0x52, 0x45, 0x5f, 0x45, 0x4d, 0x42, 0x45, 0x44,
+	0x44, 0x49, 0x4e, 0x47, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x5f, 0x45, 0x4d, 0x42, 0x45, 0x44,
+	0x5f, 0x45, 0x4e, 0x47, 0x4c, 0x49, 0x53, 0x48, 0x5f, 0x56, 0x33, 0x10, 0x01, 0x12, 0x30, 0x0a,
+	0x2c, 0x43, 0x4f, 0x48, 0x45, 0x52, 0x45, 0x5f, 0x45, 0x4d, 0x42, 0x45, 0x44, 0x44, 0x49, 0x4e,
+	0x47, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x5f, 0x45, 0x4d, 0x42, 0x45, 0x44, 0x5f, 0x4d, 0x55,
+	0x4c, 0x54, 0x49, 0x4c, 0x49, 0x4e, 0x47, 0x55, 0x41, 0x4c, 0x5f, 0x56, 0x33, 0x10, 0x02, 0x2a,
+	0x82, 0x02, 0x0a, 0x18, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x45, 0x6d, 0x62, 0x65, 0x64, 0x64,
+	0x69, 0x6e, 0x67, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2b, 0x0a, 0x27,
+	0x43, 0x4f, 0x48, 0x45, 0x52, 0x45, 0x5f, 0x45, 0x4d, 0x42, 0x45, 0x44, 0x44, 0x49, 0x4e, 0x47,
+	0x5f, 0x49, 0x4e, 0x50, 0x55, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x2f, 0x0a, 0x2b, 0x43, 0x4f, 0x48,
+	0x45, 0x52, 0x45, 0x5f, 0x45, 0x4d, 0x42, 0x45, 0x44, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x49, 0x4e,
+	0x50, 0x55, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x45, 0x41, 0x52, 0x43, 0x48, 0x5f,
+	0x44, 0x4f, 0x43, 0x55, 0x4d, 0x45, 0x4e, 0x54, 0x10, 0x01, 0x12, 0x2c, 0x0a, 0x28, 0x43, 0x4f,
+	0x48, 0x45, 0x52, 0x45, 0x5f, 0x45, 0x4d, 0x42, 0x45, 0x44, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x49,
+	0x4e, 0x50, 0x55, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x45, 0x41, 0x52, 0x43, 0x48,
+	0x5f, 0x51, 0x55, 0x45, 0x52, 0x59, 0x10, 0x02, 0x12, 0x2e, 0x0a, 0x2a, 0x43, 0x4f, 0x48, 0x45,
+	0x52, 0x45, 0x5f, 0x45, 0x4d, 0x42, 0x45, 0x44, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x49, 0x4e, 0x50,
+	0x55, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x49, 0x46, 0x49,
+	0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x03, 0x12, 0x2a, 0x0a, 0x26, 0x43, 0x4f, 0x48, 0x45,
+	0x52, 0x45, 0x5f, 0x45, 0x4d, 0x42, 0x45, 0x44, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x49, 0x4e, 0x50,
+	0x55, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x4c, 0x55, 0x53, 0x54, 0x45, 0x52, 0x49,
+	0x4e, 0x47, 0x10, 0x04, 0x2a, 0x80, 0x01, 0x0a, 0x13, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x43,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x25, 0x0a, 0x21,
+	0x43, 0x4f, 0x48, 0x45, 0x52, 0x45, 0x5f, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x4f, 0x52,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x24, 0x0a, 0x20, 0x43, 0x4f, 0x48, 0x45, 0x52, 0x45, 0x5f, 0x43, 0x4f,
+	0x4e, 0x4e, 0x45, 0x43, 0x54, 0x4f, 0x52, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x57, 0x45, 0x42,
+	0x5f, 0x53, 0x45, 0x41, 0x52, 0x43, 0x48, 0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x43, 0x4f, 0x48,
+	0x45, 0x52, 0x45, 0x5f, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x4f, 0x52, 0x5f, 0x54, 0x59,
+	0x50, 0x45, 0x5f, 0x49, 0x44, 0x10, 0x02, 0x2a, 0x88, 0x01, 0x0a, 0x0e, 0x43, 0x6f, 0x68, 0x65,
+	0x72, 0x65, 0x43, 0x68, 0x61, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x1c, 0x43, 0x4f,
+	0x48, 0x45, 0x52, 0x45, 0x5f, 0x43, 0x48, 0x41, 0x54, 0x5f, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1b, 0x0a, 0x17,
+	0x43, 0x4f, 0x48, 0x45, 0x52, 0x45, 0x5f, 0x43, 0x48, 0x41, 0x54, 0x5f, 0x52, 0x4f, 0x4c, 0x45,
+	0x5f, 0x53, 0x59, 0x53, 0x54, 0x45, 0x4d, 0x10, 0x01, 0x12, 0x19, 0x0a, 0x15, 0x43, 0x4f, 0x48,
+	0x45, 0x52, 0x45, 0x5f, 0x43, 0x48, 0x41, 0x54, 0x5f, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x55, 0x53,
+	0x45, 0x52, 0x10, 0x02, 0x12, 0x1c, 0x0a, 0x18, 0x43, 0x4f, 0x48, 0x45, 0x52, 0x45, 0x5f, 0x43,
+	0x48, 0x41, 0x54, 0x5f, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x43, 0x48, 0x41, 0x54, 0x42, 0x4f, 0x54,
+	0x10, 0x03, 0x32, 0x96, 0x02, 0x0a, 0x0d, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x51, 0x0a, 0x0c, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x50, 0x72,
+	0x6f, 0x6d, 0x70, 0x74, 0x12, 0x1e, 0x2e, 0x63, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x63, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x53, 0x0a, 0x0c, 0x43, 0x6f, 0x68, 0x65, 0x72,
+	0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x1e, 0x2e, 0x63, 0x6f, 0x68, 0x65, 0x72, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x63, 0x6f, 0x68, 0x65, 0x72, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x5d, 0x0a, 0x10,
+	0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x45, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x73,
+	0x12, 0x22, 0x2e, 0x63, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x68,
+	0x65, 0x72, 0x65, 0x45, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x63, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x45, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x69, 0x6e, 0x67,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x98, 0x01, 0x0a, 0x0d,
+	0x63, 0x6f, 0x6d, 0x2e, 0x63, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x42, 0x0b, 0x43,
+	0x6f, 0x68, 0x65, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x48, 0x03, 0x50, 0x01, 0x5a, 0x33,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x61, 0x73, 0x65, 0x6d,
+	0x69, 0x6e, 0x64, 0x2d, 0x61, 0x69, 0x2f, 0x6d, 0x6f, 0x6e, 0x6f, 0x72, 0x65, 0x70, 0x6f, 0x2f,
+	0x67, 0x65, 0x6e, 0x2f, 0x63, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0xa2, 0x02, 0x03, 0x43, 0x58, 0x58, 0xaa, 0x02, 0x09, 0x43, 0x6f, 0x68, 0x65,
+	0x72, 0x65, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x09, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x5c, 0x56,
+	0x31, 0xe2, 0x02, 0x15, 0x43, 0x6f, 0x68, 0x65, 0x72, 0x65, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50,
+	0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x0a, 0x43, 0x6f, 0x68, 0x65,
+	0x72, 0x65, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -579,28 +1064,42 @@ func file_cohere_v1_cohere_proto_rawDescGZIP() []byte {
 	return file_cohere_v1_cohere_proto_rawDescData
 }
 
-var file_cohere_v1_cohere_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_cohere_v1_cohere_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_cohere_v1_cohere_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_cohere_v1_cohere_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_cohere_v1_cohere_proto_goTypes = []interface{}{
-	(CohereModel)(0),              // 0: cohere.v1.CohereModel
-	(CohereConnectorType)(0),      // 1: cohere.v1.CohereConnectorType
-	(*CohereModelParameters)(nil), // 2: cohere.v1.CohereModelParameters
-	(*CoherePromptRequest)(nil),   // 3: cohere.v1.CoherePromptRequest
-	(*CoherePromptResponse)(nil),  // 4: cohere.v1.CoherePromptResponse
-	(*CohereStreamResponse)(nil),  // 5: cohere.v1.CohereStreamResponse
+	(CohereModel)(0),                 // 0: cohere.v1.CohereModel
+	(CohereEmbeddingModel)(0),        // 1: cohere.v1.CohereEmbeddingModel
+	(CohereEmbeddingInputType)(0),    // 2: cohere.v1.CohereEmbeddingInputType
+	(CohereConnectorType)(0),         // 3: cohere.v1.CohereConnectorType
+	(CohereChatRole)(0),              // 4: cohere.v1.CohereChatRole
+	(*CohereChatMessage)(nil),        // 5: cohere.v1.CohereChatMessage
+	(*CohereModelParameters)(nil),    // 6: cohere.v1.CohereModelParameters
+	(*CoherePromptRequest)(nil),      // 7: cohere.v1.CoherePromptRequest
+	(*CoherePromptResponse)(nil),     // 8: cohere.v1.CoherePromptResponse
+	(*CohereStreamResponse)(nil),     // 9: cohere.v1.CohereStreamResponse
+	(*CohereEmbeddingsRequest)(nil),  // 10: cohere.v1.CohereEmbeddingsRequest
+	(*CohereEmbedding)(nil),          // 11: cohere.v1.CohereEmbedding
+	(*CohereEmbeddingsResponse)(nil), // 12: cohere.v1.CohereEmbeddingsResponse
 }
 var file_cohere_v1_cohere_proto_depIdxs = []int32{
-	0, // 0: cohere.v1.CoherePromptRequest.model:type_name -> cohere.v1.CohereModel
-	2, // 1: cohere.v1.CoherePromptRequest.parameters:type_name -> cohere.v1.CohereModelParameters
-	3, // 2: cohere.v1.CohereService.CoherePrompt:input_type -> cohere.v1.CoherePromptRequest
-	3, // 3: cohere.v1.CohereService.CohereStream:input_type -> cohere.v1.CoherePromptRequest
-	4, // 4: cohere.v1.CohereService.CoherePrompt:output_type -> cohere.v1.CoherePromptResponse
-	5, // 5: cohere.v1.CohereService.CohereStream:output_type -> cohere.v1.CohereStreamResponse
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	4,  // 0: cohere.v1.CohereChatMessage.role:type_name -> cohere.v1.CohereChatRole
+	0,  // 1: cohere.v1.CoherePromptRequest.model:type_name -> cohere.v1.CohereModel
+	6,  // 2: cohere.v1.CoherePromptRequest.parameters:type_name -> cohere.v1.CohereModelParameters
+	5,  // 3: cohere.v1.CoherePromptRequest.chat_history:type_name -> cohere.v1.CohereChatMessage
+	1,  // 4: cohere.v1.CohereEmbeddingsRequest.model:type_name -> cohere.v1.CohereEmbeddingModel
+	2,  // 5: cohere.v1.CohereEmbeddingsRequest.input_type:type_name -> cohere.v1.CohereEmbeddingInputType
+	11, // 6: cohere.v1.CohereEmbeddingsResponse.embeddings:type_name -> cohere.v1.CohereEmbedding
+	7,  // 7: cohere.v1.CohereService.CoherePrompt:input_type -> cohere.v1.CoherePromptRequest
+	7,  // 8: cohere.v1.CohereService.CohereStream:input_type -> cohere.v1.CoherePromptRequest
+	10, // 9: cohere.v1.CohereService.CohereEmbeddings:input_type -> cohere.v1.CohereEmbeddingsRequest
+	8,  // 10: cohere.v1.CohereService.CoherePrompt:output_type -> cohere.v1.CoherePromptResponse
+	9,  // 11: cohere.v1.CohereService.CohereStream:output_type -> cohere.v1.CohereStreamResponse
+	12, // 12: cohere.v1.CohereService.CohereEmbeddings:output_type -> cohere.v1.CohereEmbeddingsResponse
+	10, // [10:13] is the sub-list for method output_type
+	7,  // [7:10] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_cohere_v1_cohere_proto_init() }
@@ -610,7 +1109,7 @@ func file_cohere_v1_cohere_proto_init() {
 	}
 	if !protoimpl.UnsafeEnabled {
 		file_cohere_v1_cohere_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CohereModelParameters); i {
+			switch v := v.(*CohereChatMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -622,7 +1121,7 @@ func file_cohere_v1_cohere_proto_init() {
 			}
 		}
 		file_cohere_v1_cohere_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CoherePromptRequest); i {
+			switch v := v.(*CohereModelParameters); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -634,7 +1133,7 @@ func file_cohere_v1_cohere_proto_init() {
 			}
 		}
 		file_cohere_v1_cohere_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CoherePromptResponse); i {
+			switch v := v.(*CoherePromptRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -646,6 +1145,18 @@ func file_cohere_v1_cohere_proto_init() {
 			}
 		}
 		file_cohere_v1_cohere_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CoherePromptResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cohere_v1_cohere_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CohereStreamResponse); i {
 			case 0:
 				return &v.state
@@ -657,16 +1168,52 @@ func file_cohere_v1_cohere_proto_init() {
 				return nil
 			}
 		}
+		file_cohere_v1_cohere_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CohereEmbeddingsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cohere_v1_cohere_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CohereEmbedding); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cohere_v1_cohere_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CohereEmbeddingsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
-	file_cohere_v1_cohere_proto_msgTypes[0].OneofWrappers = []interface{}{}
-	file_cohere_v1_cohere_proto_msgTypes[3].OneofWrappers = []interface{}{}
+	file_cohere_v1_cohere_proto_msgTypes[1].OneofWrappers = []interface{}{}
+	file_cohere_v1_cohere_proto_msgTypes[4].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_cohere_v1_cohere_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   4,
+			NumEnums:      5,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
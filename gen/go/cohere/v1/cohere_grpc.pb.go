@@ -19,8 +19,9 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	CohereService_CoherePrompt_FullMethodName = "/cohere.v1.CohereService/CoherePrompt"
-	CohereService_CohereStream_FullMethodName = "/cohere.v1.CohereService/CohereStream"
+	CohereService_CoherePrompt_FullMethodName     = "/cohere.v1.CohereService/CoherePrompt"
+	CohereService_CohereStream_FullMethodName     = "/cohere.v1.CohereService/CohereStream"
+	CohereService_CohereEmbeddings_FullMethodName = "/cohere.v1.CohereService/CohereEmbeddings"
 )
 
 // CohereServiceClient is the client API for CohereService service.
@@ -31,6 +32,8 @@ type CohereServiceClient interface {
 	CoherePrompt(ctx context.Context, in *CoherePromptRequest, opts ...grpc.CallOption) (*CoherePromptResponse, error)
 	// Request a streaming LLM prompt
 	CohereStream(ctx context.Context, in *CoherePromptRequest, opts ...grpc.CallOption) (CohereService_CohereStreamClient, error)
+	// Request embedding vectors for a batch of inputs
+	CohereEmbeddings(ctx context.Context, in *CohereEmbeddingsRequest, opts ...grpc.CallOption) (*CohereEmbeddingsResponse, error)
 }
 
 type cohereServiceClient struct {
@@ -82,6 +85,15 @@ func (x *cohereServiceCohereStreamClient) Recv() (*CohereStreamResponse, error)
 	return m, nil
 }
 
+func (c *cohereServiceClient) CohereEmbeddings(ctx context.Context, in *CohereEmbeddingsRequest, opts ...grpc.CallOption) (*CohereEmbeddingsResponse, error) {
+	out := new(CohereEmbeddingsResponse)
+	err := c.cc.Invoke(ctx, CohereService_CohereEmbeddings_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CohereServiceServer is the server API for CohereService service.
 // All implementations must embed UnimplementedCohereServiceServer
 // for forward compatibility
@@ -90,6 +102,8 @@ type CohereServiceServer interface {
 	CoherePrompt(context.Context, *CoherePromptRequest) (*CoherePromptResponse, error)
 	// Request a streaming LLM prompt
 	CohereStream(*CoherePromptRequest, CohereService_CohereStreamServer) error
+	// Request embedding vectors for a batch of inputs
+	CohereEmbeddings(context.Context, *CohereEmbeddingsRequest) (*CohereEmbeddingsResponse, error)
 	mustEmbedUnimplementedCohereServiceServer()
 }
 
@@ -103,6 +117,9 @@ func (UnimplementedCohereServiceServer) CoherePrompt(context.Context, *CoherePro
 func (UnimplementedCohereServiceServer) CohereStream(*CoherePromptRequest, CohereService_CohereStreamServer) error {
 	return status.Errorf(codes.Unimplemented, "method CohereStream not implemented")
 }
+func (UnimplementedCohereServiceServer) CohereEmbeddings(context.Context, *CohereEmbeddingsRequest) (*CohereEmbeddingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CohereEmbeddings not implemented")
+}
 func (UnimplementedCohereServiceServer) mustEmbedUnimplementedCohereServiceServer() {}
 
 // UnsafeCohereServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -155,6 +172,24 @@ func (x *cohereServiceCohereStreamServer) Send(m *CohereStreamResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _CohereService_CohereEmbeddings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CohereEmbeddingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CohereServiceServer).CohereEmbeddings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CohereService_CohereEmbeddings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CohereServiceServer).CohereEmbeddings(ctx, req.(*CohereEmbeddingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CohereService_ServiceDesc is the grpc.ServiceDesc for CohereService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -166,6 +201,10 @@ var CohereService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CoherePrompt",
 			Handler:    _CohereService_CoherePrompt_Handler,
 		},
+		{
+			MethodName: "CohereEmbeddings",
+			Handler:    _CohereService_CohereEmbeddings_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
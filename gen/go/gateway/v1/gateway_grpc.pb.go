@@ -19,8 +19,12 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	APIGatewayService_RequestPrompt_FullMethodName          = "/gateway.v1.APIGatewayService/RequestPrompt"
-	APIGatewayService_RequestStreamingPrompt_FullMethodName = "/gateway.v1.APIGatewayService/RequestStreamingPrompt"
+	APIGatewayService_RequestPrompt_FullMethodName           = "/gateway.v1.APIGatewayService/RequestPrompt"
+	APIGatewayService_RequestStreamingPrompt_FullMethodName  = "/gateway.v1.APIGatewayService/RequestStreamingPrompt"
+	APIGatewayService_RequestEmbeddings_FullMethodName       = "/gateway.v1.APIGatewayService/RequestEmbeddings"
+	APIGatewayService_CreateBatchJob_FullMethodName          = "/gateway.v1.APIGatewayService/CreateBatchJob"
+	APIGatewayService_RetrieveBatchJob_FullMethodName        = "/gateway.v1.APIGatewayService/RetrieveBatchJob"
+	APIGatewayService_RetrieveBatchJobResults_FullMethodName = "/gateway.v1.APIGatewayService/RetrieveBatchJobResults"
 )
 
 // APIGatewayServiceClient is the client API for APIGatewayService service.
@@ -31,6 +35,14 @@ type APIGatewayServiceClient interface {
 	RequestPrompt(ctx context.Context, in *PromptRequest, opts ...grpc.CallOption) (*PromptResponse, error)
 	// Request a streaming LLM prompt
 	RequestStreamingPrompt(ctx context.Context, in *PromptRequest, opts ...grpc.CallOption) (APIGatewayService_RequestStreamingPromptClient, error)
+	// Request embedding vectors for a batch of inputs
+	RequestEmbeddings(ctx context.Context, in *EmbeddingsRequest, opts ...grpc.CallOption) (*EmbeddingsResponse, error)
+	// Submit a batch job, executing a prompt config for each set of template variables in the background
+	CreateBatchJob(ctx context.Context, in *BatchJobRequest, opts ...grpc.CallOption) (*BatchJobCreatedResponse, error)
+	// Retrieve the status and progress of a batch job
+	RetrieveBatchJob(ctx context.Context, in *BatchJobStatusRequest, opts ...grpc.CallOption) (*BatchJobStatusResponse, error)
+	// Retrieve a page of the item results of a batch job, ordered by the item index
+	RetrieveBatchJobResults(ctx context.Context, in *BatchJobResultsRequest, opts ...grpc.CallOption) (*BatchJobResultsResponse, error)
 }
 
 type aPIGatewayServiceClient struct {
@@ -82,6 +94,42 @@ func (x *aPIGatewayServiceRequestStreamingPromptClient) Recv() (*StreamingPrompt
 	return m, nil
 }
 
+func (c *aPIGatewayServiceClient) RequestEmbeddings(ctx context.Context, in *EmbeddingsRequest, opts ...grpc.CallOption) (*EmbeddingsResponse, error) {
+	out := new(EmbeddingsResponse)
+	err := c.cc.Invoke(ctx, APIGatewayService_RequestEmbeddings_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIGatewayServiceClient) CreateBatchJob(ctx context.Context, in *BatchJobRequest, opts ...grpc.CallOption) (*BatchJobCreatedResponse, error) {
+	out := new(BatchJobCreatedResponse)
+	err := c.cc.Invoke(ctx, APIGatewayService_CreateBatchJob_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIGatewayServiceClient) RetrieveBatchJob(ctx context.Context, in *BatchJobStatusRequest, opts ...grpc.CallOption) (*BatchJobStatusResponse, error) {
+	out := new(BatchJobStatusResponse)
+	err := c.cc.Invoke(ctx, APIGatewayService_RetrieveBatchJob_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIGatewayServiceClient) RetrieveBatchJobResults(ctx context.Context, in *BatchJobResultsRequest, opts ...grpc.CallOption) (*BatchJobResultsResponse, error) {
+	out := new(BatchJobResultsResponse)
+	err := c.cc.Invoke(ctx, APIGatewayService_RetrieveBatchJobResults_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // APIGatewayServiceServer is the server API for APIGatewayService service.
 // All implementations must embed UnimplementedAPIGatewayServiceServer
 // for forward compatibility
@@ -90,6 +138,14 @@ type APIGatewayServiceServer interface {
 	RequestPrompt(context.Context, *PromptRequest) (*PromptResponse, error)
 	// Request a streaming LLM prompt
 	RequestStreamingPrompt(*PromptRequest, APIGatewayService_RequestStreamingPromptServer) error
+	// Request embedding vectors for a batch of inputs
+	RequestEmbeddings(context.Context, *EmbeddingsRequest) (*EmbeddingsResponse, error)
+	// Submit a batch job, executing a prompt config for each set of template variables in the background
+	CreateBatchJob(context.Context, *BatchJobRequest) (*BatchJobCreatedResponse, error)
+	// Retrieve the status and progress of a batch job
+	RetrieveBatchJob(context.Context, *BatchJobStatusRequest) (*BatchJobStatusResponse, error)
+	// Retrieve a page of the item results of a batch job, ordered by the item index
+	RetrieveBatchJobResults(context.Context, *BatchJobResultsRequest) (*BatchJobResultsResponse, error)
 	mustEmbedUnimplementedAPIGatewayServiceServer()
 }
 
@@ -103,6 +159,18 @@ func (UnimplementedAPIGatewayServiceServer) RequestPrompt(context.Context, *Prom
 func (UnimplementedAPIGatewayServiceServer) RequestStreamingPrompt(*PromptRequest, APIGatewayService_RequestStreamingPromptServer) error {
 	return status.Errorf(codes.Unimplemented, "method RequestStreamingPrompt not implemented")
 }
+func (UnimplementedAPIGatewayServiceServer) RequestEmbeddings(context.Context, *EmbeddingsRequest) (*EmbeddingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestEmbeddings not implemented")
+}
+func (UnimplementedAPIGatewayServiceServer) CreateBatchJob(context.Context, *BatchJobRequest) (*BatchJobCreatedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBatchJob not implemented")
+}
+func (UnimplementedAPIGatewayServiceServer) RetrieveBatchJob(context.Context, *BatchJobStatusRequest) (*BatchJobStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetrieveBatchJob not implemented")
+}
+func (UnimplementedAPIGatewayServiceServer) RetrieveBatchJobResults(context.Context, *BatchJobResultsRequest) (*BatchJobResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetrieveBatchJobResults not implemented")
+}
 func (UnimplementedAPIGatewayServiceServer) mustEmbedUnimplementedAPIGatewayServiceServer() {}
 
 // UnsafeAPIGatewayServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -155,6 +223,78 @@ func (x *aPIGatewayServiceRequestStreamingPromptServer) Send(m *StreamingPromptR
 	return x.ServerStream.SendMsg(m)
 }
 
+func _APIGatewayService_RequestEmbeddings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmbeddingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIGatewayServiceServer).RequestEmbeddings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: APIGatewayService_RequestEmbeddings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIGatewayServiceServer).RequestEmbeddings(ctx, req.(*EmbeddingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIGatewayService_CreateBatchJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIGatewayServiceServer).CreateBatchJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: APIGatewayService_CreateBatchJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIGatewayServiceServer).CreateBatchJob(ctx, req.(*BatchJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIGatewayService_RetrieveBatchJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchJobStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIGatewayServiceServer).RetrieveBatchJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: APIGatewayService_RetrieveBatchJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIGatewayServiceServer).RetrieveBatchJob(ctx, req.(*BatchJobStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIGatewayService_RetrieveBatchJobResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchJobResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIGatewayServiceServer).RetrieveBatchJobResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: APIGatewayService_RetrieveBatchJobResults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIGatewayServiceServer).RetrieveBatchJobResults(ctx, req.(*BatchJobResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // APIGatewayService_ServiceDesc is the grpc.ServiceDesc for APIGatewayService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -166,6 +306,22 @@ var APIGatewayService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RequestPrompt",
 			Handler:    _APIGatewayService_RequestPrompt_Handler,
 		},
+		{
+			MethodName: "RequestEmbeddings",
+			Handler:    _APIGatewayService_RequestEmbeddings_Handler,
+		},
+		{
+			MethodName: "CreateBatchJob",
+			Handler:    _APIGatewayService_CreateBatchJob_Handler,
+		},
+		{
+			MethodName: "RetrieveBatchJob",
+			Handler:    _APIGatewayService_RetrieveBatchJob_Handler,
+		},
+		{
+			MethodName: "RetrieveBatchJobResults",
+			Handler:    _APIGatewayService_RetrieveBatchJobResults_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -81,6 +81,63 @@ func (OpenAIModel) EnumDescriptor() ([]byte, []int) {
 	return file_openai_v1_openai_proto_rawDescGZIP(), []int{0}
 }
 
+// Type of OpenAI Embedding Model
+type OpenAIEmbeddingModel int32
+
+const (
+	// OpenAI Embedding Model is not Specified
+	OpenAIEmbeddingModel_OPEN_AI_EMBEDDING_MODEL_UNSPECIFIED OpenAIEmbeddingModel = 0
+	// OpenAI Text Embedding 3 Small
+	OpenAIEmbeddingModel_OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_3_SMALL OpenAIEmbeddingModel = 1
+	// OpenAI Text Embedding 3 Large
+	OpenAIEmbeddingModel_OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_3_LARGE OpenAIEmbeddingModel = 2
+	// OpenAI Text Embedding Ada 002
+	OpenAIEmbeddingModel_OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_ADA_002 OpenAIEmbeddingModel = 3
+)
+
+// Enum value maps for OpenAIEmbeddingModel.
+var (
+	OpenAIEmbeddingModel_name = map[int32]string{
+		0: "OPEN_AI_EMBEDDING_MODEL_UNSPECIFIED",
+		1: "OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_3_SMALL",
+		2: "OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_3_LARGE",
+		3: "OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_ADA_002",
+	}
+	OpenAIEmbeddingModel_value = map[string]int32{
+		"OPEN_AI_EMBEDDING_MODEL_UNSPECIFIED":            0,
+		"OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_3_SMALL": 1,
+		"OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_3_LARGE": 2,
+		"OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_ADA_002": 3,
+	}
+)
+
+func (x OpenAIEmbeddingModel) Enum() *OpenAIEmbeddingModel {
+	p := new(OpenAIEmbeddingModel)
+	*p = x
+	return p
+}
+
+func (x OpenAIEmbeddingModel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OpenAIEmbeddingModel) Descriptor() protoreflect.EnumDescriptor {
+	return file_openai_v1_openai_proto_enumTypes[1].Descriptor()
+}
+
+func (OpenAIEmbeddingModel) Type() protoreflect.EnumType {
+	return &file_openai_v1_openai_proto_enumTypes[1]
+}
+
+func (x OpenAIEmbeddingModel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OpenAIEmbeddingModel.Descriptor instead.
+func (OpenAIEmbeddingModel) EnumDescriptor() ([]byte, []int) {
+	return file_openai_v1_openai_proto_rawDescGZIP(), []int{1}
+}
+
 // Type of OpenAI Message
 type OpenAIMessageRole int32
 
@@ -95,6 +152,8 @@ const (
 	OpenAIMessageRole_OPEN_AI_MESSAGE_ROLE_ASSISTANT OpenAIMessageRole = 3
 	// OpenAI Function message
 	OpenAIMessageRole_OPEN_AI_MESSAGE_ROLE_FUNCTION OpenAIMessageRole = 4
+	// OpenAI Tool message, holding the result of a tool call
+	OpenAIMessageRole_OPEN_AI_MESSAGE_ROLE_TOOL OpenAIMessageRole = 5
 )
 
 // Enum value maps for OpenAIMessageRole.
@@ -105,6 +164,7 @@ var (
 		2: "OPEN_AI_MESSAGE_ROLE_USER",
 		3: "OPEN_AI_MESSAGE_ROLE_ASSISTANT",
 		4: "OPEN_AI_MESSAGE_ROLE_FUNCTION",
+		5: "OPEN_AI_MESSAGE_ROLE_TOOL",
 	}
 	OpenAIMessageRole_value = map[string]int32{
 		"OPEN_AI_MESSAGE_ROLE_UNSPECIFIED": 0,
@@ -112,6 +172,7 @@ var (
 		"OPEN_AI_MESSAGE_ROLE_USER":        2,
 		"OPEN_AI_MESSAGE_ROLE_ASSISTANT":   3,
 		"OPEN_AI_MESSAGE_ROLE_FUNCTION":    4,
+		"OPEN_AI_MESSAGE_ROLE_TOOL":        5,
 	}
 )
 
@@ -126,11 +187,11 @@ func (x OpenAIMessageRole) String() string {
 }
 
 func (OpenAIMessageRole) Descriptor() protoreflect.EnumDescriptor {
-	return file_openai_v1_openai_proto_enumTypes[1].Descriptor()
+	return file_openai_v1_openai_proto_enumTypes[2].Descriptor()
 }
 
 func (OpenAIMessageRole) Type() protoreflect.EnumType {
-	return &file_openai_v1_openai_proto_enumTypes[1]
+	return &file_openai_v1_openai_proto_enumTypes[2]
 }
 
 func (x OpenAIMessageRole) Number() protoreflect.EnumNumber {
@@ -139,7 +200,7 @@ func (x OpenAIMessageRole) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use OpenAIMessageRole.Descriptor instead.
 func (OpenAIMessageRole) EnumDescriptor() ([]byte, []int) {
-	return file_openai_v1_openai_proto_rawDescGZIP(), []int{1}
+	return file_openai_v1_openai_proto_rawDescGZIP(), []int{2}
 }
 
 // An OpenAI function call
@@ -200,6 +261,140 @@ func (x *OpenAIFunctionCall) GetName() string {
 	return ""
 }
 
+// An OpenAI tool call requested by the model
+type OpenAIToolCall struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ID of the tool call
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The name of the tool to call
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// The JSON encoded arguments to call the tool with
+	Arguments string `protobuf:"bytes,3,opt,name=arguments,proto3" json:"arguments,omitempty"`
+}
+
+func (x *OpenAIToolCall) Reset() {
+	*x = OpenAIToolCall{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_openai_v1_openai_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OpenAIToolCall) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenAIToolCall) ProtoMessage() {}
+
+func (x *OpenAIToolCall) ProtoReflect() protoreflect.Message {
+	mi := &file_openai_v1_openai_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenAIToolCall.ProtoReflect.Descriptor instead.
+func (*OpenAIToolCall) Descriptor() ([]byte, []int) {
+	return file_openai_v1_openai_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OpenAIToolCall) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *OpenAIToolCall) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OpenAIToolCall) GetArguments() string {
+	if x != nil {
+		return x.Arguments
+	}
+	return ""
+}
+
+// An OpenAI tool the model may call
+type OpenAITool struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the tool
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// A description of what the tool does
+	Description *string `protobuf:"bytes,2,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// The JSON schema of the tool arguments
+	Parameters *string `protobuf:"bytes,3,opt,name=parameters,proto3,oneof" json:"parameters,omitempty"`
+}
+
+func (x *OpenAITool) Reset() {
+	*x = OpenAITool{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_openai_v1_openai_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OpenAITool) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenAITool) ProtoMessage() {}
+
+func (x *OpenAITool) ProtoReflect() protoreflect.Message {
+	mi := &file_openai_v1_openai_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenAITool.ProtoReflect.Descriptor instead.
+func (*OpenAITool) Descriptor() ([]byte, []int) {
+	return file_openai_v1_openai_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OpenAITool) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OpenAITool) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *OpenAITool) GetParameters() string {
+	if x != nil && x.Parameters != nil {
+		return *x.Parameters
+	}
+	return ""
+}
+
 // An OpenAI Chat Message
 type OpenAIMessage struct {
 	state         protoimpl.MessageState
@@ -214,12 +409,16 @@ type OpenAIMessage struct {
 	Name *string `protobuf:"bytes,3,opt,name=name,proto3,oneof" json:"name,omitempty"`
 	// The signature function to invoke, if any
 	FunctionCall *OpenAIFunctionCall `protobuf:"bytes,4,opt,name=function_call,json=functionCall,proto3,oneof" json:"function_call,omitempty"`
+	// The ID of the tool call this message is the result of, for tool messages
+	ToolCallId *string `protobuf:"bytes,5,opt,name=tool_call_id,json=toolCallId,proto3,oneof" json:"tool_call_id,omitempty"`
+	// The tool calls requested by the model, for assistant messages
+	ToolCalls []*OpenAIToolCall `protobuf:"bytes,6,rep,name=tool_calls,json=toolCalls,proto3" json:"tool_calls,omitempty"`
 }
 
 func (x *OpenAIMessage) Reset() {
 	*x = OpenAIMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_openai_v1_openai_proto_msgTypes[1]
+		mi := &file_openai_v1_openai_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -232,7 +431,7 @@ func (x *OpenAIMessage) String() string {
 func (*OpenAIMessage) ProtoMessage() {}
 
 func (x *OpenAIMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_openai_v1_openai_proto_msgTypes[1]
+	mi := &file_openai_v1_openai_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -245,7 +444,7 @@ func (x *OpenAIMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OpenAIMessage.ProtoReflect.Descriptor instead.
 func (*OpenAIMessage) Descriptor() ([]byte, []int) {
-	return file_openai_v1_openai_proto_rawDescGZIP(), []int{1}
+	return file_openai_v1_openai_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *OpenAIMessage) GetContent() string {
@@ -276,6 +475,20 @@ func (x *OpenAIMessage) GetFunctionCall() *OpenAIFunctionCall {
 	return nil
 }
 
+func (x *OpenAIMessage) GetToolCallId() string {
+	if x != nil && x.ToolCallId != nil {
+		return *x.ToolCallId
+	}
+	return ""
+}
+
+func (x *OpenAIMessage) GetToolCalls() []*OpenAIToolCall {
+	if x != nil {
+		return x.ToolCalls
+	}
+	return nil
+}
+
 // OpenAI API Request parameters
 type OpenAIModelParameters struct {
 	state         protoimpl.MessageState
@@ -297,7 +510,7 @@ type OpenAIModelParameters struct {
 func (x *OpenAIModelParameters) Reset() {
 	*x = OpenAIModelParameters{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_openai_v1_openai_proto_msgTypes[2]
+		mi := &file_openai_v1_openai_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -310,7 +523,7 @@ func (x *OpenAIModelParameters) String() string {
 func (*OpenAIModelParameters) ProtoMessage() {}
 
 func (x *OpenAIModelParameters) ProtoReflect() protoreflect.Message {
-	mi := &file_openai_v1_openai_proto_msgTypes[2]
+	mi := &file_openai_v1_openai_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -323,7 +536,7 @@ func (x *OpenAIModelParameters) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OpenAIModelParameters.ProtoReflect.Descriptor instead.
 func (*OpenAIModelParameters) Descriptor() ([]byte, []int) {
-	return file_openai_v1_openai_proto_rawDescGZIP(), []int{2}
+	return file_openai_v1_openai_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *OpenAIModelParameters) GetTemperature() float32 {
@@ -375,12 +588,16 @@ type OpenAIPromptRequest struct {
 	Parameters *OpenAIModelParameters `protobuf:"bytes,3,opt,name=parameters,proto3" json:"parameters,omitempty"`
 	// Unique application ID to keep track of conversations;
 	ApplicationId *string `protobuf:"bytes,4,opt,name=application_id,json=applicationId,proto3,oneof" json:"application_id,omitempty"`
+	// The tools the model may call
+	Tools []*OpenAITool `protobuf:"bytes,5,rep,name=tools,proto3" json:"tools,omitempty"`
+	// Whether the model must respond with a JSON object
+	JsonMode *bool `protobuf:"varint,6,opt,name=json_mode,json=jsonMode,proto3,oneof" json:"json_mode,omitempty"`
 }
 
 func (x *OpenAIPromptRequest) Reset() {
 	*x = OpenAIPromptRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_openai_v1_openai_proto_msgTypes[3]
+		mi := &file_openai_v1_openai_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -393,7 +610,7 @@ func (x *OpenAIPromptRequest) String() string {
 func (*OpenAIPromptRequest) ProtoMessage() {}
 
 func (x *OpenAIPromptRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_openai_v1_openai_proto_msgTypes[3]
+	mi := &file_openai_v1_openai_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -406,7 +623,7 @@ func (x *OpenAIPromptRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OpenAIPromptRequest.ProtoReflect.Descriptor instead.
 func (*OpenAIPromptRequest) Descriptor() ([]byte, []int) {
-	return file_openai_v1_openai_proto_rawDescGZIP(), []int{3}
+	return file_openai_v1_openai_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *OpenAIPromptRequest) GetModel() OpenAIModel {
@@ -437,6 +654,20 @@ func (x *OpenAIPromptRequest) GetApplicationId() string {
 	return ""
 }
 
+func (x *OpenAIPromptRequest) GetTools() []*OpenAITool {
+	if x != nil {
+		return x.Tools
+	}
+	return nil
+}
+
+func (x *OpenAIPromptRequest) GetJsonMode() bool {
+	if x != nil && x.JsonMode != nil {
+		return *x.JsonMode
+	}
+	return false
+}
+
 // An OpenAI Prompt Response Message
 type OpenAIPromptResponse struct {
 	state         protoimpl.MessageState
@@ -451,12 +682,14 @@ type OpenAIPromptResponse struct {
 	ResponseTokensCount uint32 `protobuf:"varint,3,opt,name=response_tokens_count,json=responseTokensCount,proto3" json:"response_tokens_count,omitempty"`
 	// Finish reason
 	FinishReason string `protobuf:"bytes,4,opt,name=finish_reason,json=finishReason,proto3" json:"finish_reason,omitempty"`
+	// The tool calls requested by the model
+	ToolCalls []*OpenAIToolCall `protobuf:"bytes,5,rep,name=tool_calls,json=toolCalls,proto3" json:"tool_calls,omitempty"`
 }
 
 func (x *OpenAIPromptResponse) Reset() {
 	*x = OpenAIPromptResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_openai_v1_openai_proto_msgTypes[4]
+		mi := &file_openai_v1_openai_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -469,7 +702,7 @@ func (x *OpenAIPromptResponse) String() string {
 func (*OpenAIPromptResponse) ProtoMessage() {}
 
 func (x *OpenAIPromptResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_openai_v1_openai_proto_msgTypes[4]
+	mi := &file_openai_v1_openai_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -482,7 +715,7 @@ func (x *OpenAIPromptResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OpenAIPromptResponse.ProtoReflect.Descriptor instead.
 func (*OpenAIPromptResponse) Descriptor() ([]byte, []int) {
-	return file_openai_v1_openai_proto_rawDescGZIP(), []int{4}
+	return file_openai_v1_openai_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *OpenAIPromptResponse) GetContent() string {
@@ -513,6 +746,13 @@ func (x *OpenAIPromptResponse) GetFinishReason() string {
 	return ""
 }
 
+func (x *OpenAIPromptResponse) GetToolCalls() []*OpenAIToolCall {
+	if x != nil {
+		return x.ToolCalls
+	}
+	return nil
+}
+
 // An OpenAI Streaming Response Message
 type OpenAIStreamResponse struct {
 	state         protoimpl.MessageState
@@ -527,12 +767,14 @@ type OpenAIStreamResponse struct {
 	RequestTokensCount *uint32 `protobuf:"varint,3,opt,name=request_tokens_count,json=requestTokensCount,proto3,oneof" json:"request_tokens_count,omitempty"`
 	// Count of the response tokens, as returned by the Cohere /tokenize endpoint
 	ResponseTokensCount *uint32 `protobuf:"varint,4,opt,name=response_tokens_count,json=responseTokensCount,proto3,oneof" json:"response_tokens_count,omitempty"`
+	// The tool calls requested by the model, given complete in the last message
+	ToolCalls []*OpenAIToolCall `protobuf:"bytes,5,rep,name=tool_calls,json=toolCalls,proto3" json:"tool_calls,omitempty"`
 }
 
 func (x *OpenAIStreamResponse) Reset() {
 	*x = OpenAIStreamResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_openai_v1_openai_proto_msgTypes[5]
+		mi := &file_openai_v1_openai_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -545,7 +787,7 @@ func (x *OpenAIStreamResponse) String() string {
 func (*OpenAIStreamResponse) ProtoMessage() {}
 
 func (x *OpenAIStreamResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_openai_v1_openai_proto_msgTypes[5]
+	mi := &file_openai_v1_openai_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -558,7 +800,7 @@ func (x *OpenAIStreamResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OpenAIStreamResponse.ProtoReflect.Descriptor instead.
 func (*OpenAIStreamResponse) Descriptor() ([]byte, []int) {
-	return file_openai_v1_openai_proto_rawDescGZIP(), []int{5}
+	return file_openai_v1_openai_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *OpenAIStreamResponse) GetContent() string {
@@ -589,6 +831,196 @@ func (x *OpenAIStreamResponse) GetResponseTokensCount() uint32 {
 	return 0
 }
 
+func (x *OpenAIStreamResponse) GetToolCalls() []*OpenAIToolCall {
+	if x != nil {
+		return x.ToolCalls
+	}
+	return nil
+}
+
+// A Request for OpenAI embedding vectors
+type OpenAIEmbeddingsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// OpenAI Embedding Model identifier
+	Model OpenAIEmbeddingModel `protobuf:"varint,1,opt,name=model,proto3,enum=openai.v1.OpenAIEmbeddingModel" json:"model,omitempty"`
+	// The inputs to embed
+	Inputs []string `protobuf:"bytes,2,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	// The number of dimensions of the embedding vectors, supported by the text-embedding-3 models
+	Dimensions *uint32 `protobuf:"varint,3,opt,name=dimensions,proto3,oneof" json:"dimensions,omitempty"`
+	// Unique application ID to keep track of requests;
+	ApplicationId *string `protobuf:"bytes,4,opt,name=application_id,json=applicationId,proto3,oneof" json:"application_id,omitempty"`
+}
+
+func (x *OpenAIEmbeddingsRequest) Reset() {
+	*x = OpenAIEmbeddingsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_openai_v1_openai_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OpenAIEmbeddingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenAIEmbeddingsRequest) ProtoMessage() {}
+
+func (x *OpenAIEmbeddingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_openai_v1_openai_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenAIEmbeddingsRequest.ProtoReflect.Descriptor instead.
+func (*OpenAIEmbeddingsRequest) Descriptor() ([]byte, []int) {
+	return file_openai_v1_openai_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *OpenAIEmbeddingsRequest) GetModel() OpenAIEmbeddingModel {
+	if x != nil {
+		return x.Model
+	}
+	return OpenAIEmbeddingModel_OPEN_AI_EMBEDDING_MODEL_UNSPECIFIED
+}
+
+func (x *OpenAIEmbeddingsRequest) GetInputs() []string {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *OpenAIEmbeddingsRequest) GetDimensions() uint32 {
+	if x != nil && x.Dimensions != nil {
+		return *x.Dimensions
+	}
+	return 0
+}
+
+func (x *OpenAIEmbeddingsRequest) GetApplicationId() string {
+	if x != nil && x.ApplicationId != nil {
+		return *x.ApplicationId
+	}
+	return ""
+}
+
+// An OpenAI embedding vector
+type OpenAIEmbedding struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The values of the embedding vector
+	Values []float32 `protobuf:"fixed32,1,rep,packed,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *OpenAIEmbedding) Reset() {
+	*x = OpenAIEmbedding{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_openai_v1_openai_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OpenAIEmbedding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenAIEmbedding) ProtoMessage() {}
+
+func (x *OpenAIEmbedding) ProtoReflect() protoreflect.Message {
+	mi := &file_openai_v1_openai_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenAIEmbedding.ProtoReflect.Descriptor instead.
+func (*OpenAIEmbedding) Descriptor() ([]byte, []int) {
+	return file_openai_v1_openai_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *OpenAIEmbedding) GetValues() []float32 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// An OpenAI Embeddings Response Message
+type OpenAIEmbeddingsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The embedding vectors, in the order of the request inputs
+	Embeddings []*OpenAIEmbedding `protobuf:"bytes,1,rep,name=embeddings,proto3" json:"embeddings,omitempty"`
+	// Count of the request tokens, as returned by the OpenAI API
+	RequestTokensCount uint32 `protobuf:"varint,2,opt,name=request_tokens_count,json=requestTokensCount,proto3" json:"request_tokens_count,omitempty"`
+}
+
+func (x *OpenAIEmbeddingsResponse) Reset() {
+	*x = OpenAIEmbeddingsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_openai_v1_openai_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OpenAIEmbeddingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenAIEmbeddingsResponse) ProtoMessage() {}
+
+func (x *OpenAIEmbeddingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_openai_v1_openai_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenAIEmbeddingsResponse.ProtoReflect.Descriptor instead.
+func (*OpenAIEmbeddingsResponse) Descriptor() ([]byte, []int) {
+	return file_openai_v1_openai_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *OpenAIEmbeddingsResponse) GetEmbeddings() []*OpenAIEmbedding {
+	if x != nil {
+		return x.Embeddings
+	}
+	return nil
+}
+
+func (x *OpenAIEmbeddingsResponse) GetRequestTokensCount() uint32 {
+	if x != nil {
+		return x.RequestTokensCount
+	}
+	return 0
+}
+
 var File_openai_v1_openai_proto protoreflect.FileDescriptor
 
 var file_openai_v1_openai_proto_rawDesc = []byte{
@@ -598,131 +1030,212 @@ var file_openai_v1_openai_proto_rawDesc = []byte{
 	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x61, 0x6c, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x72, 0x67,
 	0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x72,
 	0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xe9, 0x01, 0x0a, 0x0d,
-	0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1d, 0x0a,
-	0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
-	0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x12, 0x30, 0x0a, 0x04,
-	0x72, 0x6f, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x6f, 0x70, 0x65,
-	0x6e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x17,
-	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x88, 0x01, 0x01, 0x12, 0x47, 0x0a, 0x0d, 0x66, 0x75, 0x6e, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x61, 0x6c, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d,
-	0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x41,
-	0x49, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x61, 0x6c, 0x6c, 0x48, 0x02, 0x52,
-	0x0c, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x61, 0x6c, 0x6c, 0x88, 0x01, 0x01,
-	0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x42, 0x07, 0x0a, 0x05,
-	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x5f, 0x63, 0x61, 0x6c, 0x6c, 0x22, 0xb2, 0x02, 0x0a, 0x15, 0x4f, 0x70, 0x65, 0x6e,
-	0x41, 0x49, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72,
-	0x73, 0x12, 0x25, 0x0a, 0x0b, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x48, 0x00, 0x52, 0x0b, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72,
-	0x61, 0x74, 0x75, 0x72, 0x65, 0x88, 0x01, 0x01, 0x12, 0x18, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x5f,
-	0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x48, 0x01, 0x52, 0x04, 0x74, 0x6f, 0x70, 0x50, 0x88,
-	0x01, 0x01, 0x12, 0x22, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x02, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x54, 0x6f, 0x6b,
-	0x65, 0x6e, 0x73, 0x88, 0x01, 0x01, 0x12, 0x2e, 0x0a, 0x10, 0x70, 0x72, 0x65, 0x73, 0x65, 0x6e,
-	0x63, 0x65, 0x5f, 0x70, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02,
-	0x48, 0x03, 0x52, 0x0f, 0x70, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x50, 0x65, 0x6e, 0x61,
-	0x6c, 0x74, 0x79, 0x88, 0x01, 0x01, 0x12, 0x30, 0x0a, 0x11, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65,
-	0x6e, 0x63, 0x79, 0x5f, 0x70, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x02, 0x48, 0x04, 0x52, 0x10, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x50, 0x65,
-	0x6e, 0x61, 0x6c, 0x74, 0x79, 0x88, 0x01, 0x01, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x74, 0x65, 0x6d,
-	0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x74, 0x6f, 0x70,
-	0x5f, 0x70, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
-	0x73, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x70, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x70,
-	0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x66, 0x72, 0x65, 0x71, 0x75,
-	0x65, 0x6e, 0x63, 0x79, 0x5f, 0x70, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x22, 0xfa, 0x01, 0x0a,
-	0x13, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e,
-	0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x52, 0x05, 0x6d, 0x6f, 0x64,
-	0x65, 0x6c, 0x12, 0x34, 0x0a, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x69, 0x2e, 0x76, 0x31,
-	0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x08,
-	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x40, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x61,
-	0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6f,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x52, 0x0a, 0x0e, 0x4f,
+	0x70, 0x65, 0x6e, 0x41, 0x49, 0x54, 0x6f, 0x6f, 0x6c, 0x43, 0x61, 0x6c, 0x6c, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22,
+	0x8b, 0x01, 0x0a, 0x0a, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x54, 0x6f, 0x6f, 0x6c, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x25, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x23, 0x0a, 0x0a, 0x70, 0x61, 0x72,
+	0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52,
+	0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x88, 0x01, 0x01, 0x42, 0x0e,
+	0x0a, 0x0c, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x0d,
+	0x0a, 0x0b, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x22, 0xdb, 0x02,
+	0x0a, 0x0d, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x1d, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x00, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x12, 0x30,
+	0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x6f,
 	0x70, 0x65, 0x6e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x4d,
-	0x6f, 0x64, 0x65, 0x6c, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x52, 0x0a,
-	0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x2a, 0x0a, 0x0e, 0x61, 0x70,
-	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x09, 0x48, 0x00, 0x52, 0x0d, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x49, 0x64, 0x88, 0x01, 0x01, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x61, 0x70, 0x70, 0x6c, 0x69,
-	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x22, 0xbb, 0x01, 0x0a, 0x14, 0x4f, 0x70,
-	0x65, 0x6e, 0x41, 0x49, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x30, 0x0a, 0x14,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65,
+	0x12, 0x17, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x88, 0x01, 0x01, 0x12, 0x47, 0x0a, 0x0d, 0x66, 0x75, 0x6e,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x61, 0x6c, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65,
+	0x6e, 0x41, 0x49, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x61, 0x6c, 0x6c, 0x48,
+	0x02, 0x52, 0x0c, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x61, 0x6c, 0x6c, 0x88,
+	0x01, 0x01, 0x12, 0x25, 0x0a, 0x0c, 0x74, 0x6f, 0x6f, 0x6c, 0x5f, 0x63, 0x61, 0x6c, 0x6c, 0x5f,
+	0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x03, 0x52, 0x0a, 0x74, 0x6f, 0x6f, 0x6c,
+	0x43, 0x61, 0x6c, 0x6c, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x38, 0x0a, 0x0a, 0x74, 0x6f, 0x6f,
+	0x6c, 0x5f, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x6f, 0x70, 0x65, 0x6e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49,
+	0x54, 0x6f, 0x6f, 0x6c, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x09, 0x74, 0x6f, 0x6f, 0x6c, 0x43, 0x61,
+	0x6c, 0x6c, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x42,
+	0x07, 0x0a, 0x05, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x66, 0x75, 0x6e,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x61, 0x6c, 0x6c, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x74,
+	0x6f, 0x6f, 0x6c, 0x5f, 0x63, 0x61, 0x6c, 0x6c, 0x5f, 0x69, 0x64, 0x22, 0xb2, 0x02, 0x0a, 0x15,
+	0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x50, 0x61, 0x72, 0x61, 0x6d,
+	0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x25, 0x0a, 0x0b, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x48, 0x00, 0x52, 0x0b, 0x74, 0x65,
+	0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x88, 0x01, 0x01, 0x12, 0x18, 0x0a, 0x05,
+	0x74, 0x6f, 0x70, 0x5f, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x48, 0x01, 0x52, 0x04, 0x74,
+	0x6f, 0x70, 0x50, 0x88, 0x01, 0x01, 0x12, 0x22, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x5f, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x02, 0x52, 0x09, 0x6d, 0x61,
+	0x78, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x88, 0x01, 0x01, 0x12, 0x2e, 0x0a, 0x10, 0x70, 0x72,
+	0x65, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x70, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x02, 0x48, 0x03, 0x52, 0x0f, 0x70, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x63, 0x65,
+	0x50, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x88, 0x01, 0x01, 0x12, 0x30, 0x0a, 0x11, 0x66, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x70, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x02, 0x48, 0x04, 0x52, 0x10, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e,
+	0x63, 0x79, 0x50, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x88, 0x01, 0x01, 0x42, 0x0e, 0x0a, 0x0c,
+	0x5f, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x42, 0x08, 0x0a, 0x06,
+	0x5f, 0x74, 0x6f, 0x70, 0x5f, 0x70, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x70, 0x72, 0x65, 0x73, 0x65, 0x6e,
+	0x63, 0x65, 0x5f, 0x70, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x66,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x70, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79,
+	0x22, 0xd7, 0x02, 0x0a, 0x13, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x50, 0x72, 0x6f, 0x6d, 0x70,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x05, 0x6d, 0x6f, 0x64, 0x65,
+	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x52,
+	0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x34, 0x0a, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x61,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x52, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x40, 0x0a, 0x0a,
+	0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x20, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65,
+	0x6e, 0x41, 0x49, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65,
+	0x72, 0x73, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x2a,
+	0x0a, 0x0e, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0d, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x2b, 0x0a, 0x05, 0x74, 0x6f,
+	0x6f, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6f, 0x70, 0x65, 0x6e,
+	0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x54, 0x6f, 0x6f, 0x6c,
+	0x52, 0x05, 0x74, 0x6f, 0x6f, 0x6c, 0x73, 0x12, 0x20, 0x0a, 0x09, 0x6a, 0x73, 0x6f, 0x6e, 0x5f,
+	0x6d, 0x6f, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x48, 0x01, 0x52, 0x08, 0x6a, 0x73,
+	0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x88, 0x01, 0x01, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x61, 0x70,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x42, 0x0c, 0x0a, 0x0a,
+	0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x22, 0xf5, 0x01, 0x0a, 0x14, 0x4f,
+	0x70, 0x65, 0x6e, 0x41, 0x49, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x30, 0x0a,
+	0x14, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x5f,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x32, 0x0a, 0x15, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x13,
+	0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x5f, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x66, 0x69, 0x6e, 0x69,
+	0x73, 0x68, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x38, 0x0a, 0x0a, 0x74, 0x6f, 0x6f, 0x6c,
+	0x5f, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6f,
+	0x70, 0x65, 0x6e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x54,
+	0x6f, 0x6f, 0x6c, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x09, 0x74, 0x6f, 0x6f, 0x6c, 0x43, 0x61, 0x6c,
+	0x6c, 0x73, 0x22, 0xc9, 0x02, 0x0a, 0x14, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x53, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x0d, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x5f,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0c,
+	0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12,
+	0x35, 0x0a, 0x14, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x01, 0x52,
+	0x12, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x12, 0x37, 0x0a, 0x15, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x02, 0x52, 0x13, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x12,
+	0x38, 0x0a, 0x0a, 0x74, 0x6f, 0x6f, 0x6c, 0x5f, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x54, 0x6f, 0x6f, 0x6c, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x09,
+	0x74, 0x6f, 0x6f, 0x6c, 0x43, 0x61, 0x6c, 0x6c, 0x73, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x66, 0x69,
+	0x6e, 0x69, 0x73, 0x68, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x42, 0x17, 0x0a, 0x15, 0x5f,
 	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x5f, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x72, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x32,
-	0x0a, 0x15, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
-	0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x13, 0x72,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x43, 0x6f, 0x75,
-	0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x5f, 0x72, 0x65, 0x61,
-	0x73, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x66, 0x69, 0x6e, 0x69, 0x73,
-	0x68, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x8f, 0x02, 0x0a, 0x14, 0x4f, 0x70, 0x65, 0x6e,
-	0x41, 0x49, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x0d, 0x66, 0x69,
-	0x6e, 0x69, 0x73, 0x68, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x48, 0x00, 0x52, 0x0c, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x52, 0x65, 0x61, 0x73, 0x6f,
-	0x6e, 0x88, 0x01, 0x01, 0x12, 0x35, 0x0a, 0x14, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f,
-	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0d, 0x48, 0x01, 0x52, 0x12, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x54, 0x6f, 0x6b,
-	0x65, 0x6e, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x12, 0x37, 0x0a, 0x15, 0x72,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x5f, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x02, 0x52, 0x13, 0x72, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x43, 0x6f, 0x75, 0x6e,
-	0x74, 0x88, 0x01, 0x01, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x5f,
-	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x42, 0x17, 0x0a, 0x15, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x42,
-	0x18, 0x0a, 0x16, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x74, 0x6f, 0x6b,
-	0x65, 0x6e, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2a, 0xaa, 0x01, 0x0a, 0x0b, 0x4f, 0x70,
-	0x65, 0x6e, 0x41, 0x49, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x1d, 0x0a, 0x19, 0x4f, 0x50, 0x45,
-	0x4e, 0x5f, 0x41, 0x49, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
-	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x21, 0x0a, 0x1d, 0x4f, 0x50, 0x45, 0x4e,
-	0x5f, 0x41, 0x49, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x5f, 0x47, 0x50, 0x54, 0x33, 0x5f, 0x35,
-	0x5f, 0x54, 0x55, 0x52, 0x42, 0x4f, 0x5f, 0x34, 0x4b, 0x10, 0x01, 0x12, 0x22, 0x0a, 0x1e, 0x4f,
-	0x50, 0x45, 0x4e, 0x5f, 0x41, 0x49, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x5f, 0x47, 0x50, 0x54,
-	0x33, 0x5f, 0x35, 0x5f, 0x54, 0x55, 0x52, 0x42, 0x4f, 0x5f, 0x31, 0x36, 0x4b, 0x10, 0x02, 0x12,
-	0x19, 0x0a, 0x15, 0x4f, 0x50, 0x45, 0x4e, 0x5f, 0x41, 0x49, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x4c,
-	0x5f, 0x47, 0x50, 0x54, 0x34, 0x5f, 0x38, 0x4b, 0x10, 0x03, 0x12, 0x1a, 0x0a, 0x16, 0x4f, 0x50,
-	0x45, 0x4e, 0x5f, 0x41, 0x49, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x5f, 0x47, 0x50, 0x54, 0x34,
-	0x5f, 0x33, 0x32, 0x4b, 0x10, 0x04, 0x2a, 0xc0, 0x01, 0x0a, 0x11, 0x4f, 0x70, 0x65, 0x6e, 0x41,
-	0x49, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x24, 0x0a, 0x20,
-	0x4f, 0x50, 0x45, 0x4e, 0x5f, 0x41, 0x49, 0x5f, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f,
-	0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
-	0x10, 0x00, 0x12, 0x1f, 0x0a, 0x1b, 0x4f, 0x50, 0x45, 0x4e, 0x5f, 0x41, 0x49, 0x5f, 0x4d, 0x45,
-	0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x53, 0x59, 0x53, 0x54, 0x45,
-	0x4d, 0x10, 0x01, 0x12, 0x1d, 0x0a, 0x19, 0x4f, 0x50, 0x45, 0x4e, 0x5f, 0x41, 0x49, 0x5f, 0x4d,
-	0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x55, 0x53, 0x45, 0x52,
-	0x10, 0x02, 0x12, 0x22, 0x0a, 0x1e, 0x4f, 0x50, 0x45, 0x4e, 0x5f, 0x41, 0x49, 0x5f, 0x4d, 0x45,
-	0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x41, 0x53, 0x53, 0x49, 0x53,
-	0x54, 0x41, 0x4e, 0x54, 0x10, 0x03, 0x12, 0x21, 0x0a, 0x1d, 0x4f, 0x50, 0x45, 0x4e, 0x5f, 0x41,
-	0x49, 0x5f, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x46,
-	0x55, 0x4e, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x04, 0x32, 0xb7, 0x01, 0x0a, 0x0d, 0x4f, 0x70,
-	0x65, 0x6e, 0x41, 0x49, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x51, 0x0a, 0x0c, 0x4f,
-	0x70, 0x65, 0x6e, 0x41, 0x49, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x12, 0x1e, 0x2e, 0x6f, 0x70,
-	0x65, 0x6e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x50, 0x72,
-	0x6f, 0x6d, 0x70, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x6f, 0x70,
-	0x65, 0x6e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x50, 0x72,
-	0x6f, 0x6d, 0x70, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x53,
-	0x0a, 0x0c, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x1e,
-	0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x41,
-	0x49, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f,
-	0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x41,
-	0x49, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x30, 0x01, 0x42, 0x98, 0x01, 0x0a, 0x0d, 0x63, 0x6f, 0x6d, 0x2e, 0x6f, 0x70, 0x65, 0x6e,
-	0x61, 0x69, 0x2e, 0x76, 0x31, 0x42, 0x0b, 0x4f, 0x70, 0x65, 0x6e, 0x61, 0x69, 0x50, 0x72, 0x6f,
-	0x74, 0x6f, 0x48, 0x03, 0x50, 0x01, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2f, 0x62, 0x61, 0x73, 0x65, 0x6d, 0x69, 0x6e, 0x64, 0x2d, 0x61, 0x69, 0x2f, 0x6d,
-	0x6f, 0x6e, 0x6f, 0x72, 0x65, 0x70, 0x6f, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x6f, 0x70, 0x65, 0x6e,
-	0x61, 0x69, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0xa2, 0x02, 0x03, 0x4f, 0x58,
-	0x58, 0xaa, 0x02, 0x09, 0x4f, 0x70, 0x65, 0x6e, 0x61, 0x69, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x09,
-	0x4f, 0x70, 0x65, 0x6e, 0x61, 0x69, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x15, 0x4f, 0x70, 0x65, 0x6e,
-	0x61, 0x69, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0xea, 0x02, 0x0a, 0x4f, 0x70, 0x65, 0x6e, 0x61, 0x69, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6f, 0x75, 0x6e, 0x74, 0x42, 0x18, 0x0a, 0x16, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xdb,
+	0x01, 0x0a, 0x17, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x45, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x69,
+	0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x35, 0x0a, 0x05, 0x6d, 0x6f,
+	0x64, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x6f, 0x70, 0x65, 0x6e,
+	0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x45, 0x6d, 0x62, 0x65,
+	0x64, 0x64, 0x69, 0x6e, 0x67, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65,
+	0x6c, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x12, 0x23, 0x0a, 0x0a, 0x64, 0x69, 0x6d,
+	0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52,
+	0x0a, 0x64, 0x69, 0x6d, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x88, 0x01, 0x01, 0x12, 0x2a,
+	0x0a, 0x0e, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x0d, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x88, 0x01, 0x01, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x64,
+	0x69, 0x6d, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x61, 0x70,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x22, 0x29, 0x0a, 0x0f,
+	0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x45, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x12,
+	0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x02, 0x52,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x88, 0x01, 0x0a, 0x18, 0x4f, 0x70, 0x65, 0x6e,
+	0x41, 0x49, 0x45, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x0a, 0x65, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x69, 0x6e,
+	0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x61,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x45, 0x6d, 0x62, 0x65, 0x64,
+	0x64, 0x69, 0x6e, 0x67, 0x52, 0x0a, 0x65, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x73,
+	0x12, 0x30, 0x0a, 0x14, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x2a, 0xaa, 0x01, 0x0a, 0x0b, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x4d, 0x6f, 0x64,
+	0x65, 0x6c, 0x12, 0x1d, 0x0a, 0x19, 0x4f, 0x50, 0x45, 0x4e, 0x5f, 0x41, 0x49, 0x5f, 0x4d, 0x4f,
+	0x44, 0x45, 0x4c, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x21, 0x0a, 0x1d, 0x4f, 0x50, 0x45, 0x4e, 0x5f, 0x41, 0x49, 0x5f, 0x4d, 0x4f, 0x44,
+	0x45, 0x4c, 0x5f, 0x47, 0x50, 0x54, 0x33, 0x5f, 0x35, 0x5f, 0x54, 0x55, 0x52, 0x42, 0x4f, 0x5f,
+	0x34, 0x4b, 0x10, 0x01, 0x12, 0x22, 0x0a, 0x1e, 0x4f, 0x50, 0x45, 0x4e, 0x5f, 0x41, 0x49, 0x5f,
+	0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x5f, 0x47, 0x50, 0x54, 0x33, 0x5f, 0x35, 0x5f, 0x54, 0x55, 0x52,
+	0x42, 0x4f, 0x5f, 0x31, 0x36, 0x4b, 0x10, 0x02, 0x12, 0x19, 0x0a, 0x15, 0x4f, 0x50, 0x45, 0x4e,
+	0x5f, 0x41, 0x49, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x5f, 0x47, 0x50, 0x54, 0x34, 0x5f, 0x38,
+	0x4b, 0x10, 0x03, 0x12, 0x1a, 0x0a, 0x16, 0x4f, 0x50, 0x45, 0x4e, 0x5f, 0x41, 0x49, 0x5f, 0x4d,
+	0x4f, 0x44, 0x45, 0x4c, 0x5f, 0x47, 0x50, 0x54, 0x34, 0x5f, 0x33, 0x32, 0x4b, 0x10, 0x04, 0x2a,
+	0xdb, 0x01, 0x0a, 0x14, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x45, 0x6d, 0x62, 0x65, 0x64, 0x64,
+	0x69, 0x6e, 0x67, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x27, 0x0a, 0x23, 0x4f, 0x50, 0x45, 0x4e,
+	0x5f, 0x41, 0x49, 0x5f, 0x45, 0x4d, 0x42, 0x45, 0x44, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x4d, 0x4f,
+	0x44, 0x45, 0x4c, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x32, 0x0a, 0x2e, 0x4f, 0x50, 0x45, 0x4e, 0x5f, 0x41, 0x49, 0x5f, 0x45, 0x4d, 0x42,
+	0x45, 0x44, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x5f, 0x54, 0x45, 0x58,
+	0x54, 0x5f, 0x45, 0x4d, 0x42, 0x45, 0x44, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x33, 0x5f, 0x53, 0x4d,
+	0x41, 0x4c, 0x4c, 0x10, 0x01, 0x12, 0x32, 0x0a, 0x2e, 0x4f, 0x50, 0x45, 0x4e, 0x5f, 0x41, 0x49,
+	0x5f, 0x45, 0x4d, 0x42, 0x45, 0x44, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x4c,
+	0x5f, 0x54, 0x45, 0x58, 0x54, 0x5f, 0x45, 0x4d, 0x42, 0x45, 0x44, 0x44, 0x49, 0x4e, 0x47, 0x5f,
+	0x33, 0x5f, 0x4c, 0x41, 0x52, 0x47, 0x45, 0x10, 0x02, 0x12, 0x32, 0x0a, 0x2e, 0x4f, 0x50, 0x45,
+	0x4e, 0x5f, 0x41, 0x49, 0x5f, 0x45, 0x4d, 0x42, 0x45, 0x44, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x4d,
+	0x4f, 0x44, 0x45, 0x4c, 0x5f, 0x54, 0x45, 0x58, 0x54, 0x5f, 0x45, 0x4d, 0x42, 0x45, 0x44, 0x44,
+	0x49, 0x4e, 0x47, 0x5f, 0x41, 0x44, 0x41, 0x5f, 0x30, 0x30, 0x32, 0x10, 0x03, 0x2a, 0xdf, 0x01,
+	0x0a, 0x11, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52,
+	0x6f, 0x6c, 0x65, 0x12, 0x24, 0x0a, 0x20, 0x4f, 0x50, 0x45, 0x4e, 0x5f, 0x41, 0x49, 0x5f, 0x4d,
+	0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1f, 0x0a, 0x1b, 0x4f, 0x50, 0x45,
+	0x4e, 0x5f, 0x41, 0x49, 0x5f, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x52, 0x4f, 0x4c,
+	0x45, 0x5f, 0x53, 0x59, 0x53, 0x54, 0x45, 0x4d, 0x10, 0x01, 0x12, 0x1d, 0x0a, 0x19, 0x4f, 0x50,
+	0x45, 0x4e, 0x5f, 0x41, 0x49, 0x5f, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x52, 0x4f,
+	0x4c, 0x45, 0x5f, 0x55, 0x53, 0x45, 0x52, 0x10, 0x02, 0x12, 0x22, 0x0a, 0x1e, 0x4f, 0x50, 0x45,
+	0x4e, 0x5f, 0x41, 0x49, 0x5f, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x52, 0x4f, 0x4c,
+	0x45, 0x5f, 0x41, 0x53, 0x53, 0x49, 0x53, 0x54, 0x41, 0x4e, 0x54, 0x10, 0x03, 0x12, 0x21, 0x0a,
+	0x1d, 0x4f, 0x50, 0x45, 0x4e, 0x5f, 0x41, 0x49, 0x5f, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45,
+	0x5f, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x46, 0x55, 0x4e, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x04,
+	0x12, 0x1d, 0x0a, 0x19, 0x4f, 0x50, 0x45, 0x4e, 0x5f, 0x41, 0x49, 0x5f, 0x4d, 0x45, 0x53, 0x53,
+	0x41, 0x47, 0x45, 0x5f, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x54, 0x4f, 0x4f, 0x4c, 0x10, 0x05, 0x32,
+	0x96, 0x02, 0x0a, 0x0d, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x51, 0x0a, 0x0c, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x50, 0x72, 0x6f, 0x6d, 0x70,
+	0x74, 0x12, 0x1e, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70,
+	0x65, 0x6e, 0x41, 0x49, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1f, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70,
+	0x65, 0x6e, 0x41, 0x49, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x53, 0x0a, 0x0c, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x53, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x12, 0x1e, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x5d, 0x0a, 0x10, 0x4f, 0x70, 0x65,
+	0x6e, 0x41, 0x49, 0x45, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x22, 0x2e,
+	0x6f, 0x70, 0x65, 0x6e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49,
+	0x45, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x23, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70,
+	0x65, 0x6e, 0x41, 0x49, 0x45, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x98, 0x01, 0x0a, 0x0d, 0x63, 0x6f, 0x6d,
+	0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x42, 0x0b, 0x4f, 0x70, 0x65, 0x6e,
+	0x61, 0x69, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x48, 0x03, 0x50, 0x01, 0x5a, 0x33, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x61, 0x73, 0x65, 0x6d, 0x69, 0x6e, 0x64,
+	0x2d, 0x61, 0x69, 0x2f, 0x6d, 0x6f, 0x6e, 0x6f, 0x72, 0x65, 0x70, 0x6f, 0x2f, 0x67, 0x65, 0x6e,
+	0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x69, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0xa2, 0x02, 0x03, 0x4f, 0x58, 0x58, 0xaa, 0x02, 0x09, 0x4f, 0x70, 0x65, 0x6e, 0x61, 0x69, 0x2e,
+	0x56, 0x31, 0xca, 0x02, 0x09, 0x4f, 0x70, 0x65, 0x6e, 0x61, 0x69, 0x5c, 0x56, 0x31, 0xe2, 0x02,
+	0x15, 0x4f, 0x70, 0x65, 0x6e, 0x61, 0x69, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x0a, 0x4f, 0x70, 0x65, 0x6e, 0x61, 0x69, 0x3a,
+	0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -737,33 +1250,47 @@ func file_openai_v1_openai_proto_rawDescGZIP() []byte {
 	return file_openai_v1_openai_proto_rawDescData
 }
 
-var file_openai_v1_openai_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_openai_v1_openai_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_openai_v1_openai_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_openai_v1_openai_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_openai_v1_openai_proto_goTypes = []interface{}{
-	(OpenAIModel)(0),              // 0: openai.v1.OpenAIModel
-	(OpenAIMessageRole)(0),        // 1: openai.v1.OpenAIMessageRole
-	(*OpenAIFunctionCall)(nil),    // 2: openai.v1.OpenAIFunctionCall
-	(*OpenAIMessage)(nil),         // 3: openai.v1.OpenAIMessage
-	(*OpenAIModelParameters)(nil), // 4: openai.v1.OpenAIModelParameters
-	(*OpenAIPromptRequest)(nil),   // 5: openai.v1.OpenAIPromptRequest
-	(*OpenAIPromptResponse)(nil),  // 6: openai.v1.OpenAIPromptResponse
-	(*OpenAIStreamResponse)(nil),  // 7: openai.v1.OpenAIStreamResponse
+	(OpenAIModel)(0),                 // 0: openai.v1.OpenAIModel
+	(OpenAIEmbeddingModel)(0),        // 1: openai.v1.OpenAIEmbeddingModel
+	(OpenAIMessageRole)(0),           // 2: openai.v1.OpenAIMessageRole
+	(*OpenAIFunctionCall)(nil),       // 3: openai.v1.OpenAIFunctionCall
+	(*OpenAIToolCall)(nil),           // 4: openai.v1.OpenAIToolCall
+	(*OpenAITool)(nil),               // 5: openai.v1.OpenAITool
+	(*OpenAIMessage)(nil),            // 6: openai.v1.OpenAIMessage
+	(*OpenAIModelParameters)(nil),    // 7: openai.v1.OpenAIModelParameters
+	(*OpenAIPromptRequest)(nil),      // 8: openai.v1.OpenAIPromptRequest
+	(*OpenAIPromptResponse)(nil),     // 9: openai.v1.OpenAIPromptResponse
+	(*OpenAIStreamResponse)(nil),     // 10: openai.v1.OpenAIStreamResponse
+	(*OpenAIEmbeddingsRequest)(nil),  // 11: openai.v1.OpenAIEmbeddingsRequest
+	(*OpenAIEmbedding)(nil),          // 12: openai.v1.OpenAIEmbedding
+	(*OpenAIEmbeddingsResponse)(nil), // 13: openai.v1.OpenAIEmbeddingsResponse
 }
 var file_openai_v1_openai_proto_depIdxs = []int32{
-	1, // 0: openai.v1.OpenAIMessage.role:type_name -> openai.v1.OpenAIMessageRole
-	2, // 1: openai.v1.OpenAIMessage.function_call:type_name -> openai.v1.OpenAIFunctionCall
-	0, // 2: openai.v1.OpenAIPromptRequest.model:type_name -> openai.v1.OpenAIModel
-	3, // 3: openai.v1.OpenAIPromptRequest.messages:type_name -> openai.v1.OpenAIMessage
-	4, // 4: openai.v1.OpenAIPromptRequest.parameters:type_name -> openai.v1.OpenAIModelParameters
-	5, // 5: openai.v1.OpenAIService.OpenAIPrompt:input_type -> openai.v1.OpenAIPromptRequest
-	5, // 6: openai.v1.OpenAIService.OpenAIStream:input_type -> openai.v1.OpenAIPromptRequest
-	6, // 7: openai.v1.OpenAIService.OpenAIPrompt:output_type -> openai.v1.OpenAIPromptResponse
-	7, // 8: openai.v1.OpenAIService.OpenAIStream:output_type -> openai.v1.OpenAIStreamResponse
-	7, // [7:9] is the sub-list for method output_type
-	5, // [5:7] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	2,  // 0: openai.v1.OpenAIMessage.role:type_name -> openai.v1.OpenAIMessageRole
+	3,  // 1: openai.v1.OpenAIMessage.function_call:type_name -> openai.v1.OpenAIFunctionCall
+	4,  // 2: openai.v1.OpenAIMessage.tool_calls:type_name -> openai.v1.OpenAIToolCall
+	0,  // 3: openai.v1.OpenAIPromptRequest.model:type_name -> openai.v1.OpenAIModel
+	6,  // 4: openai.v1.OpenAIPromptRequest.messages:type_name -> openai.v1.OpenAIMessage
+	7,  // 5: openai.v1.OpenAIPromptRequest.parameters:type_name -> openai.v1.OpenAIModelParameters
+	5,  // 6: openai.v1.OpenAIPromptRequest.tools:type_name -> openai.v1.OpenAITool
+	4,  // 7: openai.v1.OpenAIPromptResponse.tool_calls:type_name -> openai.v1.OpenAIToolCall
+	4,  // 8: openai.v1.OpenAIStreamResponse.tool_calls:type_name -> openai.v1.OpenAIToolCall
+	1,  // 9: openai.v1.OpenAIEmbeddingsRequest.model:type_name -> openai.v1.OpenAIEmbeddingModel
+	12, // 10: openai.v1.OpenAIEmbeddingsResponse.embeddings:type_name -> openai.v1.OpenAIEmbedding
+	8,  // 11: openai.v1.OpenAIService.OpenAIPrompt:input_type -> openai.v1.OpenAIPromptRequest
+	8,  // 12: openai.v1.OpenAIService.OpenAIStream:input_type -> openai.v1.OpenAIPromptRequest
+	11, // 13: openai.v1.OpenAIService.OpenAIEmbeddings:input_type -> openai.v1.OpenAIEmbeddingsRequest
+	9,  // 14: openai.v1.OpenAIService.OpenAIPrompt:output_type -> openai.v1.OpenAIPromptResponse
+	10, // 15: openai.v1.OpenAIService.OpenAIStream:output_type -> openai.v1.OpenAIStreamResponse
+	13, // 16: openai.v1.OpenAIService.OpenAIEmbeddings:output_type -> openai.v1.OpenAIEmbeddingsResponse
+	14, // [14:17] is the sub-list for method output_type
+	11, // [11:14] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_openai_v1_openai_proto_init() }
@@ -785,7 +1312,7 @@ func file_openai_v1_openai_proto_init() {
 			}
 		}
 		file_openai_v1_openai_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OpenAIMessage); i {
+			switch v := v.(*OpenAIToolCall); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -797,7 +1324,7 @@ func file_openai_v1_openai_proto_init() {
 			}
 		}
 		file_openai_v1_openai_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OpenAIModelParameters); i {
+			switch v := v.(*OpenAITool); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -809,7 +1336,7 @@ func file_openai_v1_openai_proto_init() {
 			}
 		}
 		file_openai_v1_openai_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OpenAIPromptRequest); i {
+			switch v := v.(*OpenAIMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -821,7 +1348,7 @@ func file_openai_v1_openai_proto_init() {
 			}
 		}
 		file_openai_v1_openai_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OpenAIPromptResponse); i {
+			switch v := v.(*OpenAIModelParameters); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -833,6 +1360,30 @@ func file_openai_v1_openai_proto_init() {
 			}
 		}
 		file_openai_v1_openai_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OpenAIPromptRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_openai_v1_openai_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OpenAIPromptResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_openai_v1_openai_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*OpenAIStreamResponse); i {
 			case 0:
 				return &v.state
@@ -844,18 +1395,56 @@ func file_openai_v1_openai_proto_init() {
 				return nil
 			}
 		}
+		file_openai_v1_openai_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OpenAIEmbeddingsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_openai_v1_openai_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OpenAIEmbedding); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_openai_v1_openai_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OpenAIEmbeddingsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
-	file_openai_v1_openai_proto_msgTypes[1].OneofWrappers = []interface{}{}
 	file_openai_v1_openai_proto_msgTypes[2].OneofWrappers = []interface{}{}
 	file_openai_v1_openai_proto_msgTypes[3].OneofWrappers = []interface{}{}
+	file_openai_v1_openai_proto_msgTypes[4].OneofWrappers = []interface{}{}
 	file_openai_v1_openai_proto_msgTypes[5].OneofWrappers = []interface{}{}
+	file_openai_v1_openai_proto_msgTypes[7].OneofWrappers = []interface{}{}
+	file_openai_v1_openai_proto_msgTypes[8].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_openai_v1_openai_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   6,
+			NumEnums:      3,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
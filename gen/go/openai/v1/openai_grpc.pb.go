@@ -19,8 +19,9 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	OpenAIService_OpenAIPrompt_FullMethodName = "/openai.v1.OpenAIService/OpenAIPrompt"
-	OpenAIService_OpenAIStream_FullMethodName = "/openai.v1.OpenAIService/OpenAIStream"
+	OpenAIService_OpenAIPrompt_FullMethodName     = "/openai.v1.OpenAIService/OpenAIPrompt"
+	OpenAIService_OpenAIStream_FullMethodName     = "/openai.v1.OpenAIService/OpenAIStream"
+	OpenAIService_OpenAIEmbeddings_FullMethodName = "/openai.v1.OpenAIService/OpenAIEmbeddings"
 )
 
 // OpenAIServiceClient is the client API for OpenAIService service.
@@ -31,6 +32,8 @@ type OpenAIServiceClient interface {
 	OpenAIPrompt(ctx context.Context, in *OpenAIPromptRequest, opts ...grpc.CallOption) (*OpenAIPromptResponse, error)
 	// Request a streaming LLM prompt
 	OpenAIStream(ctx context.Context, in *OpenAIPromptRequest, opts ...grpc.CallOption) (OpenAIService_OpenAIStreamClient, error)
+	// Request embedding vectors for a batch of inputs
+	OpenAIEmbeddings(ctx context.Context, in *OpenAIEmbeddingsRequest, opts ...grpc.CallOption) (*OpenAIEmbeddingsResponse, error)
 }
 
 type openAIServiceClient struct {
@@ -82,6 +85,15 @@ func (x *openAIServiceOpenAIStreamClient) Recv() (*OpenAIStreamResponse, error)
 	return m, nil
 }
 
+func (c *openAIServiceClient) OpenAIEmbeddings(ctx context.Context, in *OpenAIEmbeddingsRequest, opts ...grpc.CallOption) (*OpenAIEmbeddingsResponse, error) {
+	out := new(OpenAIEmbeddingsResponse)
+	err := c.cc.Invoke(ctx, OpenAIService_OpenAIEmbeddings_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OpenAIServiceServer is the server API for OpenAIService service.
 // All implementations must embed UnimplementedOpenAIServiceServer
 // for forward compatibility
@@ -90,6 +102,8 @@ type OpenAIServiceServer interface {
 	OpenAIPrompt(context.Context, *OpenAIPromptRequest) (*OpenAIPromptResponse, error)
 	// Request a streaming LLM prompt
 	OpenAIStream(*OpenAIPromptRequest, OpenAIService_OpenAIStreamServer) error
+	// Request embedding vectors for a batch of inputs
+	OpenAIEmbeddings(context.Context, *OpenAIEmbeddingsRequest) (*OpenAIEmbeddingsResponse, error)
 	mustEmbedUnimplementedOpenAIServiceServer()
 }
 
@@ -103,6 +117,9 @@ func (UnimplementedOpenAIServiceServer) OpenAIPrompt(context.Context, *OpenAIPro
 func (UnimplementedOpenAIServiceServer) OpenAIStream(*OpenAIPromptRequest, OpenAIService_OpenAIStreamServer) error {
 	return status.Errorf(codes.Unimplemented, "method OpenAIStream not implemented")
 }
+func (UnimplementedOpenAIServiceServer) OpenAIEmbeddings(context.Context, *OpenAIEmbeddingsRequest) (*OpenAIEmbeddingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OpenAIEmbeddings not implemented")
+}
 func (UnimplementedOpenAIServiceServer) mustEmbedUnimplementedOpenAIServiceServer() {}
 
 // UnsafeOpenAIServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -155,6 +172,24 @@ func (x *openAIServiceOpenAIStreamServer) Send(m *OpenAIStreamResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _OpenAIService_OpenAIEmbeddings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenAIEmbeddingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenAIServiceServer).OpenAIEmbeddings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OpenAIService_OpenAIEmbeddings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenAIServiceServer).OpenAIEmbeddings(ctx, req.(*OpenAIEmbeddingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OpenAIService_ServiceDesc is the grpc.ServiceDesc for OpenAIService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -166,6 +201,10 @@ var OpenAIService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "OpenAIPrompt",
 			Handler:    _OpenAIService_OpenAIPrompt_Handler,
 		},
+		{
+			MethodName: "OpenAIEmbeddings",
+			Handler:    _OpenAIService_OpenAIEmbeddings_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
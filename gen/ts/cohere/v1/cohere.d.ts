@@ -2,6 +2,25 @@
 // @generated from protobuf file "cohere/v1/cohere.proto" (package "cohere.v1", syntax proto3)
 // tslint:disable
 import { MessageType } from "@protobuf-ts/runtime";
+/**
+ * A Cohere chat history message
+ *
+ * @generated from protobuf message cohere.v1.CohereChatMessage
+ */
+export interface CohereChatMessage {
+    /**
+     * The role of the message author
+     *
+     * @generated from protobuf field: cohere.v1.CohereChatRole role = 1;
+     */
+    role: CohereChatRole;
+    /**
+     * The content of the message
+     *
+     * @generated from protobuf field: string message = 2;
+     */
+    message: string;
+}
 /**
  * Cohere API Request parameters
  *
@@ -82,6 +101,12 @@ export interface CoherePromptRequest {
      * @generated from protobuf field: cohere.v1.CohereModelParameters parameters = 3;
      */
     parameters?: CohereModelParameters;
+    /**
+     * The conversation history, following the prompt message
+     *
+     * @generated from protobuf field: repeated cohere.v1.CohereChatMessage chat_history = 4;
+     */
+    chatHistory: CohereChatMessage[];
 }
 /**
  * The CoherePromptResponse contains the data that is returned from the Cohere API.
@@ -145,6 +170,63 @@ export interface CohereStreamResponse {
      */
     responseTokensCount?: number;
 }
+/**
+ * The CohereEmbeddingsRequest contains the data that will be sent to the Cohere embed API.
+ *
+ * @generated from protobuf message cohere.v1.CohereEmbeddingsRequest
+ */
+export interface CohereEmbeddingsRequest {
+    /**
+     * Cohere Embedding Model identifier
+     *
+     * @generated from protobuf field: cohere.v1.CohereEmbeddingModel model = 1;
+     */
+    model: CohereEmbeddingModel;
+    /**
+     * The inputs to embed
+     *
+     * @generated from protobuf field: repeated string inputs = 2;
+     */
+    inputs: string[];
+    /**
+     * The type of the inputs, required by the v3 embedding models
+     *
+     * @generated from protobuf field: cohere.v1.CohereEmbeddingInputType input_type = 3;
+     */
+    inputType: CohereEmbeddingInputType;
+}
+/**
+ * A Cohere embedding vector
+ *
+ * @generated from protobuf message cohere.v1.CohereEmbedding
+ */
+export interface CohereEmbedding {
+    /**
+     * The values of the embedding vector
+     *
+     * @generated from protobuf field: repeated float values = 1;
+     */
+    values: number[];
+}
+/**
+ * The CohereEmbeddingsResponse contains the data that is returned from the Cohere embed API.
+ *
+ * @generated from protobuf message cohere.v1.CohereEmbeddingsResponse
+ */
+export interface CohereEmbeddingsResponse {
+    /**
+     * The embedding vectors, in the order of the request inputs
+     *
+     * @generated from protobuf field: repeated cohere.v1.CohereEmbedding embeddings = 1;
+     */
+    embeddings: CohereEmbedding[];
+    /**
+     * Count of the request tokens, as returned by the Cohere API
+     *
+     * @generated from protobuf field: uint32 request_tokens_count = 2;
+     */
+    requestTokensCount: number;
+}
 /**
  * Type of Cohere Model
  *
@@ -182,6 +264,68 @@ export declare enum CohereModel {
      */
     COMMAND_LIGHT_NIGHTLY = 4
 }
+/**
+ * Type of Cohere Embedding Model
+ *
+ * @generated from protobuf enum cohere.v1.CohereEmbeddingModel
+ */
+export declare enum CohereEmbeddingModel {
+    /**
+     * Cohere Embedding Model is not specified
+     *
+     * @generated from protobuf enum value: COHERE_EMBEDDING_MODEL_UNSPECIFIED = 0;
+     */
+    UNSPECIFIED = 0,
+    /**
+     * Embed English v3 - the English embedding model.
+     *
+     * @generated from protobuf enum value: COHERE_EMBEDDING_MODEL_EMBED_ENGLISH_V3 = 1;
+     */
+    EMBED_ENGLISH_V3 = 1,
+    /**
+     * Embed Multilingual v3 - the multilingual embedding model.
+     *
+     * @generated from protobuf enum value: COHERE_EMBEDDING_MODEL_EMBED_MULTILINGUAL_V3 = 2;
+     */
+    EMBED_MULTILINGUAL_V3 = 2
+}
+/**
+ * Type of the inputs of a Cohere embeddings request
+ *
+ * @generated from protobuf enum cohere.v1.CohereEmbeddingInputType
+ */
+export declare enum CohereEmbeddingInputType {
+    /**
+     * Cohere Embedding Input Type is not specified
+     *
+     * @generated from protobuf enum value: COHERE_EMBEDDING_INPUT_TYPE_UNSPECIFIED = 0;
+     */
+    UNSPECIFIED = 0,
+    /**
+     * Documents stored in a vector database for search.
+     *
+     * @generated from protobuf enum value: COHERE_EMBEDDING_INPUT_TYPE_SEARCH_DOCUMENT = 1;
+     */
+    SEARCH_DOCUMENT = 1,
+    /**
+     * Search queries run against a vector database.
+     *
+     * @generated from protobuf enum value: COHERE_EMBEDDING_INPUT_TYPE_SEARCH_QUERY = 2;
+     */
+    SEARCH_QUERY = 2,
+    /**
+     * Texts passed to a text classifier.
+     *
+     * @generated from protobuf enum value: COHERE_EMBEDDING_INPUT_TYPE_CLASSIFICATION = 3;
+     */
+    CLASSIFICATION = 3,
+    /**
+     * Texts passed to a clustering algorithm.
+     *
+     * @generated from protobuf enum value: COHERE_EMBEDDING_INPUT_TYPE_CLUSTERING = 4;
+     */
+    CLUSTERING = 4
+}
 /**
  * Type of Cohere RAG Connector
  *
@@ -207,6 +351,44 @@ export declare enum CohereConnectorType {
      */
     ID = 2
 }
+/**
+ * Role of a Cohere chat history message author
+ *
+ * @generated from protobuf enum cohere.v1.CohereChatRole
+ */
+export declare enum CohereChatRole {
+    /**
+     * Cohere chat role is not specified
+     *
+     * @generated from protobuf enum value: COHERE_CHAT_ROLE_UNSPECIFIED = 0;
+     */
+    UNSPECIFIED = 0,
+    /**
+     * System message
+     *
+     * @generated from protobuf enum value: COHERE_CHAT_ROLE_SYSTEM = 1;
+     */
+    SYSTEM = 1,
+    /**
+     * User message
+     *
+     * @generated from protobuf enum value: COHERE_CHAT_ROLE_USER = 2;
+     */
+    USER = 2,
+    /**
+     * Chatbot message, i.e. a previous model response
+     *
+     * @generated from protobuf enum value: COHERE_CHAT_ROLE_CHATBOT = 3;
+     */
+    CHATBOT = 3
+}
+declare class CohereChatMessage$Type extends MessageType<CohereChatMessage> {
+    constructor();
+}
+/**
+ * @generated MessageType for protobuf message cohere.v1.CohereChatMessage
+ */
+export declare const CohereChatMessage: CohereChatMessage$Type;
 declare class CohereModelParameters$Type extends MessageType<CohereModelParameters> {
     constructor();
 }
@@ -235,6 +417,27 @@ declare class CohereStreamResponse$Type extends MessageType<CohereStreamResponse
  * @generated MessageType for protobuf message cohere.v1.CohereStreamResponse
  */
 export declare const CohereStreamResponse: CohereStreamResponse$Type;
+declare class CohereEmbeddingsRequest$Type extends MessageType<CohereEmbeddingsRequest> {
+    constructor();
+}
+/**
+ * @generated MessageType for protobuf message cohere.v1.CohereEmbeddingsRequest
+ */
+export declare const CohereEmbeddingsRequest: CohereEmbeddingsRequest$Type;
+declare class CohereEmbedding$Type extends MessageType<CohereEmbedding> {
+    constructor();
+}
+/**
+ * @generated MessageType for protobuf message cohere.v1.CohereEmbedding
+ */
+export declare const CohereEmbedding: CohereEmbedding$Type;
+declare class CohereEmbeddingsResponse$Type extends MessageType<CohereEmbeddingsResponse> {
+    constructor();
+}
+/**
+ * @generated MessageType for protobuf message cohere.v1.CohereEmbeddingsResponse
+ */
+export declare const CohereEmbeddingsResponse: CohereEmbeddingsResponse$Type;
 /**
  * @generated ServiceType for protobuf service cohere.v1.CohereService
  */
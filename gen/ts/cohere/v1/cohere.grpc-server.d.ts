@@ -1,6 +1,8 @@
 // @generated by protobuf-ts 2.9.4 with parameter generate_dependencies,long_type_string,output_javascript_es2020,server_grpc1,force_client_none
 // @generated from protobuf file "cohere/v1/cohere.proto" (package "cohere.v1", syntax proto3)
 // tslint:disable
+import { CohereEmbeddingsResponse } from "./cohere";
+import { CohereEmbeddingsRequest } from "./cohere";
 import { CohereStreamResponse } from "./cohere";
 import { CoherePromptResponse } from "./cohere";
 import { CoherePromptRequest } from "./cohere";
@@ -23,6 +25,12 @@ export interface ICohereService extends grpc.UntypedServiceImplementation {
      * @generated from protobuf rpc: CohereStream(cohere.v1.CoherePromptRequest) returns (stream cohere.v1.CohereStreamResponse);
      */
     cohereStream: grpc.handleServerStreamingCall<CoherePromptRequest, CohereStreamResponse>;
+    /**
+     * Request embedding vectors for a batch of inputs
+     *
+     * @generated from protobuf rpc: CohereEmbeddings(cohere.v1.CohereEmbeddingsRequest) returns (cohere.v1.CohereEmbeddingsResponse);
+     */
+    cohereEmbeddings: grpc.handleUnaryCall<CohereEmbeddingsRequest, CohereEmbeddingsResponse>;
 }
 /**
  * @grpc/grpc-js definition for the protobuf service cohere.v1.CohereService.
@@ -4,6 +4,8 @@
 // @generated by protobuf-ts 2.9.4 with parameter generate_dependencies,long_type_string,output_javascript_es2020,server_grpc1,force_client_none
 // @generated from protobuf file "cohere/v1/cohere.proto" (package "cohere.v1", syntax proto3)
 // tslint:disable
+import { CohereEmbeddingsResponse } from "./cohere";
+import { CohereEmbeddingsRequest } from "./cohere";
 import { CohereStreamResponse } from "./cohere";
 import { CoherePromptResponse } from "./cohere";
 import { CoherePromptRequest } from "./cohere";
@@ -38,5 +40,15 @@ export const cohereServiceDefinition = {
         requestDeserialize: bytes => CoherePromptRequest.fromBinary(bytes),
         responseSerialize: value => Buffer.from(CohereStreamResponse.toBinary(value)),
         requestSerialize: value => Buffer.from(CoherePromptRequest.toBinary(value))
+    },
+    cohereEmbeddings: {
+        path: "/cohere.v1.CohereService/CohereEmbeddings",
+        originalName: "CohereEmbeddings",
+        requestStream: false,
+        responseStream: false,
+        responseDeserialize: bytes => CohereEmbeddingsResponse.fromBinary(bytes),
+        requestDeserialize: bytes => CohereEmbeddingsRequest.fromBinary(bytes),
+        responseSerialize: value => Buffer.from(CohereEmbeddingsResponse.toBinary(value)),
+        requestSerialize: value => Buffer.from(CohereEmbeddingsRequest.toBinary(value))
     }
 };
@@ -44,6 +44,70 @@ export var CohereModel;
      */
     CohereModel[CohereModel["COMMAND_LIGHT_NIGHTLY"] = 4] = "COMMAND_LIGHT_NIGHTLY";
 })(CohereModel || (CohereModel = {}));
+/**
+ * Type of Cohere Embedding Model
+ *
+ * @generated from protobuf enum cohere.v1.CohereEmbeddingModel
+ */
+export var CohereEmbeddingModel;
+(function (CohereEmbeddingModel) {
+    /**
+     * Cohere Embedding Model is not specified
+     *
+     * @generated from protobuf enum value: COHERE_EMBEDDING_MODEL_UNSPECIFIED = 0;
+     */
+    CohereEmbeddingModel[CohereEmbeddingModel["UNSPECIFIED"] = 0] = "UNSPECIFIED";
+    /**
+     * Embed English v3 - the English embedding model.
+     *
+     * @generated from protobuf enum value: COHERE_EMBEDDING_MODEL_EMBED_ENGLISH_V3 = 1;
+     */
+    CohereEmbeddingModel[CohereEmbeddingModel["EMBED_ENGLISH_V3"] = 1] = "EMBED_ENGLISH_V3";
+    /**
+     * Embed Multilingual v3 - the multilingual embedding model.
+     *
+     * @generated from protobuf enum value: COHERE_EMBEDDING_MODEL_EMBED_MULTILINGUAL_V3 = 2;
+     */
+    CohereEmbeddingModel[CohereEmbeddingModel["EMBED_MULTILINGUAL_V3"] = 2] = "EMBED_MULTILINGUAL_V3";
+})(CohereEmbeddingModel || (CohereEmbeddingModel = {}));
+/**
+ * Type of the inputs of a Cohere embeddings request
+ *
+ * @generated from protobuf enum cohere.v1.CohereEmbeddingInputType
+ */
+export var CohereEmbeddingInputType;
+(function (CohereEmbeddingInputType) {
+    /**
+     * Cohere Embedding Input Type is not specified
+     *
+     * @generated from protobuf enum value: COHERE_EMBEDDING_INPUT_TYPE_UNSPECIFIED = 0;
+     */
+    CohereEmbeddingInputType[CohereEmbeddingInputType["UNSPECIFIED"] = 0] = "UNSPECIFIED";
+    /**
+     * Documents stored in a vector database for search.
+     *
+     * @generated from protobuf enum value: COHERE_EMBEDDING_INPUT_TYPE_SEARCH_DOCUMENT = 1;
+     */
+    CohereEmbeddingInputType[CohereEmbeddingInputType["SEARCH_DOCUMENT"] = 1] = "SEARCH_DOCUMENT";
+    /**
+     * Search queries run against a vector database.
+     *
+     * @generated from protobuf enum value: COHERE_EMBEDDING_INPUT_TYPE_SEARCH_QUERY = 2;
+     */
+    CohereEmbeddingInputType[CohereEmbeddingInputType["SEARCH_QUERY"] = 2] = "SEARCH_QUERY";
+    /**
+     * Texts passed to a text classifier.
+     *
+     * @generated from protobuf enum value: COHERE_EMBEDDING_INPUT_TYPE_CLASSIFICATION = 3;
+     */
+    CohereEmbeddingInputType[CohereEmbeddingInputType["CLASSIFICATION"] = 3] = "CLASSIFICATION";
+    /**
+     * Texts passed to a clustering algorithm.
+     *
+     * @generated from protobuf enum value: COHERE_EMBEDDING_INPUT_TYPE_CLUSTERING = 4;
+     */
+    CohereEmbeddingInputType[CohereEmbeddingInputType["CLUSTERING"] = 4] = "CLUSTERING";
+})(CohereEmbeddingInputType || (CohereEmbeddingInputType = {}));
 /**
  * Type of Cohere RAG Connector
  *
@@ -70,6 +134,51 @@ export var CohereConnectorType;
      */
     CohereConnectorType[CohereConnectorType["ID"] = 2] = "ID";
 })(CohereConnectorType || (CohereConnectorType = {}));
+/**
+ * Role of a Cohere chat history message author
+ *
+ * @generated from protobuf enum cohere.v1.CohereChatRole
+ */
+export var CohereChatRole;
+(function (CohereChatRole) {
+    /**
+     * Cohere chat role is not specified
+     *
+     * @generated from protobuf enum value: COHERE_CHAT_ROLE_UNSPECIFIED = 0;
+     */
+    CohereChatRole[CohereChatRole["UNSPECIFIED"] = 0] = "UNSPECIFIED";
+    /**
+     * System message
+     *
+     * @generated from protobuf enum value: COHERE_CHAT_ROLE_SYSTEM = 1;
+     */
+    CohereChatRole[CohereChatRole["SYSTEM"] = 1] = "SYSTEM";
+    /**
+     * User message
+     *
+     * @generated from protobuf enum value: COHERE_CHAT_ROLE_USER = 2;
+     */
+    CohereChatRole[CohereChatRole["USER"] = 2] = "USER";
+    /**
+     * Chatbot message, i.e. a previous model response
+     *
+     * @generated from protobuf enum value: COHERE_CHAT_ROLE_CHATBOT = 3;
+     */
+    CohereChatRole[CohereChatRole["CHATBOT"] = 3] = "CHATBOT";
+})(CohereChatRole || (CohereChatRole = {}));
+// @generated message type with reflection information, may provide speed optimized methods
+class CohereChatMessage$Type extends MessageType {
+    constructor() {
+        super("cohere.v1.CohereChatMessage", [
+            { no: 1, name: "role", kind: "enum", T: () => ["cohere.v1.CohereChatRole", CohereChatRole, "COHERE_CHAT_ROLE_"] },
+            { no: 2, name: "message", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+}
+/**
+ * @generated MessageType for protobuf message cohere.v1.CohereChatMessage
+ */
+export const CohereChatMessage = new CohereChatMessage$Type();
 // @generated message type with reflection information, may provide speed optimized methods
 class CohereModelParameters$Type extends MessageType {
     constructor() {
@@ -93,7 +202,8 @@ class CoherePromptRequest$Type extends MessageType {
         super("cohere.v1.CoherePromptRequest", [
             { no: 1, name: "model", kind: "enum", T: () => ["cohere.v1.CohereModel", CohereModel, "COHERE_MODEL_"] },
             { no: 2, name: "message", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 3, name: "parameters", kind: "message", T: () => CohereModelParameters }
+            { no: 3, name: "parameters", kind: "message", T: () => CohereModelParameters },
+            { no: 4, name: "chat_history", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => CohereChatMessage }
         ]);
     }
 }
@@ -131,10 +241,50 @@ class CohereStreamResponse$Type extends MessageType {
  * @generated MessageType for protobuf message cohere.v1.CohereStreamResponse
  */
 export const CohereStreamResponse = new CohereStreamResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class CohereEmbeddingsRequest$Type extends MessageType {
+    constructor() {
+        super("cohere.v1.CohereEmbeddingsRequest", [
+            { no: 1, name: "model", kind: "enum", T: () => ["cohere.v1.CohereEmbeddingModel", CohereEmbeddingModel] },
+            { no: 2, name: "inputs", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "input_type", kind: "enum", T: () => ["cohere.v1.CohereEmbeddingInputType", CohereEmbeddingInputType] }
+        ]);
+    }
+}
+/**
+ * @generated MessageType for protobuf message cohere.v1.CohereEmbeddingsRequest
+ */
+export const CohereEmbeddingsRequest = new CohereEmbeddingsRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class CohereEmbedding$Type extends MessageType {
+    constructor() {
+        super("cohere.v1.CohereEmbedding", [
+            { no: 1, name: "values", kind: "scalar", repeat: 1 /*RepeatType.PACKED*/, T: 2 /*ScalarType.FLOAT*/ }
+        ]);
+    }
+}
+/**
+ * @generated MessageType for protobuf message cohere.v1.CohereEmbedding
+ */
+export const CohereEmbedding = new CohereEmbedding$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class CohereEmbeddingsResponse$Type extends MessageType {
+    constructor() {
+        super("cohere.v1.CohereEmbeddingsResponse", [
+            { no: 1, name: "embeddings", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => CohereEmbedding },
+            { no: 2, name: "request_tokens_count", kind: "scalar", T: 13 /*ScalarType.UINT32*/ }
+        ]);
+    }
+}
+/**
+ * @generated MessageType for protobuf message cohere.v1.CohereEmbeddingsResponse
+ */
+export const CohereEmbeddingsResponse = new CohereEmbeddingsResponse$Type();
 /**
  * @generated ServiceType for protobuf service cohere.v1.CohereService
  */
 export const CohereService = new ServiceType("cohere.v1.CohereService", [
     { name: "CoherePrompt", options: {}, I: CoherePromptRequest, O: CoherePromptResponse },
-    { name: "CohereStream", serverStreaming: true, options: {}, I: CoherePromptRequest, O: CohereStreamResponse }
+    { name: "CohereStream", serverStreaming: true, options: {}, I: CoherePromptRequest, O: CohereStreamResponse },
+    { name: "CohereEmbeddings", options: {}, I: CohereEmbeddingsRequest, O: CohereEmbeddingsResponse }
 ]);
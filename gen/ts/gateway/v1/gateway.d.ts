@@ -2,6 +2,62 @@
 // @generated from protobuf file "gateway/v1/gateway.proto" (package "gateway.v1", syntax proto3)
 // tslint:disable
 import { MessageType } from "@protobuf-ts/runtime";
+/**
+ * A tool call requested by the model
+ *
+ * @generated from protobuf message gateway.v1.ToolCall
+ */
+export interface ToolCall {
+    /**
+     * The ID of the tool call, which must be set on the tool message holding its result
+     *
+     * @generated from protobuf field: string id = 1;
+     */
+    id: string;
+    /**
+     * The name of the tool to call
+     *
+     * @generated from protobuf field: string name = 2;
+     */
+    name: string;
+    /**
+     * The JSON encoded arguments to call the tool with
+     *
+     * @generated from protobuf field: string arguments = 3;
+     */
+    arguments: string;
+}
+/**
+ * A message in a multi-turn conversation
+ *
+ * @generated from protobuf message gateway.v1.ConversationMessage
+ */
+export interface ConversationMessage {
+    /**
+     * The role of the message author
+     *
+     * @generated from protobuf field: gateway.v1.ConversationRole role = 1;
+     */
+    role: ConversationRole;
+    /**
+     * The content of the message
+     *
+     * @generated from protobuf field: string content = 2;
+     */
+    content: string;
+    /**
+     * The ID of the tool call this message is the result of, required for tool messages
+     *
+     * @generated from protobuf field: optional string tool_call_id = 3;
+     */
+    toolCallId?: string;
+    /**
+     * The tool calls requested by the model, for assistant messages
+     *
+     * @generated from protobuf field: repeated gateway.v1.ToolCall tool_calls = 4;
+     */
+    toolCalls: ToolCall[];
+}
 /**
  * A request for a prompt - sending user input to the server.
  *
@@ -23,6 +79,26 @@ export interface PromptRequest {
      * @generated from protobuf field: optional string prompt_config_id = 2;
      */
     promptConfigId?: string;
+    /**
+     * Optional conversation history, appended after the prompt config messages
+     *
+     * @generated from protobuf field: repeated gateway.v1.ConversationMessage conversation_history = 3;
+     */
+    conversationHistory: ConversationMessage[];
+    /**
+     * Optional conversation identifier. If set, the gateway stores the conversation messages and model responses,
+     * and replays the stored history before the conversation_history messages of subsequent requests.
+     *
+     * @generated from protobuf field: optional string conversation_id = 4;
+     */
+    conversationId?: string;
+    /**
+     * Optional key identifying the end user of the request. When the application splits traffic between prompt configs,
+     * requests with the same user key are always routed to the same prompt config.
+     *
+     * @generated from protobuf field: optional string user_key = 5;
+     */
+    userKey?: string;
 }
 /**
  * A Prompt Response Message
@@ -54,6 +130,30 @@ export interface PromptResponse {
      * @generated from protobuf field: uint32 request_duration = 4;
      */
     requestDuration: number;
+    /**
+     * The vendor of the model that served the request, which differs from the prompt config vendor when a fallback model was used
+     *
+     * @generated from protobuf field: string model_vendor = 5;
+     */
+    modelVendor: string;
+    /**
+     * The model that served the request, which differs from the prompt config model when a fallback model was used
+     *
+     * @generated from protobuf field: string model_type = 6;
+     */
+    modelType: string;
+    /**
+     * Whether the response was served from the prompt config response cache
+     *
+     * @generated from protobuf field: bool is_cache_hit = 7;
+     */
+    isCacheHit: boolean;
+    /**
+     * The tool calls requested by the model
+     *
+     * @generated from protobuf field: repeated gateway.v1.ToolCall tool_calls = 8;
+     */
+    toolCalls: ToolCall[];
 }
 /**
  * An Streaming Prompt Response Message
@@ -91,7 +191,374 @@ export interface StreamingPromptResponse {
      * @generated from protobuf field: optional uint32 stream_duration = 5;
      */
     streamDuration?: number;
+    /**
+     * The vendor of the model that served the request, given when the stream ends
+     *
+     * @generated from protobuf field: optional string model_vendor = 6;
+     */
+    modelVendor?: string;
+    /**
+     * The model that served the request, given when the stream ends
+     *
+     * @generated from protobuf field: optional string model_type = 7;
+     */
+    modelType?: string;
+    /**
+     * The tool calls requested by the model, given when the stream ends
+     *
+     * @generated from protobuf field: repeated gateway.v1.ToolCall tool_calls = 8;
+     */
+    toolCalls: ToolCall[];
+}
+/**
+ * A request for embedding vectors - sending a batch of inputs to the server.
+ *
+ * @generated from protobuf message gateway.v1.EmbeddingsRequest
+ */
+export interface EmbeddingsRequest {
+    /**
+     * The inputs to embed, an embedding vector is returned for every input
+     *
+     * @generated from protobuf field: repeated string inputs = 1;
+     */
+    inputs: string[];
+    /**
+     * The vendor of the embedding model, e.g. OPEN_AI or COHERE
+     *
+     * @generated from protobuf field: string model_vendor = 2;
+     */
+    modelVendor: string;
+    /**
+     * The embedding model, e.g. text-embedding-3-small or embed-english-v3.0
+     *
+     * @generated from protobuf field: string model_type = 3;
+     */
+    modelType: string;
+    /**
+     * Optional number of dimensions of the embedding vectors, supported by the OpenAI text-embedding-3 models
+     *
+     * @generated from protobuf field: optional uint32 dimensions = 4;
+     */
+    dimensions?: number;
+    /**
+     * Optional type of the inputs, used by the Cohere embedding models:
+     * search_document (the default), search_query, classification or clustering
+     *
+     * @generated from protobuf field: optional string input_type = 5;
+     */
+    inputType?: string;
+}
+/**
+ * An embedding vector
+ *
+ * @generated from protobuf message gateway.v1.Embedding
+ */
+export interface Embedding {
+    /**
+     * The values of the embedding vector
+     *
+     * @generated from protobuf field: repeated float values = 1;
+     */
+    values: number[];
+}
+/**
+ * An Embeddings Response Message
+ *
+ * @generated from protobuf message gateway.v1.EmbeddingsResponse
+ */
+export interface EmbeddingsResponse {
+    /**
+     * The embedding vectors, in the order of the request inputs
+     *
+     * @generated from protobuf field: repeated gateway.v1.Embedding embeddings = 1;
+     */
+    embeddings: Embedding[];
+    /**
+     * Number of tokens used for the embeddings request
+     *
+     * @generated from protobuf field: uint32 request_tokens = 2;
+     */
+    requestTokens: number;
+    /**
+     * Request duration
+     *
+     * @generated from protobuf field: uint32 request_duration = 3;
+     */
+    requestDuration: number;
+    /**
+     * The vendor of the model that served the request
+     *
+     * @generated from protobuf field: string model_vendor = 4;
+     */
+    modelVendor: string;
+    /**
+     * The model that served the request
+     *
+     * @generated from protobuf field: string model_type = 5;
+     */
+    modelType: string;
+}
+/**
+ * The template variables of a single batch job item
+ *
+ * @generated from protobuf message gateway.v1.BatchJobItem
+ */
+export interface BatchJobItem {
+    /**
+     * The template variables of the item
+     *
+     * @generated from protobuf field: map<string, string> template_variables = 1;
+     */
+    templateVariables: {
+        [key: string]: string;
+    };
 }
+/**
+ * A request to submit a batch job
+ *
+ * @generated from protobuf message gateway.v1.BatchJobRequest
+ */
+export interface BatchJobRequest {
+    /**
+     * The items of the batch job, the prompt config is executed once for every item
+     *
+     * @generated from protobuf field: repeated gateway.v1.BatchJobItem items = 1;
+     */
+    items: BatchJobItem[];
+    /**
+     * Optional ID of the prompt config to execute, defaults to the application default prompt config
+     *
+     * @generated from protobuf field: optional string prompt_config_id = 2;
+     */
+    promptConfigId?: string;
+    /**
+     * Optional URL that receives a signed POST request when the job finishes
+     *
+     * @generated from protobuf field: optional string callback_url = 3;
+     */
+    callbackUrl?: string;
+}
+/**
+ * The response of a submitted batch job
+ *
+ * @generated from protobuf message gateway.v1.BatchJobCreatedResponse
+ */
+export interface BatchJobCreatedResponse {
+    /**
+     * The ID of the batch job
+     *
+     * @generated from protobuf field: string job_id = 1;
+     */
+    jobId: string;
+    /**
+     * The secret used to sign the callback request, only returned when a callback URL is given.
+     * The X-BaseMind-Signature header of the callback is formatted as t=<unix timestamp>,v1=<signature>,
+     * where the signature is the hex encoded HMAC-SHA256 of "<unix timestamp>.<request body>"
+     *
+     * @generated from protobuf field: optional string callback_secret = 2;
+     */
+    callbackSecret?: string;
+}
+/**
+ * A request for the status of a batch job
+ *
+ * @generated from protobuf message gateway.v1.BatchJobStatusRequest
+ */
+export interface BatchJobStatusRequest {
+    /**
+     * The ID of the batch job
+     *
+     * @generated from protobuf field: string job_id = 1;
+     */
+    jobId: string;
+}
+/**
+ * The status and progress of a batch job
+ *
+ * @generated from protobuf message gateway.v1.BatchJobStatusResponse
+ */
+export interface BatchJobStatusResponse {
+    /**
+     * The ID of the batch job
+     *
+     * @generated from protobuf field: string job_id = 1;
+     */
+    jobId: string;
+    /**
+     * The job status: PENDING, RUNNING, COMPLETED or FAILED - a job fails if none of its items completed
+     *
+     * @generated from protobuf field: string status = 2;
+     */
+    status: string;
+    /**
+     * Number of items in the job
+     *
+     * @generated from protobuf field: uint32 total_items = 3;
+     */
+    totalItems: number;
+    /**
+     * Number of items that completed successfully
+     *
+     * @generated from protobuf field: uint32 completed_items = 4;
+     */
+    completedItems: number;
+    /**
+     * Number of items that failed after exhausting their retries
+     *
+     * @generated from protobuf field: uint32 failed_items = 5;
+     */
+    failedItems: number;
+    /**
+     * Job creation time as a unix timestamp in milliseconds
+     *
+     * @generated from protobuf field: uint64 created_at = 6;
+     */
+    createdAt: string;
+    /**
+     * Job finish time as a unix timestamp in milliseconds, given when the job finished
+     *
+     * @generated from protobuf field: optional uint64 finished_at = 7;
+     */
+    finishedAt?: string;
+}
+/**
+ * A request for a page of the item results of a batch job
+ *
+ * @generated from protobuf message gateway.v1.BatchJobResultsRequest
+ */
+export interface BatchJobResultsRequest {
+    /**
+     * The ID of the batch job
+     *
+     * @generated from protobuf field: string job_id = 1;
+     */
+    jobId: string;
+    /**
+     * The number of results to skip
+     *
+     * @generated from protobuf field: uint32 offset = 2;
+     */
+    offset: number;
+    /**
+     * The maximal number of results to return, defaults to 100
+     *
+     * @generated from protobuf field: optional uint32 limit = 3;
+     */
+    limit?: number;
+}
+/**
+ * The result of a single batch job item
+ *
+ * @generated from protobuf message gateway.v1.BatchJobItemResult
+ */
+export interface BatchJobItemResult {
+    /**
+     * The index of the item in the batch job request
+     *
+     * @generated from protobuf field: uint32 index = 1;
+     */
+    index: number;
+    /**
+     * The item status: PENDING, RUNNING, COMPLETED or FAILED
+     *
+     * @generated from protobuf field: string status = 2;
+     */
+    status: string;
+    /**
+     * The prompt response content, given when the item completed
+     *
+     * @generated from protobuf field: optional string content = 3;
+     */
+    content?: string;
+    /**
+     * The error of the last attempt, given when the item failed
+     *
+     * @generated from protobuf field: optional string error = 4;
+     */
+    error?: string;
+    /**
+     * Number of attempts made for the item
+     *
+     * @generated from protobuf field: uint32 attempts = 5;
+     */
+    attempts: number;
+    /**
+     * Number of tokens used for the request
+     *
+     * @generated from protobuf field: uint32 request_tokens = 6;
+     */
+    requestTokens: number;
+    /**
+     * Number of tokens used for the response
+     *
+     * @generated from protobuf field: uint32 response_tokens = 7;
+     */
+    responseTokens: number;
+}
+/**
+ * A page of the item results of a batch job
+ *
+ * @generated from protobuf message gateway.v1.BatchJobResultsResponse
+ */
+export interface BatchJobResultsResponse {
+    /**
+     * The item results, ordered by the item index
+     *
+     * @generated from protobuf field: repeated gateway.v1.BatchJobItemResult results = 1;
+     */
+    results: BatchJobItemResult[];
+}
+/**
+ * Role of a conversation message author
+ *
+ * @generated from protobuf enum gateway.v1.ConversationRole
+ */
+export declare enum ConversationRole {
+    /**
+     * Conversation role is not specified
+     *
+     * @generated from protobuf enum value: CONVERSATION_ROLE_UNSPECIFIED = 0;
+     */
+    UNSPECIFIED = 0,
+    /**
+     * System message
+     *
+     * @generated from protobuf enum value: CONVERSATION_ROLE_SYSTEM = 1;
+     */
+    SYSTEM = 1,
+    /**
+     * User message
+     *
+     * @generated from protobuf enum value: CONVERSATION_ROLE_USER = 2;
+     */
+    USER = 2,
+    /**
+     * Assistant message, i.e. a previous model response
+     *
+     * @generated from protobuf enum value: CONVERSATION_ROLE_ASSISTANT = 3;
+     */
+    ASSISTANT = 3,
+    /**
+     * Tool message, holding the result of a tool call
+     *
+     * @generated from protobuf enum value: CONVERSATION_ROLE_TOOL = 4;
+     */
+    TOOL = 4
+}
+declare class ToolCall$Type extends MessageType<ToolCall> {
+    constructor();
+}
+/**
+ * @generated MessageType for protobuf message gateway.v1.ToolCall
+ */
+export declare const ToolCall: ToolCall$Type;
+declare class ConversationMessage$Type extends MessageType<ConversationMessage> {
+    constructor();
+}
+/**
+ * @generated MessageType for protobuf message gateway.v1.ConversationMessage
+ */
+export declare const ConversationMessage: ConversationMessage$Type;
 declare class PromptRequest$Type extends MessageType<PromptRequest> {
     constructor();
 }
@@ -113,6 +580,83 @@ declare class StreamingPromptResponse$Type extends MessageType<StreamingPromptRe
  * @generated MessageType for protobuf message gateway.v1.StreamingPromptResponse
  */
 export declare const StreamingPromptResponse: StreamingPromptResponse$Type;
+declare class EmbeddingsRequest$Type extends MessageType<EmbeddingsRequest> {
+    constructor();
+}
+/**
+ * @generated MessageType for protobuf message gateway.v1.EmbeddingsRequest
+ */
+export declare const EmbeddingsRequest: EmbeddingsRequest$Type;
+declare class Embedding$Type extends MessageType<Embedding> {
+    constructor();
+}
+/**
+ * @generated MessageType for protobuf message gateway.v1.Embedding
+ */
+export declare const Embedding: Embedding$Type;
+declare class EmbeddingsResponse$Type extends MessageType<EmbeddingsResponse> {
+    constructor();
+}
+/**
+ * @generated MessageType for protobuf message gateway.v1.EmbeddingsResponse
+ */
+export declare const EmbeddingsResponse: EmbeddingsResponse$Type;
+declare class BatchJobItem$Type extends MessageType<BatchJobItem> {
+    constructor();
+}
+/**
+ * @generated MessageType for protobuf message gateway.v1.BatchJobItem
+ */
+export declare const BatchJobItem: BatchJobItem$Type;
+declare class BatchJobRequest$Type extends MessageType<BatchJobRequest> {
+    constructor();
+}
+/**
+ * @generated MessageType for protobuf message gateway.v1.BatchJobRequest
+ */
+export declare const BatchJobRequest: BatchJobRequest$Type;
+declare class BatchJobCreatedResponse$Type extends MessageType<BatchJobCreatedResponse> {
+    constructor();
+}
+/**
+ * @generated MessageType for protobuf message gateway.v1.BatchJobCreatedResponse
+ */
+export declare const BatchJobCreatedResponse: BatchJobCreatedResponse$Type;
+declare class BatchJobStatusRequest$Type extends MessageType<BatchJobStatusRequest> {
+    constructor();
+}
+/**
+ * @generated MessageType for protobuf message gateway.v1.BatchJobStatusRequest
+ */
+export declare const BatchJobStatusRequest: BatchJobStatusRequest$Type;
+declare class BatchJobStatusResponse$Type extends MessageType<BatchJobStatusResponse> {
+    constructor();
+}
+/**
+ * @generated MessageType for protobuf message gateway.v1.BatchJobStatusResponse
+ */
+export declare const BatchJobStatusResponse: BatchJobStatusResponse$Type;
+declare class BatchJobResultsRequest$Type extends MessageType<BatchJobResultsRequest> {
+    constructor();
+}
+/**
+ * @generated MessageType for protobuf message gateway.v1.BatchJobResultsRequest
+ */
+export declare const BatchJobResultsRequest: BatchJobResultsRequest$Type;
+declare class BatchJobItemResult$Type extends MessageType<BatchJobItemResult> {
+    constructor();
+}
+/**
+ * @generated MessageType for protobuf message gateway.v1.BatchJobItemResult
+ */
+export declare const BatchJobItemResult: BatchJobItemResult$Type;
+declare class BatchJobResultsResponse$Type extends MessageType<BatchJobResultsResponse> {
+    constructor();
+}
+/**
+ * @generated MessageType for protobuf message gateway.v1.BatchJobResultsResponse
+ */
+export declare const BatchJobResultsResponse: BatchJobResultsResponse$Type;
 /**
  * @generated ServiceType for protobuf service gateway.v1.APIGatewayService
  */
@@ -1,6 +1,14 @@
 // @generated by protobuf-ts 2.9.4 with parameter generate_dependencies,long_type_string,output_javascript_es2020,server_grpc1,force_client_none
 // @generated from protobuf file "gateway/v1/gateway.proto" (package "gateway.v1", syntax proto3)
 // tslint:disable
+import { BatchJobResultsResponse } from "./gateway";
+import { BatchJobResultsRequest } from "./gateway";
+import { BatchJobStatusResponse } from "./gateway";
+import { BatchJobStatusRequest } from "./gateway";
+import { BatchJobCreatedResponse } from "./gateway";
+import { BatchJobRequest } from "./gateway";
+import { EmbeddingsResponse } from "./gateway";
+import { EmbeddingsRequest } from "./gateway";
 import { StreamingPromptResponse } from "./gateway";
 import { PromptResponse } from "./gateway";
 import { PromptRequest } from "./gateway";
@@ -23,6 +31,30 @@ export interface IAPIGatewayService extends grpc.UntypedServiceImplementation {
      * @generated from protobuf rpc: RequestStreamingPrompt(gateway.v1.PromptRequest) returns (stream gateway.v1.StreamingPromptResponse);
      */
     requestStreamingPrompt: grpc.handleServerStreamingCall<PromptRequest, StreamingPromptResponse>;
+    /**
+     * Request embedding vectors for a batch of inputs
+     *
+     * @generated from protobuf rpc: RequestEmbeddings(gateway.v1.EmbeddingsRequest) returns (gateway.v1.EmbeddingsResponse);
+     */
+    requestEmbeddings: grpc.handleUnaryCall<EmbeddingsRequest, EmbeddingsResponse>;
+    /**
+     * Submit a batch job, executing a prompt config for each set of template variables in the background
+     *
+     * @generated from protobuf rpc: CreateBatchJob(gateway.v1.BatchJobRequest) returns (gateway.v1.BatchJobCreatedResponse);
+     */
+    createBatchJob: grpc.handleUnaryCall<BatchJobRequest, BatchJobCreatedResponse>;
+    /**
+     * Retrieve the status and progress of a batch job
+     *
+     * @generated from protobuf rpc: RetrieveBatchJob(gateway.v1.BatchJobStatusRequest) returns (gateway.v1.BatchJobStatusResponse);
+     */
+    retrieveBatchJob: grpc.handleUnaryCall<BatchJobStatusRequest, BatchJobStatusResponse>;
+    /**
+     * Retrieve a page of the item results of a batch job, ordered by the item index
+     *
+     * @generated from protobuf rpc: RetrieveBatchJobResults(gateway.v1.BatchJobResultsRequest) returns (gateway.v1.BatchJobResultsResponse);
+     */
+    retrieveBatchJobResults: grpc.handleUnaryCall<BatchJobResultsRequest, BatchJobResultsResponse>;
 }
 /**
  * @grpc/grpc-js definition for the protobuf service gateway.v1.APIGatewayService.
@@ -4,6 +4,14 @@
 // @generated by protobuf-ts 2.9.4 with parameter generate_dependencies,long_type_string,output_javascript_es2020,server_grpc1,force_client_none
 // @generated from protobuf file "gateway/v1/gateway.proto" (package "gateway.v1", syntax proto3)
 // tslint:disable
+import { BatchJobResultsResponse } from "./gateway";
+import { BatchJobResultsRequest } from "./gateway";
+import { BatchJobStatusResponse } from "./gateway";
+import { BatchJobStatusRequest } from "./gateway";
+import { BatchJobCreatedResponse } from "./gateway";
+import { BatchJobRequest } from "./gateway";
+import { EmbeddingsResponse } from "./gateway";
+import { EmbeddingsRequest } from "./gateway";
 import { StreamingPromptResponse } from "./gateway";
 import { PromptResponse } from "./gateway";
 import { PromptRequest } from "./gateway";
@@ -38,5 +46,45 @@ export const aPIGatewayServiceDefinition = {
         requestDeserialize: bytes => PromptRequest.fromBinary(bytes),
         responseSerialize: value => Buffer.from(StreamingPromptResponse.toBinary(value)),
         requestSerialize: value => Buffer.from(PromptRequest.toBinary(value))
+    },
+    requestEmbeddings: {
+        path: "/gateway.v1.APIGatewayService/RequestEmbeddings",
+        originalName: "RequestEmbeddings",
+        requestStream: false,
+        responseStream: false,
+        responseDeserialize: bytes => EmbeddingsResponse.fromBinary(bytes),
+        requestDeserialize: bytes => EmbeddingsRequest.fromBinary(bytes),
+        responseSerialize: value => Buffer.from(EmbeddingsResponse.toBinary(value)),
+        requestSerialize: value => Buffer.from(EmbeddingsRequest.toBinary(value))
+    },
+    createBatchJob: {
+        path: "/gateway.v1.APIGatewayService/CreateBatchJob",
+        originalName: "CreateBatchJob",
+        requestStream: false,
+        responseStream: false,
+        responseDeserialize: bytes => BatchJobCreatedResponse.fromBinary(bytes),
+        requestDeserialize: bytes => BatchJobRequest.fromBinary(bytes),
+        responseSerialize: value => Buffer.from(BatchJobCreatedResponse.toBinary(value)),
+        requestSerialize: value => Buffer.from(BatchJobRequest.toBinary(value))
+    }
+    retrieveBatchJob: {
+        path: "/gateway.v1.APIGatewayService/RetrieveBatchJob",
+        originalName: "RetrieveBatchJob",
+        requestStream: false,
+        responseStream: false,
+        responseDeserialize: bytes => BatchJobStatusResponse.fromBinary(bytes),
+        requestDeserialize: bytes => BatchJobStatusRequest.fromBinary(bytes),
+        responseSerialize: value => Buffer.from(BatchJobStatusResponse.toBinary(value)),
+        requestSerialize: value => Buffer.from(BatchJobStatusRequest.toBinary(value))
+    }
+    retrieveBatchJobResults: {
+        path: "/gateway.v1.APIGatewayService/RetrieveBatchJobResults",
+        originalName: "RetrieveBatchJobResults",
+        requestStream: false,
+        responseStream: false,
+        responseDeserialize: bytes => BatchJobResultsResponse.fromBinary(bytes),
+        requestDeserialize: bytes => BatchJobResultsRequest.fromBinary(bytes),
+        responseSerialize: value => Buffer.from(BatchJobResultsResponse.toBinary(value)),
+        requestSerialize: value => Buffer.from(BatchJobResultsRequest.toBinary(value))
     }
 };
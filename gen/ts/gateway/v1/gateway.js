@@ -6,12 +6,82 @@
 // tslint:disable
 import { ServiceType } from "@protobuf-ts/runtime-rpc";
 import { MessageType } from "@protobuf-ts/runtime";
+/**
+ * Role of a conversation message author
+ *
+ * @generated from protobuf enum gateway.v1.ConversationRole
+ */
+export var ConversationRole;
+(function (ConversationRole) {
+    /**
+     * Conversation role is not specified
+     *
+     * @generated from protobuf enum value: CONVERSATION_ROLE_UNSPECIFIED = 0;
+     */
+    ConversationRole[ConversationRole["UNSPECIFIED"] = 0] = "UNSPECIFIED";
+    /**
+     * System message
+     *
+     * @generated from protobuf enum value: CONVERSATION_ROLE_SYSTEM = 1;
+     */
+    ConversationRole[ConversationRole["SYSTEM"] = 1] = "SYSTEM";
+    /**
+     * User message
+     *
+     * @generated from protobuf enum value: CONVERSATION_ROLE_USER = 2;
+     */
+    ConversationRole[ConversationRole["USER"] = 2] = "USER";
+    /**
+     * Assistant message, i.e. a previous model response
+     *
+     * @generated from protobuf enum value: CONVERSATION_ROLE_ASSISTANT = 3;
+     */
+    ConversationRole[ConversationRole["ASSISTANT"] = 3] = "ASSISTANT";
+    /**
+     * Tool message, holding the result of a tool call
+     *
+     * @generated from protobuf enum value: CONVERSATION_ROLE_TOOL = 4;
+     */
+    ConversationRole[ConversationRole["TOOL"] = 4] = "TOOL";
+})(ConversationRole || (ConversationRole = {}));
+// @generated message type with reflection information, may provide speed optimized methods
+class ToolCall$Type extends MessageType {
+    constructor() {
+        super("gateway.v1.ToolCall", [
+            { no: 1, name: "id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "arguments", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+}
+/**
+ * @generated MessageType for protobuf message gateway.v1.ToolCall
+ */
+export const ToolCall = new ToolCall$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class ConversationMessage$Type extends MessageType {
+    constructor() {
+        super("gateway.v1.ConversationMessage", [
+            { no: 1, name: "role", kind: "enum", T: () => ["gateway.v1.ConversationRole", ConversationRole, "CONVERSATION_ROLE_"] },
+            { no: 2, name: "content", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "tool_call_id", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ },
+            { no: 4, name: "tool_calls", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => ToolCall }
+        ]);
+    }
+}
+/**
+ * @generated MessageType for protobuf message gateway.v1.ConversationMessage
+ */
+export const ConversationMessage = new ConversationMessage$Type();
 // @generated message type with reflection information, may provide speed optimized methods
 class PromptRequest$Type extends MessageType {
     constructor() {
         super("gateway.v1.PromptRequest", [
             { no: 1, name: "template_variables", kind: "map", K: 9 /*ScalarType.STRING*/, V: { kind: "scalar", T: 9 /*ScalarType.STRING*/ } },
-            { no: 2, name: "prompt_config_id", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ }
+            { no: 2, name: "prompt_config_id", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "conversation_history", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => ConversationMessage },
+            { no: 4, name: "conversation_id", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ },
+            { no: 5, name: "user_key", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ }
         ]);
     }
 }
@@ -26,7 +96,11 @@ class PromptResponse$Type extends MessageType {
             { no: 1, name: "content", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
             { no: 2, name: "request_tokens", kind: "scalar", T: 13 /*ScalarType.UINT32*/ },
             { no: 3, name: "response_tokens", kind: "scalar", T: 13 /*ScalarType.UINT32*/ },
-            { no: 4, name: "request_duration", kind: "scalar", T: 13 /*ScalarType.UINT32*/ }
+            { no: 4, name: "request_duration", kind: "scalar", T: 13 /*ScalarType.UINT32*/ },
+            { no: 5, name: "model_vendor", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 6, name: "model_type", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 7, name: "is_cache_hit", kind: "scalar", T: 8 /*ScalarType.BOOL*/ },
+            { no: 8, name: "tool_calls", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => ToolCall }
         ]);
     }
 }
@@ -42,7 +116,10 @@ class StreamingPromptResponse$Type extends MessageType {
             { no: 2, name: "finish_reason", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ },
             { no: 3, name: "request_tokens", kind: "scalar", opt: true, T: 13 /*ScalarType.UINT32*/ },
             { no: 4, name: "response_tokens", kind: "scalar", opt: true, T: 13 /*ScalarType.UINT32*/ },
-            { no: 5, name: "stream_duration", kind: "scalar", opt: true, T: 13 /*ScalarType.UINT32*/ }
+            { no: 5, name: "stream_duration", kind: "scalar", opt: true, T: 13 /*ScalarType.UINT32*/ },
+            { no: 6, name: "model_vendor", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ },
+            { no: 7, name: "model_type", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ },
+            { no: 8, name: "tool_calls", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => ToolCall }
         ]);
     }
 }
@@ -50,10 +127,171 @@ class StreamingPromptResponse$Type extends MessageType {
  * @generated MessageType for protobuf message gateway.v1.StreamingPromptResponse
  */
 export const StreamingPromptResponse = new StreamingPromptResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class EmbeddingsRequest$Type extends MessageType {
+    constructor() {
+        super("gateway.v1.EmbeddingsRequest", [
+            { no: 1, name: "inputs", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "model_vendor", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "model_type", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 4, name: "dimensions", kind: "scalar", opt: true, T: 13 /*ScalarType.UINT32*/ },
+            { no: 5, name: "input_type", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+}
+/**
+ * @generated MessageType for protobuf message gateway.v1.EmbeddingsRequest
+ */
+export const EmbeddingsRequest = new EmbeddingsRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class Embedding$Type extends MessageType {
+    constructor() {
+        super("gateway.v1.Embedding", [
+            { no: 1, name: "values", kind: "scalar", repeat: 1 /*RepeatType.PACKED*/, T: 2 /*ScalarType.FLOAT*/ }
+        ]);
+    }
+}
+/**
+ * @generated MessageType for protobuf message gateway.v1.Embedding
+ */
+export const Embedding = new Embedding$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class EmbeddingsResponse$Type extends MessageType {
+    constructor() {
+        super("gateway.v1.EmbeddingsResponse", [
+            { no: 1, name: "embeddings", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => Embedding },
+            { no: 2, name: "request_tokens", kind: "scalar", T: 13 /*ScalarType.UINT32*/ },
+            { no: 3, name: "request_duration", kind: "scalar", T: 13 /*ScalarType.UINT32*/ },
+            { no: 4, name: "model_vendor", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 5, name: "model_type", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+}
+/**
+ * @generated MessageType for protobuf message gateway.v1.EmbeddingsResponse
+ */
+export const EmbeddingsResponse = new EmbeddingsResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class BatchJobItem$Type extends MessageType {
+    constructor() {
+        super("gateway.v1.BatchJobItem", [
+            { no: 1, name: "template_variables", kind: "map", K: 9 /*ScalarType.STRING*/, V: { kind: "scalar", T: 9 /*ScalarType.STRING*/ } }
+        ]);
+    }
+}
+/**
+ * @generated MessageType for protobuf message gateway.v1.BatchJobItem
+ */
+export const BatchJobItem = new BatchJobItem$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class BatchJobRequest$Type extends MessageType {
+    constructor() {
+        super("gateway.v1.BatchJobRequest", [
+            { no: 1, name: "items", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => BatchJobItem },
+            { no: 2, name: "prompt_config_id", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "callback_url", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+}
+/**
+ * @generated MessageType for protobuf message gateway.v1.BatchJobRequest
+ */
+export const BatchJobRequest = new BatchJobRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class BatchJobCreatedResponse$Type extends MessageType {
+    constructor() {
+        super("gateway.v1.BatchJobCreatedResponse", [
+            { no: 1, name: "job_id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "callback_secret", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+}
+/**
+ * @generated MessageType for protobuf message gateway.v1.BatchJobCreatedResponse
+ */
+export const BatchJobCreatedResponse = new BatchJobCreatedResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class BatchJobStatusRequest$Type extends MessageType {
+    constructor() {
+        super("gateway.v1.BatchJobStatusRequest", [
+            { no: 1, name: "job_id", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+}
+/**
+ * @generated MessageType for protobuf message gateway.v1.BatchJobStatusRequest
+ */
+export const BatchJobStatusRequest = new BatchJobStatusRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class BatchJobStatusResponse$Type extends MessageType {
+    constructor() {
+        super("gateway.v1.BatchJobStatusResponse", [
+            { no: 1, name: "job_id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "status", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "total_items", kind: "scalar", T: 13 /*ScalarType.UINT32*/ },
+            { no: 4, name: "completed_items", kind: "scalar", T: 13 /*ScalarType.UINT32*/ },
+            { no: 5, name: "failed_items", kind: "scalar", T: 13 /*ScalarType.UINT32*/ },
+            { no: 6, name: "created_at", kind: "scalar", T: 4 /*ScalarType.UINT64*/ },
+            { no: 7, name: "finished_at", kind: "scalar", opt: true, T: 4 /*ScalarType.UINT64*/ }
+        ]);
+    }
+}
+/**
+ * @generated MessageType for protobuf message gateway.v1.BatchJobStatusResponse
+ */
+export const BatchJobStatusResponse = new BatchJobStatusResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class BatchJobResultsRequest$Type extends MessageType {
+    constructor() {
+        super("gateway.v1.BatchJobResultsRequest", [
+            { no: 1, name: "job_id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "offset", kind: "scalar", T: 13 /*ScalarType.UINT32*/ },
+            { no: 3, name: "limit", kind: "scalar", opt: true, T: 13 /*ScalarType.UINT32*/ }
+        ]);
+    }
+}
+/**
+ * @generated MessageType for protobuf message gateway.v1.BatchJobResultsRequest
+ */
+export const BatchJobResultsRequest = new BatchJobResultsRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class BatchJobItemResult$Type extends MessageType {
+    constructor() {
+        super("gateway.v1.BatchJobItemResult", [
+            { no: 1, name: "index", kind: "scalar", T: 13 /*ScalarType.UINT32*/ },
+            { no: 2, name: "status", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "content", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ },
+            { no: 4, name: "error", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ },
+            { no: 5, name: "attempts", kind: "scalar", T: 13 /*ScalarType.UINT32*/ },
+            { no: 6, name: "request_tokens", kind: "scalar", T: 13 /*ScalarType.UINT32*/ },
+            { no: 7, name: "response_tokens", kind: "scalar", T: 13 /*ScalarType.UINT32*/ }
+        ]);
+    }
+}
+/**
+ * @generated MessageType for protobuf message gateway.v1.BatchJobItemResult
+ */
+export const BatchJobItemResult = new BatchJobItemResult$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class BatchJobResultsResponse$Type extends MessageType {
+    constructor() {
+        super("gateway.v1.BatchJobResultsResponse", [
+            { no: 1, name: "results", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => BatchJobItemResult }
+        ]);
+    }
+}
+/**
+ * @generated MessageType for protobuf message gateway.v1.BatchJobResultsResponse
+ */
+export const BatchJobResultsResponse = new BatchJobResultsResponse$Type();
 /**
  * @generated ServiceType for protobuf service gateway.v1.APIGatewayService
  */
 export const APIGatewayService = new ServiceType("gateway.v1.APIGatewayService", [
     { name: "RequestPrompt", options: {}, I: PromptRequest, O: PromptResponse },
-    { name: "RequestStreamingPrompt", serverStreaming: true, options: {}, I: PromptRequest, O: StreamingPromptResponse }
+    { name: "RequestStreamingPrompt", serverStreaming: true, options: {}, I: PromptRequest, O: StreamingPromptResponse },
+    { name: "RequestEmbeddings", options: {}, I: EmbeddingsRequest, O: EmbeddingsResponse },
+    { name: "CreateBatchJob", options: {}, I: BatchJobRequest, O: BatchJobCreatedResponse },
+    { name: "RetrieveBatchJob", options: {}, I: BatchJobStatusRequest, O: BatchJobStatusResponse },
+    { name: "RetrieveBatchJobResults", options: {}, I: BatchJobResultsRequest, O: BatchJobResultsResponse }
 ]);
@@ -21,6 +21,56 @@ export interface OpenAIFunctionCall {
      */
     name: string;
 }
+/**
+ * An OpenAI tool call requested by the model
+ *
+ * @generated from protobuf message openai.v1.OpenAIToolCall
+ */
+export interface OpenAIToolCall {
+    /**
+     * The ID of the tool call
+     *
+     * @generated from protobuf field: string id = 1;
+     */
+    id: string;
+    /**
+     * The name of the tool to call
+     *
+     * @generated from protobuf field: string name = 2;
+     */
+    name: string;
+    /**
+     * The JSON encoded arguments to call the tool with
+     *
+     * @generated from protobuf field: string arguments = 3;
+     */
+    arguments: string;
+}
+/**
+ * An OpenAI tool the model may call
+ *
+ * @generated from protobuf message openai.v1.OpenAITool
+ */
+export interface OpenAITool {
+    /**
+     * The name of the tool
+     *
+     * @generated from protobuf field: string name = 1;
+     */
+    name: string;
+    /**
+     * A description of what the tool does
+     *
+     * @generated from protobuf field: optional string description = 2;
+     */
+    description?: string;
+    /**
+     * The JSON schema of the tool arguments
+     *
+     * @generated from protobuf field: optional string parameters = 3;
+     */
+    parameters?: string;
+}
 /**
  * An OpenAI Chat Message
  *
@@ -51,6 +101,18 @@ export interface OpenAIMessage {
      * @generated from protobuf field: optional openai.v1.OpenAIFunctionCall function_call = 4;
      */
     functionCall?: OpenAIFunctionCall;
+    /**
+     * The ID of the tool call this message is the result of, for tool messages
+     *
+     * @generated from protobuf field: optional string tool_call_id = 5;
+     */
+    toolCallId?: string;
+    /**
+     * The tool calls requested by the model, for assistant messages
+     *
+     * @generated from protobuf field: repeated openai.v1.OpenAIToolCall tool_calls = 6;
+     */
+    toolCalls: OpenAIToolCall[];
 }
 /**
  * OpenAI API Request parameters
@@ -119,6 +181,18 @@ export interface OpenAIPromptRequest {
      * @generated from protobuf field: optional string application_id = 4;
      */
     applicationId?: string;
+    /**
+     * The tools the model may call
+     *
+     * @generated from protobuf field: repeated openai.v1.OpenAITool tools = 5;
+     */
+    tools: OpenAITool[];
+    /**
+     * Whether the model must respond with a JSON object
+     *
+     * @generated from protobuf field: optional bool json_mode = 6;
+     */
+    jsonMode?: boolean;
 }
 /**
  * An OpenAI Prompt Response Message
@@ -150,6 +224,12 @@ export interface OpenAIPromptResponse {
      * @generated from protobuf field: string finish_reason = 4;
      */
     finishReason: string;
+    /**
+     * The tool calls requested by the model
+     *
+     * @generated from protobuf field: repeated openai.v1.OpenAIToolCall tool_calls = 5;
+     */
+    toolCalls: OpenAIToolCall[];
 }
 /**
  * An OpenAI Streaming Response Message
@@ -181,6 +261,75 @@ export interface OpenAIStreamResponse {
      * @generated from protobuf field: optional uint32 response_tokens_count = 4;
      */
     responseTokensCount?: number;
+    /**
+     * The tool calls requested by the model, given complete in the last message
+     *
+     * @generated from protobuf field: repeated openai.v1.OpenAIToolCall tool_calls = 5;
+     */
+    toolCalls: OpenAIToolCall[];
+}
+/**
+ * A Request for OpenAI embedding vectors
+ *
+ * @generated from protobuf message openai.v1.OpenAIEmbeddingsRequest
+ */
+export interface OpenAIEmbeddingsRequest {
+    /**
+     * OpenAI Embedding Model identifier
+     *
+     * @generated from protobuf field: openai.v1.OpenAIEmbeddingModel model = 1;
+     */
+    model: OpenAIEmbeddingModel;
+    /**
+     * The inputs to embed
+     *
+     * @generated from protobuf field: repeated string inputs = 2;
+     */
+    inputs: string[];
+    /**
+     * The number of dimensions of the embedding vectors, supported by the text-embedding-3 models
+     *
+     * @generated from protobuf field: optional uint32 dimensions = 3;
+     */
+    dimensions?: number;
+    /**
+     * Unique application ID to keep track of requests;
+     *
+     * @generated from protobuf field: optional string application_id = 4;
+     */
+    applicationId?: string;
+}
+/**
+ * An OpenAI embedding vector
+ *
+ * @generated from protobuf message openai.v1.OpenAIEmbedding
+ */
+export interface OpenAIEmbedding {
+    /**
+     * The values of the embedding vector
+     *
+     * @generated from protobuf field: repeated float values = 1;
+     */
+    values: number[];
+}
+/**
+ * An OpenAI Embeddings Response Message
+ *
+ * @generated from protobuf message openai.v1.OpenAIEmbeddingsResponse
+ */
+export interface OpenAIEmbeddingsResponse {
+    /**
+     * The embedding vectors, in the order of the request inputs
+     *
+     * @generated from protobuf field: repeated openai.v1.OpenAIEmbedding embeddings = 1;
+     */
+    embeddings: OpenAIEmbedding[];
+    /**
+     * Count of the request tokens, as returned by the OpenAI API
+     *
+     * @generated from protobuf field: uint32 request_tokens_count = 2;
+     */
+    requestTokensCount: number;
 }
 /**
  * Type of OpenAI Model
@@ -219,6 +368,37 @@ export declare enum OpenAIModel {
      */
     OPEN_AI_MODEL_GPT4_32K = 4
 }
+/**
+ * Type of OpenAI Embedding Model
+ *
+ * @generated from protobuf enum openai.v1.OpenAIEmbeddingModel
+ */
+export declare enum OpenAIEmbeddingModel {
+    /**
+     * OpenAI Embedding Model is not Specified
+     *
+     * @generated from protobuf enum value: OPEN_AI_EMBEDDING_MODEL_UNSPECIFIED = 0;
+     */
+    OPEN_AI_EMBEDDING_MODEL_UNSPECIFIED = 0,
+    /**
+     * OpenAI Text Embedding 3 Small
+     *
+     * @generated from protobuf enum value: OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_3_SMALL = 1;
+     */
+    OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_3_SMALL = 1,
+    /**
+     * OpenAI Text Embedding 3 Large
+     *
+     * @generated from protobuf enum value: OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_3_LARGE = 2;
+     */
+    OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_3_LARGE = 2,
+    /**
+     * OpenAI Text Embedding Ada 002
+     *
+     * @generated from protobuf enum value: OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_ADA_002 = 3;
+     */
+    OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_ADA_002 = 3
+}
 /**
  * Type of OpenAI Message
  *
@@ -254,7 +434,13 @@ export declare enum OpenAIMessageRole {
      *
      * @generated from protobuf enum value: OPEN_AI_MESSAGE_ROLE_FUNCTION = 4;
      */
-    OPEN_AI_MESSAGE_ROLE_FUNCTION = 4
+    OPEN_AI_MESSAGE_ROLE_FUNCTION = 4,
+    /**
+     * OpenAI Tool message, holding the result of a tool call
+     *
+     * @generated from protobuf enum value: OPEN_AI_MESSAGE_ROLE_TOOL = 5;
+     */
+    OPEN_AI_MESSAGE_ROLE_TOOL = 5
 }
 declare class OpenAIFunctionCall$Type extends MessageType<OpenAIFunctionCall> {
     constructor();
@@ -263,6 +449,20 @@ declare class OpenAIFunctionCall$Type extends MessageType<OpenAIFunctionCall> {
  * @generated MessageType for protobuf message openai.v1.OpenAIFunctionCall
  */
 export declare const OpenAIFunctionCall: OpenAIFunctionCall$Type;
+declare class OpenAIToolCall$Type extends MessageType<OpenAIToolCall> {
+    constructor();
+}
+/**
+ * @generated MessageType for protobuf message openai.v1.OpenAIToolCall
+ */
+export declare const OpenAIToolCall: OpenAIToolCall$Type;
+declare class OpenAITool$Type extends MessageType<OpenAITool> {
+    constructor();
+}
+/**
+ * @generated MessageType for protobuf message openai.v1.OpenAITool
+ */
+export declare const OpenAITool: OpenAITool$Type;
 declare class OpenAIMessage$Type extends MessageType<OpenAIMessage> {
     constructor();
 }
@@ -298,6 +498,27 @@ declare class OpenAIStreamResponse$Type extends MessageType<OpenAIStreamResponse
  * @generated MessageType for protobuf message openai.v1.OpenAIStreamResponse
  */
 export declare const OpenAIStreamResponse: OpenAIStreamResponse$Type;
+declare class OpenAIEmbeddingsRequest$Type extends MessageType<OpenAIEmbeddingsRequest> {
+    constructor();
+}
+/**
+ * @generated MessageType for protobuf message openai.v1.OpenAIEmbeddingsRequest
+ */
+export declare const OpenAIEmbeddingsRequest: OpenAIEmbeddingsRequest$Type;
+declare class OpenAIEmbedding$Type extends MessageType<OpenAIEmbedding> {
+    constructor();
+}
+/**
+ * @generated MessageType for protobuf message openai.v1.OpenAIEmbedding
+ */
+export declare const OpenAIEmbedding: OpenAIEmbedding$Type;
+declare class OpenAIEmbeddingsResponse$Type extends MessageType<OpenAIEmbeddingsResponse> {
+    constructor();
+}
+/**
+ * @generated MessageType for protobuf message openai.v1.OpenAIEmbeddingsResponse
+ */
+export declare const OpenAIEmbeddingsResponse: OpenAIEmbeddingsResponse$Type;
 /**
  * @generated ServiceType for protobuf service openai.v1.OpenAIService
  */
@@ -1,6 +1,8 @@
 // @generated by protobuf-ts 2.9.4 with parameter generate_dependencies,long_type_string,output_javascript_es2020,server_grpc1,force_client_none
 // @generated from protobuf file "openai/v1/openai.proto" (package "openai.v1", syntax proto3)
 // tslint:disable
+import { OpenAIEmbeddingsResponse } from "./openai";
+import { OpenAIEmbeddingsRequest } from "./openai";
 import { OpenAIStreamResponse } from "./openai";
 import { OpenAIPromptResponse } from "./openai";
 import { OpenAIPromptRequest } from "./openai";
@@ -23,6 +25,12 @@ export interface IOpenAIService extends grpc.UntypedServiceImplementation {
      * @generated from protobuf rpc: OpenAIStream(openai.v1.OpenAIPromptRequest) returns (stream openai.v1.OpenAIStreamResponse);
      */
     openAIStream: grpc.handleServerStreamingCall<OpenAIPromptRequest, OpenAIStreamResponse>;
+    /**
+     * Request embedding vectors for a batch of inputs
+     *
+     * @generated from protobuf rpc: OpenAIEmbeddings(openai.v1.OpenAIEmbeddingsRequest) returns (openai.v1.OpenAIEmbeddingsResponse);
+     */
+    openAIEmbeddings: grpc.handleUnaryCall<OpenAIEmbeddingsRequest, OpenAIEmbeddingsResponse>;
 }
 /**
  * @grpc/grpc-js definition for the protobuf service openai.v1.OpenAIService.
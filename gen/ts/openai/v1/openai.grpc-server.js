@@ -4,6 +4,8 @@
 // @generated by protobuf-ts 2.9.4 with parameter generate_dependencies,long_type_string,output_javascript_es2020,server_grpc1,force_client_none
 // @generated from protobuf file "openai/v1/openai.proto" (package "openai.v1", syntax proto3)
 // tslint:disable
+import { OpenAIEmbeddingsResponse } from "./openai";
+import { OpenAIEmbeddingsRequest } from "./openai";
 import { OpenAIStreamResponse } from "./openai";
 import { OpenAIPromptResponse } from "./openai";
 import { OpenAIPromptRequest } from "./openai";
@@ -38,5 +40,15 @@ export const openAIServiceDefinition = {
         requestDeserialize: bytes => OpenAIPromptRequest.fromBinary(bytes),
         responseSerialize: value => Buffer.from(OpenAIStreamResponse.toBinary(value)),
         requestSerialize: value => Buffer.from(OpenAIPromptRequest.toBinary(value))
+    },
+    openAIEmbeddings: {
+        path: "/openai.v1.OpenAIService/OpenAIEmbeddings",
+        originalName: "OpenAIEmbeddings",
+        requestStream: false,
+        responseStream: false,
+        responseDeserialize: bytes => OpenAIEmbeddingsResponse.fromBinary(bytes),
+        requestDeserialize: bytes => OpenAIEmbeddingsRequest.fromBinary(bytes),
+        responseSerialize: value => Buffer.from(OpenAIEmbeddingsResponse.toBinary(value)),
+        requestSerialize: value => Buffer.from(OpenAIEmbeddingsRequest.toBinary(value))
     }
 };
@@ -44,6 +44,38 @@ export var OpenAIModel;
      */
     OpenAIModel[OpenAIModel["OPEN_AI_MODEL_GPT4_32K"] = 4] = "OPEN_AI_MODEL_GPT4_32K";
 })(OpenAIModel || (OpenAIModel = {}));
+/**
+ * Type of OpenAI Embedding Model
+ *
+ * @generated from protobuf enum openai.v1.OpenAIEmbeddingModel
+ */
+export var OpenAIEmbeddingModel;
+(function (OpenAIEmbeddingModel) {
+    /**
+     * OpenAI Embedding Model is not Specified
+     *
+     * @generated from protobuf enum value: OPEN_AI_EMBEDDING_MODEL_UNSPECIFIED = 0;
+     */
+    OpenAIEmbeddingModel[OpenAIEmbeddingModel["OPEN_AI_EMBEDDING_MODEL_UNSPECIFIED"] = 0] = "OPEN_AI_EMBEDDING_MODEL_UNSPECIFIED";
+    /**
+     * OpenAI Text Embedding 3 Small
+     *
+     * @generated from protobuf enum value: OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_3_SMALL = 1;
+     */
+    OpenAIEmbeddingModel[OpenAIEmbeddingModel["OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_3_SMALL"] = 1] = "OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_3_SMALL";
+    /**
+     * OpenAI Text Embedding 3 Large
+     *
+     * @generated from protobuf enum value: OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_3_LARGE = 2;
+     */
+    OpenAIEmbeddingModel[OpenAIEmbeddingModel["OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_3_LARGE"] = 2] = "OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_3_LARGE";
+    /**
+     * OpenAI Text Embedding Ada 002
+     *
+     * @generated from protobuf enum value: OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_ADA_002 = 3;
+     */
+    OpenAIEmbeddingModel[OpenAIEmbeddingModel["OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_ADA_002"] = 3] = "OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_ADA_002";
+})(OpenAIEmbeddingModel || (OpenAIEmbeddingModel = {}));
 /**
  * Type of OpenAI Message
  *
@@ -81,6 +113,12 @@ export var OpenAIMessageRole;
      * @generated from protobuf enum value: OPEN_AI_MESSAGE_ROLE_FUNCTION = 4;
      */
     OpenAIMessageRole[OpenAIMessageRole["OPEN_AI_MESSAGE_ROLE_FUNCTION"] = 4] = "OPEN_AI_MESSAGE_ROLE_FUNCTION";
+    /**
+     * OpenAI Tool message, holding the result of a tool call
+     *
+     * @generated from protobuf enum value: OPEN_AI_MESSAGE_ROLE_TOOL = 5;
+     */
+    OpenAIMessageRole[OpenAIMessageRole["OPEN_AI_MESSAGE_ROLE_TOOL"] = 5] = "OPEN_AI_MESSAGE_ROLE_TOOL";
 })(OpenAIMessageRole || (OpenAIMessageRole = {}));
 // @generated message type with reflection information, may provide speed optimized methods
 class OpenAIFunctionCall$Type extends MessageType {
@@ -96,13 +134,43 @@ class OpenAIFunctionCall$Type extends MessageType {
  */
 export const OpenAIFunctionCall = new OpenAIFunctionCall$Type();
 // @generated message type with reflection information, may provide speed optimized methods
+class OpenAIToolCall$Type extends MessageType {
+    constructor() {
+        super("openai.v1.OpenAIToolCall", [
+            { no: 1, name: "id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "arguments", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+}
+/**
+ * @generated MessageType for protobuf message openai.v1.OpenAIToolCall
+ */
+export const OpenAIToolCall = new OpenAIToolCall$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class OpenAITool$Type extends MessageType {
+    constructor() {
+        super("openai.v1.OpenAITool", [
+            { no: 1, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "description", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "parameters", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+}
+/**
+ * @generated MessageType for protobuf message openai.v1.OpenAITool
+ */
+export const OpenAITool = new OpenAITool$Type();
+// @generated message type with reflection information, may provide speed optimized methods
 class OpenAIMessage$Type extends MessageType {
     constructor() {
         super("openai.v1.OpenAIMessage", [
             { no: 1, name: "content", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ },
             { no: 2, name: "role", kind: "enum", T: () => ["openai.v1.OpenAIMessageRole", OpenAIMessageRole] },
             { no: 3, name: "name", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ },
-            { no: 4, name: "function_call", kind: "message", T: () => OpenAIFunctionCall }
+            { no: 4, name: "function_call", kind: "message", T: () => OpenAIFunctionCall },
+            { no: 5, name: "tool_call_id", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ },
+            { no: 6, name: "tool_calls", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => OpenAIToolCall }
         ]);
     }
 }
@@ -133,7 +201,9 @@ class OpenAIPromptRequest$Type extends MessageType {
             { no: 1, name: "model", kind: "enum", T: () => ["openai.v1.OpenAIModel", OpenAIModel] },
             { no: 2, name: "messages", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => OpenAIMessage },
             { no: 3, name: "parameters", kind: "message", T: () => OpenAIModelParameters },
-            { no: 4, name: "application_id", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ }
+            { no: 4, name: "application_id", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ },
+            { no: 5, name: "tools", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => OpenAITool },
+            { no: 6, name: "json_mode", kind: "scalar", opt: true, T: 8 /*ScalarType.BOOL*/ }
         ]);
     }
 }
@@ -148,7 +218,8 @@ class OpenAIPromptResponse$Type extends MessageType {
             { no: 1, name: "content", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
             { no: 2, name: "request_tokens_count", kind: "scalar", T: 13 /*ScalarType.UINT32*/ },
             { no: 3, name: "response_tokens_count", kind: "scalar", T: 13 /*ScalarType.UINT32*/ },
-            { no: 4, name: "finish_reason", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+            { no: 4, name: "finish_reason", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 5, name: "tool_calls", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => OpenAIToolCall }
         ]);
     }
 }
@@ -163,7 +234,8 @@ class OpenAIStreamResponse$Type extends MessageType {
             { no: 1, name: "content", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
             { no: 2, name: "finish_reason", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ },
             { no: 3, name: "request_tokens_count", kind: "scalar", opt: true, T: 13 /*ScalarType.UINT32*/ },
-            { no: 4, name: "response_tokens_count", kind: "scalar", opt: true, T: 13 /*ScalarType.UINT32*/ }
+            { no: 4, name: "response_tokens_count", kind: "scalar", opt: true, T: 13 /*ScalarType.UINT32*/ },
+            { no: 5, name: "tool_calls", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => OpenAIToolCall }
         ]);
     }
 }
@@ -171,10 +243,51 @@ class OpenAIStreamResponse$Type extends MessageType {
  * @generated MessageType for protobuf message openai.v1.OpenAIStreamResponse
  */
 export const OpenAIStreamResponse = new OpenAIStreamResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class OpenAIEmbeddingsRequest$Type extends MessageType {
+    constructor() {
+        super("openai.v1.OpenAIEmbeddingsRequest", [
+            { no: 1, name: "model", kind: "enum", T: () => ["openai.v1.OpenAIEmbeddingModel", OpenAIEmbeddingModel] },
+            { no: 2, name: "inputs", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "dimensions", kind: "scalar", opt: true, T: 13 /*ScalarType.UINT32*/ },
+            { no: 4, name: "application_id", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+}
+/**
+ * @generated MessageType for protobuf message openai.v1.OpenAIEmbeddingsRequest
+ */
+export const OpenAIEmbeddingsRequest = new OpenAIEmbeddingsRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class OpenAIEmbedding$Type extends MessageType {
+    constructor() {
+        super("openai.v1.OpenAIEmbedding", [
+            { no: 1, name: "values", kind: "scalar", repeat: 1 /*RepeatType.PACKED*/, T: 2 /*ScalarType.FLOAT*/ }
+        ]);
+    }
+}
+/**
+ * @generated MessageType for protobuf message openai.v1.OpenAIEmbedding
+ */
+export const OpenAIEmbedding = new OpenAIEmbedding$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class OpenAIEmbeddingsResponse$Type extends MessageType {
+    constructor() {
+        super("openai.v1.OpenAIEmbeddingsResponse", [
+            { no: 1, name: "embeddings", kind: "message", repeat: 1 /*RepeatType.PACKED*/, T: () => OpenAIEmbedding },
+            { no: 2, name: "request_tokens_count", kind: "scalar", T: 13 /*ScalarType.UINT32*/ }
+        ]);
+    }
+}
+/**
+ * @generated MessageType for protobuf message openai.v1.OpenAIEmbeddingsResponse
+ */
+export const OpenAIEmbeddingsResponse = new OpenAIEmbeddingsResponse$Type();
 /**
  * @generated ServiceType for protobuf service openai.v1.OpenAIService
  */
 export const OpenAIService = new ServiceType("openai.v1.OpenAIService", [
     { name: "OpenAIPrompt", options: {}, I: OpenAIPromptRequest, O: OpenAIPromptResponse },
-    { name: "OpenAIStream", serverStreaming: true, options: {}, I: OpenAIPromptRequest, O: OpenAIStreamResponse }
+    { name: "OpenAIStream", serverStreaming: true, options: {}, I: OpenAIPromptRequest, O: OpenAIStreamResponse },
+    { name: "OpenAIEmbeddings", options: {}, I: OpenAIEmbeddingsRequest, O: OpenAIEmbeddingsResponse }
 ]);
@@ -1,16 +1,23 @@
 package cohere
 
 import (
+	"context"
 	cohereconnector "github.com/basemind-ai/monorepo/gen/go/cohere/v1"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/utils"
 	"github.com/basemind-ai/monorepo/shared/go/exc"
 	"github.com/basemind-ai/monorepo/shared/go/grpcutils"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 )
 
+// retryPolicyEnvPrefix is the prefix of the environment variables configuring the retry policy,
+// e.g. COHERE_CONNECTOR_TIMEOUT.
+const retryPolicyEnvPrefix = "COHERE_CONNECTOR_"
+
 // Client implements the Cohere connector gRPC client.
 type Client struct {
-	client cohereconnector.CohereServiceClient
+	client      cohereconnector.CohereServiceClient
+	retryPolicy utils.RetryPolicy
 }
 
 // New creates a new Cohere connector client.
@@ -18,5 +25,8 @@ func New(serverAddress string, opts ...grpc.DialOption) *Client {
 	conn := exc.MustResult(grpcutils.NewConnection(serverAddress, opts...))
 	log.Info().Msg("initialized Cohere connector connection")
 
-	return &Client{client: cohereconnector.NewCohereServiceClient(conn)}
+	return &Client{
+		client:      cohereconnector.NewCohereServiceClient(conn),
+		retryPolicy: utils.RetryPolicyFromEnv(context.Background(), retryPolicyEnvPrefix),
+	}
 }
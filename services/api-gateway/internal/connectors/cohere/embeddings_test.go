@@ -0,0 +1,91 @@
+package cohere_test
+
+import (
+	"context"
+	"github.com/basemind-ai/monorepo/e2e/factories"
+	cohereconnector "github.com/basemind-ai/monorepo/gen/go/cohere/v1"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/dto"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/services"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/exc"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRequestEmbeddings(t *testing.T) {
+	_ = factories.CreateProviderPricingModels(context.TODO())
+	project, _ := factories.CreateProject(context.TODO())
+	application, _ := factories.CreateApplication(context.TODO(), project.ID)
+
+	modelPricing, _ := services.RetrieveProviderModelPricing(
+		context.TODO(),
+		models.ModelTypeEmbedEnglishV30,
+		models.ModelVendorCOHERE,
+		application.ProjectID,
+	)
+
+	embeddingsConfigurationDTO := &dto.EmbeddingsConfigurationDTO{
+		ApplicationID:        application.ID,
+		ModelVendor:          models.ModelVendorCOHERE,
+		ModelType:            models.ModelTypeEmbedEnglishV30,
+		InputType:            ptr.To("search_query"),
+		ProviderModelPricing: modelPricing,
+	}
+
+	inputs := []string{"The meaning of life", "is 42"}
+
+	t.Run("returns the embeddings", func(t *testing.T) {
+		client, mockService := CreateClientAndService(t)
+
+		mockService.ExpectedEmbeddingsRequest = &cohereconnector.CohereEmbeddingsRequest{
+			Model:     cohereconnector.CohereEmbeddingModel_COHERE_EMBEDDING_MODEL_EMBED_ENGLISH_V3,
+			Inputs:    inputs,
+			InputType: cohereconnector.CohereEmbeddingInputType_COHERE_EMBEDDING_INPUT_TYPE_SEARCH_QUERY,
+		}
+		mockService.EmbeddingsResponse = &cohereconnector.CohereEmbeddingsResponse{
+			Embeddings: []*cohereconnector.CohereEmbedding{
+				{Values: []float32{0.1, 0.2}},
+				{Values: []float32{0.3, 0.4}},
+			},
+			RequestTokensCount: 100,
+		}
+
+		result := client.RequestEmbeddings(context.TODO(), embeddingsConfigurationDTO, inputs)
+		assert.NoError(t, result.Error)
+		assert.Equal(t, [][]float32{{0.1, 0.2}, {0.3, 0.4}}, result.Embeddings)
+
+		assert.NotNil(t, result.RequestRecord)
+		assert.Equal(t, application.ID, result.RequestRecord.ApplicationID)
+		assert.False(t, result.RequestRecord.PromptConfigID.Valid)
+		assert.Equal(t, int32(100), result.RequestRecord.RequestTokens)
+		// 100 tokens at 0.1 per 1,000,000 tokens
+		assert.Equal(
+			t,
+			"0.00001",
+			exc.MustResult(db.NumericToDecimal(result.RequestRecord.RequestTokensCost)).String(),
+		)
+	})
+
+	t.Run("returns an error if the request fails", func(t *testing.T) {
+		client, mockService := CreateClientAndService(t)
+		mockService.Error = assert.AnError
+
+		result := client.RequestEmbeddings(context.TODO(), embeddingsConfigurationDTO, inputs)
+		assert.Error(t, result.Error)
+		assert.NotNil(t, result.RequestRecord)
+		assert.Equal(t, models.PromptFinishReasonERROR, result.RequestRecord.FinishReason)
+	})
+
+	t.Run("returns an error for an unknown input type", func(t *testing.T) {
+		client, _ := CreateClientAndService(t)
+
+		configuration := *embeddingsConfigurationDTO
+		configuration.InputType = ptr.To("unknown")
+
+		result := client.RequestEmbeddings(context.TODO(), &configuration, inputs)
+		assert.Error(t, result.Error)
+		assert.Nil(t, result.RequestRecord)
+	})
+}
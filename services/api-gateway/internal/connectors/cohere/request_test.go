@@ -9,6 +9,7 @@ import (
 	"github.com/basemind-ai/monorepo/services/api-gateway/internal/services"
 	"github.com/basemind-ai/monorepo/shared/go/datatypes"
 	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/exc"
 	"github.com/basemind-ai/monorepo/shared/go/ptr"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -25,10 +26,11 @@ func TestRequestPrompt(t *testing.T) {
 		application.ID,
 	)
 
-	modelPricing := services.RetrieveProviderModelPricing(
+	modelPricing, _ := services.RetrieveProviderModelPricing(
 		context.TODO(),
 		promptConfig.ModelType,
 		promptConfig.ModelVendor,
+		application.ProjectID,
 	)
 
 	requestConfigurationDTO := &dto.RequestConfigurationDTO{
@@ -86,10 +88,23 @@ func TestRequestPrompt(t *testing.T) {
 			context.TODO(),
 			requestConfigurationDTO,
 			templateVariables,
+			nil,
 		)
 		assert.NoError(t, result.Error)
 		assert.Equal(t, "Response content", *result.Content)
 		assert.NotNil(t, result.RequestRecord)
+		assert.Equal(
+			t,
+			"0.00001",
+			exc.MustResult(db.NumericToDecimal(result.RequestRecord.RequestTokensCost)).String(),
+		)
+		assert.Equal(
+			t,
+			"0.00002",
+			exc.MustResult(db.NumericToDecimal(result.RequestRecord.ResponseTokensCost)).String(),
+		)
+		assert.True(t, result.RequestRecord.DurationMs.Valid)
+		assert.GreaterOrEqual(t, result.RequestRecord.DurationMs.Int32, int32(0))
 	})
 
 	t.Run("returns an error if the request fails", func(t *testing.T) {
@@ -101,6 +116,7 @@ func TestRequestPrompt(t *testing.T) {
 			context.TODO(),
 			requestConfigurationDTO,
 			templateVariables,
+			nil,
 		)
 		assert.Error(t, result.Error)
 	})
@@ -112,6 +128,7 @@ func TestRequestPrompt(t *testing.T) {
 			context.TODO(),
 			requestConfigurationDTO,
 			map[string]string{},
+			nil,
 		)
 		assert.Errorf(t, result.Error, "missing template variable {userInput}")
 	})
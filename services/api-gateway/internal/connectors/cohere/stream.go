@@ -5,6 +5,7 @@ import (
 	cohereconnector "github.com/basemind-ai/monorepo/gen/go/cohere/v1"
 	"github.com/basemind-ai/monorepo/services/api-gateway/internal/dto"
 	"github.com/basemind-ai/monorepo/services/api-gateway/internal/utils"
+	"github.com/basemind-ai/monorepo/shared/go/datatypes"
 	"github.com/basemind-ai/monorepo/shared/go/db"
 	"github.com/basemind-ai/monorepo/shared/go/db/models"
 	"github.com/basemind-ai/monorepo/shared/go/exc"
@@ -33,11 +34,13 @@ func (c *Client) RequestStream(
 	ctx context.Context,
 	requestConfiguration *dto.RequestConfigurationDTO,
 	templateVariables map[string]string,
+	conversationHistory []dto.ConversationMessageDTO,
 	channel chan<- dto.PromptResultDTO,
 ) {
 	promptRequest, promptRequestErr := CreatePromptRequest(
 		requestConfiguration,
 		templateVariables,
+		conversationHistory,
 	)
 	if promptRequestErr != nil {
 		log.Error().Err(promptRequestErr).Msg("failed to create prompt request")
@@ -53,21 +56,35 @@ func (c *Client) RequestStream(
 
 	recordParams := &models.CreatePromptRequestRecordParams{
 		PromptConfigID:         requestConfiguration.PromptConfigID,
+		PromptConfigVersionID:  requestConfiguration.PromptConfigVersionID,
+		IsTrafficSplit:         requestConfiguration.IsTrafficSplit,
 		IsStreamResponse:       true,
 		StartTime:              pgtype.Timestamptz{Time: startTime, Valid: true},
 		ProviderModelPricingID: *modelPricingID,
 	}
 	finalResult := &dto.PromptResultDTO{}
 
-	stream, streamErr := c.client.CohereStream(ctx, promptRequest)
+	// the stream context is released once the stream has been consumed
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, attempts, streamErr := utils.StreamWithRetry(
+		streamCtx,
+		c.retryPolicy,
+		func(ctx context.Context) (utils.Stream[cohereconnector.CohereStreamResponse], error) {
+			return c.client.CohereStream(ctx, promptRequest)
+		},
+	)
+	recordParams.Attempts = int32(attempts)
 	finalResult.Error = streamErr
 
 	if finalResult.Error == nil {
 		streamFinish := utils.StreamFromClient[cohereconnector.CohereStreamResponse](
+			ctx,
 			channel,
 			finalResult,
 			recordParams,
-			startTime,
+			GetRequestPromptString(promptRequest),
 			stream,
 			parseMessage,
 		)
@@ -78,21 +95,32 @@ func (c *Client) RequestStream(
 		recordParams.ResponseTokens = int32(streamFinish.ResponseTokenCount)
 
 		costs := utils.CalculateCosts(
-			recordParams.RequestTokens,
-			recordParams.ResponseTokens,
+			utils.TokenUsage{
+				datatypes.TokenClassInput:  recordParams.RequestTokens,
+				datatypes.TokenClassOutput: recordParams.ResponseTokens,
+			},
 			requestConfiguration.ProviderModelPricing,
 		)
-		recordParams.RequestTokensCost = *exc.MustResult(db.StringToNumeric(costs.RequestTokenCost.String()))
-		recordParams.ResponseTokensCost = *exc.MustResult(db.StringToNumeric(costs.RequestTokenCost.String()))
+		recordParams.RequestTokensCost = *exc.MustResult(db.StringToNumeric(costs.RequestTokenCost().String()))
+		recordParams.ResponseTokensCost = *exc.MustResult(db.StringToNumeric(costs.ResponseTokenCost().String()))
 	}
 
 	if finalResult.Error != nil {
 		recordParams.ErrorLog = pgtype.Text{String: finalResult.Error.Error(), Valid: true}
+		recordParams.FinishReason = models.PromptFinishReasonERROR
+
+		if !recordParams.RequestTokensCost.Valid {
+			// the stream failed before any tokens were counted
+			utils.SetRecordFinishTime(recordParams)
+			recordParams.RequestTokensCost = *exc.MustResult(db.StringToNumeric("0"))
+			recordParams.ResponseTokensCost = *exc.MustResult(db.StringToNumeric("0"))
+		}
 	}
 
+	// the record is created for cancelled streams as well, hence the context cancellation is not propagated
 	promptRecord := exc.MustResult(db.GetQueries().
 		CreatePromptRequestRecord(
-			ctx,
+			context.WithoutCancel(ctx),
 			*recordParams,
 		))
 
@@ -24,10 +24,11 @@ func TestRequestStream(t *testing.T) {
 		application.ID,
 	)
 
-	modelPricing := services.RetrieveProviderModelPricing(
+	modelPricing, _ := services.RetrieveProviderModelPricing(
 		context.TODO(),
 		promptConfig.ModelType,
 		promptConfig.ModelVendor,
+		application.ProjectID,
 	)
 
 	requestConfigurationDTO := &dto.RequestConfigurationDTO{
@@ -80,6 +81,7 @@ func TestRequestStream(t *testing.T) {
 				context.TODO(),
 				requestConfigurationDTO,
 				templateVariables,
+				nil,
 				channel,
 			)
 		}()
@@ -103,6 +105,8 @@ func TestRequestStream(t *testing.T) {
 		assert.Nil(t, chunks[3].Content)
 		assert.Nil(t, chunks[3].Error)
 		assert.NotNil(t, chunks[3].RequestRecord)
+		assert.True(t, chunks[3].RequestRecord.DurationMs.Valid)
+		assert.GreaterOrEqual(t, chunks[3].RequestRecord.DurationMs.Int32, int32(0))
 	})
 
 	t.Run("returns an error if the request fails", func(t *testing.T) {
@@ -117,6 +121,7 @@ func TestRequestStream(t *testing.T) {
 				context.TODO(),
 				requestConfigurationDTO,
 				templateVariables,
+				nil,
 				channel,
 			)
 		}()
@@ -142,6 +147,7 @@ func TestRequestStream(t *testing.T) {
 				context.TODO(),
 				requestConfigurationDTO,
 				map[string]string{},
+				nil,
 				channel,
 			)
 		}()
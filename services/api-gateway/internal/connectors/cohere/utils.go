@@ -9,6 +9,9 @@ import (
 	"github.com/basemind-ai/monorepo/shared/go/datatypes"
 	"github.com/basemind-ai/monorepo/shared/go/db/models"
 	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
 )
 
 var ModelTypeMap = map[models.ModelType]cohereconnector.CohereModel{
@@ -18,6 +21,12 @@ var ModelTypeMap = map[models.ModelType]cohereconnector.CohereModel{
 	models.ModelTypeCommandLightNightly: cohereconnector.CohereModel_COHERE_MODEL_COMMAND_LIGHT_NIGHTLY,
 }
 
+var ChatRoleMap = map[models.ConversationMessageRole]cohereconnector.CohereChatRole{
+	models.ConversationMessageRoleSystem:    cohereconnector.CohereChatRole_COHERE_CHAT_ROLE_SYSTEM,
+	models.ConversationMessageRoleUser:      cohereconnector.CohereChatRole_COHERE_CHAT_ROLE_USER,
+	models.ConversationMessageRoleAssistant: cohereconnector.CohereChatRole_COHERE_CHAT_ROLE_CHATBOT,
+}
+
 func GetModelType(modelType models.ModelType) (*cohereconnector.CohereModel, error) {
 	value, ok := ModelTypeMap[modelType]
 	if !ok {
@@ -27,6 +36,42 @@ func GetModelType(modelType models.ModelType) (*cohereconnector.CohereModel, err
 	return &value, nil
 }
 
+var EmbeddingModelTypeMap = map[models.ModelType]cohereconnector.CohereEmbeddingModel{
+	models.ModelTypeEmbedEnglishV30:      cohereconnector.CohereEmbeddingModel_COHERE_EMBEDDING_MODEL_EMBED_ENGLISH_V3,
+	models.ModelTypeEmbedMultilingualV30: cohereconnector.CohereEmbeddingModel_COHERE_EMBEDDING_MODEL_EMBED_MULTILINGUAL_V3,
+}
+
+var EmbeddingInputTypeMap = map[string]cohereconnector.CohereEmbeddingInputType{
+	"search_document": cohereconnector.CohereEmbeddingInputType_COHERE_EMBEDDING_INPUT_TYPE_SEARCH_DOCUMENT,
+	"search_query":    cohereconnector.CohereEmbeddingInputType_COHERE_EMBEDDING_INPUT_TYPE_SEARCH_QUERY,
+	"classification":  cohereconnector.CohereEmbeddingInputType_COHERE_EMBEDDING_INPUT_TYPE_CLASSIFICATION,
+	"clustering":      cohereconnector.CohereEmbeddingInputType_COHERE_EMBEDDING_INPUT_TYPE_CLUSTERING,
+}
+
+func GetEmbeddingModelType(modelType models.ModelType) (*cohereconnector.CohereEmbeddingModel, error) {
+	value, ok := EmbeddingModelTypeMap[modelType]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown embedding model type {%s}", modelType)
+	}
+
+	return &value, nil
+}
+
+// GetEmbeddingInputType returns the connector input type of an embeddings request.
+// Inputs without a type are embedded as search documents.
+func GetEmbeddingInputType(inputType *string) (*cohereconnector.CohereEmbeddingInputType, error) {
+	if inputType == nil {
+		return cohereconnector.CohereEmbeddingInputType_COHERE_EMBEDDING_INPUT_TYPE_SEARCH_DOCUMENT.Enum(), nil
+	}
+
+	value, ok := EmbeddingInputTypeMap[*inputType]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown embedding input type {%s}", *inputType)
+	}
+
+	return &value, nil
+}
+
 func parseParameters(
 	rawParameters *json.RawMessage,
 	connectorParameters *cohereconnector.CohereModelParameters,
@@ -67,9 +112,19 @@ func parseParameters(
 	return nil
 }
 
+func GetChatRole(role models.ConversationMessageRole) (*cohereconnector.CohereChatRole, error) {
+	value, ok := ChatRoleMap[role]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown message role {%s}", role)
+	}
+
+	return &value, nil
+}
+
 func CreatePromptRequest(
 	requestConfiguration *dto.RequestConfigurationDTO,
 	templateVariables map[string]string,
+	conversationHistory []dto.ConversationMessageDTO,
 ) (*cohereconnector.CoherePromptRequest, error) {
 	model, modelErr := GetModelType(requestConfiguration.PromptConfigData.ModelType)
 	if modelErr != nil {
@@ -114,5 +169,40 @@ func CreatePromptRequest(
 	}
 	promptRequest.Message = message
 
+	// the Cohere generate API has no JSON mode, so the model is only instructed to follow the response schema
+	if responseSchema := requestConfiguration.PromptConfigData.ResponseSchema; responseSchema != nil {
+		promptRequest.Message += "\n\n" + utils.CreateResponseSchemaInstruction(*responseSchema)
+	}
+
+	// tools are not supported by the Cohere generate API, so tool messages cannot be forwarded
+	for _, conversationMessage := range conversationHistory {
+		if conversationMessage.Role == models.ConversationMessageRoleTool ||
+			len(conversationMessage.ToolCalls) > 0 {
+			return nil, status.Error(
+				codes.InvalidArgument,
+				"tool calls are not supported by Cohere models",
+			)
+		}
+
+		chatRole, roleErr := GetChatRole(conversationMessage.Role)
+		if roleErr != nil {
+			return nil, roleErr
+		}
+		promptRequest.ChatHistory = append(promptRequest.ChatHistory, &cohereconnector.CohereChatMessage{
+			Role:    *chatRole,
+			Message: conversationMessage.Content,
+		})
+	}
+
 	return promptRequest, nil
 }
+
+// GetRequestPromptString returns the text of the chat history and message of a prompt request.
+func GetRequestPromptString(promptRequest *cohereconnector.CoherePromptRequest) string {
+	promptMessages := make([]string, 0, len(promptRequest.ChatHistory)+1)
+	for _, chatMessage := range promptRequest.ChatHistory {
+		promptMessages = append(promptMessages, chatMessage.Message)
+	}
+
+	return strings.Join(append(promptMessages, promptRequest.Message), "\n")
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/basemind-ai/monorepo/shared/go/db/models"
 	"github.com/basemind-ai/monorepo/shared/go/ptr"
 	"github.com/basemind-ai/monorepo/shared/go/serialization"
+	"strings"
 	"testing"
 
 	"github.com/basemind-ai/monorepo/e2e/factories"
@@ -38,6 +39,48 @@ func TestUtils(t *testing.T) {
 		})
 	})
 
+	t.Run("GetEmbeddingModelType", func(t *testing.T) {
+		t.Run("returns the expected models", func(t *testing.T) {
+			for modelType, expected := range cohere.EmbeddingModelTypeMap {
+				result, err := cohere.GetEmbeddingModelType(modelType)
+
+				assert.NoError(t, err)
+				assert.Equal(t, expected, *result)
+			}
+		})
+		t.Run("returns an error for a completion model type", func(t *testing.T) {
+			modelType, err := cohere.GetEmbeddingModelType(models.ModelTypeCommand)
+			assert.Error(t, err)
+			assert.Nil(t, modelType)
+		})
+	})
+
+	t.Run("GetEmbeddingInputType", func(t *testing.T) {
+		t.Run("returns the expected input types", func(t *testing.T) {
+			for inputType, expected := range cohere.EmbeddingInputTypeMap {
+				result, err := cohere.GetEmbeddingInputType(ptr.To(inputType))
+
+				assert.NoError(t, err)
+				assert.Equal(t, expected, *result)
+			}
+		})
+		t.Run("defaults to the search document input type", func(t *testing.T) {
+			result, err := cohere.GetEmbeddingInputType(nil)
+
+			assert.NoError(t, err)
+			assert.Equal(
+				t,
+				cohereconnector.CohereEmbeddingInputType_COHERE_EMBEDDING_INPUT_TYPE_SEARCH_DOCUMENT,
+				*result,
+			)
+		})
+		t.Run("returns an error for an unknown input type", func(t *testing.T) {
+			result, err := cohere.GetEmbeddingInputType(ptr.To("unknown"))
+			assert.Error(t, err)
+			assert.Nil(t, result)
+		})
+	})
+
 	t.Run("CreatePromptRequest", func(t *testing.T) {
 		project, _ := factories.CreateProject(context.TODO())
 		application, _ := factories.CreateApplication(context.TODO(), project.ID)
@@ -90,12 +133,83 @@ func TestUtils(t *testing.T) {
 			promptRequest, err := cohere.CreatePromptRequest(
 				requestConfig,
 				templateVariables,
+				nil,
 			)
 			assert.NoError(t, err)
 
 			assert.Equal(t, expectedPromptRequest, promptRequest)
 		})
 
+		t.Run("appends the response schema instruction to the message", func(t *testing.T) {
+			copied := *requestConfig
+			copied.PromptConfigData.ResponseSchema = ptr.To(json.RawMessage(`{"type":"object"}`))
+
+			promptRequest, err := cohere.CreatePromptRequest(&copied, templateVariables, nil)
+			assert.NoError(t, err)
+
+			assert.True(t, strings.HasPrefix(promptRequest.Message, expectedPromptMessage))
+			assert.Contains(t, promptRequest.Message, `{"type":"object"}`)
+		})
+
+		t.Run("maps the conversation history into the chat history", func(t *testing.T) {
+			promptRequest, err := cohere.CreatePromptRequest(
+				requestConfig,
+				templateVariables,
+				[]dto.ConversationMessageDTO{
+					{Role: models.ConversationMessageRoleSystem, Content: "Be concise."},
+					{Role: models.ConversationMessageRoleUser, Content: "What is cheese?"},
+					{Role: models.ConversationMessageRoleAssistant, Content: "A dairy product."},
+				},
+			)
+			assert.NoError(t, err)
+
+			assert.Equal(t, expectedPromptMessage, promptRequest.Message)
+			assert.Equal(t, []*cohereconnector.CohereChatMessage{
+				{
+					Role:    cohereconnector.CohereChatRole_COHERE_CHAT_ROLE_SYSTEM,
+					Message: "Be concise.",
+				},
+				{
+					Role:    cohereconnector.CohereChatRole_COHERE_CHAT_ROLE_USER,
+					Message: "What is cheese?",
+				},
+				{
+					Role:    cohereconnector.CohereChatRole_COHERE_CHAT_ROLE_CHATBOT,
+					Message: "A dairy product.",
+				},
+			}, promptRequest.ChatHistory)
+		})
+
+		t.Run("returns error for unknown conversation role", func(t *testing.T) {
+			_, err := cohere.CreatePromptRequest(
+				requestConfig,
+				templateVariables,
+				[]dto.ConversationMessageDTO{{Role: "unknown", Content: "What is cheese?"}},
+			)
+			assert.Error(t, err)
+		})
+
+		t.Run("returns error for tool messages in the conversation history", func(t *testing.T) {
+			for _, message := range []dto.ConversationMessageDTO{
+				{
+					Role:       models.ConversationMessageRoleTool,
+					Content:    `{"temperature":20}`,
+					ToolCallID: ptr.To("call-1"),
+				},
+				{
+					Role:      models.ConversationMessageRoleAssistant,
+					ToolCalls: []dto.ToolCallDTO{{ID: "call-1", Name: "get_weather"}},
+				},
+			} {
+				_, err := cohere.CreatePromptRequest(
+					requestConfig,
+					templateVariables,
+					[]dto.ConversationMessageDTO{message},
+				)
+				assert.Error(t, err)
+			}
+		})
+
 		t.Run("returns error for unknown model type", func(t *testing.T) {
 			modelType := "unknown"
 			modelParameters := []byte(`{}`)
@@ -110,6 +224,7 @@ func TestUtils(t *testing.T) {
 			_, err := cohere.CreatePromptRequest(
 				&copied,
 				templateVariables,
+				nil,
 			)
 			assert.Error(t, err)
 
@@ -131,6 +246,7 @@ func TestUtils(t *testing.T) {
 			_, err := cohere.CreatePromptRequest(
 				&copied,
 				templateVariables,
+				nil,
 			)
 			assert.Error(t, err)
 		})
@@ -151,6 +267,7 @@ func TestUtils(t *testing.T) {
 			_, err := cohere.CreatePromptRequest(
 				&copied,
 				templateVariables,
+				nil,
 			)
 			assert.Error(t, err)
 		})
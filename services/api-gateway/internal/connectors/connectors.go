@@ -4,62 +4,128 @@ import (
 	"context"
 	"github.com/basemind-ai/monorepo/services/api-gateway/internal/connectors/cohere"
 	"github.com/basemind-ai/monorepo/services/api-gateway/internal/connectors/openai"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/connectors/openaicompat"
 	"github.com/basemind-ai/monorepo/services/api-gateway/internal/dto"
 	"github.com/basemind-ai/monorepo/shared/go/db/models"
-	"github.com/basemind-ai/monorepo/shared/go/exc"
-	"github.com/sethvargo/go-envconfig"
+	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"os"
+	"sync"
 )
 
-var (
-	openaiConnectorClient *openai.Client
-	cohereConnectorClient *cohere.Client
-)
-
-type connectorConfig struct {
-	OpenAIConnectorAddress string `env:"OPENAI_CONNECTOR_ADDRESS,required"`
-	CohereConnectorAddress string `env:"COHERE_CONNECTOR_ADDRESS,required"`
-}
-
 // ProviderConnector - an interface that must be implemented by all connectors.
 type ProviderConnector interface {
 	RequestPrompt(
 		ctx context.Context,
 		requestConfiguration *dto.RequestConfigurationDTO,
 		templateVariables map[string]string,
+		conversationHistory []dto.ConversationMessageDTO,
 	) dto.PromptResultDTO
 	RequestStream(
 		ctx context.Context,
 		requestConfiguration *dto.RequestConfigurationDTO,
 		templateVariables map[string]string,
+		conversationHistory []dto.ConversationMessageDTO,
 		channel chan<- dto.PromptResultDTO,
 	)
+	RequestEmbeddings(
+		ctx context.Context,
+		embeddingsConfiguration *dto.EmbeddingsConfigurationDTO,
+		inputs []string,
+	) dto.EmbeddingsResultDTO
 }
 
-// Init - initializes the connectors. This function is called once.
-func Init(ctx context.Context, opts ...grpc.DialOption) {
-	config := &connectorConfig{}
-	exc.Must(envconfig.Process(ctx, config), "failed to process environment variables")
+// Factory - a function that creates a ProviderConnector for the given address.
+type Factory func(address string, opts ...grpc.DialOption) ProviderConnector
 
-	openaiConnectorClient = openai.New(config.OpenAIConnectorAddress, opts...)
-	cohereConnectorClient = cohere.New(config.CohereConnectorAddress, opts...)
+// Registration - the registration of a connector for a given model vendor.
+type Registration struct {
+	// AddressEnvVar is the name of the environment variable holding the connector address.
+	AddressEnvVar string
+	// Factory is the function used to create the connector.
+	Factory Factory
+}
+
+var (
+	mutex         sync.RWMutex
+	registrations = map[models.ModelVendor]Registration{}
+	registry      = map[models.ModelVendor]ProviderConnector{}
+)
+
+func init() {
+	Register(models.ModelVendorOPENAI, Registration{
+		AddressEnvVar: "OPENAI_CONNECTOR_ADDRESS",
+		Factory: func(address string, opts ...grpc.DialOption) ProviderConnector {
+			// an HTTP(S) address points to an OpenAI compatible API, which is called directly
+			if openaicompat.IsHTTPAddress(address) {
+				return openai.NewFromServiceClient(
+					openaicompat.NewFromEnv(context.Background(), address),
+				)
+			}
+			return openai.New(address, opts...)
+		},
+	})
+	Register(models.ModelVendorCOHERE, Registration{
+		AddressEnvVar: "COHERE_CONNECTOR_ADDRESS",
+		Factory: func(address string, opts ...grpc.DialOption) ProviderConnector {
+			return cohere.New(address, opts...)
+		},
+	})
+}
+
+// Register - registers a connector for the given model vendor.
+// The connector is created when Init is called, if its address environment variable is set.
+// Registering a vendor twice replaces the previous registration.
+func Register(vendor models.ModelVendor, registration Registration) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	registrations[vendor] = registration
+}
+
+// Init - initializes the registered connectors. This function is called once.
+// Connectors whose address environment variable is not set are skipped.
+func Init(_ context.Context, opts ...grpc.DialOption) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	registry = make(map[models.ModelVendor]ProviderConnector, len(registrations))
+
+	for vendor, registration := range registrations {
+		address, isSet := os.LookupEnv(registration.AddressEnvVar)
+		if !isSet {
+			log.Warn().
+				Str("vendor", string(vendor)).
+				Str("envVar", registration.AddressEnvVar).
+				Msg("connector address is not set, skipping connector initialization")
+			continue
+		}
+
+		registry[vendor] = registration.Factory(address, opts...)
+	}
 }
 
 // GetProviderConnector - returns the connector for the given provider.
-// Panics if the provider is not supported.
-func GetProviderConnector(provider models.ModelVendor) ProviderConnector {
-	switch provider {
-	case models.ModelVendorOPENAI:
-		return exc.ReturnNotNil(
-			openaiConnectorClient,
-			"OpenAI-Connector client was not initialized",
-		)
-	case models.ModelVendorCOHERE:
-		return exc.ReturnNotNil(
-			cohereConnectorClient,
-			"Cohere-Connector client was not initialized",
+// Returns an InvalidArgument status error if the provider is not registered,
+// and an Unimplemented status error if the provider connector is not initialized.
+func GetProviderConnector(provider models.ModelVendor) (ProviderConnector, error) {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	if _, isRegistered := registrations[provider]; !isRegistered {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown model vendor {%s}", provider)
+	}
+
+	connector, isInitialized := registry[provider]
+	if !isInitialized {
+		return nil, status.Errorf(
+			codes.Unimplemented,
+			"connector for model vendor {%s} is not configured",
+			provider,
 		)
-	default:
-		panic("unknown provider")
 	}
+
+	return connector, nil
 }
@@ -3,45 +3,121 @@ package connectors_test
 import (
 	"context"
 	"github.com/basemind-ai/monorepo/services/api-gateway/internal/connectors"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/dto"
 	"github.com/basemind-ai/monorepo/shared/go/db/models"
 	"github.com/basemind-ai/monorepo/shared/go/testutils"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"testing"
 )
 
-func TestGetOpenAIConnectorClientPanicsWithoutAddress(t *testing.T) {
+type mockConnector struct {
+	Address string
+}
+
+func (mockConnector) RequestPrompt(
+	_ context.Context,
+	_ *dto.RequestConfigurationDTO,
+	_ map[string]string,
+	_ []dto.ConversationMessageDTO,
+) dto.PromptResultDTO {
+	return dto.PromptResultDTO{}
+}
+
+func (mockConnector) RequestStream(
+	_ context.Context,
+	_ *dto.RequestConfigurationDTO,
+	_ map[string]string,
+	_ []dto.ConversationMessageDTO,
+	channel chan<- dto.PromptResultDTO,
+) {
+	close(channel)
+}
+
+func (mockConnector) RequestEmbeddings(
+	_ context.Context,
+	_ *dto.EmbeddingsConfigurationDTO,
+	_ []string,
+) dto.EmbeddingsResultDTO {
+	return dto.EmbeddingsResultDTO{}
+}
+
+func TestConnectors(t *testing.T) {
 	t.Run("GetProviderConnector", func(t *testing.T) {
-		t.Run("panics when not initialized", func(t *testing.T) {
+		t.Run("returns an Unimplemented error when not initialized", func(t *testing.T) {
 			testutils.UnsetTestEnv(t)
 
-			assert.Panics(t, func() { connectors.GetProviderConnector(models.ModelVendorOPENAI) })
+			connectors.Init(context.TODO())
+
+			_, err := connectors.GetProviderConnector(models.ModelVendorOPENAI)
+			assert.Equal(t, codes.Unimplemented, status.Code(err))
 		})
 
-		t.Run("does not panic when initialized", func(t *testing.T) {
+		t.Run("returns the connector when initialized", func(t *testing.T) {
 			t.Setenv("OPENAI_CONNECTOR_ADDRESS", "localhost:50051")
 			t.Setenv("COHERE_CONNECTOR_ADDRESS", "localhost:50052")
 
-			connectors.Init(
-				context.TODO(),
-			)
-			assert.NotPanics(
-				t,
-				func() { connectors.GetProviderConnector(models.ModelVendorOPENAI) },
-			)
+			connectors.Init(context.TODO())
+
+			for _, vendor := range []models.ModelVendor{models.ModelVendorOPENAI, models.ModelVendorCOHERE} {
+				connector, err := connectors.GetProviderConnector(vendor)
+				assert.NoError(t, err)
+				assert.NotNil(t, connector)
+			}
+		})
+
+		t.Run("initializes only the connectors whose address is set", func(t *testing.T) {
+			t.Setenv("OPENAI_CONNECTOR_ADDRESS", "localhost:50051")
+
+			connectors.Init(context.TODO())
+
+			_, openaiErr := connectors.GetProviderConnector(models.ModelVendorOPENAI)
+			assert.NoError(t, openaiErr)
+
+			_, cohereErr := connectors.GetProviderConnector(models.ModelVendorCOHERE)
+			assert.Equal(t, codes.Unimplemented, status.Code(cohereErr))
+		})
+
+		t.Run("initializes the OpenAI connector for an HTTP address", func(t *testing.T) {
+			t.Setenv("OPENAI_CONNECTOR_ADDRESS", "http://localhost:11434/v1")
+
+			connectors.Init(context.TODO())
+
+			connector, err := connectors.GetProviderConnector(models.ModelVendorOPENAI)
+			assert.NoError(t, err)
+			assert.NotNil(t, connector)
 		})
 
-		t.Run("panics for unknown provider", func(t *testing.T) {
+		t.Run("returns an InvalidArgument error for an unknown provider", func(t *testing.T) {
 			t.Setenv("OPENAI_CONNECTOR_ADDRESS", "localhost:50051")
 			t.Setenv("COHERE_CONNECTOR_ADDRESS", "localhost:50052")
 
-			connectors.Init(
-				context.TODO(),
-			)
+			connectors.Init(context.TODO())
+
+			_, err := connectors.GetProviderConnector(models.ModelVendor("unknown"))
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	})
+
+	t.Run("Register", func(t *testing.T) {
+		t.Run("registers a connector for a new vendor", func(t *testing.T) {
+			vendor := models.ModelVendor("LOCAL")
+			t.Setenv("LOCAL_CONNECTOR_ADDRESS", "localhost:50053")
+
+			connectors.Register(vendor, connectors.Registration{
+				AddressEnvVar: "LOCAL_CONNECTOR_ADDRESS",
+				Factory: func(address string, _ ...grpc.DialOption) connectors.ProviderConnector {
+					return mockConnector{Address: address}
+				},
+			})
+
+			connectors.Init(context.TODO())
 
-			assert.Panics(
-				t,
-				func() { connectors.GetProviderConnector(models.ModelVendor("unknown")) },
-			)
+			connector, err := connectors.GetProviderConnector(vendor)
+			assert.NoError(t, err)
+			assert.Equal(t, mockConnector{Address: "localhost:50053"}, connector)
 		})
 	})
 }
@@ -0,0 +1,106 @@
+package openai
+
+import (
+	"context"
+	openaiconnector "github.com/basemind-ai/monorepo/gen/go/openai/v1"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/dto"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/utils"
+	"github.com/basemind-ai/monorepo/shared/go/datatypes"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/exc"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+	"time"
+)
+
+// CreateEmbeddingsRequest creates the OpenAI connector embeddings request for the given inputs.
+func CreateEmbeddingsRequest(
+	embeddingsConfiguration *dto.EmbeddingsConfigurationDTO,
+	inputs []string,
+) (*openaiconnector.OpenAIEmbeddingsRequest, error) {
+	model, modelErr := GetEmbeddingModelType(embeddingsConfiguration.ModelType)
+	if modelErr != nil {
+		return nil, modelErr
+	}
+
+	applicationID := db.UUIDToString(&embeddingsConfiguration.ApplicationID)
+
+	return &openaiconnector.OpenAIEmbeddingsRequest{
+		Model:         *model,
+		Inputs:        inputs,
+		Dimensions:    embeddingsConfiguration.Dimensions,
+		ApplicationId: &applicationID,
+	}, nil
+}
+
+// RequestEmbeddings sends an embeddings request to the OpenAI API connector.
+// The input tokens are billed at the input token price of the model.
+func (c *Client) RequestEmbeddings(
+	ctx context.Context,
+	embeddingsConfiguration *dto.EmbeddingsConfigurationDTO,
+	inputs []string,
+) dto.EmbeddingsResultDTO {
+	embeddingsRequest, createRequestErr := CreateEmbeddingsRequest(embeddingsConfiguration, inputs)
+	if createRequestErr != nil {
+		return dto.EmbeddingsResultDTO{Error: createRequestErr}
+	}
+
+	modelPricingID := exc.MustResult(db.StringToUUID(embeddingsConfiguration.ProviderModelPricing.ID))
+
+	recordParams := models.CreatePromptRequestRecordParams{
+		ApplicationID:          embeddingsConfiguration.ApplicationID,
+		IsStreamResponse:       false,
+		StartTime:              pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		ProviderModelPricingID: *modelPricingID,
+		ResponseTokensCost:     *exc.MustResult(db.StringToNumeric("0")),
+	}
+	embeddingsResult := dto.EmbeddingsResultDTO{}
+
+	response, attempts, requestErr := utils.CallWithRetry(
+		ctx,
+		c.retryPolicy,
+		func(ctx context.Context) (*openaiconnector.OpenAIEmbeddingsResponse, error) {
+			return c.client.OpenAIEmbeddings(ctx, embeddingsRequest)
+		},
+	)
+	recordParams.Attempts = int32(attempts)
+	utils.SetRecordFinishTime(&recordParams)
+
+	if requestErr == nil {
+		embeddingsResult.Embeddings = make([][]float32, len(response.Embeddings))
+		for i, embedding := range response.Embeddings {
+			embeddingsResult.Embeddings[i] = embedding.Values
+		}
+
+		recordParams.FinishReason = models.PromptFinishReasonDONE
+		recordParams.RequestTokens = int32(response.RequestTokensCount)
+
+		costs := utils.CalculateCosts(
+			utils.TokenUsage{datatypes.TokenClassInput: recordParams.RequestTokens},
+			embeddingsConfiguration.ProviderModelPricing,
+		)
+		recordParams.RequestTokensCost = *exc.MustResult(db.StringToNumeric(costs.RequestTokenCost().String()))
+	} else {
+		log.Debug().Err(requestErr).Msg("request error")
+		embeddingsResult.Error = requestErr
+		recordParams.ErrorLog = pgtype.Text{String: requestErr.Error(), Valid: true}
+		recordParams.FinishReason = models.PromptFinishReasonERROR
+		recordParams.RequestTokensCost = *exc.MustResult(db.StringToNumeric("0"))
+	}
+
+	requestRecord, createRequestRecordErr := db.
+		GetQueries().
+		CreatePromptRequestRecord(
+			ctx,
+			recordParams,
+		)
+
+	if embeddingsResult.Error == nil {
+		embeddingsResult.Error = createRequestRecordErr
+	}
+
+	embeddingsResult.RequestRecord = &requestRecord
+
+	return embeddingsResult
+}
@@ -0,0 +1,99 @@
+package openai_test
+
+import (
+	"context"
+	"github.com/basemind-ai/monorepo/e2e/factories"
+	openaiconnector "github.com/basemind-ai/monorepo/gen/go/openai/v1"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/dto"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/services"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/exc"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRequestEmbeddings(t *testing.T) {
+	_ = factories.CreateProviderPricingModels(context.TODO())
+	project, _ := factories.CreateProject(context.TODO())
+	application, _ := factories.CreateApplication(context.TODO(), project.ID)
+
+	applicationID := db.UUIDToString(&application.ID)
+
+	modelPricing, _ := services.RetrieveProviderModelPricing(
+		context.TODO(),
+		models.ModelTypeTextEmbedding3Small,
+		models.ModelVendorOPENAI,
+		application.ProjectID,
+	)
+
+	embeddingsConfigurationDTO := &dto.EmbeddingsConfigurationDTO{
+		ApplicationID:        application.ID,
+		ModelVendor:          models.ModelVendorOPENAI,
+		ModelType:            models.ModelTypeTextEmbedding3Small,
+		Dimensions:           ptr.To(uint32(2)),
+		ProviderModelPricing: modelPricing,
+	}
+
+	inputs := []string{"The meaning of life", "is 42"}
+
+	t.Run("returns the embeddings", func(t *testing.T) {
+		client, mockService := CreateClientAndService(t)
+
+		mockService.ExpectedEmbeddingsRequest = &openaiconnector.OpenAIEmbeddingsRequest{
+			Model:         openaiconnector.OpenAIEmbeddingModel_OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_3_SMALL,
+			Inputs:        inputs,
+			Dimensions:    ptr.To(uint32(2)),
+			ApplicationId: &applicationID,
+		}
+		mockService.EmbeddingsResponse = &openaiconnector.OpenAIEmbeddingsResponse{
+			Embeddings: []*openaiconnector.OpenAIEmbedding{
+				{Values: []float32{0.1, 0.2}},
+				{Values: []float32{0.3, 0.4}},
+			},
+			RequestTokensCount: 50,
+		}
+
+		result := client.RequestEmbeddings(context.TODO(), embeddingsConfigurationDTO, inputs)
+		assert.NoError(t, result.Error)
+		assert.Equal(t, [][]float32{{0.1, 0.2}, {0.3, 0.4}}, result.Embeddings)
+
+		assert.NotNil(t, result.RequestRecord)
+		assert.Equal(t, application.ID, result.RequestRecord.ApplicationID)
+		assert.False(t, result.RequestRecord.PromptConfigID.Valid)
+		assert.Equal(t, int32(50), result.RequestRecord.RequestTokens)
+		assert.Equal(t, int32(0), result.RequestRecord.ResponseTokens)
+		// 50 tokens at 0.02 per 1,000,000 tokens
+		assert.Equal(
+			t,
+			"0.000001",
+			exc.MustResult(db.NumericToDecimal(result.RequestRecord.RequestTokensCost)).String(),
+		)
+		assert.True(
+			t,
+			exc.MustResult(db.NumericToDecimal(result.RequestRecord.ResponseTokensCost)).IsZero(),
+		)
+	})
+
+	t.Run("returns an error if the request fails", func(t *testing.T) {
+		client, mockService := CreateClientAndService(t)
+		mockService.Error = assert.AnError
+
+		result := client.RequestEmbeddings(context.TODO(), embeddingsConfigurationDTO, inputs)
+		assert.Error(t, result.Error)
+		assert.NotNil(t, result.RequestRecord)
+		assert.Equal(t, models.PromptFinishReasonERROR, result.RequestRecord.FinishReason)
+	})
+
+	t.Run("returns an error for a completion model", func(t *testing.T) {
+		client, _ := CreateClientAndService(t)
+
+		configuration := *embeddingsConfigurationDTO
+		configuration.ModelType = models.ModelTypeGpt4
+
+		result := client.RequestEmbeddings(context.TODO(), &configuration, inputs)
+		assert.Error(t, result.Error)
+		assert.Nil(t, result.RequestRecord)
+	})
+}
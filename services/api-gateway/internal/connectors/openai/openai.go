@@ -1,16 +1,23 @@
 package openai
 
 import (
+	"context"
 	openaiconnector "github.com/basemind-ai/monorepo/gen/go/openai/v1"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/utils"
 	"github.com/basemind-ai/monorepo/shared/go/exc"
 	"github.com/basemind-ai/monorepo/shared/go/grpcutils"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 )
 
+// retryPolicyEnvPrefix is the prefix of the environment variables configuring the retry policy,
+// e.g. OPENAI_CONNECTOR_TIMEOUT.
+const retryPolicyEnvPrefix = "OPENAI_CONNECTOR_"
+
 // Client implements the OpenAI connector gRPC client.
 type Client struct {
-	client openaiconnector.OpenAIServiceClient
+	client      openaiconnector.OpenAIServiceClient
+	retryPolicy utils.RetryPolicy
 }
 
 // New creates a new OpenAI connector client.
@@ -18,5 +25,17 @@ func New(serverAddress string, opts ...grpc.DialOption) *Client {
 	conn := exc.MustResult(grpcutils.NewConnection(serverAddress, opts...))
 	log.Info().Msg("initialized OpenAI connector connection")
 
-	return &Client{client: openaiconnector.NewOpenAIServiceClient(conn)}
+	return &Client{
+		client:      openaiconnector.NewOpenAIServiceClient(conn),
+		retryPolicy: utils.RetryPolicyFromEnv(context.Background(), retryPolicyEnvPrefix),
+	}
+}
+
+// NewFromServiceClient creates a new OpenAI connector client using the given service client.
+// This allows using an in-process implementation of the OpenAI service instead of a gRPC connection.
+func NewFromServiceClient(serviceClient openaiconnector.OpenAIServiceClient) *Client {
+	return &Client{
+		client:      serviceClient,
+		retryPolicy: utils.RetryPolicyFromEnv(context.Background(), retryPolicyEnvPrefix),
+	}
 }
@@ -2,8 +2,10 @@ package openai
 
 import (
 	"context"
+	openaiconnector "github.com/basemind-ai/monorepo/gen/go/openai/v1"
 	"github.com/basemind-ai/monorepo/services/api-gateway/internal/dto"
 	"github.com/basemind-ai/monorepo/services/api-gateway/internal/utils"
+	"github.com/basemind-ai/monorepo/shared/go/datatypes"
 	"github.com/basemind-ai/monorepo/shared/go/db"
 	"github.com/basemind-ai/monorepo/shared/go/db/models"
 	"github.com/basemind-ai/monorepo/shared/go/exc"
@@ -17,10 +19,12 @@ func (c *Client) RequestPrompt(
 	ctx context.Context,
 	requestConfiguration *dto.RequestConfigurationDTO,
 	templateVariables map[string]string,
+	conversationHistory []dto.ConversationMessageDTO,
 ) dto.PromptResultDTO {
 	promptRequest, createPromptRequestErr := CreatePromptRequest(
 		requestConfiguration,
 		templateVariables,
+		conversationHistory,
 	)
 	if createPromptRequestErr != nil {
 		return dto.PromptResultDTO{Error: createPromptRequestErr}
@@ -30,33 +34,48 @@ func (c *Client) RequestPrompt(
 
 	recordParams := models.CreatePromptRequestRecordParams{
 		PromptConfigID:         requestConfiguration.PromptConfigID,
+		PromptConfigVersionID:  requestConfiguration.PromptConfigVersionID,
+		IsTrafficSplit:         requestConfiguration.IsTrafficSplit,
 		IsStreamResponse:       false,
 		StartTime:              pgtype.Timestamptz{Time: time.Now(), Valid: true},
 		ProviderModelPricingID: *modelPricingID,
 	}
 	promptResult := dto.PromptResultDTO{}
 
-	response, requestErr := c.client.OpenAIPrompt(ctx, promptRequest)
-	recordParams.FinishTime = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	response, attempts, requestErr := utils.CallWithRetry(
+		ctx,
+		c.retryPolicy,
+		func(ctx context.Context) (*openaiconnector.OpenAIPromptResponse, error) {
+			return c.client.OpenAIPrompt(ctx, promptRequest)
+		},
+	)
+	recordParams.Attempts = int32(attempts)
+	utils.SetRecordFinishTime(&recordParams)
 
 	if requestErr == nil {
 		promptResult.Content = &response.Content
+		promptResult.ToolCalls = ParseToolCalls(response.ToolCalls)
 		recordParams.FinishReason = models.PromptFinishReason(response.FinishReason)
 
 		recordParams.RequestTokens = int32(response.RequestTokensCount)
 		recordParams.ResponseTokens = int32(response.ResponseTokensCount)
 
 		costs := utils.CalculateCosts(
-			recordParams.RequestTokens,
-			recordParams.ResponseTokens,
+			utils.TokenUsage{
+				datatypes.TokenClassInput:  recordParams.RequestTokens,
+				datatypes.TokenClassOutput: recordParams.ResponseTokens,
+			},
 			requestConfiguration.ProviderModelPricing,
 		)
-		recordParams.RequestTokensCost = *exc.MustResult(db.StringToNumeric(costs.RequestTokenCost.String()))
-		recordParams.ResponseTokensCost = *exc.MustResult(db.StringToNumeric(costs.RequestTokenCost.String()))
+		recordParams.RequestTokensCost = *exc.MustResult(db.StringToNumeric(costs.RequestTokenCost().String()))
+		recordParams.ResponseTokensCost = *exc.MustResult(db.StringToNumeric(costs.ResponseTokenCost().String()))
 	} else {
 		log.Debug().Err(requestErr).Msg("request error")
 		promptResult.Error = requestErr
 		recordParams.ErrorLog = pgtype.Text{String: requestErr.Error(), Valid: true}
+		recordParams.FinishReason = models.PromptFinishReasonERROR
+		recordParams.RequestTokensCost = *exc.MustResult(db.StringToNumeric("0"))
+		recordParams.ResponseTokensCost = *exc.MustResult(db.StringToNumeric("0"))
 	}
 
 	requestRecord, createRequestRecordErr := db.
@@ -9,6 +9,7 @@ import (
 	"github.com/basemind-ai/monorepo/services/api-gateway/internal/services"
 	"github.com/basemind-ai/monorepo/shared/go/datatypes"
 	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/exc"
 	"github.com/basemind-ai/monorepo/shared/go/ptr"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -26,10 +27,11 @@ func TestRequestPrompt(t *testing.T) {
 
 	applicationID := db.UUIDToString(&application.ID)
 
-	modelPricing := services.RetrieveProviderModelPricing(
+	modelPricing, _ := services.RetrieveProviderModelPricing(
 		context.TODO(),
 		promptConfig.ModelType,
 		promptConfig.ModelVendor,
+		application.ProjectID,
 	)
 
 	requestConfigurationDTO := &dto.RequestConfigurationDTO{
@@ -96,10 +98,23 @@ func TestRequestPrompt(t *testing.T) {
 			context.TODO(),
 			requestConfigurationDTO,
 			templateVariables,
+			nil,
 		)
 		assert.NoError(t, result.Error)
 		assert.Equal(t, "Response content", *result.Content)
 		assert.NotNil(t, result.RequestRecord)
+		assert.Equal(
+			t,
+			"0.000003",
+			exc.MustResult(db.NumericToDecimal(result.RequestRecord.RequestTokensCost)).String(),
+		)
+		assert.Equal(
+			t,
+			"0.000004",
+			exc.MustResult(db.NumericToDecimal(result.RequestRecord.ResponseTokensCost)).String(),
+		)
+		assert.True(t, result.RequestRecord.DurationMs.Valid)
+		assert.GreaterOrEqual(t, result.RequestRecord.DurationMs.Int32, int32(0))
 	})
 
 	t.Run("returns an error if the request fails", func(t *testing.T) {
@@ -111,6 +126,7 @@ func TestRequestPrompt(t *testing.T) {
 			context.TODO(),
 			requestConfigurationDTO,
 			templateVariables,
+			nil,
 		)
 		assert.Error(t, result.Error)
 	})
@@ -122,6 +138,7 @@ func TestRequestPrompt(t *testing.T) {
 			context.TODO(),
 			requestConfigurationDTO,
 			map[string]string{},
+			nil,
 		)
 		assert.Errorf(t, result.Error, "missing template variable {userInput}")
 	})
@@ -5,6 +5,7 @@ import (
 	openaiconnector "github.com/basemind-ai/monorepo/gen/go/openai/v1"
 	"github.com/basemind-ai/monorepo/services/api-gateway/internal/dto"
 	"github.com/basemind-ai/monorepo/services/api-gateway/internal/utils"
+	"github.com/basemind-ai/monorepo/shared/go/datatypes"
 	"github.com/basemind-ai/monorepo/shared/go/db"
 	"github.com/basemind-ai/monorepo/shared/go/db/models"
 	"github.com/basemind-ai/monorepo/shared/go/exc"
@@ -26,6 +27,7 @@ func parseMessage(msg *openaiconnector.OpenAIStreamResponse) *utils.StreamMessag
 		FinishReason:       msg.FinishReason,
 		RequestTokenCount:  msg.RequestTokensCount,
 		ResponseTokenCount: msg.ResponseTokensCount,
+		ToolCalls:          ParseToolCalls(msg.ToolCalls),
 	}
 }
 
@@ -33,11 +35,13 @@ func (c *Client) RequestStream(
 	ctx context.Context,
 	requestConfiguration *dto.RequestConfigurationDTO,
 	templateVariables map[string]string,
+	conversationHistory []dto.ConversationMessageDTO,
 	channel chan<- dto.PromptResultDTO,
 ) {
 	promptRequest, promptRequestErr := CreatePromptRequest(
 		requestConfiguration,
 		templateVariables,
+		conversationHistory,
 	)
 	if promptRequestErr != nil {
 		log.Error().Err(promptRequestErr).Msg("failed to create prompt request")
@@ -53,46 +57,72 @@ func (c *Client) RequestStream(
 
 	recordParams := &models.CreatePromptRequestRecordParams{
 		PromptConfigID:         requestConfiguration.PromptConfigID,
+		PromptConfigVersionID:  requestConfiguration.PromptConfigVersionID,
+		IsTrafficSplit:         requestConfiguration.IsTrafficSplit,
 		IsStreamResponse:       true,
 		StartTime:              pgtype.Timestamptz{Time: startTime, Valid: true},
 		ProviderModelPricingID: *modelPricingID,
 	}
 	finalResult := &dto.PromptResultDTO{}
 
-	stream, streamErr := c.client.OpenAIStream(ctx, promptRequest)
+	// the stream context is released once the stream has been consumed
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, attempts, streamErr := utils.StreamWithRetry(
+		streamCtx,
+		c.retryPolicy,
+		func(ctx context.Context) (utils.Stream[openaiconnector.OpenAIStreamResponse], error) {
+			return c.client.OpenAIStream(ctx, promptRequest)
+		},
+	)
+	recordParams.Attempts = int32(attempts)
 	finalResult.Error = streamErr
 
 	if finalResult.Error == nil {
 		streamFinish := utils.StreamFromClient[openaiconnector.OpenAIStreamResponse](
+			ctx,
 			channel,
 			finalResult,
 			recordParams,
-			startTime,
+			GetRequestPromptString(promptRequest.Messages),
 			stream,
 			parseMessage,
 		)
 
 		recordParams.FinishReason = streamFinish.FinishReason
+		finalResult.ToolCalls = streamFinish.ToolCalls
 
 		recordParams.RequestTokens = int32(streamFinish.RequestTokenCount)
 		recordParams.ResponseTokens = int32(streamFinish.ResponseTokenCount)
 
 		costs := utils.CalculateCosts(
-			recordParams.RequestTokens,
-			recordParams.ResponseTokens,
+			utils.TokenUsage{
+				datatypes.TokenClassInput:  recordParams.RequestTokens,
+				datatypes.TokenClassOutput: recordParams.ResponseTokens,
+			},
 			requestConfiguration.ProviderModelPricing,
 		)
-		recordParams.RequestTokensCost = *exc.MustResult(db.StringToNumeric(costs.RequestTokenCost.String()))
-		recordParams.ResponseTokensCost = *exc.MustResult(db.StringToNumeric(costs.RequestTokenCost.String()))
+		recordParams.RequestTokensCost = *exc.MustResult(db.StringToNumeric(costs.RequestTokenCost().String()))
+		recordParams.ResponseTokensCost = *exc.MustResult(db.StringToNumeric(costs.ResponseTokenCost().String()))
 	}
 
 	if finalResult.Error != nil {
 		recordParams.ErrorLog = pgtype.Text{String: finalResult.Error.Error(), Valid: true}
+		recordParams.FinishReason = models.PromptFinishReasonERROR
+
+		if !recordParams.RequestTokensCost.Valid {
+			// the stream failed before any tokens were counted
+			utils.SetRecordFinishTime(recordParams)
+			recordParams.RequestTokensCost = *exc.MustResult(db.StringToNumeric("0"))
+			recordParams.ResponseTokensCost = *exc.MustResult(db.StringToNumeric("0"))
+		}
 	}
 
+	// the record is created for cancelled streams as well, hence the context cancellation is not propagated
 	promptRecord := exc.MustResult(db.GetQueries().
 		CreatePromptRequestRecord(
-			ctx,
+			context.WithoutCancel(ctx),
 			*recordParams,
 		))
 
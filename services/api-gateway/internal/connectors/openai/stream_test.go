@@ -7,8 +7,10 @@ import (
 	openaiconnector "github.com/basemind-ai/monorepo/gen/go/openai/v1"
 	"github.com/basemind-ai/monorepo/services/api-gateway/internal/dto"
 	"github.com/basemind-ai/monorepo/services/api-gateway/internal/services"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/utils"
 	"github.com/basemind-ai/monorepo/shared/go/datatypes"
 	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
 	"github.com/basemind-ai/monorepo/shared/go/ptr"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -23,10 +25,11 @@ func TestRequestStream(t *testing.T) {
 		application.ID,
 	)
 
-	modelPricing := services.RetrieveProviderModelPricing(
+	modelPricing, _ := services.RetrieveProviderModelPricing(
 		context.TODO(),
 		promptConfig.ModelType,
 		promptConfig.ModelVendor,
+		application.ProjectID,
 	)
 
 	requestConfigurationDTO := &dto.RequestConfigurationDTO{
@@ -74,6 +77,7 @@ func TestRequestStream(t *testing.T) {
 				context.TODO(),
 				requestConfigurationDTO,
 				templateVariables,
+				nil,
 				channel,
 			)
 		}()
@@ -98,6 +102,59 @@ func TestRequestStream(t *testing.T) {
 		assert.Nil(t, chunks[3].Error)
 		assert.NotNil(t, chunks[3].RequestRecord)
 		assert.NotNil(t, chunks[3].RequestRecord.FinishReason)
+		assert.True(t, chunks[3].RequestRecord.DurationMs.Valid)
+		assert.GreaterOrEqual(t, chunks[3].RequestRecord.DurationMs.Int32, int32(0))
+	})
+
+	t.Run("records the streamed tokens when the request is cancelled", func(t *testing.T) {
+		client, mockService := CreateClientAndService(t)
+
+		channel := make(chan dto.PromptResultDTO)
+
+		finishReason := "DONE"
+		tokens := uint32(10)
+		mockService.Stream = []*openaiconnector.OpenAIStreamResponse{
+			{Content: "1"},
+			{Content: "2"},
+			{Content: "3"},
+			{
+				FinishReason:        &finishReason,
+				RequestTokensCount:  &tokens,
+				ResponseTokensCount: &tokens,
+			},
+		}
+
+		ctx, cancel := context.WithCancel(context.TODO())
+		defer cancel()
+
+		go func() {
+			client.RequestStream(
+				ctx,
+				requestConfigurationDTO,
+				templateVariables,
+				nil,
+				channel,
+			)
+		}()
+
+		assert.Equal(t, "1", *(<-channel).Content)
+		assert.Equal(t, "2", *(<-channel).Content)
+
+		// the client disconnects while the third chunk is being sent
+		cancel()
+
+		var result dto.PromptResultDTO
+
+		for value := range channel {
+			result = value
+		}
+
+		assert.NoError(t, result.Error)
+		assert.NotNil(t, result.RequestRecord)
+		assert.True(t, result.RequestRecord.ID.Valid)
+		assert.Equal(t, models.PromptFinishReasonCANCELLED, result.RequestRecord.FinishReason)
+		assert.Equal(t, int32(utils.EstimateTokenCount("123")), result.RequestRecord.ResponseTokens)
+		assert.Positive(t, result.RequestRecord.RequestTokens)
 	})
 
 	t.Run("returns an error if the request fails", func(t *testing.T) {
@@ -112,6 +169,7 @@ func TestRequestStream(t *testing.T) {
 				context.TODO(),
 				requestConfigurationDTO,
 				templateVariables,
+				nil,
 				channel,
 			)
 		}()
@@ -137,6 +195,7 @@ func TestRequestStream(t *testing.T) {
 				context.TODO(),
 				requestConfigurationDTO,
 				map[string]string{},
+				nil,
 				channel,
 			)
 		}()
@@ -33,6 +33,21 @@ func GetModelType(modelType models.ModelType) (*openaiconnector.OpenAIModel, err
 	return &value, nil
 }
 
+var EmbeddingModelTypeMap = map[models.ModelType]openaiconnector.OpenAIEmbeddingModel{
+	models.ModelTypeTextEmbedding3Small: openaiconnector.OpenAIEmbeddingModel_OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_3_SMALL,
+	models.ModelTypeTextEmbedding3Large: openaiconnector.OpenAIEmbeddingModel_OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_3_LARGE,
+	models.ModelTypeTextEmbeddingAda002: openaiconnector.OpenAIEmbeddingModel_OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_ADA_002,
+}
+
+func GetEmbeddingModelType(modelType models.ModelType) (*openaiconnector.OpenAIEmbeddingModel, error) {
+	value, ok := EmbeddingModelTypeMap[modelType]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown embedding model type {%s}", modelType)
+	}
+
+	return &value, nil
+}
+
 func GetMessageRole(role string) (*openaiconnector.OpenAIMessageRole, error) {
 	switch role {
 	case "system":
@@ -43,6 +58,8 @@ func GetMessageRole(role string) (*openaiconnector.OpenAIMessageRole, error) {
 		return openaiconnector.OpenAIMessageRole_OPEN_AI_MESSAGE_ROLE_ASSISTANT.Enum(), nil
 	case "function":
 		return openaiconnector.OpenAIMessageRole_OPEN_AI_MESSAGE_ROLE_FUNCTION.Enum(), nil
+	case "tool":
+		return openaiconnector.OpenAIMessageRole_OPEN_AI_MESSAGE_ROLE_TOOL.Enum(), nil
 	default:
 		return nil, status.Errorf(codes.InvalidArgument, "unknown message role {%s}", role)
 	}
@@ -84,9 +101,68 @@ func parseParameters(
 	return nil
 }
 
+// CreateTools maps the prompt config tools to connector tools.
+func CreateTools(tools []datatypes.ToolDTO) []*openaiconnector.OpenAITool {
+	if len(tools) == 0 {
+		return nil
+	}
+
+	openAITools := make([]*openaiconnector.OpenAITool, 0, len(tools))
+	for _, tool := range tools {
+		openAITool := &openaiconnector.OpenAITool{
+			Name:        tool.Name,
+			Description: tool.Description,
+		}
+		if tool.Parameters != nil {
+			openAITool.Parameters = ptr.To(string(*tool.Parameters))
+		}
+
+		openAITools = append(openAITools, openAITool)
+	}
+
+	return openAITools
+}
+
+// CreateToolCalls maps vendor neutral tool calls to connector tool calls.
+func CreateToolCalls(toolCalls []dto.ToolCallDTO) []*openaiconnector.OpenAIToolCall {
+	if len(toolCalls) == 0 {
+		return nil
+	}
+
+	openAIToolCalls := make([]*openaiconnector.OpenAIToolCall, 0, len(toolCalls))
+	for _, toolCall := range toolCalls {
+		openAIToolCalls = append(openAIToolCalls, &openaiconnector.OpenAIToolCall{
+			Id:        toolCall.ID,
+			Name:      toolCall.Name,
+			Arguments: toolCall.Arguments,
+		})
+	}
+
+	return openAIToolCalls
+}
+
+// ParseToolCalls maps connector tool calls to vendor neutral tool calls.
+func ParseToolCalls(openAIToolCalls []*openaiconnector.OpenAIToolCall) []dto.ToolCallDTO {
+	if len(openAIToolCalls) == 0 {
+		return nil
+	}
+
+	toolCalls := make([]dto.ToolCallDTO, 0, len(openAIToolCalls))
+	for _, openAIToolCall := range openAIToolCalls {
+		toolCalls = append(toolCalls, dto.ToolCallDTO{
+			ID:        openAIToolCall.Id,
+			Name:      openAIToolCall.Name,
+			Arguments: openAIToolCall.Arguments,
+		})
+	}
+
+	return toolCalls
+}
+
 func CreatePromptRequest(
 	requestConfiguration *dto.RequestConfigurationDTO,
 	templateVariables map[string]string,
+	conversationHistory []dto.ConversationMessageDTO,
 ) (*openaiconnector.OpenAIPromptRequest, error) {
 	model, modelErr := GetModelType(requestConfiguration.PromptConfigData.ModelType)
 	if modelErr != nil {
@@ -105,6 +181,7 @@ func CreatePromptRequest(
 		ApplicationId: &applicationIDString,
 		Parameters:    modelParameters,
 		Messages:      []*openaiconnector.OpenAIMessage{},
+		Tools:         CreateTools(requestConfiguration.PromptConfigData.Tools),
 	}
 
 	var openAIPromptMessageDTOs []*datatypes.OpenAIPromptMessageDTO
@@ -147,6 +224,28 @@ func CreatePromptRequest(
 		promptRequest.Messages = append(promptRequest.Messages, openAIMessage)
 	}
 
+	if responseSchema := requestConfiguration.PromptConfigData.ResponseSchema; responseSchema != nil {
+		promptRequest.JsonMode = ptr.To(true)
+		promptRequest.Messages = append(promptRequest.Messages, &openaiconnector.OpenAIMessage{
+			Role:    openaiconnector.OpenAIMessageRole_OPEN_AI_MESSAGE_ROLE_SYSTEM,
+			Content: ptr.To(utils.CreateResponseSchemaInstruction(*responseSchema)),
+		})
+	}
+
+	// the conversation history is appended after the configured prompt messages
+	for _, conversationMessage := range conversationHistory {
+		messageRole, roleErr := GetMessageRole(string(conversationMessage.Role))
+		if roleErr != nil {
+			return nil, roleErr
+		}
+		promptRequest.Messages = append(promptRequest.Messages, &openaiconnector.OpenAIMessage{
+			Role:       *messageRole,
+			Content:    &conversationMessage.Content,
+			ToolCallId: conversationMessage.ToolCallID,
+			ToolCalls:  CreateToolCalls(conversationMessage.ToolCalls),
+		})
+	}
+
 	return promptRequest, nil
 }
 
@@ -38,6 +38,22 @@ func TestUtils(t *testing.T) {
 		})
 	})
 
+	t.Run("GetEmbeddingModelType", func(t *testing.T) {
+		t.Run("returns the expected models", func(t *testing.T) {
+			for modelType, expected := range openai.EmbeddingModelTypeMap {
+				result, err := openai.GetEmbeddingModelType(modelType)
+
+				assert.NoError(t, err)
+				assert.Equal(t, expected, *result)
+			}
+		})
+		t.Run("returns an error for a completion model type", func(t *testing.T) {
+			modelType, err := openai.GetEmbeddingModelType(models.ModelTypeGpt4)
+			assert.Error(t, err)
+			assert.Nil(t, modelType)
+		})
+	})
+
 	t.Run("GetMessageRole", func(t *testing.T) {
 		testCases := []struct {
 			Role     string
@@ -58,6 +74,10 @@ func TestUtils(t *testing.T) {
 				Role:     "function",
 				Expected: openaiconnector.OpenAIMessageRole_OPEN_AI_MESSAGE_ROLE_FUNCTION,
 			},
+			{
+				Role:     "tool",
+				Expected: openaiconnector.OpenAIMessageRole_OPEN_AI_MESSAGE_ROLE_TOOL,
+			},
 		}
 		for _, testCase := range testCases {
 			t.Run(
@@ -138,12 +158,96 @@ func TestUtils(t *testing.T) {
 			promptRequest, err := openai.CreatePromptRequest(
 				requestConfig,
 				templateVariables,
+				nil,
 			)
 			assert.NoError(t, err)
 
 			assert.Equal(t, expectedPromptRequest, promptRequest)
 		})
 
+		t.Run("appends the conversation history after the prompt messages", func(t *testing.T) {
+			conversationHistory := []dto.ConversationMessageDTO{
+				{Role: models.ConversationMessageRoleUser, Content: "What is cheese?"},
+				{Role: models.ConversationMessageRoleAssistant, Content: "A dairy product."},
+				{Role: models.ConversationMessageRoleUser, Content: "Which kinds are there?"},
+			}
+
+			promptRequest, err := openai.CreatePromptRequest(
+				requestConfig,
+				templateVariables,
+				conversationHistory,
+			)
+			assert.NoError(t, err)
+
+			assert.Len(t, promptRequest.Messages, 5)
+			assert.Equal(t, &content, promptRequest.Messages[1].Content)
+			for i, message := range conversationHistory {
+				expectedRole, _ := openai.GetMessageRole(string(message.Role))
+				assert.Equal(t, *expectedRole, promptRequest.Messages[i+2].Role)
+				assert.Equal(t, message.Content, *promptRequest.Messages[i+2].Content)
+			}
+		})
+
+		t.Run("requests JSON mode and instructs the model to follow the response schema", func(t *testing.T) {
+			copied := *requestConfig
+			copied.PromptConfigData.ResponseSchema = ptr.To(json.RawMessage(`{"type":"object"}`))
+
+			promptRequest, err := openai.CreatePromptRequest(&copied, templateVariables, nil)
+			assert.NoError(t, err)
+
+			assert.True(t, promptRequest.GetJsonMode())
+			assert.Len(t, promptRequest.Messages, 3)
+			assert.Equal(
+				t,
+				openaiconnector.OpenAIMessageRole_OPEN_AI_MESSAGE_ROLE_SYSTEM,
+				promptRequest.Messages[2].Role,
+			)
+			assert.Contains(t, *promptRequest.Messages[2].Content, `{"type":"object"}`)
+		})
+
+		t.Run("sets the tools and maps the tool calls of the conversation history", func(t *testing.T) {
+			copied := *requestConfig
+			copied.PromptConfigData.Tools = []datatypes.ToolDTO{{
+				Name:        "get_weather",
+				Description: ptr.To("Returns the weather of a city"),
+				Parameters:  ptr.To(json.RawMessage(`{"type":"object"}`)),
+			}}
+
+			promptRequest, err := openai.CreatePromptRequest(
+				&copied,
+				templateVariables,
+				[]dto.ConversationMessageDTO{
+					{
+						Role: models.ConversationMessageRoleAssistant,
+						ToolCalls: []dto.ToolCallDTO{
+							{ID: "call-1", Name: "get_weather", Arguments: `{"city":"Paris"}`},
+						},
+					},
+					{
+						Role:       models.ConversationMessageRoleTool,
+						Content:    `{"temperature":20}`,
+						ToolCallID: ptr.To("call-1"),
+					},
+				},
+			)
+			assert.NoError(t, err)
+
+			assert.Equal(t, []*openaiconnector.OpenAITool{{
+				Name:        "get_weather",
+				Description: ptr.To("Returns the weather of a city"),
+				Parameters:  ptr.To(`{"type":"object"}`),
+			}}, promptRequest.Tools)
+			assert.Equal(t, []*openaiconnector.OpenAIToolCall{
+				{Id: "call-1", Name: "get_weather", Arguments: `{"city":"Paris"}`},
+			}, promptRequest.Messages[2].ToolCalls)
+			assert.Equal(
+				t,
+				openaiconnector.OpenAIMessageRole_OPEN_AI_MESSAGE_ROLE_TOOL,
+				promptRequest.Messages[3].Role,
+			)
+			assert.Equal(t, ptr.To("call-1"), promptRequest.Messages[3].ToolCallId)
+		})
+
 		t.Run("handles function message correctly", func(t *testing.T) {
 			functionName := "sum"
 			promptMessages := serialization.SerializeJSON([]*datatypes.OpenAIPromptMessageDTO{{
@@ -176,6 +280,7 @@ func TestUtils(t *testing.T) {
 			promptRequest, err := openai.CreatePromptRequest(
 				&copied,
 				templateVariables,
+				nil,
 			)
 			assert.NoError(t, err)
 
@@ -196,6 +301,7 @@ func TestUtils(t *testing.T) {
 			_, err := openai.CreatePromptRequest(
 				&copied,
 				templateVariables,
+				nil,
 			)
 			assert.Error(t, err)
 
@@ -217,6 +323,7 @@ func TestUtils(t *testing.T) {
 			_, err := openai.CreatePromptRequest(
 				&copied,
 				templateVariables,
+				nil,
 			)
 			assert.Error(t, err)
 		})
@@ -235,6 +342,7 @@ func TestUtils(t *testing.T) {
 			_, err := openai.CreatePromptRequest(
 				&copied,
 				templateVariables,
+				nil,
 			)
 			assert.Error(t, err)
 		})
@@ -255,11 +363,27 @@ func TestUtils(t *testing.T) {
 			_, err := openai.CreatePromptRequest(
 				&copied,
 				templateVariables,
+				nil,
 			)
 			assert.Error(t, err)
 		})
 	})
 
+	t.Run("ParseToolCalls", func(t *testing.T) {
+		t.Run("maps the connector tool calls", func(t *testing.T) {
+			toolCalls := openai.ParseToolCalls([]*openaiconnector.OpenAIToolCall{
+				{Id: "call-1", Name: "get_weather", Arguments: `{"city":"Paris"}`},
+			})
+			assert.Equal(t, []dto.ToolCallDTO{
+				{ID: "call-1", Name: "get_weather", Arguments: `{"city":"Paris"}`},
+			}, toolCalls)
+		})
+
+		t.Run("returns nil without tool calls", func(t *testing.T) {
+			assert.Nil(t, openai.ParseToolCalls(nil))
+		})
+	})
+
 	t.Run("GetRequestPromptString", func(t *testing.T) {
 		t.Run("returns the request prompt as string", func(t *testing.T) {
 			floatValue := float32(1)
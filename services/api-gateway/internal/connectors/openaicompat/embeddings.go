@@ -0,0 +1,67 @@
+package openaicompat
+
+import (
+	"context"
+	"encoding/json"
+	openaiconnector "github.com/basemind-ai/monorepo/gen/go/openai/v1"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+)
+
+// OpenAIEmbeddings sends an embeddings request.
+func (c *ServiceClient) OpenAIEmbeddings(
+	ctx context.Context,
+	request *openaiconnector.OpenAIEmbeddingsRequest,
+	_ ...grpc.CallOption,
+) (*openaiconnector.OpenAIEmbeddingsResponse, error) {
+	body, bodyErr := c.createEmbeddingsRequestBody(request)
+	if bodyErr != nil {
+		return nil, bodyErr
+	}
+
+	response, requestErr := c.doRequest(ctx, "embeddings", body, false)
+	if requestErr != nil {
+		return nil, requestErr
+	}
+
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	result := &embeddingsResponse{}
+	if decodeErr := json.NewDecoder(response.Body).Decode(result); decodeErr != nil {
+		return nil, status.Errorf(codes.Internal, "failed to decode response - %v", decodeErr)
+	}
+
+	if len(result.Data) != len(request.Inputs) {
+		return nil, status.Errorf(
+			codes.Internal,
+			"response contains %d embeddings for %d inputs",
+			len(result.Data),
+			len(request.Inputs),
+		)
+	}
+
+	// the embeddings are ordered by the index of their input, which the API does not guarantee
+	embeddings := make([]*openaiconnector.OpenAIEmbedding, len(result.Data))
+	for _, data := range result.Data {
+		if data.Index < 0 || data.Index >= len(embeddings) || embeddings[data.Index] != nil {
+			return nil, status.Errorf(codes.Internal, "response contains an invalid embedding index %d", data.Index)
+		}
+
+		embeddings[data.Index] = &openaiconnector.OpenAIEmbedding{Values: data.Embedding}
+	}
+
+	embeddingsResponse := &openaiconnector.OpenAIEmbeddingsResponse{Embeddings: embeddings}
+
+	if result.Usage != nil {
+		embeddingsResponse.RequestTokensCount = result.Usage.PromptTokens
+	} else {
+		embeddingsResponse.RequestTokensCount = utils.EstimateTokenCount(strings.Join(request.Inputs, ""))
+	}
+
+	return embeddingsResponse, nil
+}
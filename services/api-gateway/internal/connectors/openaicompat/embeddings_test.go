@@ -0,0 +1,104 @@
+package openaicompat_test
+
+import (
+	"context"
+	openaiconnector "github.com/basemind-ai/monorepo/gen/go/openai/v1"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/connectors/openaicompat"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/utils"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"testing"
+)
+
+func createEmbeddingsRequest() *openaiconnector.OpenAIEmbeddingsRequest {
+	return &openaiconnector.OpenAIEmbeddingsRequest{
+		Model:         openaiconnector.OpenAIEmbeddingModel_OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_3_SMALL,
+		Inputs:        []string{"The meaning of life", "is 42"},
+		Dimensions:    ptr.To(uint32(2)),
+		ApplicationId: ptr.To("application-id"),
+	}
+}
+
+func TestOpenAIEmbeddings(t *testing.T) {
+	t.Run("sends the expected request and parses the response", func(t *testing.T) {
+		client, api := createClientAndAPI(t, openaicompat.Options{APIKey: "default-key"})
+		api.ResponseBody = `{
+			"data": [
+				{"index": 1, "embedding": [0.3, 0.4]},
+				{"index": 0, "embedding": [0.1, 0.2]}
+			],
+			"usage": {"prompt_tokens": 8}
+		}`
+
+		response, err := client.OpenAIEmbeddings(context.TODO(), createEmbeddingsRequest())
+		assert.NoError(t, err)
+
+		assert.Len(t, response.Embeddings, 2)
+		assert.Equal(t, []float32{0.1, 0.2}, response.Embeddings[0].Values)
+		assert.Equal(t, []float32{0.3, 0.4}, response.Embeddings[1].Values)
+		assert.Equal(t, uint32(8), response.RequestTokensCount)
+
+		assert.Equal(t, http.MethodPost, api.Request.Method)
+		assert.Equal(t, "/v1/embeddings", api.Request.URL.Path)
+		assert.Equal(t, "Bearer default-key", api.Request.Header.Get("Authorization"))
+
+		assert.Equal(t, "text-embedding-3-small", api.RequestBody["model"])
+		assert.Equal(t, []any{"The meaning of life", "is 42"}, api.RequestBody["input"])
+		assert.InDelta(t, 2, api.RequestBody["dimensions"], 0.001)
+		assert.Equal(t, "application-id", api.RequestBody["user"])
+	})
+
+	t.Run("uses the embedding model override", func(t *testing.T) {
+		client, api := createClientAndAPI(t, openaicompat.Options{EmbeddingModel: "nomic-embed-text"})
+		api.ResponseBody = `{"data": [{"index": 0, "embedding": [0.1]}, {"index": 1, "embedding": [0.2]}]}`
+
+		response, err := client.OpenAIEmbeddings(context.TODO(), createEmbeddingsRequest())
+		assert.NoError(t, err)
+
+		assert.Equal(t, "nomic-embed-text", api.RequestBody["model"])
+		// the token count is estimated when the API does not return the usage
+		assert.Equal(
+			t,
+			utils.EstimateTokenCount("The meaning of lifeis 42"),
+			response.RequestTokensCount,
+		)
+	})
+
+	t.Run("returns an error if the embeddings do not match the inputs", func(t *testing.T) {
+		client, api := createClientAndAPI(t, openaicompat.Options{})
+		api.ResponseBody = `{"data": [{"index": 0, "embedding": [0.1]}]}`
+
+		_, err := client.OpenAIEmbeddings(context.TODO(), createEmbeddingsRequest())
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+
+	t.Run("returns an error for a duplicate embedding index", func(t *testing.T) {
+		client, api := createClientAndAPI(t, openaicompat.Options{})
+		api.ResponseBody = `{"data": [{"index": 0, "embedding": [0.1]}, {"index": 0, "embedding": [0.2]}]}`
+
+		_, err := client.OpenAIEmbeddings(context.TODO(), createEmbeddingsRequest())
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+
+	t.Run("returns an error for an unknown model", func(t *testing.T) {
+		client, _ := createClientAndAPI(t, openaicompat.Options{})
+
+		request := createEmbeddingsRequest()
+		request.Model = openaiconnector.OpenAIEmbeddingModel_OPEN_AI_EMBEDDING_MODEL_UNSPECIFIED
+
+		_, err := client.OpenAIEmbeddings(context.TODO(), request)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("returns the API error", func(t *testing.T) {
+		client, api := createClientAndAPI(t, openaicompat.Options{})
+		api.StatusCode = http.StatusUnauthorized
+		api.ResponseBody = `{"error": {"message": "invalid api key"}}`
+
+		_, err := client.OpenAIEmbeddings(context.TODO(), createEmbeddingsRequest())
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
@@ -0,0 +1,69 @@
+package openaicompat
+
+import (
+	"context"
+	openaiconnector "github.com/basemind-ai/monorepo/gen/go/openai/v1"
+	"github.com/basemind-ai/monorepo/shared/go/exc"
+	"github.com/basemind-ai/monorepo/shared/go/tracing"
+	"github.com/rs/zerolog/log"
+	"github.com/sethvargo/go-envconfig"
+	"net/http"
+	"strings"
+)
+
+// Options - the configuration of the OpenAI compatible HTTP client.
+type Options struct {
+	// BaseURL is the base URL of the API, e.g. https://api.openai.com/v1 or http://localhost:11434/v1.
+	// A query string, e.g. an Azure "api-version", is appended to every request.
+	BaseURL string
+	// APIKey is the default API key, used when the request context does not carry a provider key.
+	APIKey string `env:"OPEN_AI_API_KEY"`
+	// AuthHeader is the header used to send the API key. "Authorization" sends it as a bearer token,
+	// any other header (e.g. Azure's "api-key") sends the key as is.
+	AuthHeader string `env:"OPENAI_CONNECTOR_AUTH_HEADER,default=Authorization"`
+	// Model overrides the model name sent to the API, e.g. for servers that host a single local model.
+	Model string `env:"OPENAI_CONNECTOR_MODEL"`
+	// EmbeddingModel overrides the model name sent to the API in embeddings requests.
+	EmbeddingModel string `env:"OPENAI_CONNECTOR_EMBEDDING_MODEL"`
+	// HTTPClient is the client used to make requests. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// ServiceClient implements the OpenAI connector service client interface by calling an OpenAI compatible
+// chat completions and embeddings HTTP API directly, instead of going through the OpenAI connector service.
+type ServiceClient struct {
+	options Options
+}
+
+// IsHTTPAddress returns true if the given connector address is an HTTP(S) URL.
+func IsHTTPAddress(address string) bool {
+	return strings.HasPrefix(address, "http://") || strings.HasPrefix(address, "https://")
+}
+
+// New creates a new OpenAI compatible HTTP service client.
+func New(options Options) *ServiceClient {
+	if options.HTTPClient == nil {
+		options.HTTPClient = http.DefaultClient
+	}
+
+	if options.AuthHeader == "" {
+		options.AuthHeader = "Authorization"
+	}
+
+	log.Info().Str("baseURL", options.BaseURL).Msg("initialized OpenAI compatible HTTP client")
+
+	return &ServiceClient{options: options}
+}
+
+// NewFromEnv creates a new OpenAI compatible HTTP service client for the given base URL,
+// reading the remaining options from the environment.
+func NewFromEnv(ctx context.Context, baseURL string) *ServiceClient {
+	options := Options{}
+	exc.Must(envconfig.Process(ctx, &options))
+	options.BaseURL = baseURL
+	options.HTTPClient = &http.Client{Transport: tracing.NewHTTPTransport(http.DefaultTransport)}
+
+	return New(options)
+}
+
+var _ openaiconnector.OpenAIServiceClient = &ServiceClient{}
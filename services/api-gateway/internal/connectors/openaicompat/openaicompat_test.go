@@ -0,0 +1,112 @@
+package openaicompat_test
+
+import (
+	"context"
+	"encoding/json"
+	openaiconnector "github.com/basemind-ai/monorepo/gen/go/openai/v1"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/connectors/openaicompat"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// mockAPI is a stand-in for an OpenAI compatible chat completions API.
+type mockAPI struct {
+	T            *testing.T
+	StatusCode   int
+	ResponseBody string
+	Events       []string
+	Request      *http.Request
+	RequestBody  map[string]any
+}
+
+func (m *mockAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.Request = r
+	m.RequestBody = map[string]any{}
+	assert.NoError(m.T, json.NewDecoder(r.Body).Decode(&m.RequestBody))
+
+	if m.Events != nil {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		for _, event := range m.Events {
+			_, _ = w.Write([]byte("data: " + event + "\n\n"))
+			w.(http.Flusher).Flush()
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if m.StatusCode == 0 {
+		m.StatusCode = http.StatusOK
+	}
+	w.WriteHeader(m.StatusCode)
+	_, _ = w.Write([]byte(m.ResponseBody))
+}
+
+func createClientAndAPI(
+	t *testing.T,
+	options openaicompat.Options,
+) (*openaicompat.ServiceClient, *mockAPI) {
+	t.Helper()
+	api := &mockAPI{T: t}
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	// options.BaseURL is used as a suffix, e.g. to pass a query string
+	options.BaseURL = server.URL + "/v1" + options.BaseURL
+	options.HTTPClient = server.Client()
+
+	return openaicompat.New(options), api
+}
+
+func createPromptRequest() *openaiconnector.OpenAIPromptRequest {
+	return &openaiconnector.OpenAIPromptRequest{
+		Model: openaiconnector.OpenAIModel_OPEN_AI_MODEL_GPT4_8K,
+		Messages: []*openaiconnector.OpenAIMessage{
+			{
+				Role:    openaiconnector.OpenAIMessageRole_OPEN_AI_MESSAGE_ROLE_SYSTEM,
+				Content: ptr.To("You are a helpful assistant. "),
+			},
+			{
+				Role:    openaiconnector.OpenAIMessageRole_OPEN_AI_MESSAGE_ROLE_USER,
+				Content: ptr.To("What is the meaning of life?"),
+			},
+		},
+		Parameters: &openaiconnector.OpenAIModelParameters{
+			Temperature: ptr.To(float32(0.5)),
+			MaxTokens:   ptr.To(uint32(0)),
+		},
+		ApplicationId: ptr.To("application-id"),
+	}
+}
+
+func TestOpenAICompat(t *testing.T) {
+	t.Run("IsHTTPAddress", func(t *testing.T) {
+		assert.True(t, openaicompat.IsHTTPAddress("http://localhost:11434/v1"))
+		assert.True(t, openaicompat.IsHTTPAddress("https://api.openai.com/v1"))
+		assert.False(t, openaicompat.IsHTTPAddress("localhost:50051"))
+		assert.False(t, openaicompat.IsHTTPAddress("openai-connector:4000"))
+	})
+
+	t.Run("NewFromEnv", func(t *testing.T) {
+		t.Run("reads the options from the environment", func(t *testing.T) {
+			api := &mockAPI{T: t, ResponseBody: `{"choices":[{"message":{"content":"hi"}}]}`}
+			server := httptest.NewServer(api)
+			t.Cleanup(server.Close)
+
+			t.Setenv("OPEN_AI_API_KEY", "env-key")
+			t.Setenv("OPENAI_CONNECTOR_AUTH_HEADER", "api-key")
+			t.Setenv("OPENAI_CONNECTOR_MODEL", "llama3")
+
+			client := openaicompat.NewFromEnv(context.TODO(), server.URL)
+
+			_, err := client.OpenAIPrompt(context.TODO(), createPromptRequest())
+			assert.NoError(t, err)
+			assert.Equal(t, "env-key", api.Request.Header.Get("api-key"))
+			assert.Empty(t, api.Request.Header.Get("Authorization"))
+			assert.Equal(t, "llama3", api.RequestBody["model"])
+		})
+	})
+}
@@ -0,0 +1,62 @@
+package openaicompat
+
+import (
+	"context"
+	"encoding/json"
+	openaiconnector "github.com/basemind-ai/monorepo/gen/go/openai/v1"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/connectors/openai"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/utils"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// OpenAIPrompt sends a regular (non-streaming) chat completions request.
+func (c *ServiceClient) OpenAIPrompt(
+	ctx context.Context,
+	request *openaiconnector.OpenAIPromptRequest,
+	_ ...grpc.CallOption,
+) (*openaiconnector.OpenAIPromptResponse, error) {
+	body, bodyErr := c.createRequestBody(request, false)
+	if bodyErr != nil {
+		return nil, bodyErr
+	}
+
+	response, requestErr := c.doRequest(ctx, "chat/completions", body, false)
+	if requestErr != nil {
+		return nil, requestErr
+	}
+
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	completion := &chatCompletionResponse{}
+	if decodeErr := json.NewDecoder(response.Body).Decode(completion); decodeErr != nil {
+		return nil, status.Errorf(codes.Internal, "failed to decode response - %v", decodeErr)
+	}
+
+	if len(completion.Choices) == 0 {
+		return nil, status.Error(codes.Internal, "response does not contain any choices")
+	}
+
+	choice := completion.Choices[0]
+	promptResponse := &openaiconnector.OpenAIPromptResponse{
+		Content:      ptr.Deref(choice.Message.Content, ""),
+		FinishReason: GetFinishReason(ptr.Deref(choice.FinishReason, "")),
+		ToolCalls:    ParseToolCalls(choice.Message.ToolCalls),
+	}
+
+	if completion.Usage != nil {
+		promptResponse.RequestTokensCount = completion.Usage.PromptTokens
+		promptResponse.ResponseTokensCount = completion.Usage.CompletionTokens
+	} else {
+		promptResponse.RequestTokensCount = utils.EstimateTokenCount(
+			openai.GetRequestPromptString(request.Messages),
+		)
+		promptResponse.ResponseTokensCount = utils.EstimateTokenCount(promptResponse.Content)
+	}
+
+	return promptResponse, nil
+}
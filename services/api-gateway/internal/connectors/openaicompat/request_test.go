@@ -0,0 +1,199 @@
+package openaicompat_test
+
+import (
+	"context"
+	openaiconnector "github.com/basemind-ai/monorepo/gen/go/openai/v1"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/connectors/openaicompat"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"testing"
+)
+
+func TestOpenAIPrompt(t *testing.T) {
+	t.Run("sends the expected request and parses the response", func(t *testing.T) {
+		client, api := createClientAndAPI(t, openaicompat.Options{APIKey: "default-key"})
+		api.ResponseBody = `{
+			"choices": [{"message": {"role": "assistant", "content": "42"}, "finish_reason": "stop"}],
+			"usage": {"prompt_tokens": 12, "completion_tokens": 3}
+		}`
+
+		response, err := client.OpenAIPrompt(context.TODO(), createPromptRequest())
+		assert.NoError(t, err)
+
+		assert.Equal(t, "42", response.Content)
+		assert.Equal(t, "DONE", response.FinishReason)
+		assert.Equal(t, uint32(12), response.RequestTokensCount)
+		assert.Equal(t, uint32(3), response.ResponseTokensCount)
+
+		assert.Equal(t, http.MethodPost, api.Request.Method)
+		assert.Equal(t, "/v1/chat/completions", api.Request.URL.Path)
+		assert.Equal(t, "Bearer default-key", api.Request.Header.Get("Authorization"))
+
+		assert.Equal(t, "gpt-4-0613", api.RequestBody["model"])
+		assert.Equal(t, "application-id", api.RequestBody["user"])
+		assert.InDelta(t, 0.5, api.RequestBody["temperature"], 0.001)
+		assert.NotContains(t, api.RequestBody, "max_tokens")
+		assert.NotContains(t, api.RequestBody, "stream")
+		assert.Equal(t, []any{
+			map[string]any{"role": "system", "content": "You are a helpful assistant."},
+			map[string]any{"role": "user", "content": "What is the meaning of life?"},
+		}, api.RequestBody["messages"])
+	})
+
+	t.Run("sends the tools and parses the tool calls", func(t *testing.T) {
+		client, api := createClientAndAPI(t, openaicompat.Options{})
+		api.ResponseBody = `{
+			"choices": [{
+				"message": {
+					"role": "assistant",
+					"content": null,
+					"tool_calls": [{
+						"id": "call-1",
+						"type": "function",
+						"function": {"name": "get_weather", "arguments": "{\"city\":\"Paris\"}"}
+					}]
+				},
+				"finish_reason": "tool_calls"
+			}]
+		}`
+
+		request := createPromptRequest()
+		request.Tools = []*openaiconnector.OpenAITool{{
+			Name:        "get_weather",
+			Description: ptr.To("Returns the weather of a city"),
+			Parameters:  ptr.To(`{"type":"object"}`),
+		}}
+		request.Messages = append(request.Messages,
+			&openaiconnector.OpenAIMessage{
+				Role: openaiconnector.OpenAIMessageRole_OPEN_AI_MESSAGE_ROLE_ASSISTANT,
+				ToolCalls: []*openaiconnector.OpenAIToolCall{
+					{Id: "call-0", Name: "get_weather", Arguments: `{"city":"Rome"}`},
+				},
+			},
+			&openaiconnector.OpenAIMessage{
+				Role:       openaiconnector.OpenAIMessageRole_OPEN_AI_MESSAGE_ROLE_TOOL,
+				Content:    ptr.To(`{"temperature":20}`),
+				ToolCallId: ptr.To("call-0"),
+			},
+		)
+
+		response, err := client.OpenAIPrompt(context.TODO(), request)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "DONE", response.FinishReason)
+		assert.Len(t, response.ToolCalls, 1)
+		assert.Equal(t, "call-1", response.ToolCalls[0].Id)
+		assert.Equal(t, "get_weather", response.ToolCalls[0].Name)
+		assert.Equal(t, `{"city":"Paris"}`, response.ToolCalls[0].Arguments)
+
+		assert.Equal(t, []any{
+			map[string]any{
+				"type": "function",
+				"function": map[string]any{
+					"name":        "get_weather",
+					"description": "Returns the weather of a city",
+					"parameters":  map[string]any{"type": "object"},
+				},
+			},
+		}, api.RequestBody["tools"])
+
+		messages := api.RequestBody["messages"].([]any)
+		assert.Equal(t, []any{
+			map[string]any{
+				"id":       "call-0",
+				"type":     "function",
+				"function": map[string]any{"name": "get_weather", "arguments": `{"city":"Rome"}`},
+			},
+		}, messages[2].(map[string]any)["tool_calls"])
+		assert.Equal(t, map[string]any{
+			"role":         "tool",
+			"content":      `{"temperature":20}`,
+			"tool_call_id": "call-0",
+		}, messages[3])
+	})
+
+	t.Run("requests a JSON object response in JSON mode", func(t *testing.T) {
+		client, api := createClientAndAPI(t, openaicompat.Options{})
+		api.ResponseBody = `{"choices": [{"message": {"content": "{}"}}]}`
+
+		request := createPromptRequest()
+		request.JsonMode = ptr.To(true)
+
+		_, err := client.OpenAIPrompt(context.TODO(), request)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"type": "json_object"}, api.RequestBody["response_format"])
+	})
+
+	t.Run("uses the API key from the outgoing context", func(t *testing.T) {
+		client, api := createClientAndAPI(t, openaicompat.Options{APIKey: "default-key"})
+		api.ResponseBody = `{"choices": [{"message": {"content": "42"}}]}`
+
+		ctx := metadata.AppendToOutgoingContext(context.TODO(), "X-API-Key", "provider-key")
+		_, err := client.OpenAIPrompt(ctx, createPromptRequest())
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer provider-key", api.Request.Header.Get("Authorization"))
+	})
+
+	t.Run("preserves the base URL query string", func(t *testing.T) {
+		client, api := createClientAndAPI(t, openaicompat.Options{
+			BaseURL:    "?api-version=2024-02-01",
+			AuthHeader: "api-key",
+			APIKey:     "azure-key",
+		})
+		api.ResponseBody = `{"choices": [{"message": {"content": "42"}}]}`
+
+		_, err := client.OpenAIPrompt(context.TODO(), createPromptRequest())
+		assert.NoError(t, err)
+		assert.Equal(t, "/v1/chat/completions", api.Request.URL.Path)
+		assert.Equal(t, "2024-02-01", api.Request.URL.Query().Get("api-version"))
+		assert.Equal(t, "azure-key", api.Request.Header.Get("api-key"))
+	})
+
+	t.Run("maps a length finish reason to LIMIT", func(t *testing.T) {
+		client, api := createClientAndAPI(t, openaicompat.Options{})
+		api.ResponseBody = `{"choices": [{"message": {"content": "42"}, "finish_reason": "length"}]}`
+
+		response, err := client.OpenAIPrompt(context.TODO(), createPromptRequest())
+		assert.NoError(t, err)
+		assert.Equal(t, "LIMIT", response.FinishReason)
+	})
+
+	t.Run("estimates the token counts when usage is not returned", func(t *testing.T) {
+		client, api := createClientAndAPI(t, openaicompat.Options{})
+		api.ResponseBody = `{"choices": [{"message": {"content": "12345678"}}]}`
+
+		response, err := client.OpenAIPrompt(context.TODO(), createPromptRequest())
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(2), response.ResponseTokensCount)
+		assert.Greater(t, response.RequestTokensCount, uint32(0))
+	})
+
+	t.Run("returns a status error for an error response", func(t *testing.T) {
+		client, api := createClientAndAPI(t, openaicompat.Options{})
+		api.StatusCode = http.StatusTooManyRequests
+		api.ResponseBody = `{"error": {"message": "rate limit exceeded"}}`
+
+		_, err := client.OpenAIPrompt(context.TODO(), createPromptRequest())
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		assert.Contains(t, err.Error(), "rate limit exceeded")
+	})
+
+	t.Run("returns an Unavailable error when the server is unreachable", func(t *testing.T) {
+		client := openaicompat.New(openaicompat.Options{BaseURL: "http://127.0.0.1:1/v1"})
+
+		_, err := client.OpenAIPrompt(context.TODO(), createPromptRequest())
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+
+	t.Run("returns an error for a response without choices", func(t *testing.T) {
+		client, api := createClientAndAPI(t, openaicompat.Options{})
+		api.ResponseBody = `{"choices": []}`
+
+		_, err := client.OpenAIPrompt(context.TODO(), createPromptRequest())
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
@@ -0,0 +1,222 @@
+package openaicompat
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	openaiconnector "github.com/basemind-ai/monorepo/gen/go/openai/v1"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/connectors/openai"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/utils"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	streamDoneMessage = "[DONE]"
+	// maxEventLineSize is the maximal size of a server-sent event line, which can carry the full arguments of a tool call.
+	maxEventLineSize = 4 * 1024 * 1024
+)
+
+// OpenAIStream sends a streaming chat completions request and returns a stream of server-sent events
+// adapted to the OpenAI connector stream client interface.
+func (c *ServiceClient) OpenAIStream(
+	ctx context.Context,
+	request *openaiconnector.OpenAIPromptRequest,
+	_ ...grpc.CallOption,
+) (openaiconnector.OpenAIService_OpenAIStreamClient, error) {
+	body, bodyErr := c.createRequestBody(request, true)
+	if bodyErr != nil {
+		return nil, bodyErr
+	}
+
+	response, requestErr := c.doRequest(ctx, "chat/completions", body, true)
+	if requestErr != nil {
+		return nil, requestErr
+	}
+
+	scanner := bufio.NewScanner(response.Body)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxEventLineSize)
+
+	return &eventStream{
+		ctx:        ctx,
+		response:   response,
+		scanner:    scanner,
+		promptText: openai.GetRequestPromptString(request.Messages),
+	}, nil
+}
+
+// eventStream implements the OpenAI connector stream client on top of a server-sent events response.
+type eventStream struct {
+	ctx          context.Context
+	response     *http.Response
+	scanner      *bufio.Scanner
+	promptText   string
+	content      strings.Builder
+	finishReason *string
+	usage        *usage
+	toolCalls    []*openaiconnector.OpenAIToolCall
+	isFinished   bool
+}
+
+// addToolCallDeltas merges the tool call fragments of a stream delta into the tool calls of the stream.
+func (s *eventStream) addToolCallDeltas(deltas []toolCall) {
+	for _, delta := range deltas {
+		index := ptr.Deref(delta.Index, len(s.toolCalls))
+		for len(s.toolCalls) <= index {
+			s.toolCalls = append(s.toolCalls, &openaiconnector.OpenAIToolCall{})
+		}
+
+		call := s.toolCalls[index]
+		if delta.ID != "" {
+			call.Id = delta.ID
+		}
+		call.Name += delta.Function.Name
+		call.Arguments += delta.Function.Arguments
+	}
+}
+
+// Recv returns the next content message of the stream. The last message carries the finish reason and
+// token counts; subsequent calls return io.EOF.
+func (s *eventStream) Recv() (*openaiconnector.OpenAIStreamResponse, error) {
+	if s.isFinished {
+		return nil, io.EOF
+	}
+
+	for s.scanner.Scan() {
+		line := strings.TrimSpace(s.scanner.Text())
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if data == streamDoneMessage {
+			return s.finish(), nil
+		}
+
+		chunk := &chatCompletionResponse{}
+		if unmarshalErr := json.Unmarshal([]byte(data), chunk); unmarshalErr != nil {
+			return nil, s.fail(status.Errorf(codes.Internal, "failed to decode stream event - %v", unmarshalErr))
+		}
+
+		if chunk.Usage != nil {
+			s.usage = chunk.Usage
+		}
+
+		if len(chunk.Choices) == 0 {
+			continue
+		}
+
+		choice := chunk.Choices[0]
+		s.addToolCallDeltas(choice.Delta.ToolCalls)
+
+		if choice.FinishReason != nil {
+			s.finishReason = ptr.To(GetFinishReason(*choice.FinishReason))
+		}
+
+		if content := ptr.Deref(choice.Delta.Content, ""); content != "" {
+			s.content.WriteString(content)
+			return &openaiconnector.OpenAIStreamResponse{Content: content}, nil
+		}
+	}
+
+	if scanErr := s.scanner.Err(); scanErr != nil {
+		return nil, s.fail(transportError(s.ctx, scanErr))
+	}
+
+	if s.finishReason != nil {
+		return s.finish(), nil
+	}
+
+	if ctxErr := s.ctx.Err(); ctxErr != nil {
+		return nil, s.fail(transportError(s.ctx, ctxErr))
+	}
+
+	return nil, s.fail(status.Error(codes.Unavailable, "stream ended unexpectedly"))
+}
+
+func (s *eventStream) finish() *openaiconnector.OpenAIStreamResponse {
+	s.close()
+
+	message := &openaiconnector.OpenAIStreamResponse{
+		FinishReason: ptr.To(ptr.Deref(s.finishReason, "DONE")),
+		ToolCalls:    s.toolCalls,
+	}
+
+	if s.usage != nil {
+		message.RequestTokensCount = ptr.To(s.usage.PromptTokens)
+		message.ResponseTokensCount = ptr.To(s.usage.CompletionTokens)
+	} else {
+		message.RequestTokensCount = ptr.To(utils.EstimateTokenCount(s.promptText))
+		message.ResponseTokensCount = ptr.To(utils.EstimateTokenCount(s.content.String()))
+	}
+
+	return message
+}
+
+func (s *eventStream) fail(err error) error {
+	s.close()
+	return err
+}
+
+func (s *eventStream) close() {
+	if !s.isFinished {
+		s.isFinished = true
+		_ = s.response.Body.Close()
+	}
+}
+
+// Header returns the response headers as metadata.
+func (s *eventStream) Header() (metadata.MD, error) {
+	md := metadata.MD{}
+	for key, values := range s.response.Header {
+		md.Append(key, values...)
+	}
+
+	return md, nil
+}
+
+// Trailer returns empty metadata, since trailers are not used by the API.
+func (s *eventStream) Trailer() metadata.MD {
+	return metadata.MD{}
+}
+
+// CloseSend is a no-op, since the request has already been sent.
+func (s *eventStream) CloseSend() error {
+	return nil
+}
+
+// Context returns the context of the stream.
+func (s *eventStream) Context() context.Context {
+	return s.ctx
+}
+
+// SendMsg is not supported by a server-streaming call.
+func (s *eventStream) SendMsg(_ any) error {
+	return status.Error(codes.Unimplemented, "sending messages is not supported")
+}
+
+// RecvMsg receives the next message into the given OpenAIStreamResponse.
+func (s *eventStream) RecvMsg(m any) error {
+	target, ok := m.(*openaiconnector.OpenAIStreamResponse)
+	if !ok {
+		return errors.New("unsupported message type")
+	}
+
+	msg, recvErr := s.Recv()
+	if recvErr != nil {
+		return recvErr
+	}
+
+	proto.Reset(target)
+	proto.Merge(target, msg)
+
+	return nil
+}
@@ -0,0 +1,179 @@
+package openaicompat_test
+
+import (
+	"context"
+	openaiconnector "github.com/basemind-ai/monorepo/gen/go/openai/v1"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/connectors/openaicompat"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func receiveAll(
+	t *testing.T,
+	stream openaiconnector.OpenAIService_OpenAIStreamClient,
+) ([]*openaiconnector.OpenAIStreamResponse, error) {
+	t.Helper()
+
+	var messages []*openaiconnector.OpenAIStreamResponse
+
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			return messages, err
+		}
+
+		messages = append(messages, msg)
+	}
+}
+
+func TestOpenAIStream(t *testing.T) {
+	t.Run("streams the content and finishes with the usage", func(t *testing.T) {
+		client, api := createClientAndAPI(t, openaicompat.Options{})
+		api.Events = []string{
+			`{"choices": [{"delta": {"role": "assistant"}}]}`,
+			`{"choices": [{"delta": {"content": "Hello"}}]}`,
+			`{"choices": [{"delta": {"content": " world"}}]}`,
+			`{"choices": [{"delta": {}, "finish_reason": "length"}]}`,
+			`{"choices": [], "usage": {"prompt_tokens": 10, "completion_tokens": 2}}`,
+			"[DONE]",
+		}
+
+		stream, err := client.OpenAIStream(context.TODO(), createPromptRequest())
+		assert.NoError(t, err)
+
+		messages, recvErr := receiveAll(t, stream)
+		assert.ErrorIs(t, recvErr, io.EOF)
+		assert.Len(t, messages, 3)
+
+		assert.Equal(t, "Hello", messages[0].Content)
+		assert.Nil(t, messages[0].FinishReason)
+		assert.Equal(t, " world", messages[1].Content)
+
+		assert.Equal(t, "LIMIT", ptr.Deref(messages[2].FinishReason, ""))
+		assert.Equal(t, uint32(10), ptr.Deref(messages[2].RequestTokensCount, 0))
+		assert.Equal(t, uint32(2), ptr.Deref(messages[2].ResponseTokensCount, 0))
+
+		assert.Equal(t, true, api.RequestBody["stream"])
+		assert.Equal(t, map[string]any{"include_usage": true}, api.RequestBody["stream_options"])
+	})
+
+	t.Run("accumulates the streamed tool calls into the last message", func(t *testing.T) {
+		client, api := createClientAndAPI(t, openaicompat.Options{})
+		api.Events = []string{
+			`{"choices": [{"delta": {"role": "assistant", "tool_calls": [{"index": 0, "id": "call-1", "type": "function", "function": {"name": "get_weather", "arguments": ""}}]}}]}`,
+			`{"choices": [{"delta": {"tool_calls": [{"index": 0, "function": {"arguments": "{\"city\":"}}]}}]}`,
+			`{"choices": [{"delta": {"tool_calls": [{"index": 1, "id": "call-2", "type": "function", "function": {"name": "get_time", "arguments": "{}"}}]}}]}`,
+			`{"choices": [{"delta": {"tool_calls": [{"index": 0, "function": {"arguments": "\"Paris\"}"}}]}}]}`,
+			`{"choices": [{"delta": {}, "finish_reason": "tool_calls"}]}`,
+			"[DONE]",
+		}
+
+		stream, err := client.OpenAIStream(context.TODO(), createPromptRequest())
+		assert.NoError(t, err)
+
+		messages, recvErr := receiveAll(t, stream)
+		assert.ErrorIs(t, recvErr, io.EOF)
+
+		lastMessage := messages[len(messages)-1]
+		assert.Equal(t, "DONE", ptr.Deref(lastMessage.FinishReason, ""))
+		assert.Len(t, lastMessage.ToolCalls, 2)
+		assert.Equal(t, "call-1", lastMessage.ToolCalls[0].Id)
+		assert.Equal(t, "get_weather", lastMessage.ToolCalls[0].Name)
+		assert.Equal(t, `{"city":"Paris"}`, lastMessage.ToolCalls[0].Arguments)
+		assert.Equal(t, "call-2", lastMessage.ToolCalls[1].Id)
+		assert.Equal(t, "get_time", lastMessage.ToolCalls[1].Name)
+
+		for _, message := range messages[:len(messages)-1] {
+			assert.Nil(t, message.ToolCalls)
+		}
+	})
+
+	t.Run("receives an event exceeding the default scanner buffer", func(t *testing.T) {
+		arguments := strings.Repeat("a", 100*1024)
+
+		client, api := createClientAndAPI(t, openaicompat.Options{})
+		api.Events = []string{
+			`{"choices": [{"delta": {"role": "assistant", "tool_calls": [{"index": 0, "id": "call-1", "type": "function", "function": {"name": "summarize", "arguments": "` + arguments + `"}}]}}]}`,
+			`{"choices": [{"delta": {}, "finish_reason": "tool_calls"}]}`,
+			"[DONE]",
+		}
+
+		stream, err := client.OpenAIStream(context.TODO(), createPromptRequest())
+		assert.NoError(t, err)
+
+		messages, recvErr := receiveAll(t, stream)
+		assert.ErrorIs(t, recvErr, io.EOF)
+
+		lastMessage := messages[len(messages)-1]
+		assert.Len(t, lastMessage.ToolCalls, 1)
+		assert.Equal(t, arguments, lastMessage.ToolCalls[0].Arguments)
+	})
+
+	t.Run("estimates the token counts when usage is not returned", func(t *testing.T) {
+		client, api := createClientAndAPI(t, openaicompat.Options{})
+		api.Events = []string{
+			`{"choices": [{"delta": {"content": "12345678"}}]}`,
+			`{"choices": [{"delta": {}, "finish_reason": "stop"}]}`,
+		}
+
+		stream, err := client.OpenAIStream(context.TODO(), createPromptRequest())
+		assert.NoError(t, err)
+
+		messages, recvErr := receiveAll(t, stream)
+		assert.ErrorIs(t, recvErr, io.EOF)
+		assert.Len(t, messages, 2)
+		assert.Equal(t, "DONE", ptr.Deref(messages[1].FinishReason, ""))
+		assert.Equal(t, uint32(2), ptr.Deref(messages[1].ResponseTokensCount, 0))
+	})
+
+	t.Run("returns an error when the stream ends without a finish reason", func(t *testing.T) {
+		client, api := createClientAndAPI(t, openaicompat.Options{})
+		api.Events = []string{`{"choices": [{"delta": {"content": "Hello"}}]}`}
+
+		stream, err := client.OpenAIStream(context.TODO(), createPromptRequest())
+		assert.NoError(t, err)
+
+		messages, recvErr := receiveAll(t, stream)
+		assert.Len(t, messages, 1)
+		assert.Equal(t, codes.Unavailable, status.Code(recvErr))
+	})
+
+	t.Run("returns an error for an invalid event", func(t *testing.T) {
+		client, api := createClientAndAPI(t, openaicompat.Options{})
+		api.Events = []string{`not json`}
+
+		stream, err := client.OpenAIStream(context.TODO(), createPromptRequest())
+		assert.NoError(t, err)
+
+		_, recvErr := receiveAll(t, stream)
+		assert.Equal(t, codes.Internal, status.Code(recvErr))
+	})
+
+	t.Run("returns a status error for an error response", func(t *testing.T) {
+		client, api := createClientAndAPI(t, openaicompat.Options{})
+		api.StatusCode = http.StatusUnauthorized
+		api.ResponseBody = `{"error": {"message": "invalid api key"}}`
+
+		_, err := client.OpenAIStream(context.TODO(), createPromptRequest())
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("RecvMsg receives into the given message", func(t *testing.T) {
+		client, api := createClientAndAPI(t, openaicompat.Options{})
+		api.Events = []string{`{"choices": [{"delta": {"content": "Hello"}}]}`, "[DONE]"}
+
+		stream, err := client.OpenAIStream(context.TODO(), createPromptRequest())
+		assert.NoError(t, err)
+
+		msg := &openaiconnector.OpenAIStreamResponse{}
+		assert.NoError(t, stream.RecvMsg(msg))
+		assert.Equal(t, "Hello", msg.Content)
+		assert.Error(t, stream.SendMsg(msg))
+	})
+}
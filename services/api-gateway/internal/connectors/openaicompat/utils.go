@@ -0,0 +1,407 @@
+package openaicompat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	openaiconnector "github.com/basemind-ai/monorepo/gen/go/openai/v1"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ModelNameMap maps the connector model enum to the OpenAI API model name.
+var ModelNameMap = map[openaiconnector.OpenAIModel]string{
+	openaiconnector.OpenAIModel_OPEN_AI_MODEL_GPT3_5_TURBO_4K:  "gpt-3.5-turbo",
+	openaiconnector.OpenAIModel_OPEN_AI_MODEL_GPT3_5_TURBO_16K: "gpt-3.5-turbo-16k",
+	openaiconnector.OpenAIModel_OPEN_AI_MODEL_GPT4_8K:          "gpt-4-0613",
+	openaiconnector.OpenAIModel_OPEN_AI_MODEL_GPT4_32K:         "gpt-4-32k",
+}
+
+// EmbeddingModelNameMap maps the connector embedding model enum to the OpenAI API model name.
+var EmbeddingModelNameMap = map[openaiconnector.OpenAIEmbeddingModel]string{
+	openaiconnector.OpenAIEmbeddingModel_OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_3_SMALL: "text-embedding-3-small",
+	openaiconnector.OpenAIEmbeddingModel_OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_3_LARGE: "text-embedding-3-large",
+	openaiconnector.OpenAIEmbeddingModel_OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_ADA_002: "text-embedding-ada-002",
+}
+
+var messageRoleMap = map[openaiconnector.OpenAIMessageRole]string{
+	openaiconnector.OpenAIMessageRole_OPEN_AI_MESSAGE_ROLE_SYSTEM:    "system",
+	openaiconnector.OpenAIMessageRole_OPEN_AI_MESSAGE_ROLE_USER:      "user",
+	openaiconnector.OpenAIMessageRole_OPEN_AI_MESSAGE_ROLE_ASSISTANT: "assistant",
+	openaiconnector.OpenAIMessageRole_OPEN_AI_MESSAGE_ROLE_FUNCTION:  "function",
+	openaiconnector.OpenAIMessageRole_OPEN_AI_MESSAGE_ROLE_TOOL:      "tool",
+}
+
+const toolTypeFunction = "function"
+
+type functionCall struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+}
+
+// toolCall is a tool call of a message. In stream deltas, the index designates the tool call
+// a fragment belongs to, and only the first fragment of every tool call has its id and name.
+type toolCall struct {
+	Index    *int         `json:"index,omitempty"`
+	ID       string       `json:"id,omitempty"`
+	Type     string       `json:"type,omitempty"`
+	Function functionCall `json:"function"`
+}
+
+type toolFunction struct {
+	Name        string          `json:"name"`
+	Description *string         `json:"description,omitempty"`
+	Parameters  json.RawMessage `json:"parameters,omitempty"`
+}
+
+type tool struct {
+	Type     string       `json:"type"`
+	Function toolFunction `json:"function"`
+}
+
+type chatMessage struct {
+	Role         string        `json:"role"`
+	Content      *string       `json:"content"`
+	Name         *string       `json:"name,omitempty"`
+	FunctionCall *functionCall `json:"function_call,omitempty"`
+	ToolCallID   *string       `json:"tool_call_id,omitempty"`
+	ToolCalls    []toolCall    `json:"tool_calls,omitempty"`
+}
+
+type responseFormat struct {
+	Type string `json:"type"`
+}
+
+type streamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+type chatCompletionRequest struct {
+	Model            string          `json:"model"`
+	Messages         []chatMessage   `json:"messages"`
+	Temperature      *float32        `json:"temperature,omitempty"`
+	TopP             *float32        `json:"top_p,omitempty"`
+	MaxTokens        *uint32         `json:"max_tokens,omitempty"`
+	PresencePenalty  *float32        `json:"presence_penalty,omitempty"`
+	FrequencyPenalty *float32        `json:"frequency_penalty,omitempty"`
+	User             *string         `json:"user,omitempty"`
+	Tools            []tool          `json:"tools,omitempty"`
+	ResponseFormat   *responseFormat `json:"response_format,omitempty"`
+	Stream           bool            `json:"stream,omitempty"`
+	StreamOptions    *streamOptions  `json:"stream_options,omitempty"`
+}
+
+type usage struct {
+	PromptTokens     uint32 `json:"prompt_tokens"`
+	CompletionTokens uint32 `json:"completion_tokens"`
+}
+
+type chatCompletionChoice struct {
+	Message struct {
+		Content   *string    `json:"content"`
+		ToolCalls []toolCall `json:"tool_calls"`
+	} `json:"message"`
+	Delta struct {
+		Content   *string    `json:"content"`
+		ToolCalls []toolCall `json:"tool_calls"`
+	} `json:"delta"`
+	FinishReason *string `json:"finish_reason"`
+}
+
+type chatCompletionResponse struct {
+	Choices []chatCompletionChoice `json:"choices"`
+	Usage   *usage                 `json:"usage"`
+}
+
+type embeddingsRequest struct {
+	Model      string   `json:"model"`
+	Input      []string `json:"input"`
+	Dimensions *uint32  `json:"dimensions,omitempty"`
+	User       *string  `json:"user,omitempty"`
+}
+
+type embeddingData struct {
+	Index     int       `json:"index"`
+	Embedding []float32 `json:"embedding"`
+}
+
+type embeddingsResponse struct {
+	Data  []embeddingData `json:"data"`
+	Usage *usage          `json:"usage"`
+}
+
+type errorResponse struct {
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// GetFinishReason maps an OpenAI finish reason to the gateway finish reason.
+func GetFinishReason(finishReason string) string {
+	if finishReason == "length" {
+		return "LIMIT"
+	}
+
+	return "DONE"
+}
+
+// ParseToolCalls maps the tool calls of a response message to connector tool calls.
+func ParseToolCalls(toolCalls []toolCall) []*openaiconnector.OpenAIToolCall {
+	if len(toolCalls) == 0 {
+		return nil
+	}
+
+	parsed := make([]*openaiconnector.OpenAIToolCall, 0, len(toolCalls))
+	for _, call := range toolCalls {
+		parsed = append(parsed, &openaiconnector.OpenAIToolCall{
+			Id:        call.ID,
+			Name:      call.Function.Name,
+			Arguments: call.Function.Arguments,
+		})
+	}
+
+	return parsed
+}
+
+func (c *ServiceClient) createRequestBody(
+	request *openaiconnector.OpenAIPromptRequest,
+	isStream bool,
+) (*chatCompletionRequest, error) {
+	model := c.options.Model
+	if model == "" {
+		modelName, ok := ModelNameMap[request.Model]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown model {%s}", request.Model)
+		}
+
+		model = modelName
+	}
+
+	body := &chatCompletionRequest{
+		Model:    model,
+		Messages: make([]chatMessage, 0, len(request.Messages)),
+		User:     request.ApplicationId,
+		Stream:   isStream,
+	}
+
+	if isStream {
+		body.StreamOptions = &streamOptions{IncludeUsage: true}
+	}
+
+	for _, message := range request.Messages {
+		role, ok := messageRoleMap[message.Role]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown message role {%s}", message.Role)
+		}
+
+		chatMsg := chatMessage{Role: role, Name: message.Name}
+
+		if message.Content != nil {
+			if content := strings.TrimSpace(*message.Content); content != "" {
+				chatMsg.Content = &content
+			}
+		}
+
+		if message.FunctionCall != nil {
+			chatMsg.FunctionCall = &functionCall{
+				Name:      message.FunctionCall.Name,
+				Arguments: message.FunctionCall.Arguments,
+			}
+		}
+
+		chatMsg.ToolCallID = message.ToolCallId
+		for _, call := range message.ToolCalls {
+			chatMsg.ToolCalls = append(chatMsg.ToolCalls, toolCall{
+				ID:       call.Id,
+				Type:     toolTypeFunction,
+				Function: functionCall{Name: call.Name, Arguments: call.Arguments},
+			})
+		}
+
+		body.Messages = append(body.Messages, chatMsg)
+	}
+
+	for _, requestTool := range request.Tools {
+		function := toolFunction{Name: requestTool.Name, Description: requestTool.Description}
+		if requestTool.Parameters != nil {
+			function.Parameters = json.RawMessage(*requestTool.Parameters)
+		}
+
+		body.Tools = append(body.Tools, tool{Type: toolTypeFunction, Function: function})
+	}
+
+	if request.GetJsonMode() {
+		body.ResponseFormat = &responseFormat{Type: "json_object"}
+	}
+
+	if parameters := request.Parameters; parameters != nil {
+		body.Temperature = parameters.Temperature
+		body.TopP = parameters.TopP
+		body.PresencePenalty = parameters.PresencePenalty
+		body.FrequencyPenalty = parameters.FrequencyPenalty
+
+		if ptr.Deref(parameters.MaxTokens, 0) > 0 {
+			body.MaxTokens = parameters.MaxTokens
+		}
+	}
+
+	return body, nil
+}
+
+func (c *ServiceClient) createEmbeddingsRequestBody(
+	request *openaiconnector.OpenAIEmbeddingsRequest,
+) (*embeddingsRequest, error) {
+	model := c.options.EmbeddingModel
+	if model == "" {
+		modelName, ok := EmbeddingModelNameMap[request.Model]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown embedding model {%s}", request.Model)
+		}
+
+		model = modelName
+	}
+
+	return &embeddingsRequest{
+		Model:      model,
+		Input:      request.Inputs,
+		Dimensions: request.Dimensions,
+		User:       request.ApplicationId,
+	}, nil
+}
+
+func (c *ServiceClient) createRequestURL(path string) (string, error) {
+	baseURL, parseErr := url.Parse(c.options.BaseURL)
+	if parseErr != nil {
+		return "", status.Errorf(codes.Internal, "invalid base URL {%s} - %v", c.options.BaseURL, parseErr)
+	}
+
+	return baseURL.JoinPath(path).String(), nil
+}
+
+func (c *ServiceClient) getAPIKey(ctx context.Context) string {
+	if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		if values := md.Get("x-api-key"); len(values) > 0 && values[0] != "" {
+			return values[0]
+		}
+	}
+
+	return c.options.APIKey
+}
+
+// doRequest sends a request with the given body to the given API path, e.g. "chat/completions",
+// and returns the response, mapping failures to gRPC status errors.
+// The caller is responsible for closing the response body.
+func (c *ServiceClient) doRequest(
+	ctx context.Context,
+	path string,
+	body any,
+	isStream bool,
+) (*http.Response, error) {
+	requestURL, urlErr := c.createRequestURL(path)
+	if urlErr != nil {
+		return nil, urlErr
+	}
+
+	data, marshalErr := json.Marshal(body)
+	if marshalErr != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal request - %v", marshalErr)
+	}
+
+	httpRequest, requestErr := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		requestURL,
+		bytes.NewReader(data),
+	)
+	if requestErr != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create request - %v", requestErr)
+	}
+
+	httpRequest.Header.Set("Content-Type", "application/json")
+
+	if isStream {
+		httpRequest.Header.Set("Accept", "text/event-stream")
+	}
+
+	if apiKey := c.getAPIKey(ctx); apiKey != "" {
+		if strings.EqualFold(c.options.AuthHeader, "Authorization") {
+			httpRequest.Header.Set("Authorization", "Bearer "+apiKey)
+		} else {
+			httpRequest.Header.Set(c.options.AuthHeader, apiKey)
+		}
+	}
+
+	response, doErr := c.options.HTTPClient.Do(httpRequest)
+	if doErr != nil {
+		return nil, transportError(ctx, doErr)
+	}
+
+	if response.StatusCode >= http.StatusBadRequest {
+		defer func() {
+			_ = response.Body.Close()
+		}()
+
+		return nil, responseError(response)
+	}
+
+	return response, nil
+}
+
+func transportError(ctx context.Context, err error) error {
+	ctxErr := ctx.Err()
+	if errors.Is(ctxErr, context.Canceled) || errors.Is(err, context.Canceled) {
+		return status.Error(codes.Canceled, err.Error())
+	}
+
+	if errors.Is(ctxErr, context.DeadlineExceeded) || errors.Is(err, context.DeadlineExceeded) {
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+
+	return status.Errorf(codes.Unavailable, "request failed - %v", err)
+}
+
+// HTTPStatusToCode maps an HTTP status code returned by the API to a gRPC status code.
+func HTTPStatusToCode(statusCode int) codes.Code {
+	switch {
+	case statusCode == http.StatusBadRequest, statusCode == http.StatusUnprocessableEntity:
+		return codes.InvalidArgument
+	case statusCode == http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case statusCode == http.StatusForbidden:
+		return codes.PermissionDenied
+	case statusCode == http.StatusNotFound:
+		return codes.NotFound
+	case statusCode == http.StatusRequestTimeout, statusCode == http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	case statusCode == http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case statusCode >= http.StatusInternalServerError:
+		return codes.Unavailable
+	default:
+		return codes.Unknown
+	}
+}
+
+func responseError(response *http.Response) error {
+	message := http.StatusText(response.StatusCode)
+
+	data, _ := io.ReadAll(io.LimitReader(response.Body, 1<<16))
+	errResponse := &errorResponse{}
+
+	if json.Unmarshal(data, errResponse) == nil && errResponse.Error.Message != "" {
+		message = errResponse.Error.Message
+	}
+
+	return status.Error(
+		HTTPStatusToCode(response.StatusCode),
+		fmt.Sprintf("request failed with status %d - %s", response.StatusCode, message),
+	)
+}
@@ -0,0 +1,44 @@
+package openaicompat_test
+
+import (
+	"fmt"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/connectors/openaicompat"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"net/http"
+	"testing"
+)
+
+func TestUtils(t *testing.T) {
+	t.Run("GetFinishReason", func(t *testing.T) {
+		assert.Equal(t, "LIMIT", openaicompat.GetFinishReason("length"))
+		assert.Equal(t, "DONE", openaicompat.GetFinishReason("stop"))
+		assert.Equal(t, "DONE", openaicompat.GetFinishReason(""))
+	})
+
+	t.Run("HTTPStatusToCode", func(t *testing.T) {
+		testCases := []struct {
+			StatusCode int
+			Expected   codes.Code
+		}{
+			{StatusCode: http.StatusBadRequest, Expected: codes.InvalidArgument},
+			{StatusCode: http.StatusUnauthorized, Expected: codes.Unauthenticated},
+			{StatusCode: http.StatusForbidden, Expected: codes.PermissionDenied},
+			{StatusCode: http.StatusNotFound, Expected: codes.NotFound},
+			{StatusCode: http.StatusRequestTimeout, Expected: codes.DeadlineExceeded},
+			{StatusCode: http.StatusTooManyRequests, Expected: codes.ResourceExhausted},
+			{StatusCode: http.StatusInternalServerError, Expected: codes.Unavailable},
+			{StatusCode: http.StatusServiceUnavailable, Expected: codes.Unavailable},
+			{StatusCode: http.StatusGatewayTimeout, Expected: codes.DeadlineExceeded},
+		}
+
+		for _, testCase := range testCases {
+			t.Run(
+				fmt.Sprintf("maps %d to %s", testCase.StatusCode, testCase.Expected),
+				func(t *testing.T) {
+					assert.Equal(t, testCase.Expected, openaicompat.HTTPStatusToCode(testCase.StatusCode))
+				},
+			)
+		}
+	})
+}
@@ -11,6 +11,20 @@ type PromptResultDTO struct { // skipcq: TCV-001
 	Content       *string
 	Error         error
 	RequestRecord *models.PromptRequestRecord
+	// ToolCalls are the tool calls requested by the model, they are set on the final result.
+	ToolCalls []ToolCallDTO
+	// ModelVendor and ModelType designate the model that served the request, they are set on the final result.
+	ModelVendor models.ModelVendor
+	ModelType   models.ModelType
+}
+
+// CachedPromptResponseDTO is a data type used to store a prompt response in the response cache.
+type CachedPromptResponseDTO struct { // skipcq: TCV-001
+	Content      string
+	FinishReason models.PromptFinishReason
+	ModelVendor  models.ModelVendor
+	ModelType    models.ModelType
+	ToolCalls    []ToolCallDTO
 }
 
 // RequestConfigurationDTO is a data type used encapsulate the current application prompt configuration.
@@ -19,8 +33,85 @@ type RequestConfigurationDTO struct { // skipcq: TCV-001
 	ApplicationID pgtype.UUID `json:"applicationUUID"`
 	// PromptConfigID is the promptConfig DB ID
 	PromptConfigID pgtype.UUID `json:"promptConfigId,omitempty"`
+	// PromptConfigVersionID is the DB ID of the prompt config version, it is invalid for prompt configs without versions
+	PromptConfigVersionID pgtype.UUID `json:"promptConfigVersionId,omitempty"`
 	// PromptConfigData the prompt config DB record
 	PromptConfigData datatypes.PromptConfigDTO `json:"promptConfigDTO"`
 	// ProviderModelPricing is the pricing information for the model vendor
 	ProviderModelPricing datatypes.ProviderModelPricingDTO `json:"providerModelPricing"`
+	// Fallbacks are the request configurations to try, in order, when the primary model is unavailable
+	Fallbacks []RequestConfigurationDTO `json:"fallbacks,omitempty"`
+	// TrafficSplit are the weighted variants the application splits the requests without a prompt config ID between
+	TrafficSplit []TrafficSplitVariantDTO `json:"trafficSplit,omitempty"`
+	// IsTrafficSplit designates that the prompt config was chosen by the application traffic split
+	IsTrafficSplit bool `json:"isTrafficSplit,omitempty"`
+	// PayloadLogging are the payload logging settings of the application, it is nil if payload logging is disabled
+	PayloadLogging *PayloadLoggingDTO `json:"payloadLogging,omitempty"`
+}
+
+// PayloadLoggingDTO is a data type used to encapsulate the payload logging settings of an application.
+type PayloadLoggingDTO struct { // skipcq: TCV-001
+	// RedactionRules are applied, in order, to the payloads before they are stored
+	RedactionRules []datatypes.PayloadRedactionRuleDTO `json:"redactionRules,omitempty"`
+}
+
+// PayloadMessageDTO is a data type used to store a rendered prompt message as part of a request payload.
+type PayloadMessageDTO struct { // skipcq: TCV-001
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// TrafficSplitVariantDTO is a data type used to encapsulate a weighted variant of an application traffic split.
+type TrafficSplitVariantDTO struct { // skipcq: TCV-001
+	Weight               int32                   `json:"weight"`
+	RequestConfiguration RequestConfigurationDTO `json:"requestConfiguration"`
+}
+
+// Attempts returns the request configurations to try, in order - the primary configuration followed by the fallbacks.
+func (r *RequestConfigurationDTO) Attempts() []*RequestConfigurationDTO {
+	attempts := []*RequestConfigurationDTO{r}
+	for i := range r.Fallbacks {
+		attempts = append(attempts, &r.Fallbacks[i])
+	}
+
+	return attempts
+}
+
+// ToolCallDTO is a data type used to encapsulate a tool call requested by a model, independent of the model vendor.
+type ToolCallDTO struct { // skipcq: TCV-001
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+}
+
+// ConversationMessageDTO is a data type used to encapsulate a message of a multi-turn conversation.
+type ConversationMessageDTO struct { // skipcq: TCV-001
+	Role    models.ConversationMessageRole
+	Content string
+	// ToolCallID is the ID of the tool call a tool message is the result of
+	ToolCallID *string
+	// ToolCalls are the tool calls requested by the model in an assistant message
+	ToolCalls []ToolCallDTO
+}
+
+// EmbeddingsConfigurationDTO is a data type used to encapsulate the configuration of an embeddings request.
+type EmbeddingsConfigurationDTO struct { // skipcq: TCV-001
+	// ApplicationID is the DB ID of the application making the request
+	ApplicationID pgtype.UUID
+	ModelVendor   models.ModelVendor
+	ModelType     models.ModelType
+	// Dimensions is the number of dimensions of the embedding vectors, nil for the model default
+	Dimensions *uint32
+	// InputType is the type of the inputs, used by the Cohere models, nil for the default
+	InputType *string
+	// ProviderModelPricing is the pricing information for the model vendor
+	ProviderModelPricing datatypes.ProviderModelPricingDTO
+}
+
+// EmbeddingsResultDTO is a data type used to encapsulate the result of an embeddings request.
+type EmbeddingsResultDTO struct { // skipcq: TCV-001
+	// Embeddings are the embedding vectors, in the order of the request inputs
+	Embeddings    [][]float32
+	Error         error
+	RequestRecord *models.PromptRequestRecord
 }
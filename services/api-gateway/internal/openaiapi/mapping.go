@@ -0,0 +1,207 @@
+package openaiapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/basemind-ai/monorepo/gen/go/gateway/v1"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/services"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/serialization"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"strings"
+)
+
+var chatRoleMap = map[string]gateway.ConversationRole{
+	"system":    gateway.ConversationRole_CONVERSATION_ROLE_SYSTEM,
+	"developer": gateway.ConversationRole_CONVERSATION_ROLE_SYSTEM,
+	"user":      gateway.ConversationRole_CONVERSATION_ROLE_USER,
+	"assistant": gateway.ConversationRole_CONVERSATION_ROLE_ASSISTANT,
+	"tool":      gateway.ConversationRole_CONVERSATION_ROLE_TOOL,
+}
+
+// ParseMessageContent returns the text content of a chat message - either a string,
+// or an array of text content parts which are joined.
+func ParseMessageContent(content json.RawMessage) (string, error) {
+	if len(content) == 0 || bytes.Equal(content, []byte("null")) {
+		return "", nil
+	}
+
+	var text string
+	if unmarshalErr := json.Unmarshal(content, &text); unmarshalErr == nil {
+		return text, nil
+	}
+
+	var parts []ContentPart
+	if unmarshalErr := json.Unmarshal(content, &parts); unmarshalErr != nil {
+		return "", status.Error(
+			codes.InvalidArgument,
+			"message content must be a string or an array of content parts",
+		)
+	}
+
+	var builder strings.Builder
+	for _, part := range parts {
+		if part.Type != "text" {
+			return "", status.Errorf(
+				codes.InvalidArgument,
+				"unsupported message content part type: %s",
+				part.Type,
+			)
+		}
+
+		builder.WriteString(part.Text)
+	}
+
+	return builder.String(), nil
+}
+
+// ParseChatMessages maps the messages of a chat completion request into the conversation history of
+// a gateway prompt request. The conversation history is validated by the gateway.
+func ParseChatMessages(messages []ChatMessage) ([]*gateway.ConversationMessage, error) {
+	conversationHistory := make([]*gateway.ConversationMessage, 0, len(messages))
+
+	for i, message := range messages {
+		role, ok := chatRoleMap[message.Role]
+		if !ok {
+			return nil, status.Errorf(
+				codes.InvalidArgument,
+				"invalid role for message %d: %s",
+				i,
+				message.Role,
+			)
+		}
+
+		content, contentErr := ParseMessageContent(message.Content)
+		if contentErr != nil {
+			return nil, contentErr
+		}
+
+		toolCalls := make([]*gateway.ToolCall, 0, len(message.ToolCalls))
+		for _, toolCall := range message.ToolCalls {
+			toolCalls = append(toolCalls, &gateway.ToolCall{
+				Id:        toolCall.ID,
+				Name:      toolCall.Function.Name,
+				Arguments: toolCall.Function.Arguments,
+			})
+		}
+
+		conversationHistory = append(conversationHistory, &gateway.ConversationMessage{
+			Role:       role,
+			Content:    content,
+			ToolCallId: message.ToolCallID,
+			ToolCalls:  toolCalls,
+		})
+	}
+
+	return conversationHistory, nil
+}
+
+// CreateToolCalls maps the tool calls of a gateway response into OpenAI tool calls.
+func CreateToolCalls(toolCalls []*gateway.ToolCall, isStream bool) []ToolCall {
+	if len(toolCalls) == 0 {
+		return nil
+	}
+
+	mappedToolCalls := make([]ToolCall, 0, len(toolCalls))
+	for i, toolCall := range toolCalls {
+		mappedToolCall := ToolCall{
+			ID:   toolCall.GetId(),
+			Type: "function",
+			Function: FunctionCall{
+				Name:      toolCall.GetName(),
+				Arguments: toolCall.GetArguments(),
+			},
+		}
+
+		if isStream {
+			index := i
+			mappedToolCall.Index = &index
+		}
+
+		mappedToolCalls = append(mappedToolCalls, mappedToolCall)
+	}
+
+	return mappedToolCalls
+}
+
+// MapFinishReason maps a gateway finish reason into an OpenAI finish reason.
+func MapFinishReason(finishReason string, hasToolCalls bool) string {
+	if hasToolCalls {
+		return "tool_calls"
+	}
+
+	if finishReason == string(models.PromptFinishReasonLIMIT) {
+		return "length"
+	}
+
+	return "stop"
+}
+
+// HTTPStatusFromCode maps a gRPC status code into the HTTP status of an error response.
+func HTTPStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.Canceled:
+		return 499
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// CreateErrorResponse creates the OpenAI error response of a gateway error.
+func CreateErrorResponse(err error) ErrorResponse {
+	grpcStatus := status.Convert(err)
+
+	errorType := "api_error"
+
+	switch HTTPStatusFromCode(grpcStatus.Code()) {
+	case http.StatusBadRequest, http.StatusNotFound, http.StatusConflict:
+		errorType = "invalid_request_error"
+	case http.StatusUnauthorized:
+		errorType = "authentication_error"
+	case http.StatusForbidden:
+		errorType = "permission_error"
+	case http.StatusTooManyRequests:
+		errorType = "rate_limit_error"
+		if grpcStatus.Message() == services.ErrorInsufficientCredits {
+			errorType = "insufficient_quota"
+		}
+	}
+
+	return ErrorResponse{
+		Error: ErrorDetail{
+			Message: grpcStatus.Message(),
+			Type:    errorType,
+		},
+	}
+}
+
+// RenderError renders a gateway error as an OpenAI error response.
+func RenderError(w http.ResponseWriter, err error) {
+	log.Debug().Err(err).Msg("openai api request failed")
+	serialization.RenderJSONResponse(
+		w,
+		HTTPStatusFromCode(status.Code(err)),
+		CreateErrorResponse(err),
+	)
+}
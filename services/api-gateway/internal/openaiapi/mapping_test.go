@@ -0,0 +1,147 @@
+package openaiapi_test
+
+import (
+	"encoding/json"
+	"github.com/basemind-ai/monorepo/gen/go/gateway/v1"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/openaiapi"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/services"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"testing"
+)
+
+func TestMapping(t *testing.T) { //nolint: revive
+	t.Run("ParseMessageContent", func(t *testing.T) {
+		t.Run("returns string content", func(t *testing.T) {
+			content, err := openaiapi.ParseMessageContent(json.RawMessage(`"hello"`))
+			assert.NoError(t, err)
+			assert.Equal(t, "hello", content)
+		})
+
+		t.Run("joins text content parts", func(t *testing.T) {
+			content, err := openaiapi.ParseMessageContent(
+				json.RawMessage(`[{"type":"text","text":"hello "},{"type":"text","text":"world"}]`),
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, "hello world", content)
+		})
+
+		t.Run("returns an empty string for missing content", func(t *testing.T) {
+			for _, rawContent := range []json.RawMessage{nil, json.RawMessage("null")} {
+				content, err := openaiapi.ParseMessageContent(rawContent)
+				assert.NoError(t, err)
+				assert.Empty(t, content)
+			}
+		})
+
+		t.Run("returns an error for non text content parts", func(t *testing.T) {
+			_, err := openaiapi.ParseMessageContent(
+				json.RawMessage(`[{"type":"image_url","image_url":{"url":"https://example.com"}}]`),
+			)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+
+		t.Run("returns an error for invalid content", func(t *testing.T) {
+			_, err := openaiapi.ParseMessageContent(json.RawMessage(`{"text":"hello"}`))
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	})
+
+	t.Run("ParseChatMessages", func(t *testing.T) {
+		t.Run("maps the messages into the conversation history", func(t *testing.T) {
+			conversationHistory, err := openaiapi.ParseChatMessages([]openaiapi.ChatMessage{
+				{Role: "system", Content: json.RawMessage(`"be nice"`)},
+				{Role: "user", Content: json.RawMessage(`"what is the weather?"`)},
+				{
+					Role: "assistant",
+					ToolCalls: []openaiapi.ToolCall{
+						{
+							ID:   "call-1",
+							Type: "function",
+							Function: openaiapi.FunctionCall{
+								Name:      "get_weather",
+								Arguments: `{"city":"Berlin"}`,
+							},
+						},
+					},
+				},
+				{Role: "tool", Content: json.RawMessage(`"sunny"`), ToolCallID: ptr.To("call-1")},
+			})
+			assert.NoError(t, err)
+			assert.Len(t, conversationHistory, 4)
+
+			assert.Equal(t, gateway.ConversationRole_CONVERSATION_ROLE_SYSTEM, conversationHistory[0].Role)
+			assert.Equal(t, "be nice", conversationHistory[0].Content)
+			assert.Equal(t, gateway.ConversationRole_CONVERSATION_ROLE_USER, conversationHistory[1].Role)
+			assert.Equal(t, gateway.ConversationRole_CONVERSATION_ROLE_ASSISTANT, conversationHistory[2].Role)
+			assert.Len(t, conversationHistory[2].ToolCalls, 1)
+			assert.Equal(t, "call-1", conversationHistory[2].ToolCalls[0].Id)
+			assert.Equal(t, "get_weather", conversationHistory[2].ToolCalls[0].Name)
+			assert.Equal(t, `{"city":"Berlin"}`, conversationHistory[2].ToolCalls[0].Arguments)
+			assert.Equal(t, gateway.ConversationRole_CONVERSATION_ROLE_TOOL, conversationHistory[3].Role)
+			assert.Equal(t, "call-1", *conversationHistory[3].ToolCallId)
+		})
+
+		t.Run("returns an error for an invalid role", func(t *testing.T) {
+			_, err := openaiapi.ParseChatMessages([]openaiapi.ChatMessage{
+				{Role: "function", Content: json.RawMessage(`"hello"`)},
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	})
+
+	t.Run("CreateToolCalls", func(t *testing.T) {
+		toolCalls := []*gateway.ToolCall{{Id: "call-1", Name: "get_weather", Arguments: "{}"}}
+
+		mappedToolCalls := openaiapi.CreateToolCalls(toolCalls, false)
+		assert.Len(t, mappedToolCalls, 1)
+		assert.Equal(t, "call-1", mappedToolCalls[0].ID)
+		assert.Equal(t, "function", mappedToolCalls[0].Type)
+		assert.Equal(t, "get_weather", mappedToolCalls[0].Function.Name)
+		assert.Nil(t, mappedToolCalls[0].Index)
+
+		streamedToolCalls := openaiapi.CreateToolCalls(toolCalls, true)
+		assert.Equal(t, 0, *streamedToolCalls[0].Index)
+
+		assert.Nil(t, openaiapi.CreateToolCalls(nil, false))
+	})
+
+	t.Run("MapFinishReason", func(t *testing.T) {
+		assert.Equal(t, "stop", openaiapi.MapFinishReason("DONE", false))
+		assert.Equal(t, "length", openaiapi.MapFinishReason("LIMIT", false))
+		assert.Equal(t, "tool_calls", openaiapi.MapFinishReason("DONE", true))
+	})
+
+	t.Run("HTTPStatusFromCode", func(t *testing.T) {
+		for code, expectedStatus := range map[codes.Code]int{
+			codes.InvalidArgument:   http.StatusBadRequest,
+			codes.Unauthenticated:   http.StatusUnauthorized,
+			codes.NotFound:          http.StatusNotFound,
+			codes.ResourceExhausted: http.StatusTooManyRequests,
+			codes.Unavailable:       http.StatusServiceUnavailable,
+			codes.Internal:          http.StatusInternalServerError,
+		} {
+			assert.Equal(t, expectedStatus, openaiapi.HTTPStatusFromCode(code))
+		}
+	})
+
+	t.Run("CreateErrorResponse", func(t *testing.T) {
+		for _, testCase := range []struct {
+			err          error
+			expectedType string
+		}{
+			{status.Error(codes.InvalidArgument, "invalid"), "invalid_request_error"},
+			{status.Error(codes.Unauthenticated, "invalid token"), "authentication_error"},
+			{status.Error(codes.ResourceExhausted, "rate limit exceeded"), "rate_limit_error"},
+			{status.Error(codes.ResourceExhausted, services.ErrorInsufficientCredits), "insufficient_quota"},
+			{status.Error(codes.Internal, "error"), "api_error"},
+		} {
+			errorResponse := openaiapi.CreateErrorResponse(testCase.err)
+			assert.Equal(t, testCase.expectedType, errorResponse.Error.Type)
+			assert.Equal(t, status.Convert(testCase.err).Message(), errorResponse.Error.Message)
+		}
+	})
+}
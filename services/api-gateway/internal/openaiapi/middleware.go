@@ -0,0 +1,74 @@
+package openaiapi
+
+import (
+	"context"
+	"github.com/basemind-ai/monorepo/shared/go/grpcutils"
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"strconv"
+)
+
+type tokenCounterContextKeyType int
+
+// tokenCounterContextKey is the key used to store the token counter of a rate limited request in the context.
+const tokenCounterContextKey tokenCounterContextKeyType = iota
+
+// AuthMiddleware authenticates the requests using the auth handler of the gRPC server,
+// which expects the API key JWT as a bearer token in the authorization header.
+// The application ID, project ID and rate limits set by the auth handler are added to the request context.
+func AuthMiddleware(authHandler auth.AuthFunc) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			incomingContext := metadata.NewIncomingContext(
+				r.Context(),
+				metadata.Pairs("authorization", r.Header.Get("Authorization")),
+			)
+
+			authContext, authErr := authHandler(incomingContext)
+			if authErr != nil {
+				RenderError(w, authErr)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(authContext))
+		})
+	}
+}
+
+// RateLimitMiddleware enforces the rate limits set in the context by the AuthMiddleware,
+// counting the tokens reported by the handler against the token limits once the request has finished.
+func RateLimitMiddleware(rateLimiter *grpcutils.RateLimiter) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			limits, ok := r.Context().Value(grpcutils.RateLimitsContextKey).(grpcutils.RateLimits)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if retryAfter, allowed := rateLimiter.Allow(r.Context(), limits); !allowed {
+				w.Header().Set(
+					"Retry-After",
+					strconv.FormatInt(grpcutils.RetryAfterSeconds(retryAfter), 10),
+				)
+				RenderError(w, status.Error(codes.ResourceExhausted, "rate limit exceeded"))
+				return
+			}
+
+			var tokens int64
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), tokenCounterContextKey, &tokens)))
+			rateLimiter.ConsumeTokens(r.Context(), limits, tokens)
+		})
+	}
+}
+
+// countTokens counts the given tokens against the rate limits of the request, if it is rate limited.
+func countTokens(ctx context.Context, tokens uint32) {
+	if counter, ok := ctx.Value(tokenCounterContextKey).(*int64); ok {
+		*counter += int64(tokens)
+	}
+}
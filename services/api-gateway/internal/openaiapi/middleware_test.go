@@ -0,0 +1,168 @@
+package openaiapi_test
+
+import (
+	"context"
+	"github.com/basemind-ai/monorepo/gen/go/gateway/v1"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/openaiapi"
+	"github.com/basemind-ai/monorepo/shared/go/grpcutils"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/go-redis/redismock/v9"
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/auth"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) { //nolint: revive
+	t.Run("AuthMiddleware", func(t *testing.T) {
+		authHandler := func(ctx context.Context) (context.Context, error) {
+			token, err := auth.AuthFromMD(ctx, "bearer")
+			if err != nil || token != "valid-token" {
+				return nil, status.Error(codes.Unauthenticated, "invalid auth token")
+			}
+
+			return context.WithValue(ctx, grpcutils.ApplicationIDContextKey, "application-id"), nil
+		}
+
+		handler := openaiapi.AuthMiddleware(authHandler)(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "application-id", r.Context().Value(grpcutils.ApplicationIDContextKey))
+				w.WriteHeader(http.StatusOK)
+			}),
+		)
+
+		t.Run("adds the auth context to the request", func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/v1/models", nil)
+			request.Header.Set("Authorization", "Bearer valid-token")
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		})
+
+		t.Run("responds with status 401 UNAUTHORIZED for an invalid token", func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/v1/models", nil)
+			request.Header.Set("Authorization", "Bearer invalid-token")
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		})
+
+		t.Run("responds with status 401 UNAUTHORIZED without an auth header", func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/v1/models", nil))
+			assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		})
+	})
+
+	t.Run("RateLimitMiddleware", func(t *testing.T) {
+		limits := grpcutils.RateLimits{
+			ApplicationID:           "application-id",
+			APIKeyID:                "api-key-id",
+			APIKeyRequestsPerMinute: 10,
+		}
+
+		windowKeyPattern := "^ratelimit:\\{application-id\\}:api-key:api-key-id:requests:\\d+$"
+
+		expectCheck := func(mockRedis redismock.ClientMock) *redismock.ExpectedCmd {
+			return mockRedis.Regexp().ExpectEvalSha(
+				"^[0-9a-f]{40}$",
+				[]string{windowKeyPattern, windowKeyPattern},
+				"^[01]\\.\\d{6}$",
+				int64(120000),
+				int32(10), int64(1), int64(1),
+			)
+		}
+
+		// the handler streams a finished response, which reports the tokens of the request
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_ = openaiapi.NewEventStream(r.Context(), w, "chatcmpl-123", 0, "default", false).
+				Send(&gateway.StreamingPromptResponse{
+					FinishReason:   ptr.To("DONE"),
+					RequestTokens:  ptr.To(uint32(10)),
+					ResponseTokens: ptr.To(uint32(20)),
+				})
+		})
+
+		createRequest := func(limits any) *http.Request {
+			request := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+			return request.WithContext(
+				context.WithValue(request.Context(), grpcutils.RateLimitsContextKey, limits),
+			)
+		}
+
+		t.Run("allows requests within the limits", func(t *testing.T) {
+			redisClient, mockRedis := redismock.NewClientMock()
+			expectCheck(mockRedis).SetVal(int64(0))
+
+			recorder := httptest.NewRecorder()
+			openaiapi.RateLimitMiddleware(grpcutils.NewRateLimiter(redisClient))(handler).
+				ServeHTTP(recorder, createRequest(limits))
+
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.NoError(t, mockRedis.ExpectationsWereMet())
+		})
+
+		t.Run("counts the tokens of the request against the token limits", func(t *testing.T) {
+			tokenLimits := limits
+			tokenLimits.APIKeyRequestsPerMinute = 0
+			tokenLimits.APIKeyTokensPerMinute = 100
+
+			tokensWindowKeyPattern := "^ratelimit:\\{application-id\\}:api-key:api-key-id:tokens:\\d+$"
+
+			redisClient, mockRedis := redismock.NewClientMock()
+			mockRedis.Regexp().ExpectEvalSha(
+				"^[0-9a-f]{40}$",
+				[]string{tokensWindowKeyPattern, tokensWindowKeyPattern},
+				"^[01]\\.\\d{6}$",
+				int64(120000),
+				int32(100), int64(1), int64(0),
+			).SetVal(int64(0))
+			mockRedis.Regexp().ExpectEvalSha(
+				"^[0-9a-f]{40}$",
+				[]string{tokensWindowKeyPattern},
+				int64(30),
+				int64(120000),
+			).SetVal(int64(0))
+
+			recorder := httptest.NewRecorder()
+			openaiapi.RateLimitMiddleware(grpcutils.NewRateLimiter(redisClient))(handler).
+				ServeHTTP(recorder, createRequest(tokenLimits))
+
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.NoError(t, mockRedis.ExpectationsWereMet())
+		})
+
+		t.Run("responds with status 429 TOO MANY REQUESTS when a limit is exceeded", func(t *testing.T) {
+			redisClient, mockRedis := redismock.NewClientMock()
+			expectCheck(mockRedis).SetVal(int64(1))
+
+			recorder := httptest.NewRecorder()
+			openaiapi.RateLimitMiddleware(grpcutils.NewRateLimiter(redisClient))(handler).
+				ServeHTTP(recorder, createRequest(limits))
+
+			assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+
+			retryAfter, parseErr := strconv.Atoi(recorder.Header().Get("Retry-After"))
+			assert.NoError(t, parseErr)
+			assert.GreaterOrEqual(t, retryAfter, 1)
+			assert.LessOrEqual(t, retryAfter, 60)
+		})
+
+		t.Run("does not limit requests without rate limits", func(t *testing.T) {
+			redisClient, mockRedis := redismock.NewClientMock()
+
+			recorder := httptest.NewRecorder()
+			openaiapi.RateLimitMiddleware(grpcutils.NewRateLimiter(redisClient))(handler).
+				ServeHTTP(recorder, createRequest(nil))
+
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.NoError(t, mockRedis.ExpectationsWereMet())
+		})
+	})
+}
@@ -0,0 +1,263 @@
+package openaiapi
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/basemind-ai/monorepo/gen/go/gateway/v1"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/services"
+	"github.com/basemind-ai/monorepo/shared/go/cryptoutils"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/grpcutils"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/basemind-ai/monorepo/shared/go/serialization"
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"time"
+)
+
+const (
+	ChatCompletionsEndpoint = "/chat/completions"
+	ModelsEndpoint          = "/models"
+)
+
+const (
+	// PromptConfigIDHeader is the header designating the ID of the prompt config to use.
+	// It takes precedence over the model of the request.
+	PromptConfigIDHeader = "X-Prompt-Config-Id"
+	// DefaultModelAlias is the model designating the application default prompt config,
+	// or its traffic split if it has one.
+	DefaultModelAlias = "default"
+	// MaxRequestBodySize is the maximal size of a request body in bytes,
+	// matching the default maximal message size of the gRPC server.
+	MaxRequestBodySize = 4 * 1024 * 1024
+)
+
+// RegisterHandlers registers the OpenAI compatible endpoints.
+func RegisterHandlers(mux *chi.Mux) {
+	mux.Route("/v1", func(router chi.Router) {
+		router.Post(ChatCompletionsEndpoint, handleCreateChatCompletion)
+		router.Get(ModelsEndpoint, handleRetrieveModels)
+	})
+}
+
+// createCompletionID creates a random chat completion ID.
+func createCompletionID() string {
+	return fmt.Sprintf("chatcmpl-%s", hex.EncodeToString(cryptoutils.RandomBytes(12)))
+}
+
+// ResolvePromptConfigID resolves the ID of the prompt config of a chat completion request -
+// the ID set in the PromptConfigIDHeader, or the prompt config with the name or ID given as the model.
+// Returns nil for the DefaultModelAlias, in which case the gateway uses the application default prompt config.
+func ResolvePromptConfigID(
+	ctx context.Context,
+	applicationID pgtype.UUID,
+	promptConfigIDHeader string,
+	model string,
+) (*string, error) {
+	if promptConfigIDHeader != "" {
+		return &promptConfigIDHeader, nil
+	}
+
+	if model == "" {
+		return nil, status.Error(codes.InvalidArgument, "model is required")
+	}
+
+	if model == DefaultModelAlias {
+		return nil, nil
+	}
+
+	promptConfigs, retrievalErr := db.GetQueries().RetrievePromptConfigs(ctx, applicationID)
+	if retrievalErr != nil {
+		log.Error().Err(retrievalErr).Msg("failed to retrieve prompt configs")
+		return nil, status.Error(codes.Internal, "failed to retrieve the application prompt configs")
+	}
+
+	for _, promptConfig := range promptConfigs {
+		promptConfigID := db.UUIDToString(&promptConfig.ID)
+		if promptConfig.Name == model || promptConfigID == model {
+			return &promptConfigID, nil
+		}
+	}
+
+	return nil, status.Errorf(codes.NotFound, "the model %s does not exist", model)
+}
+
+// CreatePromptRequest maps a chat completion request into a gateway prompt request.
+func CreatePromptRequest(
+	request ChatCompletionRequest,
+	promptConfigID *string,
+) (*gateway.PromptRequest, error) {
+	conversationHistory, parseErr := ParseChatMessages(request.Messages)
+	if parseErr != nil {
+		return nil, parseErr
+	}
+
+	return &gateway.PromptRequest{
+		TemplateVariables:   request.TemplateVariables,
+		PromptConfigId:      promptConfigID,
+		ConversationHistory: conversationHistory,
+		ConversationId:      request.ConversationID,
+		UserKey:             request.User,
+	}, nil
+}
+
+// CreateChatCompletion maps a gateway prompt response into a chat completion.
+func CreateChatCompletion(
+	id string,
+	created int64,
+	model string,
+	response *gateway.PromptResponse,
+) ChatCompletion {
+	var content *string
+	if response.GetContent() != "" || len(response.GetToolCalls()) == 0 {
+		content = ptr.To(response.GetContent())
+	}
+
+	return ChatCompletion{
+		ID:      id,
+		Object:  "chat.completion",
+		Created: created,
+		Model:   model,
+		Choices: []ChatCompletionChoice{
+			{
+				Message: ChatResponseMessage{
+					Role:      "assistant",
+					Content:   content,
+					ToolCalls: CreateToolCalls(response.GetToolCalls(), false),
+				},
+				FinishReason: MapFinishReason("", len(response.GetToolCalls()) > 0),
+			},
+		},
+		Usage: Usage{
+			PromptTokens:     response.GetRequestTokens(),
+			CompletionTokens: response.GetResponseTokens(),
+			TotalTokens:      response.GetRequestTokens() + response.GetResponseTokens(),
+		},
+	}
+}
+
+// handleCreateChatCompletion - creates a chat completion using the prompt config designated by the request.
+// The request is handled by the API gateway service, and is streamed as server sent events if requested.
+func handleCreateChatCompletion(w http.ResponseWriter, r *http.Request) {
+	applicationID, ok := r.Context().Value(grpcutils.ApplicationIDContextKey).(pgtype.UUID)
+	if !ok {
+		RenderError(w, status.Error(codes.Unauthenticated, services.ErrorApplicationIDNotInContext))
+		return
+	}
+
+	request := ChatCompletionRequest{}
+	if deserializationErr := serialization.DeserializeJSON(
+		http.MaxBytesReader(w, r.Body, MaxRequestBodySize),
+		&request,
+	); deserializationErr != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(deserializationErr, &maxBytesErr) {
+			serialization.RenderJSONResponse(w, http.StatusRequestEntityTooLarge, ErrorResponse{
+				Error: ErrorDetail{
+					Message: fmt.Sprintf("request body exceeds %d bytes", MaxRequestBodySize),
+					Type:    "invalid_request_error",
+				},
+			})
+			return
+		}
+
+		RenderError(w, status.Errorf(codes.InvalidArgument, "invalid request body: %v", deserializationErr))
+		return
+	}
+
+	promptConfigID, resolveErr := ResolvePromptConfigID(
+		r.Context(),
+		applicationID,
+		r.Header.Get(PromptConfigIDHeader),
+		request.Model,
+	)
+	if resolveErr != nil {
+		RenderError(w, resolveErr)
+		return
+	}
+
+	promptRequest, mappingErr := CreatePromptRequest(request, promptConfigID)
+	if mappingErr != nil {
+		RenderError(w, mappingErr)
+		return
+	}
+
+	id := createCompletionID()
+	created := time.Now().Unix()
+
+	if !request.Stream {
+		response, requestErr := services.APIGatewayServer{}.RequestPrompt(r.Context(), promptRequest)
+		if requestErr != nil {
+			RenderError(w, requestErr)
+			return
+		}
+
+		countTokens(r.Context(), response.GetRequestTokens()+response.GetResponseTokens())
+
+		serialization.RenderJSONResponse(
+			w,
+			http.StatusOK,
+			CreateChatCompletion(id, created, request.Model, response),
+		)
+
+		return
+	}
+
+	eventStream := NewEventStream(
+		r.Context(),
+		w,
+		id,
+		created,
+		request.Model,
+		request.StreamOptions != nil && request.StreamOptions.IncludeUsage,
+	)
+
+	streamErr := services.APIGatewayServer{}.RequestStreamingPrompt(promptRequest, eventStream)
+	if streamErr != nil {
+		if !eventStream.IsStarted() {
+			RenderError(w, streamErr)
+			return
+		}
+
+		log.Debug().Err(streamErr).Msg("openai api stream failed")
+		_ = eventStream.WriteEvent(CreateErrorResponse(streamErr))
+
+		return
+	}
+
+	_ = eventStream.WriteEvent("[DONE]")
+}
+
+// handleRetrieveModels - lists the prompt configs of the application as models, identified by their name.
+func handleRetrieveModels(w http.ResponseWriter, r *http.Request) {
+	applicationID, ok := r.Context().Value(grpcutils.ApplicationIDContextKey).(pgtype.UUID)
+	if !ok {
+		RenderError(w, status.Error(codes.Unauthenticated, services.ErrorApplicationIDNotInContext))
+		return
+	}
+
+	promptConfigs, retrievalErr := db.GetQueries().RetrievePromptConfigs(r.Context(), applicationID)
+	if retrievalErr != nil {
+		log.Error().Err(retrievalErr).Msg("failed to retrieve prompt configs")
+		RenderError(w, status.Error(codes.Internal, "failed to retrieve the application prompt configs"))
+		return
+	}
+
+	modelList := ModelList{Object: "list", Data: make([]Model, 0, len(promptConfigs))}
+	for _, promptConfig := range promptConfigs {
+		modelList.Data = append(modelList.Data, Model{
+			ID:      promptConfig.Name,
+			Object:  "model",
+			Created: promptConfig.CreatedAt.Time.Unix(),
+			OwnedBy: "basemind",
+		})
+	}
+
+	serialization.RenderJSONResponse(w, http.StatusOK, modelList)
+}
@@ -0,0 +1,318 @@
+package openaiapi_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/basemind-ai/monorepo/e2e/factories"
+	openaiconnector "github.com/basemind-ai/monorepo/gen/go/openai/v1"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/connectors"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/openaiapi"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/grpcutils"
+	"github.com/basemind-ai/monorepo/shared/go/jwtutils"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/basemind-ai/monorepo/shared/go/rediscache"
+	"github.com/basemind-ai/monorepo/shared/go/router"
+	"github.com/basemind-ai/monorepo/shared/go/serialization"
+	"github.com/basemind-ai/monorepo/shared/go/testutils"
+	"github.com/go-redis/cache/v9"
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const JWTSecret = "ABC123"
+
+func TestMain(m *testing.M) {
+	cleanup := testutils.CreateNamespaceTestDBModule("openaiapi-test")
+	defer cleanup()
+	m.Run()
+}
+
+func createOpenAIService(t *testing.T) *testutils.MockOpenAIService {
+	t.Helper()
+
+	t.Setenv("OPENAI_CONNECTOR_ADDRESS", "")
+	t.Setenv("COHERE_CONNECTOR_ADDRESS", "")
+
+	mockService := &testutils.MockOpenAIService{T: t}
+	listener := testutils.CreateTestGRPCServer[openaiconnector.OpenAIServiceServer](
+		t,
+		openaiconnector.RegisterOpenAIServiceServer,
+		mockService,
+	)
+
+	connectors.Init(
+		context.TODO(),
+		grpc.WithContextDialer(
+			func(context.Context, string) (net.Conn, error) {
+				return listener.Dial()
+			},
+		),
+	)
+
+	return mockService
+}
+
+// createTestCache sets a mock redis client, in which every lookup is a cache miss.
+// The given number of cache writes are expected, regardless of their keys and values.
+func createTestCache(t *testing.T, expectedWrites int) {
+	t.Helper()
+
+	redisDB, mockRedis := redismock.NewClientMock()
+	rediscache.SetClient(cache.New(&cache.Options{Redis: redisDB}))
+
+	for range expectedWrites {
+		mockRedis.CustomMatch(func(_, _ []any) error {
+			return nil
+		}).ExpectSet("key", "value", time.Minute).SetVal("OK")
+	}
+}
+
+func TestOpenAIAPI(t *testing.T) { //nolint: revive
+	testutils.SetTestEnv(t)
+
+	project, _ := factories.CreateProject(context.TODO())
+	_ = factories.CreateProviderPricingModels(context.TODO())
+	application, _ := factories.CreateApplication(context.TODO(), project.ID)
+	promptConfig, _ := factories.CreateOpenAIPromptConfig(context.TODO(), application.ID)
+
+	apiKey, _ := factories.CreateApplicationInternalAPIKey(context.TODO(), application.ID)
+	jwtToken, jwtCreateErr := jwtutils.CreateJWT(
+		time.Minute,
+		[]byte(JWTSecret),
+		db.UUIDToString(&apiKey.ID),
+	)
+	assert.NoError(t, jwtCreateErr)
+
+	server := httptest.NewServer(router.New(router.Options{
+		Environment:      "test",
+		ServiceName:      "test",
+		RegisterHandlers: openaiapi.RegisterHandlers,
+		Middlewares: []func(next http.Handler) http.Handler{
+			openaiapi.AuthMiddleware(grpcutils.NewAuthHandler(JWTSecret).HandleAuth),
+		},
+	}))
+	t.Cleanup(server.Close)
+
+	doRequest := func(
+		t *testing.T,
+		method string,
+		endpoint string,
+		body any,
+		headers map[string]string,
+	) *http.Response {
+		t.Helper()
+
+		var requestBody bytes.Buffer
+		if body != nil {
+			requestBody.Write(serialization.SerializeJSON(body))
+		}
+
+		request, requestErr := http.NewRequestWithContext(
+			context.TODO(),
+			method,
+			fmt.Sprintf("%s/v1%s", server.URL, endpoint),
+			&requestBody,
+		)
+		assert.NoError(t, requestErr)
+
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", jwtToken))
+		for key, value := range headers {
+			request.Header.Set(key, value)
+		}
+
+		response, responseErr := server.Client().Do(request)
+		assert.NoError(t, responseErr)
+
+		t.Cleanup(func() {
+			_ = response.Body.Close()
+		})
+
+		return response
+	}
+
+	t.Run(fmt.Sprintf("GET: %s", openaiapi.ModelsEndpoint), func(t *testing.T) {
+		t.Run("lists the application prompt configs as models", func(t *testing.T) {
+			response := doRequest(t, http.MethodGet, openaiapi.ModelsEndpoint, nil, nil)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+
+			modelList := openaiapi.ModelList{}
+			assert.NoError(t, serialization.DeserializeJSON(response.Body, &modelList))
+			assert.Equal(t, "list", modelList.Object)
+			assert.Len(t, modelList.Data, 1)
+			assert.Equal(t, promptConfig.Name, modelList.Data[0].ID)
+			assert.Equal(t, "model", modelList.Data[0].Object)
+		})
+
+		t.Run("responds with status 401 UNAUTHORIZED for an invalid token", func(t *testing.T) {
+			response := doRequest(
+				t,
+				http.MethodGet,
+				openaiapi.ModelsEndpoint,
+				nil,
+				map[string]string{"Authorization": "Bearer invalid"},
+			)
+			assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+
+			errorResponse := openaiapi.ErrorResponse{}
+			assert.NoError(t, serialization.DeserializeJSON(response.Body, &errorResponse))
+			assert.Equal(t, "authentication_error", errorResponse.Error.Type)
+		})
+	})
+
+	t.Run("ResolvePromptConfigID", func(t *testing.T) {
+		promptConfigID := db.UUIDToString(&promptConfig.ID)
+
+		t.Run("resolves the prompt config from the header", func(t *testing.T) {
+			resolvedID, err := openaiapi.ResolvePromptConfigID(
+				context.TODO(), application.ID, promptConfigID, "unknown",
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, promptConfigID, *resolvedID)
+		})
+
+		t.Run("resolves the prompt config by name and ID", func(t *testing.T) {
+			for _, model := range []string{promptConfig.Name, promptConfigID} {
+				resolvedID, err := openaiapi.ResolvePromptConfigID(
+					context.TODO(), application.ID, "", model,
+				)
+				assert.NoError(t, err)
+				assert.Equal(t, promptConfigID, *resolvedID)
+			}
+		})
+
+		t.Run("resolves the default alias to the application default", func(t *testing.T) {
+			resolvedID, err := openaiapi.ResolvePromptConfigID(
+				context.TODO(), application.ID, "", openaiapi.DefaultModelAlias,
+			)
+			assert.NoError(t, err)
+			assert.Nil(t, resolvedID)
+		})
+	})
+
+	t.Run(fmt.Sprintf("POST: %s", openaiapi.ChatCompletionsEndpoint), func(t *testing.T) {
+		t.Run("creates a chat completion", func(t *testing.T) {
+			openaiService := createOpenAIService(t)
+			createTestCache(t, 3)
+
+			openaiService.Response = &openaiconnector.OpenAIPromptResponse{
+				Content:             "Response content",
+				FinishReason:        "DONE",
+				RequestTokensCount:  10,
+				ResponseTokensCount: 20,
+			}
+
+			response := doRequest(t, http.MethodPost, openaiapi.ChatCompletionsEndpoint, openaiapi.ChatCompletionRequest{
+				Model: promptConfig.Name,
+				Messages: []openaiapi.ChatMessage{
+					{Role: "user", Content: json.RawMessage(`"and another thing"`)},
+				},
+				TemplateVariables: map[string]string{"userInput": "abc"},
+			}, nil)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+
+			chatCompletion := openaiapi.ChatCompletion{}
+			assert.NoError(t, serialization.DeserializeJSON(response.Body, &chatCompletion))
+			assert.Equal(t, "chat.completion", chatCompletion.Object)
+			assert.Equal(t, promptConfig.Name, chatCompletion.Model)
+			assert.Len(t, chatCompletion.Choices, 1)
+			assert.Equal(t, "assistant", chatCompletion.Choices[0].Message.Role)
+			assert.Equal(t, "Response content", *chatCompletion.Choices[0].Message.Content)
+			assert.Equal(t, "stop", chatCompletion.Choices[0].FinishReason)
+			assert.Equal(t, uint32(10), chatCompletion.Usage.PromptTokens)
+			assert.Equal(t, uint32(20), chatCompletion.Usage.CompletionTokens)
+			assert.Equal(t, uint32(30), chatCompletion.Usage.TotalTokens)
+		})
+
+		t.Run("streams a chat completion", func(t *testing.T) {
+			openaiService := createOpenAIService(t)
+			createTestCache(t, 3)
+
+			openaiService.Stream = []*openaiconnector.OpenAIStreamResponse{
+				{Content: "1"},
+				{Content: "2"},
+				{
+					FinishReason:        ptr.To("DONE"),
+					RequestTokensCount:  ptr.To(uint32(10)),
+					ResponseTokensCount: ptr.To(uint32(20)),
+				},
+			}
+
+			response := doRequest(t, http.MethodPost, openaiapi.ChatCompletionsEndpoint, openaiapi.ChatCompletionRequest{
+				Model:             openaiapi.DefaultModelAlias,
+				Stream:            true,
+				StreamOptions:     &openaiapi.StreamOptions{IncludeUsage: true},
+				TemplateVariables: map[string]string{"userInput": "abc"},
+			}, nil)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+			assert.Equal(t, "text/event-stream", response.Header.Get("Content-Type"))
+
+			recorder := httptest.NewRecorder()
+			_, _ = recorder.Body.ReadFrom(response.Body)
+
+			events := parseEvents(t, recorder)
+			assert.Len(t, events, 5)
+			assert.Equal(t, "1", parseChunk(t, events[0]).Choices[0].Delta.Content)
+			assert.Equal(t, "2", parseChunk(t, events[1]).Choices[0].Delta.Content)
+			assert.Equal(t, "stop", *parseChunk(t, events[2]).Choices[0].FinishReason)
+			assert.Equal(t, uint32(30), parseChunk(t, events[3]).Usage.TotalTokens)
+			assert.Equal(t, "[DONE]", events[4])
+		})
+
+		t.Run("responds with status 400 BAD REQUEST for missing template variables", func(t *testing.T) {
+			createTestCache(t, 3)
+
+			response := doRequest(t, http.MethodPost, openaiapi.ChatCompletionsEndpoint, openaiapi.ChatCompletionRequest{
+				Model: promptConfig.Name,
+			}, nil)
+			assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+		})
+
+		t.Run("responds with status 400 BAD REQUEST for an invalid message role", func(t *testing.T) {
+			response := doRequest(t, http.MethodPost, openaiapi.ChatCompletionsEndpoint, openaiapi.ChatCompletionRequest{
+				Model: promptConfig.Name,
+				Messages: []openaiapi.ChatMessage{
+					{Role: "function", Content: json.RawMessage(`"abc"`)},
+				},
+			}, nil)
+			assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+			errorResponse := openaiapi.ErrorResponse{}
+			assert.NoError(t, serialization.DeserializeJSON(response.Body, &errorResponse))
+			assert.Equal(t, "invalid_request_error", errorResponse.Error.Type)
+		})
+
+		t.Run("responds with status 400 BAD REQUEST for an invalid body", func(t *testing.T) {
+			response := doRequest(t, http.MethodPost, openaiapi.ChatCompletionsEndpoint, "invalid", nil)
+			assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+		})
+
+		t.Run("responds with status 413 REQUEST ENTITY TOO LARGE for a body exceeding the limit", func(t *testing.T) {
+			response := doRequest(t, http.MethodPost, openaiapi.ChatCompletionsEndpoint, openaiapi.ChatCompletionRequest{
+				Model:             promptConfig.Name,
+				TemplateVariables: map[string]string{"userInput": strings.Repeat("a", openaiapi.MaxRequestBodySize)},
+			}, nil)
+			assert.Equal(t, http.StatusRequestEntityTooLarge, response.StatusCode)
+
+			errorResponse := openaiapi.ErrorResponse{}
+			assert.NoError(t, serialization.DeserializeJSON(response.Body, &errorResponse))
+			assert.Equal(t, "invalid_request_error", errorResponse.Error.Type)
+		})
+
+		t.Run("responds with status 404 NOT FOUND for an unknown model", func(t *testing.T) {
+			response := doRequest(t, http.MethodPost, openaiapi.ChatCompletionsEndpoint, openaiapi.ChatCompletionRequest{
+				Model: "unknown",
+			}, nil)
+			assert.Equal(t, http.StatusNotFound, response.StatusCode)
+		})
+	})
+}
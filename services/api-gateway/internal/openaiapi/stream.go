@@ -0,0 +1,161 @@
+package openaiapi
+
+import (
+	"context"
+	"fmt"
+	"github.com/basemind-ai/monorepo/gen/go/gateway/v1"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/basemind-ai/monorepo/shared/go/serialization"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"net/http"
+)
+
+// EventStream adapts an HTTP response to the server stream of the RequestStreamingPrompt handler,
+// writing the streaming prompt responses as server sent chat completion chunks.
+type EventStream struct {
+	ctx          context.Context
+	writer       http.ResponseWriter
+	controller   *http.ResponseController
+	id           string
+	created      int64
+	model        string
+	includeUsage bool
+	isStarted    bool
+}
+
+// NewEventStream creates an EventStream writing the chunks of the given chat completion to the response.
+func NewEventStream(
+	ctx context.Context,
+	w http.ResponseWriter,
+	id string,
+	created int64,
+	model string,
+	includeUsage bool,
+) *EventStream {
+	return &EventStream{
+		ctx:          ctx,
+		writer:       w,
+		controller:   http.NewResponseController(w),
+		id:           id,
+		created:      created,
+		model:        model,
+		includeUsage: includeUsage,
+	}
+}
+
+// IsStarted returns true if the response headers were sent, after which errors are sent as events.
+func (s *EventStream) IsStarted() bool {
+	return s.isStarted
+}
+
+// WriteEvent writes the given data as a server sent event.
+func (s *EventStream) WriteEvent(data any) error {
+	if !s.isStarted {
+		s.writer.Header().Set("Content-Type", "text/event-stream")
+		s.writer.Header().Set("Cache-Control", "no-cache")
+		s.writer.Header().Set("Connection", "keep-alive")
+		s.writer.WriteHeader(http.StatusOK)
+		s.isStarted = true
+	}
+
+	var payload []byte
+	if text, isText := data.(string); isText {
+		payload = []byte(text)
+	} else {
+		payload = serialization.SerializeJSON(data)
+	}
+
+	if _, writeErr := fmt.Fprintf(s.writer, "data: %s\n\n", payload); writeErr != nil {
+		return writeErr
+	}
+
+	return s.controller.Flush()
+}
+
+// createChunk creates a chunk of the chat completion with the given choices.
+func (s *EventStream) createChunk(choices []ChatCompletionChunkChoice) ChatCompletionChunk {
+	return ChatCompletionChunk{
+		ID:      s.id,
+		Object:  "chat.completion.chunk",
+		Created: s.created,
+		Model:   s.model,
+		Choices: choices,
+	}
+}
+
+// Send writes a streaming prompt response as a chunk. The chunk of a finished stream has a finish reason,
+// and is followed by a usage chunk if the request included usage in the stream options.
+// Error responses are not written, the error returned by the handler is written instead.
+func (s *EventStream) Send(response *gateway.StreamingPromptResponse) error {
+	finishReason := response.GetFinishReason()
+	if finishReason == string(models.PromptFinishReasonERROR) {
+		return nil
+	}
+
+	delta := ChatDelta{Content: response.GetContent()}
+	if !s.isStarted {
+		delta.Role = "assistant"
+	}
+
+	choice := ChatCompletionChunkChoice{Delta: delta}
+
+	if response.FinishReason != nil {
+		choice.Delta.ToolCalls = CreateToolCalls(response.GetToolCalls(), true)
+		choice.FinishReason = ptr.To(MapFinishReason(finishReason, len(response.GetToolCalls()) > 0))
+
+		countTokens(s.ctx, response.GetRequestTokens()+response.GetResponseTokens())
+	}
+
+	if writeErr := s.WriteEvent(s.createChunk([]ChatCompletionChunkChoice{choice})); writeErr != nil {
+		return writeErr
+	}
+
+	if response.FinishReason != nil && s.includeUsage {
+		usageChunk := s.createChunk([]ChatCompletionChunkChoice{})
+		usageChunk.Usage = &Usage{
+			PromptTokens:     response.GetRequestTokens(),
+			CompletionTokens: response.GetResponseTokens(),
+			TotalTokens:      response.GetRequestTokens() + response.GetResponseTokens(),
+		}
+
+		return s.WriteEvent(usageChunk)
+	}
+
+	return nil
+}
+
+// SendMsg writes the given message, which must be a streaming prompt response.
+func (s *EventStream) SendMsg(m any) error {
+	response, ok := m.(*gateway.StreamingPromptResponse)
+	if !ok {
+		return status.Errorf(codes.Internal, "unexpected stream message type %T", m)
+	}
+
+	return s.Send(response)
+}
+
+// RecvMsg is not supported, the request is read before the stream is created.
+func (*EventStream) RecvMsg(any) error {
+	return status.Error(codes.Unimplemented, "receiving messages is not supported")
+}
+
+// Context returns the context of the HTTP request.
+func (s *EventStream) Context() context.Context {
+	return s.ctx
+}
+
+// SetHeader is a no-op, gRPC metadata is not sent over HTTP.
+func (*EventStream) SetHeader(metadata.MD) error {
+	return nil
+}
+
+// SendHeader is a no-op, gRPC metadata is not sent over HTTP.
+func (*EventStream) SendHeader(metadata.MD) error {
+	return nil
+}
+
+// SetTrailer is a no-op, gRPC metadata is not sent over HTTP.
+func (*EventStream) SetTrailer(metadata.MD) {}
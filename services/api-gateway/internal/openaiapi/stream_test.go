@@ -0,0 +1,145 @@
+package openaiapi_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"github.com/basemind-ai/monorepo/gen/go/gateway/v1"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/openaiapi"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// parseEvents returns the data of the server sent events written to the recorder.
+func parseEvents(t *testing.T, recorder *httptest.ResponseRecorder) []string {
+	t.Helper()
+
+	var events []string
+
+	scanner := bufio.NewScanner(recorder.Body)
+	for scanner.Scan() {
+		if data, isData := strings.CutPrefix(scanner.Text(), "data: "); isData {
+			events = append(events, data)
+		}
+	}
+
+	return events
+}
+
+func parseChunk(t *testing.T, event string) openaiapi.ChatCompletionChunk {
+	t.Helper()
+
+	chunk := openaiapi.ChatCompletionChunk{}
+	assert.NoError(t, json.Unmarshal([]byte(event), &chunk))
+
+	return chunk
+}
+
+func TestEventStream(t *testing.T) { //nolint: revive
+	createEventStream := func(includeUsage bool) (*openaiapi.EventStream, *httptest.ResponseRecorder) {
+		recorder := httptest.NewRecorder()
+		return openaiapi.NewEventStream(
+			context.TODO(),
+			recorder,
+			"chatcmpl-123",
+			1700000000,
+			"my-prompt-config",
+			includeUsage,
+		), recorder
+	}
+
+	t.Run("writes the responses as chunks", func(t *testing.T) {
+		eventStream, recorder := createEventStream(false)
+		assert.False(t, eventStream.IsStarted())
+
+		assert.NoError(t, eventStream.SendMsg(&gateway.StreamingPromptResponse{Content: "Hello"}))
+		assert.True(t, eventStream.IsStarted())
+		assert.NoError(t, eventStream.SendMsg(&gateway.StreamingPromptResponse{Content: " world"}))
+		assert.NoError(t, eventStream.SendMsg(&gateway.StreamingPromptResponse{
+			FinishReason:   ptr.To("DONE"),
+			RequestTokens:  ptr.To(uint32(10)),
+			ResponseTokens: ptr.To(uint32(20)),
+		}))
+
+		assert.Equal(t, "text/event-stream", recorder.Header().Get("Content-Type"))
+
+		events := parseEvents(t, recorder)
+		assert.Len(t, events, 3)
+
+		firstChunk := parseChunk(t, events[0])
+		assert.Equal(t, "chatcmpl-123", firstChunk.ID)
+		assert.Equal(t, "chat.completion.chunk", firstChunk.Object)
+		assert.Equal(t, "my-prompt-config", firstChunk.Model)
+		assert.Equal(t, "assistant", firstChunk.Choices[0].Delta.Role)
+		assert.Equal(t, "Hello", firstChunk.Choices[0].Delta.Content)
+		assert.Nil(t, firstChunk.Choices[0].FinishReason)
+
+		secondChunk := parseChunk(t, events[1])
+		assert.Empty(t, secondChunk.Choices[0].Delta.Role)
+		assert.Equal(t, " world", secondChunk.Choices[0].Delta.Content)
+
+		finalChunk := parseChunk(t, events[2])
+		assert.Equal(t, "stop", *finalChunk.Choices[0].FinishReason)
+		assert.Nil(t, finalChunk.Usage)
+	})
+
+	t.Run("writes a usage chunk when usage is included", func(t *testing.T) {
+		eventStream, recorder := createEventStream(true)
+
+		assert.NoError(t, eventStream.Send(&gateway.StreamingPromptResponse{
+			FinishReason:   ptr.To("LIMIT"),
+			RequestTokens:  ptr.To(uint32(10)),
+			ResponseTokens: ptr.To(uint32(20)),
+		}))
+
+		events := parseEvents(t, recorder)
+		assert.Len(t, events, 2)
+
+		assert.Equal(t, "length", *parseChunk(t, events[0]).Choices[0].FinishReason)
+
+		usageChunk := parseChunk(t, events[1])
+		assert.Empty(t, usageChunk.Choices)
+		assert.Equal(t, uint32(10), usageChunk.Usage.PromptTokens)
+		assert.Equal(t, uint32(20), usageChunk.Usage.CompletionTokens)
+		assert.Equal(t, uint32(30), usageChunk.Usage.TotalTokens)
+	})
+
+	t.Run("writes the tool calls with the finish reason", func(t *testing.T) {
+		eventStream, recorder := createEventStream(false)
+
+		assert.NoError(t, eventStream.Send(&gateway.StreamingPromptResponse{
+			FinishReason: ptr.To("DONE"),
+			ToolCalls:    []*gateway.ToolCall{{Id: "call-1", Name: "get_weather", Arguments: "{}"}},
+		}))
+
+		chunk := parseChunk(t, parseEvents(t, recorder)[0])
+		assert.Equal(t, "tool_calls", *chunk.Choices[0].FinishReason)
+		assert.Len(t, chunk.Choices[0].Delta.ToolCalls, 1)
+		assert.Equal(t, "get_weather", chunk.Choices[0].Delta.ToolCalls[0].Function.Name)
+	})
+
+	t.Run("does not write error responses", func(t *testing.T) {
+		eventStream, recorder := createEventStream(false)
+
+		assert.NoError(t, eventStream.Send(&gateway.StreamingPromptResponse{FinishReason: ptr.To("ERROR")}))
+		assert.False(t, eventStream.IsStarted())
+		assert.Empty(t, parseEvents(t, recorder))
+	})
+
+	t.Run("returns an error for unexpected messages", func(t *testing.T) {
+		eventStream, _ := createEventStream(false)
+
+		assert.Error(t, eventStream.SendMsg(&gateway.PromptResponse{}))
+		assert.Error(t, eventStream.RecvMsg(nil))
+	})
+
+	t.Run("writes text events as is", func(t *testing.T) {
+		eventStream, recorder := createEventStream(false)
+
+		assert.NoError(t, eventStream.WriteEvent("[DONE]"))
+		assert.Equal(t, []string{"[DONE]"}, parseEvents(t, recorder))
+	})
+}
@@ -0,0 +1,139 @@
+package openaiapi
+
+import "encoding/json"
+
+// ChatCompletionRequest is the body of an OpenAI chat completion request.
+// Only the fields that can be mapped onto a gateway prompt request are supported,
+// the model parameters are taken from the prompt config.
+type ChatCompletionRequest struct {
+	// Model is the name or ID of the prompt config, or DefaultModelAlias for the application default.
+	Model    string        `json:"model"`
+	Messages []ChatMessage `json:"messages"`
+	Stream   bool          `json:"stream,omitempty"`
+	// StreamOptions are the options of a streaming request, IncludeUsage adds a final usage chunk to the stream.
+	StreamOptions *StreamOptions `json:"stream_options,omitempty"`
+	// User is used as the user key of the request, which keeps a user on the same traffic split variant.
+	User *string `json:"user,omitempty"`
+	// TemplateVariables are the values of the prompt config template variables.
+	// This field is an extension of the OpenAI API, it is passed by OpenAI SDKs as an extra body parameter.
+	TemplateVariables map[string]string `json:"template_variables,omitempty"`
+	// ConversationID is the ID of a stored conversation, whose messages precede the request messages.
+	// This field is an extension of the OpenAI API, it is passed by OpenAI SDKs as an extra body parameter.
+	ConversationID *string `json:"conversation_id,omitempty"`
+}
+
+// StreamOptions are the options of a streaming chat completion request.
+type StreamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+// ChatMessage is a message of a chat completion request or response.
+// The content of a request message is either a string or an array of content parts.
+type ChatMessage struct {
+	Role       string          `json:"role"`
+	Content    json.RawMessage `json:"content,omitempty"`
+	ToolCallID *string         `json:"tool_call_id,omitempty"`
+	ToolCalls  []ToolCall      `json:"tool_calls,omitempty"`
+}
+
+// ContentPart is a part of the content of a chat message, only text parts are supported.
+type ContentPart struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// ToolCall is a function call requested by the model.
+type ToolCall struct {
+	// Index is the index of the tool call in a stream chunk.
+	Index    *int         `json:"index,omitempty"`
+	ID       string       `json:"id"`
+	Type     string       `json:"type"`
+	Function FunctionCall `json:"function"`
+}
+
+// FunctionCall is the name and JSON encoded arguments of a function called by the model.
+type FunctionCall struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+}
+
+// ChatCompletion is the response of a non-streaming chat completion request.
+type ChatCompletion struct {
+	ID      string                 `json:"id"`
+	Object  string                 `json:"object"`
+	Created int64                  `json:"created"`
+	Model   string                 `json:"model"`
+	Choices []ChatCompletionChoice `json:"choices"`
+	Usage   Usage                  `json:"usage"`
+}
+
+// ChatCompletionChoice is a choice of a chat completion, the gateway always returns a single choice.
+type ChatCompletionChoice struct {
+	Index        int                 `json:"index"`
+	Message      ChatResponseMessage `json:"message"`
+	FinishReason string              `json:"finish_reason"`
+}
+
+// ChatResponseMessage is the assistant message of a chat completion.
+type ChatResponseMessage struct {
+	Role      string     `json:"role"`
+	Content   *string    `json:"content"`
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
+}
+
+// ChatCompletionChunk is a server sent event of a streaming chat completion.
+type ChatCompletionChunk struct {
+	ID      string                      `json:"id"`
+	Object  string                      `json:"object"`
+	Created int64                       `json:"created"`
+	Model   string                      `json:"model"`
+	Choices []ChatCompletionChunkChoice `json:"choices"`
+	Usage   *Usage                      `json:"usage,omitempty"`
+}
+
+// ChatCompletionChunkChoice is the choice of a stream chunk.
+type ChatCompletionChunkChoice struct {
+	Index        int       `json:"index"`
+	Delta        ChatDelta `json:"delta"`
+	FinishReason *string   `json:"finish_reason"`
+}
+
+// ChatDelta is the content streamed in a chunk.
+type ChatDelta struct {
+	Role      string     `json:"role,omitempty"`
+	Content   string     `json:"content,omitempty"`
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
+}
+
+// Usage is the token usage of a chat completion.
+type Usage struct {
+	PromptTokens     uint32 `json:"prompt_tokens"`
+	CompletionTokens uint32 `json:"completion_tokens"`
+	TotalTokens      uint32 `json:"total_tokens"`
+}
+
+// Model is a prompt config of the application, listed as a model.
+type Model struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Created int64  `json:"created"`
+	OwnedBy string `json:"owned_by"`
+}
+
+// ModelList is the response of the list models request.
+type ModelList struct {
+	Object string  `json:"object"`
+	Data   []Model `json:"data"`
+}
+
+// ErrorResponse is the body of an OpenAI API error response.
+type ErrorResponse struct {
+	Error ErrorDetail `json:"error"`
+}
+
+// ErrorDetail describes an OpenAI API error.
+type ErrorDetail struct {
+	Message string  `json:"message"`
+	Type    string  `json:"type"`
+	Code    *string `json:"code"`
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"github.com/basemind-ai/monorepo/gen/go/gateway/v1"
-	"github.com/basemind-ai/monorepo/services/api-gateway/internal/connectors"
 	"github.com/basemind-ai/monorepo/services/api-gateway/internal/dto"
 	"github.com/basemind-ai/monorepo/shared/go/db"
 	"github.com/basemind-ai/monorepo/shared/go/grpcutils"
@@ -22,6 +21,14 @@ const (
 	ErrorInsufficientCredits       = "insufficient credits"
 )
 
+// The names of the cached values, used to label the cache metrics.
+const (
+	requestConfigurationCacheName = "request_configuration"
+	projectCreditsCacheName       = "project_credits"
+	spendBudgetCacheName          = "spend_budget"
+	providerKeyCacheName          = "provider_key"
+)
+
 type APIGatewayServer struct {
 	gateway.UnimplementedAPIGatewayServiceServer
 }
@@ -47,6 +54,7 @@ func (APIGatewayServer) RequestPrompt(
 
 	requestConfigurationDTO, retrievalErr := rediscache.With[dto.RequestConfigurationDTO](
 		ctx,
+		requestConfigurationCacheName,
 		cacheKey,
 		&dto.RequestConfigurationDTO{},
 		time.Minute*30,
@@ -60,8 +68,16 @@ func (APIGatewayServer) RequestPrompt(
 		)
 	}
 
+	if validationErr := ValidateUserKey(request.UserKey); validationErr != nil {
+		// the validation error is already a grpc status error
+		return nil, validationErr
+	}
+
+	requestConfigurationDTO = SelectTrafficSplitVariant(requestConfigurationDTO, request.UserKey)
+
 	if insufficientCreditsErr, retrievalErr := rediscache.With[status.Status](
 		ctx,
+		projectCreditsCacheName,
 		db.UUIDToString(&projectID),
 		&status.Status{},
 		time.Minute*5,
@@ -72,35 +88,134 @@ func (APIGatewayServer) RequestPrompt(
 		return nil, insufficientCreditsErr.Err()
 	}
 
+	if budgetErr := EnforceSpendBudgets(ctx, projectID, applicationID); budgetErr != nil {
+		// the budget error is already a grpc status error
+		return nil, budgetErr
+	}
+
 	if validationError := ValidateExpectedVariables(request.TemplateVariables, requestConfigurationDTO.PromptConfigData.ExpectedTemplateVariables); validationError != nil {
 		// the validation error is already a grpc status error
 		return nil, validationError
 	}
 
-	providerKeyContext := CreateProviderAPIKeyContext(
+	conversationHistory, requestMessages, conversationErr := CreateConversationHistory(
 		ctx,
-		projectID,
-		requestConfigurationDTO.PromptConfigData.ModelVendor,
+		applicationID,
+		request,
 	)
+	if conversationErr != nil {
+		// the conversation error is already a grpc status error
+		return nil, conversationErr
+	}
+
+	isResponseCacheEnabled := requestConfigurationDTO.PromptConfigData.ResponseCacheTTLSeconds > 0
+	responseCacheKey := ""
 
-	promptResult := connectors.GetProviderConnector(requestConfigurationDTO.PromptConfigData.ModelVendor).
-		RequestPrompt(
-			providerKeyContext,
+	if isResponseCacheEnabled {
+		responseCacheKey = CreateResponseCacheKey(
 			requestConfigurationDTO,
 			request.TemplateVariables,
+			conversationHistory,
 		)
 
+		if cachedResponse, isCached := RetrieveCachedResponse(ctx, responseCacheKey); isCached {
+			cacheHitRecord, recordErr := CreateCacheHitRecord(ctx, requestConfigurationDTO, cachedResponse)
+			if recordErr != nil {
+				return nil, status.Error(codes.Internal, "failed to record the cached prompt response")
+			}
+
+			StorePromptRequestPayload(
+				ctx,
+				requestConfigurationDTO,
+				request,
+				conversationHistory,
+				dto.PromptResultDTO{
+					Content:       &cachedResponse.Content,
+					RequestRecord: cacheHitRecord,
+					ModelVendor:   cachedResponse.ModelVendor,
+					ModelType:     cachedResponse.ModelType,
+				},
+			)
+
+			StoreConversationTurn(
+				ctx,
+				applicationID,
+				request,
+				requestMessages,
+				cachedResponse.Content,
+				cachedResponse.ToolCalls,
+			)
+
+			return &gateway.PromptResponse{
+				Content:     cachedResponse.Content,
+				ModelVendor: string(cachedResponse.ModelVendor),
+				ModelType:   string(cachedResponse.ModelType),
+				IsCacheHit:  true,
+				ToolCalls:   CreateToolCalls(cachedResponse.ToolCalls),
+			}, nil
+		}
+	}
+
+	ctx, releaseCreditHold, holdErr := CreateCreditHold(
+		ctx,
+		projectID,
+		requestConfigurationDTO,
+		request.TemplateVariables,
+	)
+	if holdErr != nil {
+		// the hold error is already a grpc status error
+		return nil, holdErr
+	}
+
+	defer releaseCreditHold()
+
+	promptResult, connectorErr := RequestPromptWithResponseSchema(
+		ctx,
+		projectID,
+		requestConfigurationDTO,
+		request.TemplateVariables,
+		conversationHistory,
+	)
+	if connectorErr != nil {
+		// the connector and response schema validation errors are already grpc status errors
+		return nil, connectorErr
+	}
+
 	if promptResult.Error != nil {
 		log.Error().Err(promptResult.Error).Msg("error in prompt request")
 		return nil, status.Error(codes.Internal, "error communicating with AI provider")
 	}
 
-	go DeductCredit(ctx, promptResult.RequestRecord)
+	DeductCredit(ctx, promptResult.RequestRecord)
+
+	if isResponseCacheEnabled {
+		CacheResponse(ctx, responseCacheKey, requestConfigurationDTO, promptResult)
+	}
+
+	StorePromptRequestPayload(
+		ctx,
+		requestConfigurationDTO,
+		request,
+		conversationHistory,
+		promptResult,
+	)
+
+	StoreConversationTurn(
+		ctx,
+		applicationID,
+		request,
+		requestMessages,
+		*promptResult.Content,
+		promptResult.ToolCalls,
+	)
 
 	return &gateway.PromptResponse{
 		Content:        *promptResult.Content,
 		RequestTokens:  uint32(promptResult.RequestRecord.RequestTokens),
 		ResponseTokens: uint32(promptResult.RequestRecord.ResponseTokens),
+		ModelVendor:    string(promptResult.ModelVendor),
+		ModelType:      string(promptResult.ModelType),
+		ToolCalls:      CreateToolCalls(promptResult.ToolCalls),
 	}, nil
 }
 
@@ -125,6 +240,7 @@ func (APIGatewayServer) RequestStreamingPrompt(
 
 	requestConfigurationDTO, retrievalErr := rediscache.With[dto.RequestConfigurationDTO](
 		streamServer.Context(),
+		requestConfigurationCacheName,
 		cacheKey,
 		&dto.RequestConfigurationDTO{},
 		time.Minute*30,
@@ -138,8 +254,16 @@ func (APIGatewayServer) RequestStreamingPrompt(
 		)
 	}
 
+	if validationErr := ValidateUserKey(request.UserKey); validationErr != nil {
+		// the validation error is already a grpc status error
+		return validationErr
+	}
+
+	requestConfigurationDTO = SelectTrafficSplitVariant(requestConfigurationDTO, request.UserKey)
+
 	if insufficientCreditsErr, retrievalErr := rediscache.With[status.Status](
 		streamServer.Context(),
+		projectCreditsCacheName,
 		db.UUIDToString(&projectID),
 		&status.Status{},
 		time.Minute*5,
@@ -150,31 +274,232 @@ func (APIGatewayServer) RequestStreamingPrompt(
 		return insufficientCreditsErr.Err()
 	}
 
+	if budgetErr := EnforceSpendBudgets(streamServer.Context(), projectID, applicationID); budgetErr != nil {
+		// the budget error is already a grpc status error
+		return budgetErr
+	}
+
 	if validationError := ValidateExpectedVariables(request.TemplateVariables, requestConfigurationDTO.PromptConfigData.ExpectedTemplateVariables); validationError != nil {
 		// the validation error is already a grpc status error
 		return validationError
 	}
 
-	providerKeyContext := CreateProviderAPIKeyContext(
+	conversationHistory, requestMessages, conversationErr := CreateConversationHistory(
+		streamServer.Context(),
+		applicationID,
+		request,
+	)
+	if conversationErr != nil {
+		// the conversation error is already a grpc status error
+		return conversationErr
+	}
+
+	streamCtx, releaseCreditHold, holdErr := CreateCreditHold(
 		streamServer.Context(),
 		projectID,
-		requestConfigurationDTO.PromptConfigData.ModelVendor,
+		requestConfigurationDTO,
+		request.TemplateVariables,
 	)
+	if holdErr != nil {
+		// the hold error is already a grpc status error
+		return holdErr
+	}
+
+	defer releaseCreditHold()
 
 	channel := make(chan dto.PromptResultDTO)
 
-	go connectors.GetProviderConnector(requestConfigurationDTO.PromptConfigData.ModelVendor).
-		RequestStream(
-			providerKeyContext,
-			requestConfigurationDTO,
-			request.TemplateVariables,
-			channel,
-		)
+	if connectorErr := RequestStreamWithFallback(
+		streamCtx,
+		projectID,
+		requestConfigurationDTO,
+		request.TemplateVariables,
+		conversationHistory,
+		channel,
+	); connectorErr != nil {
+		// the connector error is already a grpc status error
+		return connectorErr
+	}
 
 	return StreamFromChannel(
-		streamServer.Context(),
+		streamCtx,
 		channel,
 		streamServer,
-		CreateAPIGatewayStreamMessage,
+		CreatePayloadLogStreamMessageFactory(
+			requestConfigurationDTO,
+			request,
+			conversationHistory,
+			CreateConversationStreamMessageFactory(applicationID, request, requestMessages),
+		),
 	)
 }
+
+func (APIGatewayServer) RequestEmbeddings(
+	ctx context.Context,
+	request *gateway.EmbeddingsRequest,
+) (*gateway.EmbeddingsResponse, error) {
+	projectID, ok := ctx.Value(grpcutils.ProjectIDContextKey).(pgtype.UUID)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, ErrorProjectIDNotInContext)
+	}
+
+	applicationID, ok := ctx.Value(grpcutils.ApplicationIDContextKey).(pgtype.UUID)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, ErrorApplicationIDNotInContext)
+	}
+
+	if validationErr := ValidateEmbeddingsRequest(request); validationErr != nil {
+		// the validation error is already a grpc status error
+		return nil, validationErr
+	}
+
+	embeddingsConfigurationDTO, retrievalErr := RetrieveEmbeddingsConfiguration(ctx, applicationID, request)
+	if retrievalErr != nil {
+		// the retrieval error is already a grpc status error
+		return nil, retrievalErr
+	}
+
+	if insufficientCreditsErr, retrievalErr := rediscache.With[status.Status](
+		ctx,
+		projectCreditsCacheName,
+		db.UUIDToString(&projectID),
+		&status.Status{},
+		time.Minute*5,
+		CheckProjectCredits(ctx, projectID),
+	); retrievalErr != nil {
+		return nil, retrievalErr
+	} else if insufficientCreditsErr.Code() == codes.ResourceExhausted {
+		return nil, insufficientCreditsErr.Err()
+	}
+
+	if budgetErr := EnforceSpendBudgets(ctx, projectID, applicationID); budgetErr != nil {
+		// the budget error is already a grpc status error
+		return nil, budgetErr
+	}
+
+	ctx, releaseCreditHold, holdErr := CreateEmbeddingsCreditHold(
+		ctx,
+		projectID,
+		embeddingsConfigurationDTO.ProviderModelPricing,
+		request.Inputs,
+	)
+	if holdErr != nil {
+		// the hold error is already a grpc status error
+		return nil, holdErr
+	}
+
+	defer releaseCreditHold()
+
+	embeddingsResult, connectorErr := RequestEmbeddings(
+		ctx,
+		projectID,
+		embeddingsConfigurationDTO,
+		request.Inputs,
+	)
+	if connectorErr != nil {
+		// the connector error is already a grpc status error
+		return nil, connectorErr
+	}
+
+	if embeddingsResult.Error != nil {
+		log.Error().Err(embeddingsResult.Error).Msg("error in embeddings request")
+		return nil, status.Error(codes.Internal, "error communicating with AI provider")
+	}
+
+	DeductCredit(ctx, embeddingsResult.RequestRecord)
+
+	return CreateEmbeddingsResponse(embeddingsConfigurationDTO, embeddingsResult), nil
+}
+
+func (APIGatewayServer) CreateBatchJob(
+	ctx context.Context,
+	request *gateway.BatchJobRequest,
+) (*gateway.BatchJobCreatedResponse, error) {
+	projectID, ok := ctx.Value(grpcutils.ProjectIDContextKey).(pgtype.UUID)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, ErrorProjectIDNotInContext)
+	}
+
+	applicationID, ok := ctx.Value(grpcutils.ApplicationIDContextKey).(pgtype.UUID)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, ErrorApplicationIDNotInContext)
+	}
+
+	if validationErr := ValidateBatchJobRequest(ctx, request); validationErr != nil {
+		// the validation error is already a grpc status error
+		return nil, validationErr
+	}
+
+	cacheKey := db.UUIDToString(&applicationID)
+	if request.PromptConfigId != nil {
+		cacheKey = fmt.Sprintf("%s:%s", db.UUIDToString(&applicationID), *request.PromptConfigId)
+	}
+
+	requestConfigurationDTO, retrievalErr := rediscache.With[dto.RequestConfigurationDTO](
+		ctx,
+		requestConfigurationCacheName,
+		cacheKey,
+		&dto.RequestConfigurationDTO{},
+		time.Minute*30,
+		RetrieveRequestConfiguration(ctx, applicationID, request.PromptConfigId),
+	)
+	if retrievalErr != nil {
+		log.Error().Err(retrievalErr).Msg("failed to retrieve the request configuration from Redis")
+		return nil, status.Error(
+			codes.NotFound,
+			retrievalErr.Error(),
+		)
+	}
+
+	if insufficientCreditsErr, retrievalErr := rediscache.With[status.Status](
+		ctx,
+		projectCreditsCacheName,
+		db.UUIDToString(&projectID),
+		&status.Status{},
+		time.Minute*5,
+		CheckProjectCredits(ctx, projectID),
+	); retrievalErr != nil {
+		return nil, retrievalErr
+	} else if insufficientCreditsErr.Code() == codes.ResourceExhausted {
+		return nil, insufficientCreditsErr.Err()
+	}
+
+	// the creation error is already a grpc status error
+	return CreateBatchJob(ctx, applicationID, requestConfigurationDTO, request)
+}
+
+func (APIGatewayServer) RetrieveBatchJob(
+	ctx context.Context,
+	request *gateway.BatchJobStatusRequest,
+) (*gateway.BatchJobStatusResponse, error) {
+	applicationID, ok := ctx.Value(grpcutils.ApplicationIDContextKey).(pgtype.UUID)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, ErrorApplicationIDNotInContext)
+	}
+
+	batchJob, retrievalErr := RetrieveBatchJob(ctx, applicationID, request.JobId)
+	if retrievalErr != nil {
+		// the retrieval error is already a grpc status error
+		return nil, retrievalErr
+	}
+
+	return CreateBatchJobStatusResponse(ctx, batchJob)
+}
+
+func (APIGatewayServer) RetrieveBatchJobResults(
+	ctx context.Context,
+	request *gateway.BatchJobResultsRequest,
+) (*gateway.BatchJobResultsResponse, error) {
+	applicationID, ok := ctx.Value(grpcutils.ApplicationIDContextKey).(pgtype.UUID)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, ErrorApplicationIDNotInContext)
+	}
+
+	batchJob, retrievalErr := RetrieveBatchJob(ctx, applicationID, request.JobId)
+	if retrievalErr != nil {
+		// the retrieval error is already a grpc status error
+		return nil, retrievalErr
+	}
+
+	return CreateBatchJobResultsResponse(ctx, batchJob, request)
+}
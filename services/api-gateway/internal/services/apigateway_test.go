@@ -7,6 +7,7 @@ import (
 	"github.com/basemind-ai/monorepo/gen/go/gateway/v1"
 	"github.com/basemind-ai/monorepo/services/api-gateway/internal/dto"
 	"github.com/basemind-ai/monorepo/services/api-gateway/internal/services"
+	"github.com/basemind-ai/monorepo/shared/go/budgets"
 	"github.com/basemind-ai/monorepo/shared/go/datatypes"
 	"github.com/basemind-ai/monorepo/shared/go/db"
 	"github.com/basemind-ai/monorepo/shared/go/exc"
@@ -17,6 +18,7 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"testing"
 	"time"
@@ -33,8 +35,8 @@ func createRequestConfigurationDTO(
 		application.ID,
 	)
 
-	pricingModel := services.RetrieveProviderModelPricing(
-		context.TODO(), promptConfig.ModelType, promptConfig.ModelVendor)
+	pricingModel, _ := services.RetrieveProviderModelPricing(
+		context.TODO(), promptConfig.ModelType, promptConfig.ModelVendor, projectID)
 
 	return dto.RequestConfigurationDTO{
 		ApplicationID:  application.ID,
@@ -194,6 +196,11 @@ func TestAPIGatewayService(t *testing.T) {
 			mockRedis.ExpectSet(db.UUIDToString(&project.ID), exc.MustResult(cacheClient.Marshal(status.Status{})), time.Minute*5).
 				SetVal("OK")
 
+			mockRedis.ExpectGet(budgets.CreateCacheKey(requestConfigurationDTO.ApplicationID)).
+				RedisNil()
+			mockRedis.ExpectSet(budgets.CreateCacheKey(requestConfigurationDTO.ApplicationID), exc.MustResult(cacheClient.Marshal(status.Status{})), services.SpendBudgetsCacheTTL).
+				SetVal("OK")
+
 			mockRedis.ExpectGet(db.UUIDToString(&project.ID)).
 				RedisNil()
 			mockRedis.ExpectSet(db.UUIDToString(&project.ID), expectedCacheValue, time.Minute*5).
@@ -284,6 +291,11 @@ func TestAPIGatewayService(t *testing.T) {
 			mockRedis.ExpectSet(db.UUIDToString(&project.ID), exc.MustResult(cacheClient.Marshal(status.Status{})), time.Minute*5).
 				SetVal("OK")
 
+			mockRedis.ExpectGet(budgets.CreateCacheKey(requestConfigurationDTO.ApplicationID)).
+				RedisNil()
+			mockRedis.ExpectSet(budgets.CreateCacheKey(requestConfigurationDTO.ApplicationID), exc.MustResult(cacheClient.Marshal(status.Status{})), services.SpendBudgetsCacheTTL).
+				SetVal("OK")
+
 			err := srv.RequestStreamingPrompt(&gateway.PromptRequest{
 				TemplateVariables: map[string]string{"name": "John"},
 			}, mockGatewayServerStream{Ctx: createContext(requestConfigurationDTO.ApplicationID)})
@@ -291,4 +303,88 @@ func TestAPIGatewayService(t *testing.T) {
 			assert.ErrorContains(t, err, "missing template variables")
 		})
 	})
+
+	t.Run("RequestEmbeddings", func(t *testing.T) {
+		t.Run("return error when projectID is not set in context", func(t *testing.T) {
+			_, err := srv.RequestEmbeddings(context.TODO(), nil)
+			assert.ErrorContains(t, err, services.ErrorProjectIDNotInContext)
+		})
+
+		t.Run("return error when applicationID is not set in context", func(t *testing.T) {
+			_, err := srv.RequestEmbeddings(
+				context.WithValue(context.TODO(), grpcutils.ProjectIDContextKey, pgtype.UUID{}),
+				nil,
+			)
+			assert.ErrorContains(t, err, services.ErrorApplicationIDNotInContext)
+		})
+
+		t.Run("returns error when the model is not an embedding model", func(t *testing.T) {
+			_, err := srv.RequestEmbeddings(
+				createContext(requestConfigurationDTO.ApplicationID),
+				&gateway.EmbeddingsRequest{
+					Inputs:      []string{"The meaning of life"},
+					ModelVendor: string(requestConfigurationDTO.PromptConfigData.ModelVendor),
+					ModelType:   string(requestConfigurationDTO.PromptConfigData.ModelType),
+				},
+			)
+
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	})
+
+	t.Run("CreateBatchJob", func(t *testing.T) {
+		t.Run("return error when projectID is not set in context", func(t *testing.T) {
+			_, err := srv.CreateBatchJob(context.TODO(), nil)
+			assert.ErrorContains(t, err, services.ErrorProjectIDNotInContext)
+		})
+
+		t.Run("return error when applicationID is not set in context", func(t *testing.T) {
+			_, err := srv.CreateBatchJob(
+				context.WithValue(context.TODO(), grpcutils.ProjectIDContextKey, pgtype.UUID{}),
+				nil,
+			)
+			assert.ErrorContains(t, err, services.ErrorApplicationIDNotInContext)
+		})
+
+		t.Run("returns error when the request has no items", func(t *testing.T) {
+			_, err := srv.CreateBatchJob(
+				createContext(requestConfigurationDTO.ApplicationID),
+				&gateway.BatchJobRequest{},
+			)
+
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	})
+
+	t.Run("RetrieveBatchJob", func(t *testing.T) {
+		t.Run("return error when applicationID is not set in context", func(t *testing.T) {
+			_, err := srv.RetrieveBatchJob(context.TODO(), nil)
+			assert.ErrorContains(t, err, services.ErrorApplicationIDNotInContext)
+		})
+
+		t.Run("returns error when the job does not exist", func(t *testing.T) {
+			_, err := srv.RetrieveBatchJob(
+				createContext(requestConfigurationDTO.ApplicationID),
+				&gateway.BatchJobStatusRequest{JobId: "f6fc7d3e-9bd6-4dd2-8a7a-5d8b0fd8f1b5"},
+			)
+
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
+
+	t.Run("RetrieveBatchJobResults", func(t *testing.T) {
+		t.Run("return error when applicationID is not set in context", func(t *testing.T) {
+			_, err := srv.RetrieveBatchJobResults(context.TODO(), nil)
+			assert.ErrorContains(t, err, services.ErrorApplicationIDNotInContext)
+		})
+
+		t.Run("returns error when the job does not exist", func(t *testing.T) {
+			_, err := srv.RetrieveBatchJobResults(
+				createContext(requestConfigurationDTO.ApplicationID),
+				&gateway.BatchJobResultsRequest{JobId: "f6fc7d3e-9bd6-4dd2-8a7a-5d8b0fd8f1b5"},
+			)
+
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
 }
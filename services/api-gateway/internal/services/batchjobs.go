@@ -0,0 +1,671 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/basemind-ai/monorepo/gen/go/gateway/v1"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/dto"
+	"github.com/basemind-ai/monorepo/shared/go/config"
+	"github.com/basemind-ai/monorepo/shared/go/cryptoutils"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/grpcutils"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/basemind-ai/monorepo/shared/go/rediscache"
+	"github.com/basemind-ai/monorepo/shared/go/serialization"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// MaxBatchJobItems is the maximal number of items of a batch job.
+	MaxBatchJobItems = 10_000
+	// DefaultBatchJobResultsLimit is the number of results returned when a results request does not set a limit.
+	DefaultBatchJobResultsLimit = 100
+	// MaxBatchJobResultsLimit is the maximal number of results returned by a results request.
+	MaxBatchJobResultsLimit = 1000
+	// BatchJobItemMaxAttempts is the maximal number of attempts made for a batch job item.
+	BatchJobItemMaxAttempts = 3
+	// BatchJobItemLease is the time a worker has to finish a claimed item, before it is claimed by another worker.
+	BatchJobItemLease = 10 * time.Minute
+	// BatchJobItemRetryDelay is the delay before the first retry of an item, doubled with every further attempt.
+	BatchJobItemRetryDelay = 10 * time.Second
+	// BatchJobCallbackMaxAttempts is the maximal number of attempts made to deliver a batch job callback.
+	BatchJobCallbackMaxAttempts = 5
+	// BatchJobCallbackRetryDelay is the delay before a failed callback delivery is retried.
+	BatchJobCallbackRetryDelay = time.Minute
+	// BatchJobCallbackTimeout is the timeout of a callback request.
+	BatchJobCallbackTimeout = 10 * time.Second
+	// BatchJobPollInterval is the interval in which idle workers poll for pending items and callbacks.
+	BatchJobPollInterval = 2 * time.Second
+	// BatchJobSignatureHeader is the header holding the signature of a callback request.
+	BatchJobSignatureHeader = "X-BaseMind-Signature"
+	// batchJobCallbackSecretSize is the number of random bytes of a callback secret.
+	batchJobCallbackSecretSize = 32
+)
+
+// BatchJobCallbackPayload is the JSON body of a batch job callback request.
+type BatchJobCallbackPayload struct {
+	JobID          string `json:"jobId"`
+	Status         string `json:"status"`
+	TotalItems     int32  `json:"totalItems"`
+	CompletedItems int64  `json:"completedItems"`
+	FailedItems    int64  `json:"failedItems"`
+	FinishedAt     int64  `json:"finishedAt"`
+}
+
+var batchJobCallbackClient = NewBatchJobCallbackClient()
+
+// NewBatchJobCallbackClient returns the HTTP client that delivers batch job callbacks.
+// The client never follows redirects. In production, it resolves the callback host when dialing and refuses to
+// connect to loopback, private, link-local, multicast and unspecified addresses.
+func NewBatchJobCallbackClient() *http.Client {
+	dialer := &net.Dialer{Timeout: BatchJobCallbackTimeout}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// a proxy would connect to the callback host instead of the dialer
+	transport.Proxy = nil
+	transport.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+		if config.Get(ctx).Environment != "production" {
+			return dialer.DialContext(ctx, network, address)
+		}
+
+		host, port, splitErr := net.SplitHostPort(address)
+		if splitErr != nil {
+			return nil, splitErr
+		}
+
+		ipAddresses, lookupErr := net.DefaultResolver.LookupIPAddr(ctx, host)
+		if lookupErr != nil {
+			return nil, lookupErr
+		}
+
+		if len(ipAddresses) == 0 {
+			return nil, fmt.Errorf("callback host %s has no addresses", host)
+		}
+
+		for _, ipAddress := range ipAddresses {
+			if !isPublicIP(ipAddress.IP) {
+				return nil, fmt.Errorf("callback host %s resolves to the non-public address %s", host, ipAddress.IP)
+			}
+		}
+
+		// the resolved address is dialed, so that the host cannot resolve to another address in between
+		return dialer.DialContext(ctx, network, net.JoinHostPort(ipAddresses[0].IP.String(), port))
+	}
+
+	return &http.Client{
+		Timeout:   BatchJobCallbackTimeout,
+		Transport: transport,
+		CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// isPublicIP returns whether the IP address is not a loopback, private, link-local, multicast or unspecified address.
+func isPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified()
+}
+
+// ValidateBatchJobRequest validates the number of items and the callback URL of a batch job request.
+// Callback URLs must use https, plain http is only allowed outside of production. In production, callback URLs
+// must not point to localhost or to a non-public IP address.
+// Returns an InvalidArgument status error if the request is invalid.
+func ValidateBatchJobRequest(ctx context.Context, request *gateway.BatchJobRequest) error {
+	if len(request.Items) == 0 || len(request.Items) > MaxBatchJobItems {
+		return status.Errorf(
+			codes.InvalidArgument,
+			"batch jobs must have between 1 and %d items",
+			MaxBatchJobItems,
+		)
+	}
+
+	if request.CallbackUrl != nil {
+		callbackURL, parseErr := url.Parse(*request.CallbackUrl)
+		if parseErr != nil || callbackURL.Host == "" {
+			return status.Error(codes.InvalidArgument, "invalid callback url")
+		}
+
+		isProduction := config.Get(ctx).Environment == "production"
+		if callbackURL.Scheme != "https" && (callbackURL.Scheme != "http" || isProduction) {
+			return status.Error(codes.InvalidArgument, "callback url must use https")
+		}
+
+		callbackIP := net.ParseIP(callbackURL.Hostname())
+		isPrivateHost := strings.EqualFold(callbackURL.Hostname(), "localhost") ||
+			(callbackIP != nil && !isPublicIP(callbackIP))
+		if isProduction && isPrivateHost {
+			return status.Error(codes.InvalidArgument, "callback url must not point to a private address")
+		}
+	}
+
+	return nil
+}
+
+// CreateBatchJob stores a batch job and its items, so that they are executed by the batch job workers.
+// The template variables of every item are validated against the expected variables of the request configuration.
+// If the request has a callback URL, a callback secret is generated, stored encrypted and returned.
+func CreateBatchJob(
+	ctx context.Context,
+	applicationID pgtype.UUID,
+	requestConfiguration *dto.RequestConfigurationDTO,
+	request *gateway.BatchJobRequest,
+) (*gateway.BatchJobCreatedResponse, error) {
+	items := make([]models.CreateBatchJobItemsParams, len(request.Items))
+
+	for i, item := range request.Items {
+		if validationErr := ValidateExpectedVariables(
+			item.TemplateVariables,
+			requestConfiguration.PromptConfigData.ExpectedTemplateVariables,
+		); validationErr != nil {
+			return nil, status.Errorf(
+				codes.InvalidArgument,
+				"invalid batch job item %d: %s",
+				i,
+				status.Convert(validationErr).Message(),
+			)
+		}
+
+		items[i] = models.CreateBatchJobItemsParams{
+			ItemIndex:         int32(i),
+			TemplateVariables: serialization.SerializeJSON(item.TemplateVariables),
+		}
+	}
+
+	params := models.CreateBatchJobParams{
+		ApplicationID: applicationID,
+		TotalItems:    int32(len(items)),
+	}
+
+	if request.PromptConfigId != nil {
+		promptConfigID, uuidErr := db.StringToUUID(*request.PromptConfigId)
+		if uuidErr != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid prompt-config id: %v", uuidErr)
+		}
+
+		params.PromptConfigID = *promptConfigID
+	}
+
+	response := &gateway.BatchJobCreatedResponse{}
+
+	if request.CallbackUrl != nil {
+		callbackSecret := hex.EncodeToString(cryptoutils.RandomBytes(batchJobCallbackSecretSize))
+
+		params.CallbackUrl = pgtype.Text{String: *request.CallbackUrl, Valid: true}
+		params.EncryptedCallbackSecret = pgtype.Text{
+			String: cryptoutils.Encrypt(callbackSecret, config.Get(ctx).CryptoPassKey),
+			Valid:  true,
+		}
+		response.CallbackSecret = &callbackSecret
+	}
+
+	tx, txErr := db.GetOrCreateTx(ctx)
+	if txErr != nil {
+		log.Error().Err(txErr).Msg("failed to create transaction")
+		return nil, status.Error(codes.Internal, "failed to create batch job")
+	}
+
+	if db.ShouldCommit(ctx) {
+		defer db.HandleRollback(ctx, tx)
+	}
+
+	queries := db.GetQueries().WithTx(tx)
+
+	batchJob, createErr := queries.CreateBatchJob(ctx, params)
+	if createErr != nil {
+		log.Error().Err(createErr).Msg("failed to create batch job")
+		return nil, status.Error(codes.Internal, "failed to create batch job")
+	}
+
+	for i := range items {
+		items[i].BatchJobID = batchJob.ID
+	}
+
+	if _, copyErr := queries.CreateBatchJobItems(ctx, items); copyErr != nil {
+		log.Error().Err(copyErr).Msg("failed to create batch job items")
+		return nil, status.Error(codes.Internal, "failed to create batch job")
+	}
+
+	db.CommitIfShouldCommit(ctx, tx)
+
+	response.JobId = db.UUIDToString(&batchJob.ID)
+
+	return response, nil
+}
+
+// RetrieveBatchJob retrieves a batch job of the application.
+// Returns a NotFound status error if the job does not exist or belongs to another application.
+func RetrieveBatchJob(
+	ctx context.Context,
+	applicationID pgtype.UUID,
+	jobID string,
+) (models.BatchJob, error) {
+	batchJobID, uuidErr := db.StringToUUID(jobID)
+	if uuidErr != nil {
+		return models.BatchJob{}, status.Errorf(codes.InvalidArgument, "invalid job id: %v", uuidErr)
+	}
+
+	batchJob, retrievalErr := db.GetQueries().RetrieveBatchJob(ctx, *batchJobID)
+	if retrievalErr != nil || batchJob.ApplicationID != applicationID {
+		return models.BatchJob{}, status.Error(codes.NotFound, "batch job does not exist")
+	}
+
+	return batchJob, nil
+}
+
+// CreateBatchJobStatusResponse creates the status response of a batch job, counting its finished items.
+func CreateBatchJobStatusResponse(
+	ctx context.Context,
+	batchJob models.BatchJob,
+) (*gateway.BatchJobStatusResponse, error) {
+	progress, retrievalErr := db.GetQueries().RetrieveBatchJobProgress(ctx, batchJob.ID)
+	if retrievalErr != nil {
+		log.Error().Err(retrievalErr).Msg("failed to retrieve batch job progress")
+		return nil, status.Error(codes.Internal, "failed to retrieve batch job progress")
+	}
+
+	response := &gateway.BatchJobStatusResponse{
+		JobId:          db.UUIDToString(&batchJob.ID),
+		Status:         string(batchJob.Status),
+		TotalItems:     uint32(batchJob.TotalItems),
+		CompletedItems: uint32(progress.CompletedItems),
+		FailedItems:    uint32(progress.FailedItems),
+		CreatedAt:      uint64(batchJob.CreatedAt.Time.UnixMilli()),
+	}
+
+	if batchJob.FinishedAt.Valid {
+		response.FinishedAt = ptr.To(uint64(batchJob.FinishedAt.Time.UnixMilli()))
+	}
+
+	return response, nil
+}
+
+// CreateBatchJobResultsResponse creates a page of the item results of a batch job, ordered by the item index.
+// The limit defaults to DefaultBatchJobResultsLimit and is capped at MaxBatchJobResultsLimit.
+func CreateBatchJobResultsResponse(
+	ctx context.Context,
+	batchJob models.BatchJob,
+	request *gateway.BatchJobResultsRequest,
+) (*gateway.BatchJobResultsResponse, error) {
+	limit := min(ptr.Deref(request.Limit, DefaultBatchJobResultsLimit), MaxBatchJobResultsLimit)
+
+	items, retrievalErr := db.GetQueries().
+		RetrieveBatchJobItems(ctx, models.RetrieveBatchJobItemsParams{
+			BatchJobID: batchJob.ID,
+			PageOffset: int32(min(request.Offset, MaxBatchJobItems)),
+			PageLimit:  int32(limit),
+		})
+	if retrievalErr != nil {
+		log.Error().Err(retrievalErr).Msg("failed to retrieve batch job items")
+		return nil, status.Error(codes.Internal, "failed to retrieve batch job results")
+	}
+
+	results := make([]*gateway.BatchJobItemResult, len(items))
+
+	for i, item := range items {
+		results[i] = &gateway.BatchJobItemResult{
+			Index:          uint32(item.ItemIndex),
+			Status:         string(item.Status),
+			Attempts:       uint32(item.Attempts),
+			RequestTokens:  uint32(item.RequestTokens),
+			ResponseTokens: uint32(item.ResponseTokens),
+		}
+
+		if item.Content.Valid {
+			results[i].Content = &item.Content.String
+		}
+
+		if item.ErrorLog.Valid && item.Status == models.BatchJobItemStatusFAILED {
+			results[i].Error = &item.ErrorLog.String
+		}
+	}
+
+	return &gateway.BatchJobResultsResponse{Results: results}, nil
+}
+
+// SignBatchJobCallback returns the hex encoded HMAC-SHA256 signature of "<timestamp>.<body>".
+func SignBatchJobCallback(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = fmt.Fprintf(mac, "%d.", timestamp)
+	_, _ = mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// isRetryableBatchJobItemError returns whether a failed batch job item should be attempted again.
+// Errors caused by the item or the application configuration are not retried.
+func isRetryableBatchJobItemError(err error) bool {
+	switch status.Code(err) {
+	case codes.InvalidArgument,
+		codes.NotFound,
+		codes.FailedPrecondition,
+		codes.PermissionDenied,
+		codes.Unauthenticated,
+		codes.OutOfRange:
+		return false
+	default:
+		return true
+	}
+}
+
+// executeBatchJobItem requests the prompt of a batch job item, deducts its credit and stores its payload.
+// The returned result holds the request record if a request was made, also when an error is returned.
+func executeBatchJobItem(
+	ctx context.Context,
+	item models.ClaimBatchJobItemRow,
+) (dto.PromptResultDTO, error) {
+	templateVariables := map[string]string{}
+	if unmarshalErr := json.Unmarshal(item.TemplateVariables, &templateVariables); unmarshalErr != nil {
+		return dto.PromptResultDTO{}, status.Errorf(
+			codes.InvalidArgument,
+			"invalid template variables: %v",
+			unmarshalErr,
+		)
+	}
+
+	var promptConfigID *string
+	cacheKey := db.UUIDToString(&item.ApplicationID)
+
+	if item.PromptConfigID.Valid {
+		promptConfigID = ptr.To(db.UUIDToString(&item.PromptConfigID))
+		cacheKey = fmt.Sprintf("%s:%s", cacheKey, *promptConfigID)
+	}
+
+	requestConfigurationDTO, retrievalErr := rediscache.With[dto.RequestConfigurationDTO](
+		ctx,
+		requestConfigurationCacheName,
+		cacheKey,
+		&dto.RequestConfigurationDTO{},
+		time.Minute*30,
+		RetrieveRequestConfiguration(ctx, item.ApplicationID, promptConfigID),
+	)
+	if retrievalErr != nil {
+		return dto.PromptResultDTO{}, status.Error(codes.NotFound, retrievalErr.Error())
+	}
+
+	requestConfigurationDTO = SelectTrafficSplitVariant(requestConfigurationDTO, nil)
+
+	if insufficientCreditsErr, retrievalErr := rediscache.With[status.Status](
+		ctx,
+		projectCreditsCacheName,
+		db.UUIDToString(&item.ProjectID),
+		&status.Status{},
+		time.Minute*5,
+		CheckProjectCredits(ctx, item.ProjectID),
+	); retrievalErr != nil {
+		return dto.PromptResultDTO{}, retrievalErr
+	} else if insufficientCreditsErr.Code() == codes.ResourceExhausted {
+		return dto.PromptResultDTO{}, insufficientCreditsErr.Err()
+	}
+
+	if budgetErr := EnforceSpendBudgets(ctx, item.ProjectID, item.ApplicationID); budgetErr != nil {
+		return dto.PromptResultDTO{}, budgetErr
+	}
+
+	if validationErr := ValidateExpectedVariables(
+		templateVariables,
+		requestConfigurationDTO.PromptConfigData.ExpectedTemplateVariables,
+	); validationErr != nil {
+		return dto.PromptResultDTO{}, validationErr
+	}
+
+	ctx, releaseCreditHold, holdErr := CreateCreditHold(
+		ctx,
+		item.ProjectID,
+		requestConfigurationDTO,
+		templateVariables,
+	)
+	if holdErr != nil {
+		return dto.PromptResultDTO{}, holdErr
+	}
+
+	defer releaseCreditHold()
+
+	promptResult, connectorErr := RequestPromptWithResponseSchema(
+		ctx,
+		item.ProjectID,
+		requestConfigurationDTO,
+		templateVariables,
+		nil,
+	)
+	if connectorErr != nil {
+		return promptResult, connectorErr
+	}
+
+	if promptResult.Error != nil {
+		log.Error().Err(promptResult.Error).Msg("error in batch job item prompt request")
+		return promptResult, status.Error(codes.Unavailable, "error communicating with AI provider")
+	}
+
+	DeductCredit(ctx, promptResult.RequestRecord)
+
+	StorePromptRequestPayload(
+		ctx,
+		requestConfigurationDTO,
+		&gateway.PromptRequest{
+			TemplateVariables: templateVariables,
+			PromptConfigId:    promptConfigID,
+		},
+		nil,
+		promptResult,
+	)
+
+	return promptResult, nil
+}
+
+// storeBatchJobItemResult stores the outcome of a batch job item attempt.
+// Retryable errors are retried with an exponential delay until the attempts are exhausted, then the item fails.
+func storeBatchJobItemResult(
+	ctx context.Context,
+	item models.ClaimBatchJobItemRow,
+	promptResult dto.PromptResultDTO,
+	executionErr error,
+) error {
+	var promptRequestRecordID pgtype.UUID
+	if promptResult.RequestRecord != nil {
+		promptRequestRecordID = promptResult.RequestRecord.ID
+	}
+
+	if executionErr == nil {
+		return db.GetQueries().CompleteBatchJobItem(ctx, models.CompleteBatchJobItemParams{
+			Content:               pgtype.Text{String: ptr.Deref(promptResult.Content, ""), Valid: true},
+			PromptRequestRecordID: promptRequestRecordID,
+			ID:                    item.ID,
+		})
+	}
+
+	errorLog := pgtype.Text{String: status.Convert(executionErr).Message(), Valid: true}
+
+	if isRetryableBatchJobItemError(executionErr) && item.Attempts < BatchJobItemMaxAttempts {
+		retryDelay := BatchJobItemRetryDelay * time.Duration(1<<(item.Attempts-1))
+
+		return db.GetQueries().RetryBatchJobItem(ctx, models.RetryBatchJobItemParams{
+			ErrorLog:          errorLog,
+			RetryDelaySeconds: retryDelay.Seconds(),
+			ID:                item.ID,
+		})
+	}
+
+	return db.GetQueries().FailBatchJobItem(ctx, models.FailBatchJobItemParams{
+		ErrorLog:              errorLog,
+		PromptRequestRecordID: promptRequestRecordID,
+		ID:                    item.ID,
+	})
+}
+
+// ProcessNextBatchJobItem claims the next pending batch job item and executes it.
+// Items whose lease expired are claimed again, and fail once they exceed the maximal attempts.
+// The job is finished once all its items are finished.
+// Returns whether an item was claimed.
+func ProcessNextBatchJobItem(ctx context.Context) bool {
+	item, claimErr := db.GetQueries().ClaimBatchJobItem(ctx, BatchJobItemLease.Seconds())
+	if claimErr != nil {
+		if !errors.Is(claimErr, pgx.ErrNoRows) && ctx.Err() == nil {
+			log.Error().Err(claimErr).Msg("failed to claim batch job item")
+		}
+		return false
+	}
+
+	// the outcome of a claimed item is stored even if the workers are shut down meanwhile
+	storeCtx := context.WithoutCancel(ctx)
+
+	if startErr := db.GetQueries().StartBatchJob(storeCtx, item.BatchJobID); startErr != nil {
+		log.Error().Err(startErr).Msg("failed to start batch job")
+	}
+
+	var promptResult dto.PromptResultDTO
+	var executionErr error
+
+	if item.Attempts > BatchJobItemMaxAttempts {
+		executionErr = status.Error(codes.DeadlineExceeded, "batch job item exceeded the maximal attempts")
+	} else {
+		itemCtx := context.WithValue(ctx, grpcutils.ProjectIDContextKey, item.ProjectID)
+		itemCtx = context.WithValue(itemCtx, grpcutils.ApplicationIDContextKey, item.ApplicationID)
+
+		promptResult, executionErr = executeBatchJobItem(itemCtx, item)
+	}
+
+	if executionErr != nil {
+		log.Warn().
+			Err(executionErr).
+			Str("batchJobId", db.UUIDToString(&item.BatchJobID)).
+			Int32("itemIndex", item.ItemIndex).
+			Int32("attempts", item.Attempts).
+			Msg("batch job item failed")
+	}
+
+	if storeErr := storeBatchJobItemResult(storeCtx, item, promptResult, executionErr); storeErr != nil {
+		// the item is claimed again once its lease expires
+		log.Error().Err(storeErr).Msg("failed to store batch job item result")
+		return true
+	}
+
+	if _, finishErr := db.GetQueries().FinishBatchJob(storeCtx, item.BatchJobID); finishErr != nil &&
+		!errors.Is(finishErr, pgx.ErrNoRows) {
+		log.Error().Err(finishErr).Msg("failed to finish batch job")
+	}
+
+	return true
+}
+
+// DeliverNextBatchJobCallback claims the next due callback of a finished batch job and delivers it.
+// The callback is a signed POST request to the callback URL of the job. Failed deliveries are retried after
+// BatchJobCallbackRetryDelay, up to BatchJobCallbackMaxAttempts times.
+// Returns whether a callback was claimed.
+func DeliverNextBatchJobCallback(ctx context.Context) bool {
+	batchJob, claimErr := db.GetQueries().
+		ClaimBatchJobCallback(ctx, models.ClaimBatchJobCallbackParams{
+			RetryDelaySeconds: BatchJobCallbackRetryDelay.Seconds(),
+			MaxAttempts:       BatchJobCallbackMaxAttempts,
+		})
+	if claimErr != nil {
+		if !errors.Is(claimErr, pgx.ErrNoRows) && ctx.Err() == nil {
+			log.Error().Err(claimErr).Msg("failed to claim batch job callback")
+		}
+		return false
+	}
+
+	progress, retrievalErr := db.GetQueries().RetrieveBatchJobProgress(ctx, batchJob.ID)
+	if retrievalErr != nil {
+		log.Error().Err(retrievalErr).Msg("failed to retrieve batch job progress")
+		return true
+	}
+
+	body := serialization.SerializeJSON(BatchJobCallbackPayload{
+		JobID:          db.UUIDToString(&batchJob.ID),
+		Status:         string(batchJob.Status),
+		TotalItems:     batchJob.TotalItems,
+		CompletedItems: progress.CompletedItems,
+		FailedItems:    progress.FailedItems,
+		FinishedAt:     batchJob.FinishedAt.Time.UnixMilli(),
+	})
+
+	timestamp := time.Now().Unix()
+	signature := SignBatchJobCallback(
+		cryptoutils.Decrypt(batchJob.EncryptedCallbackSecret.String, config.Get(ctx).CryptoPassKey),
+		timestamp,
+		body,
+	)
+
+	request, requestErr := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		batchJob.CallbackUrl.String,
+		bytes.NewReader(body),
+	)
+	if requestErr != nil {
+		log.Error().Err(requestErr).Msg("failed to create batch job callback request")
+		return true
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(BatchJobSignatureHeader, fmt.Sprintf("t=%d,v1=%s", timestamp, signature))
+
+	response, deliveryErr := batchJobCallbackClient.Do(request)
+	if deliveryErr != nil {
+		log.Warn().Err(deliveryErr).Msg("failed to deliver batch job callback")
+		return true
+	}
+
+	_ = response.Body.Close()
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		log.Warn().Int("statusCode", response.StatusCode).Msg("batch job callback was rejected")
+		return true
+	}
+
+	if deliveredErr := db.GetQueries().SetBatchJobCallbackDelivered(ctx, batchJob.ID); deliveredErr != nil {
+		log.Error().Err(deliveredErr).Msg("failed to mark batch job callback as delivered")
+	}
+
+	return true
+}
+
+// RunBatchJobWorkers runs the given number of batch job workers until the context is done.
+// Every worker executes pending items and delivers due callbacks, and polls in the given interval when idle.
+func RunBatchJobWorkers(ctx context.Context, workers int, pollInterval time.Duration) {
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for ctx.Err() == nil {
+				if ProcessNextBatchJobItem(ctx) || DeliverNextBatchJobCallback(ctx) {
+					continue
+				}
+
+				select {
+				case <-ctx.Done():
+				case <-time.After(pollInterval):
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
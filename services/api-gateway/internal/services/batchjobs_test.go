@@ -0,0 +1,431 @@
+package services_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/basemind-ai/monorepo/e2e/factories"
+	"github.com/basemind-ai/monorepo/gen/go/gateway/v1"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/services"
+	"github.com/basemind-ai/monorepo/shared/go/config"
+	"github.com/basemind-ai/monorepo/shared/go/cryptoutils"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/basemind-ai/monorepo/shared/go/testutils"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func setProductionEnvironment(t *testing.T) {
+	t.Helper()
+
+	environment := config.Get(context.TODO()).Environment
+	config.Get(context.TODO()).Environment = "production"
+	t.Cleanup(func() {
+		config.Get(context.TODO()).Environment = environment
+	})
+}
+
+func TestBatchJobs(t *testing.T) { //nolint: revive
+	testutils.SetTestEnv(t)
+	project, _ := factories.CreateProject(context.TODO())
+	_ = factories.CreateProviderPricingModels(context.TODO())
+	requestConfigurationDTO := createRequestConfigurationDTO(t, project.ID)
+
+	createUncommittedContext := func(t *testing.T) context.Context {
+		t.Helper()
+
+		tx, err := db.GetTransaction(context.TODO())
+		assert.NoError(t, err)
+
+		t.Cleanup(func() {
+			_ = tx.Rollback(context.TODO())
+		})
+
+		return db.CreateShouldCommitContext(db.CreateTransactionContext(context.TODO(), tx), false)
+	}
+
+	t.Run("ValidateBatchJobRequest", func(t *testing.T) {
+		items := []*gateway.BatchJobItem{{TemplateVariables: map[string]string{"userInput": "cheese"}}}
+
+		t.Run("returns nil for a valid request", func(t *testing.T) {
+			assert.NoError(t, services.ValidateBatchJobRequest(context.TODO(), &gateway.BatchJobRequest{
+				Items:       items,
+				CallbackUrl: ptr.To("https://example.com/callback"),
+			}))
+		})
+
+		t.Run("allows http callback urls outside of production", func(t *testing.T) {
+			assert.NoError(t, services.ValidateBatchJobRequest(context.TODO(), &gateway.BatchJobRequest{
+				Items:       items,
+				CallbackUrl: ptr.To("http://localhost:8080/callback"),
+			}))
+		})
+
+		t.Run("returns error when the request has no items", func(t *testing.T) {
+			err := services.ValidateBatchJobRequest(context.TODO(), &gateway.BatchJobRequest{})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+
+		t.Run("returns error when the request has too many items", func(t *testing.T) {
+			err := services.ValidateBatchJobRequest(context.TODO(), &gateway.BatchJobRequest{
+				Items: make([]*gateway.BatchJobItem, services.MaxBatchJobItems+1),
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+
+		for _, callbackURL := range []string{"not a url", "/callback", "ftp://example.com/callback"} {
+			t.Run(fmt.Sprintf("returns error for the callback url %q", callbackURL), func(t *testing.T) {
+				err := services.ValidateBatchJobRequest(context.TODO(), &gateway.BatchJobRequest{
+					Items:       items,
+					CallbackUrl: ptr.To(callbackURL),
+				})
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			})
+		}
+
+		for _, callbackURL := range []string{
+			"http://example.com/callback",
+			"https://localhost/callback",
+			"https://127.0.0.1/callback",
+			"https://10.0.0.1/callback",
+			"https://169.254.169.254/latest/meta-data",
+			"https://[::1]/callback",
+		} {
+			t.Run(fmt.Sprintf("returns error for the callback url %q in production", callbackURL), func(t *testing.T) {
+				setProductionEnvironment(t)
+
+				err := services.ValidateBatchJobRequest(context.TODO(), &gateway.BatchJobRequest{
+					Items:       items,
+					CallbackUrl: ptr.To(callbackURL),
+				})
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			})
+		}
+	})
+
+	t.Run("NewBatchJobCallbackClient", func(t *testing.T) {
+		t.Run("does not follow redirects", func(t *testing.T) {
+			isRedirected := false
+
+			redirectTarget := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					isRedirected = true
+					w.WriteHeader(http.StatusNoContent)
+				}),
+			)
+			defer redirectTarget.Close()
+
+			redirectServer := httptest.NewServer(http.RedirectHandler(redirectTarget.URL, http.StatusTemporaryRedirect))
+			defer redirectServer.Close()
+
+			response, err := services.NewBatchJobCallbackClient().Post(redirectServer.URL, "application/json", nil)
+			assert.NoError(t, err)
+			_ = response.Body.Close()
+
+			assert.Equal(t, http.StatusTemporaryRedirect, response.StatusCode)
+			assert.False(t, isRedirected)
+		})
+
+		t.Run("refuses to connect to a loopback address in production", func(t *testing.T) {
+			setProductionEnvironment(t)
+
+			isCalled := false
+
+			callbackServer := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					isCalled = true
+					w.WriteHeader(http.StatusNoContent)
+				}),
+			)
+			defer callbackServer.Close()
+
+			_, err := services.NewBatchJobCallbackClient().Post(callbackServer.URL, "application/json", nil)
+			assert.ErrorContains(t, err, "non-public address")
+			assert.False(t, isCalled)
+		})
+	})
+
+	t.Run("SignBatchJobCallback", func(t *testing.T) {
+		t.Run("returns the HMAC-SHA256 of the timestamp and body", func(t *testing.T) {
+			mac := hmac.New(sha256.New, []byte("secret"))
+			_, _ = mac.Write([]byte(`1700000000.{"jobId":"123"}`))
+
+			assert.Equal(
+				t,
+				hex.EncodeToString(mac.Sum(nil)),
+				services.SignBatchJobCallback("secret", 1700000000, []byte(`{"jobId":"123"}`)),
+			)
+		})
+
+		t.Run("returns a different signature for a different timestamp", func(t *testing.T) {
+			assert.NotEqual(
+				t,
+				services.SignBatchJobCallback("secret", 1700000000, []byte("body")),
+				services.SignBatchJobCallback("secret", 1700000001, []byte("body")),
+			)
+		})
+	})
+
+	t.Run("CreateBatchJob", func(t *testing.T) {
+		t.Run("creates a batch job with its items", func(t *testing.T) {
+			ctx := createUncommittedContext(t)
+
+			response, err := services.CreateBatchJob(
+				ctx,
+				requestConfigurationDTO.ApplicationID,
+				&requestConfigurationDTO,
+				&gateway.BatchJobRequest{
+					Items: []*gateway.BatchJobItem{
+						{TemplateVariables: map[string]string{"userInput": "cheese"}},
+						{TemplateVariables: map[string]string{"userInput": "wine"}},
+					},
+					PromptConfigId: ptr.To(db.UUIDToString(&requestConfigurationDTO.PromptConfigID)),
+				},
+			)
+			assert.NoError(t, err)
+			assert.Nil(t, response.CallbackSecret)
+
+			tx, _ := db.GetOrCreateTx(ctx)
+			jobID, _ := db.StringToUUID(response.JobId)
+
+			batchJob, retrievalErr := db.GetQueries().WithTx(tx).RetrieveBatchJob(ctx, *jobID)
+			assert.NoError(t, retrievalErr)
+			assert.Equal(t, models.BatchJobStatusPENDING, batchJob.Status)
+			assert.Equal(t, int32(2), batchJob.TotalItems)
+			assert.Equal(t, requestConfigurationDTO.PromptConfigID, batchJob.PromptConfigID)
+			assert.False(t, batchJob.CallbackUrl.Valid)
+
+			items, itemsErr := db.GetQueries().WithTx(tx).
+				RetrieveBatchJobItems(ctx, models.RetrieveBatchJobItemsParams{
+					BatchJobID: *jobID,
+					PageLimit:  10,
+				})
+			assert.NoError(t, itemsErr)
+			assert.Len(t, items, 2)
+			assert.Equal(t, models.BatchJobItemStatusPENDING, items[1].Status)
+		})
+
+		t.Run("returns the callback secret and stores it encrypted", func(t *testing.T) {
+			ctx := createUncommittedContext(t)
+
+			response, err := services.CreateBatchJob(
+				ctx,
+				requestConfigurationDTO.ApplicationID,
+				&requestConfigurationDTO,
+				&gateway.BatchJobRequest{
+					Items: []*gateway.BatchJobItem{
+						{TemplateVariables: map[string]string{"userInput": "cheese"}},
+					},
+					CallbackUrl: ptr.To("https://example.com/callback"),
+				},
+			)
+			assert.NoError(t, err)
+			assert.NotEmpty(t, ptr.Deref(response.CallbackSecret, ""))
+
+			tx, _ := db.GetOrCreateTx(ctx)
+			jobID, _ := db.StringToUUID(response.JobId)
+
+			batchJob, retrievalErr := db.GetQueries().WithTx(tx).RetrieveBatchJob(ctx, *jobID)
+			assert.NoError(t, retrievalErr)
+			assert.Equal(t, "https://example.com/callback", batchJob.CallbackUrl.String)
+			assert.NotEqual(t, *response.CallbackSecret, batchJob.EncryptedCallbackSecret.String)
+			assert.Equal(t, *response.CallbackSecret, cryptoutils.Decrypt(
+				batchJob.EncryptedCallbackSecret.String,
+				config.Get(ctx).CryptoPassKey,
+			))
+		})
+
+		t.Run("returns error naming the item with missing template variables", func(t *testing.T) {
+			_, err := services.CreateBatchJob(
+				createUncommittedContext(t),
+				requestConfigurationDTO.ApplicationID,
+				&requestConfigurationDTO,
+				&gateway.BatchJobRequest{
+					Items: []*gateway.BatchJobItem{
+						{TemplateVariables: map[string]string{"userInput": "cheese"}},
+						{TemplateVariables: map[string]string{}},
+					},
+				},
+			)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.ErrorContains(t, err, "item 1")
+		})
+
+		t.Run("returns error for an invalid prompt config id", func(t *testing.T) {
+			_, err := services.CreateBatchJob(
+				createUncommittedContext(t),
+				requestConfigurationDTO.ApplicationID,
+				&requestConfigurationDTO,
+				&gateway.BatchJobRequest{
+					Items: []*gateway.BatchJobItem{
+						{TemplateVariables: map[string]string{"userInput": "cheese"}},
+					},
+					PromptConfigId: ptr.To("invalid"),
+				},
+			)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	})
+
+	t.Run("batch job execution", func(t *testing.T) {
+		callbackSecret := "secret"
+
+		var callbackRequest *http.Request
+		var callbackBody []byte
+
+		callbackServer := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				callbackRequest = r
+				callbackBody, _ = io.ReadAll(r.Body)
+				w.WriteHeader(http.StatusNoContent)
+			}),
+		)
+		defer callbackServer.Close()
+
+		batchJob, err := db.GetQueries().CreateBatchJob(context.TODO(), models.CreateBatchJobParams{
+			ApplicationID: requestConfigurationDTO.ApplicationID,
+			TotalItems:    1,
+			CallbackUrl:   pgtype.Text{String: callbackServer.URL, Valid: true},
+			EncryptedCallbackSecret: pgtype.Text{
+				String: cryptoutils.Encrypt(callbackSecret, config.Get(context.TODO()).CryptoPassKey),
+				Valid:  true,
+			},
+		})
+		assert.NoError(t, err)
+
+		// the item cannot be executed, since its template variables are not a JSON object
+		_, err = db.GetQueries().CreateBatchJobItems(context.TODO(), []models.CreateBatchJobItemsParams{{
+			BatchJobID:        batchJob.ID,
+			ItemIndex:         0,
+			TemplateVariables: []byte(`["cheese"]`),
+		}})
+		assert.NoError(t, err)
+
+		jobID := db.UUIDToString(&batchJob.ID)
+
+		t.Run("RetrieveBatchJob returns the job of the application", func(t *testing.T) {
+			retrievedJob, retrievalErr := services.RetrieveBatchJob(
+				context.TODO(),
+				requestConfigurationDTO.ApplicationID,
+				jobID,
+			)
+			assert.NoError(t, retrievalErr)
+			assert.Equal(t, batchJob.ID, retrievedJob.ID)
+		})
+
+		t.Run("RetrieveBatchJob returns error for the job of another application", func(t *testing.T) {
+			application, _ := factories.CreateApplication(context.TODO(), project.ID)
+
+			_, retrievalErr := services.RetrieveBatchJob(context.TODO(), application.ID, jobID)
+			assert.Equal(t, codes.NotFound, status.Code(retrievalErr))
+		})
+
+		t.Run("RetrieveBatchJob returns error for an invalid job id", func(t *testing.T) {
+			_, retrievalErr := services.RetrieveBatchJob(
+				context.TODO(),
+				requestConfigurationDTO.ApplicationID,
+				"invalid",
+			)
+			assert.Equal(t, codes.InvalidArgument, status.Code(retrievalErr))
+		})
+
+		t.Run("CreateBatchJobStatusResponse returns the progress of a pending job", func(t *testing.T) {
+			response, responseErr := services.CreateBatchJobStatusResponse(context.TODO(), batchJob)
+			assert.NoError(t, responseErr)
+			assert.Equal(t, jobID, response.JobId)
+			assert.Equal(t, "PENDING", response.Status)
+			assert.Equal(t, uint32(1), response.TotalItems)
+			assert.Equal(t, uint32(0), response.CompletedItems)
+			assert.Nil(t, response.FinishedAt)
+		})
+
+		t.Run("DeliverNextBatchJobCallback returns false when no callback is due", func(t *testing.T) {
+			assert.False(t, services.DeliverNextBatchJobCallback(context.TODO()))
+		})
+
+		t.Run("ProcessNextBatchJobItem fails an item that cannot be executed", func(t *testing.T) {
+			assert.True(t, services.ProcessNextBatchJobItem(context.TODO()))
+			assert.False(t, services.ProcessNextBatchJobItem(context.TODO()))
+
+			finishedJob, retrievalErr := db.GetQueries().RetrieveBatchJob(context.TODO(), batchJob.ID)
+			assert.NoError(t, retrievalErr)
+			assert.Equal(t, models.BatchJobStatusFAILED, finishedJob.Status)
+			assert.True(t, finishedJob.FinishedAt.Valid)
+
+			response, responseErr := services.CreateBatchJobStatusResponse(context.TODO(), finishedJob)
+			assert.NoError(t, responseErr)
+			assert.Equal(t, uint32(1), response.FailedItems)
+			assert.NotNil(t, response.FinishedAt)
+		})
+
+		t.Run("CreateBatchJobResultsResponse returns the item results", func(t *testing.T) {
+			response, responseErr := services.CreateBatchJobResultsResponse(
+				context.TODO(),
+				batchJob,
+				&gateway.BatchJobResultsRequest{JobId: jobID},
+			)
+			assert.NoError(t, responseErr)
+			assert.Len(t, response.Results, 1)
+			assert.Equal(t, "FAILED", response.Results[0].Status)
+			assert.Equal(t, uint32(1), response.Results[0].Attempts)
+			assert.Nil(t, response.Results[0].Content)
+			assert.Contains(t, ptr.Deref(response.Results[0].Error, ""), "invalid template variables")
+		})
+
+		t.Run("CreateBatchJobResultsResponse returns an empty page past the last item", func(t *testing.T) {
+			response, responseErr := services.CreateBatchJobResultsResponse(
+				context.TODO(),
+				batchJob,
+				&gateway.BatchJobResultsRequest{JobId: jobID, Offset: 1},
+			)
+			assert.NoError(t, responseErr)
+			assert.Empty(t, response.Results)
+		})
+
+		t.Run("DeliverNextBatchJobCallback delivers a signed callback", func(t *testing.T) {
+			assert.True(t, services.DeliverNextBatchJobCallback(context.TODO()))
+			assert.False(t, services.DeliverNextBatchJobCallback(context.TODO()))
+
+			assert.NotNil(t, callbackRequest)
+			assert.Equal(t, "application/json", callbackRequest.Header.Get("Content-Type"))
+
+			var timestamp int64
+			var signature string
+			_, scanErr := fmt.Sscanf(
+				callbackRequest.Header.Get(services.BatchJobSignatureHeader),
+				"t=%d,v1=%s",
+				&timestamp,
+				&signature,
+			)
+			assert.NoError(t, scanErr)
+			assert.Equal(
+				t,
+				services.SignBatchJobCallback(callbackSecret, timestamp, callbackBody),
+				signature,
+			)
+
+			payload := services.BatchJobCallbackPayload{}
+			assert.NoError(t, json.Unmarshal(callbackBody, &payload))
+			assert.Equal(t, jobID, payload.JobID)
+			assert.Equal(t, "FAILED", payload.Status)
+			assert.Equal(t, int64(1), payload.FailedItems)
+
+			deliveredJob, retrievalErr := db.GetQueries().RetrieveBatchJob(context.TODO(), batchJob.ID)
+			assert.NoError(t, retrievalErr)
+			assert.True(t, deliveredJob.CallbackDeliveredAt.Valid)
+			assert.Equal(t, int32(1), deliveredJob.CallbackAttempts)
+		})
+	})
+
+}
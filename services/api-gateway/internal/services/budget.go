@@ -0,0 +1,212 @@
+package services
+
+import (
+	"cloud.google.com/go/pubsub"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/basemind-ai/monorepo/cloud-functions/emailsender"
+	"github.com/basemind-ai/monorepo/shared/go/budgets"
+	"github.com/basemind-ai/monorepo/shared/go/config"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/pubsubutils"
+	"github.com/basemind-ai/monorepo/shared/go/rediscache"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+const (
+	ErrorSpendBudgetExceeded    = "spend budget exceeded"
+	spendBudgetAlertFromAddress = "support@basemind.ai"
+	// SpendBudgetsCacheTTL is the time the spend budget status of an application is cached.
+	// Spend within this time is not enforced, since it is not part of the cached status.
+	SpendBudgetsCacheTTL = time.Minute
+)
+
+// CheckSpendBudgets checks the spend budgets of the project and the application against their current spend.
+// Alerts are created for the thresholds the spend has reached, once per budget period, and sent to the project admins.
+// Returns a ResourceExhausted status if the spend has reached the limit of a budget with a hard enforcement,
+// budgets with a soft enforcement are only alerted on.
+func CheckSpendBudgets(
+	ctx context.Context,
+	projectID pgtype.UUID,
+	applicationID pgtype.UUID,
+) func() (*status.Status, error) {
+	return func() (*status.Status, error) {
+		spendBudgets, retrievalErr := db.GetQueries().
+			RetrieveApplicationSpendBudgets(ctx, models.RetrieveApplicationSpendBudgetsParams{
+				ProjectID:     projectID,
+				ApplicationID: applicationID,
+			})
+		if retrievalErr != nil {
+			return &status.Status{}, fmt.Errorf("failed to retrieve spend budgets - %w", retrievalErr)
+		}
+
+		budgetStatus := &status.Status{}
+
+		for _, spendBudget := range spendBudgets {
+			periodStart := budgets.PeriodStart(spendBudget.Period, time.Now())
+
+			spend, spendErr := budgets.RetrieveSpend(ctx, spendBudget, periodStart)
+			if spendErr != nil {
+				return &status.Status{}, spendErr
+			}
+
+			limit, conversionErr := db.NumericToDecimal(spendBudget.SpendLimit)
+			if conversionErr != nil {
+				return &status.Status{}, conversionErr
+			}
+
+			for _, threshold := range budgets.ReachedThresholds(spend, *limit) {
+				createSpendBudgetAlert(ctx, spendBudget, periodStart, threshold, spend)
+			}
+
+			if !budgets.IsExceeded(spend, *limit) {
+				continue
+			}
+
+			if spendBudget.Enforcement == models.SpendBudgetEnforcementHARD {
+				budgetStatus = status.New(codes.ResourceExhausted, ErrorSpendBudgetExceeded)
+			} else {
+				log.Warn().
+					Str("spendBudgetId", db.UUIDToString(&spendBudget.ID)).
+					Str("spend", spend.String()).
+					Msg("soft spend budget exceeded")
+			}
+		}
+
+		return budgetStatus, nil
+	}
+}
+
+// EnforceSpendBudgets returns a ResourceExhausted status error if a hard spend budget of the project or application
+// is exceeded. The budget status is cached for SpendBudgetsCacheTTL.
+func EnforceSpendBudgets(
+	ctx context.Context,
+	projectID pgtype.UUID,
+	applicationID pgtype.UUID,
+) error {
+	budgetStatus, retrievalErr := rediscache.With[status.Status](
+		ctx,
+		spendBudgetCacheName,
+		budgets.CreateCacheKey(applicationID),
+		&status.Status{},
+		SpendBudgetsCacheTTL,
+		CheckSpendBudgets(ctx, projectID, applicationID),
+	)
+	if retrievalErr != nil {
+		log.Error().Err(retrievalErr).Msg("failed to check spend budgets")
+		return status.Error(codes.Internal, "failed to check spend budgets")
+	}
+
+	if budgetStatus.Code() == codes.ResourceExhausted {
+		return budgetStatus.Err()
+	}
+
+	return nil
+}
+
+// createSpendBudgetAlert creates the alert of a reached threshold, and notifies the project admins about it.
+// An alert that was already created in the budget period is not created or sent again.
+func createSpendBudgetAlert(
+	ctx context.Context,
+	spendBudget models.SpendBudget,
+	periodStart time.Time,
+	threshold int32,
+	spend decimal.Decimal,
+) {
+	numericSpend, conversionErr := db.StringToNumeric(spend.String())
+	if conversionErr != nil {
+		log.Error().Err(conversionErr).Msg("failed to convert spend")
+		return
+	}
+
+	alert, createErr := db.GetQueries().CreateSpendBudgetAlert(ctx, models.CreateSpendBudgetAlertParams{
+		SpendBudgetID: spendBudget.ID,
+		PeriodStart:   pgtype.Timestamptz{Time: periodStart, Valid: true},
+		Threshold:     threshold,
+		Spend:         *numericSpend,
+	})
+	if createErr != nil {
+		if !errors.Is(createErr, pgx.ErrNoRows) {
+			log.Error().Err(createErr).Msg("failed to create spend budget alert")
+		}
+		return
+	}
+
+	go SendSpendBudgetAlert(context.Background(), spendBudget, alert)
+}
+
+// SendSpendBudgetAlert emails a spend budget alert to the admins of the budget project, through the email sender
+// pub/sub topic. Alerts are not emailed if BUDGET_ALERT_EMAIL_TEMPLATE_ID is not configured.
+func SendSpendBudgetAlert(
+	ctx context.Context,
+	spendBudget models.SpendBudget,
+	alert models.SpendBudgetAlert,
+) {
+	cfg := config.Get(ctx)
+	if cfg.BudgetAlertEmailTemplateID == "" {
+		log.Debug().Msg("budget alert email template is not configured, skipping alert email")
+		return
+	}
+
+	project, projectErr := db.GetQueries().RetrieveProject(ctx, spendBudget.ProjectID)
+	if projectErr != nil {
+		log.Error().Err(projectErr).Msg("failed to retrieve project for spend budget alert")
+		return
+	}
+
+	userAccounts, userAccountsErr := db.GetQueries().
+		RetrieveProjectUserAccounts(ctx, spendBudget.ProjectID)
+	if userAccountsErr != nil {
+		log.Error().Err(userAccountsErr).Msg("failed to retrieve project users for spend budget alert")
+		return
+	}
+
+	limit, _ := db.NumericToDecimal(spendBudget.SpendLimit)
+	spend, _ := db.NumericToDecimal(alert.Spend)
+
+	topic := pubsubutils.GetTopic(ctx, pubsubutils.EmailSenderPubSubTopicID)
+
+	for _, userAccount := range userAccounts {
+		if userAccount.Permission.AccessPermissionType != models.AccessPermissionTypeADMIN {
+			continue
+		}
+
+		messageData, marshalErr := json.Marshal(emailsender.SendEmailRequestDTO{
+			FromName:    "BaseMind.AI",
+			FromAddress: spendBudgetAlertFromAddress,
+			ToName:      userAccount.DisplayName,
+			ToAddress:   userAccount.Email,
+			TemplateID:  cfg.BudgetAlertEmailTemplateID,
+			TemplateVariables: map[string]string{
+				"dashboardUrl": cfg.FrontendBaseURL,
+				"enforcement":  string(spendBudget.Enforcement),
+				"period":       string(spendBudget.Period),
+				"projectName":  project.Name,
+				"spend":        spend.String(),
+				"spendLimit":   limit.String(),
+				"threshold":    fmt.Sprintf("%d", alert.Threshold),
+			},
+		})
+		if marshalErr != nil {
+			log.Error().Err(marshalErr).Msg("failed to marshal spend budget alert email")
+			return
+		}
+
+		if publishErr := pubsubutils.PublishWithRetry(
+			ctx,
+			topic,
+			&pubsub.Message{Data: messageData},
+		); publishErr != nil {
+			log.Error().Err(publishErr).Msg("failed to publish spend budget alert email")
+		}
+	}
+}
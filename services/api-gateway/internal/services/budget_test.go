@@ -0,0 +1,163 @@
+package services_test
+
+import (
+	"context"
+	"github.com/basemind-ai/monorepo/e2e/factories"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/services"
+	"github.com/basemind-ai/monorepo/shared/go/budgets"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/exc"
+	"github.com/basemind-ai/monorepo/shared/go/testutils"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"testing"
+	"time"
+)
+
+func TestSpendBudgets(t *testing.T) { //nolint: revive
+	testutils.SetTestEnv(t)
+
+	// the spend of a single prompt request record created by the factories is 0.0000465
+	createSpendingApplication := func(t *testing.T) (pgtype.UUID, pgtype.UUID) {
+		t.Helper()
+
+		project, _ := factories.CreateProject(context.TODO())
+		application, _ := factories.CreateApplication(context.TODO(), project.ID)
+		promptConfig, _ := factories.CreateOpenAIPromptConfig(context.TODO(), application.ID)
+		_, _ = factories.CreatePromptRequestRecord(context.TODO(), promptConfig.ID)
+
+		return project.ID, application.ID
+	}
+
+	createSpendBudget := func(
+		t *testing.T,
+		projectID pgtype.UUID,
+		applicationID pgtype.UUID,
+		enforcement models.SpendBudgetEnforcement,
+		spendLimit string,
+	) models.SpendBudget {
+		t.Helper()
+
+		spendBudget, createErr := db.GetQueries().CreateSpendBudget(
+			context.TODO(),
+			models.CreateSpendBudgetParams{
+				ProjectID:     projectID,
+				ApplicationID: applicationID,
+				Period:        models.SpendBudgetPeriodMONTHLY,
+				Enforcement:   enforcement,
+				SpendLimit:    *exc.MustResult(db.StringToNumeric(spendLimit)),
+			},
+		)
+		assert.NoError(t, createErr)
+
+		return spendBudget
+	}
+
+	retrieveAlertThresholds := func(t *testing.T, projectID pgtype.UUID) []int32 {
+		t.Helper()
+
+		alerts, retrievalErr := db.GetQueries().RetrieveProjectSpendBudgetAlerts(
+			context.TODO(),
+			models.RetrieveProjectSpendBudgetAlertsParams{ProjectID: projectID, PageLimit: 100},
+		)
+		assert.NoError(t, retrievalErr)
+
+		thresholds := make([]int32, len(alerts))
+		for i, alert := range alerts {
+			thresholds[i] = alert.Threshold
+		}
+
+		return thresholds
+	}
+
+	t.Run("CheckSpendBudgets", func(t *testing.T) {
+		t.Run("returns an empty status without spend budgets", func(t *testing.T) {
+			projectID, applicationID := createSpendingApplication(t)
+
+			budgetStatus, err := services.CheckSpendBudgets(context.TODO(), projectID, applicationID)()
+			assert.NoError(t, err)
+			assert.Equal(t, codes.OK, budgetStatus.Code())
+		})
+
+		t.Run("returns an empty status when the spend is below the limit", func(t *testing.T) {
+			projectID, applicationID := createSpendingApplication(t)
+			createSpendBudget(t, projectID, pgtype.UUID{}, models.SpendBudgetEnforcementHARD, "1")
+
+			budgetStatus, err := services.CheckSpendBudgets(context.TODO(), projectID, applicationID)()
+			assert.NoError(t, err)
+			assert.Equal(t, codes.OK, budgetStatus.Code())
+			assert.Empty(t, retrieveAlertThresholds(t, projectID))
+		})
+
+		t.Run("returns a ResourceExhausted status for an exceeded hard project budget", func(t *testing.T) {
+			projectID, applicationID := createSpendingApplication(t)
+			createSpendBudget(t, projectID, pgtype.UUID{}, models.SpendBudgetEnforcementHARD, "0.00004")
+
+			budgetStatus, err := services.CheckSpendBudgets(context.TODO(), projectID, applicationID)()
+			assert.NoError(t, err)
+			assert.Equal(t, codes.ResourceExhausted, budgetStatus.Code())
+			assert.Equal(t, services.ErrorSpendBudgetExceeded, budgetStatus.Message())
+		})
+
+		t.Run("returns a ResourceExhausted status for an exceeded hard application budget", func(t *testing.T) {
+			projectID, applicationID := createSpendingApplication(t)
+			createSpendBudget(t, projectID, applicationID, models.SpendBudgetEnforcementHARD, "0.00004")
+
+			budgetStatus, err := services.CheckSpendBudgets(context.TODO(), projectID, applicationID)()
+			assert.NoError(t, err)
+			assert.Equal(t, codes.ResourceExhausted, budgetStatus.Code())
+		})
+
+		t.Run("ignores the budgets of other applications", func(t *testing.T) {
+			projectID, applicationID := createSpendingApplication(t)
+			otherApplication, _ := factories.CreateApplication(context.TODO(), projectID)
+			createSpendBudget(t, projectID, applicationID, models.SpendBudgetEnforcementHARD, "0.00004")
+
+			budgetStatus, err := services.CheckSpendBudgets(context.TODO(), projectID, otherApplication.ID)()
+			assert.NoError(t, err)
+			assert.Equal(t, codes.OK, budgetStatus.Code())
+		})
+
+		t.Run("allows requests when a soft budget is exceeded", func(t *testing.T) {
+			projectID, applicationID := createSpendingApplication(t)
+			createSpendBudget(t, projectID, pgtype.UUID{}, models.SpendBudgetEnforcementSOFT, "0.00004")
+
+			budgetStatus, err := services.CheckSpendBudgets(context.TODO(), projectID, applicationID)()
+			assert.NoError(t, err)
+			assert.Equal(t, codes.OK, budgetStatus.Code())
+			assert.ElementsMatch(t, []int32{50, 80, 100}, retrieveAlertThresholds(t, projectID))
+		})
+
+		t.Run("creates an alert once per reached threshold and period", func(t *testing.T) {
+			projectID, applicationID := createSpendingApplication(t)
+			spendBudget := createSpendBudget(
+				t,
+				projectID,
+				pgtype.UUID{},
+				models.SpendBudgetEnforcementSOFT,
+				"0.00009",
+			)
+
+			for i := 0; i < 3; i++ {
+				_, err := services.CheckSpendBudgets(context.TODO(), projectID, applicationID)()
+				assert.NoError(t, err)
+			}
+
+			alerts, retrievalErr := db.GetQueries().RetrieveProjectSpendBudgetAlerts(
+				context.TODO(),
+				models.RetrieveProjectSpendBudgetAlertsParams{ProjectID: projectID, PageLimit: 100},
+			)
+			assert.NoError(t, retrievalErr)
+			assert.Len(t, alerts, 1)
+			assert.Equal(t, int32(50), alerts[0].Threshold)
+			assert.Equal(t, spendBudget.ID, alerts[0].SpendBudgetID)
+			assert.Equal(
+				t,
+				budgets.PeriodStart(models.SpendBudgetPeriodMONTHLY, time.Now()),
+				alerts[0].PeriodStart.Time.UTC(),
+			)
+		})
+	})
+}
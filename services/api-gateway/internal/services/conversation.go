@@ -0,0 +1,324 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/basemind-ai/monorepo/gen/go/gateway/v1"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/dto"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/basemind-ai/monorepo/shared/go/serialization"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxConversationIDLength is the maximum length of a conversation ID.
+	MaxConversationIDLength = 255
+	// MaxStoredConversationMessages is the maximum number of stored messages replayed for a conversation.
+	MaxStoredConversationMessages = 100
+)
+
+var conversationRoleMap = map[gateway.ConversationRole]models.ConversationMessageRole{
+	gateway.ConversationRole_CONVERSATION_ROLE_SYSTEM:    models.ConversationMessageRoleSystem,
+	gateway.ConversationRole_CONVERSATION_ROLE_USER:      models.ConversationMessageRoleUser,
+	gateway.ConversationRole_CONVERSATION_ROLE_ASSISTANT: models.ConversationMessageRoleAssistant,
+	gateway.ConversationRole_CONVERSATION_ROLE_TOOL:      models.ConversationMessageRoleTool,
+}
+
+// ParseToolCalls maps the tool calls of a conversation message into DTOs.
+func ParseToolCalls(toolCalls []*gateway.ToolCall) []dto.ToolCallDTO {
+	if len(toolCalls) == 0 {
+		return nil
+	}
+
+	toolCallDTOs := make([]dto.ToolCallDTO, 0, len(toolCalls))
+	for _, toolCall := range toolCalls {
+		toolCallDTOs = append(toolCallDTOs, dto.ToolCallDTO{
+			ID:        toolCall.GetId(),
+			Name:      toolCall.GetName(),
+			Arguments: toolCall.GetArguments(),
+		})
+	}
+
+	return toolCallDTOs
+}
+
+// CreateToolCalls maps tool call DTOs into the tool calls of a gateway response.
+func CreateToolCalls(toolCallDTOs []dto.ToolCallDTO) []*gateway.ToolCall {
+	if len(toolCallDTOs) == 0 {
+		return nil
+	}
+
+	toolCalls := make([]*gateway.ToolCall, 0, len(toolCallDTOs))
+	for _, toolCallDTO := range toolCallDTOs {
+		toolCalls = append(toolCalls, &gateway.ToolCall{
+			Id:        toolCallDTO.ID,
+			Name:      toolCallDTO.Name,
+			Arguments: toolCallDTO.Arguments,
+		})
+	}
+
+	return toolCalls
+}
+
+// ParseConversationHistory validates the conversation history of a prompt request and maps it into DTOs.
+func ParseConversationHistory(
+	conversationHistory []*gateway.ConversationMessage,
+) ([]dto.ConversationMessageDTO, error) {
+	messages := make([]dto.ConversationMessageDTO, 0, len(conversationHistory))
+
+	for i, message := range conversationHistory {
+		role, ok := conversationRoleMap[message.GetRole()]
+		if !ok {
+			return nil, status.Errorf(
+				codes.InvalidArgument,
+				"invalid role for conversation message %d: %s",
+				i,
+				message.GetRole(),
+			)
+		}
+
+		if role == models.ConversationMessageRoleTool && message.GetToolCallId() == "" {
+			return nil, status.Errorf(
+				codes.InvalidArgument,
+				"missing tool call id for conversation message %d",
+				i,
+			)
+		}
+
+		if role != models.ConversationMessageRoleAssistant && len(message.GetToolCalls()) > 0 {
+			return nil, status.Errorf(
+				codes.InvalidArgument,
+				"only assistant conversation messages may have tool calls, conversation message %d has role %s",
+				i,
+				message.GetRole(),
+			)
+		}
+
+		messages = append(messages, dto.ConversationMessageDTO{
+			Role:       role,
+			Content:    message.GetContent(),
+			ToolCallID: message.ToolCallId,
+			ToolCalls:  ParseToolCalls(message.GetToolCalls()),
+		})
+	}
+
+	return messages, nil
+}
+
+// ValidateConversationID validates the conversation ID of a prompt request, if set.
+func ValidateConversationID(conversationID *string) error {
+	if conversationID == nil {
+		return nil
+	}
+
+	if length := len(*conversationID); length == 0 || length > MaxConversationIDLength {
+		return status.Errorf(
+			codes.InvalidArgument,
+			"conversation id must be between 1 and %d characters long",
+			MaxConversationIDLength,
+		)
+	}
+
+	return nil
+}
+
+// RetrieveConversationHistory retrieves the latest stored messages of a conversation, in chronological order.
+func RetrieveConversationHistory(
+	ctx context.Context,
+	applicationID pgtype.UUID,
+	conversationID string,
+) ([]dto.ConversationMessageDTO, error) {
+	storedMessages, retrievalErr := db.GetQueries().
+		RetrieveLatestConversationMessages(ctx, models.RetrieveLatestConversationMessagesParams{
+			ApplicationID:  applicationID,
+			ConversationID: conversationID,
+			Limit:          MaxStoredConversationMessages,
+		})
+	if retrievalErr != nil {
+		log.Error().Err(retrievalErr).Msg("failed to retrieve conversation messages")
+		return nil, status.Error(codes.Internal, "failed to retrieve the conversation history")
+	}
+
+	// the messages are retrieved latest first
+	slices.Reverse(storedMessages)
+
+	messages := make([]dto.ConversationMessageDTO, 0, len(storedMessages))
+	for _, storedMessage := range storedMessages {
+		message := dto.ConversationMessageDTO{
+			Role:    storedMessage.Role,
+			Content: storedMessage.Content,
+		}
+
+		if storedMessage.ToolCallID.Valid {
+			message.ToolCallID = &storedMessage.ToolCallID.String
+		}
+
+		if len(storedMessage.ToolCalls) > 0 {
+			if unmarshalErr := json.Unmarshal(storedMessage.ToolCalls, &message.ToolCalls); unmarshalErr != nil {
+				log.Error().Err(unmarshalErr).Msg("failed to unmarshal conversation message tool calls")
+				return nil, status.Error(codes.Internal, "failed to retrieve the conversation history")
+			}
+		}
+
+		messages = append(messages, message)
+	}
+
+	return messages, nil
+}
+
+// CreateConversationHistory creates the conversation history of a prompt request.
+// Returns the full conversation history, in which the stored messages of the conversation (if a conversation ID is set)
+// are followed by the request messages, and the request messages.
+func CreateConversationHistory(
+	ctx context.Context,
+	applicationID pgtype.UUID,
+	request *gateway.PromptRequest,
+) ([]dto.ConversationMessageDTO, []dto.ConversationMessageDTO, error) {
+	if validationErr := ValidateConversationID(request.ConversationId); validationErr != nil {
+		return nil, nil, validationErr
+	}
+
+	requestMessages, parseErr := ParseConversationHistory(request.ConversationHistory)
+	if parseErr != nil {
+		return nil, nil, parseErr
+	}
+
+	if request.ConversationId == nil {
+		return requestMessages, requestMessages, nil
+	}
+
+	storedMessages, retrievalErr := RetrieveConversationHistory(
+		ctx,
+		applicationID,
+		*request.ConversationId,
+	)
+	if retrievalErr != nil {
+		return nil, nil, retrievalErr
+	}
+
+	return append(storedMessages, requestMessages...), requestMessages, nil
+}
+
+// StoreConversationMessages stores the given messages of a conversation in a single transaction, preserving their order.
+// Failures are logged, since the prompt response has already been created.
+func StoreConversationMessages(
+	ctx context.Context,
+	applicationID pgtype.UUID,
+	conversationID string,
+	messages []dto.ConversationMessageDTO,
+) {
+	tx, txErr := db.GetOrCreateTx(ctx)
+	if txErr != nil {
+		log.Error().Err(txErr).Msg("failed to create transaction")
+		return
+	}
+
+	if db.ShouldCommit(ctx) {
+		defer db.HandleRollback(ctx, tx)
+	}
+
+	queries := db.GetQueries().WithTx(tx)
+	now := time.Now()
+
+	for i, message := range messages {
+		// the messages are ordered by their creation time, which must therefore be distinct
+		createdAt := now.Add(time.Duration(i) * time.Microsecond)
+
+		var toolCalls []byte
+		if len(message.ToolCalls) > 0 {
+			toolCalls = serialization.SerializeJSON(message.ToolCalls)
+		}
+
+		if createErr := queries.
+			CreateConversationMessage(ctx, models.CreateConversationMessageParams{
+				ApplicationID:  applicationID,
+				ConversationID: conversationID,
+				Role:           message.Role,
+				Content:        message.Content,
+				ToolCallID: pgtype.Text{
+					String: ptr.Deref(message.ToolCallID, ""),
+					Valid:  message.ToolCallID != nil,
+				},
+				ToolCalls: toolCalls,
+				CreatedAt: pgtype.Timestamptz{Time: createdAt, Valid: true},
+			}); createErr != nil {
+			// the transaction is rolled back, so a turn is never stored without its response
+			log.Error().Err(createErr).Msg("failed to store conversation message")
+			return
+		}
+	}
+
+	if db.ShouldCommit(ctx) {
+		if commitErr := tx.Commit(ctx); commitErr != nil {
+			log.Error().Err(commitErr).Msg("failed to commit conversation messages")
+		}
+	}
+}
+
+// StoreConversationTurn stores the messages of the request and the assistant response, if the request has a conversation ID.
+func StoreConversationTurn(
+	ctx context.Context,
+	applicationID pgtype.UUID,
+	request *gateway.PromptRequest,
+	requestMessages []dto.ConversationMessageDTO,
+	responseContent string,
+	responseToolCalls []dto.ToolCallDTO,
+) {
+	if request.ConversationId == nil {
+		return
+	}
+
+	StoreConversationMessages(
+		ctx,
+		applicationID,
+		*request.ConversationId,
+		append(slices.Clone(requestMessages), dto.ConversationMessageDTO{
+			Role:      models.ConversationMessageRoleAssistant,
+			Content:   responseContent,
+			ToolCalls: responseToolCalls,
+		}),
+	)
+}
+
+// CreateConversationStreamMessageFactory wraps CreateAPIGatewayStreamMessage,
+// storing the conversation turn once the stream has finished successfully.
+// Streams cancelled by a client disconnect are not stored, since their completion is truncated.
+func CreateConversationStreamMessageFactory(
+	applicationID pgtype.UUID,
+	request *gateway.PromptRequest,
+	requestMessages []dto.ConversationMessageDTO,
+) func(context.Context, dto.PromptResultDTO) (*gateway.StreamingPromptResponse, bool) {
+	var responseContent strings.Builder
+
+	return func(ctx context.Context, result dto.PromptResultDTO) (*gateway.StreamingPromptResponse, bool) {
+		msg, isFinished := CreateAPIGatewayStreamMessage(ctx, result)
+		responseContent.WriteString(msg.Content)
+
+		if isFinished && result.Error == nil && !isCancelled(result) {
+			StoreConversationTurn(
+				ctx,
+				applicationID,
+				request,
+				requestMessages,
+				responseContent.String(),
+				result.ToolCalls,
+			)
+		}
+
+		return msg, isFinished
+	}
+}
+
+// isCancelled returns whether the result is the final result of a stream cancelled by the client.
+func isCancelled(result dto.PromptResultDTO) bool {
+	return result.RequestRecord != nil &&
+		result.RequestRecord.FinishReason == models.PromptFinishReasonCANCELLED
+}
@@ -0,0 +1,375 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"github.com/basemind-ai/monorepo/e2e/factories"
+	"github.com/basemind-ai/monorepo/gen/go/gateway/v1"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/dto"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/services"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/basemind-ai/monorepo/shared/go/testutils"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+	"testing"
+)
+
+func TestConversation(t *testing.T) { //nolint: revive
+	testutils.SetTestEnv(t)
+	project, _ := factories.CreateProject(context.TODO())
+	application, _ := factories.CreateApplication(context.TODO(), project.ID)
+
+	t.Run("ParseConversationHistory", func(t *testing.T) {
+		t.Run("maps the conversation messages", func(t *testing.T) {
+			messages, err := services.ParseConversationHistory([]*gateway.ConversationMessage{
+				{Role: gateway.ConversationRole_CONVERSATION_ROLE_SYSTEM, Content: "Be concise."},
+				{Role: gateway.ConversationRole_CONVERSATION_ROLE_USER, Content: "What is cheese?"},
+				{
+					Role:    gateway.ConversationRole_CONVERSATION_ROLE_ASSISTANT,
+					Content: "A dairy product.",
+				},
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, []dto.ConversationMessageDTO{
+				{Role: models.ConversationMessageRoleSystem, Content: "Be concise."},
+				{Role: models.ConversationMessageRoleUser, Content: "What is cheese?"},
+				{Role: models.ConversationMessageRoleAssistant, Content: "A dairy product."},
+			}, messages)
+		})
+
+		t.Run("returns an invalid argument error for an unspecified role", func(t *testing.T) {
+			_, err := services.ParseConversationHistory([]*gateway.ConversationMessage{
+				{Content: "What is cheese?"},
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+
+		t.Run("maps the tool calls and tool results", func(t *testing.T) {
+			messages, err := services.ParseConversationHistory([]*gateway.ConversationMessage{
+				{
+					Role: gateway.ConversationRole_CONVERSATION_ROLE_ASSISTANT,
+					ToolCalls: []*gateway.ToolCall{
+						{Id: "call-1", Name: "get_weather", Arguments: `{"city":"Paris"}`},
+					},
+				},
+				{
+					Role:       gateway.ConversationRole_CONVERSATION_ROLE_TOOL,
+					Content:    `{"temperature":20}`,
+					ToolCallId: ptr.To("call-1"),
+				},
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, []dto.ConversationMessageDTO{
+				{
+					Role: models.ConversationMessageRoleAssistant,
+					ToolCalls: []dto.ToolCallDTO{
+						{ID: "call-1", Name: "get_weather", Arguments: `{"city":"Paris"}`},
+					},
+				},
+				{
+					Role:       models.ConversationMessageRoleTool,
+					Content:    `{"temperature":20}`,
+					ToolCallID: ptr.To("call-1"),
+				},
+			}, messages)
+		})
+
+		t.Run("returns an invalid argument error for a tool message without a tool call id", func(t *testing.T) {
+			_, err := services.ParseConversationHistory([]*gateway.ConversationMessage{
+				{Role: gateway.ConversationRole_CONVERSATION_ROLE_TOOL, Content: "20"},
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+
+		t.Run("returns an invalid argument error for tool calls of a user message", func(t *testing.T) {
+			_, err := services.ParseConversationHistory([]*gateway.ConversationMessage{
+				{
+					Role:      gateway.ConversationRole_CONVERSATION_ROLE_USER,
+					ToolCalls: []*gateway.ToolCall{{Id: "call-1", Name: "get_weather"}},
+				},
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	})
+
+	t.Run("ValidateConversationID", func(t *testing.T) {
+		for _, testCase := range []struct {
+			Name           string
+			ConversationID *string
+			IsValid        bool
+		}{
+			{Name: "allows no conversation id", ConversationID: nil, IsValid: true},
+			{Name: "allows a conversation id", ConversationID: ptr.To("conversation"), IsValid: true},
+			{Name: "rejects an empty conversation id", ConversationID: ptr.To(""), IsValid: false},
+			{
+				Name:           "rejects a too long conversation id",
+				ConversationID: ptr.To(strings.Repeat("a", services.MaxConversationIDLength+1)),
+				IsValid:        false,
+			},
+		} {
+			t.Run(testCase.Name, func(t *testing.T) {
+				err := services.ValidateConversationID(testCase.ConversationID)
+				if testCase.IsValid {
+					assert.NoError(t, err)
+				} else {
+					assert.Equal(t, codes.InvalidArgument, status.Code(err))
+				}
+			})
+		}
+	})
+
+	t.Run("CreateConversationHistory", func(t *testing.T) {
+		t.Run("returns the request messages without a conversation id", func(t *testing.T) {
+			history, requestMessages, err := services.CreateConversationHistory(
+				context.TODO(),
+				application.ID,
+				&gateway.PromptRequest{
+					ConversationHistory: []*gateway.ConversationMessage{
+						{
+							Role:    gateway.ConversationRole_CONVERSATION_ROLE_USER,
+							Content: "What is cheese?",
+						},
+					},
+				},
+			)
+			assert.NoError(t, err)
+			assert.Len(t, history, 1)
+			assert.Equal(t, history, requestMessages)
+		})
+
+		t.Run("prepends the stored messages of the conversation", func(t *testing.T) {
+			request := &gateway.PromptRequest{
+				ConversationId: ptr.To("stored-conversation"),
+				ConversationHistory: []*gateway.ConversationMessage{
+					{
+						Role:    gateway.ConversationRole_CONVERSATION_ROLE_USER,
+						Content: "Which kinds are there?",
+					},
+				},
+			}
+
+			services.StoreConversationTurn(
+				context.TODO(),
+				application.ID,
+				request,
+				[]dto.ConversationMessageDTO{
+					{Role: models.ConversationMessageRoleUser, Content: "What is cheese?"},
+				},
+				"A dairy product.",
+				nil,
+			)
+
+			history, requestMessages, err := services.CreateConversationHistory(
+				context.TODO(),
+				application.ID,
+				request,
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, []dto.ConversationMessageDTO{
+				{Role: models.ConversationMessageRoleUser, Content: "What is cheese?"},
+				{Role: models.ConversationMessageRoleAssistant, Content: "A dairy product."},
+				{Role: models.ConversationMessageRoleUser, Content: "Which kinds are there?"},
+			}, history)
+			assert.Equal(t, history[2:], requestMessages)
+		})
+
+		t.Run("does not store a partial turn when storing a message fails", func(t *testing.T) {
+			request := &gateway.PromptRequest{ConversationId: ptr.To("failed-conversation")}
+
+			services.StoreConversationTurn(
+				context.TODO(),
+				application.ID,
+				request,
+				[]dto.ConversationMessageDTO{
+					{Role: models.ConversationMessageRoleUser, Content: "What is cheese?"},
+					{Role: models.ConversationMessageRole("invalid"), Content: "Invalid role"},
+				},
+				"A dairy product.",
+				nil,
+			)
+
+			history, _, err := services.CreateConversationHistory(
+				context.TODO(),
+				application.ID,
+				request,
+			)
+			assert.NoError(t, err)
+			assert.Empty(t, history)
+		})
+
+		t.Run("replays the stored tool calls and tool results", func(t *testing.T) {
+			toolCalls := []dto.ToolCallDTO{
+				{ID: "call-1", Name: "get_weather", Arguments: `{"city":"Paris"}`},
+			}
+			request := &gateway.PromptRequest{ConversationId: ptr.To("tool-conversation")}
+
+			services.StoreConversationTurn(
+				context.TODO(),
+				application.ID,
+				request,
+				[]dto.ConversationMessageDTO{
+					{Role: models.ConversationMessageRoleUser, Content: "What is the weather?"},
+				},
+				"",
+				toolCalls,
+			)
+			services.StoreConversationTurn(
+				context.TODO(),
+				application.ID,
+				request,
+				[]dto.ConversationMessageDTO{
+					{
+						Role:       models.ConversationMessageRoleTool,
+						Content:    `{"temperature":20}`,
+						ToolCallID: ptr.To("call-1"),
+					},
+				},
+				"It is 20 degrees.",
+				nil,
+			)
+
+			history, _, err := services.CreateConversationHistory(
+				context.TODO(),
+				application.ID,
+				request,
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, []dto.ConversationMessageDTO{
+				{Role: models.ConversationMessageRoleUser, Content: "What is the weather?"},
+				{Role: models.ConversationMessageRoleAssistant, ToolCalls: toolCalls},
+				{
+					Role:       models.ConversationMessageRoleTool,
+					Content:    `{"temperature":20}`,
+					ToolCallID: ptr.To("call-1"),
+				},
+				{Role: models.ConversationMessageRoleAssistant, Content: "It is 20 degrees."},
+			}, history)
+		})
+
+		t.Run("scopes the stored messages to the application", func(t *testing.T) {
+			otherApplication, _ := factories.CreateApplication(context.TODO(), project.ID)
+
+			history, _, err := services.CreateConversationHistory(
+				context.TODO(),
+				otherApplication.ID,
+				&gateway.PromptRequest{ConversationId: ptr.To("stored-conversation")},
+			)
+			assert.NoError(t, err)
+			assert.Len(t, history, 0)
+		})
+
+		t.Run("returns an error for an invalid conversation id", func(t *testing.T) {
+			_, _, err := services.CreateConversationHistory(
+				context.TODO(),
+				application.ID,
+				&gateway.PromptRequest{ConversationId: ptr.To("")},
+			)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	})
+
+	t.Run("CreateConversationStreamMessageFactory", func(t *testing.T) {
+		t.Run("stores the streamed response once the stream finished", func(t *testing.T) {
+			request := &gateway.PromptRequest{ConversationId: ptr.To("streamed-conversation")}
+			requestMessages := []dto.ConversationMessageDTO{
+				{Role: models.ConversationMessageRoleUser, Content: "What is cheese?"},
+			}
+			promptConfig, _ := factories.CreateOpenAIPromptConfig(context.TODO(), application.ID)
+			requestRecord, _ := factories.CreatePromptRequestRecord(context.TODO(), promptConfig.ID)
+
+			messageFactory := services.CreateConversationStreamMessageFactory(
+				application.ID,
+				request,
+				requestMessages,
+			)
+
+			_, isFinished := messageFactory(
+				context.TODO(),
+				dto.PromptResultDTO{Content: ptr.To("A dairy ")},
+			)
+			assert.False(t, isFinished)
+
+			_, isFinished = messageFactory(
+				context.TODO(),
+				dto.PromptResultDTO{Content: ptr.To("product."), RequestRecord: requestRecord},
+			)
+			assert.True(t, isFinished)
+
+			history, _, err := services.CreateConversationHistory(
+				context.TODO(),
+				application.ID,
+				request,
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, []dto.ConversationMessageDTO{
+				{Role: models.ConversationMessageRoleUser, Content: "What is cheese?"},
+				{Role: models.ConversationMessageRoleAssistant, Content: "A dairy product."},
+			}, history)
+		})
+
+		t.Run("does not store the response if the stream was cancelled", func(t *testing.T) {
+			request := &gateway.PromptRequest{ConversationId: ptr.To("cancelled-conversation")}
+			requestMessages := []dto.ConversationMessageDTO{
+				{Role: models.ConversationMessageRoleUser, Content: "What is cheese?"},
+			}
+
+			messageFactory := services.CreateConversationStreamMessageFactory(
+				application.ID,
+				request,
+				requestMessages,
+			)
+
+			ctx, cancel := context.WithCancel(context.TODO())
+			channel := make(chan dto.PromptResultDTO)
+			streamServer := &mockGatewayServerStream{Ctx: ctx}
+
+			go func() {
+				channel <- dto.PromptResultDTO{Content: ptr.To("A dairy ")}
+				cancel()
+				channel <- dto.PromptResultDTO{
+					RequestRecord: &models.PromptRequestRecord{
+						FinishReason: models.PromptFinishReasonCANCELLED,
+					},
+				}
+				close(channel)
+			}()
+
+			_ = services.StreamFromChannel(ctx, channel, streamServer, messageFactory)
+
+			history, _, err := services.CreateConversationHistory(
+				context.TODO(),
+				application.ID,
+				request,
+			)
+			assert.NoError(t, err)
+			assert.Len(t, history, 0)
+		})
+
+		t.Run("does not store the response if the stream failed", func(t *testing.T) {
+			request := &gateway.PromptRequest{ConversationId: ptr.To("failed-conversation")}
+
+			messageFactory := services.CreateConversationStreamMessageFactory(
+				application.ID,
+				request,
+				nil,
+			)
+
+			_, isFinished := messageFactory(
+				context.TODO(),
+				dto.PromptResultDTO{Error: errors.New("provider error")},
+			)
+			assert.True(t, isFinished)
+
+			history, _, err := services.CreateConversationHistory(
+				context.TODO(),
+				application.ID,
+				request,
+			)
+			assert.NoError(t, err)
+			assert.Len(t, history, 0)
+		})
+	})
+}
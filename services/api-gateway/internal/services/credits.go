@@ -0,0 +1,299 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/dto"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/utils"
+	"github.com/basemind-ai/monorepo/shared/go/config"
+	"github.com/basemind-ai/monorepo/shared/go/datatypes"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/exc"
+	"github.com/basemind-ai/monorepo/shared/go/grpcutils"
+	"github.com/basemind-ai/monorepo/shared/go/ledger"
+	"github.com/basemind-ai/monorepo/shared/go/metrics"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/basemind-ai/monorepo/shared/go/rediscache"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sync/atomic"
+	"time"
+)
+
+type creditHoldContextKeyType int
+
+const creditHoldContextKey creditHoldContextKeyType = iota
+
+// creditHold is the credit hold of a request, which is released by the final debit of the request.
+type creditHold struct {
+	id        pgtype.UUID
+	isDebited atomic.Bool
+}
+
+const (
+	// CreditReconciliationInterval is the interval in which unrecorded debits are debited and expired holds deleted.
+	CreditReconciliationInterval = 5 * time.Minute
+	// unrecordedDebitGracePeriod is the age of a request record before it is debited by the reconciliation,
+	// so that the debits after the requests are not contended.
+	unrecordedDebitGracePeriod = time.Minute
+	// defaultHoldResponseTokens is the response tokens estimate of prompt configs without a max tokens parameter.
+	defaultHoldResponseTokens = 4096
+	// charactersPerToken is the rough number of characters per token used to estimate the request tokens.
+	charactersPerToken = 4
+)
+
+// DeductCredit debits the cost of the request record from the credits of the project, and records it in the ledger.
+// It must be called before the response is returned, so the credit hold of the request is only released once the
+// debit is recorded. The first debit with the credit hold in its context releases the hold in the same transaction,
+// so the debits of intermediate results must use withoutCreditHold to keep the hold until the final debit.
+// The debit is idempotent - a request record is debited at most once. A failed debit is logged and counted in the
+// metrics, and is debited later by the credit reconciliation - the hold keeps reserving the credits until it expires.
+// The cached credit check of the project is invalidated once its credits run out.
+func DeductCredit(
+	ctx context.Context, requestRecord *models.PromptRequestRecord,
+) {
+	projectID, ok := ctx.Value(grpcutils.ProjectIDContextKey).(pgtype.UUID)
+	if !ok {
+		log.Error().Msg("project id not in context")
+		return
+	}
+
+	// the debit must be recorded even if the request was cancelled, e.g. for cancelled streams
+	ctx = context.WithoutCancel(ctx)
+
+	var holdID pgtype.UUID
+	if hold, ok := ctx.Value(creditHoldContextKey).(*creditHold); ok && hold.isDebited.CompareAndSwap(false, true) {
+		holdID = hold.id
+	}
+
+	cost, costErr := ledger.RequestRecordCost(
+		requestRecord.RequestTokensCost,
+		requestRecord.ResponseTokensCost,
+	)
+	if costErr != nil {
+		log.Error().Err(costErr).Msg("failed to calculate request record cost")
+		metrics.CreditDeductionFailuresTotal.WithLabelValues(db.UUIDToString(&projectID)).Inc()
+		return
+	}
+
+	entry, debitErr := ledger.RecordDebit(ctx, projectID, requestRecord.ID, cost, holdID)
+	if debitErr != nil {
+		if !errors.Is(debitErr, ledger.ErrAlreadyDebited) {
+			log.Error().Err(debitErr).Msg("failed to deduct project credits")
+			metrics.CreditDeductionFailuresTotal.WithLabelValues(db.UUIDToString(&projectID)).Inc()
+		}
+		return
+	}
+
+	if balance, conversionErr := db.NumericToDecimal(entry.BalanceAfter); conversionErr == nil &&
+		!balance.IsPositive() {
+		rediscache.Invalidate(ctx, db.UUIDToString(&projectID))
+	}
+}
+
+// withoutCreditHold returns a context whose debits do not release the credit hold of the request, e.g. for the
+// debits of the invalid responses of the response schema retries, which are followed by further calls.
+func withoutCreditHold(ctx context.Context) context.Context {
+	return context.WithValue(ctx, creditHoldContextKey, nil)
+}
+
+// EstimateMaxCost returns a rough estimate of the maximal cost of a request - its prompt messages and template
+// variables at about four characters per token, and the max tokens parameter of the prompt config as the response.
+// The estimate covers the worst case of the request - every response schema retry served by the most expensive of
+// its primary and fallback models, with the invalid responses sent back to the model.
+func EstimateMaxCost(
+	requestConfiguration *dto.RequestConfigurationDTO,
+	templateVariables map[string]string,
+) decimal.Decimal {
+	calls := int32(1)
+	if len(ptr.Deref(requestConfiguration.PromptConfigData.ResponseSchema, nil)) > 0 {
+		calls += requestConfiguration.PromptConfigData.ResponseSchemaMaxRetries
+	}
+
+	maxCost := decimal.Zero
+
+	for _, attempt := range requestConfiguration.Attempts() {
+		if cost := estimateAttemptMaxCost(attempt, templateVariables, calls); cost.GreaterThan(maxCost) {
+			maxCost = cost
+		}
+	}
+
+	return maxCost
+}
+
+// estimateAttemptMaxCost returns a rough estimate of the maximal cost of the given number of calls of a model,
+// where every call after the first also sends the responses of the previous calls.
+func estimateAttemptMaxCost(
+	requestConfiguration *dto.RequestConfigurationDTO,
+	templateVariables map[string]string,
+	calls int32,
+) decimal.Decimal {
+	if requestConfiguration.ProviderModelPricing.TokenUnitSize <= 0 {
+		return decimal.Zero
+	}
+
+	promptConfig := requestConfiguration.PromptConfigData
+
+	requestCharacters := 0
+	if promptConfig.ProviderPromptMessages != nil {
+		requestCharacters += len(*promptConfig.ProviderPromptMessages)
+	}
+
+	for _, value := range templateVariables {
+		requestCharacters += len(value)
+	}
+
+	responseTokens := int32(defaultHoldResponseTokens)
+
+	if promptConfig.ModelParameters != nil {
+		parameters := struct {
+			MaxTokens *int32 `json:"maxTokens"`
+		}{}
+		if unmarshalErr := json.Unmarshal(*promptConfig.ModelParameters, &parameters); unmarshalErr == nil &&
+			parameters.MaxTokens != nil && *parameters.MaxTokens > 0 {
+			responseTokens = *parameters.MaxTokens
+		}
+	}
+
+	requestTokens := int32(requestCharacters/charactersPerToken) + 1
+
+	costs := utils.CalculateCosts(
+		utils.TokenUsage{
+			// the n-th call sends the n-1 responses of the previous calls
+			datatypes.TokenClassInput:  calls*requestTokens + calls*(calls-1)/2*responseTokens,
+			datatypes.TokenClassOutput: calls * responseTokens,
+		},
+		requestConfiguration.ProviderModelPricing,
+	)
+
+	return costs.TotalCost()
+}
+
+// EstimateEmbeddingsMaxCost returns a rough estimate of the maximal cost of an embeddings request - its inputs at
+// about four characters per token.
+func EstimateEmbeddingsMaxCost(
+	modelPricing datatypes.ProviderModelPricingDTO,
+	inputs []string,
+) decimal.Decimal {
+	if modelPricing.TokenUnitSize <= 0 {
+		return decimal.Zero
+	}
+
+	var requestTokens int32
+	for _, input := range inputs {
+		requestTokens += int32(len(input)/charactersPerToken) + 1
+	}
+
+	costs := utils.CalculateCosts(
+		utils.TokenUsage{datatypes.TokenClassInput: requestTokens},
+		modelPricing,
+	)
+
+	return costs.TotalCost()
+}
+
+// CreateCreditHold reserves the estimated maximal cost of a request from the available credits of the project,
+// unless CREDIT_HOLDS_ENABLED is set to false. Unlike the cached credit check, the hold is checked against the current
+// credits and the holds of the in-flight requests, so concurrent requests cannot overdraw the project.
+// Returns a context carrying the hold, which is released by the final debit of the request, and a function to be
+// deferred, which releases the hold if the request failed before its final debit.
+func CreateCreditHold(
+	ctx context.Context,
+	projectID pgtype.UUID,
+	requestConfiguration *dto.RequestConfigurationDTO,
+	templateVariables map[string]string,
+) (context.Context, func(), error) {
+	if !config.Get(ctx).CreditHoldsEnabled {
+		return ctx, func() {}, nil
+	}
+
+	return createCreditHold(ctx, projectID, EstimateMaxCost(requestConfiguration, templateVariables))
+}
+
+// CreateEmbeddingsCreditHold reserves the estimated maximal cost of an embeddings request, like CreateCreditHold.
+func CreateEmbeddingsCreditHold(
+	ctx context.Context,
+	projectID pgtype.UUID,
+	modelPricing datatypes.ProviderModelPricingDTO,
+	inputs []string,
+) (context.Context, func(), error) {
+	if !config.Get(ctx).CreditHoldsEnabled {
+		return ctx, func() {}, nil
+	}
+
+	return createCreditHold(ctx, projectID, EstimateEmbeddingsMaxCost(modelPricing, inputs))
+}
+
+func createCreditHold(
+	ctx context.Context,
+	projectID pgtype.UUID,
+	amount decimal.Decimal,
+) (context.Context, func(), error) {
+	hold, holdErr := ledger.CreateHold(ctx, projectID, amount)
+	if holdErr != nil {
+		if errors.Is(holdErr, ledger.ErrInsufficientCredits) {
+			return ctx, nil, status.Error(codes.ResourceExhausted, ErrorInsufficientCredits)
+		}
+
+		log.Error().Err(holdErr).Msg("failed to create credit hold")
+		return ctx, nil, status.Error(codes.Internal, "failed to reserve credits")
+	}
+
+	requestHold := &creditHold{id: hold.ID}
+
+	release := func() {
+		if requestHold.isDebited.Load() {
+			return
+		}
+
+		exc.LogIfErr(
+			ledger.ReleaseHold(context.WithoutCancel(ctx), hold.ID),
+			"failed to release credit hold",
+		)
+	}
+
+	return context.WithValue(ctx, creditHoldContextKey, requestHold), release, nil
+}
+
+// ReconcileCredits debits the request records whose debit failed, and deletes the expired credit holds.
+func ReconcileCredits(ctx context.Context) {
+	recorded, recordErr := ledger.RecordUnrecordedDebits(
+		ctx,
+		pgtype.UUID{},
+		time.Now().Add(-unrecordedDebitGracePeriod),
+	)
+	if recordErr != nil {
+		log.Error().Err(recordErr).Msg("failed to record unrecorded debits")
+	} else if recorded > 0 {
+		log.Warn().Int("recorded", recorded).Msg("recorded unrecorded debits")
+	}
+
+	deletedRows, deleteErr := ledger.DeleteExpiredHolds(ctx)
+	if deleteErr != nil {
+		log.Error().Err(deleteErr).Msg("failed to delete expired credit holds")
+		return
+	}
+
+	log.Debug().Int64("deletedRows", deletedRows).Msg("deleted expired credit holds")
+}
+
+// RunCreditReconciliation reconciles the credits in the given interval, until the context is done.
+func RunCreditReconciliation(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		ReconcileCredits(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/basemind-ai/monorepo/e2e/factories"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/dto"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/services"
+	"github.com/basemind-ai/monorepo/shared/go/config"
+	"github.com/basemind-ai/monorepo/shared/go/datatypes"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/exc"
+	"github.com/basemind-ai/monorepo/shared/go/grpcutils"
+	"github.com/basemind-ai/monorepo/shared/go/testutils"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestCredits(t *testing.T) { //nolint: revive
+	testutils.SetTestEnv(t)
+
+	pricing := datatypes.ProviderModelPricingDTO{
+		InputTokenPrice:  decimal.RequireFromString("0.001"),
+		OutputTokenPrice: decimal.RequireFromString("0.002"),
+		TokenUnitSize:    1000,
+	}
+
+	t.Run("EstimateMaxCost", func(t *testing.T) {
+		t.Run("estimates the cost with the max tokens parameter", func(t *testing.T) {
+			promptMessages := json.RawMessage(`"12345678"`)
+			modelParameters := json.RawMessage(`{"maxTokens": 100}`)
+
+			cost := services.EstimateMaxCost(&dto.RequestConfigurationDTO{
+				PromptConfigData: datatypes.PromptConfigDTO{
+					ProviderPromptMessages: &promptMessages,
+					ModelParameters:        &modelParameters,
+				},
+				ProviderModelPricing: pricing,
+			}, map[string]string{"name": "abcd"})
+
+			// (14 characters / 4 + 1) request tokens and 100 response tokens, per 1000 tokens
+			assert.Equal(t, "0.000204", cost.String())
+		})
+
+		t.Run("estimates the response tokens without the max tokens parameter", func(t *testing.T) {
+			cost := services.EstimateMaxCost(&dto.RequestConfigurationDTO{
+				ProviderModelPricing: pricing,
+			}, nil)
+
+			assert.Equal(t, "0.008193", cost.String())
+		})
+
+		t.Run("estimates the cost of the most expensive fallback model", func(t *testing.T) {
+			cost := services.EstimateMaxCost(&dto.RequestConfigurationDTO{
+				ProviderModelPricing: pricing,
+				Fallbacks: []dto.RequestConfigurationDTO{
+					{
+						ProviderModelPricing: datatypes.ProviderModelPricingDTO{
+							InputTokenPrice:  decimal.RequireFromString("0.002"),
+							OutputTokenPrice: decimal.RequireFromString("0.004"),
+							TokenUnitSize:    1000,
+						},
+					},
+					{},
+				},
+			}, nil)
+
+			assert.Equal(t, "0.016386", cost.String())
+		})
+
+		t.Run("estimates the cost of the response schema retries", func(t *testing.T) {
+			promptMessages := json.RawMessage(`"123456"`)
+			modelParameters := json.RawMessage(`{"maxTokens": 100}`)
+			responseSchema := json.RawMessage(`{"type":"object"}`)
+
+			cost := services.EstimateMaxCost(&dto.RequestConfigurationDTO{
+				PromptConfigData: datatypes.PromptConfigDTO{
+					ProviderPromptMessages:   &promptMessages,
+					ModelParameters:          &modelParameters,
+					ResponseSchema:           &responseSchema,
+					ResponseSchemaMaxRetries: 2,
+				},
+				ProviderModelPricing: pricing,
+			}, nil)
+
+			// 3 calls of (8 characters / 4 + 1) request tokens and 100 response tokens,
+			// where the retries send the 1 + 2 previous responses
+			assert.Equal(t, "0.000909", cost.String())
+		})
+
+		t.Run("returns zero without pricing", func(t *testing.T) {
+			cost := services.EstimateMaxCost(&dto.RequestConfigurationDTO{}, nil)
+
+			assert.True(t, cost.IsZero())
+		})
+	})
+
+	t.Run("EstimateEmbeddingsMaxCost", func(t *testing.T) {
+		t.Run("estimates the cost of the inputs", func(t *testing.T) {
+			cost := services.EstimateEmbeddingsMaxCost(pricing, []string{"12345678", "abcd"})
+
+			// (8 characters / 4 + 1) + (4 characters / 4 + 1) request tokens, per 1000 tokens
+			assert.Equal(t, "0.000005", cost.String())
+		})
+
+		t.Run("returns zero without pricing", func(t *testing.T) {
+			cost := services.EstimateEmbeddingsMaxCost(datatypes.ProviderModelPricingDTO{}, []string{"abcd"})
+
+			assert.True(t, cost.IsZero())
+		})
+	})
+
+	t.Run("CreateCreditHold", func(t *testing.T) {
+		retrieveHeldTotal := func(projectID pgtype.UUID) decimal.Decimal {
+			heldTotal, retrievalErr := db.GetQueries().
+				RetrieveProjectActiveCreditHoldsTotal(context.TODO(), projectID)
+			assert.NoError(t, retrievalErr)
+
+			return *exc.MustResult(db.NumericToDecimal(heldTotal))
+		}
+
+		t.Run("reserves credits until the request is debited", func(t *testing.T) {
+			project, _ := factories.CreateProject(context.TODO())
+			application, _ := factories.CreateApplication(context.TODO(), project.ID)
+			promptConfig, _ := factories.CreateOpenAIPromptConfig(context.TODO(), application.ID)
+			promptRequestRecord, _ := factories.CreatePromptRequestRecord(
+				context.TODO(),
+				promptConfig.ID,
+			)
+
+			ctx, release, holdErr := services.CreateCreditHold(
+				context.WithValue(context.TODO(), grpcutils.ProjectIDContextKey, project.ID),
+				project.ID,
+				&dto.RequestConfigurationDTO{ProviderModelPricing: pricing},
+				nil,
+			)
+			assert.NoError(t, holdErr)
+			assert.Equal(t, "0.008193", retrieveHeldTotal(project.ID).String())
+
+			services.DeductCredit(ctx, promptRequestRecord)
+			assert.True(t, retrieveHeldTotal(project.ID).IsZero())
+
+			release()
+			assert.True(t, retrieveHeldTotal(project.ID).IsZero())
+		})
+
+		t.Run("releases the hold of a request that was not debited", func(t *testing.T) {
+			project, _ := factories.CreateProject(context.TODO())
+
+			_, release, holdErr := services.CreateCreditHold(
+				context.TODO(),
+				project.ID,
+				&dto.RequestConfigurationDTO{ProviderModelPricing: pricing},
+				nil,
+			)
+			assert.NoError(t, holdErr)
+			assert.False(t, retrieveHeldTotal(project.ID).IsZero())
+
+			release()
+			assert.True(t, retrieveHeldTotal(project.ID).IsZero())
+		})
+
+		t.Run("returns ResourceExhausted if the credits do not cover the hold", func(t *testing.T) {
+			project, _ := factories.CreateProject(context.TODO())
+
+			_, _, holdErr := services.CreateCreditHold(
+				context.TODO(),
+				project.ID,
+				&dto.RequestConfigurationDTO{
+					ProviderModelPricing: datatypes.ProviderModelPricingDTO{
+						InputTokenPrice:  decimal.RequireFromString("1"),
+						OutputTokenPrice: decimal.RequireFromString("1"),
+						TokenUnitSize:    1,
+					},
+				},
+				nil,
+			)
+			assert.Equal(t, codes.ResourceExhausted, status.Code(holdErr))
+		})
+
+		t.Run("does not reserve credits if holds are disabled", func(t *testing.T) {
+			config.Get(context.TODO()).CreditHoldsEnabled = false
+			t.Cleanup(func() {
+				config.Get(context.TODO()).CreditHoldsEnabled = true
+			})
+
+			project, _ := factories.CreateProject(context.TODO())
+
+			ctx, release, holdErr := services.CreateCreditHold(
+				context.TODO(),
+				project.ID,
+				&dto.RequestConfigurationDTO{ProviderModelPricing: pricing},
+				nil,
+			)
+			assert.NoError(t, holdErr)
+			assert.NotNil(t, ctx)
+			release()
+
+			assert.True(t, retrieveHeldTotal(project.ID).IsZero())
+		})
+	})
+
+	t.Run("DeductCredit", func(t *testing.T) {
+		t.Run("deducts the cost of a request record once", func(t *testing.T) {
+			project, _ := factories.CreateProject(context.TODO())
+			application, _ := factories.CreateApplication(context.TODO(), project.ID)
+			promptConfig, _ := factories.CreateOpenAIPromptConfig(context.TODO(), application.ID)
+			promptRequestRecord, _ := factories.CreatePromptRequestRecord(
+				context.TODO(),
+				promptConfig.ID,
+			)
+
+			ctx := context.WithValue(
+				context.Background(),
+				grpcutils.ProjectIDContextKey,
+				project.ID,
+			)
+
+			services.DeductCredit(ctx, promptRequestRecord)
+			services.DeductCredit(ctx, promptRequestRecord)
+
+			retrievedProject, retrievalErr := db.GetQueries().RetrieveProject(ctx, project.ID)
+			assert.NoError(t, retrievalErr)
+			assert.Equal(
+				t,
+				"0.9999535",
+				exc.MustResult(db.NumericToDecimal(retrievedProject.Credits)).String(),
+			)
+		})
+
+		t.Run("does not panic without a project ID", func(t *testing.T) {
+			assert.NotPanics(t, func() {
+				services.DeductCredit(context.TODO(), nil)
+			})
+		})
+	})
+}
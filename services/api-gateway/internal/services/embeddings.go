@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"github.com/basemind-ai/monorepo/gen/go/gateway/v1"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/connectors"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/dto"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"slices"
+	"time"
+)
+
+// MaxEmbeddingsInputs is the maximal number of inputs of an embeddings request.
+const MaxEmbeddingsInputs = 2048
+
+// embeddingInputTypes are the supported input types of an embeddings request.
+var embeddingInputTypes = []string{"search_document", "search_query", "classification", "clustering"}
+
+// ValidateEmbeddingsRequest validates the inputs, model and options of an embeddings request.
+// Returns an InvalidArgument status error if the request is invalid.
+func ValidateEmbeddingsRequest(request *gateway.EmbeddingsRequest) error {
+	if len(request.Inputs) == 0 || len(request.Inputs) > MaxEmbeddingsInputs {
+		return status.Errorf(
+			codes.InvalidArgument,
+			"embeddings requests must have between 1 and %d inputs",
+			MaxEmbeddingsInputs,
+		)
+	}
+
+	if slices.Contains(request.Inputs, "") {
+		return status.Error(codes.InvalidArgument, "embeddings inputs must not be empty")
+	}
+
+	if validationErr := models.ValidateEmbeddingModelType(
+		models.ModelVendor(request.ModelVendor),
+		models.ModelType(request.ModelType),
+	); validationErr != nil {
+		return status.Error(codes.InvalidArgument, validationErr.Error())
+	}
+
+	if request.Dimensions != nil && *request.Dimensions == 0 {
+		return status.Error(codes.InvalidArgument, "embeddings dimensions must be greater than 0")
+	}
+
+	if request.InputType != nil && !slices.Contains(embeddingInputTypes, *request.InputType) {
+		return status.Errorf(codes.InvalidArgument, "unknown embeddings input type {%s}", *request.InputType)
+	}
+
+	return nil
+}
+
+// RetrieveEmbeddingsConfiguration retrieves the configuration of an embeddings request of the given application,
+// including the active pricing of the requested model for the project of the application.
+func RetrieveEmbeddingsConfiguration(
+	ctx context.Context,
+	applicationID pgtype.UUID,
+	request *gateway.EmbeddingsRequest,
+) (*dto.EmbeddingsConfigurationDTO, error) {
+	application, applicationQueryErr := db.GetQueries().RetrieveApplication(ctx, applicationID)
+	if applicationQueryErr != nil {
+		return nil, status.Errorf(
+			codes.NotFound,
+			"application does not exist: %v",
+			applicationQueryErr,
+		)
+	}
+
+	modelVendor := models.ModelVendor(request.ModelVendor)
+	modelType := models.ModelType(request.ModelType)
+
+	providerModelPricing, pricingErr := RetrieveProviderModelPricing(
+		ctx, modelType, modelVendor, application.ProjectID,
+	)
+	if pricingErr != nil {
+		// the pricing error is already a grpc status error
+		return nil, pricingErr
+	}
+
+	return &dto.EmbeddingsConfigurationDTO{
+		ApplicationID:        application.ID,
+		ModelVendor:          modelVendor,
+		ModelType:            modelType,
+		Dimensions:           request.Dimensions,
+		InputType:            request.InputType,
+		ProviderModelPricing: providerModelPricing,
+	}, nil
+}
+
+// RequestEmbeddings requests the embeddings of the inputs from the connector of the model vendor,
+// using the provider key of the project if it has one. The connector creates the request record of the request.
+func RequestEmbeddings(
+	ctx context.Context,
+	projectID pgtype.UUID,
+	embeddingsConfiguration *dto.EmbeddingsConfigurationDTO,
+	inputs []string,
+) (dto.EmbeddingsResultDTO, error) {
+	connector, connectorErr := connectors.GetProviderConnector(embeddingsConfiguration.ModelVendor)
+	if connectorErr != nil {
+		// the connector error is already a grpc status error
+		return dto.EmbeddingsResultDTO{}, connectorErr
+	}
+
+	providerKeyContext := CreateProviderAPIKeyContext(
+		ctx,
+		projectID,
+		embeddingsConfiguration.ModelVendor,
+	)
+
+	startTime := time.Now()
+	embeddingsResult := connector.RequestEmbeddings(providerKeyContext, embeddingsConfiguration, inputs)
+	observeEmbeddingsResult(projectID, embeddingsConfiguration, embeddingsResult, startTime)
+
+	return embeddingsResult, nil
+}
+
+// CreateEmbeddingsResponse creates the gateway response of an embeddings result.
+func CreateEmbeddingsResponse(
+	embeddingsConfiguration *dto.EmbeddingsConfigurationDTO,
+	embeddingsResult dto.EmbeddingsResultDTO,
+) *gateway.EmbeddingsResponse {
+	embeddings := make([]*gateway.Embedding, len(embeddingsResult.Embeddings))
+	for i, values := range embeddingsResult.Embeddings {
+		embeddings[i] = &gateway.Embedding{Values: values}
+	}
+
+	requestRecord := embeddingsResult.RequestRecord
+
+	return &gateway.EmbeddingsResponse{
+		Embeddings:    embeddings,
+		RequestTokens: uint32(requestRecord.RequestTokens),
+		RequestDuration: uint32(
+			requestRecord.FinishTime.Time.Sub(requestRecord.StartTime.Time).Milliseconds(),
+		),
+		ModelVendor: string(embeddingsConfiguration.ModelVendor),
+		ModelType:   string(embeddingsConfiguration.ModelType),
+	}
+}
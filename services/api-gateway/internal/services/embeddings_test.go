@@ -0,0 +1,161 @@
+package services_test
+
+import (
+	"context"
+	"github.com/basemind-ai/monorepo/e2e/factories"
+	"github.com/basemind-ai/monorepo/gen/go/gateway/v1"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/dto"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/services"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/basemind-ai/monorepo/shared/go/testutils"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+func TestEmbeddings(t *testing.T) { //nolint: revive
+	testutils.SetTestEnv(t)
+	project, _ := factories.CreateProject(context.TODO())
+	_ = factories.CreateProviderPricingModels(context.TODO())
+
+	createRequest := func() *gateway.EmbeddingsRequest {
+		return &gateway.EmbeddingsRequest{
+			Inputs:      []string{"The meaning of life", "is 42"},
+			ModelVendor: string(models.ModelVendorOPENAI),
+			ModelType:   string(models.ModelTypeTextEmbedding3Small),
+		}
+	}
+
+	t.Run("ValidateEmbeddingsRequest", func(t *testing.T) {
+		t.Run("returns nil for a valid request", func(t *testing.T) {
+			request := createRequest()
+			request.Dimensions = ptr.To(uint32(256))
+
+			assert.NoError(t, services.ValidateEmbeddingsRequest(request))
+
+			request = createRequest()
+			request.ModelVendor = string(models.ModelVendorCOHERE)
+			request.ModelType = string(models.ModelTypeEmbedEnglishV30)
+			request.InputType = ptr.To("search_query")
+
+			assert.NoError(t, services.ValidateEmbeddingsRequest(request))
+		})
+
+		for _, testCase := range []struct {
+			Name   string
+			Modify func(request *gateway.EmbeddingsRequest)
+		}{
+			{
+				Name:   "returns an error without inputs",
+				Modify: func(request *gateway.EmbeddingsRequest) { request.Inputs = nil },
+			},
+			{
+				Name: "returns an error with too many inputs",
+				Modify: func(request *gateway.EmbeddingsRequest) {
+					request.Inputs = make([]string, services.MaxEmbeddingsInputs+1)
+					for i := range request.Inputs {
+						request.Inputs[i] = "input"
+					}
+				},
+			},
+			{
+				Name:   "returns an error for an empty input",
+				Modify: func(request *gateway.EmbeddingsRequest) { request.Inputs = append(request.Inputs, "") },
+			},
+			{
+				Name: "returns an error for a completion model",
+				Modify: func(request *gateway.EmbeddingsRequest) {
+					request.ModelType = string(models.ModelTypeGpt4)
+				},
+			},
+			{
+				Name: "returns an error for a model of another vendor",
+				Modify: func(request *gateway.EmbeddingsRequest) {
+					request.ModelVendor = string(models.ModelVendorCOHERE)
+				},
+			},
+			{
+				Name:   "returns an error for zero dimensions",
+				Modify: func(request *gateway.EmbeddingsRequest) { request.Dimensions = ptr.To(uint32(0)) },
+			},
+			{
+				Name:   "returns an error for an unknown input type",
+				Modify: func(request *gateway.EmbeddingsRequest) { request.InputType = ptr.To("unknown") },
+			},
+		} {
+			t.Run(testCase.Name, func(t *testing.T) {
+				request := createRequest()
+				testCase.Modify(request)
+
+				err := services.ValidateEmbeddingsRequest(request)
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			})
+		}
+	})
+
+	t.Run("RetrieveEmbeddingsConfiguration", func(t *testing.T) {
+		t.Run("returns the configuration with the model pricing", func(t *testing.T) {
+			application, _ := factories.CreateApplication(context.TODO(), project.ID)
+
+			request := createRequest()
+			request.Dimensions = ptr.To(uint32(256))
+
+			configuration, err := services.RetrieveEmbeddingsConfiguration(
+				context.TODO(),
+				application.ID,
+				request,
+			)
+			assert.NoError(t, err)
+
+			assert.Equal(t, application.ID, configuration.ApplicationID)
+			assert.Equal(t, models.ModelVendorOPENAI, configuration.ModelVendor)
+			assert.Equal(t, models.ModelTypeTextEmbedding3Small, configuration.ModelType)
+			assert.Equal(t, request.Dimensions, configuration.Dimensions)
+			assert.Equal(t, "0.02", configuration.ProviderModelPricing.InputTokenPrice.String())
+		})
+
+		t.Run("returns a not found error if the application does not exist", func(t *testing.T) {
+			_, err := services.RetrieveEmbeddingsConfiguration(
+				context.TODO(),
+				pgtype.UUID{Bytes: [16]byte{1}, Valid: true},
+				createRequest(),
+			)
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
+
+	t.Run("CreateEmbeddingsResponse", func(t *testing.T) {
+		t.Run("returns the embeddings and the request record usage", func(t *testing.T) {
+			startTime := time.Now()
+
+			response := services.CreateEmbeddingsResponse(
+				&dto.EmbeddingsConfigurationDTO{
+					ModelVendor: models.ModelVendorOPENAI,
+					ModelType:   models.ModelTypeTextEmbedding3Small,
+				},
+				dto.EmbeddingsResultDTO{
+					Embeddings: [][]float32{{0.1, 0.2}, {0.3, 0.4}},
+					RequestRecord: &models.PromptRequestRecord{
+						RequestTokens: 7,
+						StartTime:     pgtype.Timestamptz{Time: startTime, Valid: true},
+						FinishTime: pgtype.Timestamptz{
+							Time:  startTime.Add(150 * time.Millisecond),
+							Valid: true,
+						},
+					},
+				},
+			)
+
+			assert.Len(t, response.Embeddings, 2)
+			assert.Equal(t, []float32{0.3, 0.4}, response.Embeddings[1].Values)
+			assert.Equal(t, uint32(7), response.RequestTokens)
+			assert.Equal(t, uint32(150), response.RequestDuration)
+			assert.Equal(t, string(models.ModelVendorOPENAI), response.ModelVendor)
+			assert.Equal(t, string(models.ModelTypeTextEmbedding3Small), response.ModelType)
+		})
+	})
+}
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/connectors"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/dto"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+// IsFallbackError returns true if the given connector error should trigger a fallback to the next model.
+func IsFallbackError(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// shouldFallback returns true if the request should be retried with the next model after the given result.
+func shouldFallback(ctx context.Context, result dto.PromptResultDTO, isLastAttempt bool) bool {
+	return !isLastAttempt && ctx.Err() == nil && IsFallbackError(result.Error)
+}
+
+// resolveAttempts returns the request configurations that have a configured connector, in order.
+// Returns the connector error of the primary configuration if it is not configured,
+// fallback configurations without a connector are skipped.
+func resolveAttempts(
+	requestConfiguration *dto.RequestConfigurationDTO,
+) ([]*dto.RequestConfigurationDTO, []connectors.ProviderConnector, error) {
+	var (
+		attempts          []*dto.RequestConfigurationDTO
+		attemptConnectors []connectors.ProviderConnector
+	)
+
+	for i, attempt := range requestConfiguration.Attempts() {
+		connector, connectorErr := connectors.GetProviderConnector(
+			attempt.PromptConfigData.ModelVendor,
+		)
+		if connectorErr != nil {
+			if i == 0 {
+				// the connector error is already a grpc status error
+				return nil, nil, connectorErr
+			}
+
+			log.Warn().
+				Err(connectorErr).
+				Str("modelVendor", string(attempt.PromptConfigData.ModelVendor)).
+				Msg("skipping fallback model")
+
+			continue
+		}
+
+		attempts = append(attempts, attempt)
+		attemptConnectors = append(attemptConnectors, connector)
+	}
+
+	return attempts, attemptConnectors, nil
+}
+
+// RequestPromptWithFallback requests a prompt using the primary model of the request configuration,
+// falling back to the next configured model when the connector returns a fallback error.
+// Every attempt creates its own prompt request record.
+func RequestPromptWithFallback(
+	ctx context.Context,
+	projectID pgtype.UUID,
+	requestConfiguration *dto.RequestConfigurationDTO,
+	templateVariables map[string]string,
+	conversationHistory []dto.ConversationMessageDTO,
+) (dto.PromptResultDTO, error) {
+	attempts, attemptConnectors, resolveErr := resolveAttempts(requestConfiguration)
+	if resolveErr != nil {
+		return dto.PromptResultDTO{}, resolveErr
+	}
+
+	var promptResult dto.PromptResultDTO
+
+	for i, attempt := range attempts {
+		providerKeyContext := CreateProviderAPIKeyContext(
+			ctx,
+			projectID,
+			attempt.PromptConfigData.ModelVendor,
+		)
+
+		startTime := time.Now()
+		promptResult = attemptConnectors[i].RequestPrompt(
+			providerKeyContext,
+			attempt,
+			templateVariables,
+			conversationHistory,
+		)
+		observePromptResult(projectID, attempt, promptResult, startTime, false)
+
+		promptResult.ModelVendor = attempt.PromptConfigData.ModelVendor
+		promptResult.ModelType = attempt.PromptConfigData.ModelType
+
+		if !shouldFallback(ctx, promptResult, i == len(attempts)-1) {
+			break
+		}
+
+		log.Warn().
+			Err(promptResult.Error).
+			Str("modelVendor", string(attempt.PromptConfigData.ModelVendor)).
+			Str("modelType", string(attempt.PromptConfigData.ModelType)).
+			Msg("prompt request failed, falling back to the next model")
+	}
+
+	return promptResult, nil
+}
+
+// RequestStreamWithFallback requests a streaming prompt using the primary model of the request configuration,
+// falling back to the next configured model when the connector returns a fallback error.
+// A fallback is only possible before any content has been streamed to the channel.
+// Every attempt creates its own prompt request record. The channel is closed when the stream ends.
+func RequestStreamWithFallback(
+	ctx context.Context,
+	projectID pgtype.UUID,
+	requestConfiguration *dto.RequestConfigurationDTO,
+	templateVariables map[string]string,
+	conversationHistory []dto.ConversationMessageDTO,
+	channel chan<- dto.PromptResultDTO,
+) error {
+	attempts, attemptConnectors, resolveErr := resolveAttempts(requestConfiguration)
+	if resolveErr != nil {
+		return resolveErr
+	}
+
+	go func() {
+		defer close(channel)
+
+		for i, attempt := range attempts {
+			providerKeyContext := CreateProviderAPIKeyContext(
+				ctx,
+				projectID,
+				attempt.PromptConfigData.ModelVendor,
+			)
+
+			attemptChannel := make(chan dto.PromptResultDTO)
+			observer := newStreamObserver(projectID, attempt)
+
+			go attemptConnectors[i].RequestStream(
+				providerKeyContext,
+				attempt,
+				templateVariables,
+				conversationHistory,
+				attemptChannel,
+			)
+
+			isStreaming := false
+			isFallback := false
+
+			for result := range attemptChannel {
+				observer.observe(result)
+
+				isFinal := result.Error != nil || result.RequestRecord != nil
+				if isFinal {
+					result.ModelVendor = attempt.PromptConfigData.ModelVendor
+					result.ModelType = attempt.PromptConfigData.ModelType
+				}
+
+				if !isStreaming && shouldFallback(ctx, result, i == len(attempts)-1) {
+					isFallback = true
+
+					log.Warn().
+						Err(result.Error).
+						Str("modelVendor", string(attempt.PromptConfigData.ModelVendor)).
+						Str("modelType", string(attempt.PromptConfigData.ModelType)).
+						Msg("prompt stream failed, falling back to the next model")
+
+					continue
+				}
+
+				isStreaming = true
+				channel <- result
+			}
+
+			if !isFallback {
+				return
+			}
+		}
+	}()
+
+	return nil
+}
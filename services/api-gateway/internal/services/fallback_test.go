@@ -0,0 +1,309 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/basemind-ai/monorepo/e2e/factories"
+	openaiconnector "github.com/basemind-ai/monorepo/gen/go/openai/v1"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/connectors"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/dto"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/services"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/utils"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/basemind-ai/monorepo/shared/go/testutils"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+// generatingStream is an upstream stream that keeps generating content until its context is cancelled.
+type generatingStream struct {
+	ctx context.Context
+}
+
+func (s generatingStream) Recv() (*string, error) {
+	if s.ctx.Err() != nil {
+		return nil, status.FromContextError(s.ctx.Err()).Err()
+	}
+
+	return ptr.To("token "), nil
+}
+
+// mockStreamConnector streams the content of a generatingStream the way the provider connectors do.
+type mockStreamConnector struct {
+	connectors.ProviderConnector
+}
+
+func (mockStreamConnector) RequestStream(
+	ctx context.Context,
+	_ *dto.RequestConfigurationDTO,
+	_ map[string]string,
+	_ []dto.ConversationMessageDTO,
+	channel chan<- dto.PromptResultDTO,
+) {
+	finalResult := &dto.PromptResultDTO{}
+
+	streamFinish := utils.StreamFromClient[string](
+		ctx,
+		channel,
+		finalResult,
+		&models.CreatePromptRequestRecordParams{},
+		"prompt text",
+		generatingStream{ctx: ctx},
+		func(msg *string) *utils.StreamMessage {
+			if msg == nil {
+				return &utils.StreamMessage{FinishReason: ptr.To(string(models.PromptFinishReasonERROR))}
+			}
+
+			return &utils.StreamMessage{Content: msg}
+		},
+	)
+
+	finalResult.RequestRecord = &models.PromptRequestRecord{
+		FinishReason:   streamFinish.FinishReason,
+		RequestTokens:  int32(streamFinish.RequestTokenCount),
+		ResponseTokens: int32(streamFinish.ResponseTokenCount),
+	}
+
+	channel <- *finalResult
+	close(channel)
+}
+
+func TestFallback(t *testing.T) { //nolint: revive
+	testutils.SetTestEnv(t)
+	project, _ := factories.CreateProject(context.TODO())
+	_ = factories.CreateProviderPricingModels(context.TODO())
+
+	createFallbackRequestConfiguration := func(t *testing.T) *dto.RequestConfigurationDTO {
+		t.Helper()
+
+		requestConfiguration := createRequestConfigurationDTO(t, project.ID)
+		fallback := requestConfiguration
+		fallback.PromptConfigData.ModelType = models.ModelTypeGpt35Turbo16k
+		fallback.ProviderModelPricing, _ = services.RetrieveProviderModelPricing(
+			context.TODO(),
+			models.ModelTypeGpt35Turbo16k,
+			models.ModelVendorOPENAI,
+			project.ID,
+		)
+		requestConfiguration.Fallbacks = []dto.RequestConfigurationDTO{fallback}
+
+		return &requestConfiguration
+	}
+
+	providerKeyCacheKey := fmt.Sprintf(
+		"%s:%s",
+		db.UUIDToString(&project.ID),
+		models.ModelVendorOPENAI,
+	)
+
+	t.Run("IsFallbackError", func(t *testing.T) {
+		for _, code := range []codes.Code{codes.Unavailable, codes.ResourceExhausted, codes.DeadlineExceeded} {
+			assert.True(t, services.IsFallbackError(status.Error(code, "error")))
+		}
+
+		for _, code := range []codes.Code{codes.Internal, codes.InvalidArgument, codes.Unauthenticated} {
+			assert.False(t, services.IsFallbackError(status.Error(code, "error")))
+		}
+
+		assert.False(t, services.IsFallbackError(nil))
+	})
+
+	t.Run("RequestPromptWithFallback", func(t *testing.T) {
+		t.Run("returns the primary result when the request succeeds", func(t *testing.T) {
+			openaiService := createOpenAIService(t)
+			_, mockRedis := createTestCache(t, providerKeyCacheKey)
+
+			openaiService.Response = &openaiconnector.OpenAIPromptResponse{
+				Content:             "Response content",
+				FinishReason:        "DONE",
+				RequestTokensCount:  10,
+				ResponseTokensCount: 20,
+			}
+
+			mockRedis.ExpectGet(providerKeyCacheKey).RedisNil()
+
+			requestConfiguration := createFallbackRequestConfiguration(t)
+
+			result, err := services.RequestPromptWithFallback(
+				context.TODO(),
+				project.ID,
+				requestConfiguration,
+				map[string]string{"userInput": "abc"},
+				nil,
+			)
+			assert.NoError(t, err)
+			assert.NoError(t, result.Error)
+			assert.Equal(t, "Response content", *result.Content)
+			assert.Equal(t, requestConfiguration.PromptConfigData.ModelType, result.ModelType)
+			assert.Equal(t, models.ModelVendorOPENAI, result.ModelVendor)
+		})
+
+		t.Run("falls back to the next model on a fallback error", func(t *testing.T) {
+			openaiService := createOpenAIService(t)
+			_, mockRedis := createTestCache(t, providerKeyCacheKey)
+
+			openaiService.Error = status.Error(codes.Unavailable, "service unavailable")
+
+			mockRedis.ExpectGet(providerKeyCacheKey).RedisNil()
+			mockRedis.ExpectGet(providerKeyCacheKey).RedisNil()
+
+			result, err := services.RequestPromptWithFallback(
+				context.TODO(),
+				project.ID,
+				createFallbackRequestConfiguration(t),
+				map[string]string{"userInput": "abc"},
+				nil,
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, codes.Unavailable, status.Code(result.Error))
+			assert.Equal(t, models.ModelTypeGpt35Turbo16k, result.ModelType)
+			assert.NotNil(t, result.RequestRecord)
+			assert.Equal(t, models.PromptFinishReasonERROR, result.RequestRecord.FinishReason)
+		})
+
+		t.Run("does not fall back on other errors", func(t *testing.T) {
+			openaiService := createOpenAIService(t)
+			_, mockRedis := createTestCache(t, providerKeyCacheKey)
+
+			openaiService.Error = status.Error(codes.InvalidArgument, "invalid request")
+
+			mockRedis.ExpectGet(providerKeyCacheKey).RedisNil()
+
+			requestConfiguration := createFallbackRequestConfiguration(t)
+
+			result, err := services.RequestPromptWithFallback(
+				context.TODO(),
+				project.ID,
+				requestConfiguration,
+				map[string]string{"userInput": "abc"},
+				nil,
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, codes.InvalidArgument, status.Code(result.Error))
+			assert.Equal(t, requestConfiguration.PromptConfigData.ModelType, result.ModelType)
+		})
+
+		t.Run("returns an error when the primary connector is not configured", func(t *testing.T) {
+			requestConfiguration := createFallbackRequestConfiguration(t)
+			requestConfiguration.PromptConfigData.ModelVendor = models.ModelVendor("UNKNOWN")
+
+			_, err := services.RequestPromptWithFallback(
+				context.TODO(),
+				project.ID,
+				requestConfiguration,
+				map[string]string{"userInput": "abc"},
+				nil,
+			)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	})
+
+	t.Run("RequestStreamWithFallback", func(t *testing.T) {
+		t.Run("falls back to the next model when the stream fails before any content", func(t *testing.T) {
+			openaiService := createOpenAIService(t)
+			_, mockRedis := createTestCache(t, providerKeyCacheKey)
+
+			openaiService.Error = status.Error(codes.Unavailable, "service unavailable")
+
+			mockRedis.ExpectGet(providerKeyCacheKey).RedisNil()
+			mockRedis.ExpectGet(providerKeyCacheKey).RedisNil()
+
+			channel := make(chan dto.PromptResultDTO)
+
+			err := services.RequestStreamWithFallback(
+				context.TODO(),
+				project.ID,
+				createFallbackRequestConfiguration(t),
+				map[string]string{"userInput": "abc"},
+				nil,
+				channel,
+			)
+			assert.NoError(t, err)
+
+			results := make([]dto.PromptResultDTO, 0)
+			for result := range channel {
+				results = append(results, result)
+			}
+
+			assert.Len(t, results, 1)
+			assert.Equal(t, codes.Unavailable, status.Code(results[0].Error))
+			assert.Equal(t, models.ModelTypeGpt35Turbo16k, results[0].ModelType)
+		})
+
+		t.Run("aborts the connector stream when the request is cancelled", func(t *testing.T) {
+			mockVendor := models.ModelVendor("MOCK")
+			connectors.Register(mockVendor, connectors.Registration{
+				AddressEnvVar: "MOCK_CONNECTOR_ADDRESS",
+				Factory: func(string, ...grpc.DialOption) connectors.ProviderConnector {
+					return mockStreamConnector{}
+				},
+			})
+			t.Setenv("MOCK_CONNECTOR_ADDRESS", "mock")
+			connectors.Init(context.TODO())
+
+			mockProviderKeyCacheKey := fmt.Sprintf("%s:%s", db.UUIDToString(&project.ID), mockVendor)
+			_, mockRedis := createTestCache(t, mockProviderKeyCacheKey)
+			mockRedis.ExpectGet(mockProviderKeyCacheKey).RedisNil()
+
+			requestConfiguration := createRequestConfigurationDTO(t, project.ID)
+			requestConfiguration.PromptConfigData.ModelVendor = mockVendor
+
+			defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+			ctx, cancel := context.WithCancel(context.TODO())
+			defer cancel()
+
+			channel := make(chan dto.PromptResultDTO)
+
+			err := services.RequestStreamWithFallback(
+				ctx,
+				project.ID,
+				&requestConfiguration,
+				map[string]string{"userInput": "abc"},
+				nil,
+				channel,
+			)
+			assert.NoError(t, err)
+
+			var results []dto.PromptResultDTO
+
+			streamErr := services.StreamFromChannel(
+				ctx,
+				channel,
+				&mockGatewayServerStream{Ctx: ctx},
+				func(_ context.Context, result dto.PromptResultDTO) (dto.PromptResultDTO, bool) {
+					results = append(results, result)
+
+					// the client disconnects after receiving the first tokens
+					if len(results) == 2 {
+						cancel()
+					}
+
+					return result, result.RequestRecord != nil
+				},
+			)
+			assert.Equal(t, context.Canceled, streamErr)
+
+			finalResult := results[len(results)-1]
+			assert.NoError(t, finalResult.Error)
+			assert.Equal(t, mockVendor, finalResult.ModelVendor)
+			assert.Equal(t, models.PromptFinishReasonCANCELLED, finalResult.RequestRecord.FinishReason)
+			assert.Equal(
+				t,
+				int32(utils.EstimateTokenCount("prompt text")),
+				finalResult.RequestRecord.RequestTokens,
+			)
+			assert.GreaterOrEqual(
+				t,
+				finalResult.RequestRecord.ResponseTokens,
+				int32(utils.EstimateTokenCount("token token ")),
+			)
+		})
+	})
+}
@@ -9,6 +9,8 @@ import (
 	openaiconnector "github.com/basemind-ai/monorepo/gen/go/openai/v1"
 	"github.com/basemind-ai/monorepo/gen/go/ptesting/v1"
 	"github.com/basemind-ai/monorepo/services/api-gateway/internal/connectors"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/services"
+	"github.com/basemind-ai/monorepo/shared/go/budgets"
 	"github.com/basemind-ai/monorepo/shared/go/db"
 	"github.com/basemind-ai/monorepo/shared/go/db/models"
 	"github.com/basemind-ai/monorepo/shared/go/exc"
@@ -117,6 +119,8 @@ func TestIntegration(t *testing.T) { //nolint: revive
 	)
 	assert.NoError(t, providerKeyErr)
 
+	providerKeyCacheKey := fmt.Sprintf("%s:%s", projectID, models.ModelVendorOPENAI)
+
 	t.Run("APIGatewayService", func(t *testing.T) {
 		t.Run("RequestPrompt", func(t *testing.T) {
 			t.Run("returns response correctly", func(t *testing.T) {
@@ -147,7 +151,12 @@ func TestIntegration(t *testing.T) { //nolint: revive
 				mockRedis.ExpectSet(db.UUIDToString(&project.ID), exc.MustResult(cacheClient.Marshal(status.Status{})), time.Minute*5).
 					SetVal("OK")
 
-				mockRedis.ExpectSet(db.UUIDToString(&project.ID), exc.MustResult(cacheClient.Marshal(&models.RetrieveProviderKeyRow{
+				mockRedis.ExpectGet(budgets.CreateCacheKey(requestConfigurationDTO.ApplicationID)).
+					RedisNil()
+				mockRedis.ExpectSet(budgets.CreateCacheKey(requestConfigurationDTO.ApplicationID), exc.MustResult(cacheClient.Marshal(status.Status{})), services.SpendBudgetsCacheTTL).
+					SetVal("OK")
+
+				mockRedis.ExpectSet(providerKeyCacheKey, exc.MustResult(cacheClient.Marshal(&models.RetrieveProviderKeyRow{
 					ID:              providerKey.ID,
 					ModelVendor:     models.ModelVendorOPENAI,
 					EncryptedApiKey: providerKey.EncryptedApiKey,
@@ -175,7 +184,7 @@ func TestIntegration(t *testing.T) { //nolint: revive
 				mockRedis.ExpectGet(db.UUIDToString(&project.ID)).
 					SetVal(string(exc.MustResult(cacheClient.Marshal(status.Status{}))))
 
-				mockRedis.ExpectGet(db.UUIDToString(&project.ID)).
+				mockRedis.ExpectGet(providerKeyCacheKey).
 					SetVal(string(exc.MustResult(cacheClient.Marshal(&models.RetrieveProviderKeyRow{
 						ID:              providerKey.ID,
 						ModelVendor:     models.ModelVendorOPENAI,
@@ -209,7 +218,7 @@ func TestIntegration(t *testing.T) { //nolint: revive
 					RedisNil()
 				mockRedis.ExpectSet(db.UUIDToString(&requestConfigurationDTO.ApplicationID), expectedCacheValue, time.Hour/2).
 					SetVal("OK")
-				mockRedis.ExpectSet(db.UUIDToString(&project.ID), exc.MustResult(cacheClient.Marshal(&models.RetrieveProviderKeyRow{
+				mockRedis.ExpectSet(providerKeyCacheKey, exc.MustResult(cacheClient.Marshal(&models.RetrieveProviderKeyRow{
 					ID:              providerKey.ID,
 					ModelVendor:     models.ModelVendorOPENAI,
 					EncryptedApiKey: providerKey.EncryptedApiKey,
@@ -271,7 +280,12 @@ func TestIntegration(t *testing.T) { //nolint: revive
 				mockRedis.ExpectSet(db.UUIDToString(&project.ID), exc.MustResult(cacheClient.Marshal(status.Status{})), time.Minute*5).
 					SetVal("OK")
 
-				mockRedis.ExpectSet(db.UUIDToString(&project.ID), exc.MustResult(cacheClient.Marshal(&models.RetrieveProviderKeyRow{
+				mockRedis.ExpectGet(budgets.CreateCacheKey(requestConfigurationDTO.ApplicationID)).
+					RedisNil()
+				mockRedis.ExpectSet(budgets.CreateCacheKey(requestConfigurationDTO.ApplicationID), exc.MustResult(cacheClient.Marshal(status.Status{})), services.SpendBudgetsCacheTTL).
+					SetVal("OK")
+
+				mockRedis.ExpectSet(providerKeyCacheKey, exc.MustResult(cacheClient.Marshal(&models.RetrieveProviderKeyRow{
 					ID:              providerKey.ID,
 					ModelVendor:     models.ModelVendorOPENAI,
 					EncryptedApiKey: providerKey.EncryptedApiKey,
@@ -352,7 +366,7 @@ func TestIntegration(t *testing.T) { //nolint: revive
 					db.UUIDToString(&requestConfigurationDTO.ApplicationID),
 				)
 
-				mockRedis.ExpectSet(db.UUIDToString(&project.ID), exc.MustResult(cacheClient.Marshal(&models.RetrieveProviderKeyRow{
+				mockRedis.ExpectSet(providerKeyCacheKey, exc.MustResult(cacheClient.Marshal(&models.RetrieveProviderKeyRow{
 					ID:              providerKey.ID,
 					ModelVendor:     models.ModelVendorOPENAI,
 					EncryptedApiKey: providerKey.EncryptedApiKey,
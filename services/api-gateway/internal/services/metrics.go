@@ -0,0 +1,124 @@
+package services
+
+import (
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/dto"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/metrics"
+	"github.com/jackc/pgx/v5/pgtype"
+	"strconv"
+	"time"
+)
+
+// observePromptResult records the connector latency of a final prompt result,
+// and the tokens of its request record if it has one.
+func observePromptResult(
+	projectID pgtype.UUID,
+	requestConfiguration *dto.RequestConfigurationDTO,
+	result dto.PromptResultDTO,
+	startTime time.Time,
+	isStream bool,
+) {
+	modelVendor := string(requestConfiguration.PromptConfigData.ModelVendor)
+	modelType := string(requestConfiguration.PromptConfigData.ModelType)
+
+	outcome := "success"
+	if result.Error != nil {
+		outcome = "error"
+	} else if result.RequestRecord != nil &&
+		result.RequestRecord.FinishReason == models.PromptFinishReasonCANCELLED {
+		outcome = "cancelled"
+	}
+
+	metrics.ConnectorRequestDuration.
+		WithLabelValues(modelVendor, modelType, strconv.FormatBool(isStream), outcome).
+		Observe(time.Since(startTime).Seconds())
+
+	if result.RequestRecord == nil {
+		return
+	}
+
+	projectLabel := db.UUIDToString(&projectID)
+	applicationLabel := db.UUIDToString(&requestConfiguration.ApplicationID)
+
+	metrics.TokensTotal.
+		WithLabelValues(projectLabel, applicationLabel, modelVendor, modelType, "request").
+		Add(float64(result.RequestRecord.RequestTokens))
+	metrics.TokensTotal.
+		WithLabelValues(projectLabel, applicationLabel, modelVendor, modelType, "response").
+		Add(float64(result.RequestRecord.ResponseTokens))
+}
+
+// streamObserver records the metrics of a connector stream from the results it sends.
+type streamObserver struct {
+	projectID            pgtype.UUID
+	requestConfiguration *dto.RequestConfigurationDTO
+	startTime            time.Time
+	hasContent           bool
+}
+
+func newStreamObserver(
+	projectID pgtype.UUID,
+	requestConfiguration *dto.RequestConfigurationDTO,
+) *streamObserver {
+	return &streamObserver{
+		projectID:            projectID,
+		requestConfiguration: requestConfiguration,
+		startTime:            time.Now(),
+	}
+}
+
+// observe records the time to first token on the first content of the stream,
+// and the latency and tokens of the stream on its final result.
+func (o *streamObserver) observe(result dto.PromptResultDTO) {
+	if !o.hasContent && result.Content != nil && *result.Content != "" {
+		o.hasContent = true
+
+		metrics.StreamTimeToFirstToken.WithLabelValues(
+			string(o.requestConfiguration.PromptConfigData.ModelVendor),
+			string(o.requestConfiguration.PromptConfigData.ModelType),
+		).Observe(time.Since(o.startTime).Seconds())
+	}
+
+	if result.Error != nil || result.RequestRecord != nil {
+		observePromptResult(o.projectID, o.requestConfiguration, result, o.startTime, true)
+	}
+}
+
+// observeEmbeddingsResult records the connector latency of an embeddings result,
+// and the request tokens of its request record if it has one.
+func observeEmbeddingsResult(
+	projectID pgtype.UUID,
+	embeddingsConfiguration *dto.EmbeddingsConfigurationDTO,
+	result dto.EmbeddingsResultDTO,
+	startTime time.Time,
+) {
+	modelVendor := string(embeddingsConfiguration.ModelVendor)
+	modelType := string(embeddingsConfiguration.ModelType)
+
+	outcome := "success"
+	if result.Error != nil {
+		outcome = "error"
+	} else if result.RequestRecord != nil &&
+		result.RequestRecord.FinishReason == models.PromptFinishReasonCANCELLED {
+		outcome = "cancelled"
+	}
+
+	metrics.ConnectorRequestDuration.
+		WithLabelValues(modelVendor, modelType, strconv.FormatBool(false), outcome).
+		Observe(time.Since(startTime).Seconds())
+
+	if result.RequestRecord == nil {
+		return
+	}
+
+	metrics.TokensTotal.
+		WithLabelValues(
+			db.UUIDToString(&projectID),
+			db.UUIDToString(&embeddingsConfiguration.ApplicationID),
+			modelVendor,
+			modelType,
+			"request",
+		).
+		Add(float64(result.RequestRecord.RequestTokens))
+}
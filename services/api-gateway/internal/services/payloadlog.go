@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/basemind-ai/monorepo/gen/go/gateway/v1"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/dto"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/utils"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/basemind-ai/monorepo/shared/go/redaction"
+	"github.com/basemind-ai/monorepo/shared/go/serialization"
+	"github.com/rs/zerolog/log"
+	"strings"
+	"time"
+)
+
+// PayloadRetentionInterval is the interval in which expired payload logs are deleted.
+const PayloadRetentionInterval = time.Hour
+
+// promptMessage is the vendor independent shape of a stored prompt message -
+// OpenAI messages have a role and content, Cohere messages have a message only.
+type promptMessage struct {
+	Role              *string   `json:"role,omitempty"`
+	Content           *string   `json:"content,omitempty"`
+	Message           *string   `json:"message,omitempty"`
+	TemplateVariables *[]string `json:"templateVariables,omitempty"`
+}
+
+// RenderPayloadMessages renders the messages sent to the model for the payload log - the prompt config messages
+// with the template variables applied, followed by the conversation history.
+func RenderPayloadMessages(
+	promptMessages *json.RawMessage,
+	templateVariables map[string]string,
+	conversationHistory []dto.ConversationMessageDTO,
+) []dto.PayloadMessageDTO {
+	var storedMessages []promptMessage
+	if promptMessages != nil {
+		if unmarshalErr := json.Unmarshal(*promptMessages, &storedMessages); unmarshalErr != nil {
+			log.Error().Err(unmarshalErr).Msg("failed to unmarshal prompt messages for the payload log")
+		}
+	}
+
+	messages := make([]dto.PayloadMessageDTO, 0, len(storedMessages)+len(conversationHistory))
+	for _, storedMessage := range storedMessages {
+		content := ptr.Deref(storedMessage.Content, ptr.Deref(storedMessage.Message, ""))
+
+		// the template variables were validated before the request was sent, so parsing should not fail
+		if parsedContent, parseErr := utils.ParseTemplateVariables(
+			content,
+			ptr.Deref(storedMessage.TemplateVariables, []string{}),
+			templateVariables,
+		); parseErr == nil {
+			content = parsedContent
+		}
+
+		messages = append(messages, dto.PayloadMessageDTO{
+			Role:    ptr.Deref(storedMessage.Role, string(models.ConversationMessageRoleUser)),
+			Content: content,
+		})
+	}
+
+	for _, conversationMessage := range conversationHistory {
+		messages = append(messages, dto.PayloadMessageDTO{
+			Role:    string(conversationMessage.Role),
+			Content: conversationMessage.Content,
+		})
+	}
+
+	return messages
+}
+
+// servingRequestConfiguration returns the request configuration of the model that served a prompt result -
+// the primary configuration, or the fallback with the model of the result.
+func servingRequestConfiguration(
+	requestConfiguration *dto.RequestConfigurationDTO,
+	promptResult dto.PromptResultDTO,
+) *dto.RequestConfigurationDTO {
+	for _, attempt := range requestConfiguration.Attempts() {
+		if attempt.PromptConfigData.ModelVendor == promptResult.ModelVendor &&
+			attempt.PromptConfigData.ModelType == promptResult.ModelType {
+			return attempt
+		}
+	}
+
+	return requestConfiguration
+}
+
+// StorePromptRequestPayload stores the template variables, rendered messages and response content of a prompt request,
+// if the application has payload logging enabled. The redaction rules of the application are applied before storage.
+// Failures are logged, since the prompt response has already been created.
+func StorePromptRequestPayload(
+	ctx context.Context,
+	requestConfiguration *dto.RequestConfigurationDTO,
+	request *gateway.PromptRequest,
+	conversationHistory []dto.ConversationMessageDTO,
+	promptResult dto.PromptResultDTO,
+) {
+	if requestConfiguration.PayloadLogging == nil || promptResult.RequestRecord == nil {
+		return
+	}
+
+	redactor, compileErr := redaction.Compile(requestConfiguration.PayloadLogging.RedactionRules)
+	if compileErr != nil {
+		// the payload is not stored, rather than storing it without redaction
+		log.Error().Err(compileErr).Msg("failed to compile payload redaction rules")
+		return
+	}
+
+	templateVariables := make(map[string]string, len(request.TemplateVariables))
+	for key, value := range request.TemplateVariables {
+		templateVariables[key] = redactor.Redact(value)
+	}
+
+	messages := RenderPayloadMessages(
+		servingRequestConfiguration(requestConfiguration, promptResult).
+			PromptConfigData.ProviderPromptMessages,
+		request.TemplateVariables,
+		conversationHistory,
+	)
+	for i := range messages {
+		messages[i].Content = redactor.Redact(messages[i].Content)
+	}
+
+	if createErr := db.GetQueries().
+		CreatePromptRequestPayload(ctx, models.CreatePromptRequestPayloadParams{
+			TemplateVariables:     serialization.SerializeJSON(templateVariables),
+			RequestMessages:       serialization.SerializeJSON(messages),
+			ResponseContent:       redactor.Redact(ptr.Deref(promptResult.Content, "")),
+			PromptRequestRecordID: promptResult.RequestRecord.ID,
+			ApplicationID:         requestConfiguration.ApplicationID,
+		}); createErr != nil {
+		log.Error().Err(createErr).Msg("failed to store prompt request payload")
+	}
+}
+
+// CreatePayloadLogStreamMessageFactory wraps a stream message factory,
+// storing the request payload once the stream has finished.
+func CreatePayloadLogStreamMessageFactory(
+	requestConfiguration *dto.RequestConfigurationDTO,
+	request *gateway.PromptRequest,
+	conversationHistory []dto.ConversationMessageDTO,
+	messageFactory func(context.Context, dto.PromptResultDTO) (*gateway.StreamingPromptResponse, bool),
+) func(context.Context, dto.PromptResultDTO) (*gateway.StreamingPromptResponse, bool) {
+	var responseContent strings.Builder
+
+	return func(ctx context.Context, result dto.PromptResultDTO) (*gateway.StreamingPromptResponse, bool) {
+		msg, isFinished := messageFactory(ctx, result)
+		responseContent.WriteString(msg.Content)
+
+		if isFinished {
+			result.Content = ptr.To(responseContent.String())
+			StorePromptRequestPayload(ctx, requestConfiguration, request, conversationHistory, result)
+		}
+
+		return msg, isFinished
+	}
+}
+
+// DeleteExpiredPayloads deletes the payload logs that are older than the retention of their application.
+func DeleteExpiredPayloads(ctx context.Context) {
+	deletedRows, deleteErr := db.GetQueries().DeleteExpiredPromptRequestPayloads(ctx)
+	if deleteErr != nil {
+		log.Error().Err(deleteErr).Msg("failed to delete expired prompt request payloads")
+		return
+	}
+
+	log.Debug().Int64("deletedRows", deletedRows).Msg("deleted expired prompt request payloads")
+}
+
+// RunPayloadRetention deletes the expired payload logs in the given interval, until the context is done.
+func RunPayloadRetention(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		DeleteExpiredPayloads(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/basemind-ai/monorepo/e2e/factories"
+	"github.com/basemind-ai/monorepo/gen/go/gateway/v1"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/dto"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/services"
+	"github.com/basemind-ai/monorepo/shared/go/datatypes"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/basemind-ai/monorepo/shared/go/redaction"
+	"github.com/basemind-ai/monorepo/shared/go/serialization"
+	"github.com/basemind-ai/monorepo/shared/go/testutils"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestPayloadLog(t *testing.T) { //nolint: revive
+	testutils.SetTestEnv(t)
+	project, _ := factories.CreateProject(context.TODO())
+	_ = factories.CreateProviderPricingModels(context.TODO())
+
+	retrievePayloads := func(t *testing.T, applicationID pgtype.UUID) []models.RetrievePromptRequestPayloadsRow {
+		t.Helper()
+
+		payloads, err := db.GetQueries().RetrievePromptRequestPayloads(
+			context.TODO(),
+			models.RetrievePromptRequestPayloadsParams{
+				ApplicationID: applicationID,
+				FromDate:      pgtype.Timestamptz{Time: time.Now().Add(-time.Hour), Valid: true},
+				ToDate:        pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true},
+				PageLimit:     10,
+			},
+		)
+		assert.NoError(t, err)
+
+		return payloads
+	}
+
+	t.Run("RenderPayloadMessages", func(t *testing.T) {
+		t.Run("renders OpenAI prompt messages and the conversation history", func(t *testing.T) {
+			messages := services.RenderPayloadMessages(
+				factories.CreateOpenAIPromptMessages(
+					"You are a helpful chat bot.",
+					"This is what the user asked for: {userInput}",
+					&[]string{"userInput"},
+				),
+				map[string]string{"userInput": "cheese"},
+				[]dto.ConversationMessageDTO{
+					{Role: models.ConversationMessageRoleAssistant, Content: "What kind?"},
+				},
+			)
+			assert.Equal(t, []dto.PayloadMessageDTO{
+				{Role: "system", Content: "You are a helpful chat bot."},
+				{Role: "user", Content: "This is what the user asked for: cheese"},
+				{Role: "assistant", Content: "What kind?"},
+			}, messages)
+		})
+
+		t.Run("renders Cohere prompt messages", func(t *testing.T) {
+			messages := services.RenderPayloadMessages(
+				ptr.To(json.RawMessage(serialization.SerializeJSON(
+					[]datatypes.CoherePromptMessageDTO{{
+						Message:           "Tell me about {topic}",
+						TemplateVariables: &[]string{"topic"},
+					}},
+				))),
+				map[string]string{"topic": "cheese"},
+				nil,
+			)
+			assert.Equal(t, []dto.PayloadMessageDTO{
+				{Role: "user", Content: "Tell me about cheese"},
+			}, messages)
+		})
+	})
+
+	t.Run("StorePromptRequestPayload", func(t *testing.T) {
+		request := &gateway.PromptRequest{
+			TemplateVariables: map[string]string{"userInput": "mail jane@example.com"},
+		}
+
+		t.Run("does not store the payload if payload logging is disabled", func(t *testing.T) {
+			application, _ := factories.CreateApplication(context.TODO(), project.ID)
+			promptConfig, _ := factories.CreateOpenAIPromptConfig(context.TODO(), application.ID)
+			requestRecord, _ := factories.CreatePromptRequestRecord(context.TODO(), promptConfig.ID)
+
+			services.StorePromptRequestPayload(
+				context.TODO(),
+				&dto.RequestConfigurationDTO{ApplicationID: application.ID},
+				request,
+				nil,
+				dto.PromptResultDTO{Content: ptr.To("done"), RequestRecord: requestRecord},
+			)
+
+			assert.Empty(t, retrievePayloads(t, application.ID))
+		})
+
+		t.Run("stores the redacted payload", func(t *testing.T) {
+			application, _ := factories.CreateApplication(context.TODO(), project.ID)
+			promptConfig, _ := factories.CreateOpenAIPromptConfig(context.TODO(), application.ID)
+			requestRecord, _ := factories.CreatePromptRequestRecord(context.TODO(), promptConfig.ID)
+
+			services.StorePromptRequestPayload(
+				context.TODO(),
+				&dto.RequestConfigurationDTO{
+					ApplicationID: application.ID,
+					PromptConfigData: datatypes.PromptConfigDTO{
+						ModelVendor: models.ModelVendorOPENAI,
+						ModelType:   models.ModelTypeGpt35Turbo,
+						ProviderPromptMessages: ptr.To(
+							json.RawMessage(promptConfig.ProviderPromptMessages),
+						),
+					},
+					PayloadLogging: &dto.PayloadLoggingDTO{
+						RedactionRules: []datatypes.PayloadRedactionRuleDTO{
+							{Name: "email", Preset: ptr.To(redaction.PresetEmail)},
+						},
+					},
+				},
+				request,
+				nil,
+				dto.PromptResultDTO{
+					Content:       ptr.To("I mailed jane@example.com"),
+					RequestRecord: requestRecord,
+					ModelVendor:   models.ModelVendorOPENAI,
+					ModelType:     models.ModelTypeGpt35Turbo,
+				},
+			)
+
+			payloads := retrievePayloads(t, application.ID)
+			assert.Len(t, payloads, 1)
+			assert.Equal(t, requestRecord.ID, payloads[0].PromptRequestRecordID)
+			assert.Equal(t, "I mailed [REDACTED]", payloads[0].ResponseContent)
+			assert.JSONEq(t, `{"userInput": "mail [REDACTED]"}`, string(payloads[0].TemplateVariables))
+
+			var messages []dto.PayloadMessageDTO
+			assert.NoError(t, json.Unmarshal(payloads[0].RequestMessages, &messages))
+			assert.Equal(t, []dto.PayloadMessageDTO{
+				{Role: "system", Content: "You are a helpful chat bot."},
+				{Role: "user", Content: "This is what the user asked for: mail [REDACTED]"},
+			}, messages)
+		})
+	})
+
+	t.Run("RetrieveRequestConfiguration", func(t *testing.T) {
+		t.Run("includes the payload logging settings of the application", func(t *testing.T) {
+			application, _ := factories.CreateApplication(context.TODO(), project.ID)
+			_, _ = factories.CreateOpenAIPromptConfig(context.TODO(), application.ID)
+
+			_, err := db.GetQueries().UpdateApplicationPayloadLogging(
+				context.TODO(),
+				models.UpdateApplicationPayloadLoggingParams{
+					ID:                      application.ID,
+					PayloadLoggingEnabled:   true,
+					PayloadLogRetentionDays: 7,
+					PayloadRedactionRules: serialization.SerializeJSON(
+						[]datatypes.PayloadRedactionRuleDTO{
+							{Name: "email", Preset: ptr.To(redaction.PresetEmail)},
+						},
+					),
+				},
+			)
+			assert.NoError(t, err)
+
+			requestConfiguration, retrievalErr := services.RetrieveRequestConfiguration(
+				context.TODO(),
+				application.ID,
+				nil,
+			)()
+			assert.NoError(t, retrievalErr)
+			assert.NotNil(t, requestConfiguration.PayloadLogging)
+			assert.Len(t, requestConfiguration.PayloadLogging.RedactionRules, 1)
+		})
+
+		t.Run("does not include payload logging settings if payload logging is disabled", func(t *testing.T) {
+			application, _ := factories.CreateApplication(context.TODO(), project.ID)
+			_, _ = factories.CreateOpenAIPromptConfig(context.TODO(), application.ID)
+
+			requestConfiguration, retrievalErr := services.RetrieveRequestConfiguration(
+				context.TODO(),
+				application.ID,
+				nil,
+			)()
+			assert.NoError(t, retrievalErr)
+			assert.Nil(t, requestConfiguration.PayloadLogging)
+		})
+	})
+}
@@ -46,11 +46,28 @@ func (PromptTestingServer) TestPrompt(
 		return insufficientCreditsErr.Err()
 	}
 
-	modelPricing := RetrieveProviderModelPricing(
+	if budgetExceededErr, retrievalErr := CheckSpendBudgets(streamServer.Context(), *projectID, *applicationID)(); retrievalErr != nil {
+		return retrievalErr
+	} else if budgetExceededErr.Code() == codes.ResourceExhausted {
+		return budgetExceededErr.Err()
+	}
+
+	connector, connectorErr := connectors.GetProviderConnector(
+		models.ModelVendor(request.ModelVendor),
+	)
+	if connectorErr != nil {
+		return connectorErr
+	}
+
+	modelPricing, pricingErr := RetrieveProviderModelPricing(
 		streamServer.Context(),
 		models.ModelType(request.ModelType),
 		models.ModelVendor(request.ModelVendor),
+		*projectID,
 	)
+	if pricingErr != nil {
+		return pricingErr
+	}
 
 	requestConfigurationDTO := &dto.RequestConfigurationDTO{
 		ApplicationID:  *applicationID,
@@ -76,19 +93,24 @@ func (PromptTestingServer) TestPrompt(
 		Interface("requestConfigurationDTO", requestConfigurationDTO).
 		Msg("initiating stream request")
 
-	go connectors.GetProviderConnector(models.ModelVendor(request.ModelVendor)).
-		RequestStream(
-			providerKeyContext,
-			requestConfigurationDTO,
-			request.TemplateVariables,
-			channel,
-		)
+	observer := newStreamObserver(*projectID, requestConfigurationDTO)
+
+	go connector.RequestStream(
+		providerKeyContext,
+		requestConfigurationDTO,
+		request.TemplateVariables,
+		nil,
+		channel,
+	)
 
 	return StreamFromChannel(
 		streamServer.Context(),
 		channel,
 		streamServer,
-		CreatePromptTestingStreamMessage,
+		func(ctx context.Context, result dto.PromptResultDTO) (*ptesting.PromptTestingStreamingPromptResponse, bool) {
+			observer.observe(result)
+			return CreatePromptTestingStreamMessage(ctx, result)
+		},
 	)
 }
 
@@ -109,7 +131,7 @@ func CreatePromptTestingStreamMessage(
 		promptRequestRecordID := db.UUIDToString(&result.RequestRecord.ID)
 		msg.PromptRequestRecordId = &promptRequestRecordID
 
-		go DeductCredit(ctx, result.RequestRecord)
+		DeductCredit(ctx, result.RequestRecord)
 	}
 
 	if result.Content != nil {
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/dto"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/exc"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/basemind-ai/monorepo/shared/go/rediscache"
+	"github.com/go-redis/cache/v9"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+	"slices"
+	"strings"
+	"time"
+)
+
+// renderPromptMessages returns the prompt messages with the template variables applied.
+// The variable values are JSON escaped, so the rendered messages remain valid JSON.
+func renderPromptMessages(
+	promptMessages *json.RawMessage,
+	templateVariables map[string]string,
+) string {
+	keys := make([]string, 0, len(templateVariables))
+	for key := range templateVariables {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	replacements := make([]string, 0, len(keys)*2)
+	for _, key := range keys {
+		escapedValue := string(exc.MustResult(json.Marshal(templateVariables[key])))
+		replacements = append(
+			replacements,
+			fmt.Sprintf("{%s}", key),
+			escapedValue[1:len(escapedValue)-1],
+		)
+	}
+
+	return strings.NewReplacer(replacements...).Replace(string(ptr.Deref(promptMessages, nil)))
+}
+
+// CreateResponseCacheKey creates the response cache key of a prompt request.
+// The key is scoped to the prompt config version, and is a hash of the model, model parameters, tools,
+// response schema, rendered prompt messages and conversation history.
+func CreateResponseCacheKey(
+	requestConfiguration *dto.RequestConfigurationDTO,
+	templateVariables map[string]string,
+	conversationHistory []dto.ConversationMessageDTO,
+) string {
+	promptConfig := requestConfiguration.PromptConfigData
+
+	hash := sha256.New()
+	for _, part := range []string{
+		string(promptConfig.ModelVendor),
+		string(promptConfig.ModelType),
+		string(ptr.Deref(promptConfig.ModelParameters, nil)),
+		string(exc.MustResult(json.Marshal(promptConfig.Tools))),
+		string(ptr.Deref(promptConfig.ResponseSchema, nil)),
+		renderPromptMessages(promptConfig.ProviderPromptMessages, templateVariables),
+		string(exc.MustResult(json.Marshal(conversationHistory))),
+	} {
+		// the parts are NUL separated, so that they cannot be shifted into one another
+		_, _ = hash.Write([]byte(part))
+		_, _ = hash.Write([]byte{0})
+	}
+
+	return fmt.Sprintf(
+		"response:%s:%d:%s",
+		db.UUIDToString(&requestConfiguration.PromptConfigID),
+		promptConfig.Version,
+		hex.EncodeToString(hash.Sum(nil)),
+	)
+}
+
+// RetrieveCachedResponse retrieves a cached prompt response. Returns false if the response is not cached.
+func RetrieveCachedResponse(
+	ctx context.Context,
+	cacheKey string,
+) (*dto.CachedPromptResponseDTO, bool) {
+	cachedResponse := &dto.CachedPromptResponseDTO{}
+	if getErr := rediscache.GetClient().Get(ctx, cacheKey, cachedResponse); getErr != nil {
+		return nil, false
+	}
+
+	return cachedResponse, true
+}
+
+// CacheResponse caches a successful prompt result under the given key, for the prompt config TTL.
+func CacheResponse(
+	ctx context.Context,
+	cacheKey string,
+	requestConfiguration *dto.RequestConfigurationDTO,
+	promptResult dto.PromptResultDTO,
+) {
+	exc.LogIfErr(
+		rediscache.GetClient().Set(&cache.Item{
+			Ctx: ctx,
+			Key: cacheKey,
+			Value: dto.CachedPromptResponseDTO{
+				Content:      *promptResult.Content,
+				FinishReason: promptResult.RequestRecord.FinishReason,
+				ModelVendor:  promptResult.ModelVendor,
+				ModelType:    promptResult.ModelType,
+				ToolCalls:    promptResult.ToolCalls,
+			},
+			TTL: time.Duration(
+				requestConfiguration.PromptConfigData.ResponseCacheTTLSeconds,
+			) * time.Second,
+		}),
+		"failed to cache prompt response",
+	)
+}
+
+// CreateCacheHitRecord creates the prompt request record of a response served from the cache.
+// The record has no tokens, cost, attempts or duration, since the provider was not called.
+func CreateCacheHitRecord(
+	ctx context.Context,
+	requestConfiguration *dto.RequestConfigurationDTO,
+	cachedResponse *dto.CachedPromptResponseDTO,
+) (*models.PromptRequestRecord, error) {
+	now := time.Now()
+	zeroCost := *exc.MustResult(db.StringToNumeric("0"))
+	modelPricingID := exc.MustResult(db.StringToUUID(requestConfiguration.ProviderModelPricing.ID))
+
+	requestRecord, createErr := db.GetQueries().
+		CreatePromptRequestRecord(ctx, models.CreatePromptRequestRecordParams{
+			IsStreamResponse:       false,
+			RequestTokensCost:      zeroCost,
+			ResponseTokensCost:     zeroCost,
+			StartTime:              pgtype.Timestamptz{Time: now, Valid: true},
+			FinishTime:             pgtype.Timestamptz{Time: now, Valid: true},
+			PromptConfigID:         requestConfiguration.PromptConfigID,
+			PromptConfigVersionID:  requestConfiguration.PromptConfigVersionID,
+			IsTrafficSplit:         requestConfiguration.IsTrafficSplit,
+			ProviderModelPricingID: *modelPricingID,
+			FinishReason:           cachedResponse.FinishReason,
+			IsCacheHit:             true,
+		})
+	if createErr != nil {
+		log.Error().Err(createErr).Msg("failed to create cache hit prompt request record")
+		return nil, fmt.Errorf("failed to create prompt request record - %w", createErr)
+	}
+
+	return &requestRecord, nil
+}
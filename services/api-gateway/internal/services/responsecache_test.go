@@ -0,0 +1,191 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/basemind-ai/monorepo/e2e/factories"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/dto"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/services"
+	"github.com/basemind-ai/monorepo/shared/go/datatypes"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/exc"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/basemind-ai/monorepo/shared/go/testutils"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestResponseCache(t *testing.T) { //nolint: revive
+	testutils.SetTestEnv(t)
+	project, _ := factories.CreateProject(context.TODO())
+	_ = factories.CreateProviderPricingModels(context.TODO())
+
+	requestConfigurationDTO := createRequestConfigurationDTO(t, project.ID)
+	templateVariables := map[string]string{"userInput": "abc"}
+
+	t.Run("CreateResponseCacheKey", func(t *testing.T) {
+		t.Run("returns the same key for identical requests", func(t *testing.T) {
+			assert.Equal(
+				t,
+				services.CreateResponseCacheKey(&requestConfigurationDTO, templateVariables, nil),
+				services.CreateResponseCacheKey(
+					&requestConfigurationDTO,
+					map[string]string{"userInput": "abc"},
+					nil,
+				),
+			)
+		})
+
+		t.Run("scopes the key to the prompt config", func(t *testing.T) {
+			assert.Contains(
+				t,
+				services.CreateResponseCacheKey(&requestConfigurationDTO, templateVariables, nil),
+				db.UUIDToString(&requestConfigurationDTO.PromptConfigID),
+			)
+		})
+
+		t.Run("returns a different key for a different prompt config version", func(t *testing.T) {
+			otherConfiguration := requestConfigurationDTO
+			otherConfiguration.PromptConfigData.Version = requestConfigurationDTO.PromptConfigData.Version + 1
+
+			assert.NotEqual(
+				t,
+				services.CreateResponseCacheKey(&requestConfigurationDTO, templateVariables, nil),
+				services.CreateResponseCacheKey(&otherConfiguration, templateVariables, nil),
+			)
+		})
+
+		t.Run("returns a different key for different template variables", func(t *testing.T) {
+			assert.NotEqual(
+				t,
+				services.CreateResponseCacheKey(&requestConfigurationDTO, templateVariables, nil),
+				services.CreateResponseCacheKey(
+					&requestConfigurationDTO,
+					map[string]string{"userInput": "def"},
+					nil,
+				),
+			)
+		})
+
+		t.Run("returns a different key for different model parameters", func(t *testing.T) {
+			otherConfiguration := requestConfigurationDTO
+			otherConfiguration.PromptConfigData.ModelParameters = ptr.To(
+				json.RawMessage(`{"temperature": 2}`),
+			)
+
+			assert.NotEqual(
+				t,
+				services.CreateResponseCacheKey(&requestConfigurationDTO, templateVariables, nil),
+				services.CreateResponseCacheKey(&otherConfiguration, templateVariables, nil),
+			)
+		})
+
+		t.Run("returns a different key for a different model", func(t *testing.T) {
+			otherConfiguration := requestConfigurationDTO
+			otherConfiguration.PromptConfigData.ModelType = models.ModelTypeGpt432k
+
+			assert.NotEqual(
+				t,
+				services.CreateResponseCacheKey(&requestConfigurationDTO, templateVariables, nil),
+				services.CreateResponseCacheKey(&otherConfiguration, templateVariables, nil),
+			)
+		})
+
+		t.Run("returns a different key for a different conversation history", func(t *testing.T) {
+			assert.NotEqual(
+				t,
+				services.CreateResponseCacheKey(&requestConfigurationDTO, templateVariables, nil),
+				services.CreateResponseCacheKey(
+					&requestConfigurationDTO,
+					templateVariables,
+					[]dto.ConversationMessageDTO{
+						{Role: models.ConversationMessageRoleUser, Content: "abc"},
+					},
+				),
+			)
+		})
+		t.Run("returns a different key for different tools", func(t *testing.T) {
+			otherConfiguration := requestConfigurationDTO
+			otherConfiguration.PromptConfigData.Tools = []datatypes.ToolDTO{{Name: "get_weather"}}
+
+			assert.NotEqual(
+				t,
+				services.CreateResponseCacheKey(&requestConfigurationDTO, templateVariables, nil),
+				services.CreateResponseCacheKey(&otherConfiguration, templateVariables, nil),
+			)
+		})
+		t.Run("returns a different key for a different response schema", func(t *testing.T) {
+			otherConfiguration := requestConfigurationDTO
+			otherConfiguration.PromptConfigData.ResponseSchema = ptr.To(
+				json.RawMessage(`{"type":"object"}`),
+			)
+
+			assert.NotEqual(
+				t,
+				services.CreateResponseCacheKey(&requestConfigurationDTO, templateVariables, nil),
+				services.CreateResponseCacheKey(&otherConfiguration, templateVariables, nil),
+			)
+		})
+	})
+
+	t.Run("RetrieveCachedResponse", func(t *testing.T) {
+		cacheKey := services.CreateResponseCacheKey(&requestConfigurationDTO, templateVariables, nil)
+
+		t.Run("returns the cached response", func(t *testing.T) {
+			cacheClient, mockRedis := createTestCache(t, cacheKey)
+			cachedResponse := dto.CachedPromptResponseDTO{
+				Content:      "cached content",
+				FinishReason: models.PromptFinishReasonDONE,
+				ModelVendor:  models.ModelVendorOPENAI,
+				ModelType:    models.ModelTypeGpt35Turbo,
+			}
+
+			mockRedis.ExpectGet(cacheKey).
+				SetVal(string(exc.MustResult(cacheClient.Marshal(cachedResponse))))
+
+			result, isCached := services.RetrieveCachedResponse(context.TODO(), cacheKey)
+			assert.True(t, isCached)
+			assert.Equal(t, cachedResponse, *result)
+		})
+
+		t.Run("returns false when the response is not cached", func(t *testing.T) {
+			_, mockRedis := createTestCache(t, cacheKey)
+			mockRedis.ExpectGet(cacheKey).RedisNil()
+
+			result, isCached := services.RetrieveCachedResponse(context.TODO(), cacheKey)
+			assert.False(t, isCached)
+			assert.Nil(t, result)
+		})
+	})
+
+	t.Run("CreateCacheHitRecord", func(t *testing.T) {
+		t.Run("creates a zero cost record marked as a cache hit", func(t *testing.T) {
+			record, err := services.CreateCacheHitRecord(
+				context.TODO(),
+				&requestConfigurationDTO,
+				&dto.CachedPromptResponseDTO{
+					Content:      "cached content",
+					FinishReason: models.PromptFinishReasonDONE,
+				},
+			)
+			assert.NoError(t, err)
+			assert.True(t, record.IsCacheHit)
+			// the provider was not called, so the record is left out of the latency analytics
+			assert.False(t, record.DurationMs.Valid)
+			assert.Equal(t, int32(0), record.RequestTokens)
+			assert.Equal(t, int32(0), record.ResponseTokens)
+			assert.Equal(t, models.PromptFinishReasonDONE, record.FinishReason)
+			assert.Equal(
+				t,
+				"0",
+				exc.MustResult(db.NumericToDecimal(record.RequestTokensCost)).String(),
+			)
+			assert.Equal(
+				t,
+				"0",
+				exc.MustResult(db.NumericToDecimal(record.ResponseTokensCost)).String(),
+			)
+		})
+	})
+}
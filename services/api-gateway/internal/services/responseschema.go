@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/dto"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/utils"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"slices"
+	"strconv"
+)
+
+const (
+	// ErrorReasonResponseSchemaValidation is the error info reason of a response that does not match the response schema.
+	ErrorReasonResponseSchemaValidation = "RESPONSE_SCHEMA_VALIDATION_FAILED"
+	errorInfoDomain                     = "basemind.ai"
+)
+
+// CreateResponseSchemaValidationError creates the failed precondition status error returned when the model
+// response does not match the response schema, with an error info detail holding the number of attempts.
+func CreateResponseSchemaValidationError(validationErr error, attempts int) error {
+	validationStatus := status.New(
+		codes.FailedPrecondition,
+		fmt.Sprintf("the model response does not match the response schema - %s", validationErr),
+	)
+
+	detailedStatus, detailsErr := validationStatus.WithDetails(&errdetails.ErrorInfo{
+		Reason:   ErrorReasonResponseSchemaValidation,
+		Domain:   errorInfoDomain,
+		Metadata: map[string]string{"attempts": strconv.Itoa(attempts)},
+	})
+	if detailsErr != nil {
+		log.Error().Err(detailsErr).Msg("failed to add the error details")
+		return validationStatus.Err()
+	}
+
+	return detailedStatus.Err()
+}
+
+// createRetryMessages returns the conversation messages that send an invalid response back to the model,
+// together with the validation error.
+func createRetryMessages(content string, validationErr error) []dto.ConversationMessageDTO {
+	return []dto.ConversationMessageDTO{
+		{Role: models.ConversationMessageRoleAssistant, Content: content},
+		{
+			Role: models.ConversationMessageRoleUser,
+			Content: fmt.Sprintf(
+				"Your response is invalid: %s. Respond again with only the corrected JSON object.",
+				validationErr,
+			),
+		},
+	}
+}
+
+// RequestPromptWithResponseSchema requests a prompt with fallback, and validates the response content against
+// the response schema of the prompt config. Responses with tool calls are not validated.
+// An invalid response is sent back to the model together with the validation error, up to the configured number
+// of retries. The credit of every invalid response is deducted.
+// Returns a failed precondition status error if the response is still invalid once the retries are exhausted.
+func RequestPromptWithResponseSchema(
+	ctx context.Context,
+	projectID pgtype.UUID,
+	requestConfiguration *dto.RequestConfigurationDTO,
+	templateVariables map[string]string,
+	conversationHistory []dto.ConversationMessageDTO,
+) (dto.PromptResultDTO, error) {
+	responseSchema := ptr.Deref(requestConfiguration.PromptConfigData.ResponseSchema, nil)
+	maxRetries := int(requestConfiguration.PromptConfigData.ResponseSchemaMaxRetries)
+
+	for retry := 0; ; retry++ {
+		promptResult, connectorErr := RequestPromptWithFallback(
+			ctx,
+			projectID,
+			requestConfiguration,
+			templateVariables,
+			conversationHistory,
+		)
+		if connectorErr != nil || promptResult.Error != nil || len(responseSchema) == 0 ||
+			len(promptResult.ToolCalls) > 0 {
+			return promptResult, connectorErr
+		}
+
+		content := ptr.Deref(promptResult.Content, "")
+
+		validationErr := utils.ValidateResponseSchema(responseSchema, content)
+		if validationErr == nil {
+			return promptResult, nil
+		}
+
+		// the credit hold is kept for the retries and released by the final debit of the request
+		DeductCredit(withoutCreditHold(ctx), promptResult.RequestRecord)
+
+		log.Warn().
+			Err(validationErr).
+			Int("retry", retry).
+			Str("modelType", string(promptResult.ModelType)).
+			Msg("prompt response does not match the response schema")
+
+		if retry >= maxRetries {
+			return promptResult, CreateResponseSchemaValidationError(validationErr, retry+1)
+		}
+
+		// the history is clipped so that the retry messages never overwrite the caller's backing array
+		conversationHistory = append(
+			slices.Clip(conversationHistory),
+			createRetryMessages(content, validationErr)...,
+		)
+	}
+}
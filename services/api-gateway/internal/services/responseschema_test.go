@@ -0,0 +1,179 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/basemind-ai/monorepo/e2e/factories"
+	openaiconnector "github.com/basemind-ai/monorepo/gen/go/openai/v1"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/services"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/exc"
+	"github.com/basemind-ai/monorepo/shared/go/grpcutils"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/basemind-ai/monorepo/shared/go/testutils"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestResponseSchema(t *testing.T) { //nolint: revive
+	testutils.SetTestEnv(t)
+	project, _ := factories.CreateProject(context.TODO())
+	_ = factories.CreateProviderPricingModels(context.TODO())
+
+	providerKeyCacheKey := fmt.Sprintf(
+		"%s:%s",
+		db.UUIDToString(&project.ID),
+		models.ModelVendorOPENAI,
+	)
+	responseSchema := json.RawMessage(
+		`{"type":"object","properties":{"name":{"type":"string"}},"required":["name"]}`,
+	)
+
+	t.Run("CreateResponseSchemaValidationError", func(t *testing.T) {
+		err := services.CreateResponseSchemaValidationError(errors.New("name is required"), 2)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		details := status.Convert(err).Details()
+		assert.Len(t, details, 1)
+
+		errorInfo, ok := details[0].(*errdetails.ErrorInfo)
+		assert.True(t, ok)
+		assert.Equal(t, services.ErrorReasonResponseSchemaValidation, errorInfo.Reason)
+		assert.Equal(t, "2", errorInfo.Metadata["attempts"])
+	})
+
+	t.Run("RequestPromptWithResponseSchema", func(t *testing.T) {
+		t.Run("returns a response that matches the response schema", func(t *testing.T) {
+			openaiService := createOpenAIService(t)
+			_, mockRedis := createTestCache(t, providerKeyCacheKey)
+
+			openaiService.Response = &openaiconnector.OpenAIPromptResponse{
+				Content:      `{"name":"cheese"}`,
+				FinishReason: "DONE",
+			}
+
+			mockRedis.ExpectGet(providerKeyCacheKey).RedisNil()
+
+			requestConfiguration := createRequestConfigurationDTO(t, project.ID)
+			requestConfiguration.PromptConfigData.ResponseSchema = ptr.To(responseSchema)
+
+			result, err := services.RequestPromptWithResponseSchema(
+				context.TODO(),
+				project.ID,
+				&requestConfiguration,
+				map[string]string{"userInput": "abc"},
+				nil,
+			)
+			assert.NoError(t, err)
+			assert.NoError(t, result.Error)
+			assert.Equal(t, `{"name":"cheese"}`, *result.Content)
+		})
+
+		t.Run("does not validate the response without a response schema", func(t *testing.T) {
+			openaiService := createOpenAIService(t)
+			_, mockRedis := createTestCache(t, providerKeyCacheKey)
+
+			openaiService.Response = &openaiconnector.OpenAIPromptResponse{
+				Content:      "not JSON",
+				FinishReason: "DONE",
+			}
+
+			mockRedis.ExpectGet(providerKeyCacheKey).RedisNil()
+
+			requestConfiguration := createRequestConfigurationDTO(t, project.ID)
+
+			result, err := services.RequestPromptWithResponseSchema(
+				context.TODO(),
+				project.ID,
+				&requestConfiguration,
+				map[string]string{"userInput": "abc"},
+				nil,
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, "not JSON", *result.Content)
+		})
+
+		t.Run("returns a failed precondition error once the retries are exhausted", func(t *testing.T) {
+			openaiService := createOpenAIService(t)
+			_, mockRedis := createTestCache(t, providerKeyCacheKey)
+
+			openaiService.Response = &openaiconnector.OpenAIPromptResponse{
+				Content:      `{"title":"cheese"}`,
+				FinishReason: "DONE",
+			}
+
+			mockRedis.ExpectGet(providerKeyCacheKey).RedisNil()
+			mockRedis.ExpectGet(providerKeyCacheKey).RedisNil()
+
+			requestConfiguration := createRequestConfigurationDTO(t, project.ID)
+			requestConfiguration.PromptConfigData.ResponseSchema = ptr.To(responseSchema)
+			requestConfiguration.PromptConfigData.ResponseSchemaMaxRetries = 1
+
+			_, err := services.RequestPromptWithResponseSchema(
+				context.TODO(),
+				project.ID,
+				&requestConfiguration,
+				map[string]string{"userInput": "abc"},
+				nil,
+			)
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+			errorInfo, ok := status.Convert(err).Details()[0].(*errdetails.ErrorInfo)
+			assert.True(t, ok)
+			assert.Equal(t, "2", errorInfo.Metadata["attempts"])
+		})
+
+		t.Run("keeps the credit hold until the final debit of the request", func(t *testing.T) {
+			openaiService := createOpenAIService(t)
+			_, mockRedis := createTestCache(t, providerKeyCacheKey)
+
+			openaiService.Response = &openaiconnector.OpenAIPromptResponse{
+				Content:      `{"title":"cheese"}`,
+				FinishReason: "DONE",
+			}
+
+			mockRedis.ExpectGet(providerKeyCacheKey).RedisNil()
+			mockRedis.ExpectGet(providerKeyCacheKey).RedisNil()
+
+			requestConfiguration := createRequestConfigurationDTO(t, project.ID)
+			requestConfiguration.PromptConfigData.ResponseSchema = ptr.To(responseSchema)
+			requestConfiguration.PromptConfigData.ResponseSchemaMaxRetries = 1
+
+			ctx, release, holdErr := services.CreateCreditHold(
+				context.WithValue(context.TODO(), grpcutils.ProjectIDContextKey, project.ID),
+				project.ID,
+				&requestConfiguration,
+				nil,
+			)
+			assert.NoError(t, holdErr)
+
+			_, err := services.RequestPromptWithResponseSchema(
+				ctx,
+				project.ID,
+				&requestConfiguration,
+				map[string]string{"userInput": "abc"},
+				nil,
+			)
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+			// the debits of the invalid responses do not release the hold
+			heldTotal, retrievalErr := db.GetQueries().
+				RetrieveProjectActiveCreditHoldsTotal(context.TODO(), project.ID)
+			assert.NoError(t, retrievalErr)
+			assert.False(t, exc.MustResult(db.NumericToDecimal(heldTotal)).IsZero())
+
+			release()
+
+			heldTotal, retrievalErr = db.GetQueries().
+				RetrieveProjectActiveCreditHoldsTotal(context.TODO(), project.ID)
+			assert.NoError(t, retrievalErr)
+			assert.True(t, exc.MustResult(db.NumericToDecimal(heldTotal)).IsZero())
+		})
+	})
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/dto"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"hash/fnv"
+	"math/rand"
+	"slices"
+)
+
+// MaxUserKeyLength is the maximum length of a user key.
+const MaxUserKeyLength = 255
+
+// RetrieveTrafficSplit retrieves the request configurations of the prompt configs the application splits its
+// traffic between, in a stable order. Returns an empty slice if the application does not split its traffic.
+func RetrieveTrafficSplit(
+	ctx context.Context,
+	applicationID pgtype.UUID,
+	projectID pgtype.UUID,
+) ([]dto.TrafficSplitVariantDTO, error) {
+	promptConfigs, retrievalErr := db.GetQueries().RetrieveTrafficSplitPromptConfigs(ctx, applicationID)
+	if retrievalErr != nil {
+		return nil, fmt.Errorf("failed to retrieve traffic split prompt configs - %w", retrievalErr)
+	}
+
+	trafficSplit := make([]dto.TrafficSplitVariantDTO, 0, len(promptConfigs))
+
+	for _, trafficSplitPromptConfig := range promptConfigs {
+		promptConfigID := db.UUIDToString(&trafficSplitPromptConfig.ID)
+
+		promptConfig, promptConfigErr := RetrievePromptConfig(ctx, applicationID, &promptConfigID)
+		if promptConfigErr != nil {
+			return nil, promptConfigErr
+		}
+
+		requestConfiguration, configurationErr := createRequestConfiguration(
+			ctx,
+			applicationID,
+			projectID,
+			promptConfig,
+		)
+		if configurationErr != nil {
+			return nil, configurationErr
+		}
+
+		trafficSplit = append(trafficSplit, dto.TrafficSplitVariantDTO{
+			Weight:               trafficSplitPromptConfig.TrafficWeight,
+			RequestConfiguration: requestConfiguration,
+		})
+	}
+
+	return trafficSplit, nil
+}
+
+// ValidateUserKey validates the user key of a prompt request, if set.
+func ValidateUserKey(userKey *string) error {
+	if userKey == nil {
+		return nil
+	}
+
+	if length := len(*userKey); length == 0 || length > MaxUserKeyLength {
+		return status.Errorf(
+			codes.InvalidArgument,
+			"user key must be between 1 and %d characters long",
+			MaxUserKeyLength,
+		)
+	}
+
+	return nil
+}
+
+// SelectTrafficSplitVariant returns the request configuration of the traffic split variant a request is routed to.
+// Variants are chosen by weight - requests with a user key are always routed to the same variant of a split, other
+// requests are routed at random. The request configuration is returned as is if the application does not split its traffic.
+func SelectTrafficSplitVariant(
+	requestConfiguration *dto.RequestConfigurationDTO,
+	userKey *string,
+) *dto.RequestConfigurationDTO {
+	totalWeight := int32(0)
+	for _, variant := range requestConfiguration.TrafficSplit {
+		totalWeight += variant.Weight
+	}
+
+	if totalWeight <= 0 {
+		return requestConfiguration
+	}
+
+	var bucket int32
+	if userKey != nil {
+		// the hash is salted with the application and the variants, so that the same users do not land in the same
+		// bucket of every split
+		hash := fnv.New32a()
+		_, _ = hash.Write(requestConfiguration.ApplicationID.Bytes[:])
+		for _, variant := range requestConfiguration.TrafficSplit {
+			_, _ = hash.Write(variant.RequestConfiguration.PromptConfigID.Bytes[:])
+		}
+		_, _ = hash.Write([]byte(*userKey))
+		bucket = int32(hash.Sum32() % uint32(totalWeight))
+	} else {
+		bucket = rand.Int31n(totalWeight) //nolint: gosec
+	}
+
+	for _, variant := range requestConfiguration.TrafficSplit {
+		if bucket >= variant.Weight {
+			bucket -= variant.Weight
+			continue
+		}
+
+		selected := variant.RequestConfiguration
+		selected.IsTrafficSplit = true
+		selected.PayloadLogging = requestConfiguration.PayloadLogging
+		selected.Fallbacks = slices.Clone(selected.Fallbacks)
+
+		for i := range selected.Fallbacks {
+			selected.Fallbacks[i].IsTrafficSplit = true
+		}
+
+		return &selected
+	}
+
+	return requestConfiguration
+}
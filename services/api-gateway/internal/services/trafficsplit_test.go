@@ -0,0 +1,233 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/basemind-ai/monorepo/e2e/factories"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/dto"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/services"
+	"github.com/basemind-ai/monorepo/shared/go/datatypes"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/basemind-ai/monorepo/shared/go/testutils"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+	"testing"
+)
+
+func TestTrafficSplit(t *testing.T) { //nolint: revive
+	testutils.SetTestEnv(t)
+	project, _ := factories.CreateProject(context.TODO())
+	_ = factories.CreateProviderPricingModels(context.TODO())
+
+	createVariant := func(name string, weight int32) dto.TrafficSplitVariantDTO {
+		return dto.TrafficSplitVariantDTO{
+			Weight: weight,
+			RequestConfiguration: dto.RequestConfigurationDTO{
+				PromptConfigData: datatypes.PromptConfigDTO{Name: name},
+				Fallbacks: []dto.RequestConfigurationDTO{
+					{PromptConfigData: datatypes.PromptConfigDTO{Name: name}},
+				},
+			},
+		}
+	}
+
+	t.Run("SelectTrafficSplitVariant", func(t *testing.T) {
+		t.Run("returns the request configuration without a traffic split", func(t *testing.T) {
+			requestConfiguration := &dto.RequestConfigurationDTO{
+				PromptConfigData: datatypes.PromptConfigDTO{Name: "default"},
+			}
+
+			selected := services.SelectTrafficSplitVariant(requestConfiguration, nil)
+			assert.Equal(t, requestConfiguration, selected)
+			assert.False(t, selected.IsTrafficSplit)
+		})
+
+		t.Run("routes requests with the same user key to the same variant", func(t *testing.T) {
+			requestConfiguration := &dto.RequestConfigurationDTO{
+				TrafficSplit: []dto.TrafficSplitVariantDTO{
+					createVariant("a", 50),
+					createVariant("b", 50),
+				},
+			}
+
+			for i := 0; i < 20; i++ {
+				userKey := ptr.To(fmt.Sprintf("user-%d", i))
+				selected := services.SelectTrafficSplitVariant(requestConfiguration, userKey)
+
+				for j := 0; j < 5; j++ {
+					assert.Equal(
+						t,
+						selected.PromptConfigData.Name,
+						services.SelectTrafficSplitVariant(requestConfiguration, userKey).
+							PromptConfigData.Name,
+					)
+				}
+			}
+		})
+
+		t.Run("routes a user key independently in each split", func(t *testing.T) {
+			createRequestConfiguration := func(applicationID byte) *dto.RequestConfigurationDTO {
+				return &dto.RequestConfigurationDTO{
+					ApplicationID: pgtype.UUID{Bytes: [16]byte{applicationID}, Valid: true},
+					TrafficSplit: []dto.TrafficSplitVariantDTO{
+						createVariant("a", 50),
+						createVariant("b", 50),
+					},
+				}
+			}
+
+			first, second := createRequestConfiguration(1), createRequestConfiguration(2)
+
+			matches := 0
+			for i := 0; i < 1000; i++ {
+				userKey := ptr.To(fmt.Sprintf("user-%d", i))
+				if services.SelectTrafficSplitVariant(first, userKey).PromptConfigData.Name ==
+					services.SelectTrafficSplitVariant(second, userKey).PromptConfigData.Name {
+					matches++
+				}
+			}
+
+			assert.InDelta(t, 500, matches, 100)
+		})
+
+		t.Run("splits requests by weight", func(t *testing.T) {
+			requestConfiguration := &dto.RequestConfigurationDTO{
+				TrafficSplit: []dto.TrafficSplitVariantDTO{
+					createVariant("a", 90),
+					createVariant("b", 10),
+				},
+			}
+
+			counts := map[string]int{}
+			for i := 0; i < 10000; i++ {
+				selected := services.SelectTrafficSplitVariant(
+					requestConfiguration,
+					ptr.To(fmt.Sprintf("user-%d", i)),
+				)
+				counts[selected.PromptConfigData.Name]++
+			}
+
+			assert.InDelta(t, 9000, counts["a"], 300)
+			assert.InDelta(t, 1000, counts["b"], 300)
+		})
+
+		t.Run("keeps the payload logging settings of the application", func(t *testing.T) {
+			requestConfiguration := &dto.RequestConfigurationDTO{
+				TrafficSplit:   []dto.TrafficSplitVariantDTO{createVariant("a", 100)},
+				PayloadLogging: &dto.PayloadLoggingDTO{},
+			}
+
+			selected := services.SelectTrafficSplitVariant(requestConfiguration, nil)
+			assert.Equal(t, requestConfiguration.PayloadLogging, selected.PayloadLogging)
+		})
+
+		t.Run("marks the variant and its fallbacks as traffic split", func(t *testing.T) {
+			requestConfiguration := &dto.RequestConfigurationDTO{
+				TrafficSplit: []dto.TrafficSplitVariantDTO{createVariant("a", 100)},
+			}
+
+			selected := services.SelectTrafficSplitVariant(requestConfiguration, nil)
+			assert.Equal(t, "a", selected.PromptConfigData.Name)
+			assert.True(t, selected.IsTrafficSplit)
+			assert.True(t, selected.Fallbacks[0].IsTrafficSplit)
+			assert.False(
+				t,
+				requestConfiguration.TrafficSplit[0].RequestConfiguration.Fallbacks[0].IsTrafficSplit,
+			)
+		})
+	})
+
+	t.Run("ValidateUserKey", func(t *testing.T) {
+		for _, testCase := range []struct {
+			Name    string
+			UserKey *string
+			IsValid bool
+		}{
+			{Name: "allows no user key", UserKey: nil, IsValid: true},
+			{Name: "allows a user key", UserKey: ptr.To("user"), IsValid: true},
+			{Name: "rejects an empty user key", UserKey: ptr.To(""), IsValid: false},
+			{
+				Name:    "rejects a too long user key",
+				UserKey: ptr.To(strings.Repeat("a", services.MaxUserKeyLength+1)),
+				IsValid: false,
+			},
+		} {
+			t.Run(testCase.Name, func(t *testing.T) {
+				err := services.ValidateUserKey(testCase.UserKey)
+				if testCase.IsValid {
+					assert.NoError(t, err)
+				} else {
+					assert.Equal(t, codes.InvalidArgument, status.Code(err))
+				}
+			})
+		}
+	})
+
+	t.Run("RetrieveRequestConfiguration", func(t *testing.T) {
+		t.Run("includes the traffic split of the application", func(t *testing.T) {
+			application, _ := factories.CreateApplication(context.TODO(), project.ID)
+			defaultPromptConfig, _ := factories.CreateOpenAIPromptConfig(
+				context.TODO(),
+				application.ID,
+			)
+			variantPromptConfig, _ := factories.CreateOpenAIPromptConfig(
+				context.TODO(),
+				application.ID,
+			)
+
+			for _, params := range []models.UpdatePromptConfigTrafficWeightParams{
+				{ID: defaultPromptConfig.ID, ApplicationID: application.ID, TrafficWeight: 90},
+				{ID: variantPromptConfig.ID, ApplicationID: application.ID, TrafficWeight: 10},
+			} {
+				_, err := db.GetQueries().UpdatePromptConfigTrafficWeight(context.TODO(), params)
+				assert.NoError(t, err)
+			}
+
+			requestConfiguration, err := services.RetrieveRequestConfiguration(
+				context.TODO(),
+				application.ID,
+				nil,
+			)()
+			assert.NoError(t, err)
+			assert.Len(t, requestConfiguration.TrafficSplit, 2)
+			assert.Equal(t, int32(90), requestConfiguration.TrafficSplit[0].Weight)
+			assert.Equal(
+				t,
+				defaultPromptConfig.ID,
+				requestConfiguration.TrafficSplit[0].RequestConfiguration.PromptConfigID,
+			)
+			assert.Equal(t, int32(10), requestConfiguration.TrafficSplit[1].Weight)
+			assert.Equal(
+				t,
+				variantPromptConfig.ID,
+				requestConfiguration.TrafficSplit[1].RequestConfiguration.PromptConfigID,
+			)
+		})
+
+		t.Run("does not include the traffic split for a prompt config ID", func(t *testing.T) {
+			application, _ := factories.CreateApplication(context.TODO(), project.ID)
+			promptConfig, _ := factories.CreateOpenAIPromptConfig(context.TODO(), application.ID)
+			_, _ = db.GetQueries().UpdatePromptConfigTrafficWeight(
+				context.TODO(),
+				models.UpdatePromptConfigTrafficWeightParams{
+					ID:            promptConfig.ID,
+					ApplicationID: application.ID,
+					TrafficWeight: 100,
+				},
+			)
+
+			requestConfiguration, err := services.RetrieveRequestConfiguration(
+				context.TODO(),
+				application.ID,
+				ptr.To(db.UUIDToString(&promptConfig.ID)),
+			)()
+			assert.NoError(t, err)
+			assert.Empty(t, requestConfiguration.TrafficSplit)
+		})
+	})
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/basemind-ai/monorepo/gen/go/gateway/v1"
 	"github.com/basemind-ai/monorepo/services/api-gateway/internal/dto"
@@ -12,9 +13,9 @@ import (
 	"github.com/basemind-ai/monorepo/shared/go/db"
 	"github.com/basemind-ai/monorepo/shared/go/db/models"
 	"github.com/basemind-ai/monorepo/shared/go/exc"
-	"github.com/basemind-ai/monorepo/shared/go/grpcutils"
 	"github.com/basemind-ai/monorepo/shared/go/ptr"
 	"github.com/basemind-ai/monorepo/shared/go/rediscache"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/rs/zerolog/log"
 	"github.com/shopspring/decimal"
@@ -25,6 +26,15 @@ import (
 	"time"
 )
 
+// versionIDToString returns the ID of a prompt config version, or an empty string for prompt configs without versions.
+func versionIDToString(versionID pgtype.UUID) string {
+	if !versionID.Valid {
+		return ""
+	}
+
+	return db.UUIDToString(&versionID)
+}
+
 // RetrievePromptConfig retrieves the prompt config - either using the provided ID, or the application default.
 func RetrievePromptConfig(
 	ctx context.Context,
@@ -44,6 +54,25 @@ func RetrievePromptConfig(
 			return nil, fmt.Errorf("failed to retrieve prompt config - %w", retrievalErr)
 		}
 
+		fallbackModels, unmarshalErr := datatypes.UnmarshalFallbackModels(promptConfig.FallbackModels)
+		if unmarshalErr != nil {
+			return nil, unmarshalErr
+		}
+
+		tools, unmarshalToolsErr := datatypes.UnmarshalTools(promptConfig.Tools)
+		if unmarshalToolsErr != nil {
+			return nil, unmarshalToolsErr
+		}
+
+		responseSchema, unmarshalResponseSchemaErr := datatypes.UnmarshalResponseSchema(
+			promptConfig.ResponseSchema,
+		)
+		if unmarshalResponseSchemaErr != nil {
+			return nil, unmarshalResponseSchemaErr
+		}
+
+		versionID := versionIDToString(promptConfig.VersionID)
+
 		return &datatypes.PromptConfigDTO{
 			ID:                        db.UUIDToString(&promptConfig.ID),
 			Name:                      promptConfig.Name,
@@ -52,6 +81,14 @@ func RetrievePromptConfig(
 			ModelVendor:               promptConfig.ModelVendor,
 			ProviderPromptMessages:    ptr.To(json.RawMessage(promptConfig.ProviderPromptMessages)),
 			ExpectedTemplateVariables: promptConfig.ExpectedTemplateVariables,
+			FallbackModels:            fallbackModels,
+			ResponseCacheTTLSeconds:   promptConfig.ResponseCacheTtlSeconds,
+			Tools:                     tools,
+			ResponseSchema:            responseSchema,
+			ResponseSchemaMaxRetries:  promptConfig.ResponseSchemaMaxRetries,
+			Version:                   promptConfig.Version,
+			VersionID:                 versionID,
+			TrafficWeight:             promptConfig.TrafficWeight,
 			IsDefault:                 promptConfig.IsDefault,
 			CreatedAt:                 promptConfig.CreatedAt.Time,
 			UpdatedAt:                 promptConfig.UpdatedAt.Time,
@@ -68,6 +105,26 @@ func RetrievePromptConfig(
 			retrieveDefaultErr,
 		)
 	}
+
+	fallbackModels, unmarshalErr := datatypes.UnmarshalFallbackModels(promptConfig.FallbackModels)
+	if unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+
+	tools, unmarshalToolsErr := datatypes.UnmarshalTools(promptConfig.Tools)
+	if unmarshalToolsErr != nil {
+		return nil, unmarshalToolsErr
+	}
+
+	responseSchema, unmarshalResponseSchemaErr := datatypes.UnmarshalResponseSchema(
+		promptConfig.ResponseSchema,
+	)
+	if unmarshalResponseSchemaErr != nil {
+		return nil, unmarshalResponseSchemaErr
+	}
+
+	versionID := versionIDToString(promptConfig.VersionID)
+
 	return &datatypes.PromptConfigDTO{
 		ID:                        db.UUIDToString(&promptConfig.ID),
 		Name:                      promptConfig.Name,
@@ -76,42 +133,176 @@ func RetrievePromptConfig(
 		ModelVendor:               promptConfig.ModelVendor,
 		ProviderPromptMessages:    ptr.To(json.RawMessage(promptConfig.ProviderPromptMessages)),
 		ExpectedTemplateVariables: promptConfig.ExpectedTemplateVariables,
+		FallbackModels:            fallbackModels,
+		ResponseCacheTTLSeconds:   promptConfig.ResponseCacheTtlSeconds,
+		Tools:                     tools,
+		ResponseSchema:            responseSchema,
+		ResponseSchemaMaxRetries:  promptConfig.ResponseSchemaMaxRetries,
+		Version:                   promptConfig.Version,
+		VersionID:                 versionID,
+		TrafficWeight:             promptConfig.TrafficWeight,
 		IsDefault:                 promptConfig.IsDefault,
 		CreatedAt:                 promptConfig.CreatedAt.Time,
 		UpdatedAt:                 promptConfig.UpdatedAt.Time,
 	}, nil
 }
 
-// RetrieveProviderModelPricing retrieves the pricing model for the given model type and vendor.
+// RetrieveProviderModelPricing retrieves the active pricing of the given model type and vendor for a project.
+// The pricing negotiated for the project, if any, takes precedence over the default pricing.
+// Returns a FailedPrecondition status error if the model has no active pricing.
 func RetrieveProviderModelPricing(
 	ctx context.Context,
 	modelType models.ModelType,
 	modelVendor models.ModelVendor,
-) datatypes.ProviderModelPricingDTO {
+	projectID pgtype.UUID,
+) (datatypes.ProviderModelPricingDTO, error) {
 	log.Debug().
 		Str("modelType", string(modelType)).
 		Str("modelVendor", string(modelVendor)).
 		Msg("retrieving provider model pricing")
 
-	providerModelPricing := exc.MustResult(db.GetQueries().
+	providerModelPricing, retrievalErr := db.GetQueries().
 		RetrieveActiveProviderModelPricing(ctx, models.RetrieveActiveProviderModelPricingParams{
 			ModelType:   modelType,
 			ModelVendor: modelVendor,
-		}))
+			ProjectID:   projectID,
+		})
+	if retrievalErr != nil {
+		if errors.Is(retrievalErr, pgx.ErrNoRows) {
+			return datatypes.ProviderModelPricingDTO{}, status.Errorf(
+				codes.FailedPrecondition,
+				"model %s of vendor %s has no active pricing",
+				modelType,
+				modelVendor,
+			)
+		}
 
-	inputDecimalValue := exc.MustResult(db.NumericToDecimal(providerModelPricing.InputTokenPrice))
-	outputDecimalValue := exc.MustResult(db.NumericToDecimal(providerModelPricing.OutputTokenPrice))
+		return datatypes.ProviderModelPricingDTO{}, status.Errorf(
+			codes.Internal,
+			"failed to retrieve the model pricing: %v",
+			retrievalErr,
+		)
+	}
+
+	inputDecimalValue, inputConversionErr := db.NumericToDecimal(providerModelPricing.InputTokenPrice)
+	if inputConversionErr != nil {
+		return datatypes.ProviderModelPricingDTO{}, status.Errorf(
+			codes.Internal,
+			"invalid model input token price: %v",
+			inputConversionErr,
+		)
+	}
+
+	outputDecimalValue, outputConversionErr := db.NumericToDecimal(providerModelPricing.OutputTokenPrice)
+	if outputConversionErr != nil {
+		return datatypes.ProviderModelPricingDTO{}, status.Errorf(
+			codes.Internal,
+			"invalid model output token price: %v",
+			outputConversionErr,
+		)
+	}
 
-	return datatypes.ProviderModelPricingDTO{
+	pricing := datatypes.ProviderModelPricingDTO{
 		ID:               db.UUIDToString(&providerModelPricing.ID),
 		InputTokenPrice:  *inputDecimalValue,
 		OutputTokenPrice: *outputDecimalValue,
 		TokenUnitSize:    providerModelPricing.TokenUnitSize,
 		ActiveFromDate:   providerModelPricing.ActiveFromDate.Time,
 	}
+
+	for _, classPrice := range []struct {
+		value  pgtype.Numeric
+		target **decimal.Decimal
+	}{
+		{providerModelPricing.CachedInputTokenPrice, &pricing.CachedInputTokenPrice},
+		{providerModelPricing.ReasoningTokenPrice, &pricing.ReasoningTokenPrice},
+		{providerModelPricing.ImageTokenPrice, &pricing.ImageTokenPrice},
+	} {
+		if !classPrice.value.Valid {
+			continue
+		}
+
+		decimalValue, conversionErr := db.NumericToDecimal(classPrice.value)
+		if conversionErr != nil {
+			return datatypes.ProviderModelPricingDTO{}, status.Errorf(
+				codes.Internal,
+				"invalid model token class price: %v",
+				conversionErr,
+			)
+		}
+
+		*classPrice.target = decimalValue
+	}
+
+	return pricing, nil
+}
+
+// createRequestConfiguration creates the request configuration of a prompt config, including its fallbacks.
+// Fallback models without an active pricing are skipped.
+func createRequestConfiguration(
+	ctx context.Context,
+	applicationID pgtype.UUID,
+	projectID pgtype.UUID,
+	promptConfig *datatypes.PromptConfigDTO,
+) (dto.RequestConfigurationDTO, error) {
+	promptConfigUUID := exc.MustResult(db.StringToUUID(promptConfig.ID))
+
+	var promptConfigVersionUUID pgtype.UUID
+	if promptConfig.VersionID != "" {
+		promptConfigVersionUUID = *exc.MustResult(db.StringToUUID(promptConfig.VersionID))
+	}
+
+	providerModelPricing, pricingErr := RetrieveProviderModelPricing(
+		ctx, promptConfig.ModelType, promptConfig.ModelVendor, projectID,
+	)
+	if pricingErr != nil {
+		return dto.RequestConfigurationDTO{}, pricingErr
+	}
+
+	var fallbacks []dto.RequestConfigurationDTO
+	for _, fallbackModel := range promptConfig.FallbackModels {
+		fallbackModelPricing, fallbackPricingErr := RetrieveProviderModelPricing(
+			ctx, fallbackModel.ModelType, fallbackModel.ModelVendor, projectID,
+		)
+		if fallbackPricingErr != nil {
+			log.Warn().
+				Err(fallbackPricingErr).
+				Str("modelType", string(fallbackModel.ModelType)).
+				Msg("skipping fallback model")
+			continue
+		}
+
+		fallbackPromptConfig := *promptConfig
+		fallbackPromptConfig.ModelVendor = fallbackModel.ModelVendor
+		fallbackPromptConfig.ModelType = fallbackModel.ModelType
+		fallbackPromptConfig.ModelParameters = fallbackModel.ModelParameters
+		fallbackPromptConfig.FallbackModels = nil
+
+		if fallbackModel.ProviderPromptMessages != nil {
+			fallbackPromptConfig.ProviderPromptMessages = fallbackModel.ProviderPromptMessages
+		}
+
+		fallbacks = append(fallbacks, dto.RequestConfigurationDTO{
+			ApplicationID:         applicationID,
+			PromptConfigID:        *promptConfigUUID,
+			PromptConfigVersionID: promptConfigVersionUUID,
+			PromptConfigData:      fallbackPromptConfig,
+			ProviderModelPricing:  fallbackModelPricing,
+		})
+	}
+
+	return dto.RequestConfigurationDTO{
+		ApplicationID:         applicationID,
+		PromptConfigID:        *promptConfigUUID,
+		PromptConfigVersionID: promptConfigVersionUUID,
+		PromptConfigData:      *promptConfig,
+		ProviderModelPricing:  providerModelPricing,
+		Fallbacks:             fallbacks,
+	}, nil
 }
 
 // RetrieveRequestConfiguration retrieves the request configuration for the given application and prompt config ID.
+// If no prompt config ID is given, the request configuration includes the traffic split of the application.
 func RetrieveRequestConfiguration(
 	ctx context.Context,
 	applicationID pgtype.UUID,
@@ -140,16 +331,51 @@ func RetrieveRequestConfiguration(
 			)
 		}
 
-		promptConfigUUID := exc.MustResult(db.StringToUUID(promptConfig.ID))
+		requestConfiguration, configurationErr := createRequestConfiguration(
+			ctx,
+			application.ID,
+			application.ProjectID,
+			promptConfig,
+		)
+		if configurationErr != nil {
+			return nil, configurationErr
+		}
 
-		return &dto.RequestConfigurationDTO{
-			ApplicationID:    application.ID,
-			PromptConfigID:   *promptConfigUUID,
-			PromptConfigData: *promptConfig,
-			ProviderModelPricing: RetrieveProviderModelPricing(
-				ctx, promptConfig.ModelType, promptConfig.ModelVendor,
-			),
-		}, nil
+		if application.PayloadLoggingEnabled {
+			redactionRules, unmarshalErr := datatypes.UnmarshalPayloadRedactionRules(
+				application.PayloadRedactionRules,
+			)
+			if unmarshalErr != nil {
+				return nil, status.Errorf(
+					codes.Internal,
+					"failed to retrieve the application payload logging settings: %v",
+					unmarshalErr,
+				)
+			}
+
+			requestConfiguration.PayloadLogging = &dto.PayloadLoggingDTO{
+				RedactionRules: redactionRules,
+			}
+		}
+
+		if promptConfigID == nil {
+			trafficSplit, trafficSplitErr := RetrieveTrafficSplit(ctx, application.ID, application.ProjectID)
+			if trafficSplitErr != nil {
+				if _, isStatusErr := status.FromError(trafficSplitErr); isStatusErr {
+					return nil, trafficSplitErr
+				}
+
+				return nil, status.Errorf(
+					codes.NotFound,
+					"failed to retrieve the application traffic split: %v",
+					trafficSplitErr,
+				)
+			}
+
+			requestConfiguration.TrafficSplit = trafficSplit
+		}
+
+		return &requestConfiguration, nil
 	}
 }
 
@@ -179,7 +405,7 @@ func CheckProjectCredits(
 
 // CreateProviderAPIKeyContext creates a context with the provider API key.
 // The provider API key is retrieved from the database, decrypted and set in the context.
-// We intentionally use the projectID to cache here - because we need to invalidate the cache if the provider api key is deleted.
+// We intentionally use the projectID and vendor to cache here - because we need to invalidate the cache if the provider api key is deleted.
 func CreateProviderAPIKeyContext(
 	ctx context.Context,
 	projectID pgtype.UUID,
@@ -187,7 +413,8 @@ func CreateProviderAPIKeyContext(
 ) context.Context {
 	providerKey, providerKeyRetrievalErr := rediscache.With[models.RetrieveProviderKeyRow](
 		ctx,
-		db.UUIDToString(&projectID),
+		providerKeyCacheName,
+		fmt.Sprintf("%s:%s", db.UUIDToString(&projectID), modelVendor),
 		&models.RetrieveProviderKeyRow{},
 		30*time.Minute,
 		func() (*models.RetrieveProviderKeyRow, error) {
@@ -241,6 +468,8 @@ func ValidateExpectedVariables(
 }
 
 // StreamFromChannel streams the prompt results from the channel to the stream server.
+// If the stream can no longer be sent, e.g. because the client disconnected, the remaining results are drained
+// from the channel, so the connector is not blocked and the final result is still recorded and billed.
 func StreamFromChannel[T any](
 	ctx context.Context,
 	channel chan dto.PromptResultDTO,
@@ -254,6 +483,7 @@ func StreamFromChannel[T any](
 
 			if sendErr := streamServer.SendMsg(msg); sendErr != nil {
 				log.Error().Err(sendErr).Msg("failed to send message")
+				drainChannel(ctx, channel, messageFactory)
 				return status.Error(codes.Internal, "failed to send message")
 			}
 
@@ -266,11 +496,26 @@ func StreamFromChannel[T any](
 				return nil
 			}
 		case <-ctx.Done():
+			drainChannel(ctx, channel, messageFactory)
 			return ctx.Err()
 		}
 	}
 }
 
+// drainChannel consumes the prompt results from the channel until it is closed, without sending them.
+// The results are handled using a context that is not cancelled, since the request context is usually done by then.
+func drainChannel[T any](
+	ctx context.Context,
+	channel chan dto.PromptResultDTO,
+	messageFactory func(context.Context, dto.PromptResultDTO) (T, bool),
+) {
+	drainCtx := context.WithoutCancel(ctx)
+
+	for result := range channel {
+		messageFactory(drainCtx, result)
+	}
+}
+
 // CreateAPIGatewayStreamMessage creates a stream message for the API Gateway.
 func CreateAPIGatewayStreamMessage(
 	ctx context.Context,
@@ -302,35 +547,21 @@ func CreateAPIGatewayStreamMessage(
 		msg.ResponseTokens = &responseTokens
 		msg.StreamDuration = &streamDuration
 
-		go DeductCredit(ctx, result.RequestRecord)
+		DeductCredit(ctx, result.RequestRecord)
 	}
 
-	if content := ptr.Deref(result.Content, ""); len(content) > 0 {
-		msg.Content = content
+	if isFinished && result.ModelVendor != "" {
+		msg.ModelVendor = ptr.To(string(result.ModelVendor))
+		msg.ModelType = ptr.To(string(result.ModelType))
 	}
 
-	return msg, isFinished
-}
-
-// DeductCredit deducts the credit from the project.
-func DeductCredit(
-	ctx context.Context, requestRecord *models.PromptRequestRecord,
-) {
-	projectID, ok := ctx.Value(grpcutils.ProjectIDContextKey).(pgtype.UUID)
-	if !ok {
-		log.Error().Msg("project id not in context")
-		return
+	if isFinished {
+		msg.ToolCalls = CreateToolCalls(result.ToolCalls)
 	}
 
-	requestCost := *exc.MustResult(db.NumericToDecimal(requestRecord.RequestTokensCost))
-	responseCost := exc.MustResult(db.NumericToDecimal(requestRecord.ResponseTokensCost))
-	totalCost := responseCost.Add(requestCost).Mul(decimal.NewFromInt(-1))
+	if content := ptr.Deref(result.Content, ""); len(content) > 0 {
+		msg.Content = content
+	}
 
-	exc.LogIfErr(
-		db.GetQueries().
-			UpdateProjectCredits(context.Background(), models.UpdateProjectCreditsParams{
-				ID:      projectID,
-				Credits: *exc.MustResult(db.StringToNumeric(totalCost.String())),
-			}),
-	)
+	return msg, isFinished
 }
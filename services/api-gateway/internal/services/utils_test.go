@@ -3,6 +3,7 @@ package services_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/basemind-ai/monorepo/e2e/factories"
 	"github.com/basemind-ai/monorepo/gen/go/gateway/v1"
 	"github.com/basemind-ai/monorepo/services/api-gateway/internal/dto"
@@ -11,9 +12,12 @@ import (
 	"github.com/basemind-ai/monorepo/shared/go/cryptoutils"
 	"github.com/basemind-ai/monorepo/shared/go/db"
 	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/exc"
 	"github.com/basemind-ai/monorepo/shared/go/grpcutils"
 	"github.com/basemind-ai/monorepo/shared/go/testutils"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
@@ -128,30 +132,61 @@ func TestUtils(t *testing.T) { //nolint:revive
 		for _, modelType := range []models.ModelType{
 			models.ModelTypeGpt432k, models.ModelTypeGpt4, models.ModelTypeGpt35Turbo, models.ModelTypeGpt35Turbo16k,
 		} {
-			assert.NotPanics(t, func() {
-				services.RetrieveProviderModelPricing(context.TODO(),
-					modelType,
-					models.ModelVendorOPENAI,
-				)
-			})
+			_, pricingErr := services.RetrieveProviderModelPricing(context.TODO(),
+				modelType,
+				models.ModelVendorOPENAI,
+				project.ID,
+			)
+			assert.NoError(t, pricingErr)
 		}
 
-		t.Run("panic if model type is not supported", func(t *testing.T) {
-			assert.Panics(t, func() {
-				services.RetrieveProviderModelPricing(context.TODO(),
-					"unsupported-model-type",
-					"openai",
-				)
-			})
+		t.Run("returns the pricing negotiated for the project", func(t *testing.T) {
+			newProject, _ := factories.CreateProject(context.TODO())
+			projectPricing, createErr := db.GetQueries().
+				CreateProviderModelPricing(context.TODO(), models.CreateProviderModelPricingParams{
+					ModelType:        models.ModelTypeGpt4,
+					ModelVendor:      models.ModelVendorOPENAI,
+					InputTokenPrice:  *exc.MustResult(db.StringToNumeric("0.01")),
+					OutputTokenPrice: *exc.MustResult(db.StringToNumeric("0.02")),
+					TokenUnitSize:    1_000,
+					ActiveFromDate:   pgtype.Date{Time: time.Now(), Valid: true},
+					ProjectID:        newProject.ID,
+				})
+			assert.NoError(t, createErr)
+
+			pricing, pricingErr := services.RetrieveProviderModelPricing(context.TODO(),
+				models.ModelTypeGpt4,
+				models.ModelVendorOPENAI,
+				newProject.ID,
+			)
+			assert.NoError(t, pricingErr)
+			assert.Equal(t, db.UUIDToString(&projectPricing.ID), pricing.ID)
+
+			pricing, pricingErr = services.RetrieveProviderModelPricing(context.TODO(),
+				models.ModelTypeGpt4,
+				models.ModelVendorOPENAI,
+				project.ID,
+			)
+			assert.NoError(t, pricingErr)
+			assert.NotEqual(t, db.UUIDToString(&projectPricing.ID), pricing.ID)
 		})
 
-		t.Run("panic if model vendor is not supported", func(t *testing.T) {
-			assert.Panics(t, func() {
-				services.RetrieveProviderModelPricing(context.TODO(),
-					"davinci",
-					"unsupported-model-vendor",
-				)
-			})
+		t.Run("returns an error if model type is not supported", func(t *testing.T) {
+			_, pricingErr := services.RetrieveProviderModelPricing(context.TODO(),
+				"unsupported-model-type",
+				"openai",
+				project.ID,
+			)
+			assert.Error(t, pricingErr)
+		})
+
+		t.Run("returns a FailedPrecondition error if the model has no active pricing", func(t *testing.T) {
+			_, pricingErr := services.RetrieveProviderModelPricing(context.TODO(),
+				models.ModelTypeCommandNightly,
+				models.ModelVendorOPENAI,
+				project.ID,
+			)
+			assert.Equal(t, codes.FailedPrecondition, status.Code(pricingErr))
 		})
 	})
 
@@ -261,8 +296,9 @@ func TestUtils(t *testing.T) { //nolint:revive
 					EncryptedApiKey: providerKey.EncryptedApiKey,
 				})
 
-				mockRedis.ExpectGet(db.UUIDToString(&project.ID)).RedisNil()
-				mockRedis.ExpectSet(db.UUIDToString(&project.ID), expectedCachedValue, time.Hour/2).
+				cacheKey := fmt.Sprintf("%s:%s", db.UUIDToString(&project.ID), modelProvider)
+				mockRedis.ExpectGet(cacheKey).RedisNil()
+				mockRedis.ExpectSet(cacheKey, expectedCachedValue, time.Hour/2).
 					SetVal("OK")
 
 				updatedContext := services.CreateProviderAPIKeyContext(
@@ -308,7 +344,8 @@ func TestUtils(t *testing.T) { //nolint:revive
 				EncryptedApiKey: providerKey.EncryptedApiKey,
 			})
 
-			mockRedis.ExpectGet(db.UUIDToString(&newProject.ID)).SetVal(string(expectedCachedValue))
+			mockRedis.ExpectGet(fmt.Sprintf("%s:%s", db.UUIDToString(&newProject.ID), modelProvider)).
+				SetVal(string(expectedCachedValue))
 
 			updatedContext := services.CreateProviderAPIKeyContext(
 				context.TODO(),
@@ -491,7 +528,7 @@ func TestUtils(t *testing.T) { //nolint:revive
 			assert.NoError(t, err)
 		})
 
-		t.Run("should return nil when the context is cancelled", func(t *testing.T) {
+		t.Run("should return the context error when the context is cancelled", func(t *testing.T) {
 			ctx, cancel := context.WithCancel(context.Background())
 			channel := make(chan dto.PromptResultDTO)
 			streamServer := &mockGatewayServerStream{Ctx: ctx}
@@ -501,12 +538,52 @@ func TestUtils(t *testing.T) { //nolint:revive
 			}
 
 			cancel()
+			close(channel)
 
 			err := services.StreamFromChannel(ctx, channel, streamServer, messageFactory)
 
 			assert.Equal(t, context.Canceled, err)
 		})
 
+		t.Run("should drain the channel when the context is cancelled", func(t *testing.T) {
+			defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+			ctx, cancel := context.WithCancel(context.Background())
+			channel := make(chan dto.PromptResultDTO)
+			streamServer := &mockGatewayServerStream{Ctx: ctx}
+
+			var handledMessages []dto.PromptResultDTO
+
+			messageFactory := func(ctx context.Context, result dto.PromptResultDTO) (dto.PromptResultDTO, bool) {
+				// the messages are handled after the cancellation using a context that is not cancelled
+				assert.NoError(t, ctx.Err())
+				handledMessages = append(handledMessages, result)
+				return result, result.RequestRecord != nil
+			}
+
+			cancel()
+
+			go func() {
+				channel <- dto.PromptResultDTO{Content: &message1}
+				channel <- dto.PromptResultDTO{
+					RequestRecord: &models.PromptRequestRecord{
+						FinishReason: models.PromptFinishReasonCANCELLED,
+					},
+				}
+				close(channel)
+			}()
+
+			err := services.StreamFromChannel(ctx, channel, streamServer, messageFactory)
+
+			assert.Equal(t, context.Canceled, err)
+			assert.Len(t, handledMessages, 2)
+			assert.Equal(
+				t,
+				models.PromptFinishReasonCANCELLED,
+				handledMessages[1].RequestRecord.FinishReason,
+			)
+		})
+
 		t.Run("should return error when sending message fails", func(t *testing.T) {
 			ctx := context.TODO()
 			channel := make(chan dto.PromptResultDTO)
@@ -528,6 +605,31 @@ func TestUtils(t *testing.T) { //nolint:revive
 			assert.EqualError(t, err, "rpc error: code = Internal desc = failed to send message")
 		})
 
+		t.Run("should drain the channel when sending a message fails", func(t *testing.T) {
+			defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+			channel := make(chan dto.PromptResultDTO)
+			streamServer := &mockGatewayServerStream{Error: errors.New("failed to send message")}
+
+			handledMessages := 0
+
+			messageFactory := func(_ context.Context, result dto.PromptResultDTO) (dto.PromptResultDTO, bool) {
+				handledMessages++
+				return result, false
+			}
+
+			go func() {
+				channel <- dto.PromptResultDTO{Content: &message1}
+				channel <- dto.PromptResultDTO{Content: &message2}
+				close(channel)
+			}()
+
+			err := services.StreamFromChannel(context.TODO(), channel, streamServer, messageFactory)
+
+			assert.Equal(t, codes.Internal, status.Code(err))
+			assert.Equal(t, 2, handledMessages)
+		})
+
 		t.Run("should return error when result has error", func(t *testing.T) {
 			ctx := context.TODO()
 			channel := make(chan dto.PromptResultDTO)
@@ -666,6 +768,22 @@ func TestUtils(t *testing.T) { //nolint:revive
 			assert.Equal(t, "DONE", *msg.FinishReason)
 		})
 
+		t.Run("sets the tool calls of the finished stream", func(t *testing.T) {
+			result := dto.PromptResultDTO{
+				RequestRecord: &models.PromptRequestRecord{
+					FinishReason: models.PromptFinishReasonDONE,
+				},
+				ToolCalls: []dto.ToolCallDTO{
+					{ID: "call-1", Name: "get_weather", Arguments: `{"city":"Paris"}`},
+				},
+			}
+			msg, _ := services.CreateAPIGatewayStreamMessage(context.TODO(), result)
+
+			assert.Equal(t, []*gateway.ToolCall{
+				{Id: "call-1", Name: "get_weather", Arguments: `{"city":"Paris"}`},
+			}, msg.ToolCalls)
+		})
+
 		t.Run("finish reason is nil when request record is nil", func(t *testing.T) {
 			result := dto.PromptResultDTO{}
 			msg, _ := services.CreateAPIGatewayStreamMessage(context.TODO(), result)
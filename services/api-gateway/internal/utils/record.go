@@ -0,0 +1,19 @@
+package utils
+
+import (
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+// SetRecordFinishTime sets the finish time of a request record to now,
+// and its duration to the milliseconds elapsed since its start time.
+func SetRecordFinishTime(recordParams *models.CreatePromptRequestRecordParams) {
+	finishTime := time.Now()
+
+	recordParams.FinishTime = pgtype.Timestamptz{Time: finishTime, Valid: true}
+	recordParams.DurationMs = pgtype.Int4{
+		Int32: int32(finishTime.Sub(recordParams.StartTime.Time).Milliseconds()),
+		Valid: true,
+	}
+}
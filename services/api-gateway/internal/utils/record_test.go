@@ -0,0 +1,31 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/utils"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetRecordFinishTime(t *testing.T) {
+	t.Run("sets the finish time and the duration since the start time", func(t *testing.T) {
+		startTime := time.Now().Add(-2 * time.Second)
+		recordParams := &models.CreatePromptRequestRecordParams{
+			StartTime: pgtype.Timestamptz{Time: startTime, Valid: true},
+		}
+
+		utils.SetRecordFinishTime(recordParams)
+
+		assert.True(t, recordParams.FinishTime.Valid)
+		assert.True(t, recordParams.DurationMs.Valid)
+		assert.GreaterOrEqual(t, recordParams.DurationMs.Int32, int32(2000))
+		assert.Equal(
+			t,
+			recordParams.FinishTime.Time.Sub(startTime).Milliseconds(),
+			int64(recordParams.DurationMs.Int32),
+		)
+	})
+}
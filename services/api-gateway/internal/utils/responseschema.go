@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/xeipuuv/gojsonschema"
+	"strings"
+)
+
+// CreateResponseSchemaInstruction returns the instruction given to the model when a prompt config declares
+// a response schema. OpenAI JSON mode also requires the word JSON to appear in the prompt messages.
+func CreateResponseSchemaInstruction(responseSchema json.RawMessage) string {
+	return fmt.Sprintf(
+		"Respond only with a JSON object that is valid according to the following JSON schema: %s",
+		responseSchema,
+	)
+}
+
+// ValidateResponseSchema validates the content of a model response against the given JSON schema.
+// Returns an error describing every schema violation, or nil if the content is valid.
+func ValidateResponseSchema(responseSchema json.RawMessage, content string) error {
+	result, validationErr := gojsonschema.Validate(
+		gojsonschema.NewBytesLoader(responseSchema),
+		gojsonschema.NewStringLoader(content),
+	)
+	if validationErr != nil {
+		return fmt.Errorf("response is not valid JSON - %w", validationErr)
+	}
+
+	if result.Valid() {
+		return nil
+	}
+
+	violations := make([]string, 0, len(result.Errors()))
+	for _, resultErr := range result.Errors() {
+		violations = append(violations, resultErr.String())
+	}
+
+	return fmt.Errorf("response does not match the JSON schema - %s", strings.Join(violations, "; "))
+}
@@ -0,0 +1,35 @@
+package utils_test
+
+import (
+	"encoding/json"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestResponseSchema(t *testing.T) {
+	responseSchema := json.RawMessage(
+		`{"type":"object","properties":{"name":{"type":"string"}},"required":["name"]}`,
+	)
+
+	t.Run("CreateResponseSchemaInstruction", func(t *testing.T) {
+		assert.Contains(t, utils.CreateResponseSchemaInstruction(responseSchema), string(responseSchema))
+	})
+
+	t.Run("ValidateResponseSchema", func(t *testing.T) {
+		t.Run("returns nil for a valid response", func(t *testing.T) {
+			assert.NoError(t, utils.ValidateResponseSchema(responseSchema, `{"name":"cheese"}`))
+		})
+
+		t.Run("returns an error for a response that is not valid JSON", func(t *testing.T) {
+			err := utils.ValidateResponseSchema(responseSchema, "cheese")
+			assert.ErrorContains(t, err, "response is not valid JSON")
+		})
+
+		t.Run("returns an error describing the schema violations", func(t *testing.T) {
+			err := utils.ValidateResponseSchema(responseSchema, `{"name":1}`)
+			assert.ErrorContains(t, err, "response does not match the JSON schema")
+			assert.ErrorContains(t, err, "name")
+		})
+	})
+}
@@ -0,0 +1,170 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"github.com/basemind-ai/monorepo/shared/go/exc"
+	"github.com/rs/zerolog/log"
+	"github.com/sethvargo/go-envconfig"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy is the deadline and retry configuration of the calls made by a connector.
+type RetryPolicy struct {
+	// Timeout is the deadline of a single call, including the full stream for streaming calls. Zero disables it.
+	Timeout time.Duration `env:"TIMEOUT,default=120s"`
+	// MaxAttempts is the maximum number of calls made for a single request, including the first one.
+	MaxAttempts int `env:"MAX_ATTEMPTS,default=3"`
+	// InitialBackoff is the upper bound of the delay before the first retry.
+	InitialBackoff time.Duration `env:"INITIAL_BACKOFF,default=200ms"`
+	// MaxBackoff caps the upper bound of the delay between retries.
+	MaxBackoff time.Duration `env:"MAX_BACKOFF,default=5s"`
+	// BackoffMultiplier is the factor by which the delay upper bound grows after each retry.
+	BackoffMultiplier float64 `env:"BACKOFF_MULTIPLIER,default=2"`
+}
+
+// RetryPolicyFromEnv reads the retry policy from the environment variables with the given prefix,
+// e.g. the prefix "OPENAI_CONNECTOR_" reads OPENAI_CONNECTOR_TIMEOUT, OPENAI_CONNECTOR_MAX_ATTEMPTS etc.
+func RetryPolicyFromEnv(ctx context.Context, prefix string) RetryPolicy {
+	policy := RetryPolicy{}
+	exc.Must(envconfig.ProcessWith(ctx, &envconfig.Config{
+		Target:   &policy,
+		Lookuper: envconfig.PrefixLookuper(prefix, envconfig.OsLookuper()),
+	}))
+
+	return policy
+}
+
+// IsRetryableError returns true if the given connector error is transient and the call can be retried.
+func IsRetryableError(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// Backoff returns the delay before the given retry, starting at 1.
+// The delay is drawn uniformly between zero and the exponentially growing upper bound (full jitter).
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	upperBound := math.Min(
+		float64(p.InitialBackoff)*math.Pow(p.BackoffMultiplier, float64(retry-1)),
+		float64(p.MaxBackoff),
+	)
+	if upperBound <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(upperBound) + 1)) //nolint: gosec
+}
+
+// attemptContext returns the context of a single call, applying the policy timeout.
+func (p RetryPolicy) attemptContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if p.Timeout > 0 {
+		return context.WithTimeout(ctx, p.Timeout)
+	}
+
+	return context.WithCancel(ctx)
+}
+
+// shouldRetry waits for the backoff of the given attempt and returns true if the call should be retried.
+func (p RetryPolicy) shouldRetry(ctx context.Context, attempt int, err error) bool {
+	if attempt >= p.MaxAttempts || ctx.Err() != nil || !IsRetryableError(err) {
+		return false
+	}
+
+	log.Debug().Err(err).Int("attempt", attempt).Msg("retrying connector call")
+
+	timer := time.NewTimer(p.Backoff(attempt))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// CallWithRetry calls the given function, retrying it according to the policy.
+// Returns the result of the last call and the number of calls made.
+func CallWithRetry[T any](
+	ctx context.Context,
+	policy RetryPolicy,
+	call func(ctx context.Context) (T, error),
+) (T, int, error) {
+	for attempt := 1; ; attempt++ {
+		attemptCtx, cancel := policy.attemptContext(ctx)
+		result, err := call(attemptCtx)
+		cancel()
+
+		if err == nil || !policy.shouldRetry(ctx, attempt, err) {
+			return result, attempt, err
+		}
+	}
+}
+
+// retryStream is a stream that replays the first received message before reading from the underlying stream.
+type retryStream[T any] struct {
+	stream   Stream[T]
+	cancel   context.CancelFunc
+	first    *T
+	firstErr error
+	replayed bool
+}
+
+func (s *retryStream[T]) Recv() (*T, error) {
+	msg, err := s.first, s.firstErr
+	if s.replayed {
+		msg, err = s.stream.Recv()
+	}
+
+	s.replayed = true
+
+	if err != nil {
+		s.cancel()
+	}
+
+	return msg, err
+}
+
+// StreamWithRetry opens a stream using the given function, retrying it according to the policy.
+// A call is only retried if it fails before its first message is received - once a token was received,
+// the stream is returned as is.
+// Returns the stream and the number of calls made, or the error of the last call.
+func StreamWithRetry[T any](
+	ctx context.Context,
+	policy RetryPolicy,
+	open func(ctx context.Context) (Stream[T], error),
+) (Stream[T], int, error) {
+	for attempt := 1; ; attempt++ {
+		attemptCtx, cancel := policy.attemptContext(ctx)
+
+		stream, err := open(attemptCtx)
+		if err == nil {
+			first, recvErr := stream.Recv()
+			if recvErr == nil || errors.Is(recvErr, io.EOF) {
+				return &retryStream[T]{
+					stream:   stream,
+					cancel:   cancel,
+					first:    first,
+					firstErr: recvErr,
+				}, attempt, nil
+			}
+
+			err = recvErr
+		}
+
+		cancel()
+
+		if !policy.shouldRetry(ctx, attempt, err) {
+			return nil, attempt, err
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package utils_test
+
+import (
+	"context"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"testing"
+	"time"
+)
+
+type mockStream struct {
+	messages []*string
+	err      error
+}
+
+func (m *mockStream) Recv() (*string, error) {
+	if len(m.messages) == 0 {
+		if m.err != nil {
+			return nil, m.err
+		}
+		return nil, io.EOF
+	}
+
+	msg := m.messages[0]
+	m.messages = m.messages[1:]
+
+	return msg, nil
+}
+
+func TestRetry(t *testing.T) { //nolint: revive
+	policy := utils.RetryPolicy{
+		Timeout:           time.Second,
+		MaxAttempts:       3,
+		InitialBackoff:    time.Millisecond,
+		MaxBackoff:        5 * time.Millisecond,
+		BackoffMultiplier: 2,
+	}
+	unavailableErr := status.Error(codes.Unavailable, "unavailable")
+
+	t.Run("RetryPolicyFromEnv", func(t *testing.T) {
+		t.Run("reads the policy using the prefix", func(t *testing.T) {
+			t.Setenv("TEST_CONNECTOR_TIMEOUT", "10s")
+			t.Setenv("TEST_CONNECTOR_MAX_ATTEMPTS", "5")
+
+			envPolicy := utils.RetryPolicyFromEnv(context.TODO(), "TEST_CONNECTOR_")
+			assert.Equal(t, 10*time.Second, envPolicy.Timeout)
+			assert.Equal(t, 5, envPolicy.MaxAttempts)
+			assert.Equal(t, 200*time.Millisecond, envPolicy.InitialBackoff)
+			assert.Equal(t, 5*time.Second, envPolicy.MaxBackoff)
+			assert.Equal(t, float64(2), envPolicy.BackoffMultiplier)
+		})
+	})
+
+	t.Run("IsRetryableError", func(t *testing.T) {
+		for _, code := range []codes.Code{codes.Unavailable, codes.ResourceExhausted, codes.Aborted, codes.DeadlineExceeded} {
+			assert.True(t, utils.IsRetryableError(status.Error(code, "error")))
+		}
+
+		for _, code := range []codes.Code{codes.Unknown, codes.InvalidArgument, codes.Canceled, codes.Internal} {
+			assert.False(t, utils.IsRetryableError(status.Error(code, "error")))
+		}
+	})
+
+	t.Run("Backoff", func(t *testing.T) {
+		t.Run("stays within the exponential upper bound", func(t *testing.T) {
+			for retry := 1; retry <= 5; retry++ {
+				assert.LessOrEqual(t, policy.Backoff(retry), policy.MaxBackoff)
+			}
+
+			assert.LessOrEqual(t, policy.Backoff(2), 2*time.Millisecond)
+		})
+
+		t.Run("returns zero without a backoff", func(t *testing.T) {
+			assert.Equal(t, time.Duration(0), utils.RetryPolicy{}.Backoff(1))
+		})
+	})
+
+	t.Run("CallWithRetry", func(t *testing.T) {
+		t.Run("returns the result of the first successful call", func(t *testing.T) {
+			calls := 0
+			result, attempts, err := utils.CallWithRetry(
+				context.TODO(),
+				policy,
+				func(context.Context) (string, error) {
+					calls++
+					if calls < 2 {
+						return "", unavailableErr
+					}
+					return "result", nil
+				},
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, "result", result)
+			assert.Equal(t, 2, attempts)
+		})
+
+		t.Run("stops after the maximum attempts", func(t *testing.T) {
+			_, attempts, err := utils.CallWithRetry(
+				context.TODO(),
+				policy,
+				func(context.Context) (string, error) {
+					return "", unavailableErr
+				},
+			)
+			assert.ErrorIs(t, err, unavailableErr)
+			assert.Equal(t, 3, attempts)
+		})
+
+		t.Run("does not retry non retryable errors", func(t *testing.T) {
+			_, attempts, err := utils.CallWithRetry(
+				context.TODO(),
+				policy,
+				func(context.Context) (string, error) {
+					return "", status.Error(codes.InvalidArgument, "invalid")
+				},
+			)
+			assert.Error(t, err)
+			assert.Equal(t, 1, attempts)
+		})
+
+		t.Run("does not retry when the context is done", func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.TODO())
+			cancel()
+
+			_, attempts, err := utils.CallWithRetry(
+				ctx,
+				policy,
+				func(context.Context) (string, error) {
+					return "", unavailableErr
+				},
+			)
+			assert.Error(t, err)
+			assert.Equal(t, 1, attempts)
+		})
+
+		t.Run("applies the timeout to every call", func(t *testing.T) {
+			_, _, err := utils.CallWithRetry(
+				context.TODO(),
+				utils.RetryPolicy{Timeout: time.Millisecond, MaxAttempts: 1},
+				func(ctx context.Context) (string, error) {
+					<-ctx.Done()
+					return "", status.FromContextError(ctx.Err()).Err()
+				},
+			)
+			assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+		})
+	})
+
+	t.Run("StreamWithRetry", func(t *testing.T) {
+		first, second := "1", "2"
+
+		t.Run("retries when the stream fails before the first message", func(t *testing.T) {
+			calls := 0
+			stream, attempts, err := utils.StreamWithRetry(
+				context.TODO(),
+				policy,
+				func(context.Context) (utils.Stream[string], error) {
+					calls++
+					if calls < 2 {
+						return &mockStream{err: unavailableErr}, nil
+					}
+					return &mockStream{messages: []*string{&first, &second}}, nil
+				},
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, 2, attempts)
+
+			msg, recvErr := stream.Recv()
+			assert.NoError(t, recvErr)
+			assert.Equal(t, first, *msg)
+
+			msg, recvErr = stream.Recv()
+			assert.NoError(t, recvErr)
+			assert.Equal(t, second, *msg)
+
+			_, recvErr = stream.Recv()
+			assert.ErrorIs(t, recvErr, io.EOF)
+		})
+
+		t.Run("does not retry after the first message", func(t *testing.T) {
+			calls := 0
+			stream, attempts, err := utils.StreamWithRetry(
+				context.TODO(),
+				policy,
+				func(context.Context) (utils.Stream[string], error) {
+					calls++
+					return &mockStream{messages: []*string{&first}, err: unavailableErr}, nil
+				},
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, 1, attempts)
+
+			msg, recvErr := stream.Recv()
+			assert.NoError(t, recvErr)
+			assert.Equal(t, first, *msg)
+
+			_, recvErr = stream.Recv()
+			assert.ErrorIs(t, recvErr, unavailableErr)
+			assert.Equal(t, 1, calls)
+		})
+
+		t.Run("returns the error after the maximum attempts", func(t *testing.T) {
+			_, attempts, err := utils.StreamWithRetry(
+				context.TODO(),
+				policy,
+				func(context.Context) (utils.Stream[string], error) {
+					return nil, unavailableErr
+				},
+			)
+			assert.ErrorIs(t, err, unavailableErr)
+			assert.Equal(t, 3, attempts)
+		})
+	})
+}
@@ -1,14 +1,14 @@
 package utils
 
 import (
+	"context"
 	"errors"
 	"github.com/basemind-ai/monorepo/services/api-gateway/internal/dto"
 	"github.com/basemind-ai/monorepo/shared/go/db/models"
 	"github.com/basemind-ai/monorepo/shared/go/ptr"
-	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/rs/zerolog/log"
 	"io"
-	"time"
+	"strings"
 )
 
 // Stream is an interface for a gRPC stream.
@@ -22,24 +22,32 @@ type StreamMessage struct {
 	FinishReason       *string
 	RequestTokenCount  *uint32
 	ResponseTokenCount *uint32
+	ToolCalls          []dto.ToolCallDTO
 }
 
 type StreamFinishResult struct {
 	FinishReason       models.PromptFinishReason
 	RequestTokenCount  uint32
 	ResponseTokenCount uint32
+	ToolCalls          []dto.ToolCallDTO
 }
 
 // StreamFromClient is a generic function that handles the streaming response from an LLM API.
+// When the context is cancelled, e.g. because the client disconnected, the stream is aborted and the tokens
+// of the prompt text and of the content streamed so far are estimated, with a CANCELLED finish reason.
 func StreamFromClient[T any]( //nolint: revive
+	ctx context.Context,
 	channel chan<- dto.PromptResultDTO,
 	finalResult *dto.PromptResultDTO,
 	recordParams *models.CreatePromptRequestRecordParams,
-	startTime time.Time,
+	promptText string,
 	stream Stream[T],
 	parseMessage func(*T) *StreamMessage,
 ) *StreamFinishResult {
-	var streamResult *StreamFinishResult
+	var (
+		streamResult    *StreamFinishResult
+		streamedContent strings.Builder
+	)
 
 	for {
 		msg, receiveErr := stream.Recv()
@@ -47,40 +55,53 @@ func StreamFromClient[T any]( //nolint: revive
 		isFinished := false
 
 		parsedMessage := parseMessage(msg)
-		if parsedMessage.FinishReason != nil {
+
+		if ctx.Err() != nil {
+			log.Debug().Err(ctx.Err()).Msg("stream cancelled")
+
 			streamResult = &StreamFinishResult{
-				FinishReason:       models.PromptFinishReason(*parsedMessage.FinishReason),
-				RequestTokenCount:  ptr.Deref(parsedMessage.RequestTokenCount, 0),
-				ResponseTokenCount: ptr.Deref(parsedMessage.ResponseTokenCount, 0),
+				FinishReason:       models.PromptFinishReasonCANCELLED,
+				RequestTokenCount:  EstimateTokenCount(promptText),
+				ResponseTokenCount: EstimateTokenCount(streamedContent.String()),
 			}
 
 			isFinished = true
-		}
-
-		if receiveErr != nil {
-			if !errors.Is(receiveErr, io.EOF) {
-				log.Debug().Err(receiveErr).Msg("received stream error")
-				finalResult.Error = receiveErr
+		} else {
+			if parsedMessage.FinishReason != nil {
+				streamResult = &StreamFinishResult{
+					FinishReason:       models.PromptFinishReason(*parsedMessage.FinishReason),
+					RequestTokenCount:  ptr.Deref(parsedMessage.RequestTokenCount, 0),
+					ResponseTokenCount: ptr.Deref(parsedMessage.ResponseTokenCount, 0),
+					ToolCalls:          parsedMessage.ToolCalls,
+				}
+
+				isFinished = true
 			}
 
-			isFinished = true
-		}
+			if receiveErr != nil {
+				if !errors.Is(receiveErr, io.EOF) {
+					log.Debug().Err(receiveErr).Msg("received stream error")
+					finalResult.Error = receiveErr
+				}
 
-		if recordParams.DurationMs.Int32 == 0 {
-			duration := int32(time.Until(startTime).Milliseconds())
-			recordParams.DurationMs = pgtype.Int4{Int32: duration, Valid: true}
+				isFinished = true
+			}
 		}
 
 		if isFinished {
 			break
 		}
 
-		channel <- dto.PromptResultDTO{
-			Content: parsedMessage.Content,
+		streamedContent.WriteString(ptr.Deref(parsedMessage.Content, ""))
+
+		// the send is abandoned once the context is cancelled, the stream is then aborted on the next receive
+		select {
+		case channel <- dto.PromptResultDTO{Content: parsedMessage.Content}:
+		case <-ctx.Done():
 		}
 	}
 
-	recordParams.FinishTime = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	SetRecordFinishTime(recordParams)
 
 	return streamResult
 }
@@ -0,0 +1,149 @@
+package utils_test
+
+import (
+	"context"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/dto"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/utils"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+// blockingStream returns its messages, and then blocks until its context is cancelled, like an upstream
+// connector stream that is still generating.
+type blockingStream struct {
+	ctx      context.Context
+	messages []*string
+}
+
+func (s *blockingStream) Recv() (*string, error) {
+	if len(s.messages) > 0 {
+		msg := s.messages[0]
+		s.messages = s.messages[1:]
+
+		return msg, nil
+	}
+
+	<-s.ctx.Done()
+
+	return nil, status.FromContextError(s.ctx.Err()).Err()
+}
+
+func parseStringMessage(msg *string) *utils.StreamMessage {
+	if msg == nil {
+		return &utils.StreamMessage{
+			FinishReason:       ptr.To(string(models.PromptFinishReasonDONE)),
+			RequestTokenCount:  ptr.To(uint32(10)),
+			ResponseTokenCount: ptr.To(uint32(20)),
+		}
+	}
+
+	return &utils.StreamMessage{Content: msg}
+}
+
+func TestStreamFromClient(t *testing.T) { //nolint: revive
+	t.Run("streams the messages and returns the finish result", func(t *testing.T) {
+		channel := make(chan dto.PromptResultDTO)
+		recordParams := &models.CreatePromptRequestRecordParams{
+			StartTime: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		}
+		finalResult := &dto.PromptResultDTO{}
+
+		go func() {
+			defer close(channel)
+
+			streamFinish := utils.StreamFromClient[string](
+				context.TODO(),
+				channel,
+				finalResult,
+				recordParams,
+				"prompt text",
+				&mockStream{messages: []*string{ptr.To("Hello"), ptr.To(" world")}},
+				parseStringMessage,
+			)
+
+			assert.Equal(t, models.PromptFinishReasonDONE, streamFinish.FinishReason)
+			assert.Equal(t, uint32(10), streamFinish.RequestTokenCount)
+			assert.Equal(t, uint32(20), streamFinish.ResponseTokenCount)
+		}()
+
+		var contents []string
+		for result := range channel {
+			contents = append(contents, *result.Content)
+		}
+
+		assert.Equal(t, []string{"Hello", " world"}, contents)
+		assert.NoError(t, finalResult.Error)
+		assert.True(t, recordParams.FinishTime.Valid)
+		assert.True(t, recordParams.DurationMs.Valid)
+		assert.GreaterOrEqual(t, recordParams.DurationMs.Int32, int32(0))
+	})
+
+	t.Run("aborts the stream when the context is cancelled", func(t *testing.T) {
+		defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		channel := make(chan dto.PromptResultDTO)
+		finalResult := &dto.PromptResultDTO{}
+		streamFinishChannel := make(chan *utils.StreamFinishResult)
+
+		go func() {
+			streamFinishChannel <- utils.StreamFromClient[string](
+				ctx,
+				channel,
+				finalResult,
+				&models.CreatePromptRequestRecordParams{},
+				"abcdefgh",
+				&blockingStream{
+					ctx:      ctx,
+					messages: []*string{ptr.To("Hello"), ptr.To(" world")},
+				},
+				parseStringMessage,
+			)
+		}()
+
+		assert.Equal(t, "Hello", *(<-channel).Content)
+		assert.Equal(t, " world", *(<-channel).Content)
+
+		cancel()
+
+		streamFinish := <-streamFinishChannel
+		assert.Equal(t, models.PromptFinishReasonCANCELLED, streamFinish.FinishReason)
+		assert.Equal(t, utils.EstimateTokenCount("abcdefgh"), streamFinish.RequestTokenCount)
+		assert.Equal(t, utils.EstimateTokenCount("Hello world"), streamFinish.ResponseTokenCount)
+		assert.NoError(t, finalResult.Error)
+	})
+
+	t.Run("does not block on the channel when the context is cancelled", func(t *testing.T) {
+		defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		streamFinishChannel := make(chan *utils.StreamFinishResult)
+
+		go func() {
+			streamFinishChannel <- utils.StreamFromClient[string](
+				ctx,
+				// the channel is not consumed
+				make(chan dto.PromptResultDTO),
+				&dto.PromptResultDTO{},
+				&models.CreatePromptRequestRecordParams{},
+				"abcdefgh",
+				&mockStream{messages: []*string{ptr.To("Hello")}},
+				parseStringMessage,
+			)
+		}()
+
+		time.AfterFunc(10*time.Millisecond, cancel)
+
+		streamFinish := <-streamFinishChannel
+		assert.Equal(t, models.PromptFinishReasonCANCELLED, streamFinish.FinishReason)
+		assert.Equal(t, utils.EstimateTokenCount("Hello"), streamFinish.ResponseTokenCount)
+	})
+}
@@ -3,28 +3,73 @@ package utils
 import (
 	"github.com/basemind-ai/monorepo/shared/go/datatypes"
 	"github.com/shopspring/decimal"
+	"unicode/utf8"
 )
 
+// TokenUsage is the number of tokens of each class used by a request.
+type TokenUsage map[datatypes.TokenClass]int32
+
+// TokenCostResult is the cost of the tokens of each class used by a request.
 type TokenCostResult struct {
-	RequestTokenCost  decimal.Decimal
-	ResponseTokenCost decimal.Decimal
+	Costs map[datatypes.TokenClass]decimal.Decimal
+}
+
+// RequestTokenCost returns the cost of the tokens sent to the model, e.g. the input and cached input tokens.
+func (r TokenCostResult) RequestTokenCost() decimal.Decimal {
+	cost := decimal.Zero
+
+	for class, classCost := range r.Costs {
+		if !class.IsResponse() {
+			cost = cost.Add(classCost)
+		}
+	}
+
+	return cost
 }
 
-// CalculateCosts calculates the input and output tokens count and costs for a given model type / vendor.
+// ResponseTokenCost returns the cost of the tokens generated by the model, e.g. the output and reasoning tokens.
+func (r TokenCostResult) ResponseTokenCost() decimal.Decimal {
+	cost := decimal.Zero
+
+	for class, classCost := range r.Costs {
+		if class.IsResponse() {
+			cost = cost.Add(classCost)
+		}
+	}
+
+	return cost
+}
+
+// TotalCost returns the cost of all the tokens used by a request.
+func (r TokenCostResult) TotalCost() decimal.Decimal {
+	return r.RequestTokenCost().Add(r.ResponseTokenCost())
+}
+
+// CalculateCosts calculates the costs of the tokens of each class for a given model type / vendor.
 func CalculateCosts(
-	requestTokenCount int32,
-	responseTokenCount int32,
+	usage TokenUsage,
 	modelPricing datatypes.ProviderModelPricingDTO,
 ) TokenCostResult {
 	// The unit size is the number of tokens per which we calculate the price. E.g. 0.002$ for 1000 tokens.
 	unitSize := decimal.NewFromInt32(modelPricing.TokenUnitSize)
 
-	return TokenCostResult{
-		RequestTokenCost: decimal.NewFromInt32(requestTokenCount).
+	costs := make(map[datatypes.TokenClass]decimal.Decimal, len(usage))
+	for class, tokenCount := range usage {
+		costs[class] = decimal.NewFromInt32(tokenCount).
 			Div(unitSize).
-			Mul(modelPricing.InputTokenPrice),
-		ResponseTokenCost: decimal.NewFromInt32(responseTokenCount).
-			Div(unitSize).
-			Mul(modelPricing.OutputTokenPrice),
+			Mul(modelPricing.TokenPrice(class))
 	}
+
+	return TokenCostResult{Costs: costs}
+}
+
+// EstimateTokenCount returns a rough token count for the given text, at about four characters per token.
+// Used when the provider does not report the usage, e.g. for streams that were cancelled before finishing.
+func EstimateTokenCount(text string) uint32 {
+	runeCount := utf8.RuneCountInString(text)
+	if runeCount == 0 {
+		return 0
+	}
+
+	return uint32((runeCount + 3) / 4)
 }
@@ -0,0 +1,70 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/utils"
+	"github.com/basemind-ai/monorepo/shared/go/datatypes"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateCosts(t *testing.T) {
+	modelPricing := datatypes.ProviderModelPricingDTO{
+		InputTokenPrice:  decimal.RequireFromString("0.002"),
+		OutputTokenPrice: decimal.RequireFromString("0.004"),
+		TokenUnitSize:    1000,
+	}
+
+	t.Run("bills the input and output tokens at their own price", func(t *testing.T) {
+		costs := utils.CalculateCosts(utils.TokenUsage{
+			datatypes.TokenClassInput:  1000,
+			datatypes.TokenClassOutput: 500,
+		}, modelPricing)
+
+		assert.Equal(t, "0.002", costs.RequestTokenCost().String())
+		assert.Equal(t, "0.002", costs.ResponseTokenCost().String())
+		assert.Equal(t, "0.004", costs.TotalCost().String())
+	})
+
+	t.Run("bills the token classes without a price as input or output tokens", func(t *testing.T) {
+		costs := utils.CalculateCosts(utils.TokenUsage{
+			datatypes.TokenClassCachedInput: 1000,
+			datatypes.TokenClassImage:       1000,
+			datatypes.TokenClassReasoning:   1000,
+		}, modelPricing)
+
+		assert.Equal(t, "0.004", costs.RequestTokenCost().String())
+		assert.Equal(t, "0.004", costs.ResponseTokenCost().String())
+	})
+
+	t.Run("bills the token classes with a price at their own price", func(t *testing.T) {
+		pricing := modelPricing
+		pricing.CachedInputTokenPrice = ptr.To(decimal.RequireFromString("0.001"))
+		pricing.ReasoningTokenPrice = ptr.To(decimal.RequireFromString("0.008"))
+
+		costs := utils.CalculateCosts(utils.TokenUsage{
+			datatypes.TokenClassInput:       1000,
+			datatypes.TokenClassCachedInput: 1000,
+			datatypes.TokenClassOutput:      1000,
+			datatypes.TokenClassReasoning:   1000,
+		}, pricing)
+
+		assert.Equal(t, "0.001", costs.Costs[datatypes.TokenClassCachedInput].String())
+		assert.Equal(t, "0.003", costs.RequestTokenCost().String())
+		assert.Equal(t, "0.012", costs.ResponseTokenCost().String())
+	})
+
+	t.Run("returns zero costs for no usage", func(t *testing.T) {
+		costs := utils.CalculateCosts(utils.TokenUsage{}, modelPricing)
+
+		assert.True(t, costs.TotalCost().IsZero())
+	})
+}
+
+func TestEstimateTokenCount(t *testing.T) {
+	assert.Equal(t, uint32(0), utils.EstimateTokenCount(""))
+	assert.Equal(t, uint32(1), utils.EstimateTokenCount("abc"))
+	assert.Equal(t, uint32(2), utils.EstimateTokenCount("abcdefgh"))
+}
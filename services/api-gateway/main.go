@@ -2,23 +2,31 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/basemind-ai/monorepo/gen/go/gateway/v1"
 	"github.com/basemind-ai/monorepo/gen/go/ptesting/v1"
 	"github.com/basemind-ai/monorepo/services/api-gateway/internal/connectors"
+	"github.com/basemind-ai/monorepo/services/api-gateway/internal/openaiapi"
 	"github.com/basemind-ai/monorepo/services/api-gateway/internal/services"
 	"github.com/basemind-ai/monorepo/shared/go/config"
 	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/exc"
 	"github.com/basemind-ai/monorepo/shared/go/grpcutils"
 	"github.com/basemind-ai/monorepo/shared/go/logging"
+	"github.com/basemind-ai/monorepo/shared/go/metrics"
 	"github.com/basemind-ai/monorepo/shared/go/rediscache"
+	"github.com/basemind-ai/monorepo/shared/go/router"
+	"github.com/basemind-ai/monorepo/shared/go/tracing"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rs/zerolog/log"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
-	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 )
 
 func main() {
@@ -36,6 +44,16 @@ func main() {
 
 	logging.Configure(cfg.Environment != "production")
 
+	shutdownTracing := exc.MustResult(tracing.Init(ctx, tracing.Options{
+		ServiceName: "api-gateway",
+		Exporter:    cfg.TracingExporter,
+		SampleRatio: cfg.TracingSampleRatio,
+	}))
+
+	defer func() {
+		exc.LogIfErr(shutdownTracing(context.Background()), "failed to shutdown tracing")
+	}()
+
 	connectors.Init(ctx)
 
 	rediscache.New(cfg.RedisURL)
@@ -47,10 +65,16 @@ func main() {
 
 	defer conn.Close()
 
+	exc.Must(metrics.RegisterDBPool(conn, prometheus.DefaultRegisterer))
+
+	authHandler := grpcutils.NewAuthHandler(cfg.JWTSecret).HandleAuth
+	rateLimiter := grpcutils.NewRateLimiter(rediscache.GetRedisClient())
+
 	server := grpcutils.CreateGRPCServer(
 		grpcutils.Options{
-			AuthHandler: grpcutils.NewAuthHandler(cfg.JWTSecret).HandleAuth,
+			AuthHandler: authHandler,
 			Environment: cfg.Environment,
+			RateLimiter: rateLimiter,
 			ServiceName: "api-gateway",
 			ServiceRegistrars: []grpcutils.ServiceRegistrar{
 				func(s grpc.ServiceRegistrar) {
@@ -63,24 +87,79 @@ func main() {
 		},
 	)
 
+	// the gateway services are served to browser clients using the gRPC-Web and Connect protocols on the same port
+	webHandler := exc.MustResult(grpcutils.CreateWebHandler(server, grpcutils.WebOptions{
+		AllowedOrigins: cfg.CORSAllowedOrigins,
+	}))
+
+	gatewayServer := &http.Server{
+		IdleTimeout:       30 * time.Second,
+		ReadHeaderTimeout: 2 * time.Second,
+		Addr:              fmt.Sprintf("0.0.0.0:%d", cfg.ServerPort),
+		Handler:           webHandler,
+	}
+
+	openaiAPIServer := &http.Server{
+		IdleTimeout:       30 * time.Second,
+		ReadHeaderTimeout: 2 * time.Second,
+		ReadTimeout:       3 * time.Second,
+		// there is no write timeout, since streaming responses are written for the duration of the prompt request
+		Addr: fmt.Sprintf(":%d", cfg.OpenAIAPIPort),
+		Handler: router.New(router.Options{
+			Environment:      cfg.Environment,
+			ServiceName:      "api-gateway",
+			RegisterHandlers: openaiapi.RegisterHandlers,
+			Middlewares: []func(next http.Handler) http.Handler{
+				openaiapi.AuthMiddleware(authHandler),
+				openaiapi.RateLimitMiddleware(rateLimiter),
+			},
+		}),
+	}
+
 	g, gCtx := errgroup.WithContext(ctx)
 
 	g.Go(func() error {
-		address := fmt.Sprintf("0.0.0.0:%d", cfg.ServerPort)
+		log.Info().
+			Str("service", "api-gateway").
+			Str("address", gatewayServer.Addr).
+			Msg("server starting")
+		return gatewayServer.ListenAndServe()
+	})
+
+	g.Go(func() error {
+		log.Info().
+			Str("service", "api-gateway").
+			Int("port", cfg.OpenAIAPIPort).
+			Msg("openai api server starting")
+		return openaiAPIServer.ListenAndServe()
+	})
+
+	g.Go(func() error {
+		return metrics.Serve(gCtx, cfg.MetricsPort)
+	})
+
+	g.Go(func() error {
+		services.RunPayloadRetention(gCtx, services.PayloadRetentionInterval)
+		return nil
+	})
 
-		listen, listenErr := net.Listen("tcp", address)
-		if listenErr != nil {
-			return listenErr
-		}
+	g.Go(func() error {
+		services.RunCreditReconciliation(gCtx, services.CreditReconciliationInterval)
+		return nil
+	})
 
-		log.Info().Str("service", "api-gateway").Str("address", address).Msg("server starting")
-		return server.Serve(listen)
+	g.Go(func() error {
+		services.RunBatchJobWorkers(gCtx, cfg.BatchJobWorkers, services.BatchJobPollInterval)
+		return nil
 	})
 
 	g.Go(func() error {
 		<-gCtx.Done()
 		server.Stop()
-		return nil
+		return errors.Join(
+			gatewayServer.Shutdown(context.Background()),
+			openaiAPIServer.Shutdown(context.Background()),
+		)
 	})
 
 	if err := g.Wait(); err != nil {
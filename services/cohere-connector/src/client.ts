@@ -1,6 +1,7 @@
 import { PassThrough } from 'node:stream';
 
 import { CohereClient } from 'cohere-ai';
+import { EmbedRequest } from 'cohere-ai/api/client/requests/EmbedRequest';
 import { GenerateRequest } from 'cohere-ai/api/client/requests/GenerateRequest';
 import { loadEnv } from 'shared/env';
 import logger from 'shared/logger';
@@ -34,6 +35,17 @@ export class BasemindCohereClient {
 		return this.client.generate(request);
 	}
 
+	/**
+	 * The embed function takes an EmbedRequest and returns the embeddings of its texts
+	 *
+	 * @param request EmbedRequest
+	 *
+	 * @return An EmbedResponse
+	 */
+	async embed(request: EmbedRequest) {
+		return this.client.embed(request);
+	}
+
 	async generateStream(request: GenerateRequest) {
 		const url = 'https://api.cohere.ai/v1/generate';
 		const options = {
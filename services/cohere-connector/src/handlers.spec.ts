@@ -9,6 +9,10 @@ import {
 	ServerWritableStream,
 } from '@grpc/grpc-js';
 import {
+	CohereEmbeddingInputType,
+	CohereEmbeddingModel,
+	CohereEmbeddingsRequest,
+	CohereEmbeddingsResponse,
 	CohereModel,
 	CoherePromptRequest,
 	CoherePromptResponse,
@@ -19,8 +23,9 @@ import { GrpcError } from 'shared/grpc';
 import { wait } from 'shared/time';
 import { Mock, MockInstance } from 'vitest';
 
-import { coherePrompt, cohereStream } from '@/handlers';
+import { cohereEmbeddings, coherePrompt, cohereStream } from '@/handlers';
 
+const mockEmbed = vi.fn();
 const mockGenerate = vi.fn();
 const mockTokenize = vi.fn();
 
@@ -30,6 +35,7 @@ vi.mock(
 		const { CohereClient, ...original } = await importOriginal();
 
 		class MockCohereClient extends CohereClient {
+			embed = mockEmbed;
 			generate = mockGenerate;
 			tokenize = mockTokenize;
 		}
@@ -50,6 +56,7 @@ describe('handlers tests', () => {
 	});
 
 	beforeEach(() => {
+		mockEmbed.mockReset();
 		mockGenerate.mockReset();
 		mockTokenize.mockReset();
 
@@ -76,6 +83,7 @@ describe('handlers tests', () => {
 
 		it('should successfully complete an Cohere prompt request and send the expected response', async () => {
 			const call = makeMockUnaryCall({
+				chatHistory: [],
 				message: 'test',
 				model: CohereModel.COMMAND,
 				parameters: {
@@ -118,6 +126,7 @@ describe('handlers tests', () => {
 
 		it('should send a GrpcError when an error occurs during the Cohere prompt request', async () => {
 			const call = makeMockUnaryCall({
+				chatHistory: [],
 				message: 'test',
 				model: CohereModel.COMMAND,
 				parameters: {
@@ -170,6 +179,7 @@ describe('handlers tests', () => {
 
 		it('should successfully create an Cohere stream request and write the response to the call', async () => {
 			const call = makeServerWritableStream({
+				chatHistory: [],
 				message: 'test',
 				model: CohereModel.COMMAND,
 				parameters: {
@@ -279,6 +289,7 @@ describe('handlers tests', () => {
 
 		it.each([''])('should handle errors', async () => {
 			const call = makeServerWritableStream({
+				chatHistory: [],
 				message: 'test',
 				model: CohereModel.COMMAND,
 				parameters: {
@@ -294,4 +305,106 @@ describe('handlers tests', () => {
 			expect(call.destroy as Mock).toHaveBeenCalled();
 		});
 	});
+
+	describe('cohereEmbeddings', () => {
+		const makeMockUnaryCall = (
+			request: CohereEmbeddingsRequest,
+		): ServerUnaryCall<
+			CohereEmbeddingsRequest,
+			CohereEmbeddingsResponse
+		> => {
+			return {
+				getPath: vi.fn(),
+				metadata: new Metadata(),
+				request,
+			} as unknown as ServerUnaryCall<
+				CohereEmbeddingsRequest,
+				CohereEmbeddingsResponse
+			>;
+		};
+
+		it('should successfully complete a Cohere embeddings request and send the expected response', async () => {
+			const call = makeMockUnaryCall({
+				inputType: CohereEmbeddingInputType.SEARCH_QUERY,
+				inputs: ['The meaning of life', 'is 42'],
+				model: CohereEmbeddingModel.EMBED_ENGLISH_V3,
+			});
+			const callback: sendUnaryData<CohereEmbeddingsResponse> = vi.fn();
+
+			mockEmbed.mockResolvedValueOnce({
+				embeddings: [
+					[0.1, 0.2],
+					[0.3, 0.4],
+				],
+				id: 'abc',
+				meta: { billedUnits: { inputTokens: 8 } },
+				responseType: 'embeddings_floats',
+				texts: ['The meaning of life', 'is 42'],
+			});
+
+			await cohereEmbeddings(call, callback);
+			expect(mockEmbed).toHaveBeenCalledWith({
+				inputType: 'search_query',
+				model: 'embed-english-v3.0',
+				texts: ['The meaning of life', 'is 42'],
+			});
+			expect(mockTokenize).not.toHaveBeenCalled();
+
+			expect(callback).toHaveBeenCalledWith(null, {
+				embeddings: [{ values: [0.1, 0.2] }, { values: [0.3, 0.4] }],
+				requestTokensCount: 8,
+			});
+		});
+
+		it('should tokenize the inputs when the response does not include the billed units', async () => {
+			const call = makeMockUnaryCall({
+				inputType: CohereEmbeddingInputType.UNSPECIFIED,
+				inputs: ['test'],
+				model: CohereEmbeddingModel.EMBED_MULTILINGUAL_V3,
+			});
+			const callback: sendUnaryData<CohereEmbeddingsResponse> = vi.fn();
+
+			mockEmbed.mockResolvedValueOnce({
+				embeddings: [[0.1, 0.2]],
+				id: 'abc',
+				responseType: 'embeddings_floats',
+				texts: ['test'],
+			});
+
+			await cohereEmbeddings(call, callback);
+			expect(mockEmbed).toHaveBeenCalledWith({
+				inputType: 'search_document',
+				model: 'embed-multilingual-v3.0',
+				texts: ['test'],
+			});
+			expect(mockTokenize).toHaveBeenCalledWith({
+				model: 'embed-multilingual-v3.0',
+				text: 'test',
+			});
+
+			expect(callback).toHaveBeenCalledWith(null, {
+				embeddings: [{ values: [0.1, 0.2] }],
+				requestTokensCount: 2,
+			});
+		});
+
+		it('should send a GrpcError when an error occurs during the Cohere embeddings request', async () => {
+			const call = makeMockUnaryCall({
+				inputType: CohereEmbeddingInputType.SEARCH_DOCUMENT,
+				inputs: ['test'],
+				model: CohereEmbeddingModel.EMBED_ENGLISH_V3,
+			});
+			const callback: sendUnaryData<CohereEmbeddingsResponse> = vi.fn();
+
+			const error = new Error('Cohere error');
+			mockEmbed.mockRejectedValueOnce(error);
+
+			await cohereEmbeddings(call, callback);
+
+			expect(callback).toHaveBeenCalledWith(
+				new GrpcError({ message: error.message }),
+				null,
+			);
+		});
+	});
 });
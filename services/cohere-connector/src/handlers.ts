@@ -5,6 +5,8 @@ import {
 } from '@grpc/grpc-js';
 import { Generation } from 'cohere-ai/api';
 import {
+	CohereEmbeddingsRequest,
+	CohereEmbeddingsResponse,
 	CoherePromptRequest,
 	CoherePromptResponse,
 	CohereStreamResponse,
@@ -17,7 +19,13 @@ import {
 import logger from 'shared/logger';
 
 import { BasemindCohereClient, createOrDefaultClient } from '@/client';
-import { createCohereRequest, getFinishReason, getModel } from '@/utils';
+import {
+	createCohereEmbedRequest,
+	createCohereRequest,
+	createPrompt,
+	getFinishReason,
+	getModel,
+} from '@/utils';
 
 const COMMUNICATION_ERROR_MESSAGE = 'error communicating with Cohere';
 const DECODER = new TextDecoder();
@@ -102,7 +110,7 @@ export async function coherePrompt(
 			await getTokensCount({
 				client,
 				model: call.request.model,
-				requestText: call.request.message,
+				requestText: createPrompt(call.request),
 				responseText: response,
 			});
 		const finishTime = Date.now();
@@ -176,7 +184,7 @@ export async function cohereStream(
 						await getTokensCount({
 							client,
 							model: call.request.model,
-							requestText: call.request.message,
+							requestText: createPrompt(call.request),
 							responseText: messages.join(''),
 						});
 
@@ -207,3 +215,52 @@ export async function cohereStream(
 		call.destroy(createInternalGrpcError(error as Error));
 	}
 }
+
+/**
+ * The cohereEmbeddings function is a gRPC handler function, requesting the embedding vectors of a batch of inputs.
+ *
+ * @param call ServerUnaryCall object, including the request and metadata
+ * @param callback sendUnaryData handler, allowing sending a response back to the client.
+ */
+export async function cohereEmbeddings(
+	call: ServerUnaryCall<CohereEmbeddingsRequest, CohereEmbeddingsResponse>,
+	callback: sendUnaryData<CohereEmbeddingsResponse>,
+) {
+	logger.debug(
+		{ inputs: call.request.inputs.length, path: call.getPath() },
+		'received Cohere embeddings request',
+	);
+
+	const client = createOrDefaultClient(
+		extractProviderAPIKeyFromMetadata(call),
+	);
+
+	try {
+		logger.debug('making Cohere embeddings request');
+
+		const startTime = Date.now();
+		const request = createCohereEmbedRequest(call.request);
+		const response = await client.embed(request);
+
+		const embeddings =
+			response.responseType === 'embeddings_floats'
+				? response.embeddings
+				: response.embeddings.float ?? [];
+		const requestTokensCount =
+			response.meta?.billedUnits?.inputTokens ??
+			(await client.tokenize(request.texts.join(''), request.model!));
+		const finishTime = Date.now();
+
+		logger.debug(
+			{ finishTime, startTime },
+			'Cohere embeddings request completed',
+		);
+		callback(null, {
+			embeddings: embeddings.map((values) => ({ values })),
+			requestTokensCount,
+		} satisfies CohereEmbeddingsResponse);
+	} catch (error: unknown) {
+		logger.error(error as Error, COMMUNICATION_ERROR_MESSAGE);
+		callback(new GrpcError({ message: (error as Error).message }), null);
+	}
+}
@@ -4,9 +4,11 @@ import {
 } from 'gen/cohere/v1/cohere.grpc-server';
 import { createServer } from 'shared/grpc';
 
-import { coherePrompt, cohereStream } from '@/handlers';
+import { cohereEmbeddings, coherePrompt, cohereStream } from '@/handlers';
 
 const implementation = {
+	// eslint-disable-next-line @typescript-eslint/no-misused-promises
+	cohereEmbeddings,
 	// eslint-disable-next-line @typescript-eslint/no-misused-promises
 	coherePrompt,
 	// eslint-disable-next-line @typescript-eslint/no-misused-promises
@@ -1,12 +1,62 @@
-import { CohereModel, CoherePromptRequest } from 'gen/cohere/v1/cohere';
+import {
+	CohereChatRole,
+	CohereEmbeddingInputType,
+	CohereEmbeddingModel,
+	CohereModel,
+	CoherePromptRequest,
+} from 'gen/cohere/v1/cohere';
 import { StreamFinishReason } from 'shared/constants';
 
-import { createCohereRequest, getFinishReason } from '@/utils';
+import {
+	createCohereEmbedRequest,
+	createCohereRequest,
+	createPrompt,
+	getFinishReason,
+} from '@/utils';
 
 describe('utils tests', () => {
+	describe('createPrompt', () => {
+		it('returns the message when there is no chat history', () => {
+			expect(
+				createPrompt({
+					chatHistory: [],
+					message: 'hello world',
+					model: CohereModel.COMMAND,
+				}),
+			).toBe('hello world');
+		});
+
+		it('appends the chat history as a transcript ending with a chatbot cue', () => {
+			expect(
+				createPrompt({
+					chatHistory: [
+						{ message: 'hi', role: CohereChatRole.USER },
+						{
+							message: 'hello, how can I help?',
+							role: CohereChatRole.CHATBOT,
+						},
+						{ message: 'tell me a joke', role: CohereChatRole.USER },
+					],
+					message: 'You are a helpful assistant.',
+					model: CohereModel.COMMAND,
+				}),
+			).toBe(
+				[
+					'You are a helpful assistant.',
+					'',
+					'User: hi',
+					'Chatbot: hello, how can I help?',
+					'User: tell me a joke',
+					'Chatbot:',
+				].join('\n'),
+			);
+		});
+	});
+
 	describe('createCohereRequest', () => {
 		it('returns a GenerateRequest with the correct model', () => {
 			const grpcRequest = {
+				chatHistory: [],
 				message: 'hello world',
 				model: CohereModel.COMMAND,
 			} satisfies CoherePromptRequest;
@@ -20,6 +70,7 @@ describe('utils tests', () => {
 
 		it('returns a GenerateRequest with the correct conversationId', () => {
 			const grpcRequest = {
+				chatHistory: [],
 				message: 'hello world',
 				model: CohereModel.COMMAND,
 			} satisfies CoherePromptRequest;
@@ -32,6 +83,7 @@ describe('utils tests', () => {
 
 		it('returns a GenerateRequest with the correct temperature', () => {
 			const grpcRequest = {
+				chatHistory: [],
 				message: 'hello world',
 				model: CohereModel.COMMAND,
 				parameters: {
@@ -48,6 +100,7 @@ describe('utils tests', () => {
 
 		it('handles temperature being 0 without removing it', () => {
 			const grpcRequest = {
+				chatHistory: [],
 				message: 'hello world',
 				model: CohereModel.COMMAND,
 				parameters: {
@@ -64,6 +117,7 @@ describe('utils tests', () => {
 
 		it('does not add connectors when the grpcRequest connectors is an empty array', () => {
 			const grpcRequest = {
+				chatHistory: [],
 				message: 'hello world',
 				model: CohereModel.COMMAND,
 				parameters: {
@@ -80,6 +134,7 @@ describe('utils tests', () => {
 
 		it('does not add connectors when the grpcRequest connectors is undefined', () => {
 			const grpcRequest = {
+				chatHistory: [],
 				message: 'hello world',
 				model: CohereModel.COMMAND,
 				parameters: {
@@ -95,6 +150,32 @@ describe('utils tests', () => {
 		});
 	});
 
+	describe('createCohereEmbedRequest', () => {
+		it('maps the embeddings request', () => {
+			expect(
+				createCohereEmbedRequest({
+					inputType: CohereEmbeddingInputType.CLASSIFICATION,
+					inputs: ['test'],
+					model: CohereEmbeddingModel.EMBED_MULTILINGUAL_V3,
+				}),
+			).toEqual({
+				inputType: 'classification',
+				model: 'embed-multilingual-v3.0',
+				texts: ['test'],
+			});
+		});
+
+		it('defaults to the search document input type', () => {
+			expect(
+				createCohereEmbedRequest({
+					inputType: CohereEmbeddingInputType.UNSPECIFIED,
+					inputs: ['test'],
+					model: CohereEmbeddingModel.EMBED_ENGLISH_V3,
+				}).inputType,
+			).toBe('search_document');
+		});
+	});
+
 	describe('getFinishReason', () => {
 		it('returns the correct finish reason for a complete event', () => {
 			expect(getFinishReason('COMPLETE')).toEqual(
@@ -1,6 +1,15 @@
+import { EmbedRequest } from 'cohere-ai/api/client/requests/EmbedRequest';
 import { GenerateRequest } from 'cohere-ai/api/client/requests/GenerateRequest';
 import { ChatStreamEndEventFinishReason } from 'cohere-ai/api/types/ChatStreamEndEventFinishReason';
-import { CohereModel, CoherePromptRequest } from 'gen/cohere/v1/cohere';
+import { EmbedInputType } from 'cohere-ai/api/types/EmbedInputType';
+import {
+	CohereChatRole,
+	CohereEmbeddingInputType,
+	CohereEmbeddingModel,
+	CohereEmbeddingsRequest,
+	CohereModel,
+	CoherePromptRequest,
+} from 'gen/cohere/v1/cohere';
 import { StreamFinishReason } from 'shared/constants';
 
 export const modelMapping: Record<CohereModel, string> = {
@@ -11,6 +20,30 @@ export const modelMapping: Record<CohereModel, string> = {
 	[CohereModel.COMMAND_LIGHT_NIGHTLY]: 'command-light-nightly',
 };
 
+export const embeddingModelMapping: Record<CohereEmbeddingModel, string> = {
+	[CohereEmbeddingModel.UNSPECIFIED]: 'embed-english-v3.0',
+	[CohereEmbeddingModel.EMBED_ENGLISH_V3]: 'embed-english-v3.0',
+	[CohereEmbeddingModel.EMBED_MULTILINGUAL_V3]: 'embed-multilingual-v3.0',
+};
+
+export const embeddingInputTypeMapping: Record<
+	CohereEmbeddingInputType,
+	EmbedInputType
+> = {
+	[CohereEmbeddingInputType.UNSPECIFIED]: EmbedInputType.SearchDocument,
+	[CohereEmbeddingInputType.SEARCH_DOCUMENT]: EmbedInputType.SearchDocument,
+	[CohereEmbeddingInputType.SEARCH_QUERY]: EmbedInputType.SearchQuery,
+	[CohereEmbeddingInputType.CLASSIFICATION]: EmbedInputType.Classification,
+	[CohereEmbeddingInputType.CLUSTERING]: EmbedInputType.Clustering,
+};
+
+export const chatRoleMapping: Record<CohereChatRole, string> = {
+	[CohereChatRole.UNSPECIFIED]: 'User',
+	[CohereChatRole.SYSTEM]: 'System',
+	[CohereChatRole.USER]: 'User',
+	[CohereChatRole.CHATBOT]: 'Chatbot',
+};
+
 export const finishReasonMapping: Record<
 	ChatStreamEndEventFinishReason,
 	StreamFinishReason
@@ -33,6 +66,33 @@ export function getModel(model: CoherePromptRequest['model']): string {
 	return modelMapping[model];
 }
 
+/**
+ * The createPrompt function renders the prompt message followed by the chat history, if any, as a generation prompt.
+ * The chat history is rendered as a transcript ending with a Chatbot cue, so the generation is the next Chatbot message.
+ *
+ * @param grpcRequest CoherePromptRequest
+ *
+ * @return A string
+ */
+export function createPrompt({
+	message,
+	chatHistory,
+}: CoherePromptRequest): string {
+	if (chatHistory.length === 0) {
+		return message;
+	}
+
+	return [
+		message,
+		'',
+		...chatHistory.map(
+			(chatMessage) =>
+				`${chatRoleMapping[chatMessage.role]}: ${chatMessage.message}`,
+		),
+		`${chatRoleMapping[CohereChatRole.CHATBOT]}:`,
+	].join('\n');
+}
+
 /**
  * The createCohereRequest function takes a CoherePromptRequest and returns a Cohere client.CoherePromptRequest
  *
@@ -45,11 +105,30 @@ export function createCohereRequest(
 ): GenerateRequest {
 	return {
 		model: getModel(grpcRequest.model),
-		prompt: grpcRequest.message,
+		prompt: createPrompt(grpcRequest),
 		...grpcRequest.parameters,
 	} satisfies GenerateRequest;
 }
 
+/**
+ * The createCohereEmbedRequest function takes a CohereEmbeddingsRequest and returns an EmbedRequest
+ *
+ * @param grpcRequest CohereEmbeddingsRequest
+ *
+ * @return An EmbedRequest
+ */
+export function createCohereEmbedRequest({
+	model,
+	inputs,
+	inputType,
+}: CohereEmbeddingsRequest): EmbedRequest {
+	return {
+		inputType: embeddingInputTypeMapping[inputType],
+		model: embeddingModelMapping[model],
+		texts: inputs,
+	} satisfies EmbedRequest;
+}
+
 /**
  *
  * @param finishReason the stream finish reason from Cohere
@@ -119,6 +119,134 @@ func RegisterHandlers(mux *chi.Mux) {
 			subRouter.Get("/", handleRetrieveProjectAnalytics)
 		})
 
+		router.Route(ProjectCreditGrantsEndpoint, func(subRouter chi.Router) {
+			subRouter.Use(middleware.PathParameterMiddleware("projectId"))
+			subRouter.Use(
+				middleware.AuthorizationMiddleware(
+					middleware.MethodPermissionMap{
+						http.MethodPost: adminOnly,
+					},
+				),
+			)
+			subRouter.Post("/", handleGrantCredits)
+		})
+
+		router.Route(ProjectCreditLedgerEndpoint, func(subRouter chi.Router) {
+			subRouter.Use(middleware.PathParameterMiddleware("projectId"))
+			subRouter.Use(
+				middleware.AuthorizationMiddleware(
+					middleware.MethodPermissionMap{
+						http.MethodGet: allPermissions,
+					},
+				),
+			)
+			subRouter.Get("/", handleRetrieveCreditLedger)
+		})
+
+		router.Route(ProjectCreditConsumptionEndpoint, func(subRouter chi.Router) {
+			subRouter.Use(middleware.PathParameterMiddleware("projectId"))
+			subRouter.Use(
+				middleware.AuthorizationMiddleware(
+					middleware.MethodPermissionMap{
+						http.MethodGet: allPermissions,
+					},
+				),
+			)
+			subRouter.Get("/", handleRetrieveCreditConsumption)
+		})
+
+		router.Route(ProjectCreditStatementEndpoint, func(subRouter chi.Router) {
+			subRouter.Use(middleware.PathParameterMiddleware("projectId"))
+			subRouter.Use(
+				middleware.AuthorizationMiddleware(
+					middleware.MethodPermissionMap{
+						http.MethodGet: allPermissions,
+					},
+				),
+			)
+			subRouter.Get("/", handleRetrieveCreditStatement)
+		})
+
+		router.Route(ProjectCreditReconciliationEndpoint, func(subRouter chi.Router) {
+			subRouter.Use(middleware.PathParameterMiddleware("projectId"))
+			subRouter.Use(
+				middleware.AuthorizationMiddleware(
+					middleware.MethodPermissionMap{
+						http.MethodGet:  adminOnly,
+						http.MethodPost: adminOnly,
+					},
+				),
+			)
+			subRouter.Get("/", handleRetrieveCreditReconciliation)
+			// applying a reconciliation adjusts the project credits without a payment
+			subRouter.With(middleware.PlatformAdminMiddleware).Post("/", handleApplyCreditReconciliation)
+		})
+
+		router.Route(ProjectPricingEndpoint, func(subRouter chi.Router) {
+			subRouter.Use(middleware.PathParameterMiddleware("projectId"))
+			subRouter.Use(
+				middleware.AuthorizationMiddleware(
+					middleware.MethodPermissionMap{
+						http.MethodGet: allPermissions,
+					},
+				),
+			)
+			subRouter.Get("/", handleRetrieveProjectPricing)
+		})
+
+		router.Route(ProviderModelPricingListEndpoint, func(subRouter chi.Router) {
+			subRouter.Use(middleware.PlatformAdminMiddleware)
+			subRouter.Get("/", handleRetrieveProviderModelPricings)
+			subRouter.Post("/", handleCreateProviderModelPricing)
+		})
+
+		router.Route(ProviderModelPricingDetailEndpoint, func(subRouter chi.Router) {
+			subRouter.Use(middleware.PlatformAdminMiddleware)
+			subRouter.Use(middleware.PathParameterMiddleware("providerModelPricingId"))
+			subRouter.Delete("/", handleDeleteProviderModelPricing)
+			subRouter.Patch("/", handleUpdateProviderModelPricing)
+		})
+
+		router.Route(ProjectSpendBudgetListEndpoint, func(subRouter chi.Router) {
+			subRouter.Use(middleware.PathParameterMiddleware("projectId"))
+			subRouter.Use(
+				middleware.AuthorizationMiddleware(
+					middleware.MethodPermissionMap{
+						http.MethodGet:  allPermissions,
+						http.MethodPost: adminOnly,
+					},
+				),
+			)
+			subRouter.Get("/", handleRetrieveSpendBudgets)
+			subRouter.Post("/", handleCreateSpendBudget)
+		})
+
+		router.Route(ProjectSpendBudgetDetailEndpoint, func(subRouter chi.Router) {
+			subRouter.Use(middleware.PathParameterMiddleware("projectId", "spendBudgetId"))
+			subRouter.Use(
+				middleware.AuthorizationMiddleware(
+					middleware.MethodPermissionMap{
+						http.MethodDelete: adminOnly,
+						http.MethodPatch:  adminOnly,
+					},
+				),
+			)
+			subRouter.Delete("/", handleDeleteSpendBudget)
+			subRouter.Patch("/", handleUpdateSpendBudget)
+		})
+
+		router.Route(ProjectSpendBudgetAlertsEndpoint, func(subRouter chi.Router) {
+			subRouter.Use(middleware.PathParameterMiddleware("projectId"))
+			subRouter.Use(
+				middleware.AuthorizationMiddleware(
+					middleware.MethodPermissionMap{
+						http.MethodGet: allPermissions,
+					},
+				),
+			)
+			subRouter.Get("/", handleRetrieveSpendBudgetAlerts)
+		})
+
 		router.Route(ProjectUserListEndpoint, func(subRouter chi.Router) {
 			subRouter.Use(middleware.PathParameterMiddleware("projectId"))
 			subRouter.Use(
@@ -189,6 +317,58 @@ func RegisterHandlers(mux *chi.Mux) {
 			subRouter.Get("/", handleRetrieveApplicationAnalytics)
 		})
 
+		router.Route(ApplicationTrafficSplitEndpoint, func(subRouter chi.Router) {
+			subRouter.Use(middleware.PathParameterMiddleware("projectId", "applicationId"))
+			subRouter.Use(
+				middleware.AuthorizationMiddleware(
+					middleware.MethodPermissionMap{
+						http.MethodGet:   allPermissions,
+						http.MethodPatch: adminOnly,
+					},
+				),
+			)
+			subRouter.Get("/", handleRetrieveTrafficSplit)
+			subRouter.Patch("/", handleUpdateTrafficSplit)
+		})
+
+		router.Route(ApplicationTrafficSplitAnalyticsEndpoint, func(subRouter chi.Router) {
+			subRouter.Use(middleware.PathParameterMiddleware("projectId", "applicationId"))
+			subRouter.Use(
+				middleware.AuthorizationMiddleware(
+					middleware.MethodPermissionMap{
+						http.MethodGet: allPermissions,
+					},
+				),
+			)
+			subRouter.Get("/", handleRetrieveTrafficSplitAnalytics)
+		})
+
+		router.Route(ApplicationPayloadLoggingEndpoint, func(subRouter chi.Router) {
+			subRouter.Use(middleware.PathParameterMiddleware("projectId", "applicationId"))
+			subRouter.Use(
+				middleware.AuthorizationMiddleware(
+					middleware.MethodPermissionMap{
+						http.MethodGet:   allPermissions,
+						http.MethodPatch: adminOnly,
+					},
+				),
+			)
+			subRouter.Get("/", handleRetrievePayloadLogging)
+			subRouter.Patch("/", handleUpdatePayloadLogging)
+		})
+
+		router.Route(ApplicationPayloadLogsEndpoint, func(subRouter chi.Router) {
+			subRouter.Use(middleware.PathParameterMiddleware("projectId", "applicationId"))
+			subRouter.Use(
+				middleware.AuthorizationMiddleware(
+					middleware.MethodPermissionMap{
+						http.MethodGet: allPermissions,
+					},
+				),
+			)
+			subRouter.Get("/", handleRetrievePromptRequestPayloads)
+		})
+
 		router.Route(ApplicationAPIKeysListEndpoint, func(subRouter chi.Router) {
 			subRouter.Use(
 				middleware.PathParameterMiddleware("projectId", "applicationId"),
@@ -213,10 +393,12 @@ func RegisterHandlers(mux *chi.Mux) {
 				middleware.AuthorizationMiddleware(
 					middleware.MethodPermissionMap{
 						http.MethodDelete: adminOnly,
+						http.MethodPatch:  adminOnly,
 					},
 				),
 			)
 			subRouter.Delete("/", handleDeleteApplicationAPIKey)
+			subRouter.Patch("/", handleUpdateApplicationAPIKey)
 		})
 
 		router.Route(InviteUserWebhookEndpoint, func(subRouter chi.Router) {
@@ -280,6 +462,58 @@ func RegisterHandlers(mux *chi.Mux) {
 			)
 			subRouter.Patch("/", handleSetApplicationDefaultPromptConfig)
 		})
+		router.Route(PromptConfigVersionListEndpoint, func(subRouter chi.Router) {
+			subRouter.Use(
+				middleware.PathParameterMiddleware("projectId", "applicationId", "promptConfigId"),
+			)
+			subRouter.Use(
+				middleware.AuthorizationMiddleware(
+					middleware.MethodPermissionMap{
+						http.MethodGet: allPermissions,
+					},
+				),
+			)
+			subRouter.Get("/", handleRetrievePromptConfigVersions)
+		})
+
+		router.Route(PromptConfigVersionDiffEndpoint, func(subRouter chi.Router) {
+			subRouter.Use(
+				middleware.PathParameterMiddleware(
+					"projectId",
+					"applicationId",
+					"promptConfigId",
+					"promptConfigVersionId",
+				),
+			)
+			subRouter.Use(
+				middleware.AuthorizationMiddleware(
+					middleware.MethodPermissionMap{
+						http.MethodGet: allPermissions,
+					},
+				),
+			)
+			subRouter.Get("/", handleDiffPromptConfigVersions)
+		})
+
+		router.Route(PromptConfigVersionRollbackEndpoint, func(subRouter chi.Router) {
+			subRouter.Use(
+				middleware.PathParameterMiddleware(
+					"projectId",
+					"applicationId",
+					"promptConfigId",
+					"promptConfigVersionId",
+				),
+			)
+			subRouter.Use(
+				middleware.AuthorizationMiddleware(
+					middleware.MethodPermissionMap{
+						http.MethodPost: adminOnly,
+					},
+				),
+			)
+			subRouter.Post("/", handleRollbackPromptConfig)
+		})
+
 		router.Route(PromptConfigTestingEndpoint, func(subRouter chi.Router) {
 			subRouter.Use(
 				middleware.PathParameterMiddleware("projectId", "applicationId"),
@@ -30,8 +30,10 @@ func handleCreateApplicationAPIKey(w http.ResponseWriter, r *http.Request) {
 	}
 
 	apiKey := exc.MustResult(db.GetQueries().CreateAPIKey(r.Context(), models.CreateAPIKeyParams{
-		ApplicationID: applicationID,
-		Name:          data.Name,
+		ApplicationID:              applicationID,
+		Name:                       data.Name,
+		RateLimitRequestsPerMinute: data.RateLimitRequestsPerMinute,
+		RateLimitTokensPerMinute:   data.RateLimitTokensPerMinute,
 	}))
 
 	apiKeyID := db.UUIDToString(&apiKey.ID)
@@ -40,10 +42,12 @@ func handleCreateApplicationAPIKey(w http.ResponseWriter, r *http.Request) {
 	jwt := exc.MustResult(jwtutils.CreateJWT(-1, []byte(cfg.JWTSecret), apiKeyID))
 
 	serialization.RenderJSONResponse(w, http.StatusCreated, &dto.ApplicationAPIKeyDTO{
-		ID:        apiKeyID,
-		CreatedAt: apiKey.CreatedAt.Time,
-		Name:      apiKey.Name,
-		Hash:      &jwt,
+		ID:                         apiKeyID,
+		CreatedAt:                  apiKey.CreatedAt.Time,
+		Name:                       apiKey.Name,
+		RateLimitRequestsPerMinute: apiKey.RateLimitRequestsPerMinute,
+		RateLimitTokensPerMinute:   apiKey.RateLimitTokensPerMinute,
+		Hash:                       &jwt,
 	})
 }
 
@@ -57,15 +61,54 @@ func handleRetrieveApplicationAPIKeys(w http.ResponseWriter, r *http.Request) {
 	for _, apiKey := range apiKeys {
 		apiKeyID := apiKey.ID
 		ret = append(ret, &dto.ApplicationAPIKeyDTO{
-			ID:        db.UUIDToString(&apiKeyID),
-			CreatedAt: apiKey.CreatedAt.Time,
-			Name:      apiKey.Name,
+			ID:                         db.UUIDToString(&apiKeyID),
+			CreatedAt:                  apiKey.CreatedAt.Time,
+			Name:                       apiKey.Name,
+			RateLimitRequestsPerMinute: apiKey.RateLimitRequestsPerMinute,
+			RateLimitTokensPerMinute:   apiKey.RateLimitTokensPerMinute,
 		})
 	}
 
 	serialization.RenderJSONResponse(w, http.StatusOK, ret)
 }
 
+// handleUpdateApplicationAPIKey - updates the rate limits of an application apiKey.
+func handleUpdateApplicationAPIKey(w http.ResponseWriter, r *http.Request) {
+	applicationID := r.Context().Value(middleware.ApplicationIDContextKey).(pgtype.UUID)
+	apiKeyID := r.Context().Value(middleware.APIKeyIDContextKey).(pgtype.UUID)
+
+	data := &dto.ApplicationAPIKeyRateLimitsDTO{}
+	if deserializationErr := serialization.DeserializeJSON(r.Body, data); deserializationErr != nil {
+		apierror.BadRequest(invalidRequestBodyError).Render(w)
+		return
+	}
+
+	if validationErr := validate.Struct(data); validationErr != nil {
+		apierror.BadRequest(validationErr.Error()).Render(w)
+		return
+	}
+
+	apiKey, updateErr := db.GetQueries().
+		UpdateAPIKeyRateLimits(r.Context(), models.UpdateAPIKeyRateLimitsParams{
+			ID:                         apiKeyID,
+			ApplicationID:              applicationID,
+			RateLimitRequestsPerMinute: data.RateLimitRequestsPerMinute,
+			RateLimitTokensPerMinute:   data.RateLimitTokensPerMinute,
+		})
+	if updateErr != nil {
+		apierror.BadRequest(invalidIDError).Render(w)
+		return
+	}
+
+	serialization.RenderJSONResponse(w, http.StatusOK, &dto.ApplicationAPIKeyDTO{
+		ID:                         db.UUIDToString(&apiKey.ID),
+		CreatedAt:                  apiKey.CreatedAt.Time,
+		Name:                       apiKey.Name,
+		RateLimitRequestsPerMinute: apiKey.RateLimitRequestsPerMinute,
+		RateLimitTokensPerMinute:   apiKey.RateLimitTokensPerMinute,
+	})
+}
+
 // handleDeleteApplicationAPIKey - deletes an application apiKey.
 func handleDeleteApplicationAPIKey(w http.ResponseWriter, r *http.Request) {
 	apiKeyID := r.Context().Value(middleware.APIKeyIDContextKey).(pgtype.UUID)
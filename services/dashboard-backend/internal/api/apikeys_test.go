@@ -162,6 +162,34 @@ func TestAPIKeyAPI(t *testing.T) {
 			assert.NotEmpty(t, *data.Hash)
 		})
 
+		t.Run("creates a new application apiKey with rate limits", func(t *testing.T) {
+			response, requestErr := testClient.Post(context.TODO(), listURL, map[string]any{
+				"name":                       "test apiKey",
+				"rateLimitRequestsPerMinute": 10,
+				"rateLimitTokensPerMinute":   1000,
+			})
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+			data := dto.ApplicationAPIKeyDTO{}
+			deserializationErr := serialization.DeserializeJSON(response.Body, &data)
+			assert.NoError(t, deserializationErr)
+			assert.Equal(t, int32(10), data.RateLimitRequestsPerMinute)
+			assert.Equal(t, int32(1000), data.RateLimitTokensPerMinute)
+		})
+
+		t.Run(
+			"responds with status 400 BAD REQUEST if a rate limit is negative",
+			func(t *testing.T) {
+				response, requestErr := testClient.Post(context.TODO(), listURL, map[string]any{
+					"name":                       "test apiKey",
+					"rateLimitRequestsPerMinute": -1,
+				})
+				assert.NoError(t, requestErr)
+				assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		)
+
 		for _, permission := range []models.AccessPermissionType{
 			models.AccessPermissionTypeMEMBER, models.AccessPermissionTypeADMIN,
 		} {
@@ -266,6 +294,105 @@ func TestAPIKeyAPI(t *testing.T) {
 		)
 	})
 
+	t.Run(fmt.Sprintf("PATCH: %s", api.ApplicationAPIKeyDetailEndpoint), func(t *testing.T) {
+		createDetailURL := func(projectID, applicationID, apiKeyID string) string {
+			return fmt.Sprintf(
+				"/v1%s",
+				strings.ReplaceAll(
+					strings.ReplaceAll(
+						strings.ReplaceAll(
+							api.ApplicationAPIKeyDetailEndpoint,
+							"{projectId}",
+							projectID,
+						),
+						"{applicationId}",
+						applicationID,
+					),
+					"{apiKeyId}", apiKeyID),
+			)
+		}
+
+		t.Run("updates the rate limits of an application apiKey", func(t *testing.T) {
+			apiKey := createAPIKey(t, applicationID, "test apiKey")
+
+			response, requestErr := testClient.Patch(
+				context.TODO(),
+				createDetailURL(projectID, applicationID, db.UUIDToString(&apiKey.ID)),
+				map[string]any{
+					"rateLimitRequestsPerMinute": 10,
+					"rateLimitTokensPerMinute":   1000,
+				},
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+
+			data := dto.ApplicationAPIKeyDTO{}
+			deserializationErr := serialization.DeserializeJSON(response.Body, &data)
+			assert.NoError(t, deserializationErr)
+			assert.Equal(t, db.UUIDToString(&apiKey.ID), data.ID)
+			assert.Equal(t, int32(10), data.RateLimitRequestsPerMinute)
+			assert.Equal(t, int32(1000), data.RateLimitTokensPerMinute)
+			assert.Nil(t, data.Hash)
+		})
+
+		t.Run(
+			"responds with status 400 BAD REQUEST if a rate limit is negative",
+			func(t *testing.T) {
+				apiKey := createAPIKey(t, applicationID, "test apiKey")
+
+				response, requestErr := testClient.Patch(
+					context.TODO(),
+					createDetailURL(projectID, applicationID, db.UUIDToString(&apiKey.ID)),
+					map[string]any{"rateLimitRequestsPerMinute": -1},
+				)
+				assert.NoError(t, requestErr)
+				assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		)
+
+		t.Run(
+			"responds with status 400 BAD REQUEST if the apiKey belongs to another application",
+			func(t *testing.T) {
+				otherApplicationID := createApplication(t, projectID)
+				apiKey := createAPIKey(t, otherApplicationID, "test apiKey")
+
+				response, requestErr := testClient.Patch(
+					context.TODO(),
+					createDetailURL(projectID, applicationID, db.UUIDToString(&apiKey.ID)),
+					map[string]any{"rateLimitRequestsPerMinute": 10},
+				)
+				assert.NoError(t, requestErr)
+				assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		)
+
+		t.Run(
+			"responds with status 401 UNAUTHORIZED if the user does not have ADMIN permission",
+			func(t *testing.T) {
+				newUserAccount, _ := factories.CreateUserAccount(context.TODO())
+				newProjectID := createProject(t)
+				newApplicationID := createApplication(t, newProjectID)
+				createUserProject(
+					t,
+					newUserAccount.FirebaseID,
+					newProjectID,
+					models.AccessPermissionTypeMEMBER,
+				)
+
+				client := createTestClient(t, newUserAccount)
+				apiKey := createAPIKey(t, newApplicationID, "test apiKey")
+
+				response, requestErr := client.Patch(
+					context.TODO(),
+					createDetailURL(newProjectID, newApplicationID, db.UUIDToString(&apiKey.ID)),
+					map[string]any{"rateLimitRequestsPerMinute": 10},
+				)
+				assert.NoError(t, requestErr)
+				assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			},
+		)
+	})
+
 	t.Run(fmt.Sprintf("DELETE: %s", api.ApplicationDetailEndpoint), func(t *testing.T) {
 		t.Run("deletes an application apiKey", func(t *testing.T) {
 			apiKey := createAPIKey(t, applicationID, "test apiKey")
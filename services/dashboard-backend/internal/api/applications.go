@@ -34,15 +34,21 @@ func handleCreateApplication(w http.ResponseWriter, r *http.Request) {
 		apierror.BadRequest("application name is either missing or empty").Render(w)
 		return
 	}
+	if data.RateLimitRequestsPerMinute < 0 || data.RateLimitTokensPerMinute < 0 {
+		apierror.BadRequest(invalidRateLimitError).Render(w)
+		return
+	}
 
 	application := exc.MustResult(db.GetQueries().CreateApplication(r.Context(), *data))
 
 	serialization.RenderJSONResponse(w, http.StatusCreated, dto.ApplicationDTO{
-		ID:          db.UUIDToString(&application.ID),
-		Name:        application.Name,
-		Description: application.Description,
-		CreatedAt:   application.CreatedAt.Time,
-		UpdatedAt:   application.UpdatedAt.Time,
+		ID:                         db.UUIDToString(&application.ID),
+		Name:                       application.Name,
+		Description:                application.Description,
+		RateLimitRequestsPerMinute: application.RateLimitRequestsPerMinute,
+		RateLimitTokensPerMinute:   application.RateLimitTokensPerMinute,
+		CreatedAt:                  application.CreatedAt.Time,
+		UpdatedAt:                  application.UpdatedAt.Time,
 	})
 }
 
@@ -58,11 +64,13 @@ func handleRetrieveApplications(w http.ResponseWriter, r *http.Request) {
 	for i, application := range applications {
 		appID := application.ID
 		data[i] = dto.ApplicationDTO{
-			ID:          db.UUIDToString(&appID),
-			Name:        application.Name,
-			Description: application.Description,
-			CreatedAt:   application.CreatedAt.Time,
-			UpdatedAt:   application.UpdatedAt.Time,
+			ID:                         db.UUIDToString(&appID),
+			Name:                       application.Name,
+			Description:                application.Description,
+			RateLimitRequestsPerMinute: application.RateLimitRequestsPerMinute,
+			RateLimitTokensPerMinute:   application.RateLimitTokensPerMinute,
+			CreatedAt:                  application.CreatedAt.Time,
+			UpdatedAt:                  application.UpdatedAt.Time,
 		}
 	}
 	serialization.RenderJSONResponse(w, http.StatusOK, data)
@@ -82,11 +90,13 @@ func handleRetrieveApplication(w http.ResponseWriter, r *http.Request) {
 	}
 
 	serialization.RenderJSONResponse(w, http.StatusOK, dto.ApplicationDTO{
-		ID:          db.UUIDToString(&application.ID),
-		Name:        application.Name,
-		Description: application.Description,
-		CreatedAt:   application.CreatedAt.Time,
-		UpdatedAt:   application.UpdatedAt.Time,
+		ID:                         db.UUIDToString(&application.ID),
+		Name:                       application.Name,
+		Description:                application.Description,
+		RateLimitRequestsPerMinute: application.RateLimitRequestsPerMinute,
+		RateLimitTokensPerMinute:   application.RateLimitTokensPerMinute,
+		CreatedAt:                  application.CreatedAt.Time,
+		UpdatedAt:                  application.UpdatedAt.Time,
 	})
 }
 
@@ -94,8 +104,20 @@ func handleRetrieveApplication(w http.ResponseWriter, r *http.Request) {
 func handleUpdateApplication(w http.ResponseWriter, r *http.Request) {
 	applicationID := r.Context().Value(middleware.ApplicationIDContextKey).(pgtype.UUID)
 
+	existingApplication, applicationRetrieveErr := db.
+		GetQueries().
+		RetrieveApplication(r.Context(), applicationID)
+
+	if applicationRetrieveErr != nil {
+		apierror.BadRequest(invalidIDError).Render(w)
+		return
+	}
+
+	// the rate limits are kept unless they are set in the request body
 	data := &models.UpdateApplicationParams{
-		ID: applicationID,
+		ID:                         applicationID,
+		RateLimitRequestsPerMinute: existingApplication.RateLimitRequestsPerMinute,
+		RateLimitTokensPerMinute:   existingApplication.RateLimitTokensPerMinute,
 	}
 	if deserializationErr := serialization.DeserializeJSON(r.Body, data); deserializationErr != nil {
 		log.Error().Err(deserializationErr).Msg("failed to deserialize request body")
@@ -107,6 +129,10 @@ func handleUpdateApplication(w http.ResponseWriter, r *http.Request) {
 		apierror.BadRequest("application name is either missing or empty").Render(w)
 		return
 	}
+	if data.RateLimitRequestsPerMinute < 0 || data.RateLimitTokensPerMinute < 0 {
+		apierror.BadRequest(invalidRateLimitError).Render(w)
+		return
+	}
 
 	application := exc.MustResult(db.GetQueries().UpdateApplication(r.Context(), *data))
 
@@ -115,11 +141,13 @@ func handleUpdateApplication(w http.ResponseWriter, r *http.Request) {
 	}()
 
 	serialization.RenderJSONResponse(w, http.StatusOK, dto.ApplicationDTO{
-		ID:          db.UUIDToString(&application.ID),
-		Name:        application.Name,
-		Description: application.Description,
-		CreatedAt:   application.CreatedAt.Time,
-		UpdatedAt:   application.UpdatedAt.Time,
+		ID:                         db.UUIDToString(&application.ID),
+		Name:                       application.Name,
+		Description:                application.Description,
+		RateLimitRequestsPerMinute: application.RateLimitRequestsPerMinute,
+		RateLimitTokensPerMinute:   application.RateLimitTokensPerMinute,
+		CreatedAt:                  application.CreatedAt.Time,
+		UpdatedAt:                  application.UpdatedAt.Time,
 	})
 }
 
@@ -132,12 +160,21 @@ func handleDeleteApplication(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// handleRetrieveApplicationAnalytics - retrieves the analytics for an application.
+// The analytics includes a time series if the interval query parameter is set.
 func handleRetrieveApplicationAnalytics(w http.ResponseWriter, r *http.Request) {
+	projectID := r.Context().Value(middleware.ProjectIDContextKey).(pgtype.UUID)
 	applicationID := r.Context().Value(middleware.ApplicationIDContextKey).(pgtype.UUID)
 
 	toDate := timeutils.ParseDate(r.URL.Query().Get("toDate"), time.Now())
 	fromDate := timeutils.ParseDate(r.URL.Query().Get("fromDate"), timeutils.GetFirstDayOfMonth())
 
+	interval, groupBy := r.URL.Query().Get("interval"), r.URL.Query().Get("groupBy")
+	if validationErr := repositories.ValidateTimeSeriesParams(interval, groupBy, fromDate, toDate); validationErr != nil {
+		apierror.BadRequest(validationErr.Error()).Render(w)
+		return
+	}
+
 	promptAnalytics := repositories.GetApplicationAnalyticsByDateRange(
 		r.Context(),
 		applicationID,
@@ -145,6 +182,17 @@ func handleRetrieveApplicationAnalytics(w http.ResponseWriter, r *http.Request)
 		toDate,
 	)
 
+	if interval != "" {
+		promptAnalytics.TimeSeries = repositories.GetAnalyticsTimeSeries(
+			r.Context(),
+			repositories.TimeSeriesScope{ProjectID: projectID, ApplicationID: applicationID},
+			interval,
+			groupBy,
+			fromDate,
+			toDate,
+		)
+	}
+
 	w.WriteHeader(http.StatusOK)
 	serialization.RenderJSONResponse(w, http.StatusOK, promptAnalytics)
 }
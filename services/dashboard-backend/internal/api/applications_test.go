@@ -53,6 +53,48 @@ func TestApplicationsAPI(t *testing.T) { //nolint: revive
 			assert.Equal(t, "test app description", application.Description)
 		})
 
+		t.Run("creates a new application with rate limits", func(t *testing.T) {
+			response, requestErr := testClient.Post(
+				context.TODO(),
+				fmt.Sprintf(
+					"/v1%s",
+					strings.ReplaceAll(api.ApplicationsListEndpoint, "{projectId}", projectID),
+				),
+				map[string]any{
+					"name":                       "test app",
+					"rateLimitRequestsPerMinute": 60,
+					"rateLimitTokensPerMinute":   10000,
+				},
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+			application := dto.ApplicationDTO{}
+			deserializationErr := serialization.DeserializeJSON(response.Body, &application)
+			assert.NoError(t, deserializationErr)
+			assert.Equal(t, int32(60), application.RateLimitRequestsPerMinute)
+			assert.Equal(t, int32(10000), application.RateLimitTokensPerMinute)
+		})
+
+		t.Run(
+			"responds with status 400 BAD REQUEST if a rate limit is negative",
+			func(t *testing.T) {
+				response, requestErr := testClient.Post(
+					context.TODO(),
+					fmt.Sprintf(
+						"/v1%s",
+						strings.ReplaceAll(api.ApplicationsListEndpoint, "{projectId}", projectID),
+					),
+					map[string]any{
+						"name":                       "test app",
+						"rateLimitRequestsPerMinute": -1,
+					},
+				)
+				assert.NoError(t, requestErr)
+				assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		)
+
 		for _, permission := range []models.AccessPermissionType{
 			models.AccessPermissionTypeMEMBER, models.AccessPermissionTypeADMIN,
 		} {
@@ -441,6 +483,71 @@ func TestApplicationsAPI(t *testing.T) { //nolint: revive
 			assert.Equal(t, "updated app description", responseApplication.Description)
 		})
 
+		t.Run("keeps the rate limits unless they are updated", func(t *testing.T) {
+			applicationID := createApplication(t, projectID)
+			url := fmt.Sprintf(
+				"/v1%s",
+				strings.ReplaceAll(
+					strings.ReplaceAll(api.ApplicationDetailEndpoint, "{projectId}", projectID),
+					"{applicationId}",
+					applicationID,
+				),
+			)
+
+			response, requestErr := testClient.Patch(context.TODO(), url, map[string]any{
+				"name":                       "updated app",
+				"rateLimitRequestsPerMinute": 60,
+				"rateLimitTokensPerMinute":   10000,
+			})
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+
+			response, requestErr = testClient.Patch(context.TODO(), url, map[string]any{
+				"name":                     "updated app again",
+				"rateLimitTokensPerMinute": 0,
+			})
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+
+			responseApplication := dto.ApplicationDTO{}
+			deserializationErr := serialization.DeserializeJSON(
+				response.Body,
+				&responseApplication,
+			)
+			assert.NoError(t, deserializationErr)
+			assert.Equal(t, "updated app again", responseApplication.Name)
+			assert.Equal(t, int32(60), responseApplication.RateLimitRequestsPerMinute)
+			assert.Equal(t, int32(0), responseApplication.RateLimitTokensPerMinute)
+		})
+
+		t.Run(
+			"responds with status 400 BAD REQUEST if a rate limit is negative",
+			func(t *testing.T) {
+				applicationID := createApplication(t, projectID)
+				response, requestErr := testClient.Patch(
+					context.TODO(),
+					fmt.Sprintf(
+						"/v1%s",
+						strings.ReplaceAll(
+							strings.ReplaceAll(
+								api.ApplicationDetailEndpoint,
+								"{projectId}",
+								projectID,
+							),
+							"{applicationId}",
+							applicationID,
+						),
+					),
+					map[string]any{
+						"name":                     "updated app",
+						"rateLimitTokensPerMinute": -1,
+					},
+				)
+				assert.NoError(t, requestErr)
+				assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		)
+
 		t.Run(
 			"responds with status 401 UNAUTHORIZED if the user does not have ADMIN permission",
 			func(t *testing.T) {},
@@ -776,6 +883,64 @@ func TestApplicationsAPI(t *testing.T) { //nolint: revive
 			assert.Equal(t, promptReqAnalytics.TokenCost, responseAnalytics.TokenCost)
 		})
 
+		t.Run("retrieves an application analytics time series", func(t *testing.T) {
+			response, requestErr := testClient.Get(
+				context.TODO(),
+				fmt.Sprintf(
+					"/v1%s?interval=day&groupBy=finishReason",
+					strings.ReplaceAll(
+						strings.ReplaceAll(
+							api.ApplicationAnalyticsEndpoint,
+							"{projectId}",
+							projectID,
+						),
+						"{applicationId}",
+						applicationID,
+					),
+				),
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+
+			responseAnalytics := dto.AnalyticsDTO{}
+			deserializationErr := serialization.DeserializeJSON(
+				response.Body,
+				&responseAnalytics,
+			)
+			assert.NoError(t, deserializationErr)
+			assert.Len(t, responseAnalytics.TimeSeries, 1)
+			assert.Equal(t, "DONE", responseAnalytics.TimeSeries[0].GroupKey)
+			assert.Equal(t, int64(1), responseAnalytics.TimeSeries[0].TotalAPICalls)
+		})
+
+		t.Run("responds with status 400 BAD REQUEST for invalid time series parameters", func(t *testing.T) {
+			for _, query := range []string{
+				"?interval=month",
+				"?interval=day&groupBy=user",
+				"?groupBy=modelType",
+				"?interval=hour&fromDate=2020-01-01T00:00:00Z",
+			} {
+				response, requestErr := testClient.Get(
+					context.TODO(),
+					fmt.Sprintf(
+						"/v1%s%s",
+						strings.ReplaceAll(
+							strings.ReplaceAll(
+								api.ApplicationAnalyticsEndpoint,
+								"{projectId}",
+								projectID,
+							),
+							"{applicationId}",
+							applicationID,
+						),
+						query,
+					),
+				)
+				assert.NoError(t, requestErr)
+				assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+			}
+		})
+
 		for _, permission := range []models.AccessPermissionType{
 			models.AccessPermissionTypeMEMBER, models.AccessPermissionTypeADMIN,
 		} {
@@ -1,36 +1,55 @@
 package api
 
 const (
-	ApplicationAPIKeyDetailEndpoint  = "/projects/{projectId}/applications/{applicationId}/apikeys/{apiKeyId}" //nolint: gosec
-	ApplicationAPIKeysListEndpoint   = "/projects/{projectId}/applications/{applicationId}/apikeys"            //nolint: gosec
-	ApplicationAnalyticsEndpoint     = "/projects/{projectId}/applications/{applicationId}/analytics"
-	ApplicationDetailEndpoint        = "/projects/{projectId}/applications/{applicationId}"
-	ApplicationsListEndpoint         = "/projects/{projectId}/applications"
-	InviteUserWebhookEndpoint        = "/webhooks/invite-user"
-	ProjectAnalyticsEndpoint         = "/projects/{projectId}/analytics"
-	ProjectDetailEndpoint            = "/projects/{projectId}"
-	ProjectOTPEndpoint               = "/projects/{projectId}/otp"
-	ProjectInvitationListEndpoint    = "/projects/{projectId}/invitation"
-	ProjectInvitationDetailEndpoint  = "/projects/{projectId}/invitation/{projectInvitationId}"
-	ProjectProviderKeyDetailEndpoint = "/projects/{projectId}/provider-keys/{providerKeyId}"
-	ProjectProviderKeyListEndpoint   = "/projects/{projectId}/provider-keys"
-	ProjectUserDetailEndpoint        = "/projects/{projectId}/users/{userId}"
-	ProjectUserListEndpoint          = "/projects/{projectId}/users"
-	ProjectsListEndpoint             = "/projects"
-	PromptConfigAnalyticsEndpoint    = "/projects/{projectId}/applications/{applicationId}/prompt-configs/{promptConfigId}/analytics"
-	PromptConfigDetailEndpoint       = "/projects/{projectId}/applications/{applicationId}/prompt-configs/{promptConfigId}"
-	PromptConfigListEndpoint         = "/projects/{projectId}/applications/{applicationId}/prompt-configs"
-	PromptConfigSetDefaultEndpoint   = "/projects/{projectId}/applications/{applicationId}/prompt-configs/{promptConfigId}/set-default"
-	PromptConfigTestingEndpoint      = "/projects/{projectId}/applications/{applicationId}/prompt-configs/test"
-	PromptTestRecordDetailEndpoint   = "/projects/{projectId}/applications/{applicationId}/test-records/{promptTestRecordId}"
-	PromptTestRecordListEndpoint     = "/projects/{projectId}/applications/{applicationId}/test-records"
-	SupportRequestEndpoint           = "/support"
-	UserAccountDetailEndpoint        = "/users"
+	ApplicationAPIKeyDetailEndpoint          = "/projects/{projectId}/applications/{applicationId}/apikeys/{apiKeyId}" //nolint: gosec
+	ApplicationAPIKeysListEndpoint           = "/projects/{projectId}/applications/{applicationId}/apikeys"            //nolint: gosec
+	ApplicationAnalyticsEndpoint             = "/projects/{projectId}/applications/{applicationId}/analytics"
+	ApplicationDetailEndpoint                = "/projects/{projectId}/applications/{applicationId}"
+	ApplicationPayloadLoggingEndpoint        = "/projects/{projectId}/applications/{applicationId}/payload-logging"
+	ApplicationPayloadLogsEndpoint           = "/projects/{projectId}/applications/{applicationId}/payload-logs"
+	ApplicationTrafficSplitEndpoint          = "/projects/{projectId}/applications/{applicationId}/traffic-split"
+	ApplicationTrafficSplitAnalyticsEndpoint = "/projects/{projectId}/applications/{applicationId}/traffic-split/analytics"
+	ApplicationsListEndpoint                 = "/projects/{projectId}/applications"
+	InviteUserWebhookEndpoint                = "/webhooks/invite-user"
+	ProjectAnalyticsEndpoint                 = "/projects/{projectId}/analytics"
+	ProjectCreditConsumptionEndpoint         = "/projects/{projectId}/credits/consumption"
+	ProjectCreditGrantsEndpoint              = "/projects/{projectId}/credits/grants"
+	ProjectCreditLedgerEndpoint              = "/projects/{projectId}/credits/ledger"
+	ProjectCreditReconciliationEndpoint      = "/projects/{projectId}/credits/reconciliation"
+	ProjectCreditStatementEndpoint           = "/projects/{projectId}/credits/statement"
+	ProjectDetailEndpoint                    = "/projects/{projectId}"
+	ProjectOTPEndpoint                       = "/projects/{projectId}/otp"
+	ProjectPricingEndpoint                   = "/projects/{projectId}/pricing"
+	ProjectInvitationListEndpoint            = "/projects/{projectId}/invitation"
+	ProjectInvitationDetailEndpoint          = "/projects/{projectId}/invitation/{projectInvitationId}"
+	ProjectProviderKeyDetailEndpoint         = "/projects/{projectId}/provider-keys/{providerKeyId}"
+	ProjectProviderKeyListEndpoint           = "/projects/{projectId}/provider-keys"
+	ProjectSpendBudgetAlertsEndpoint         = "/projects/{projectId}/spend-budget-alerts"
+	ProjectSpendBudgetDetailEndpoint         = "/projects/{projectId}/spend-budgets/{spendBudgetId}"
+	ProjectSpendBudgetListEndpoint           = "/projects/{projectId}/spend-budgets"
+	ProjectUserDetailEndpoint                = "/projects/{projectId}/users/{userId}"
+	ProjectUserListEndpoint                  = "/projects/{projectId}/users"
+	ProjectsListEndpoint                     = "/projects"
+	ProviderModelPricingDetailEndpoint       = "/pricing/{providerModelPricingId}"
+	ProviderModelPricingListEndpoint         = "/pricing"
+	PromptConfigAnalyticsEndpoint            = "/projects/{projectId}/applications/{applicationId}/prompt-configs/{promptConfigId}/analytics"
+	PromptConfigDetailEndpoint               = "/projects/{projectId}/applications/{applicationId}/prompt-configs/{promptConfigId}"
+	PromptConfigListEndpoint                 = "/projects/{projectId}/applications/{applicationId}/prompt-configs"
+	PromptConfigSetDefaultEndpoint           = "/projects/{projectId}/applications/{applicationId}/prompt-configs/{promptConfigId}/set-default"
+	PromptConfigTestingEndpoint              = "/projects/{projectId}/applications/{applicationId}/prompt-configs/test"
+	PromptConfigVersionDiffEndpoint          = "/projects/{projectId}/applications/{applicationId}/prompt-configs/{promptConfigId}/versions/{promptConfigVersionId}/diff"
+	PromptConfigVersionListEndpoint          = "/projects/{projectId}/applications/{applicationId}/prompt-configs/{promptConfigId}/versions"
+	PromptConfigVersionRollbackEndpoint      = "/projects/{projectId}/applications/{applicationId}/prompt-configs/{promptConfigId}/versions/{promptConfigVersionId}/rollback"
+	PromptTestRecordDetailEndpoint           = "/projects/{projectId}/applications/{applicationId}/test-records/{promptTestRecordId}"
+	PromptTestRecordListEndpoint             = "/projects/{projectId}/applications/{applicationId}/test-records"
+	SupportRequestEndpoint                   = "/support"
+	UserAccountDetailEndpoint                = "/users"
 )
 
 const (
 	invalidRequestBodyError = "invalid request body"
 	invalidIDError          = "invalid id"
+	invalidRateLimitError   = "rate limits must not be negative"
 )
 
 const (
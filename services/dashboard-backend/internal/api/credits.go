@@ -0,0 +1,234 @@
+package api
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/dto"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/middleware"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/repositories"
+	"github.com/basemind-ai/monorepo/shared/go/apierror"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/payments"
+	"github.com/basemind-ai/monorepo/shared/go/serialization"
+	"github.com/basemind-ai/monorepo/shared/go/timeutils"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultCreditLedgerPageSize = 50
+	maxCreditLedgerPageSize     = 500
+	creditStatementMonthLayout  = "2006-01"
+	creditStatementFormatCSV    = "csv"
+	creditStatementFormatJSON   = "json"
+)
+
+// handleRetrieveCreditReconciliation - compares the credits of the project with the given ID with its ledger,
+// without changing them.
+func handleRetrieveCreditReconciliation(w http.ResponseWriter, r *http.Request) {
+	renderCreditReconciliation(w, r, false)
+}
+
+// handleApplyCreditReconciliation - debits the unrecorded debits of the project with the given ID,
+// and records the remaining drift of its credits as a ledger adjustment. Only the platform admins can apply it.
+func handleApplyCreditReconciliation(w http.ResponseWriter, r *http.Request) {
+	renderCreditReconciliation(w, r, true)
+}
+
+// renderCreditReconciliation - renders the credit reconciliation of a project, applying it if apply is true.
+func renderCreditReconciliation(w http.ResponseWriter, r *http.Request, apply bool) {
+	projectID := r.Context().Value(middleware.ProjectIDContextKey).(pgtype.UUID)
+
+	reconciliation, reconciliationErr := repositories.ReconcileProjectCredits(
+		r.Context(),
+		projectID,
+		apply,
+	)
+	if reconciliationErr != nil {
+		log.Error().Err(reconciliationErr).Msg("failed to reconcile project credits")
+		apierror.InternalServerError().Render(w)
+		return
+	}
+
+	serialization.RenderJSONResponse(w, http.StatusOK, reconciliation)
+}
+
+// handleGrantCredits - charges a credit top-up with the payment provider, and grants the credits to the project
+// with the given ID. The reason of the grant is recorded in the ledger.
+// Only the platform admins can grant credits when the payment provider is the ManualProvider.
+func handleGrantCredits(w http.ResponseWriter, r *http.Request) {
+	userAccount := r.Context().Value(middleware.UserAccountContextKey).(*models.UserAccount)
+	projectID := r.Context().Value(middleware.ProjectIDContextKey).(pgtype.UUID)
+
+	// the manual provider grants credits without a payment, self-service top-ups require a payment provider
+	if _, isManualProvider := payments.GetProvider().(payments.ManualProvider); isManualProvider &&
+		!middleware.IsPlatformAdmin(r.Context(), userAccount) {
+		apierror.Forbidden("only platform admins can grant credits without a payment").Render(w)
+		return
+	}
+
+	creditGrantDTO := dto.CreditGrantDTO{}
+	if deserializationErr := serialization.DeserializeJSON(r.Body, &creditGrantDTO); deserializationErr != nil {
+		log.Error().Err(deserializationErr).Msg("failed to deserialize request body")
+		apierror.BadRequest(invalidRequestBodyError).Render(w)
+		return
+	}
+
+	if validateErr := validate.Struct(&creditGrantDTO); validateErr != nil {
+		apierror.BadRequest(validateErr.Error()).Render(w)
+		return
+	}
+
+	entry, grantErr := repositories.GrantCredits(r.Context(), projectID, userAccount, creditGrantDTO)
+	if grantErr != nil {
+		apiErr := apierror.InternalServerError()
+
+		if errors.Is(grantErr, payments.ErrPaymentDeclined) {
+			apiErr = apierror.New(http.StatusPaymentRequired, grantErr.Error())
+		} else if errors.Is(grantErr, repositories.ErrInvalidCreditGrant) {
+			apiErr = apierror.BadRequest(grantErr.Error())
+		}
+
+		log.Error().Err(grantErr).Msg("failed to grant credits")
+		apiErr.Render(w)
+		return
+	}
+
+	serialization.RenderJSONResponse(w, http.StatusCreated, entry)
+}
+
+// handleRetrieveCreditLedger - retrieves a page of the credit ledger of the project with the given ID, latest first.
+// The ledger can be filtered with the fromDate and toDate query parameters,
+// and paged through with the limit and offset query parameters.
+func handleRetrieveCreditLedger(w http.ResponseWriter, r *http.Request) {
+	projectID := r.Context().Value(middleware.ProjectIDContextKey).(pgtype.UUID)
+
+	toDate := timeutils.ParseDate(r.URL.Query().Get("toDate"), time.Now())
+	fromDate := timeutils.ParseDate(r.URL.Query().Get("fromDate"), time.Time{})
+
+	limit, isValidLimit := parsePaginationParam(r.URL.Query().Get("limit"), defaultCreditLedgerPageSize)
+	offset, isValidOffset := parsePaginationParam(r.URL.Query().Get("offset"), 0)
+
+	if !isValidLimit || !isValidOffset || limit == 0 || limit > maxCreditLedgerPageSize {
+		apierror.BadRequest("invalid pagination parameters").Render(w)
+		return
+	}
+
+	serialization.RenderJSONResponse(
+		w,
+		http.StatusOK,
+		repositories.GetCreditLedger(r.Context(), projectID, fromDate, toDate, limit, offset),
+	)
+}
+
+// handleRetrieveCreditConsumption - retrieves the credits consumed per day by the project with the given ID,
+// between the fromDate and toDate query parameters - by default in the current month.
+func handleRetrieveCreditConsumption(w http.ResponseWriter, r *http.Request) {
+	projectID := r.Context().Value(middleware.ProjectIDContextKey).(pgtype.UUID)
+
+	toDate := timeutils.ParseDate(r.URL.Query().Get("toDate"), time.Now())
+	fromDate := timeutils.ParseDate(r.URL.Query().Get("fromDate"), timeutils.GetFirstDayOfMonth())
+
+	serialization.RenderJSONResponse(
+		w,
+		http.StatusOK,
+		repositories.GetCreditConsumption(r.Context(), projectID, fromDate, toDate),
+	)
+}
+
+// handleRetrieveCreditStatement - retrieves the monthly credit statement of the project with the given ID.
+// The month is set with the month query parameter, e.g. 2024-04, and defaults to the current month.
+// The statement is rendered as JSON, or as a CSV attachment if the format query parameter is csv.
+func handleRetrieveCreditStatement(w http.ResponseWriter, r *http.Request) {
+	projectID := r.Context().Value(middleware.ProjectIDContextKey).(pgtype.UUID)
+
+	currentYear, currentMonth, _ := time.Now().UTC().Date()
+	periodStart := time.Date(currentYear, currentMonth, 1, 0, 0, 0, 0, time.UTC)
+	if month := r.URL.Query().Get("month"); month != "" {
+		parsedMonth, parseErr := time.Parse(creditStatementMonthLayout, month)
+		if parseErr != nil {
+			apierror.BadRequest("invalid month - expected the format YYYY-MM").Render(w)
+			return
+		}
+
+		periodStart = parsedMonth
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = creditStatementFormatJSON
+	}
+
+	if format != creditStatementFormatJSON && format != creditStatementFormatCSV {
+		apierror.BadRequest("invalid format - expected csv or json").Render(w)
+		return
+	}
+
+	statement := repositories.GetCreditStatement(r.Context(), projectID, periodStart)
+
+	if format == creditStatementFormatJSON {
+		serialization.RenderJSONResponse(w, http.StatusOK, statement)
+		return
+	}
+
+	renderCreditStatementCSV(w, statement)
+}
+
+// renderCreditStatementCSV - renders a credit statement as a CSV attachment.
+// The opening and closing balances are the first and last rows of the statement.
+func renderCreditStatementCSV(w http.ResponseWriter, statement dto.CreditStatementDTO) {
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set(
+		"Content-Disposition",
+		fmt.Sprintf("attachment; filename=\"credit-statement-%s.csv\"", statement.Month),
+	)
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+
+	rows := [][]string{
+		{"date", "type", "description", "payment_reference", "requests", "amount"},
+		{
+			statement.PeriodStart.Format(time.DateOnly),
+			"OPENING_BALANCE",
+			"",
+			"",
+			"",
+			statement.OpeningBalance.String(),
+		},
+	}
+
+	for _, entry := range statement.Entries {
+		requests := ""
+		if entry.EntryType == string(models.CreditLedgerEntryTypeDEBIT) {
+			requests = strconv.FormatInt(entry.EntriesCount, 10)
+		}
+
+		rows = append(rows, []string{
+			entry.CreatedAt.UTC().Format(time.DateOnly),
+			entry.EntryType,
+			entry.Description,
+			entry.PaymentReference,
+			requests,
+			entry.Amount.String(),
+		})
+	}
+
+	rows = append(rows, []string{
+		statement.PeriodEnd.AddDate(0, 0, -1).Format(time.DateOnly),
+		"CLOSING_BALANCE",
+		"",
+		"",
+		"",
+		statement.ClosingBalance.String(),
+	})
+
+	if writeErr := writer.WriteAll(rows); writeErr != nil {
+		log.Error().Err(writeErr).Msg("failed to write credit statement")
+	}
+}
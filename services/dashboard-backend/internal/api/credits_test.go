@@ -0,0 +1,331 @@
+package api_test
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/basemind-ai/monorepo/e2e/factories"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/api"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/dto"
+	"github.com/basemind-ai/monorepo/shared/go/config"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/payments"
+	"github.com/basemind-ai/monorepo/shared/go/serialization"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+type decliningPaymentProvider struct{}
+
+func (decliningPaymentProvider) Charge(_ context.Context, _ payments.TopUp) (string, error) {
+	return "", payments.ErrPaymentDeclined
+}
+
+type chargingPaymentProvider struct{}
+
+func (chargingPaymentProvider) Charge(_ context.Context, _ payments.TopUp) (string, error) {
+	return "payment-reference", nil
+}
+
+func TestCreditsAPI(t *testing.T) { //nolint: revive
+	userAccount, _ := factories.CreateUserAccount(context.TODO())
+	projectID := createProject(t)
+	createUserProject(t, userAccount.FirebaseID, projectID, models.AccessPermissionTypeADMIN)
+
+	testClient := createTestClient(t, userAccount)
+
+	platformAdminEmails := config.Get(context.TODO()).PlatformAdminEmails
+	config.Get(context.TODO()).PlatformAdminEmails = []string{userAccount.Email}
+	t.Cleanup(func() {
+		config.Get(context.TODO()).PlatformAdminEmails = platformAdminEmails
+	})
+
+	adminAccount, _ := factories.CreateUserAccount(context.TODO())
+	createUserProject(t, adminAccount.FirebaseID, projectID, models.AccessPermissionTypeADMIN)
+
+	adminClient := createTestClient(t, adminAccount)
+
+	memberAccount, _ := factories.CreateUserAccount(context.TODO())
+	createUserProject(t, memberAccount.FirebaseID, projectID, models.AccessPermissionTypeMEMBER)
+
+	memberClient := createTestClient(t, memberAccount)
+
+	endpoint := fmt.Sprintf(
+		"/v1%s",
+		strings.ReplaceAll(api.ProjectCreditReconciliationEndpoint, "{projectId}", projectID),
+	)
+
+	retrieveReconciliation := func(t *testing.T) dto.CreditReconciliationDTO {
+		t.Helper()
+
+		response, requestErr := testClient.Get(context.TODO(), endpoint)
+		assert.NoError(t, requestErr)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		reconciliation := dto.CreditReconciliationDTO{}
+		assert.NoError(t, serialization.DeserializeJSON(response.Body, &reconciliation))
+
+		return reconciliation
+	}
+
+	t.Run(fmt.Sprintf("GET: %s", api.ProjectCreditReconciliationEndpoint), func(t *testing.T) {
+		t.Run("compares the project credits with the ledger", func(t *testing.T) {
+			reconciliation := retrieveReconciliation(t)
+			assert.False(t, reconciliation.IsApplied)
+			assert.Equal(t, "1", reconciliation.Credits.String())
+			assert.Equal(t, "1", reconciliation.Drift.String())
+		})
+
+		t.Run("responds with status 401 UNAUTHORIZED if the user does not have ADMIN permission", func(t *testing.T) {
+			response, requestErr := memberClient.Get(context.TODO(), endpoint)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+		})
+	})
+
+	t.Run(fmt.Sprintf("POST: %s", api.ProjectCreditReconciliationEndpoint), func(t *testing.T) {
+		t.Run("records the drift as a ledger adjustment", func(t *testing.T) {
+			response, requestErr := testClient.Post(context.TODO(), endpoint, nil)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+
+			reconciliation := dto.CreditReconciliationDTO{}
+			assert.NoError(t, serialization.DeserializeJSON(response.Body, &reconciliation))
+			assert.True(t, reconciliation.IsApplied)
+
+			assert.True(t, retrieveReconciliation(t).Drift.IsZero())
+		})
+
+		t.Run("responds with status 401 UNAUTHORIZED if the user does not have ADMIN permission", func(t *testing.T) {
+			response, requestErr := memberClient.Post(context.TODO(), endpoint, nil)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+		})
+
+		t.Run("responds with status 403 FORBIDDEN if the user is not a platform admin", func(t *testing.T) {
+			response, requestErr := adminClient.Post(context.TODO(), endpoint, nil)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusForbidden, response.StatusCode)
+		})
+	})
+
+	grantsProjectID := createProject(t)
+	createUserProject(t, userAccount.FirebaseID, grantsProjectID, models.AccessPermissionTypeADMIN)
+	createUserProject(t, adminAccount.FirebaseID, grantsProjectID, models.AccessPermissionTypeADMIN)
+	createUserProject(t, memberAccount.FirebaseID, grantsProjectID, models.AccessPermissionTypeMEMBER)
+
+	fmtEndpoint := func(endpoint string) string {
+		return fmt.Sprintf("/v1%s", strings.ReplaceAll(endpoint, "{projectId}", grantsProjectID))
+	}
+
+	grantCredits := func(t *testing.T, amount int64, reason string) dto.CreditLedgerEntryDTO {
+		t.Helper()
+
+		response, requestErr := testClient.Post(
+			context.TODO(),
+			fmtEndpoint(api.ProjectCreditGrantsEndpoint),
+			dto.CreditGrantDTO{Amount: decimal.NewFromInt(amount), Reason: reason},
+		)
+		assert.NoError(t, requestErr)
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+		entry := dto.CreditLedgerEntryDTO{}
+		assert.NoError(t, serialization.DeserializeJSON(response.Body, &entry))
+
+		return entry
+	}
+
+	t.Run(fmt.Sprintf("POST: %s", api.ProjectCreditGrantsEndpoint), func(t *testing.T) {
+		t.Run("grants credits to the project", func(t *testing.T) {
+			entry := grantCredits(t, 10, "welcome credits")
+			assert.NotNil(t, entry.ID)
+			assert.Equal(t, string(models.CreditLedgerEntryTypeGRANT), entry.EntryType)
+			assert.Equal(t, "10", entry.Amount.String())
+			assert.Equal(t, "welcome credits", entry.Description)
+			assert.Equal(t, userAccount.DisplayName, entry.CreatedBy)
+		})
+
+		t.Run("responds with status 400 BAD REQUEST for an invalid grant", func(t *testing.T) {
+			for _, data := range []dto.CreditGrantDTO{
+				{Amount: decimal.NewFromInt(10)},
+				{Amount: decimal.Zero, Reason: "top-up"},
+				{Amount: decimal.NewFromInt(-10), Reason: "top-up"},
+			} {
+				response, requestErr := testClient.Post(
+					context.TODO(),
+					fmtEndpoint(api.ProjectCreditGrantsEndpoint),
+					data,
+				)
+				assert.NoError(t, requestErr)
+				assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+			}
+		})
+
+		t.Run("responds with status 402 PAYMENT REQUIRED if the payment is declined", func(t *testing.T) {
+			payments.SetProvider(decliningPaymentProvider{})
+			t.Cleanup(func() {
+				payments.SetProvider(payments.ManualProvider{})
+			})
+
+			response, requestErr := testClient.Post(
+				context.TODO(),
+				fmtEndpoint(api.ProjectCreditGrantsEndpoint),
+				dto.CreditGrantDTO{Amount: decimal.NewFromInt(10), Reason: "top-up"},
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusPaymentRequired, response.StatusCode)
+		})
+
+		t.Run("grants credits charged by the payment provider to the project admins", func(t *testing.T) {
+			payments.SetProvider(chargingPaymentProvider{})
+			t.Cleanup(func() {
+				payments.SetProvider(payments.ManualProvider{})
+			})
+
+			response, requestErr := adminClient.Post(
+				context.TODO(),
+				fmtEndpoint(api.ProjectCreditGrantsEndpoint),
+				dto.CreditGrantDTO{Amount: decimal.NewFromInt(10), Reason: "top-up"},
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+			entry := dto.CreditLedgerEntryDTO{}
+			assert.NoError(t, serialization.DeserializeJSON(response.Body, &entry))
+			assert.Equal(t, "payment-reference", entry.PaymentReference)
+			assert.Equal(t, adminAccount.DisplayName, entry.CreatedBy)
+		})
+
+		t.Run("responds with status 403 FORBIDDEN if the user is not a platform admin and the payment provider is manual", func(t *testing.T) {
+			response, requestErr := adminClient.Post(
+				context.TODO(),
+				fmtEndpoint(api.ProjectCreditGrantsEndpoint),
+				dto.CreditGrantDTO{Amount: decimal.NewFromInt(10), Reason: "top-up"},
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusForbidden, response.StatusCode)
+		})
+
+		t.Run("responds with status 401 UNAUTHORIZED if the user does not have ADMIN permission", func(t *testing.T) {
+			response, requestErr := memberClient.Post(
+				context.TODO(),
+				fmtEndpoint(api.ProjectCreditGrantsEndpoint),
+				dto.CreditGrantDTO{Amount: decimal.NewFromInt(10), Reason: "top-up"},
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+		})
+	})
+
+	t.Run(fmt.Sprintf("GET: %s", api.ProjectCreditLedgerEndpoint), func(t *testing.T) {
+		t.Run("retrieves a page of the ledger, latest first", func(t *testing.T) {
+			first := grantCredits(t, 1, "first")
+			second := grantCredits(t, 2, "second")
+
+			response, requestErr := memberClient.Get(
+				context.TODO(),
+				fmtEndpoint(api.ProjectCreditLedgerEndpoint)+"?limit=1",
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+
+			page := dto.CreditLedgerPageDTO{}
+			assert.NoError(t, serialization.DeserializeJSON(response.Body, &page))
+			assert.Len(t, page.Entries, 1)
+			assert.Equal(t, *second.ID, *page.Entries[0].ID)
+			assert.NotNil(t, page.NextOffset)
+
+			response, requestErr = memberClient.Get(
+				context.TODO(),
+				fmtEndpoint(api.ProjectCreditLedgerEndpoint)+"?limit=1&offset=1",
+			)
+			assert.NoError(t, requestErr)
+
+			page = dto.CreditLedgerPageDTO{}
+			assert.NoError(t, serialization.DeserializeJSON(response.Body, &page))
+			assert.Equal(t, *first.ID, *page.Entries[0].ID)
+		})
+
+		t.Run("responds with status 400 BAD REQUEST for invalid pagination parameters", func(t *testing.T) {
+			response, requestErr := memberClient.Get(
+				context.TODO(),
+				fmtEndpoint(api.ProjectCreditLedgerEndpoint)+"?limit=0",
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+		})
+	})
+
+	t.Run(fmt.Sprintf("GET: %s", api.ProjectCreditConsumptionEndpoint), func(t *testing.T) {
+		t.Run("retrieves the daily consumption of the project", func(t *testing.T) {
+			response, requestErr := memberClient.Get(
+				context.TODO(),
+				fmtEndpoint(api.ProjectCreditConsumptionEndpoint),
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+
+			consumption := make([]dto.CreditConsumptionDTO, 0)
+			assert.NoError(t, serialization.DeserializeJSON(response.Body, &consumption))
+			assert.Empty(t, consumption)
+		})
+	})
+
+	t.Run(fmt.Sprintf("GET: %s", api.ProjectCreditStatementEndpoint), func(t *testing.T) {
+		month := time.Now().UTC().Format("2006-01")
+
+		t.Run("retrieves the statement of the month as JSON", func(t *testing.T) {
+			response, requestErr := memberClient.Get(
+				context.TODO(),
+				fmtEndpoint(api.ProjectCreditStatementEndpoint)+"?month="+month,
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+
+			statement := dto.CreditStatementDTO{}
+			assert.NoError(t, serialization.DeserializeJSON(response.Body, &statement))
+			assert.Equal(t, month, statement.Month)
+			assert.NotEmpty(t, statement.Entries)
+			assert.True(t, statement.TotalGrants.IsPositive())
+			assert.True(
+				t,
+				statement.OpeningBalance.Add(statement.TotalGrants).
+					Add(statement.TotalAdjustments).
+					Sub(statement.TotalConsumption).
+					Equal(statement.ClosingBalance),
+			)
+		})
+
+		t.Run("retrieves the statement of the month as CSV", func(t *testing.T) {
+			response, requestErr := memberClient.Get(
+				context.TODO(),
+				fmtEndpoint(api.ProjectCreditStatementEndpoint)+"?format=csv",
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+			assert.Equal(t, "text/csv", response.Header.Get("Content-Type"))
+
+			rows, readErr := csv.NewReader(response.Body).ReadAll()
+			assert.NoError(t, readErr)
+			assert.Equal(t, "type", rows[0][1])
+			assert.Equal(t, "OPENING_BALANCE", rows[1][1])
+			assert.Equal(t, "CLOSING_BALANCE", rows[len(rows)-1][1])
+		})
+
+		t.Run("responds with status 400 BAD REQUEST for invalid parameters", func(t *testing.T) {
+			for _, query := range []string{"?month=04-2024", "?format=pdf"} {
+				response, requestErr := memberClient.Get(
+					context.TODO(),
+					fmtEndpoint(api.ProjectCreditStatementEndpoint)+query,
+				)
+				assert.NoError(t, requestErr)
+				assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+			}
+		})
+	})
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/dto"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/middleware"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/repositories"
+	"github.com/basemind-ai/monorepo/shared/go/apierror"
+	"github.com/basemind-ai/monorepo/shared/go/serialization"
+	"github.com/basemind-ai/monorepo/shared/go/timeutils"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultPayloadPageSize = 50
+	maxPayloadPageSize     = 100
+)
+
+// parsePaginationParam - parses a non-negative integer query parameter, returning the fallback if it is not set.
+func parsePaginationParam(value string, fallback int32) (int32, bool) {
+	if value == "" {
+		return fallback, true
+	}
+
+	parsed, parseErr := strconv.ParseInt(value, 10, 32)
+	if parseErr != nil || parsed < 0 {
+		return 0, false
+	}
+
+	return int32(parsed), true
+}
+
+// handleRetrievePayloadLogging - retrieves the payload logging settings of the application with the given ID.
+func handleRetrievePayloadLogging(w http.ResponseWriter, r *http.Request) {
+	applicationID := r.Context().Value(middleware.ApplicationIDContextKey).(pgtype.UUID)
+
+	payloadLogging, retrievalErr := repositories.GetPayloadLogging(r.Context(), applicationID)
+	if retrievalErr != nil {
+		log.Error().Err(retrievalErr).Msg("failed to retrieve payload logging")
+		apierror.BadRequest(invalidIDError).Render(w)
+		return
+	}
+
+	serialization.RenderJSONResponse(w, http.StatusOK, payloadLogging)
+}
+
+// handleUpdatePayloadLogging - updates the payload logging settings of the application with the given ID.
+func handleUpdatePayloadLogging(w http.ResponseWriter, r *http.Request) {
+	applicationID := r.Context().Value(middleware.ApplicationIDContextKey).(pgtype.UUID)
+
+	payloadLoggingDTO := dto.PayloadLoggingDTO{}
+	if deserializationErr := serialization.DeserializeJSON(r.Body, &payloadLoggingDTO); deserializationErr != nil {
+		log.Error().Err(deserializationErr).Msg("failed to deserialize request body")
+		apierror.BadRequest(invalidRequestBodyError).Render(w)
+		return
+	}
+
+	if validateErr := validate.Struct(&payloadLoggingDTO); validateErr != nil {
+		log.Error().Err(validateErr).Msg("invalid request")
+		apierror.BadRequest(invalidRequestBodyError).Render(w)
+		return
+	}
+
+	payloadLogging, updateErr := repositories.UpdatePayloadLogging(
+		r.Context(),
+		applicationID,
+		payloadLoggingDTO,
+	)
+	if updateErr != nil {
+		apiErr := apierror.InternalServerError()
+		if strings.Contains(updateErr.Error(), "invalid redaction rule") {
+			apiErr = apierror.BadRequest(updateErr.Error())
+		}
+
+		log.Error().Err(updateErr).Msg("failed to update payload logging")
+		apiErr.Render(w)
+		return
+	}
+
+	serialization.RenderJSONResponse(w, http.StatusOK, payloadLogging)
+}
+
+// handleRetrievePromptRequestPayloads - retrieves a page of the logged payloads of the application with the given ID.
+// The payloads can be filtered with the search, fromDate and toDate query parameters,
+// and paged through with the limit and offset query parameters.
+func handleRetrievePromptRequestPayloads(w http.ResponseWriter, r *http.Request) {
+	applicationID := r.Context().Value(middleware.ApplicationIDContextKey).(pgtype.UUID)
+
+	toDate := timeutils.ParseDate(r.URL.Query().Get("toDate"), time.Now())
+	fromDate := timeutils.ParseDate(r.URL.Query().Get("fromDate"), timeutils.GetFirstDayOfMonth())
+
+	limit, isValidLimit := parsePaginationParam(r.URL.Query().Get("limit"), defaultPayloadPageSize)
+	offset, isValidOffset := parsePaginationParam(r.URL.Query().Get("offset"), 0)
+
+	if !isValidLimit || !isValidOffset || limit == 0 || limit > maxPayloadPageSize {
+		apierror.BadRequest("invalid pagination parameters").Render(w)
+		return
+	}
+
+	serialization.RenderJSONResponse(
+		w,
+		http.StatusOK,
+		repositories.GetPromptRequestPayloads(
+			r.Context(),
+			applicationID,
+			strings.TrimSpace(r.URL.Query().Get("search")),
+			fromDate,
+			toDate,
+			limit,
+			offset,
+		),
+	)
+}
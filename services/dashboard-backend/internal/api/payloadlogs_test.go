@@ -0,0 +1,172 @@
+package api_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/basemind-ai/monorepo/e2e/factories"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/api"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/dto"
+	"github.com/basemind-ai/monorepo/shared/go/datatypes"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/basemind-ai/monorepo/shared/go/redaction"
+	"github.com/basemind-ai/monorepo/shared/go/serialization"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayloadLogAPI(t *testing.T) { //nolint: revive
+	userAccount, _ := factories.CreateUserAccount(context.TODO())
+	projectID := createProject(t)
+	createUserProject(t, userAccount.FirebaseID, projectID, models.AccessPermissionTypeADMIN)
+
+	testClient := createTestClient(t, userAccount)
+
+	fmtEndpoint := func(endpoint string, applicationID string) string {
+		return fmt.Sprintf("/v1%s", strings.NewReplacer(
+			"{projectId}", projectID,
+			"{applicationId}", applicationID,
+		).Replace(endpoint))
+	}
+
+	t.Run(fmt.Sprintf("GET: %s", api.ApplicationPayloadLoggingEndpoint), func(t *testing.T) {
+		t.Run("retrieves the default payload logging settings", func(t *testing.T) {
+			applicationID := createApplication(t, projectID)
+
+			response, requestErr := testClient.Get(
+				context.TODO(),
+				fmtEndpoint(api.ApplicationPayloadLoggingEndpoint, applicationID),
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+
+			payloadLogging := dto.PayloadLoggingDTO{}
+			deserializationErr := serialization.DeserializeJSON(response.Body, &payloadLogging)
+			assert.NoError(t, deserializationErr)
+			assert.False(t, payloadLogging.IsEnabled)
+			assert.Equal(t, int32(30), payloadLogging.RetentionDays)
+			assert.Empty(t, payloadLogging.RedactionRules)
+		})
+	})
+
+	t.Run(fmt.Sprintf("PATCH: %s", api.ApplicationPayloadLoggingEndpoint), func(t *testing.T) {
+		t.Run("updates the payload logging settings", func(t *testing.T) {
+			applicationID := createApplication(t, projectID)
+
+			payloadLogging := dto.PayloadLoggingDTO{
+				IsEnabled:     true,
+				RetentionDays: 14,
+				RedactionRules: []datatypes.PayloadRedactionRuleDTO{
+					{Name: "email", Preset: ptr.To(redaction.PresetEmail)},
+				},
+			}
+
+			response, requestErr := testClient.Patch(
+				context.TODO(),
+				fmtEndpoint(api.ApplicationPayloadLoggingEndpoint, applicationID),
+				payloadLogging,
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+
+			updatedPayloadLogging := dto.PayloadLoggingDTO{}
+			deserializationErr := serialization.DeserializeJSON(
+				response.Body,
+				&updatedPayloadLogging,
+			)
+			assert.NoError(t, deserializationErr)
+			assert.Equal(t, payloadLogging, updatedPayloadLogging)
+		})
+
+		t.Run("responds with status 400 BAD REQUEST for invalid settings", func(t *testing.T) {
+			applicationID := createApplication(t, projectID)
+
+			for _, payloadLogging := range []dto.PayloadLoggingDTO{
+				{IsEnabled: true, RetentionDays: 0},
+				{IsEnabled: true, RetentionDays: 366},
+				{
+					IsEnabled:     true,
+					RetentionDays: 7,
+					RedactionRules: []datatypes.PayloadRedactionRuleDTO{
+						{Name: "unknown", Preset: ptr.To("UNKNOWN")},
+					},
+				},
+				{
+					IsEnabled:     true,
+					RetentionDays: 7,
+					RedactionRules: []datatypes.PayloadRedactionRuleDTO{
+						{Name: "invalid", Pattern: ptr.To(`(\d+`)},
+					},
+				},
+				{
+					IsEnabled:      true,
+					RetentionDays:  7,
+					RedactionRules: []datatypes.PayloadRedactionRuleDTO{{Name: "empty"}},
+				},
+			} {
+				response, requestErr := testClient.Patch(
+					context.TODO(),
+					fmtEndpoint(api.ApplicationPayloadLoggingEndpoint, applicationID),
+					payloadLogging,
+				)
+				assert.NoError(t, requestErr)
+				assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+			}
+		})
+
+		t.Run("responds with status 401 UNAUTHORIZED if the user does not have ADMIN permission", func(t *testing.T) {
+			applicationID := createApplication(t, projectID)
+			newUserAccount, _ := factories.CreateUserAccount(context.TODO())
+			createUserProject(
+				t,
+				newUserAccount.FirebaseID,
+				projectID,
+				models.AccessPermissionTypeMEMBER,
+			)
+			client := createTestClient(t, newUserAccount)
+
+			response, requestErr := client.Patch(
+				context.TODO(),
+				fmtEndpoint(api.ApplicationPayloadLoggingEndpoint, applicationID),
+				dto.PayloadLoggingDTO{IsEnabled: true, RetentionDays: 7},
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+		})
+	})
+
+	t.Run(fmt.Sprintf("GET: %s", api.ApplicationPayloadLogsEndpoint), func(t *testing.T) {
+		t.Run("retrieves a page of the logged payloads", func(t *testing.T) {
+			applicationID := createApplication(t, projectID)
+
+			response, requestErr := testClient.Get(
+				context.TODO(),
+				fmtEndpoint(api.ApplicationPayloadLogsEndpoint, applicationID)+"?search=cheese&limit=10",
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+
+			page := dto.PromptRequestPayloadPageDTO{}
+			deserializationErr := serialization.DeserializeJSON(response.Body, &page)
+			assert.NoError(t, deserializationErr)
+			assert.Empty(t, page.Payloads)
+			assert.Nil(t, page.NextOffset)
+		})
+
+		t.Run("responds with status 400 BAD REQUEST for invalid pagination parameters", func(t *testing.T) {
+			applicationID := createApplication(t, projectID)
+
+			for _, query := range []string{"?limit=0", "?limit=101", "?limit=abc", "?offset=-1"} {
+				response, requestErr := testClient.Get(
+					context.TODO(),
+					fmtEndpoint(api.ApplicationPayloadLogsEndpoint, applicationID)+query,
+				)
+				assert.NoError(t, requestErr)
+				assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+			}
+		})
+	})
+}
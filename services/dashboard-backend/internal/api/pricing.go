@@ -0,0 +1,131 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/dto"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/middleware"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/repositories"
+	"github.com/basemind-ai/monorepo/shared/go/apierror"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/serialization"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+)
+
+// renderPricingError - renders the API error of a failed provider model pricing change.
+func renderPricingError(w http.ResponseWriter, err error) {
+	apiErr := apierror.InternalServerError()
+
+	if errors.Is(err, repositories.ErrProviderModelPricingNotFound) {
+		apiErr = apierror.NotFound(err.Error())
+	} else if strings.Contains(err.Error(), "invalid pricing") {
+		apiErr = apierror.BadRequest(err.Error())
+	}
+
+	log.Error().Err(err).Msg("failed to change provider model pricing")
+	apiErr.Render(w)
+}
+
+// handleRetrieveProviderModelPricings - retrieves the past, active and scheduled pricing of all provider models.
+// If the projectId query parameter is set, only the pricing overrides of that project are retrieved.
+func handleRetrieveProviderModelPricings(w http.ResponseWriter, r *http.Request) {
+	projectID := pgtype.UUID{}
+
+	if projectIDParam := r.URL.Query().Get("projectId"); projectIDParam != "" {
+		parsedID, uuidErr := db.StringToUUID(projectIDParam)
+		if uuidErr != nil {
+			apierror.BadRequest(invalidIDError).Render(w)
+			return
+		}
+
+		projectID = *parsedID
+	}
+
+	serialization.RenderJSONResponse(
+		w,
+		http.StatusOK,
+		repositories.GetProviderModelPricings(r.Context(), projectID),
+	)
+}
+
+// handleCreateProviderModelPricing - schedules the pricing of a provider model.
+// A pricing with a project ID is a negotiated override for the requests of that project.
+func handleCreateProviderModelPricing(w http.ResponseWriter, r *http.Request) {
+	pricingDTO := dto.ProviderModelPricingDTO{}
+	if deserializationErr := serialization.DeserializeJSON(r.Body, &pricingDTO); deserializationErr != nil {
+		log.Error().Err(deserializationErr).Msg("failed to deserialize request body")
+		apierror.BadRequest(invalidRequestBodyError).Render(w)
+		return
+	}
+
+	if validateErr := validate.Struct(&pricingDTO); validateErr != nil {
+		log.Error().Err(validateErr).Msg("invalid request")
+		apierror.BadRequest(invalidRequestBodyError).Render(w)
+		return
+	}
+
+	pricing, createErr := repositories.CreateProviderModelPricing(r.Context(), pricingDTO)
+	if createErr != nil {
+		renderPricingError(w, createErr)
+		return
+	}
+
+	serialization.RenderJSONResponse(w, http.StatusCreated, pricing)
+}
+
+// handleUpdateProviderModelPricing - ends or extends the provider model pricing with the given ID.
+func handleUpdateProviderModelPricing(w http.ResponseWriter, r *http.Request) {
+	pricingID := r.Context().Value(middleware.ProviderModelPricingIDKey).(pgtype.UUID)
+
+	updateDTO := dto.ProviderModelPricingUpdateDTO{}
+	if deserializationErr := serialization.DeserializeJSON(r.Body, &updateDTO); deserializationErr != nil {
+		log.Error().Err(deserializationErr).Msg("failed to deserialize request body")
+		apierror.BadRequest(invalidRequestBodyError).Render(w)
+		return
+	}
+
+	if validateErr := validate.Struct(&updateDTO); validateErr != nil {
+		log.Error().Err(validateErr).Msg("invalid request")
+		apierror.BadRequest(invalidRequestBodyError).Render(w)
+		return
+	}
+
+	pricing, updateErr := repositories.UpdateProviderModelPricingActiveToDate(
+		r.Context(),
+		pricingID,
+		updateDTO,
+	)
+	if updateErr != nil {
+		renderPricingError(w, updateErr)
+		return
+	}
+
+	serialization.RenderJSONResponse(w, http.StatusOK, pricing)
+}
+
+// handleDeleteProviderModelPricing - deletes the scheduled provider model pricing with the given ID.
+func handleDeleteProviderModelPricing(w http.ResponseWriter, r *http.Request) {
+	pricingID := r.Context().Value(middleware.ProviderModelPricingIDKey).(pgtype.UUID)
+
+	if deleteErr := repositories.DeleteProviderModelPricing(r.Context(), pricingID); deleteErr != nil {
+		renderPricingError(w, deleteErr)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleRetrieveProjectPricing - retrieves the pricing charged today for the requests of the project
+// with the given ID, including its negotiated overrides.
+func handleRetrieveProjectPricing(w http.ResponseWriter, r *http.Request) {
+	projectID := r.Context().Value(middleware.ProjectIDContextKey).(pgtype.UUID)
+
+	serialization.RenderJSONResponse(
+		w,
+		http.StatusOK,
+		repositories.GetProjectProviderModelPricings(r.Context(), projectID),
+	)
+}
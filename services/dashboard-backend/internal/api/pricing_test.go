@@ -0,0 +1,298 @@
+package api_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/basemind-ai/monorepo/e2e/factories"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/api"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/dto"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/basemind-ai/monorepo/shared/go/serialization"
+	"github.com/basemind-ai/monorepo/shared/go/testutils"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPricingAPI(t *testing.T) { //nolint: revive
+	testutils.SetTestEnv(t)
+
+	platformAdminAccount, _ := db.GetQueries().
+		CreateUserAccount(context.TODO(), models.CreateUserAccountParams{
+			DisplayName: "Platform Admin",
+			Email:       testutils.PlatformAdminEmail,
+			PhotoUrl:    "https://basemind.ai",
+			PhoneNumber: "1234567890",
+			FirebaseID:  factories.RandomString(10),
+		})
+	testClient := createTestClient(t, &platformAdminAccount)
+
+	userAccount, _ := factories.CreateUserAccount(context.TODO())
+	userClient := createTestClient(t, userAccount)
+
+	projectID := createProject(t)
+	createUserProject(t, userAccount.FirebaseID, projectID, models.AccessPermissionTypeMEMBER)
+
+	listURL := fmt.Sprintf("/v1%s", api.ProviderModelPricingListEndpoint)
+	detailURL := func(pricingID string) string {
+		return fmt.Sprintf(
+			"/v1%s",
+			strings.ReplaceAll(
+				api.ProviderModelPricingDetailEndpoint,
+				"{providerModelPricingId}",
+				pricingID,
+			),
+		)
+	}
+	formatDate := func(daysFromToday int) string {
+		return time.Now().UTC().AddDate(0, 0, daysFromToday).Format("2006-01-02")
+	}
+
+	createPricing := func(t *testing.T, data dto.ProviderModelPricingDTO) *http.Response {
+		t.Helper()
+
+		response, requestErr := testClient.Post(context.TODO(), listURL, data)
+		assert.NoError(t, requestErr)
+
+		return response
+	}
+
+	newPricing := func(fromDate string) dto.ProviderModelPricingDTO {
+		return dto.ProviderModelPricingDTO{
+			ModelType:        models.ModelTypeGpt4,
+			ModelVendor:      models.ModelVendorOPENAI,
+			InputTokenPrice:  decimal.RequireFromString("0.02"),
+			OutputTokenPrice: decimal.RequireFromString("0.04"),
+			TokenUnitSize:    1_000,
+			ActiveFromDate:   fromDate,
+		}
+	}
+
+	t.Run(fmt.Sprintf("POST: %s", api.ProviderModelPricingListEndpoint), func(t *testing.T) {
+		t.Run("creates a pricing override for a project", func(t *testing.T) {
+			data := newPricing(formatDate(0))
+			data.ProjectID = &projectID
+			data.CachedInputTokenPrice = ptr.To(decimal.RequireFromString("0.01"))
+
+			response := createPricing(t, data)
+			assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+			pricing := dto.ProviderModelPricingDTO{}
+			assert.NoError(t, serialization.DeserializeJSON(response.Body, &pricing))
+			assert.NotEmpty(t, pricing.ID)
+			assert.Equal(t, projectID, *pricing.ProjectID)
+			assert.Equal(t, "0.02", pricing.InputTokenPrice.String())
+			assert.Equal(t, "0.01", pricing.CachedInputTokenPrice.String())
+			assert.Nil(t, pricing.ReasoningTokenPrice)
+			assert.Nil(t, pricing.ActiveToDate)
+		})
+
+		t.Run("responds with status 400 BAD REQUEST if the range overlaps another pricing", func(t *testing.T) {
+			data := newPricing(formatDate(10))
+			data.ProjectID = &projectID
+
+			response := createPricing(t, data)
+			assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+			response = createPricing(t, newPricing(formatDate(10)))
+			assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+		})
+
+		t.Run("responds with status 400 BAD REQUEST for an invalid pricing", func(t *testing.T) {
+			negativePrice := newPricing(formatDate(30))
+			negativePrice.InputTokenPrice = decimal.NewFromInt(-1)
+
+			negativeClassPrice := newPricing(formatDate(30))
+			negativeClassPrice.ReasoningTokenPrice = ptr.To(decimal.NewFromInt(-1))
+
+			pastFromDate := newPricing(formatDate(-1))
+
+			toBeforeFrom := newPricing(formatDate(30))
+			toDate := formatDate(20)
+			toBeforeFrom.ActiveToDate = &toDate
+
+			malformedDate := newPricing("01-01-2024")
+
+			missingProject := newPricing(formatDate(30))
+			missingProjectID := "3c7e8b9a-7f4e-4e5b-9c1d-2a3b4c5d6e7f"
+			missingProject.ProjectID = &missingProjectID
+
+			for _, data := range []dto.ProviderModelPricingDTO{
+				negativePrice, negativeClassPrice, pastFromDate, toBeforeFrom, malformedDate, missingProject,
+			} {
+				response := createPricing(t, data)
+				assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+			}
+		})
+
+		t.Run("responds with status 403 FORBIDDEN if the user is not a platform admin", func(t *testing.T) {
+			response, requestErr := userClient.Post(context.TODO(), listURL, newPricing(formatDate(30)))
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusForbidden, response.StatusCode)
+		})
+	})
+
+	t.Run(fmt.Sprintf("GET: %s", api.ProviderModelPricingListEndpoint), func(t *testing.T) {
+		t.Run("retrieves the pricing of all provider models", func(t *testing.T) {
+			response, requestErr := testClient.Get(context.TODO(), listURL)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+
+			pricings := make([]dto.ProviderModelPricingDTO, 0)
+			assert.NoError(t, serialization.DeserializeJSON(response.Body, &pricings))
+			assert.NotEmpty(t, pricings)
+		})
+
+		t.Run("retrieves the pricing overrides of a project", func(t *testing.T) {
+			response, requestErr := testClient.Get(context.TODO(), listURL+"?projectId="+projectID)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+
+			pricings := make([]dto.ProviderModelPricingDTO, 0)
+			assert.NoError(t, serialization.DeserializeJSON(response.Body, &pricings))
+			assert.Len(t, pricings, 1)
+			assert.Equal(t, projectID, *pricings[0].ProjectID)
+		})
+
+		t.Run("responds with status 400 BAD REQUEST for an invalid project ID", func(t *testing.T) {
+			response, requestErr := testClient.Get(context.TODO(), listURL+"?projectId=invalid")
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+		})
+
+		t.Run("responds with status 403 FORBIDDEN if the user is not a platform admin", func(t *testing.T) {
+			response, requestErr := userClient.Get(context.TODO(), listURL)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusForbidden, response.StatusCode)
+		})
+	})
+
+	t.Run(fmt.Sprintf("PATCH: %s", api.ProviderModelPricingDetailEndpoint), func(t *testing.T) {
+		data := newPricing(formatDate(5))
+		data.ModelType = models.ModelTypeGpt432k
+		data.ProjectID = &projectID
+
+		response := createPricing(t, data)
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+		pricing := dto.ProviderModelPricingDTO{}
+		assert.NoError(t, serialization.DeserializeJSON(response.Body, &pricing))
+
+		t.Run("ends the pricing at the active to date", func(t *testing.T) {
+			toDate := formatDate(9)
+
+			response, requestErr := testClient.Patch(
+				context.TODO(),
+				detailURL(pricing.ID),
+				dto.ProviderModelPricingUpdateDTO{ActiveToDate: &toDate},
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+
+			updated := dto.ProviderModelPricingDTO{}
+			assert.NoError(t, serialization.DeserializeJSON(response.Body, &updated))
+			assert.Equal(t, toDate, *updated.ActiveToDate)
+
+			next := newPricing(formatDate(10))
+			next.ModelType = models.ModelTypeGpt432k
+			next.ProjectID = &projectID
+			assert.Equal(t, http.StatusCreated, createPricing(t, next).StatusCode)
+		})
+
+		t.Run("responds with status 400 BAD REQUEST if the range overlaps another pricing", func(t *testing.T) {
+			response, requestErr := testClient.Patch(
+				context.TODO(),
+				detailURL(pricing.ID),
+				dto.ProviderModelPricingUpdateDTO{},
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+		})
+
+		t.Run("responds with status 404 NOT FOUND if the pricing does not exist", func(t *testing.T) {
+			toDate := formatDate(9)
+
+			response, requestErr := testClient.Patch(
+				context.TODO(),
+				detailURL("3c7e8b9a-7f4e-4e5b-9c1d-2a3b4c5d6e7f"),
+				dto.ProviderModelPricingUpdateDTO{ActiveToDate: &toDate},
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusNotFound, response.StatusCode)
+		})
+	})
+
+	t.Run(fmt.Sprintf("DELETE: %s", api.ProviderModelPricingDetailEndpoint), func(t *testing.T) {
+		t.Run("deletes a scheduled pricing", func(t *testing.T) {
+			data := newPricing(formatDate(5))
+			data.ModelType = models.ModelTypeGpt35Turbo
+			data.ProjectID = &projectID
+
+			response := createPricing(t, data)
+			assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+			pricing := dto.ProviderModelPricingDTO{}
+			assert.NoError(t, serialization.DeserializeJSON(response.Body, &pricing))
+
+			response, requestErr := testClient.Delete(context.TODO(), detailURL(pricing.ID))
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusNoContent, response.StatusCode)
+		})
+
+		t.Run("responds with status 400 BAD REQUEST if the pricing is active", func(t *testing.T) {
+			data := newPricing(formatDate(0))
+			data.ModelType = models.ModelTypeGpt35Turbo16k
+			data.ProjectID = &projectID
+
+			response := createPricing(t, data)
+			assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+			pricing := dto.ProviderModelPricingDTO{}
+			assert.NoError(t, serialization.DeserializeJSON(response.Body, &pricing))
+
+			response, requestErr := testClient.Delete(context.TODO(), detailURL(pricing.ID))
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+		})
+
+		t.Run("responds with status 403 FORBIDDEN if the user is not a platform admin", func(t *testing.T) {
+			response, requestErr := userClient.Delete(
+				context.TODO(),
+				detailURL("3c7e8b9a-7f4e-4e5b-9c1d-2a3b4c5d6e7f"),
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusForbidden, response.StatusCode)
+		})
+	})
+
+	t.Run(fmt.Sprintf("GET: %s", api.ProjectPricingEndpoint), func(t *testing.T) {
+		t.Run("retrieves the pricing charged for the requests of the project", func(t *testing.T) {
+			response, requestErr := userClient.Get(
+				context.TODO(),
+				fmt.Sprintf(
+					"/v1%s",
+					strings.ReplaceAll(api.ProjectPricingEndpoint, "{projectId}", projectID),
+				),
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+
+			pricings := make([]dto.ProviderModelPricingDTO, 0)
+			assert.NoError(t, serialization.DeserializeJSON(response.Body, &pricings))
+
+			for _, pricing := range pricings {
+				if pricing.ModelType == models.ModelTypeGpt4 {
+					assert.Equal(t, projectID, *pricing.ProjectID)
+				} else if pricing.ModelType == models.ModelTypeCommand {
+					assert.Nil(t, pricing.ProjectID)
+				}
+			}
+		})
+	})
+}
@@ -131,13 +131,20 @@ func handleDeleteProject(w http.ResponseWriter, r *http.Request) {
 }
 
 // handleRetrieveProjectAnalytics - retrieves the analytics for a project.
-// The analytics includes the total API calls and model costs for all the applications in the project.
+// The analytics includes the total API calls and model costs for all the applications in the project,
+// and a time series if the interval query parameter is set.
 func handleRetrieveProjectAnalytics(w http.ResponseWriter, r *http.Request) {
 	projectID := r.Context().Value(middleware.ProjectIDContextKey).(pgtype.UUID)
 
 	toDate := timeutils.ParseDate(r.URL.Query().Get("toDate"), time.Now())
 	fromDate := timeutils.ParseDate(r.URL.Query().Get("fromDate"), timeutils.GetFirstDayOfMonth())
 
+	interval, groupBy := r.URL.Query().Get("interval"), r.URL.Query().Get("groupBy")
+	if validationErr := repositories.ValidateTimeSeriesParams(interval, groupBy, fromDate, toDate); validationErr != nil {
+		apierror.BadRequest(validationErr.Error()).Render(w)
+		return
+	}
+
 	projectAnalytics := repositories.GetProjectAnalyticsByDateRange(
 		r.Context(),
 		projectID,
@@ -145,6 +152,17 @@ func handleRetrieveProjectAnalytics(w http.ResponseWriter, r *http.Request) {
 		toDate,
 	)
 
+	if interval != "" {
+		projectAnalytics.TimeSeries = repositories.GetAnalyticsTimeSeries(
+			r.Context(),
+			repositories.TimeSeriesScope{ProjectID: projectID},
+			interval,
+			groupBy,
+			fromDate,
+			toDate,
+		)
+	}
+
 	w.WriteHeader(http.StatusOK)
 	serialization.RenderJSONResponse(w, http.StatusOK, projectAnalytics)
 }
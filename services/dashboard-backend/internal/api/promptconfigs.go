@@ -3,6 +3,7 @@ package api
 import (
 	"encoding/json"
 	"github.com/basemind-ai/monorepo/shared/go/datatypes"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
 	"github.com/basemind-ai/monorepo/shared/go/exc"
 	"github.com/basemind-ai/monorepo/shared/go/ptr"
 	"net/http"
@@ -23,6 +24,7 @@ import (
 // handleCreatePromptConfig - creates a prompt config for the given application.
 // The first prompt config created for an application is automatically set as the default.
 func handleCreatePromptConfig(w http.ResponseWriter, r *http.Request) {
+	userAccount := r.Context().Value(middleware.UserAccountContextKey).(*models.UserAccount)
 	applicationID := r.Context().Value(middleware.ApplicationIDContextKey).(pgtype.UUID)
 
 	createPromptConfigDTO := dto.PromptConfigCreateDTO{}
@@ -41,13 +43,15 @@ func handleCreatePromptConfig(w http.ResponseWriter, r *http.Request) {
 	promptConfig, createErr := repositories.CreatePromptConfig(
 		r.Context(),
 		applicationID,
+		userAccount,
 		createPromptConfigDTO,
 	)
 
 	if createErr != nil {
 		apiErr := apierror.InternalServerError()
 
-		if strings.Contains(createErr.Error(), "duplicate key value violates unique constraint") {
+		if strings.Contains(createErr.Error(), "duplicate key value violates unique constraint") ||
+			strings.Contains(createErr.Error(), "invalid fallback model") {
 			apiErr = apierror.BadRequest(createErr.Error())
 		}
 
@@ -78,15 +82,30 @@ func handleRetrievePromptConfigs(w http.ResponseWriter, r *http.Request) {
 			ModelVendor:               promptConfig.ModelVendor,
 			ProviderPromptMessages:    ptr.To(json.RawMessage(promptConfig.ProviderPromptMessages)),
 			ExpectedTemplateVariables: promptConfig.ExpectedTemplateVariables,
-			IsDefault:                 promptConfig.IsDefault,
-			CreatedAt:                 promptConfig.CreatedAt.Time,
-			UpdatedAt:                 promptConfig.UpdatedAt.Time,
+			FallbackModels: exc.MustResult(
+				datatypes.UnmarshalFallbackModels(promptConfig.FallbackModels),
+			),
+			ResponseCacheTTLSeconds: promptConfig.ResponseCacheTtlSeconds,
+			Tools:                   exc.MustResult(datatypes.UnmarshalTools(promptConfig.Tools)),
+			ResponseSchema: exc.MustResult(
+				datatypes.UnmarshalResponseSchema(promptConfig.ResponseSchema),
+			),
+			ResponseSchemaMaxRetries: promptConfig.ResponseSchemaMaxRetries,
+			Version:                  promptConfig.Version,
+			TrafficWeight:            promptConfig.TrafficWeight,
+			IsDefault:                promptConfig.IsDefault,
+			CreatedAt:                promptConfig.CreatedAt.Time,
+			UpdatedAt:                promptConfig.UpdatedAt.Time,
 		}
 	}
 
 	serialization.RenderJSONResponse(w, http.StatusOK, responseData)
 }
+
+// handleUpdatePromptConfig - updates the prompt config with the given ID.
+// Updates that change the content of the prompt config create a new version.
 func handleUpdatePromptConfig(w http.ResponseWriter, r *http.Request) {
+	userAccount := r.Context().Value(middleware.UserAccountContextKey).(*models.UserAccount)
 	promptConfigID := r.Context().Value(middleware.PromptConfigIDContextKey).(pgtype.UUID)
 
 	updatePromptConfigDTO := &dto.PromptConfigUpdateDTO{}
@@ -103,7 +122,7 @@ func handleUpdatePromptConfig(w http.ResponseWriter, r *http.Request) {
 	}
 
 	updatedPromptConfig, updatePromptConfigErr := repositories.UpdatePromptConfig(
-		r.Context(), promptConfigID, *updatePromptConfigDTO,
+		r.Context(), promptConfigID, userAccount, *updatePromptConfigDTO,
 	)
 
 	if updatePromptConfigErr != nil {
@@ -114,6 +133,9 @@ func handleUpdatePromptConfig(w http.ResponseWriter, r *http.Request) {
 		) || strings.Contains(
 			updatePromptConfigErr.Error(),
 			"unsupported model type",
+		) || strings.Contains(
+			updatePromptConfigErr.Error(),
+			"invalid fallback model",
 		) {
 			apiErr = apierror.BadRequest(updatePromptConfigErr.Error())
 		}
@@ -169,18 +191,32 @@ func handleDeletePromptConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if promptConfig.TrafficWeight > 0 {
+		apierror.BadRequest("cannot delete a prompt config that is part of the traffic split").Render(w)
+		return
+	}
+
 	repositories.DeletePromptConfig(r.Context(), applicationID, promptConfigID)
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
 // handlePromptConfigAnalytics - retrieves the analytics for a prompt config with the given ID.
+// The analytics includes a time series if the interval query parameter is set.
 func handlePromptConfigAnalytics(w http.ResponseWriter, r *http.Request) {
+	projectID := r.Context().Value(middleware.ProjectIDContextKey).(pgtype.UUID)
+	applicationID := r.Context().Value(middleware.ApplicationIDContextKey).(pgtype.UUID)
 	promptConfigID := r.Context().Value(middleware.PromptConfigIDContextKey).(pgtype.UUID)
 
 	toDate := timeutils.ParseDate(r.URL.Query().Get("toDate"), time.Now())
 	fromDate := timeutils.ParseDate(r.URL.Query().Get("fromDate"), timeutils.GetFirstDayOfMonth())
 
+	interval, groupBy := r.URL.Query().Get("interval"), r.URL.Query().Get("groupBy")
+	if validationErr := repositories.ValidateTimeSeriesParams(interval, groupBy, fromDate, toDate); validationErr != nil {
+		apierror.BadRequest(validationErr.Error()).Render(w)
+		return
+	}
+
 	promptConfigAnalytics := repositories.GetPromptConfigAnalyticsByDateRange(
 		r.Context(),
 		promptConfigID,
@@ -188,6 +224,21 @@ func handlePromptConfigAnalytics(w http.ResponseWriter, r *http.Request) {
 		toDate,
 	)
 
+	if interval != "" {
+		promptConfigAnalytics.TimeSeries = repositories.GetAnalyticsTimeSeries(
+			r.Context(),
+			repositories.TimeSeriesScope{
+				ProjectID:      projectID,
+				ApplicationID:  applicationID,
+				PromptConfigID: promptConfigID,
+			},
+			interval,
+			groupBy,
+			fromDate,
+			toDate,
+		)
+	}
+
 	w.WriteHeader(http.StatusOK)
 	serialization.RenderJSONResponse(w, http.StatusOK, promptConfigAnalytics)
 }
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/middleware"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/repositories"
+	"github.com/basemind-ai/monorepo/shared/go/apierror"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/serialization"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+)
+
+// handleRetrievePromptConfigVersions - retrieves the versions of the prompt config with the given ID, latest first.
+func handleRetrievePromptConfigVersions(w http.ResponseWriter, r *http.Request) {
+	promptConfigID := r.Context().Value(middleware.PromptConfigIDContextKey).(pgtype.UUID)
+
+	serialization.RenderJSONResponse(
+		w,
+		http.StatusOK,
+		repositories.GetPromptConfigVersions(r.Context(), promptConfigID),
+	)
+}
+
+// handleDiffPromptConfigVersions - retrieves the differences between the prompt config version with the given ID,
+// and the version given by the compareTo query parameter. Defaults to comparing with the current version.
+func handleDiffPromptConfigVersions(w http.ResponseWriter, r *http.Request) {
+	promptConfigID := r.Context().Value(middleware.PromptConfigIDContextKey).(pgtype.UUID)
+	promptConfigVersionID := r.Context().Value(middleware.PromptConfigVersionIDContextKey).(pgtype.UUID)
+
+	var compareToVersionID pgtype.UUID
+	if compareTo := r.URL.Query().Get("compareTo"); compareTo != "" {
+		parsedID, parseErr := db.StringToUUID(compareTo)
+		if parseErr != nil {
+			apierror.BadRequest(invalidIDError).Render(w)
+			return
+		}
+
+		compareToVersionID = *parsedID
+	}
+
+	diff, diffErr := repositories.DiffPromptConfigVersions(
+		r.Context(),
+		promptConfigID,
+		compareToVersionID,
+		promptConfigVersionID,
+	)
+	if diffErr != nil {
+		log.Error().Err(diffErr).Msg("failed to diff prompt config versions")
+		apierror.BadRequest("prompt config version with the given ID does not exist").Render(w)
+		return
+	}
+
+	serialization.RenderJSONResponse(w, http.StatusOK, diff)
+}
+
+// handleRollbackPromptConfig - restores the prompt config to the version with the given ID.
+// The rollback creates a new version, and the updated prompt config is returned.
+func handleRollbackPromptConfig(w http.ResponseWriter, r *http.Request) {
+	userAccount := r.Context().Value(middleware.UserAccountContextKey).(*models.UserAccount)
+	promptConfigID := r.Context().Value(middleware.PromptConfigIDContextKey).(pgtype.UUID)
+	promptConfigVersionID := r.Context().Value(middleware.PromptConfigVersionIDContextKey).(pgtype.UUID)
+
+	promptConfig, rollbackErr := repositories.RollbackPromptConfig(
+		r.Context(),
+		promptConfigID,
+		promptConfigVersionID,
+		userAccount,
+	)
+	if rollbackErr != nil {
+		apiErr := apierror.InternalServerError()
+		if strings.Contains(rollbackErr.Error(), "failed to retrieve") ||
+			strings.Contains(rollbackErr.Error(), "is already the current version") {
+			apiErr = apierror.BadRequest(rollbackErr.Error())
+		}
+
+		log.Error().Err(rollbackErr).Msg("failed to rollback prompt config")
+		apiErr.Render(w)
+		return
+	}
+
+	serialization.RenderJSONResponse(w, http.StatusOK, promptConfig)
+}
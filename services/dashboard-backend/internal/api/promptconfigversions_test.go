@@ -0,0 +1,277 @@
+package api_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/basemind-ai/monorepo/e2e/factories"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/api"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/dto"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/repositories"
+	"github.com/basemind-ai/monorepo/shared/go/datatypes"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/basemind-ai/monorepo/shared/go/serialization"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromptConfigVersionAPI(t *testing.T) { //nolint: revive
+	userAccount, _ := factories.CreateUserAccount(context.TODO())
+	projectID := createProject(t)
+	createUserProject(t, userAccount.FirebaseID, projectID, models.AccessPermissionTypeADMIN)
+
+	testClient := createTestClient(t, userAccount)
+
+	fmtEndpoint := func(
+		endpoint string,
+		applicationID string,
+		promptConfigID string,
+		promptConfigVersionID string,
+	) string {
+		return fmt.Sprintf("/v1%s", strings.NewReplacer(
+			"{projectId}", projectID,
+			"{applicationId}", applicationID,
+			"{promptConfigId}", promptConfigID,
+			"{promptConfigVersionId}", promptConfigVersionID,
+		).Replace(endpoint))
+	}
+
+	// createVersionedPromptConfig - creates a prompt config with two versions, and returns the prompt config ID
+	// and the versions, latest first.
+	createVersionedPromptConfig := func(
+		t *testing.T,
+		applicationID string,
+	) (string, []dto.PromptConfigVersionDTO) {
+		t.Helper()
+
+		uuidID, _ := db.StringToUUID(applicationID)
+		promptConfig, createErr := repositories.CreatePromptConfig(
+			context.TODO(),
+			*uuidID,
+			userAccount,
+			dto.PromptConfigCreateDTO{
+				Name:            "versioned prompt config",
+				ModelVendor:     models.ModelVendorOPENAI,
+				ModelType:       models.ModelTypeGpt35Turbo,
+				ModelParameters: factories.CreateOpenAIModelParameters(),
+				ProviderPromptMessages: factories.CreateOpenAIPromptMessages(
+					"You are a chatbot.",
+					"Please write a song about {subject}.",
+					nil,
+				),
+			},
+		)
+		assert.NoError(t, createErr)
+
+		promptConfigID, _ := db.StringToUUID(promptConfig.ID)
+		_, updateErr := repositories.UpdatePromptConfig(
+			context.TODO(),
+			*promptConfigID,
+			userAccount,
+			dto.PromptConfigUpdateDTO{ModelType: ptr.To(models.ModelTypeGpt4)},
+		)
+		assert.NoError(t, updateErr)
+
+		return promptConfig.ID, repositories.GetPromptConfigVersions(
+			context.TODO(),
+			*promptConfigID,
+		)
+	}
+
+	t.Run(fmt.Sprintf("GET: %s", api.PromptConfigVersionListEndpoint), func(t *testing.T) {
+		applicationID := createApplication(t, projectID)
+
+		t.Run("retrieves the versions of a prompt config", func(t *testing.T) {
+			promptConfigID, _ := createVersionedPromptConfig(t, applicationID)
+
+			response, requestErr := testClient.Get(
+				context.TODO(),
+				fmtEndpoint(api.PromptConfigVersionListEndpoint, applicationID, promptConfigID, ""),
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+
+			versions := make([]dto.PromptConfigVersionDTO, 0)
+			deserializationErr := serialization.DeserializeJSON(response.Body, &versions)
+			assert.NoError(t, deserializationErr)
+
+			assert.Len(t, versions, 2)
+			assert.Equal(t, int32(2), versions[0].Version)
+			assert.Equal(t, models.ModelTypeGpt4, versions[0].ModelType)
+			assert.Equal(t, userAccount.DisplayName, *versions[0].CreatedByDisplayName)
+			assert.Equal(t, int32(1), versions[1].Version)
+		})
+
+		t.Run("responds with status 403 FORBIDDEN if the user is not in the project", func(t *testing.T) {
+			promptConfigID, _ := createVersionedPromptConfig(t, applicationID)
+			newUserAccount, _ := factories.CreateUserAccount(context.TODO())
+			client := createTestClient(t, newUserAccount)
+
+			response, requestErr := client.Get(
+				context.TODO(),
+				fmtEndpoint(api.PromptConfigVersionListEndpoint, applicationID, promptConfigID, ""),
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusForbidden, response.StatusCode)
+		})
+	})
+
+	t.Run(fmt.Sprintf("GET: %s", api.PromptConfigVersionDiffEndpoint), func(t *testing.T) {
+		applicationID := createApplication(t, projectID)
+
+		t.Run("diffs a version with the current version", func(t *testing.T) {
+			promptConfigID, versions := createVersionedPromptConfig(t, applicationID)
+
+			response, requestErr := testClient.Get(
+				context.TODO(),
+				fmtEndpoint(
+					api.PromptConfigVersionDiffEndpoint,
+					applicationID,
+					promptConfigID,
+					versions[1].ID,
+				),
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+
+			diff := dto.PromptConfigVersionDiffDTO{}
+			deserializationErr := serialization.DeserializeJSON(response.Body, &diff)
+			assert.NoError(t, deserializationErr)
+
+			assert.Equal(t, int32(2), diff.FromVersion)
+			assert.Equal(t, int32(1), diff.ToVersion)
+			assert.Len(t, diff.Changes, 1)
+			assert.Equal(t, "modelType", diff.Changes[0].Field)
+		})
+
+		t.Run("diffs a version with the version given by compareTo", func(t *testing.T) {
+			promptConfigID, versions := createVersionedPromptConfig(t, applicationID)
+
+			response, requestErr := testClient.Get(
+				context.TODO(),
+				fmt.Sprintf("%s?compareTo=%s", fmtEndpoint(
+					api.PromptConfigVersionDiffEndpoint,
+					applicationID,
+					promptConfigID,
+					versions[0].ID,
+				), versions[1].ID),
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+
+			diff := dto.PromptConfigVersionDiffDTO{}
+			deserializationErr := serialization.DeserializeJSON(response.Body, &diff)
+			assert.NoError(t, deserializationErr)
+
+			assert.Equal(t, int32(1), diff.FromVersion)
+			assert.Equal(t, int32(2), diff.ToVersion)
+		})
+
+		t.Run("responds with status 400 BAD REQUEST if compareTo is invalid", func(t *testing.T) {
+			promptConfigID, versions := createVersionedPromptConfig(t, applicationID)
+
+			response, requestErr := testClient.Get(
+				context.TODO(),
+				fmt.Sprintf("%s?compareTo=invalid", fmtEndpoint(
+					api.PromptConfigVersionDiffEndpoint,
+					applicationID,
+					promptConfigID,
+					versions[0].ID,
+				)),
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+		})
+
+		t.Run("responds with status 400 BAD REQUEST for a version of another prompt config", func(t *testing.T) {
+			promptConfigID, _ := createVersionedPromptConfig(t, applicationID)
+			_, otherVersions := createVersionedPromptConfig(t, applicationID)
+
+			response, requestErr := testClient.Get(
+				context.TODO(),
+				fmtEndpoint(
+					api.PromptConfigVersionDiffEndpoint,
+					applicationID,
+					promptConfigID,
+					otherVersions[0].ID,
+				),
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+		})
+	})
+
+	t.Run(fmt.Sprintf("POST: %s", api.PromptConfigVersionRollbackEndpoint), func(t *testing.T) {
+		applicationID := createApplication(t, projectID)
+
+		t.Run("rolls back the prompt config to a previous version", func(t *testing.T) {
+			promptConfigID, versions := createVersionedPromptConfig(t, applicationID)
+
+			response, requestErr := testClient.Post(
+				context.TODO(),
+				fmtEndpoint(
+					api.PromptConfigVersionRollbackEndpoint,
+					applicationID,
+					promptConfigID,
+					versions[1].ID,
+				),
+				nil,
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+
+			promptConfig := datatypes.PromptConfigDTO{}
+			deserializationErr := serialization.DeserializeJSON(response.Body, &promptConfig)
+			assert.NoError(t, deserializationErr)
+
+			assert.Equal(t, int32(3), promptConfig.Version)
+			assert.Equal(t, models.ModelTypeGpt35Turbo, promptConfig.ModelType)
+		})
+
+		t.Run("responds with status 400 BAD REQUEST for the current version", func(t *testing.T) {
+			promptConfigID, versions := createVersionedPromptConfig(t, applicationID)
+
+			response, requestErr := testClient.Post(
+				context.TODO(),
+				fmtEndpoint(
+					api.PromptConfigVersionRollbackEndpoint,
+					applicationID,
+					promptConfigID,
+					versions[0].ID,
+				),
+				nil,
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+		})
+
+		t.Run("responds with status 401 UNAUTHORIZED if the user does not have ADMIN permission", func(t *testing.T) {
+			promptConfigID, versions := createVersionedPromptConfig(t, applicationID)
+			newUserAccount, _ := factories.CreateUserAccount(context.TODO())
+			createUserProject(
+				t,
+				newUserAccount.FirebaseID,
+				projectID,
+				models.AccessPermissionTypeMEMBER,
+			)
+			client := createTestClient(t, newUserAccount)
+
+			response, requestErr := client.Post(
+				context.TODO(),
+				fmtEndpoint(
+					api.PromptConfigVersionRollbackEndpoint,
+					applicationID,
+					promptConfigID,
+					versions[1].ID,
+				),
+				nil,
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+		})
+	})
+}
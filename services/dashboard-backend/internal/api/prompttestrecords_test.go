@@ -80,6 +80,7 @@ func TestPromptTestRecordsAPI(t *testing.T) {
 				DurationMs:     pgtype.Int4{Int32: 1000, Valid: true},
 				PromptConfigID: testPromptConfig.ID,
 				ErrorLog:       pgtype.Text{String: "", Valid: true},
+				Attempts:       1,
 			}),
 	)
 	secondPromptTestRecord := exc.MustResult(
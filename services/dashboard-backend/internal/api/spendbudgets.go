@@ -0,0 +1,139 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/dto"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/middleware"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/repositories"
+	"github.com/basemind-ai/monorepo/shared/go/apierror"
+	"github.com/basemind-ai/monorepo/shared/go/serialization"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultSpendBudgetAlertsPageSize = 50
+	maxSpendBudgetAlertsPageSize     = 100
+)
+
+// renderSpendBudgetError - renders the API error of a failed spend budget change.
+func renderSpendBudgetError(w http.ResponseWriter, err error) {
+	apiErr := apierror.InternalServerError()
+
+	if errors.Is(err, repositories.ErrSpendBudgetNotFound) {
+		apiErr = apierror.NotFound(err.Error())
+	} else if strings.Contains(err.Error(), "invalid spend budget") {
+		apiErr = apierror.BadRequest(err.Error())
+	}
+
+	log.Error().Err(err).Msg("failed to change spend budget")
+	apiErr.Render(w)
+}
+
+// handleRetrieveSpendBudgets - retrieves the spend budgets of the project with the given ID,
+// with their spend in the current period.
+func handleRetrieveSpendBudgets(w http.ResponseWriter, r *http.Request) {
+	projectID := r.Context().Value(middleware.ProjectIDContextKey).(pgtype.UUID)
+
+	serialization.RenderJSONResponse(
+		w,
+		http.StatusOK,
+		repositories.GetSpendBudgets(r.Context(), projectID),
+	)
+}
+
+// handleCreateSpendBudget - creates a spend budget for the project with the given ID.
+// A budget with an application ID applies only to the requests of that application.
+func handleCreateSpendBudget(w http.ResponseWriter, r *http.Request) {
+	projectID := r.Context().Value(middleware.ProjectIDContextKey).(pgtype.UUID)
+
+	spendBudgetDTO := dto.SpendBudgetDTO{}
+	if deserializationErr := serialization.DeserializeJSON(r.Body, &spendBudgetDTO); deserializationErr != nil {
+		log.Error().Err(deserializationErr).Msg("failed to deserialize request body")
+		apierror.BadRequest(invalidRequestBodyError).Render(w)
+		return
+	}
+
+	if validateErr := validate.Struct(&spendBudgetDTO); validateErr != nil {
+		log.Error().Err(validateErr).Msg("invalid request")
+		apierror.BadRequest(invalidRequestBodyError).Render(w)
+		return
+	}
+
+	spendBudget, createErr := repositories.CreateSpendBudget(r.Context(), projectID, spendBudgetDTO)
+	if createErr != nil {
+		renderSpendBudgetError(w, createErr)
+		return
+	}
+
+	serialization.RenderJSONResponse(w, http.StatusCreated, spendBudget)
+}
+
+// handleUpdateSpendBudget - updates the enforcement and limit of the spend budget with the given ID.
+func handleUpdateSpendBudget(w http.ResponseWriter, r *http.Request) {
+	projectID := r.Context().Value(middleware.ProjectIDContextKey).(pgtype.UUID)
+	spendBudgetID := r.Context().Value(middleware.SpendBudgetIDContextKey).(pgtype.UUID)
+
+	updateDTO := dto.SpendBudgetUpdateDTO{}
+	if deserializationErr := serialization.DeserializeJSON(r.Body, &updateDTO); deserializationErr != nil {
+		log.Error().Err(deserializationErr).Msg("failed to deserialize request body")
+		apierror.BadRequest(invalidRequestBodyError).Render(w)
+		return
+	}
+
+	if validateErr := validate.Struct(&updateDTO); validateErr != nil {
+		log.Error().Err(validateErr).Msg("invalid request")
+		apierror.BadRequest(invalidRequestBodyError).Render(w)
+		return
+	}
+
+	spendBudget, updateErr := repositories.UpdateSpendBudget(
+		r.Context(),
+		projectID,
+		spendBudgetID,
+		updateDTO,
+	)
+	if updateErr != nil {
+		renderSpendBudgetError(w, updateErr)
+		return
+	}
+
+	serialization.RenderJSONResponse(w, http.StatusOK, spendBudget)
+}
+
+// handleDeleteSpendBudget - deletes the spend budget with the given ID.
+func handleDeleteSpendBudget(w http.ResponseWriter, r *http.Request) {
+	projectID := r.Context().Value(middleware.ProjectIDContextKey).(pgtype.UUID)
+	spendBudgetID := r.Context().Value(middleware.SpendBudgetIDContextKey).(pgtype.UUID)
+
+	if deleteErr := repositories.DeleteSpendBudget(r.Context(), projectID, spendBudgetID); deleteErr != nil {
+		renderSpendBudgetError(w, deleteErr)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleRetrieveSpendBudgetAlerts - retrieves the latest spend budget alerts of the project with the given ID.
+// The number of alerts is set with the limit query parameter.
+func handleRetrieveSpendBudgetAlerts(w http.ResponseWriter, r *http.Request) {
+	projectID := r.Context().Value(middleware.ProjectIDContextKey).(pgtype.UUID)
+
+	limit, isValidLimit := parsePaginationParam(
+		r.URL.Query().Get("limit"),
+		defaultSpendBudgetAlertsPageSize,
+	)
+	if !isValidLimit || limit == 0 || limit > maxSpendBudgetAlertsPageSize {
+		apierror.BadRequest("invalid pagination parameters").Render(w)
+		return
+	}
+
+	serialization.RenderJSONResponse(
+		w,
+		http.StatusOK,
+		repositories.GetSpendBudgetAlerts(r.Context(), projectID, limit),
+	)
+}
@@ -0,0 +1,254 @@
+package api_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/basemind-ai/monorepo/e2e/factories"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/api"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/dto"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/basemind-ai/monorepo/shared/go/serialization"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpendBudgetsAPI(t *testing.T) { //nolint: revive
+	userAccount, _ := factories.CreateUserAccount(context.TODO())
+	projectID := createProject(t)
+	createUserProject(t, userAccount.FirebaseID, projectID, models.AccessPermissionTypeADMIN)
+
+	testClient := createTestClient(t, userAccount)
+
+	memberAccount, _ := factories.CreateUserAccount(context.TODO())
+	createUserProject(t, memberAccount.FirebaseID, projectID, models.AccessPermissionTypeMEMBER)
+
+	memberClient := createTestClient(t, memberAccount)
+
+	fmtEndpoint := func(endpoint string, spendBudgetID string) string {
+		return fmt.Sprintf("/v1%s", strings.NewReplacer(
+			"{projectId}", projectID,
+			"{spendBudgetId}", spendBudgetID,
+		).Replace(endpoint))
+	}
+
+	createSpendBudget := func(t *testing.T, data dto.SpendBudgetDTO) dto.SpendBudgetDTO {
+		t.Helper()
+
+		response, requestErr := testClient.Post(
+			context.TODO(),
+			fmtEndpoint(api.ProjectSpendBudgetListEndpoint, ""),
+			data,
+		)
+		assert.NoError(t, requestErr)
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+		spendBudget := dto.SpendBudgetDTO{}
+		assert.NoError(t, serialization.DeserializeJSON(response.Body, &spendBudget))
+
+		return spendBudget
+	}
+
+	t.Run(fmt.Sprintf("POST: %s", api.ProjectSpendBudgetListEndpoint), func(t *testing.T) {
+		t.Run("creates an application spend budget", func(t *testing.T) {
+			applicationID := createApplication(t, projectID)
+
+			spendBudget := createSpendBudget(t, dto.SpendBudgetDTO{
+				ApplicationID: ptr.To(applicationID),
+				Period:        string(models.SpendBudgetPeriodDAILY),
+				Enforcement:   string(models.SpendBudgetEnforcementHARD),
+				SpendLimit:    decimal.NewFromInt(5),
+			})
+			assert.NotEmpty(t, spendBudget.ID)
+			assert.Equal(t, applicationID, *spendBudget.ApplicationID)
+			assert.Equal(t, string(models.SpendBudgetPeriodDAILY), spendBudget.Period)
+		})
+
+		t.Run("responds with status 400 BAD REQUEST for an invalid spend budget", func(t *testing.T) {
+			applicationID := createApplication(t, projectID)
+
+			for _, data := range []dto.SpendBudgetDTO{
+				{
+					Period:      "WEEKLY",
+					Enforcement: string(models.SpendBudgetEnforcementHARD),
+					SpendLimit:  decimal.NewFromInt(5),
+				},
+				{
+					Period:      string(models.SpendBudgetPeriodDAILY),
+					Enforcement: "BLOCK",
+					SpendLimit:  decimal.NewFromInt(5),
+				},
+				{
+					ApplicationID: ptr.To(applicationID),
+					Period:        string(models.SpendBudgetPeriodDAILY),
+					Enforcement:   string(models.SpendBudgetEnforcementHARD),
+					SpendLimit:    decimal.Zero,
+				},
+				{
+					ApplicationID: ptr.To("invalid"),
+					Period:        string(models.SpendBudgetPeriodDAILY),
+					Enforcement:   string(models.SpendBudgetEnforcementHARD),
+					SpendLimit:    decimal.NewFromInt(5),
+				},
+			} {
+				response, requestErr := testClient.Post(
+					context.TODO(),
+					fmtEndpoint(api.ProjectSpendBudgetListEndpoint, ""),
+					data,
+				)
+				assert.NoError(t, requestErr)
+				assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+			}
+		})
+
+		t.Run("responds with status 401 UNAUTHORIZED if the user does not have ADMIN permission", func(t *testing.T) {
+			response, requestErr := memberClient.Post(
+				context.TODO(),
+				fmtEndpoint(api.ProjectSpendBudgetListEndpoint, ""),
+				dto.SpendBudgetDTO{
+					Period:      string(models.SpendBudgetPeriodMONTHLY),
+					Enforcement: string(models.SpendBudgetEnforcementHARD),
+					SpendLimit:  decimal.NewFromInt(5),
+				},
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+		})
+	})
+
+	t.Run(fmt.Sprintf("GET: %s", api.ProjectSpendBudgetListEndpoint), func(t *testing.T) {
+		t.Run("retrieves the spend budgets of the project", func(t *testing.T) {
+			applicationID := createApplication(t, projectID)
+			spendBudget := createSpendBudget(t, dto.SpendBudgetDTO{
+				ApplicationID: ptr.To(applicationID),
+				Period:        string(models.SpendBudgetPeriodMONTHLY),
+				Enforcement:   string(models.SpendBudgetEnforcementSOFT),
+				SpendLimit:    decimal.NewFromInt(5),
+			})
+
+			response, requestErr := memberClient.Get(
+				context.TODO(),
+				fmtEndpoint(api.ProjectSpendBudgetListEndpoint, ""),
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+
+			spendBudgets := make([]dto.SpendBudgetDTO, 0)
+			assert.NoError(t, serialization.DeserializeJSON(response.Body, &spendBudgets))
+
+			ids := make([]string, len(spendBudgets))
+			for i, retrieved := range spendBudgets {
+				ids[i] = retrieved.ID
+			}
+
+			assert.Contains(t, ids, spendBudget.ID)
+		})
+	})
+
+	t.Run(fmt.Sprintf("PATCH: %s", api.ProjectSpendBudgetDetailEndpoint), func(t *testing.T) {
+		t.Run("updates a spend budget", func(t *testing.T) {
+			applicationID := createApplication(t, projectID)
+			spendBudget := createSpendBudget(t, dto.SpendBudgetDTO{
+				ApplicationID: ptr.To(applicationID),
+				Period:        string(models.SpendBudgetPeriodDAILY),
+				Enforcement:   string(models.SpendBudgetEnforcementHARD),
+				SpendLimit:    decimal.NewFromInt(5),
+			})
+
+			response, requestErr := testClient.Patch(
+				context.TODO(),
+				fmtEndpoint(api.ProjectSpendBudgetDetailEndpoint, spendBudget.ID),
+				dto.SpendBudgetUpdateDTO{
+					Enforcement: string(models.SpendBudgetEnforcementSOFT),
+					SpendLimit:  decimal.NewFromInt(10),
+				},
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+
+			updated := dto.SpendBudgetDTO{}
+			assert.NoError(t, serialization.DeserializeJSON(response.Body, &updated))
+			assert.Equal(t, string(models.SpendBudgetEnforcementSOFT), updated.Enforcement)
+			assert.True(t, decimal.NewFromInt(10).Equal(updated.SpendLimit))
+		})
+
+		t.Run("responds with status 404 NOT FOUND for an unknown spend budget", func(t *testing.T) {
+			response, requestErr := testClient.Patch(
+				context.TODO(),
+				fmtEndpoint(
+					api.ProjectSpendBudgetDetailEndpoint,
+					"b8bfe7f4-6a2a-4bd0-a3a7-3d4c7fc7a0a1",
+				),
+				dto.SpendBudgetUpdateDTO{
+					Enforcement: string(models.SpendBudgetEnforcementSOFT),
+					SpendLimit:  decimal.NewFromInt(10),
+				},
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusNotFound, response.StatusCode)
+		})
+	})
+
+	t.Run(fmt.Sprintf("DELETE: %s", api.ProjectSpendBudgetDetailEndpoint), func(t *testing.T) {
+		t.Run("deletes a spend budget", func(t *testing.T) {
+			applicationID := createApplication(t, projectID)
+			spendBudget := createSpendBudget(t, dto.SpendBudgetDTO{
+				ApplicationID: ptr.To(applicationID),
+				Period:        string(models.SpendBudgetPeriodDAILY),
+				Enforcement:   string(models.SpendBudgetEnforcementHARD),
+				SpendLimit:    decimal.NewFromInt(5),
+			})
+
+			response, requestErr := testClient.Delete(
+				context.TODO(),
+				fmtEndpoint(api.ProjectSpendBudgetDetailEndpoint, spendBudget.ID),
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusNoContent, response.StatusCode)
+		})
+
+		t.Run("responds with status 401 UNAUTHORIZED if the user does not have ADMIN permission", func(t *testing.T) {
+			applicationID := createApplication(t, projectID)
+			spendBudget := createSpendBudget(t, dto.SpendBudgetDTO{
+				ApplicationID: ptr.To(applicationID),
+				Period:        string(models.SpendBudgetPeriodDAILY),
+				Enforcement:   string(models.SpendBudgetEnforcementHARD),
+				SpendLimit:    decimal.NewFromInt(5),
+			})
+
+			response, requestErr := memberClient.Delete(
+				context.TODO(),
+				fmtEndpoint(api.ProjectSpendBudgetDetailEndpoint, spendBudget.ID),
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+		})
+	})
+
+	t.Run(fmt.Sprintf("GET: %s", api.ProjectSpendBudgetAlertsEndpoint), func(t *testing.T) {
+		t.Run("retrieves the spend budget alerts of the project", func(t *testing.T) {
+			response, requestErr := memberClient.Get(
+				context.TODO(),
+				fmtEndpoint(api.ProjectSpendBudgetAlertsEndpoint, ""),
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+
+			alerts := make([]dto.SpendBudgetAlertDTO, 0)
+			assert.NoError(t, serialization.DeserializeJSON(response.Body, &alerts))
+		})
+
+		t.Run("responds with status 400 BAD REQUEST for an invalid limit", func(t *testing.T) {
+			response, requestErr := memberClient.Get(
+				context.TODO(),
+				fmtEndpoint(api.ProjectSpendBudgetAlertsEndpoint, "")+"?limit=1000",
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+		})
+	})
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/dto"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/middleware"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/repositories"
+	"github.com/basemind-ai/monorepo/shared/go/apierror"
+	"github.com/basemind-ai/monorepo/shared/go/serialization"
+	"github.com/basemind-ai/monorepo/shared/go/timeutils"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+)
+
+// handleRetrieveTrafficSplit - retrieves the traffic split of the application with the given ID.
+func handleRetrieveTrafficSplit(w http.ResponseWriter, r *http.Request) {
+	applicationID := r.Context().Value(middleware.ApplicationIDContextKey).(pgtype.UUID)
+
+	serialization.RenderJSONResponse(
+		w,
+		http.StatusOK,
+		repositories.GetTrafficSplit(r.Context(), applicationID),
+	)
+}
+
+// handleUpdateTrafficSplit - replaces the traffic split of the application with the given ID.
+// Requests without a prompt config ID are split between the variants by weight, an empty split disables splitting.
+func handleUpdateTrafficSplit(w http.ResponseWriter, r *http.Request) {
+	applicationID := r.Context().Value(middleware.ApplicationIDContextKey).(pgtype.UUID)
+
+	trafficSplitDTO := dto.TrafficSplitDTO{}
+	if deserializationErr := serialization.DeserializeJSON(r.Body, &trafficSplitDTO); deserializationErr != nil {
+		log.Error().Err(deserializationErr).Msg("failed to deserialize request body")
+		apierror.BadRequest(invalidRequestBodyError).Render(w)
+		return
+	}
+
+	if validateErr := validate.Struct(&trafficSplitDTO); validateErr != nil {
+		log.Error().Err(validateErr).Msg("invalid request")
+		apierror.BadRequest(invalidRequestBodyError).Render(w)
+		return
+	}
+
+	if updateErr := repositories.UpdateTrafficSplit(r.Context(), applicationID, trafficSplitDTO); updateErr != nil {
+		apiErr := apierror.InternalServerError()
+		if strings.Contains(updateErr.Error(), "invalid traffic split") {
+			apiErr = apierror.BadRequest(updateErr.Error())
+		}
+
+		log.Error().Err(updateErr).Msg("failed to update traffic split")
+		apiErr.Render(w)
+		return
+	}
+
+	serialization.RenderJSONResponse(
+		w,
+		http.StatusOK,
+		repositories.GetTrafficSplit(r.Context(), applicationID),
+	)
+}
+
+// handleRetrieveTrafficSplitAnalytics - retrieves the analytics of the requests routed by the traffic split
+// of the application with the given ID, per prompt config.
+func handleRetrieveTrafficSplitAnalytics(w http.ResponseWriter, r *http.Request) {
+	applicationID := r.Context().Value(middleware.ApplicationIDContextKey).(pgtype.UUID)
+
+	toDate := timeutils.ParseDate(r.URL.Query().Get("toDate"), time.Now())
+	fromDate := timeutils.ParseDate(r.URL.Query().Get("fromDate"), timeutils.GetFirstDayOfMonth())
+
+	serialization.RenderJSONResponse(
+		w,
+		http.StatusOK,
+		repositories.GetTrafficSplitAnalyticsByDateRange(
+			r.Context(),
+			applicationID,
+			fromDate,
+			toDate,
+		),
+	)
+}
@@ -0,0 +1,162 @@
+package api_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/basemind-ai/monorepo/e2e/factories"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/api"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/dto"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/serialization"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrafficSplitAPI(t *testing.T) { //nolint: revive
+	userAccount, _ := factories.CreateUserAccount(context.TODO())
+	projectID := createProject(t)
+	createUserProject(t, userAccount.FirebaseID, projectID, models.AccessPermissionTypeADMIN)
+
+	testClient := createTestClient(t, userAccount)
+
+	fmtEndpoint := func(endpoint string, applicationID string) string {
+		return fmt.Sprintf("/v1%s", strings.NewReplacer(
+			"{projectId}", projectID,
+			"{applicationId}", applicationID,
+		).Replace(endpoint))
+	}
+
+	t.Run(fmt.Sprintf("PATCH: %s", api.ApplicationTrafficSplitEndpoint), func(t *testing.T) {
+		t.Run("updates the traffic split of an application", func(t *testing.T) {
+			applicationID := createApplication(t, projectID)
+			firstPromptConfigID := createPromptConfig(t, applicationID)
+			secondPromptConfigID := createPromptConfig(t, applicationID)
+
+			trafficSplit := dto.TrafficSplitDTO{Variants: []dto.TrafficSplitVariantDTO{
+				{PromptConfigID: firstPromptConfigID, Weight: 90},
+				{PromptConfigID: secondPromptConfigID, Weight: 10},
+			}}
+
+			response, requestErr := testClient.Patch(
+				context.TODO(),
+				fmtEndpoint(api.ApplicationTrafficSplitEndpoint, applicationID),
+				trafficSplit,
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+
+			response, requestErr = testClient.Get(
+				context.TODO(),
+				fmtEndpoint(api.ApplicationTrafficSplitEndpoint, applicationID),
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+
+			retrievedTrafficSplit := dto.TrafficSplitDTO{}
+			deserializationErr := serialization.DeserializeJSON(
+				response.Body,
+				&retrievedTrafficSplit,
+			)
+			assert.NoError(t, deserializationErr)
+			assert.Equal(t, trafficSplit, retrievedTrafficSplit)
+		})
+
+		t.Run("responds with status 400 BAD REQUEST for an invalid traffic split", func(t *testing.T) {
+			applicationID := createApplication(t, projectID)
+			promptConfigID := createPromptConfig(t, applicationID)
+
+			for _, trafficSplit := range []dto.TrafficSplitDTO{
+				{Variants: []dto.TrafficSplitVariantDTO{{PromptConfigID: promptConfigID, Weight: 90}}},
+				{Variants: []dto.TrafficSplitVariantDTO{{PromptConfigID: promptConfigID, Weight: 0}}},
+				{Variants: []dto.TrafficSplitVariantDTO{{PromptConfigID: "invalid", Weight: 100}}},
+			} {
+				response, requestErr := testClient.Patch(
+					context.TODO(),
+					fmtEndpoint(api.ApplicationTrafficSplitEndpoint, applicationID),
+					trafficSplit,
+				)
+				assert.NoError(t, requestErr)
+				assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+			}
+		})
+
+		t.Run("responds with status 401 UNAUTHORIZED if the user does not have ADMIN permission", func(t *testing.T) {
+			applicationID := createApplication(t, projectID)
+			promptConfigID := createPromptConfig(t, applicationID)
+			newUserAccount, _ := factories.CreateUserAccount(context.TODO())
+			createUserProject(
+				t,
+				newUserAccount.FirebaseID,
+				projectID,
+				models.AccessPermissionTypeMEMBER,
+			)
+			client := createTestClient(t, newUserAccount)
+
+			response, requestErr := client.Patch(
+				context.TODO(),
+				fmtEndpoint(api.ApplicationTrafficSplitEndpoint, applicationID),
+				dto.TrafficSplitDTO{Variants: []dto.TrafficSplitVariantDTO{
+					{PromptConfigID: promptConfigID, Weight: 100},
+				}},
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+		})
+	})
+
+	t.Run(fmt.Sprintf("DELETE: %s", api.PromptConfigDetailEndpoint), func(t *testing.T) {
+		t.Run("responds with status 400 BAD REQUEST for a traffic split prompt config", func(t *testing.T) {
+			applicationID := createApplication(t, projectID)
+			_ = createPromptConfig(t, applicationID)
+			promptConfigID := createPromptConfig(t, applicationID)
+			promptConfigUUID, _ := db.StringToUUID(promptConfigID)
+			assert.NoError(t, db.GetQueries().UpdateDefaultPromptConfig(
+				context.TODO(),
+				models.UpdateDefaultPromptConfigParams{ID: *promptConfigUUID, IsDefault: false},
+			))
+
+			response, requestErr := testClient.Patch(
+				context.TODO(),
+				fmtEndpoint(api.ApplicationTrafficSplitEndpoint, applicationID),
+				dto.TrafficSplitDTO{Variants: []dto.TrafficSplitVariantDTO{
+					{PromptConfigID: promptConfigID, Weight: 100},
+				}},
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+
+			response, requestErr = testClient.Delete(
+				context.TODO(),
+				strings.ReplaceAll(
+					fmtEndpoint(api.PromptConfigDetailEndpoint, applicationID),
+					"{promptConfigId}",
+					promptConfigID,
+				),
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+		})
+	})
+
+	t.Run(fmt.Sprintf("GET: %s", api.ApplicationTrafficSplitAnalyticsEndpoint), func(t *testing.T) {
+		t.Run("retrieves the traffic split analytics of an application", func(t *testing.T) {
+			applicationID := createApplication(t, projectID)
+
+			response, requestErr := testClient.Get(
+				context.TODO(),
+				fmtEndpoint(api.ApplicationTrafficSplitAnalyticsEndpoint, applicationID),
+			)
+			assert.NoError(t, requestErr)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+
+			analytics := make([]dto.TrafficSplitVariantAnalyticsDTO, 0)
+			deserializationErr := serialization.DeserializeJSON(response.Body, &analytics)
+			assert.NoError(t, deserializationErr)
+			assert.Empty(t, analytics)
+		})
+	})
+}
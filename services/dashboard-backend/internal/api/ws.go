@@ -54,9 +54,9 @@ func recoveryHandler(_ gws.Logger) { // skipcq: TCV-001
 }
 
 var upgrader = gws.NewUpgrader(&handler{}, &gws.ServerOption{
-	ReadAsyncEnabled: true,
-	CompressEnabled:  true,
-	Recovery:         recoveryHandler,
+	ParallelEnabled:   true,
+	PermessageDeflate: gws.PermessageDeflate{Enabled: true},
+	Recovery:          recoveryHandler,
 })
 
 type RequestIDs struct {
@@ -207,6 +207,7 @@ func ParseMessageData(
 		promptConfig, createErr := repositories.CreatePromptConfig(
 			context.Background(),
 			applicationID,
+			nil,
 			dto.PromptConfigCreateDTO{
 				Name: fmt.Sprintf(
 					"test config - %s - %s",
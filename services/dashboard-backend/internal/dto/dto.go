@@ -2,6 +2,7 @@ package dto
 
 import (
 	"encoding/json"
+	"github.com/basemind-ai/monorepo/shared/go/datatypes"
 	"github.com/basemind-ai/monorepo/shared/go/db/models"
 	"github.com/shopspring/decimal"
 	"time"
@@ -9,11 +10,13 @@ import (
 
 // ApplicationDTO - DTO for serializing application data.
 type ApplicationDTO struct { // skipcq: TCV-001
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	ID                         string    `json:"id"`
+	Name                       string    `json:"name"`
+	Description                string    `json:"description"`
+	RateLimitRequestsPerMinute int32     `json:"rateLimitRequestsPerMinute"`
+	RateLimitTokensPerMinute   int32     `json:"rateLimitTokensPerMinute"`
+	CreatedAt                  time.Time `json:"createdAt"`
+	UpdatedAt                  time.Time `json:"updatedAt"`
 }
 
 // ProjectDTO - DTO for serializing project data.
@@ -30,29 +33,82 @@ type ProjectDTO struct { // skipcq: TCV-001
 
 // PromptConfigCreateDTO - DTO for prompt config CREATE request body.
 type PromptConfigCreateDTO struct { // skipcq: TCV-001
-	Name                   string             `json:"name"            validate:"required"`
-	ModelParameters        *json.RawMessage   `json:"modelParameters" validate:"required"`
-	ModelType              models.ModelType   `json:"modelType"       validate:"oneof=gpt-3.5-turbo gpt-3.5-turbo-16k gpt-4 gpt-4-32k command command-light command-nightly command-light-nightly"`
-	ModelVendor            models.ModelVendor `json:"modelVendor"     validate:"oneof=OPEN_AI COHERE"`
-	ProviderPromptMessages *json.RawMessage   `json:"promptMessages"  validate:"required"`
-	IsTest                 bool               `json:"isTest"`
+	Name                     string                       `json:"name"                               validate:"required"`
+	ModelParameters          *json.RawMessage             `json:"modelParameters"                    validate:"required"`
+	ModelType                models.ModelType             `json:"modelType"                          validate:"oneof=gpt-3.5-turbo gpt-3.5-turbo-16k gpt-4 gpt-4-32k command command-light command-nightly command-light-nightly"`
+	ModelVendor              models.ModelVendor           `json:"modelVendor"                        validate:"oneof=OPEN_AI COHERE"`
+	ProviderPromptMessages   *json.RawMessage             `json:"promptMessages"                     validate:"required"`
+	FallbackModels           []datatypes.FallbackModelDTO `json:"fallbackModels,omitempty"           validate:"omitempty,dive"`
+	ResponseCacheTTLSeconds  int32                        `json:"responseCacheTtlSeconds,omitempty"  validate:"omitempty,min=0,max=2592000"`
+	Tools                    []datatypes.ToolDTO          `json:"tools,omitempty"                    validate:"omitempty,dive"`
+	ResponseSchema           *json.RawMessage             `json:"responseSchema,omitempty"`
+	ResponseSchemaMaxRetries int32                        `json:"responseSchemaMaxRetries,omitempty" validate:"omitempty,min=0,max=5"`
+	IsTest                   bool                         `json:"isTest"`
 }
 
 // PromptConfigUpdateDTO - DTO for prompt config UPDATE request body.
+// ResponseSchema is not a pointer, so that an explicit null - which removes the response schema,
+// can be told apart from an omitted value.
 type PromptConfigUpdateDTO struct { // skipcq: TCV-001
-	Name                   *string             `json:"name,omitempty"            validate:"omitempty,required"`
-	ModelParameters        *json.RawMessage    `json:"modelParameters,omitempty" validate:"omitempty,required"`
-	ModelType              *models.ModelType   `json:"modelType,omitempty"       validate:"omitempty,required"`
-	ModelVendor            *models.ModelVendor `json:"modelVendor,omitempty"     validate:"omitempty,oneof=OPEN_AI COHERE"`
-	ProviderPromptMessages *json.RawMessage    `json:"promptMessages,omitempty"  validate:"omitempty,required"`
+	Name                     *string                       `json:"name,omitempty"                     validate:"omitempty,required"`
+	ModelParameters          *json.RawMessage              `json:"modelParameters,omitempty"          validate:"omitempty,required"`
+	ModelType                *models.ModelType             `json:"modelType,omitempty"                validate:"omitempty,required"`
+	ModelVendor              *models.ModelVendor           `json:"modelVendor,omitempty"              validate:"omitempty,oneof=OPEN_AI COHERE"`
+	ProviderPromptMessages   *json.RawMessage              `json:"promptMessages,omitempty"           validate:"omitempty,required"`
+	FallbackModels           *[]datatypes.FallbackModelDTO `json:"fallbackModels,omitempty"           validate:"omitempty,dive"`
+	ResponseCacheTTLSeconds  *int32                        `json:"responseCacheTtlSeconds,omitempty"  validate:"omitempty,min=0,max=2592000"`
+	Tools                    *[]datatypes.ToolDTO          `json:"tools,omitempty"                    validate:"omitempty,dive"`
+	ResponseSchema           json.RawMessage               `json:"responseSchema,omitempty"`
+	ResponseSchemaMaxRetries *int32                        `json:"responseSchemaMaxRetries,omitempty" validate:"omitempty,min=0,max=5"`
+}
+
+// PromptConfigVersionDTO - DTO for serializing an immutable prompt config version.
+type PromptConfigVersionDTO struct { // skipcq: TCV-001
+	ID                        string                       `json:"id"`
+	Version                   int32                        `json:"version"`
+	ModelParameters           *json.RawMessage             `json:"modelParameters"`
+	ModelType                 models.ModelType             `json:"modelType"`
+	ModelVendor               models.ModelVendor           `json:"modelVendor"`
+	ProviderPromptMessages    *json.RawMessage             `json:"providerPromptMessages"`
+	ExpectedTemplateVariables []string                     `json:"expectedTemplateVariables"`
+	FallbackModels            []datatypes.FallbackModelDTO `json:"fallbackModels,omitempty"`
+	Tools                     []datatypes.ToolDTO          `json:"tools,omitempty"`
+	ResponseSchema            *json.RawMessage             `json:"responseSchema,omitempty"`
+	ResponseSchemaMaxRetries  int32                        `json:"responseSchemaMaxRetries,omitempty"`
+	CreatedByUserID           *string                      `json:"createdByUserId,omitempty"`
+	CreatedByDisplayName      *string                      `json:"createdByDisplayName,omitempty"`
+	CreatedAt                 time.Time                    `json:"createdAt"`
+}
+
+// PromptConfigVersionChangeDTO - DTO for serializing a field that differs between two prompt config versions.
+type PromptConfigVersionChangeDTO struct { // skipcq: TCV-001
+	Field string          `json:"field"`
+	From  json.RawMessage `json:"from"`
+	To    json.RawMessage `json:"to"`
+}
+
+// PromptConfigVersionDiffDTO - DTO for serializing the differences between two prompt config versions.
+type PromptConfigVersionDiffDTO struct { // skipcq: TCV-001
+	FromVersion int32                          `json:"fromVersion"`
+	ToVersion   int32                          `json:"toVersion"`
+	Changes     []PromptConfigVersionChangeDTO `json:"changes"`
 }
 
 // ApplicationAPIKeyDTO - DTO for serializing application api key data.
 type ApplicationAPIKeyDTO struct { // skipcq: TCV-001
-	ID        string    `json:"id"`
-	CreatedAt time.Time `json:"createdAt"`
-	Name      string    `json:"name"           validate:"required"`
-	Hash      *string   `json:"hash,omitempty"`
+	ID                         string    `json:"id"`
+	CreatedAt                  time.Time `json:"createdAt"`
+	Name                       string    `json:"name"                       validate:"required"`
+	RateLimitRequestsPerMinute int32     `json:"rateLimitRequestsPerMinute" validate:"min=0"`
+	RateLimitTokensPerMinute   int32     `json:"rateLimitTokensPerMinute"   validate:"min=0"`
+	Hash                       *string   `json:"hash,omitempty"`
+}
+
+// ApplicationAPIKeyRateLimitsDTO - DTO for the application api key rate limits UPDATE request body.
+// A zero limit means the api key is not rate limited.
+type ApplicationAPIKeyRateLimitsDTO struct { // skipcq: TCV-001
+	RateLimitRequestsPerMinute int32 `json:"rateLimitRequestsPerMinute" validate:"min=0"`
+	RateLimitTokensPerMinute   int32 `json:"rateLimitTokensPerMinute"   validate:"min=0"`
 }
 
 // AddUserAccountToProjectDTO - DTO for add user to project request body.
@@ -80,9 +136,204 @@ type ProjectUserAccountDTO struct { // skipcq: TCV-001
 }
 
 // AnalyticsDTO - DTO for serializing analytics data.
+// TimeSeries is only set if a time series interval is requested.
 type AnalyticsDTO struct { // skipcq: TCV-001
-	TotalAPICalls int64           `json:"totalRequests"`
-	TokenCost     decimal.Decimal `json:"tokensCost"`
+	TotalAPICalls int64                         `json:"totalRequests"`
+	TokenCost     decimal.Decimal               `json:"tokensCost"`
+	TimeSeries    []AnalyticsTimeSeriesPointDTO `json:"timeSeries,omitempty"`
+}
+
+// AnalyticsTimeSeriesPointDTO - DTO for serializing the analytics of a time series bucket.
+// GroupKey and GroupName designate the group of the point, if the time series is grouped.
+type AnalyticsTimeSeriesPointDTO struct { // skipcq: TCV-001
+	Bucket         time.Time       `json:"bucket"`
+	GroupKey       string          `json:"groupKey,omitempty"`
+	GroupName      string          `json:"groupName,omitempty"`
+	TotalAPICalls  int64           `json:"totalRequests"`
+	TotalErrors    int64           `json:"totalErrors"`
+	ErrorRate      float64         `json:"errorRate"`
+	RequestTokens  int64           `json:"requestTokens"`
+	ResponseTokens int64           `json:"responseTokens"`
+	TokenCost      decimal.Decimal `json:"tokensCost"`
+	P50DurationMs  float64         `json:"p50DurationMs"`
+	P95DurationMs  float64         `json:"p95DurationMs"`
+	P99DurationMs  float64         `json:"p99DurationMs"`
+}
+
+// TrafficSplitVariantDTO - DTO for a weighted prompt config of an application traffic split.
+type TrafficSplitVariantDTO struct { // skipcq: TCV-001
+	PromptConfigID string `json:"promptConfigId" validate:"required,uuid4"`
+	Weight         int32  `json:"weight"         validate:"min=1,max=100"`
+}
+
+// TrafficSplitDTO - DTO for serializing and updating the traffic split of an application.
+// The weights of the variants are percentages and must add up to 100. An empty split disables traffic splitting.
+type TrafficSplitDTO struct { // skipcq: TCV-001
+	Variants []TrafficSplitVariantDTO `json:"variants" validate:"dive"`
+}
+
+// TrafficSplitVariantAnalyticsDTO - DTO for serializing the analytics of a traffic split variant.
+type TrafficSplitVariantAnalyticsDTO struct { // skipcq: TCV-001
+	PromptConfigID    string          `json:"promptConfigId"`
+	PromptConfigName  string          `json:"promptConfigName"`
+	Weight            int32           `json:"weight"`
+	TotalAPICalls     int64           `json:"totalRequests"`
+	TotalErrors       int64           `json:"totalErrors"`
+	TokenCost         decimal.Decimal `json:"tokensCost"`
+	AverageDurationMs float64         `json:"averageDurationMs"`
+}
+
+// PayloadLoggingDTO - DTO for serializing and updating the payload logging settings of an application.
+// Payloads are kept for RetentionDays, and the redaction rules are applied, in order, before they are stored.
+type PayloadLoggingDTO struct { // skipcq: TCV-001
+	IsEnabled      bool                                `json:"isEnabled"`
+	RetentionDays  int32                               `json:"retentionDays"            validate:"min=1,max=365"`
+	RedactionRules []datatypes.PayloadRedactionRuleDTO `json:"redactionRules,omitempty" validate:"omitempty,max=20,dive"`
+}
+
+// PromptRequestPayloadDTO - DTO for serializing a logged prompt request payload.
+type PromptRequestPayloadDTO struct { // skipcq: TCV-001
+	ID                    string                    `json:"id"`
+	PromptRequestRecordID string                    `json:"promptRequestRecordId"`
+	PromptConfigID        string                    `json:"promptConfigId"`
+	PromptConfigName      string                    `json:"promptConfigName"`
+	TemplateVariables     map[string]string         `json:"templateVariables"`
+	RequestMessages       json.RawMessage           `json:"requestMessages"`
+	ResponseContent       string                    `json:"responseContent"`
+	FinishReason          models.PromptFinishReason `json:"finishReason"`
+	RequestTokens         int32                     `json:"requestTokens"`
+	ResponseTokens        int32                     `json:"responseTokens"`
+	DurationMs            int32                     `json:"durationMs"`
+	IsStreamResponse      bool                      `json:"isStreamResponse"`
+	IsCacheHit            bool                      `json:"isCacheHit"`
+	CreatedAt             time.Time                 `json:"createdAt"`
+}
+
+// PromptRequestPayloadPageDTO - DTO for serializing a page of logged prompt request payloads.
+// NextOffset is set if there are more payloads.
+type PromptRequestPayloadPageDTO struct { // skipcq: TCV-001
+	Payloads   []PromptRequestPayloadDTO `json:"payloads"`
+	NextOffset *int32                    `json:"nextOffset,omitempty"`
+}
+
+// SpendBudgetDTO - DTO for serializing a spend budget, and for its CREATE request body.
+// A budget without an application ID applies to the whole project.
+// CurrentSpend is the spend in the budget scope since PeriodStart, the start of the current period.
+type SpendBudgetDTO struct { // skipcq: TCV-001
+	ID              string          `json:"id,omitempty"`
+	ApplicationID   *string         `json:"applicationId,omitempty"   validate:"omitempty,uuid4"`
+	ApplicationName string          `json:"applicationName,omitempty"`
+	Period          string          `json:"period"                    validate:"required,oneof=DAILY MONTHLY"`
+	Enforcement     string          `json:"enforcement"               validate:"required,oneof=HARD SOFT"`
+	SpendLimit      decimal.Decimal `json:"spendLimit"`
+	CurrentSpend    decimal.Decimal `json:"currentSpend"`
+	PeriodStart     time.Time       `json:"periodStart"`
+	CreatedAt       time.Time       `json:"createdAt"`
+	UpdatedAt       time.Time       `json:"updatedAt"`
+}
+
+// SpendBudgetUpdateDTO - DTO for spend budget PATCH request body.
+type SpendBudgetUpdateDTO struct { // skipcq: TCV-001
+	Enforcement string          `json:"enforcement" validate:"required,oneof=HARD SOFT"`
+	SpendLimit  decimal.Decimal `json:"spendLimit"`
+}
+
+// SpendBudgetAlertDTO - DTO for serializing an alert raised when a spend budget reached a threshold.
+type SpendBudgetAlertDTO struct { // skipcq: TCV-001
+	ID              string          `json:"id"`
+	SpendBudgetID   string          `json:"spendBudgetId"`
+	ApplicationID   *string         `json:"applicationId,omitempty"`
+	ApplicationName string          `json:"applicationName,omitempty"`
+	Period          string          `json:"period"`
+	Enforcement     string          `json:"enforcement"`
+	SpendLimit      decimal.Decimal `json:"spendLimit"`
+	Threshold       int32           `json:"threshold"`
+	Spend           decimal.Decimal `json:"spend"`
+	PeriodStart     time.Time       `json:"periodStart"`
+	CreatedAt       time.Time       `json:"createdAt"`
+}
+
+// CreditReconciliationDTO - DTO for serializing the reconciliation of the credits of a project with its ledger.
+// Drift is the credits minus the ledger balance, UnrecordedDebits the request records whose cost was not debited.
+type CreditReconciliationDTO struct { // skipcq: TCV-001
+	Credits          decimal.Decimal `json:"credits"`
+	LedgerBalance    decimal.Decimal `json:"ledgerBalance"`
+	Drift            decimal.Decimal `json:"drift"`
+	UnrecordedDebits int             `json:"unrecordedDebits"`
+	UnrecordedAmount decimal.Decimal `json:"unrecordedAmount"`
+	IsApplied        bool            `json:"isApplied"`
+}
+
+// CreditGrantDTO - DTO for the credit grant POST request body.
+type CreditGrantDTO struct { // skipcq: TCV-001
+	Amount decimal.Decimal `json:"amount"`
+	Reason string          `json:"reason" validate:"required,max=255"`
+}
+
+// CreditLedgerEntryDTO - DTO for serializing an entry of the credit ledger of a project.
+// The DEBIT entries are the consumption of a day, aggregating EntriesCount request debits, and have no ID.
+type CreditLedgerEntryDTO struct { // skipcq: TCV-001
+	ID               *string         `json:"id,omitempty"`
+	EntryType        string          `json:"entryType"`
+	Amount           decimal.Decimal `json:"amount"`
+	Description      string          `json:"description"`
+	PaymentReference string          `json:"paymentReference,omitempty"`
+	CreatedBy        string          `json:"createdBy,omitempty"`
+	EntriesCount     int64           `json:"entriesCount"`
+	CreatedAt        time.Time       `json:"createdAt"`
+}
+
+// CreditLedgerPageDTO - DTO for serializing a page of the credit ledger of a project.
+// NextOffset is set if there are more entries.
+type CreditLedgerPageDTO struct { // skipcq: TCV-001
+	Entries    []CreditLedgerEntryDTO `json:"entries"`
+	NextOffset *int32                 `json:"nextOffset,omitempty"`
+}
+
+// CreditConsumptionDTO - DTO for serializing the credits consumed by the requests of a project in a day.
+type CreditConsumptionDTO struct { // skipcq: TCV-001
+	Date          time.Time       `json:"date"`
+	Consumption   decimal.Decimal `json:"consumption"`
+	RequestsCount int64           `json:"requestsCount"`
+}
+
+// CreditStatementDTO - DTO for serializing the monthly credit statement of a project.
+// The closing balance is the opening balance plus the amounts of the statement entries.
+type CreditStatementDTO struct { // skipcq: TCV-001
+	Month            string                 `json:"month"`
+	PeriodStart      time.Time              `json:"periodStart"`
+	PeriodEnd        time.Time              `json:"periodEnd"`
+	OpeningBalance   decimal.Decimal        `json:"openingBalance"`
+	TotalGrants      decimal.Decimal        `json:"totalGrants"`
+	TotalAdjustments decimal.Decimal        `json:"totalAdjustments"`
+	TotalConsumption decimal.Decimal        `json:"totalConsumption"`
+	ClosingBalance   decimal.Decimal        `json:"closingBalance"`
+	Entries          []CreditLedgerEntryDTO `json:"entries"`
+}
+
+// ProviderModelPricingDTO - DTO for serializing the pricing of a provider model, and for its CREATE request body.
+// The prices are per TokenUnitSize tokens. A pricing with a project ID is a negotiated override for that project.
+// The token classes without a price of their own are billed at the input price, or the output price for reasoning.
+// The dates are formatted as YYYY-MM-DD, and a pricing without ActiveToDate is active until it is ended.
+type ProviderModelPricingDTO struct { // skipcq: TCV-001
+	ID                    string             `json:"id,omitempty"`
+	ModelType             models.ModelType   `json:"modelType"                       validate:"oneof=gpt-3.5-turbo gpt-3.5-turbo-16k gpt-4 gpt-4-32k command command-light command-nightly command-light-nightly text-embedding-3-small text-embedding-3-large text-embedding-ada-002 embed-english-v3.0 embed-multilingual-v3.0"`
+	ModelVendor           models.ModelVendor `json:"modelVendor"                     validate:"oneof=OPEN_AI COHERE"`
+	InputTokenPrice       decimal.Decimal    `json:"inputTokenPrice"`
+	OutputTokenPrice      decimal.Decimal    `json:"outputTokenPrice"`
+	CachedInputTokenPrice *decimal.Decimal   `json:"cachedInputTokenPrice,omitempty"`
+	ReasoningTokenPrice   *decimal.Decimal   `json:"reasoningTokenPrice,omitempty"`
+	ImageTokenPrice       *decimal.Decimal   `json:"imageTokenPrice,omitempty"`
+	TokenUnitSize         int32              `json:"tokenUnitSize"          validate:"required"`
+	ActiveFromDate        string             `json:"activeFromDate"         validate:"required,datetime=2006-01-02"`
+	ActiveToDate          *string            `json:"activeToDate,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	ProjectID             *string            `json:"projectId,omitempty"    validate:"omitempty,uuid4"`
+}
+
+// ProviderModelPricingUpdateDTO - DTO for the provider model pricing PATCH request body.
+// A null ActiveToDate makes the pricing active until it is ended.
+type ProviderModelPricingUpdateDTO struct { // skipcq: TCV-001
+	ActiveToDate *string `json:"activeToDate" validate:"omitempty,datetime=2006-01-02"`
 }
 
 // PromptConfigTestDTO - DTO for requesting a prompt config test.
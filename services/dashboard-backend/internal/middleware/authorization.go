@@ -3,11 +3,13 @@ package middleware
 import (
 	"context"
 	"github.com/basemind-ai/monorepo/shared/go/apierror"
+	"github.com/basemind-ai/monorepo/shared/go/config"
 	"github.com/basemind-ai/monorepo/shared/go/db"
 	"github.com/basemind-ai/monorepo/shared/go/db/models"
 	"github.com/jackc/pgx/v5/pgtype"
 	"net/http"
 	"slices"
+	"strings"
 )
 
 type authorizationContextKeyType int
@@ -51,6 +53,7 @@ func AuthorizationMiddleware(
 
 				if !slices.Contains(permissions, userProject.Permission) {
 					apierror.Unauthorized("insufficient permissions").Render(w)
+					return
 				}
 
 				ctx = context.WithValue(r.Context(), UserProjectContextKey, userProject)
@@ -60,3 +63,28 @@ func AuthorizationMiddleware(
 		})
 	}
 }
+
+// IsPlatformAdmin - returns whether the user is a platform admin.
+// The platform admins are the users whose email is configured in PLATFORM_ADMIN_EMAILS.
+func IsPlatformAdmin(ctx context.Context, userAccount *models.UserAccount) bool {
+	return slices.ContainsFunc(
+		config.Get(ctx).PlatformAdminEmails,
+		func(email string) bool {
+			return strings.EqualFold(strings.TrimSpace(email), userAccount.Email)
+		},
+	)
+}
+
+// PlatformAdminMiddleware - middleware that allows only the platform admins to access an endpoint.
+func PlatformAdminMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAccount := r.Context().Value(UserAccountContextKey).(*models.UserAccount)
+
+		if !IsPlatformAdmin(r.Context(), userAccount) {
+			apierror.Forbidden("only platform admins can access this endpoint").Render(w)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
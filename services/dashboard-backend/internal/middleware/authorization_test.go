@@ -7,10 +7,12 @@ import (
 	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/middleware"
 	"github.com/basemind-ai/monorepo/shared/go/db"
 	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/testutils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -143,6 +145,49 @@ func TestAuthorizationMiddleware(t *testing.T) {
 			authorizationMiddleware(mockNext).ServeHTTP(testRecorder, request)
 
 			assert.Equal(t, http.StatusUnauthorized, testRecorder.Code)
+			mockNext.AssertNotCalled(t, "ServeHTTP", mock.Anything, mock.Anything)
+		},
+	)
+}
+
+func TestPlatformAdminMiddleware(t *testing.T) {
+	testutils.SetTestEnv(t)
+
+	t.Run("allows the request if the user is a platform admin", func(t *testing.T) {
+		userAccount, _ := factories.CreateUserAccount(context.TODO())
+		userAccount.Email = strings.ToUpper(testutils.PlatformAdminEmail)
+
+		mockNext := &nextMock{}
+		mockNext.On("ServeHTTP", mock.Anything, mock.Anything).Return()
+
+		request := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(
+			context.WithValue(context.TODO(), middleware.UserAccountContextKey, userAccount),
+		)
+
+		testRecorder := httptest.NewRecorder()
+		middleware.PlatformAdminMiddleware(mockNext).ServeHTTP(testRecorder, request)
+
+		assert.Equal(t, http.StatusOK, testRecorder.Code)
+		mockNext.AssertCalled(t, "ServeHTTP", mock.Anything, mock.Anything)
+	})
+
+	t.Run(
+		"responds with status 403 FORBIDDEN if the user is not a platform admin",
+		func(t *testing.T) {
+			userAccount, _ := factories.CreateUserAccount(context.TODO())
+
+			mockNext := &nextMock{}
+			mockNext.On("ServeHTTP", mock.Anything, mock.Anything).Return()
+
+			request := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(
+				context.WithValue(context.TODO(), middleware.UserAccountContextKey, userAccount),
+			)
+
+			testRecorder := httptest.NewRecorder()
+			middleware.PlatformAdminMiddleware(mockNext).ServeHTTP(testRecorder, request)
+
+			assert.Equal(t, http.StatusForbidden, testRecorder.Code)
+			mockNext.AssertNotCalled(t, "ServeHTTP", mock.Anything, mock.Anything)
 		},
 	)
 }
@@ -12,25 +12,31 @@ import (
 type PathURLContextKeyType int
 
 const (
-	APIKeyIDContextKey            PathURLContextKeyType = iota
-	ApplicationIDContextKey       PathURLContextKeyType = iota
-	ProjectIDContextKey           PathURLContextKeyType = iota
-	ProjectInvitationIDContextKey PathURLContextKeyType = iota
-	PromptConfigIDContextKey      PathURLContextKeyType = iota
-	PromptTestRecordIDKey         PathURLContextKeyType = iota
-	ProviderKeyIDContextKey       PathURLContextKeyType = iota
-	UserIDContextKey              PathURLContextKeyType = iota
+	APIKeyIDContextKey              PathURLContextKeyType = iota
+	ApplicationIDContextKey         PathURLContextKeyType = iota
+	ProjectIDContextKey             PathURLContextKeyType = iota
+	ProjectInvitationIDContextKey   PathURLContextKeyType = iota
+	PromptConfigIDContextKey        PathURLContextKeyType = iota
+	PromptConfigVersionIDContextKey PathURLContextKeyType = iota
+	PromptTestRecordIDKey           PathURLContextKeyType = iota
+	ProviderKeyIDContextKey         PathURLContextKeyType = iota
+	ProviderModelPricingIDKey       PathURLContextKeyType = iota
+	SpendBudgetIDContextKey         PathURLContextKeyType = iota
+	UserIDContextKey                PathURLContextKeyType = iota
 )
 
 var pathParameterNameToContextKeyMap = map[string]PathURLContextKeyType{
-	"apiKeyId":            APIKeyIDContextKey,
-	"applicationId":       ApplicationIDContextKey,
-	"projectId":           ProjectIDContextKey,
-	"projectInvitationId": ProjectInvitationIDContextKey,
-	"promptConfigId":      PromptConfigIDContextKey,
-	"promptTestRecordId":  PromptTestRecordIDKey,
-	"providerKeyId":       ProviderKeyIDContextKey,
-	"userId":              UserIDContextKey,
+	"apiKeyId":               APIKeyIDContextKey,
+	"applicationId":          ApplicationIDContextKey,
+	"projectId":              ProjectIDContextKey,
+	"projectInvitationId":    ProjectInvitationIDContextKey,
+	"promptConfigId":         PromptConfigIDContextKey,
+	"promptConfigVersionId":  PromptConfigVersionIDContextKey,
+	"promptTestRecordId":     PromptTestRecordIDKey,
+	"providerKeyId":          ProviderKeyIDContextKey,
+	"providerModelPricingId": ProviderModelPricingIDKey,
+	"spendBudgetId":          SpendBudgetIDContextKey,
+	"userId":                 UserIDContextKey,
 }
 
 // PathParameterMiddleware - middleware that parses path parameters and adds them to the request context.
@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/dto"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/exc"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+// MaxTimeSeriesBuckets is the maximum number of buckets of an analytics time series.
+const MaxTimeSeriesBuckets = 1000
+
+// timeSeriesIntervals maps the supported time series intervals to their bucket durations.
+var timeSeriesIntervals = map[string]time.Duration{
+	"hour": time.Hour,
+	"day":  24 * time.Hour,
+	"week": 7 * 24 * time.Hour,
+}
+
+// timeSeriesGroupings are the supported dimensions an analytics time series can be grouped by.
+var timeSeriesGroupings = map[string]struct{}{
+	"application":  {},
+	"promptConfig": {},
+	"modelType":    {},
+	"modelVendor":  {},
+	"finishReason": {},
+}
+
+// TimeSeriesScope - the records an analytics time series is computed from.
+// The application and prompt config IDs are optional, an invalid ID does not narrow the scope.
+type TimeSeriesScope struct {
+	ProjectID      pgtype.UUID
+	ApplicationID  pgtype.UUID
+	PromptConfigID pgtype.UUID
+}
+
+// ValidateTimeSeriesParams - validates the interval and grouping of an analytics time series.
+// An empty interval is valid and means no time series was requested, in which case there must be no grouping.
+func ValidateTimeSeriesParams(interval, groupBy string, fromDate, toDate time.Time) error {
+	if interval == "" {
+		if groupBy != "" {
+			return fmt.Errorf("invalid time series - groupBy requires an interval")
+		}
+
+		return nil
+	}
+
+	bucketSize, ok := timeSeriesIntervals[interval]
+	if !ok {
+		return fmt.Errorf(
+			"invalid time series - interval must be one of hour, day or week, got %s",
+			interval,
+		)
+	}
+
+	if _, ok := timeSeriesGroupings[groupBy]; groupBy != "" && !ok {
+		return fmt.Errorf(
+			"invalid time series - groupBy must be one of application, promptConfig, modelType, modelVendor or finishReason, got %s",
+			groupBy,
+		)
+	}
+
+	if toDate.Sub(fromDate)/bucketSize > MaxTimeSeriesBuckets {
+		return fmt.Errorf(
+			"invalid time series - the date range exceeds %d %s buckets",
+			MaxTimeSeriesBuckets,
+			interval,
+		)
+	}
+
+	return nil
+}
+
+// GetAnalyticsTimeSeries - returns the analytics of the prompt and embedding requests in the scope,
+// bucketed by the interval, and grouped by the given dimension if groupBy is not empty.
+// Buckets without requests are omitted.
+func GetAnalyticsTimeSeries(
+	ctx context.Context,
+	scope TimeSeriesScope,
+	interval, groupBy string,
+	fromDate, toDate time.Time,
+) []dto.AnalyticsTimeSeriesPointDTO {
+	rows := exc.MustResult(db.GetQueries().RetrievePromptRequestTimeSeries(
+		ctx,
+		models.RetrievePromptRequestTimeSeriesParams{
+			BucketSize:     interval,
+			GroupBy:        groupBy,
+			ProjectID:      scope.ProjectID,
+			ApplicationID:  scope.ApplicationID,
+			PromptConfigID: scope.PromptConfigID,
+			FromDate:       pgtype.Timestamptz{Time: fromDate, Valid: true},
+			ToDate:         pgtype.Timestamptz{Time: toDate, Valid: true},
+		},
+	))
+
+	timeSeries := make([]dto.AnalyticsTimeSeriesPointDTO, len(rows))
+	for i, row := range rows {
+		errorRate := float64(0)
+		if row.TotalRequests > 0 {
+			errorRate = float64(row.TotalErrors) / float64(row.TotalRequests)
+		}
+
+		timeSeries[i] = dto.AnalyticsTimeSeriesPointDTO{
+			Bucket:         row.Bucket.Time,
+			GroupKey:       row.GroupKey,
+			GroupName:      row.GroupName,
+			TotalAPICalls:  row.TotalRequests,
+			TotalErrors:    row.TotalErrors,
+			ErrorRate:      errorRate,
+			RequestTokens:  row.RequestTokens,
+			ResponseTokens: row.ResponseTokens,
+			TokenCost:      *exc.MustResult(db.NumericToDecimal(row.TokensCost)),
+			P50DurationMs:  row.P50DurationMs,
+			P95DurationMs:  row.P95DurationMs,
+			P99DurationMs:  row.P99DurationMs,
+		}
+	}
+
+	return timeSeries
+}
@@ -0,0 +1,191 @@
+package repositories_test
+
+import (
+	"context"
+	"github.com/basemind-ai/monorepo/e2e/factories"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/repositories"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/exc"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestAnalyticsRepository(t *testing.T) { //nolint: revive
+	project, _ := factories.CreateProject(context.TODO())
+
+	t.Run("ValidateTimeSeriesParams", func(t *testing.T) {
+		now := time.Now()
+
+		for _, testCase := range []struct {
+			Name     string
+			Interval string
+			GroupBy  string
+			FromDate time.Time
+			IsValid  bool
+		}{
+			{Name: "allows no time series", FromDate: now.Add(-time.Hour), IsValid: true},
+			{Name: "allows an interval", Interval: "day", FromDate: now.AddDate(0, -1, 0), IsValid: true},
+			{
+				Name:     "allows an interval with a grouping",
+				Interval: "week",
+				GroupBy:  "modelVendor",
+				FromDate: now.AddDate(-1, 0, 0),
+				IsValid:  true,
+			},
+			{Name: "rejects an unknown interval", Interval: "month", FromDate: now, IsValid: false},
+			{
+				Name:     "rejects an unknown grouping",
+				Interval: "day",
+				GroupBy:  "userKey",
+				FromDate: now,
+				IsValid:  false,
+			},
+			{Name: "rejects a grouping without an interval", GroupBy: "modelType", FromDate: now, IsValid: false},
+			{
+				Name:     "rejects too many buckets",
+				Interval: "hour",
+				FromDate: now.AddDate(-1, 0, 0),
+				IsValid:  false,
+			},
+		} {
+			t.Run(testCase.Name, func(t *testing.T) {
+				err := repositories.ValidateTimeSeriesParams(
+					testCase.Interval,
+					testCase.GroupBy,
+					testCase.FromDate,
+					now,
+				)
+				if testCase.IsValid {
+					assert.NoError(t, err)
+				} else {
+					assert.ErrorContains(t, err, "invalid time series")
+				}
+			})
+		}
+	})
+
+	t.Run("GetAnalyticsTimeSeries", func(t *testing.T) {
+		application, _ := factories.CreateApplication(context.TODO(), project.ID)
+		promptConfig, _ := factories.CreateOpenAIPromptConfig(context.TODO(), application.ID)
+
+		for i, finishReason := range []models.PromptFinishReason{
+			models.PromptFinishReasonDONE,
+			models.PromptFinishReasonDONE,
+			models.PromptFinishReasonDONE,
+			models.PromptFinishReasonERROR,
+		} {
+			_, err := db.GetQueries().CreatePromptRequestRecord(
+				context.TODO(),
+				models.CreatePromptRequestRecordParams{
+					PromptConfigID:     promptConfig.ID,
+					RequestTokens:      10,
+					ResponseTokens:     20,
+					RequestTokensCost:  *exc.MustResult(db.StringToNumeric("0.1")),
+					ResponseTokensCost: *exc.MustResult(db.StringToNumeric("0.2")),
+					StartTime:          pgtype.Timestamptz{Time: time.Now(), Valid: true},
+					FinishTime:         pgtype.Timestamptz{Time: time.Now(), Valid: true},
+					DurationMs:         pgtype.Int4{Int32: int32(100 * (i + 1)), Valid: true},
+					FinishReason:       finishReason,
+					Attempts:           1,
+				},
+			)
+			assert.NoError(t, err)
+		}
+
+		fromDate := time.Now().Add(-time.Hour)
+		toDate := time.Now().Add(time.Hour)
+
+		t.Run("returns the bucketed analytics of the requests", func(t *testing.T) {
+			timeSeries := repositories.GetAnalyticsTimeSeries(
+				context.TODO(),
+				repositories.TimeSeriesScope{ProjectID: project.ID, ApplicationID: application.ID},
+				"day",
+				"",
+				fromDate,
+				toDate,
+			)
+			assert.NotEmpty(t, timeSeries)
+
+			totalAPICalls := int64(0)
+			for _, point := range timeSeries {
+				assert.Empty(t, point.GroupKey)
+				totalAPICalls += point.TotalAPICalls
+			}
+
+			assert.Equal(t, int64(4), totalAPICalls)
+		})
+
+		t.Run("groups the analytics by finish reason", func(t *testing.T) {
+			timeSeries := repositories.GetAnalyticsTimeSeries(
+				context.TODO(),
+				repositories.TimeSeriesScope{ProjectID: project.ID, PromptConfigID: promptConfig.ID},
+				"week",
+				"finishReason",
+				fromDate,
+				toDate,
+			)
+
+			points := map[string]int{}
+			for i, point := range timeSeries {
+				points[point.GroupKey] = i
+			}
+
+			// the requests of the test fall into a single week, unless it runs across the start of a week
+			if len(timeSeries) != 2 {
+				t.Skip("the requests fall into more than one bucket")
+			}
+
+			done := timeSeries[points["DONE"]]
+			assert.Equal(t, int64(3), done.TotalAPICalls)
+			assert.Equal(t, int64(0), done.TotalErrors)
+			assert.Equal(t, int64(30), done.RequestTokens)
+			assert.Equal(t, int64(60), done.ResponseTokens)
+			assert.True(t, decimal.RequireFromString("0.9").Equal(done.TokenCost))
+			assert.Equal(t, float64(200), done.P50DurationMs)
+
+			errored := timeSeries[points["ERROR"]]
+			assert.Equal(t, int64(1), errored.TotalAPICalls)
+			assert.Equal(t, float64(1), errored.ErrorRate)
+			assert.Equal(t, float64(400), errored.P99DurationMs)
+		})
+
+		t.Run("groups the analytics by prompt config", func(t *testing.T) {
+			timeSeries := repositories.GetAnalyticsTimeSeries(
+				context.TODO(),
+				repositories.TimeSeriesScope{ProjectID: project.ID, ApplicationID: application.ID},
+				"day",
+				"promptConfig",
+				fromDate,
+				toDate,
+			)
+			assert.NotEmpty(t, timeSeries)
+
+			for _, point := range timeSeries {
+				assert.Equal(t, db.UUIDToString(&promptConfig.ID), point.GroupKey)
+				assert.Equal(t, promptConfig.Name, point.GroupName)
+			}
+		})
+
+		t.Run("includes the embedding requests, which have no prompt config", func(t *testing.T) {
+			embeddingsApplication, _ := factories.CreateApplication(context.TODO(), project.ID)
+			_, _ = factories.CreateEmbeddingRequestRecord(context.TODO(), embeddingsApplication.ID)
+
+			timeSeries := repositories.GetAnalyticsTimeSeries(
+				context.TODO(),
+				repositories.TimeSeriesScope{ProjectID: project.ID, ApplicationID: embeddingsApplication.ID},
+				"day",
+				"promptConfig",
+				fromDate,
+				toDate,
+			)
+			assert.Len(t, timeSeries, 1)
+			assert.Equal(t, int64(1), timeSeries[0].TotalAPICalls)
+			assert.Empty(t, timeSeries[0].GroupKey)
+			assert.Empty(t, timeSeries[0].GroupName)
+		})
+	})
+}
@@ -128,5 +128,23 @@ func TestApplicationRepository(t *testing.T) {
 				applicationAnalytics.TokenCost.String(),
 			)
 		})
+
+		t.Run("includes the embedding requests of the application", func(t *testing.T) {
+			embeddingsApplication, _ := factories.CreateApplication(context.TODO(), project.ID)
+			_, _ = factories.CreateEmbeddingRequestRecord(context.TODO(), embeddingsApplication.ID)
+
+			applicationAnalytics := repositories.GetApplicationAnalyticsByDateRange(
+				context.TODO(),
+				embeddingsApplication.ID,
+				fromDate,
+				toDate,
+			)
+			assert.Equal(t, int64(1), applicationAnalytics.TotalAPICalls)
+			assert.Equal(
+				t,
+				decimal.RequireFromString("0.0000465").String(),
+				applicationAnalytics.TokenCost.String(),
+			)
+		})
 	})
 }
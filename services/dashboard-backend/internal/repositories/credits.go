@@ -0,0 +1,257 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/dto"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/exc"
+	"github.com/basemind-ai/monorepo/shared/go/ledger"
+	"github.com/basemind-ai/monorepo/shared/go/payments"
+	"github.com/basemind-ai/monorepo/shared/go/rediscache"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"math"
+	"slices"
+	"time"
+)
+
+// ErrInvalidCreditGrant is returned when a credit grant is invalid, e.g. when its amount is not positive.
+var ErrInvalidCreditGrant = errors.New("invalid credit grant")
+
+// ReconcileProjectCredits - reconciles the credits of a project with its ledger.
+// If apply is true, the unrecorded debits are debited and the drift is recorded as an adjustment.
+func ReconcileProjectCredits(
+	ctx context.Context,
+	projectID pgtype.UUID,
+	apply bool,
+) (*dto.CreditReconciliationDTO, error) {
+	report, reconciliationErr := ledger.Reconcile(ctx, projectID, apply)
+	if reconciliationErr != nil {
+		return nil, reconciliationErr
+	}
+
+	if apply && report.UnrecordedDebits > 0 {
+		go func() {
+			rediscache.Invalidate(ctx, db.UUIDToString(&projectID))
+		}()
+	}
+
+	return &dto.CreditReconciliationDTO{
+		Credits:          report.Credits,
+		LedgerBalance:    report.LedgerBalance,
+		Drift:            report.Drift,
+		UnrecordedDebits: report.UnrecordedDebits,
+		UnrecordedAmount: report.UnrecordedAmount,
+		IsApplied:        report.IsApplied,
+	}, nil
+}
+
+// ValidateCreditGrantAmount - validates that the amount of a credit grant is positive.
+func ValidateCreditGrantAmount(amount decimal.Decimal) error {
+	if !amount.IsPositive() {
+		return fmt.Errorf(
+			"%w - the amount must be positive, got %s",
+			ErrInvalidCreditGrant,
+			amount.String(),
+		)
+	}
+
+	return nil
+}
+
+// GrantCredits - charges a credit top-up with the payment provider, and grants the credits to the project.
+// The credits are granted only if the charge succeeded.
+func GrantCredits(
+	ctx context.Context,
+	projectID pgtype.UUID,
+	userAccount *models.UserAccount,
+	data dto.CreditGrantDTO,
+) (*dto.CreditLedgerEntryDTO, error) {
+	if validationErr := ValidateCreditGrantAmount(data.Amount); validationErr != nil {
+		return nil, validationErr
+	}
+
+	paymentReference, chargeErr := payments.GetProvider().Charge(ctx, payments.TopUp{
+		ProjectID:     db.UUIDToString(&projectID),
+		UserAccountID: db.UUIDToString(&userAccount.ID),
+		Amount:        data.Amount,
+		Reason:        data.Reason,
+	})
+	if chargeErr != nil {
+		return nil, fmt.Errorf("failed to charge credit top-up - %w", chargeErr)
+	}
+
+	entry, grantErr := ledger.RecordGrant(
+		ctx,
+		projectID,
+		userAccount.ID,
+		data.Amount,
+		data.Reason,
+		paymentReference,
+	)
+	if grantErr != nil {
+		return nil, fmt.Errorf(
+			"failed to grant credits of payment with reference '%s' - %w",
+			paymentReference,
+			grantErr,
+		)
+	}
+
+	// the cached credit check of the gateway must not reject the requests of a project that ran out of credits
+	go func() {
+		rediscache.Invalidate(ctx, db.UUIDToString(&projectID))
+	}()
+
+	return &dto.CreditLedgerEntryDTO{
+		ID:               optionalUUIDToString(entry.ID),
+		EntryType:        string(entry.EntryType),
+		Amount:           data.Amount,
+		Description:      entry.Description,
+		PaymentReference: entry.PaymentReference,
+		CreatedBy:        userAccount.DisplayName,
+		EntriesCount:     1,
+		CreatedAt:        entry.CreatedAt.Time,
+	}, nil
+}
+
+// retrieveCreditLedgerEntries - returns the ledger entries of a project in the given time range, latest first.
+// The debits are aggregated per day.
+func retrieveCreditLedgerEntries(
+	ctx context.Context,
+	projectID pgtype.UUID,
+	fromDate, toDate time.Time,
+	limit, offset int32,
+) []dto.CreditLedgerEntryDTO {
+	rows := exc.MustResult(db.GetQueries().RetrieveProjectCreditLedger(
+		ctx,
+		models.RetrieveProjectCreditLedgerParams{
+			ProjectID:  projectID,
+			FromDate:   pgtype.Timestamptz{Time: fromDate, Valid: true},
+			ToDate:     pgtype.Timestamptz{Time: toDate, Valid: true},
+			PageLimit:  limit,
+			PageOffset: offset,
+		},
+	))
+
+	entries := make([]dto.CreditLedgerEntryDTO, len(rows))
+	for i, row := range rows {
+		entries[i] = dto.CreditLedgerEntryDTO{
+			ID:               optionalUUIDToString(row.ID),
+			EntryType:        string(row.EntryType),
+			Amount:           *exc.MustResult(db.NumericToDecimal(row.Amount)),
+			Description:      row.Description,
+			PaymentReference: row.PaymentReference,
+			CreatedBy:        row.CreatedBy.String,
+			EntriesCount:     row.EntriesCount,
+			CreatedAt:        row.CreatedAt.Time,
+		}
+	}
+
+	return entries
+}
+
+// GetCreditLedger - returns a page of the credit ledger of a project, latest first.
+// The grants and adjustments are returned as they are, and the debits are aggregated into the consumption of a day.
+func GetCreditLedger(
+	ctx context.Context,
+	projectID pgtype.UUID,
+	fromDate, toDate time.Time,
+	limit, offset int32,
+) dto.CreditLedgerPageDTO {
+	// an extra entry is retrieved to tell if there is a next page
+	entries := retrieveCreditLedgerEntries(ctx, projectID, fromDate, toDate, limit+1, offset)
+
+	page := dto.CreditLedgerPageDTO{Entries: entries}
+
+	if len(entries) > int(limit) {
+		page.Entries = entries[:limit]
+		nextOffset := offset + limit
+		page.NextOffset = &nextOffset
+	}
+
+	return page
+}
+
+// GetCreditConsumption - returns the credits consumed by the requests of a project per day in the given time range.
+func GetCreditConsumption(
+	ctx context.Context,
+	projectID pgtype.UUID,
+	fromDate, toDate time.Time,
+) []dto.CreditConsumptionDTO {
+	rows := exc.MustResult(db.GetQueries().RetrieveProjectDailyCreditConsumption(
+		ctx,
+		models.RetrieveProjectDailyCreditConsumptionParams{
+			ProjectID: projectID,
+			FromDate:  pgtype.Timestamptz{Time: fromDate, Valid: true},
+			ToDate:    pgtype.Timestamptz{Time: toDate, Valid: true},
+		},
+	))
+
+	consumption := make([]dto.CreditConsumptionDTO, len(rows))
+	for i, row := range rows {
+		consumption[i] = dto.CreditConsumptionDTO{
+			Date:          row.Date.Time,
+			Consumption:   *exc.MustResult(db.NumericToDecimal(row.Consumption)),
+			RequestsCount: row.RequestsCount,
+		}
+	}
+
+	return consumption
+}
+
+// GetCreditStatement - returns the credit statement of a project for the month starting at the given time.
+// The statement entries are the grants, adjustments and daily consumption of the month, in chronological order.
+func GetCreditStatement(
+	ctx context.Context,
+	projectID pgtype.UUID,
+	periodStart time.Time,
+) dto.CreditStatementDTO {
+	periodEnd := periodStart.AddDate(0, 1, 0)
+
+	openingBalance := exc.MustResult(db.NumericToDecimal(
+		exc.MustResult(db.GetQueries().RetrieveProjectCreditLedgerBalanceBefore(
+			ctx,
+			models.RetrieveProjectCreditLedgerBalanceBeforeParams{
+				ProjectID: projectID,
+				CreatedAt: pgtype.Timestamptz{Time: periodStart, Valid: true},
+			},
+		)),
+	))
+
+	entries := retrieveCreditLedgerEntries(
+		ctx,
+		projectID,
+		periodStart,
+		periodEnd,
+		math.MaxInt32,
+		0,
+	)
+	slices.Reverse(entries)
+
+	statement := dto.CreditStatementDTO{
+		Month:          periodStart.Format("2006-01"),
+		PeriodStart:    periodStart,
+		PeriodEnd:      periodEnd,
+		OpeningBalance: *openingBalance,
+		ClosingBalance: *openingBalance,
+		Entries:        entries,
+	}
+
+	for _, entry := range entries {
+		switch models.CreditLedgerEntryType(entry.EntryType) {
+		case models.CreditLedgerEntryTypeGRANT:
+			statement.TotalGrants = statement.TotalGrants.Add(entry.Amount)
+		case models.CreditLedgerEntryTypeADJUSTMENT:
+			statement.TotalAdjustments = statement.TotalAdjustments.Add(entry.Amount)
+		case models.CreditLedgerEntryTypeDEBIT:
+			statement.TotalConsumption = statement.TotalConsumption.Sub(entry.Amount)
+		}
+
+		statement.ClosingBalance = statement.ClosingBalance.Add(entry.Amount)
+	}
+
+	return statement
+}
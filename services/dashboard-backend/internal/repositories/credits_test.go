@@ -0,0 +1,27 @@
+package repositories_test
+
+import (
+	"testing"
+
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/repositories"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreditsRepository(t *testing.T) {
+	t.Run("ValidateCreditGrantAmount", func(t *testing.T) {
+		t.Run("allows a positive amount", func(t *testing.T) {
+			assert.NoError(t, repositories.ValidateCreditGrantAmount(decimal.NewFromInt(10)))
+		})
+
+		t.Run("returns ErrInvalidCreditGrant for an amount that is not positive", func(t *testing.T) {
+			for _, amount := range []decimal.Decimal{decimal.Zero, decimal.NewFromInt(-10)} {
+				assert.ErrorIs(
+					t,
+					repositories.ValidateCreditGrantAmount(amount),
+					repositories.ErrInvalidCreditGrant,
+				)
+			}
+		})
+	})
+}
@@ -0,0 +1,164 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/dto"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/exc"
+	"github.com/basemind-ai/monorepo/shared/go/redaction"
+	"github.com/basemind-ai/monorepo/shared/go/rediscache"
+	"github.com/basemind-ai/monorepo/shared/go/serialization"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+	"time"
+)
+
+// applicationToPayloadLoggingDTO - maps the payload logging settings of an application into a DTO.
+func applicationToPayloadLoggingDTO(
+	isEnabled bool,
+	retentionDays int32,
+	redactionRules []byte,
+) (*dto.PayloadLoggingDTO, error) {
+	payloadLogging := &dto.PayloadLoggingDTO{
+		IsEnabled:     isEnabled,
+		RetentionDays: retentionDays,
+	}
+
+	if len(redactionRules) > 0 {
+		if unmarshalErr := json.Unmarshal(redactionRules, &payloadLogging.RedactionRules); unmarshalErr != nil {
+			return nil, fmt.Errorf("failed to unmarshal payload redaction rules - %w", unmarshalErr)
+		}
+	}
+
+	return payloadLogging, nil
+}
+
+// GetPayloadLogging - returns the payload logging settings of an application.
+func GetPayloadLogging(
+	ctx context.Context,
+	applicationID pgtype.UUID,
+) (*dto.PayloadLoggingDTO, error) {
+	application, retrievalErr := db.GetQueries().RetrieveApplication(ctx, applicationID)
+	if retrievalErr != nil {
+		return nil, fmt.Errorf("failed to retrieve application - %w", retrievalErr)
+	}
+
+	return applicationToPayloadLoggingDTO(
+		application.PayloadLoggingEnabled,
+		application.PayloadLogRetentionDays,
+		application.PayloadRedactionRules,
+	)
+}
+
+// UpdatePayloadLogging - updates the payload logging settings of an application.
+// The redaction rules are compiled before they are saved, so invalid rules are rejected.
+func UpdatePayloadLogging(
+	ctx context.Context,
+	applicationID pgtype.UUID,
+	payloadLogging dto.PayloadLoggingDTO,
+) (*dto.PayloadLoggingDTO, error) {
+	if _, compileErr := redaction.Compile(payloadLogging.RedactionRules); compileErr != nil {
+		return nil, compileErr
+	}
+
+	var redactionRules []byte
+	if len(payloadLogging.RedactionRules) > 0 {
+		redactionRules = serialization.SerializeJSON(payloadLogging.RedactionRules)
+	}
+
+	application, updateErr := db.GetQueries().
+		UpdateApplicationPayloadLogging(ctx, models.UpdateApplicationPayloadLoggingParams{
+			ID:                      applicationID,
+			PayloadLoggingEnabled:   payloadLogging.IsEnabled,
+			PayloadLogRetentionDays: payloadLogging.RetentionDays,
+			PayloadRedactionRules:   redactionRules,
+		})
+	if updateErr != nil {
+		return nil, fmt.Errorf("failed to update payload logging - %w", updateErr)
+	}
+
+	// the settings are part of the cached request configuration of every prompt config of the application
+	promptConfigs := exc.MustResult(db.GetQueries().RetrievePromptConfigs(ctx, applicationID))
+
+	go func() {
+		cacheKeys := []string{db.UUIDToString(&applicationID)}
+		for _, promptConfig := range promptConfigs {
+			cacheKeys = append(cacheKeys, fmt.Sprintf(
+				"%s:%s",
+				db.UUIDToString(&applicationID),
+				db.UUIDToString(&promptConfig.ID),
+			))
+		}
+
+		rediscache.Invalidate(ctx, cacheKeys...)
+	}()
+
+	return applicationToPayloadLoggingDTO(
+		application.PayloadLoggingEnabled,
+		application.PayloadLogRetentionDays,
+		application.PayloadRedactionRules,
+	)
+}
+
+// GetPromptRequestPayloads - returns a page of the logged payloads of an application, latest first.
+// Payloads are filtered by their creation date and, if search is not empty,
+// by a case-insensitive match of their template variables, messages or response content.
+func GetPromptRequestPayloads(
+	ctx context.Context,
+	applicationID pgtype.UUID,
+	search string,
+	fromDate, toDate time.Time,
+	limit, offset int32,
+) dto.PromptRequestPayloadPageDTO {
+	// an extra row is retrieved to tell if there is a next page
+	rows := exc.MustResult(db.GetQueries().RetrievePromptRequestPayloads(
+		ctx,
+		models.RetrievePromptRequestPayloadsParams{
+			ApplicationID: applicationID,
+			FromDate:      pgtype.Timestamptz{Time: fromDate, Valid: true},
+			ToDate:        pgtype.Timestamptz{Time: toDate, Valid: true},
+			Search:        search,
+			PageLimit:     limit + 1,
+			PageOffset:    offset,
+		},
+	))
+
+	page := dto.PromptRequestPayloadPageDTO{
+		Payloads: make([]dto.PromptRequestPayloadDTO, 0, min(len(rows), int(limit))),
+	}
+
+	if len(rows) > int(limit) {
+		rows = rows[:limit]
+		nextOffset := offset + limit
+		page.NextOffset = &nextOffset
+	}
+
+	for _, row := range rows {
+		templateVariables := map[string]string{}
+		if unmarshalErr := json.Unmarshal(row.TemplateVariables, &templateVariables); unmarshalErr != nil {
+			log.Error().Err(unmarshalErr).Msg("failed to unmarshal payload template variables")
+		}
+
+		page.Payloads = append(page.Payloads, dto.PromptRequestPayloadDTO{
+			ID:                    db.UUIDToString(&row.ID),
+			PromptRequestRecordID: db.UUIDToString(&row.PromptRequestRecordID),
+			PromptConfigID:        db.UUIDToString(&row.PromptConfigID),
+			PromptConfigName:      row.PromptConfigName,
+			TemplateVariables:     templateVariables,
+			RequestMessages:       row.RequestMessages,
+			ResponseContent:       row.ResponseContent,
+			FinishReason:          row.FinishReason,
+			RequestTokens:         row.RequestTokens,
+			ResponseTokens:        row.ResponseTokens,
+			DurationMs:            row.DurationMs.Int32,
+			IsStreamResponse:      row.IsStreamResponse,
+			IsCacheHit:            row.IsCacheHit,
+			CreatedAt:             row.CreatedAt.Time,
+		})
+	}
+
+	return page
+}
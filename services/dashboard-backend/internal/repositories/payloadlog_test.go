@@ -0,0 +1,145 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/basemind-ai/monorepo/e2e/factories"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/dto"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/repositories"
+	"github.com/basemind-ai/monorepo/shared/go/datatypes"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/basemind-ai/monorepo/shared/go/redaction"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayloadLogRepository(t *testing.T) { //nolint: revive
+	project, _ := factories.CreateProject(context.TODO())
+
+	t.Run("UpdatePayloadLogging", func(t *testing.T) {
+		t.Run("updates the payload logging settings of the application", func(t *testing.T) {
+			application, _ := factories.CreateApplication(context.TODO(), project.ID)
+
+			payloadLogging := dto.PayloadLoggingDTO{
+				IsEnabled:     true,
+				RetentionDays: 7,
+				RedactionRules: []datatypes.PayloadRedactionRuleDTO{
+					{Name: "email", Preset: ptr.To(redaction.PresetEmail)},
+					{Name: "ticket", Pattern: ptr.To(`TICKET-\d+`), Replacement: ptr.To("<ticket>")},
+				},
+			}
+
+			updated, err := repositories.UpdatePayloadLogging(
+				context.TODO(),
+				application.ID,
+				payloadLogging,
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, payloadLogging, *updated)
+
+			retrieved, err := repositories.GetPayloadLogging(context.TODO(), application.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, payloadLogging, *retrieved)
+		})
+
+		t.Run("rejects an invalid redaction rule", func(t *testing.T) {
+			application, _ := factories.CreateApplication(context.TODO(), project.ID)
+
+			_, err := repositories.UpdatePayloadLogging(context.TODO(), application.ID, dto.PayloadLoggingDTO{
+				IsEnabled:     true,
+				RetentionDays: 7,
+				RedactionRules: []datatypes.PayloadRedactionRuleDTO{
+					{Name: "invalid", Pattern: ptr.To(`(\d+`)},
+				},
+			})
+			assert.ErrorContains(t, err, "invalid redaction rule")
+
+			retrieved, err := repositories.GetPayloadLogging(context.TODO(), application.ID)
+			assert.NoError(t, err)
+			assert.False(t, retrieved.IsEnabled)
+		})
+	})
+
+	t.Run("GetPromptRequestPayloads", func(t *testing.T) {
+		application, _ := factories.CreateApplication(context.TODO(), project.ID)
+		promptConfig, _ := factories.CreateOpenAIPromptConfig(context.TODO(), application.ID)
+
+		for _, responseContent := range []string{"cheddar", "brie", "gouda"} {
+			requestRecord, _ := factories.CreatePromptRequestRecord(context.TODO(), promptConfig.ID)
+			assert.NoError(t, db.GetQueries().CreatePromptRequestPayload(
+				context.TODO(),
+				models.CreatePromptRequestPayloadParams{
+					TemplateVariables:     []byte(`{"userInput": "cheese"}`),
+					RequestMessages:       []byte(`[{"role": "user", "content": "cheese"}]`),
+					ResponseContent:       responseContent,
+					PromptRequestRecordID: requestRecord.ID,
+					ApplicationID:         application.ID,
+				},
+			))
+		}
+
+		fromDate := time.Now().Add(-time.Hour)
+		toDate := time.Now().Add(time.Hour)
+
+		t.Run("pages through the payloads, latest first", func(t *testing.T) {
+			page := repositories.GetPromptRequestPayloads(
+				context.TODO(),
+				application.ID,
+				"",
+				fromDate,
+				toDate,
+				2,
+				0,
+			)
+			assert.Len(t, page.Payloads, 2)
+			assert.Equal(t, "gouda", page.Payloads[0].ResponseContent)
+			assert.Equal(t, "brie", page.Payloads[1].ResponseContent)
+			assert.Equal(t, db.UUIDToString(&promptConfig.ID), page.Payloads[0].PromptConfigID)
+			assert.Equal(t, map[string]string{"userInput": "cheese"}, page.Payloads[0].TemplateVariables)
+			assert.Equal(t, ptr.To(int32(2)), page.NextOffset)
+
+			page = repositories.GetPromptRequestPayloads(
+				context.TODO(),
+				application.ID,
+				"",
+				fromDate,
+				toDate,
+				2,
+				*page.NextOffset,
+			)
+			assert.Len(t, page.Payloads, 1)
+			assert.Equal(t, "cheddar", page.Payloads[0].ResponseContent)
+			assert.Nil(t, page.NextOffset)
+		})
+
+		t.Run("searches the payloads", func(t *testing.T) {
+			page := repositories.GetPromptRequestPayloads(
+				context.TODO(),
+				application.ID,
+				"BRIE",
+				fromDate,
+				toDate,
+				10,
+				0,
+			)
+			assert.Len(t, page.Payloads, 1)
+			assert.Equal(t, "brie", page.Payloads[0].ResponseContent)
+		})
+
+		t.Run("filters the payloads by date", func(t *testing.T) {
+			page := repositories.GetPromptRequestPayloads(
+				context.TODO(),
+				application.ID,
+				"",
+				time.Now().Add(time.Hour),
+				time.Now().Add(2*time.Hour),
+				10,
+				0,
+			)
+			assert.Empty(t, page.Payloads)
+		})
+	})
+}
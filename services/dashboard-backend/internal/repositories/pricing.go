@@ -0,0 +1,455 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/dto"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/exc"
+	"github.com/basemind-ai/monorepo/shared/go/rediscache"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+// pricingDateLayout is the layout of the dates of a provider model pricing.
+const pricingDateLayout = "2006-01-02"
+
+// ErrProviderModelPricingNotFound is returned when a provider model pricing does not exist.
+var ErrProviderModelPricingNotFound = errors.New("provider model pricing not found")
+
+// today - returns the current date in UTC.
+func today() time.Time {
+	return time.Now().UTC().Truncate(24 * time.Hour)
+}
+
+// parsePricingDate - parses a pricing date in the YYYY-MM-DD layout.
+func parsePricingDate(value string) (time.Time, error) {
+	date, parseErr := time.Parse(pricingDateLayout, value)
+	if parseErr != nil {
+		return time.Time{}, fmt.Errorf("invalid pricing - malformed date '%s'", value)
+	}
+
+	return date, nil
+}
+
+// parseOptionalPricingDate - parses a nullable pricing date, returning an invalid date if it is nil.
+func parseOptionalPricingDate(value *string) (pgtype.Date, error) {
+	if value == nil {
+		return pgtype.Date{}, nil
+	}
+
+	date, parseErr := parsePricingDate(*value)
+	if parseErr != nil {
+		return pgtype.Date{}, parseErr
+	}
+
+	return pgtype.Date{Time: date, Valid: true}, nil
+}
+
+// ValidateActivePricingRange - validates that the end of a pricing range does not change the past.
+// The range must end on or after its start and today, or not end at all.
+func ValidateActivePricingRange(activeFromDate time.Time, activeToDate pgtype.Date) error {
+	if activeToDate.Valid {
+		if activeToDate.Time.Before(activeFromDate) {
+			return fmt.Errorf(
+				"invalid pricing - the active to date %s is before the active from date %s",
+				activeToDate.Time.Format(pricingDateLayout),
+				activeFromDate.Format(pricingDateLayout),
+			)
+		}
+
+		if activeToDate.Time.Before(today()) {
+			return fmt.Errorf(
+				"invalid pricing - the active to date %s is in the past",
+				activeToDate.Time.Format(pricingDateLayout),
+			)
+		}
+	}
+
+	return nil
+}
+
+// ValidateProviderModelPricing - validates the prices, token unit size and active range of a new pricing.
+// A new pricing can only be scheduled from today onward, since past requests were already charged.
+func ValidateProviderModelPricing(
+	data dto.ProviderModelPricingDTO,
+	activeFromDate time.Time,
+	activeToDate pgtype.Date,
+) error {
+	for _, price := range []*decimal.Decimal{
+		&data.InputTokenPrice,
+		&data.OutputTokenPrice,
+		data.CachedInputTokenPrice,
+		data.ReasoningTokenPrice,
+		data.ImageTokenPrice,
+	} {
+		if price != nil && price.IsNegative() {
+			return errors.New("invalid pricing - the token prices must not be negative")
+		}
+	}
+
+	if data.TokenUnitSize <= 0 {
+		return fmt.Errorf(
+			"invalid pricing - the token unit size must be positive, got %d",
+			data.TokenUnitSize,
+		)
+	}
+
+	if activeFromDate.Before(today()) {
+		return fmt.Errorf(
+			"invalid pricing - the active from date %s is in the past",
+			activeFromDate.Format(pricingDateLayout),
+		)
+	}
+
+	return ValidateActivePricingRange(activeFromDate, activeToDate)
+}
+
+// checkPricingOverlap - returns an error if the given range overlaps another pricing of the same model and project.
+// Note: the check must run in a transaction holding the pricing lock, or a concurrent change may create an overlap.
+func checkPricingOverlap(
+	ctx context.Context,
+	queries *models.Queries,
+	params models.RetrieveOverlappingProviderModelPricingsParams,
+) error {
+	overlapping, retrievalErr := queries.RetrieveOverlappingProviderModelPricings(ctx, params)
+	if retrievalErr != nil {
+		return fmt.Errorf("failed to retrieve overlapping pricing - %w", retrievalErr)
+	}
+
+	if len(overlapping) > 0 {
+		return fmt.Errorf(
+			"invalid pricing - the active range overlaps the pricing {%s} of %s model %s",
+			db.UUIDToString(&overlapping[0].ID),
+			params.ModelVendor,
+			params.ModelType,
+		)
+	}
+
+	return nil
+}
+
+// invalidateProjectRequestConfigurations - invalidates the cached request configurations of the applications
+// of a project, so the gateway charges their requests with the project pricing.
+// The cached request configurations of a global pricing change expire on their own.
+func invalidateProjectRequestConfigurations(ctx context.Context, projectID pgtype.UUID) {
+	if !projectID.Valid {
+		return
+	}
+
+	applications := exc.MustResult(db.GetQueries().RetrieveApplications(ctx, projectID))
+
+	cacheKeys := make([]string, len(applications))
+	for i, application := range applications {
+		cacheKeys[i] = db.UUIDToString(&application.ID)
+	}
+
+	if len(cacheKeys) == 0 {
+		return
+	}
+
+	go func() {
+		rediscache.Invalidate(ctx, cacheKeys...)
+	}()
+}
+
+// CreateProviderModelPricing - schedules the pricing of a provider model, globally or for a project.
+// The active range of the pricing must not overlap another pricing of the same model and project.
+func CreateProviderModelPricing(
+	ctx context.Context,
+	data dto.ProviderModelPricingDTO,
+) (*dto.ProviderModelPricingDTO, error) {
+	activeFromDate, parseErr := parsePricingDate(data.ActiveFromDate)
+	if parseErr != nil {
+		return nil, parseErr
+	}
+
+	activeToDate, parseErr := parseOptionalPricingDate(data.ActiveToDate)
+	if parseErr != nil {
+		return nil, parseErr
+	}
+
+	if validationErr := ValidateProviderModelPricing(data, activeFromDate, activeToDate); validationErr != nil {
+		return nil, validationErr
+	}
+
+	projectID := pgtype.UUID{}
+	if data.ProjectID != nil {
+		parsedID, uuidErr := db.StringToUUID(*data.ProjectID)
+		if uuidErr != nil {
+			return nil, fmt.Errorf("invalid pricing - %w", uuidErr)
+		}
+
+		if _, retrievalErr := db.GetQueries().RetrieveProject(ctx, *parsedID); retrievalErr != nil {
+			return nil, fmt.Errorf("invalid pricing - project {%s} does not exist", *data.ProjectID)
+		}
+
+		projectID = *parsedID
+	}
+
+	params := models.CreateProviderModelPricingParams{
+		ModelType:      data.ModelType,
+		ModelVendor:    data.ModelVendor,
+		TokenUnitSize:  data.TokenUnitSize,
+		ActiveFromDate: pgtype.Date{Time: activeFromDate, Valid: true},
+		ActiveToDate:   activeToDate,
+		ProjectID:      projectID,
+	}
+
+	for _, price := range []struct {
+		value  *decimal.Decimal
+		target *pgtype.Numeric
+	}{
+		{&data.InputTokenPrice, &params.InputTokenPrice},
+		{&data.OutputTokenPrice, &params.OutputTokenPrice},
+		{data.CachedInputTokenPrice, &params.CachedInputTokenPrice},
+		{data.ReasoningTokenPrice, &params.ReasoningTokenPrice},
+		{data.ImageTokenPrice, &params.ImageTokenPrice},
+	} {
+		if price.value == nil {
+			continue
+		}
+
+		numericPrice, conversionErr := db.StringToNumeric(price.value.String())
+		if conversionErr != nil {
+			return nil, fmt.Errorf("invalid pricing - %w", conversionErr)
+		}
+
+		*price.target = *numericPrice
+	}
+
+	tx, txErr := db.GetOrCreateTx(ctx)
+	if txErr != nil {
+		return nil, fmt.Errorf("failed to create transaction - %w", txErr)
+	}
+
+	if db.ShouldCommit(ctx) {
+		defer db.HandleRollback(ctx, tx)
+	}
+
+	queries := db.GetQueries().WithTx(tx)
+
+	if lockErr := queries.LockProviderModelPricings(ctx); lockErr != nil {
+		return nil, fmt.Errorf("failed to lock provider model pricing - %w", lockErr)
+	}
+
+	if overlapErr := checkPricingOverlap(
+		ctx,
+		queries,
+		models.RetrieveOverlappingProviderModelPricingsParams{
+			ModelType:      data.ModelType,
+			ModelVendor:    data.ModelVendor,
+			ProjectID:      projectID,
+			ActiveFromDate: pgtype.Date{Time: activeFromDate, Valid: true},
+			ActiveToDate:   activeToDate,
+		},
+	); overlapErr != nil {
+		return nil, overlapErr
+	}
+
+	pricing, createErr := queries.CreateProviderModelPricing(ctx, params)
+	if createErr != nil {
+		return nil, fmt.Errorf("failed to create provider model pricing - %w", createErr)
+	}
+
+	db.CommitIfShouldCommit(ctx, tx)
+
+	invalidateProjectRequestConfigurations(ctx, projectID)
+
+	result := providerModelPricingToDTO(pricing)
+
+	return &result, nil
+}
+
+// GetProviderModelPricings - returns the past, active and scheduled pricing of all provider models.
+// If the project ID is valid, only the pricing overrides of the project are returned.
+func GetProviderModelPricings(
+	ctx context.Context,
+	projectID pgtype.UUID,
+) []dto.ProviderModelPricingDTO {
+	rows := exc.MustResult(db.GetQueries().RetrieveProviderModelPricings(ctx, projectID))
+
+	pricings := make([]dto.ProviderModelPricingDTO, len(rows))
+	for i, row := range rows {
+		pricings[i] = providerModelPricingToDTO(row)
+	}
+
+	return pricings
+}
+
+// GetProjectProviderModelPricings - returns the pricing charged today for the requests of a project,
+// which is the pricing override of the project if one is active, or the global pricing otherwise.
+func GetProjectProviderModelPricings(
+	ctx context.Context,
+	projectID pgtype.UUID,
+) []dto.ProviderModelPricingDTO {
+	rows := exc.MustResult(db.GetQueries().RetrieveActiveProviderModelPricings(ctx, projectID))
+
+	pricings := make([]dto.ProviderModelPricingDTO, len(rows))
+	for i, row := range rows {
+		pricings[i] = providerModelPricingToDTO(models.ProviderModelPricing{
+			ID:                    row.ID,
+			ModelType:             row.ModelType,
+			ModelVendor:           row.ModelVendor,
+			InputTokenPrice:       row.InputTokenPrice,
+			OutputTokenPrice:      row.OutputTokenPrice,
+			CachedInputTokenPrice: row.CachedInputTokenPrice,
+			ReasoningTokenPrice:   row.ReasoningTokenPrice,
+			ImageTokenPrice:       row.ImageTokenPrice,
+			TokenUnitSize:         row.TokenUnitSize,
+			ActiveFromDate:        row.ActiveFromDate,
+			ActiveToDate:          row.ActiveToDate,
+			ProjectID:             row.ProjectID,
+		})
+	}
+
+	return pricings
+}
+
+// UpdateProviderModelPricingActiveToDate - ends or extends a provider model pricing.
+// The new active range must not overlap another pricing of the same model and project.
+func UpdateProviderModelPricingActiveToDate(
+	ctx context.Context,
+	pricingID pgtype.UUID,
+	data dto.ProviderModelPricingUpdateDTO,
+) (*dto.ProviderModelPricingDTO, error) {
+	activeToDate, parseErr := parseOptionalPricingDate(data.ActiveToDate)
+	if parseErr != nil {
+		return nil, parseErr
+	}
+
+	tx, txErr := db.GetOrCreateTx(ctx)
+	if txErr != nil {
+		return nil, fmt.Errorf("failed to create transaction - %w", txErr)
+	}
+
+	if db.ShouldCommit(ctx) {
+		defer db.HandleRollback(ctx, tx)
+	}
+
+	queries := db.GetQueries().WithTx(tx)
+
+	if lockErr := queries.LockProviderModelPricings(ctx); lockErr != nil {
+		return nil, fmt.Errorf("failed to lock provider model pricing - %w", lockErr)
+	}
+
+	pricing, retrievalErr := queries.RetrieveProviderModelPricing(ctx, pricingID)
+	if retrievalErr != nil {
+		if errors.Is(retrievalErr, pgx.ErrNoRows) {
+			return nil, ErrProviderModelPricingNotFound
+		}
+
+		return nil, fmt.Errorf("failed to retrieve provider model pricing - %w", retrievalErr)
+	}
+
+	if pricing.ActiveToDate.Valid && pricing.ActiveToDate.Time.Before(today()) {
+		return nil, fmt.Errorf(
+			"invalid pricing - the pricing ended on %s",
+			pricing.ActiveToDate.Time.Format(pricingDateLayout),
+		)
+	}
+
+	if validationErr := ValidateActivePricingRange(
+		pricing.ActiveFromDate.Time,
+		activeToDate,
+	); validationErr != nil {
+		return nil, validationErr
+	}
+
+	if overlapErr := checkPricingOverlap(
+		ctx,
+		queries,
+		models.RetrieveOverlappingProviderModelPricingsParams{
+			ModelType:      pricing.ModelType,
+			ModelVendor:    pricing.ModelVendor,
+			ProjectID:      pricing.ProjectID,
+			ExcludedID:     pricing.ID,
+			ActiveFromDate: pricing.ActiveFromDate,
+			ActiveToDate:   activeToDate,
+		},
+	); overlapErr != nil {
+		return nil, overlapErr
+	}
+
+	updatedPricing, updateErr := queries.UpdateProviderModelPricingActiveToDate(
+		ctx,
+		models.UpdateProviderModelPricingActiveToDateParams{
+			ID:           pricingID,
+			ActiveToDate: activeToDate,
+		},
+	)
+	if updateErr != nil {
+		return nil, fmt.Errorf("failed to update provider model pricing - %w", updateErr)
+	}
+
+	db.CommitIfShouldCommit(ctx, tx)
+
+	invalidateProjectRequestConfigurations(ctx, updatedPricing.ProjectID)
+
+	result := providerModelPricingToDTO(updatedPricing)
+
+	return &result, nil
+}
+
+// DeleteProviderModelPricing - deletes a scheduled provider model pricing.
+// Note: a pricing that is or was active is referenced by the records of the requests it charged,
+// and is ended with an active to date instead.
+func DeleteProviderModelPricing(ctx context.Context, pricingID pgtype.UUID) error {
+	pricing, retrievalErr := db.GetQueries().RetrieveProviderModelPricing(ctx, pricingID)
+	if retrievalErr != nil {
+		if errors.Is(retrievalErr, pgx.ErrNoRows) {
+			return ErrProviderModelPricingNotFound
+		}
+
+		return fmt.Errorf("failed to retrieve provider model pricing - %w", retrievalErr)
+	}
+
+	if !pricing.ActiveFromDate.Time.After(today()) {
+		return errors.New(
+			"invalid pricing - only scheduled pricing can be deleted, set an active to date instead",
+		)
+	}
+
+	if deleteErr := db.GetQueries().DeleteProviderModelPricing(ctx, pricingID); deleteErr != nil {
+		return fmt.Errorf("failed to delete provider model pricing - %w", deleteErr)
+	}
+
+	return nil
+}
+
+// providerModelPricingToDTO - converts a provider model pricing to its DTO.
+func providerModelPricingToDTO(pricing models.ProviderModelPricing) dto.ProviderModelPricingDTO {
+	var activeToDate *string
+	if pricing.ActiveToDate.Valid {
+		formatted := pricing.ActiveToDate.Time.Format(pricingDateLayout)
+		activeToDate = &formatted
+	}
+
+	return dto.ProviderModelPricingDTO{
+		ID:                    db.UUIDToString(&pricing.ID),
+		ModelType:             pricing.ModelType,
+		ModelVendor:           pricing.ModelVendor,
+		InputTokenPrice:       *exc.MustResult(db.NumericToDecimal(pricing.InputTokenPrice)),
+		OutputTokenPrice:      *exc.MustResult(db.NumericToDecimal(pricing.OutputTokenPrice)),
+		CachedInputTokenPrice: optionalNumericToDecimal(pricing.CachedInputTokenPrice),
+		ReasoningTokenPrice:   optionalNumericToDecimal(pricing.ReasoningTokenPrice),
+		ImageTokenPrice:       optionalNumericToDecimal(pricing.ImageTokenPrice),
+		TokenUnitSize:         pricing.TokenUnitSize,
+		ActiveFromDate:        pricing.ActiveFromDate.Time.Format(pricingDateLayout),
+		ActiveToDate:          activeToDate,
+		ProjectID:             optionalUUIDToString(pricing.ProjectID),
+	}
+}
+
+// optionalNumericToDecimal - returns the decimal value of a nullable numeric, or nil if it is null.
+func optionalNumericToDecimal(value pgtype.Numeric) *decimal.Decimal {
+	if !value.Valid {
+		return nil
+	}
+
+	return exc.MustResult(db.NumericToDecimal(value))
+}
@@ -0,0 +1,86 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/basemind-ai/monorepo/e2e/factories"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/dto"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/repositories"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPricingRepository(t *testing.T) { //nolint: revive
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	pricingDTO := dto.ProviderModelPricingDTO{
+		ModelType:        models.ModelTypeCommand,
+		ModelVendor:      models.ModelVendorCOHERE,
+		InputTokenPrice:  decimal.RequireFromString("0.5"),
+		OutputTokenPrice: decimal.RequireFromString("1.5"),
+		TokenUnitSize:    1_000_000,
+	}
+
+	t.Run("ValidateProviderModelPricing", func(t *testing.T) {
+		assert.NoError(t, repositories.ValidateProviderModelPricing(pricingDTO, today, pgtype.Date{}))
+		assert.NoError(t, repositories.ValidateProviderModelPricing(
+			pricingDTO,
+			today,
+			pgtype.Date{Time: today, Valid: true},
+		))
+		assert.Error(t, repositories.ValidateProviderModelPricing(
+			pricingDTO,
+			today.AddDate(0, 0, -1),
+			pgtype.Date{},
+		))
+		assert.Error(t, repositories.ValidateProviderModelPricing(
+			pricingDTO,
+			today.AddDate(0, 0, 2),
+			pgtype.Date{Time: today.AddDate(0, 0, 1), Valid: true},
+		))
+
+		negativePrice := pricingDTO
+		negativePrice.OutputTokenPrice = decimal.NewFromInt(-1)
+		assert.Error(t, repositories.ValidateProviderModelPricing(negativePrice, today, pgtype.Date{}))
+
+		zeroUnitSize := pricingDTO
+		zeroUnitSize.TokenUnitSize = 0
+		assert.Error(t, repositories.ValidateProviderModelPricing(zeroUnitSize, today, pgtype.Date{}))
+	})
+
+	t.Run("CreateProviderModelPricing", func(t *testing.T) {
+		project, _ := factories.CreateProject(context.TODO())
+		projectID := db.UUIDToString(&project.ID)
+
+		data := pricingDTO
+		data.ActiveFromDate = today.Format("2006-01-02")
+		data.ProjectID = &projectID
+
+		pricing, createErr := repositories.CreateProviderModelPricing(context.TODO(), data)
+		assert.NoError(t, createErr)
+		assert.Equal(t, data.ActiveFromDate, pricing.ActiveFromDate)
+
+		_, createErr = repositories.CreateProviderModelPricing(context.TODO(), data)
+		assert.ErrorContains(t, createErr, "overlaps")
+
+		projectPricings := repositories.GetProjectProviderModelPricings(context.TODO(), project.ID)
+		assert.Contains(t, projectPricings, *pricing)
+	})
+
+	t.Run("DeleteProviderModelPricing", func(t *testing.T) {
+		t.Run("returns an error if the pricing does not exist", func(t *testing.T) {
+			pricingID, _ := db.StringToUUID("3c7e8b9a-7f4e-4e5b-9c1d-2a3b4c5d6e7f")
+
+			assert.ErrorIs(
+				t,
+				repositories.DeleteProviderModelPricing(context.TODO(), *pricingID),
+				repositories.ErrProviderModelPricingNotFound,
+			)
+		})
+	})
+}
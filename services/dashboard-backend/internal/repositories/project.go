@@ -32,6 +32,15 @@ func CreateProject(
 		Permission: models.AccessPermissionTypeADMIN,
 	}))
 
+	// the ledger of the project is opened with its initial credits
+	exc.MustResult(queries.CreateCreditLedgerEntry(ctx, models.CreateCreditLedgerEntryParams{
+		ProjectID:    project.ID,
+		EntryType:    models.CreditLedgerEntryTypeADJUSTMENT,
+		Amount:       project.Credits,
+		BalanceAfter: project.Credits,
+		Description:  "opening balance",
+	}))
+
 	db.CommitIfShouldCommit(ctx, tx)
 
 	projectID := db.UUIDToString(&project.ID)
@@ -81,7 +90,7 @@ func GetProjectAPIRequestByDateRange(
 ) int64 {
 	totalAPICalls := exc.MustResult(db.GetQueries().
 		RetrieveProjectAPIRequestCount(ctx, models.RetrieveProjectAPIRequestCountParams{
-			ID:          projectID,
+			ProjectID:   projectID,
 			CreatedAt:   pgtype.Timestamptz{Time: fromDate, Valid: true},
 			CreatedAt_2: pgtype.Timestamptz{Time: toDate, Valid: true},
 		}))
@@ -99,7 +108,7 @@ func GetProjectAnalyticsByDateRange(
 	tokensCost := exc.MustResult(db.NumericToDecimal(exc.MustResult(
 		db.GetQueries().
 			RetrieveProjectTokensTotalCost(ctx, models.RetrieveProjectTokensTotalCostParams{
-				ID:          projectID,
+				ProjectID:   projectID,
 				CreatedAt:   pgtype.Timestamptz{Time: fromDate, Valid: true},
 				CreatedAt_2: pgtype.Timestamptz{Time: toDate, Valid: true},
 			}))))
@@ -2,7 +2,6 @@ package repositories
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"github.com/basemind-ai/monorepo/shared/go/db/models"
 	"github.com/basemind-ai/monorepo/shared/go/exc"
@@ -18,9 +17,12 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+// CreatePromptConfig - creates a prompt config together with its first version.
+// The user account is recorded as the author of the version, and may be nil.
 func CreatePromptConfig(
 	ctx context.Context,
 	applicationID pgtype.UUID,
+	userAccount *models.UserAccount,
 	createPromptConfigDTO dto.PromptConfigCreateDTO,
 ) (*datatypes.PromptConfigDTO, error) {
 	expectedTemplateVariables, promptMessages, parsePromptMessagesErr := ParsePromptMessages(
@@ -32,6 +34,30 @@ func CreatePromptConfig(
 		return nil, fmt.Errorf("failed to parse prompt messages - %w", parsePromptMessagesErr)
 	}
 
+	fallbackModels, parseFallbackModelsErr := ParseFallbackModels(
+		createPromptConfigDTO.FallbackModels,
+		createPromptConfigDTO.ModelVendor,
+		expectedTemplateVariables,
+	)
+	if parseFallbackModelsErr != nil {
+		log.Error().Err(parseFallbackModelsErr).Msg("failed to parse fallback models")
+		return nil, parseFallbackModelsErr
+	}
+
+	tools, parseToolsErr := ParseTools(createPromptConfigDTO.Tools)
+	if parseToolsErr != nil {
+		log.Error().Err(parseToolsErr).Msg("failed to parse tools")
+		return nil, parseToolsErr
+	}
+
+	responseSchema, parseResponseSchemaErr := ParseResponseSchema(
+		ptr.Deref(createPromptConfigDTO.ResponseSchema, nil),
+	)
+	if parseResponseSchemaErr != nil {
+		log.Error().Err(parseResponseSchemaErr).Msg("failed to parse response schema")
+		return nil, parseResponseSchemaErr
+	}
+
 	defaultExists := exc.MustResult(db.
 		GetQueries().
 		CheckDefaultPromptConfigExists(ctx, applicationID))
@@ -41,39 +67,46 @@ func CreatePromptConfig(
 		return nil, fmt.Errorf("invalid vendor or model - %w", invalidVendorOrModelErr)
 	}
 
+	tx := exc.MustResult(db.GetOrCreateTx(ctx))
+
+	if db.ShouldCommit(ctx) {
+		defer db.HandleRollback(ctx, tx)
+	}
+
+	queries := db.GetQueries().WithTx(tx)
+
 	// we automatically set the first created prompt config as the default.
 	// we know this is the first prompt config for the application, because there must always be a default config.
-	promptConfig, createErr := db.
-		GetQueries().
-		CreatePromptConfig(ctx, models.CreatePromptConfigParams{
-			ApplicationID:             applicationID,
-			Name:                      strings.TrimSpace(createPromptConfigDTO.Name),
-			ModelParameters:           ptr.Deref(createPromptConfigDTO.ModelParameters, nil),
-			ModelType:                 createPromptConfigDTO.ModelType,
-			ModelVendor:               createPromptConfigDTO.ModelVendor,
-			ProviderPromptMessages:    ptr.Deref(promptMessages, nil),
-			ExpectedTemplateVariables: expectedTemplateVariables,
-			IsDefault:                 !createPromptConfigDTO.IsTest && !defaultExists,
-			IsTestConfig:              createPromptConfigDTO.IsTest,
-		})
+	promptConfig, createErr := queries.CreatePromptConfig(ctx, models.CreatePromptConfigParams{
+		ApplicationID:             applicationID,
+		Name:                      strings.TrimSpace(createPromptConfigDTO.Name),
+		ModelParameters:           ptr.Deref(createPromptConfigDTO.ModelParameters, nil),
+		ModelType:                 createPromptConfigDTO.ModelType,
+		ModelVendor:               createPromptConfigDTO.ModelVendor,
+		ProviderPromptMessages:    ptr.Deref(promptMessages, nil),
+		ExpectedTemplateVariables: expectedTemplateVariables,
+		FallbackModels:            fallbackModels,
+		ResponseCacheTtlSeconds:   createPromptConfigDTO.ResponseCacheTTLSeconds,
+		Tools:                     tools,
+		ResponseSchema:            responseSchema,
+		ResponseSchemaMaxRetries:  createPromptConfigDTO.ResponseSchemaMaxRetries,
+		IsDefault:                 !createPromptConfigDTO.IsTest && !defaultExists,
+		IsTestConfig:              createPromptConfigDTO.IsTest,
+	})
 
 	if createErr != nil {
 		log.Error().Err(createErr).Msg("failed to create prompt config")
 		return nil, fmt.Errorf("failed to create prompt config - %w", createErr)
 	}
 
-	return &datatypes.PromptConfigDTO{
-		ID:                        db.UUIDToString(&promptConfig.ID),
-		Name:                      promptConfig.Name,
-		ModelParameters:           ptr.To(json.RawMessage(promptConfig.ModelParameters)),
-		ModelType:                 promptConfig.ModelType,
-		ModelVendor:               promptConfig.ModelVendor,
-		ProviderPromptMessages:    ptr.To(json.RawMessage(promptConfig.ProviderPromptMessages)),
-		ExpectedTemplateVariables: promptConfig.ExpectedTemplateVariables,
-		IsDefault:                 promptConfig.IsDefault,
-		CreatedAt:                 promptConfig.CreatedAt.Time,
-		UpdatedAt:                 promptConfig.UpdatedAt.Time,
-	}, nil
+	promptConfigVersion := exc.MustResult(queries.CreatePromptConfigVersion(
+		ctx,
+		createPromptConfigVersionParams(promptConfig, userAccount),
+	))
+
+	db.CommitIfShouldCommit(ctx, tx)
+
+	return promptConfigToDTO(promptConfig, promptConfigVersion.ID), nil
 }
 
 func UpdateApplicationDefaultPromptConfig(
@@ -132,9 +165,12 @@ func UpdateApplicationDefaultPromptConfig(
 	return nil
 }
 
+// UpdatePromptConfig - updates a prompt config.
+// A new version authored by the user account is created if the content of the prompt config changed.
 func UpdatePromptConfig(
 	ctx context.Context,
 	promptConfigID pgtype.UUID,
+	userAccount *models.UserAccount,
 	updatePromptConfigDTO dto.PromptConfigUpdateDTO,
 ) (*datatypes.PromptConfigDTO, error) {
 	existingPromptConfig, retrievePromptConfigErr := db.GetQueries().RetrievePromptConfig(
@@ -154,6 +190,11 @@ func UpdatePromptConfig(
 		ModelVendor:               existingPromptConfig.ModelVendor,
 		ProviderPromptMessages:    existingPromptConfig.ProviderPromptMessages,
 		ExpectedTemplateVariables: existingPromptConfig.ExpectedTemplateVariables,
+		FallbackModels:            existingPromptConfig.FallbackModels,
+		ResponseCacheTtlSeconds:   existingPromptConfig.ResponseCacheTtlSeconds,
+		Tools:                     existingPromptConfig.Tools,
+		ResponseSchema:            existingPromptConfig.ResponseSchema,
+		ResponseSchemaMaxRetries:  existingPromptConfig.ResponseSchemaMaxRetries,
 	}
 
 	if updatePromptConfigDTO.Name != nil {
@@ -168,6 +209,32 @@ func UpdatePromptConfig(
 	if updatePromptConfigDTO.ModelParameters != nil {
 		updateParams.ModelParameters = *updatePromptConfigDTO.ModelParameters
 	}
+	if updatePromptConfigDTO.ResponseCacheTTLSeconds != nil {
+		updateParams.ResponseCacheTtlSeconds = *updatePromptConfigDTO.ResponseCacheTTLSeconds
+	}
+	if updatePromptConfigDTO.Tools != nil {
+		tools, parseToolsErr := ParseTools(*updatePromptConfigDTO.Tools)
+		if parseToolsErr != nil {
+			log.Error().Err(parseToolsErr).Msg("failed to parse tools")
+			return nil, parseToolsErr
+		}
+
+		updateParams.Tools = tools
+	}
+	if updatePromptConfigDTO.ResponseSchema != nil {
+		responseSchema, parseResponseSchemaErr := ParseResponseSchema(
+			updatePromptConfigDTO.ResponseSchema,
+		)
+		if parseResponseSchemaErr != nil {
+			log.Error().Err(parseResponseSchemaErr).Msg("failed to parse response schema")
+			return nil, parseResponseSchemaErr
+		}
+
+		updateParams.ResponseSchema = responseSchema
+	}
+	if updatePromptConfigDTO.ResponseSchemaMaxRetries != nil {
+		updateParams.ResponseSchemaMaxRetries = *updatePromptConfigDTO.ResponseSchemaMaxRetries
+	}
 	if updatePromptConfigDTO.ProviderPromptMessages != nil {
 		expectedTemplateVariables, providerMessages, parsePromptMessagesErr := ParsePromptMessages(
 			updatePromptConfigDTO.ProviderPromptMessages,
@@ -186,42 +253,95 @@ func UpdatePromptConfig(
 		return nil, fmt.Errorf("invalid vendor or model - %w", invalidVendorOrModelErr)
 	}
 
-	updatedPromptConfig, updateErr := db.GetQueries().UpdatePromptConfig(ctx, updateParams)
+	fallbackModels, unmarshalErr := datatypes.UnmarshalFallbackModels(updateParams.FallbackModels)
+	if unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+
+	if updatePromptConfigDTO.FallbackModels != nil {
+		fallbackModels = *updatePromptConfigDTO.FallbackModels
+	}
+
+	// the fallback models are re-validated on every update, since the prompt config vendor might have changed
+	serializedFallbackModels, parseFallbackModelsErr := ParseFallbackModels(
+		fallbackModels,
+		updateParams.ModelVendor,
+		updateParams.ExpectedTemplateVariables,
+	)
+	if parseFallbackModelsErr != nil {
+		log.Error().Err(parseFallbackModelsErr).Msg("failed to parse fallback models")
+		return nil, parseFallbackModelsErr
+	}
+
+	updateParams.FallbackModels = serializedFallbackModels
+
+	// updates that do not change the content of the prompt config, e.g. renaming it, keep the current version.
+	// The version is incremented by the update query, so that concurrent updates create distinct versions.
+	isContentChanged := isPromptConfigContentChanged(existingPromptConfig, updateParams)
+	if isContentChanged {
+		updateParams.VersionIncrement = 1
+	}
+
+	return savePromptConfig(
+		ctx,
+		updateParams,
+		existingPromptConfig.VersionID,
+		isContentChanged,
+		userAccount,
+	)
+}
+
+// savePromptConfig - updates a prompt config and invalidates its cached request configuration.
+// If createVersion is true, a new version authored by the user account is created from the updated prompt config,
+// otherwise the prompt config keeps the given version ID.
+func savePromptConfig(
+	ctx context.Context,
+	updateParams models.UpdatePromptConfigParams,
+	versionID pgtype.UUID,
+	createVersion bool,
+	userAccount *models.UserAccount,
+) (*datatypes.PromptConfigDTO, error) {
+	tx := exc.MustResult(db.GetOrCreateTx(ctx))
+
+	if db.ShouldCommit(ctx) {
+		defer db.HandleRollback(ctx, tx)
+	}
+
+	queries := db.GetQueries().WithTx(tx)
+
+	updatedPromptConfig, updateErr := queries.UpdatePromptConfig(ctx, updateParams)
 	if updateErr != nil {
 		log.Error().Err(updateErr).Msg("failed to update prompt config")
 		return nil, fmt.Errorf("failed to update prompt config - %w", updateErr)
 	}
 
+	if createVersion {
+		promptConfigVersion := exc.MustResult(queries.CreatePromptConfigVersion(
+			ctx,
+			createPromptConfigVersionParams(updatedPromptConfig, userAccount),
+		))
+		versionID = promptConfigVersion.ID
+	}
+
+	db.CommitIfShouldCommit(ctx, tx)
+
 	go func() {
 		cacheKeys := []string{
 			fmt.Sprintf(
 				"%s:%s",
 				db.UUIDToString(&updatedPromptConfig.ApplicationID),
-				db.UUIDToString(&promptConfigID),
+				db.UUIDToString(&updatedPromptConfig.ID),
 			),
 		}
 
-		if updatedPromptConfig.IsDefault {
+		if updatedPromptConfig.IsDefault || updatedPromptConfig.TrafficWeight > 0 {
 			cacheKeys = append(cacheKeys, db.UUIDToString(&updatedPromptConfig.ApplicationID))
 		}
 
 		rediscache.Invalidate(ctx, cacheKeys...)
 	}()
 
-	return &datatypes.PromptConfigDTO{
-		ID:              db.UUIDToString(&updatedPromptConfig.ID),
-		Name:            updatedPromptConfig.Name,
-		ModelParameters: ptr.To(json.RawMessage(updatedPromptConfig.ModelParameters)),
-		ModelType:       updatedPromptConfig.ModelType,
-		ModelVendor:     updatedPromptConfig.ModelVendor,
-		ProviderPromptMessages: ptr.To(
-			json.RawMessage(updatedPromptConfig.ProviderPromptMessages),
-		),
-		ExpectedTemplateVariables: updatedPromptConfig.ExpectedTemplateVariables,
-		IsDefault:                 updatedPromptConfig.IsDefault,
-		CreatedAt:                 updatedPromptConfig.CreatedAt.Time,
-		UpdatedAt:                 updatedPromptConfig.UpdatedAt.Time,
-	}, nil
+	return promptConfigToDTO(updatedPromptConfig, versionID), nil
 }
 
 func DeletePromptConfig(ctx context.Context,
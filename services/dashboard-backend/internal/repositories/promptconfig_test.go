@@ -55,6 +55,7 @@ func TestPromptConfigRepository(t *testing.T) { //nolint: revive
 			promptConfig, err := repositories.CreatePromptConfig(
 				context.TODO(),
 				application.ID,
+				nil,
 				createPromptConfigDTO,
 			)
 			assert.NoError(t, err)
@@ -84,6 +85,7 @@ func TestPromptConfigRepository(t *testing.T) { //nolint: revive
 			promptConfig, err := repositories.CreatePromptConfig(
 				context.TODO(),
 				application.ID,
+				nil,
 				createPromptConfigDTO,
 			)
 			assert.NoError(t, err)
@@ -103,6 +105,7 @@ func TestPromptConfigRepository(t *testing.T) { //nolint: revive
 			promptConfig, err := repositories.CreatePromptConfig(
 				context.TODO(),
 				application.ID,
+				nil,
 				createPromptConfigDTO,
 			)
 			assert.Error(t, err)
@@ -126,6 +129,7 @@ func TestPromptConfigRepository(t *testing.T) { //nolint: revive
 			promptConfig, err := repositories.CreatePromptConfig(
 				context.TODO(),
 				application.ID,
+				nil,
 				createPromptConfigDTO,
 			)
 			assert.Error(t, err)
@@ -148,6 +152,7 @@ func TestPromptConfigRepository(t *testing.T) { //nolint: revive
 			promptConfig, _ := repositories.CreatePromptConfig(
 				context.TODO(),
 				application.ID,
+				nil,
 				createPromptConfigDTO,
 			)
 
@@ -185,6 +190,7 @@ func TestPromptConfigRepository(t *testing.T) { //nolint: revive
 			nonDefaultPromptConfig, _ := repositories.CreatePromptConfig(
 				context.TODO(),
 				application.ID,
+				nil,
 				createPromptConfigDTO,
 			)
 
@@ -298,6 +304,7 @@ func TestPromptConfigRepository(t *testing.T) { //nolint: revive
 					updatedPromptConfig, err := repositories.UpdatePromptConfig(
 						context.TODO(),
 						promptConfig.ID,
+						nil,
 						testCase.Dto,
 					)
 					assert.NoError(t, err)
@@ -349,6 +356,7 @@ func TestPromptConfigRepository(t *testing.T) { //nolint: revive
 			_, err := repositories.UpdatePromptConfig(
 				context.TODO(),
 				promptConfig.ID,
+				nil,
 				dto.PromptConfigUpdateDTO{},
 			)
 			assert.NoError(t, err)
@@ -387,6 +395,7 @@ func TestPromptConfigRepository(t *testing.T) { //nolint: revive
 			_, err := repositories.UpdatePromptConfig(
 				context.TODO(),
 				promptConfig.ID,
+				nil,
 				dto.PromptConfigUpdateDTO{Name: &newName},
 			)
 			assert.Error(t, err)
@@ -400,6 +409,7 @@ func TestPromptConfigRepository(t *testing.T) { //nolint: revive
 			_, err := repositories.UpdatePromptConfig(
 				context.TODO(),
 				promptConfig.ID,
+				nil,
 				dto.PromptConfigUpdateDTO{ProviderPromptMessages: badMessage},
 			)
 			assert.Error(t, err)
@@ -427,6 +437,7 @@ func TestPromptConfigRepository(t *testing.T) { //nolint: revive
 			_, err := repositories.UpdatePromptConfig(
 				context.TODO(),
 				promptConfig.ID,
+				nil,
 				dto.PromptConfigUpdateDTO{Name: &existingPromptConfig.Name},
 			)
 			assert.Error(t, err)
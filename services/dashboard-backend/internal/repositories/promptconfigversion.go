@@ -0,0 +1,291 @@
+package repositories
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/dto"
+	"github.com/basemind-ai/monorepo/shared/go/datatypes"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/exc"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/basemind-ai/monorepo/shared/go/serialization"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+	"reflect"
+	"slices"
+)
+
+// promptConfigVersionFields are the JSON fields of a prompt config version that are compared when diffing versions.
+var promptConfigVersionFields = []string{
+	"modelParameters",
+	"modelType",
+	"modelVendor",
+	"providerPromptMessages",
+	"expectedTemplateVariables",
+	"fallbackModels",
+	"tools",
+	"responseSchema",
+	"responseSchemaMaxRetries",
+}
+
+// promptConfigToDTO - maps a prompt config and the ID of its current version to a prompt config DTO.
+func promptConfigToDTO(
+	promptConfig models.PromptConfig,
+	versionID pgtype.UUID,
+) *datatypes.PromptConfigDTO {
+	promptConfigDTO := &datatypes.PromptConfigDTO{
+		ID:                        db.UUIDToString(&promptConfig.ID),
+		Name:                      promptConfig.Name,
+		ModelParameters:           ptr.To(json.RawMessage(promptConfig.ModelParameters)),
+		ModelType:                 promptConfig.ModelType,
+		ModelVendor:               promptConfig.ModelVendor,
+		ProviderPromptMessages:    ptr.To(json.RawMessage(promptConfig.ProviderPromptMessages)),
+		ExpectedTemplateVariables: promptConfig.ExpectedTemplateVariables,
+		FallbackModels: exc.MustResult(
+			datatypes.UnmarshalFallbackModels(promptConfig.FallbackModels),
+		),
+		ResponseCacheTTLSeconds: promptConfig.ResponseCacheTtlSeconds,
+		Tools:                   exc.MustResult(datatypes.UnmarshalTools(promptConfig.Tools)),
+		ResponseSchema: exc.MustResult(
+			datatypes.UnmarshalResponseSchema(promptConfig.ResponseSchema),
+		),
+		ResponseSchemaMaxRetries: promptConfig.ResponseSchemaMaxRetries,
+		Version:                  promptConfig.Version,
+		TrafficWeight:            promptConfig.TrafficWeight,
+		IsDefault:                promptConfig.IsDefault,
+		CreatedAt:                promptConfig.CreatedAt.Time,
+		UpdatedAt:                promptConfig.UpdatedAt.Time,
+	}
+
+	if versionID.Valid {
+		promptConfigDTO.VersionID = db.UUIDToString(&versionID)
+	}
+
+	return promptConfigDTO
+}
+
+// createPromptConfigVersionParams - returns the params for creating a version from the current content of
+// a prompt config. The user account is the author of the version, and may be nil.
+func createPromptConfigVersionParams(
+	promptConfig models.PromptConfig,
+	userAccount *models.UserAccount,
+) models.CreatePromptConfigVersionParams {
+	params := models.CreatePromptConfigVersionParams{
+		Version:                   promptConfig.Version,
+		ModelParameters:           promptConfig.ModelParameters,
+		ModelType:                 promptConfig.ModelType,
+		ModelVendor:               promptConfig.ModelVendor,
+		ProviderPromptMessages:    promptConfig.ProviderPromptMessages,
+		ExpectedTemplateVariables: promptConfig.ExpectedTemplateVariables,
+		FallbackModels:            promptConfig.FallbackModels,
+		Tools:                     promptConfig.Tools,
+		ResponseSchema:            promptConfig.ResponseSchema,
+		ResponseSchemaMaxRetries:  promptConfig.ResponseSchemaMaxRetries,
+		PromptConfigID:            promptConfig.ID,
+	}
+
+	if userAccount != nil {
+		params.CreatedByUserID = userAccount.ID
+	}
+
+	return params
+}
+
+// isPromptConfigContentChanged - returns true if the update changes any of the versioned prompt config fields.
+func isPromptConfigContentChanged(
+	existingPromptConfig models.RetrievePromptConfigRow,
+	updateParams models.UpdatePromptConfigParams,
+) bool {
+	return !bytes.Equal(existingPromptConfig.ModelParameters, updateParams.ModelParameters) ||
+		existingPromptConfig.ModelType != updateParams.ModelType ||
+		existingPromptConfig.ModelVendor != updateParams.ModelVendor ||
+		!bytes.Equal(existingPromptConfig.ProviderPromptMessages, updateParams.ProviderPromptMessages) ||
+		!slices.Equal(existingPromptConfig.ExpectedTemplateVariables, updateParams.ExpectedTemplateVariables) ||
+		!bytes.Equal(existingPromptConfig.FallbackModels, updateParams.FallbackModels) ||
+		!bytes.Equal(existingPromptConfig.Tools, updateParams.Tools) ||
+		!bytes.Equal(existingPromptConfig.ResponseSchema, updateParams.ResponseSchema) ||
+		existingPromptConfig.ResponseSchemaMaxRetries != updateParams.ResponseSchemaMaxRetries
+}
+
+// promptConfigVersionToDTO - maps a prompt config version to a prompt config version DTO.
+func promptConfigVersionToDTO(
+	promptConfigVersion models.RetrievePromptConfigVersionRow,
+) dto.PromptConfigVersionDTO {
+	promptConfigVersionDTO := dto.PromptConfigVersionDTO{
+		ID:                        db.UUIDToString(&promptConfigVersion.ID),
+		Version:                   promptConfigVersion.Version,
+		ModelParameters:           ptr.To(json.RawMessage(promptConfigVersion.ModelParameters)),
+		ModelType:                 promptConfigVersion.ModelType,
+		ModelVendor:               promptConfigVersion.ModelVendor,
+		ProviderPromptMessages:    ptr.To(json.RawMessage(promptConfigVersion.ProviderPromptMessages)),
+		ExpectedTemplateVariables: promptConfigVersion.ExpectedTemplateVariables,
+		FallbackModels: exc.MustResult(
+			datatypes.UnmarshalFallbackModels(promptConfigVersion.FallbackModels),
+		),
+		Tools: exc.MustResult(datatypes.UnmarshalTools(promptConfigVersion.Tools)),
+		ResponseSchema: exc.MustResult(
+			datatypes.UnmarshalResponseSchema(promptConfigVersion.ResponseSchema),
+		),
+		ResponseSchemaMaxRetries: promptConfigVersion.ResponseSchemaMaxRetries,
+		CreatedAt:                promptConfigVersion.CreatedAt.Time,
+	}
+
+	if promptConfigVersion.CreatedByUserID.Valid {
+		promptConfigVersionDTO.CreatedByUserID = ptr.To(
+			db.UUIDToString(&promptConfigVersion.CreatedByUserID),
+		)
+	}
+
+	if promptConfigVersion.CreatedByDisplayName.Valid {
+		promptConfigVersionDTO.CreatedByDisplayName = &promptConfigVersion.CreatedByDisplayName.String
+	}
+
+	return promptConfigVersionDTO
+}
+
+// GetPromptConfigVersions - returns the versions of a prompt config, latest first.
+func GetPromptConfigVersions(
+	ctx context.Context,
+	promptConfigID pgtype.UUID,
+) []dto.PromptConfigVersionDTO {
+	promptConfigVersions := exc.MustResult(db.GetQueries().
+		RetrievePromptConfigVersions(ctx, promptConfigID))
+
+	data := make([]dto.PromptConfigVersionDTO, len(promptConfigVersions))
+	for i, promptConfigVersion := range promptConfigVersions {
+		data[i] = promptConfigVersionToDTO(
+			models.RetrievePromptConfigVersionRow(promptConfigVersion),
+		)
+	}
+
+	return data
+}
+
+// DiffPromptConfigVersionDTOs - returns the versioned fields that differ between two prompt config versions.
+// JSON values are compared semantically, so formatting and key order differences are not reported.
+func DiffPromptConfigVersionDTOs(
+	fromVersion dto.PromptConfigVersionDTO,
+	toVersion dto.PromptConfigVersionDTO,
+) dto.PromptConfigVersionDiffDTO {
+	var fromFields, toFields map[string]json.RawMessage
+	exc.Must(json.Unmarshal(serialization.SerializeJSON(fromVersion), &fromFields))
+	exc.Must(json.Unmarshal(serialization.SerializeJSON(toVersion), &toFields))
+
+	changes := make([]dto.PromptConfigVersionChangeDTO, 0)
+
+	for _, field := range promptConfigVersionFields {
+		var fromValue, toValue any
+		if fromField, exists := fromFields[field]; exists {
+			exc.Must(json.Unmarshal(fromField, &fromValue))
+		}
+		if toField, exists := toFields[field]; exists {
+			exc.Must(json.Unmarshal(toField, &toValue))
+		}
+
+		if !reflect.DeepEqual(fromValue, toValue) {
+			changes = append(changes, dto.PromptConfigVersionChangeDTO{
+				Field: field,
+				From:  serialization.SerializeJSON(fromValue),
+				To:    serialization.SerializeJSON(toValue),
+			})
+		}
+	}
+
+	return dto.PromptConfigVersionDiffDTO{
+		FromVersion: fromVersion.Version,
+		ToVersion:   toVersion.Version,
+		Changes:     changes,
+	}
+}
+
+// DiffPromptConfigVersions - returns the differences between two versions of a prompt config.
+// If fromVersionID is not valid, the version is compared with the current version of the prompt config.
+func DiffPromptConfigVersions(
+	ctx context.Context,
+	promptConfigID pgtype.UUID,
+	fromVersionID pgtype.UUID,
+	toVersionID pgtype.UUID,
+) (*dto.PromptConfigVersionDiffDTO, error) {
+	if !fromVersionID.Valid {
+		promptConfig, retrievePromptConfigErr := db.GetQueries().
+			RetrievePromptConfig(ctx, promptConfigID)
+		if retrievePromptConfigErr != nil {
+			return nil, fmt.Errorf("failed to retrieve prompt config - %w", retrievePromptConfigErr)
+		}
+
+		fromVersionID = promptConfig.VersionID
+	}
+
+	versions := make([]dto.PromptConfigVersionDTO, 0, 2)
+
+	for _, versionID := range []pgtype.UUID{fromVersionID, toVersionID} {
+		promptConfigVersion, retrieveVersionErr := db.GetQueries().
+			RetrievePromptConfigVersion(ctx, models.RetrievePromptConfigVersionParams{
+				ID:             versionID,
+				PromptConfigID: promptConfigID,
+			})
+		if retrieveVersionErr != nil {
+			log.Error().Err(retrieveVersionErr).Msg("failed to retrieve prompt config version")
+			return nil, fmt.Errorf(
+				"failed to retrieve prompt config version - %w",
+				retrieveVersionErr,
+			)
+		}
+
+		versions = append(versions, promptConfigVersionToDTO(promptConfigVersion))
+	}
+
+	return ptr.To(DiffPromptConfigVersionDTOs(versions[0], versions[1])), nil
+}
+
+// RollbackPromptConfig - restores the content of a prompt config version.
+// The rollback creates a new version authored by the user account, so the version history is never rewritten.
+func RollbackPromptConfig(
+	ctx context.Context,
+	promptConfigID pgtype.UUID,
+	promptConfigVersionID pgtype.UUID,
+	userAccount *models.UserAccount,
+) (*datatypes.PromptConfigDTO, error) {
+	existingPromptConfig, retrievePromptConfigErr := db.GetQueries().RetrievePromptConfig(
+		ctx,
+		promptConfigID,
+	)
+	if retrievePromptConfigErr != nil {
+		log.Error().Err(retrievePromptConfigErr).Msg("failed to retrieve prompt config")
+		return nil, fmt.Errorf("failed to retrieve prompt config - %w", retrievePromptConfigErr)
+	}
+
+	if existingPromptConfig.VersionID == promptConfigVersionID {
+		return nil, fmt.Errorf("prompt config version is already the current version")
+	}
+
+	promptConfigVersion, retrieveVersionErr := db.GetQueries().
+		RetrievePromptConfigVersion(ctx, models.RetrievePromptConfigVersionParams{
+			ID:             promptConfigVersionID,
+			PromptConfigID: promptConfigID,
+		})
+	if retrieveVersionErr != nil {
+		log.Error().Err(retrieveVersionErr).Msg("failed to retrieve prompt config version")
+		return nil, fmt.Errorf("failed to retrieve prompt config version - %w", retrieveVersionErr)
+	}
+
+	return savePromptConfig(ctx, models.UpdatePromptConfigParams{
+		ID:                        promptConfigID,
+		Name:                      existingPromptConfig.Name,
+		ModelParameters:           promptConfigVersion.ModelParameters,
+		ModelType:                 promptConfigVersion.ModelType,
+		ModelVendor:               promptConfigVersion.ModelVendor,
+		ProviderPromptMessages:    promptConfigVersion.ProviderPromptMessages,
+		ExpectedTemplateVariables: promptConfigVersion.ExpectedTemplateVariables,
+		IsTestConfig:              existingPromptConfig.IsTestConfig,
+		FallbackModels:            promptConfigVersion.FallbackModels,
+		ResponseCacheTtlSeconds:   existingPromptConfig.ResponseCacheTtlSeconds,
+		Tools:                     promptConfigVersion.Tools,
+		ResponseSchema:            promptConfigVersion.ResponseSchema,
+		ResponseSchemaMaxRetries:  promptConfigVersion.ResponseSchemaMaxRetries,
+		VersionIncrement:          1,
+	}, existingPromptConfig.VersionID, true, userAccount)
+}
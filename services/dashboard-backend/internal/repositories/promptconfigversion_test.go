@@ -0,0 +1,267 @@
+package repositories_test
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/basemind-ai/monorepo/e2e/factories"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/dto"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/repositories"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromptConfigVersionRepository(t *testing.T) { //nolint: revive
+	project, _ := factories.CreateProject(context.TODO())
+	userAccount, _ := factories.CreateUserAccount(context.TODO())
+
+	createPromptConfig := func(t *testing.T) string {
+		t.Helper()
+
+		application, _ := factories.CreateApplication(context.TODO(), project.ID)
+		promptConfig, err := repositories.CreatePromptConfig(
+			context.TODO(),
+			application.ID,
+			userAccount,
+			dto.PromptConfigCreateDTO{
+				Name:            "test",
+				ModelVendor:     models.ModelVendorOPENAI,
+				ModelType:       models.ModelTypeGpt35Turbo,
+				ModelParameters: factories.CreateOpenAIModelParameters(),
+				ProviderPromptMessages: factories.CreateOpenAIPromptMessages(
+					"You are a {role}",
+					"What is cheese?",
+					nil,
+				),
+			},
+		)
+		assert.NoError(t, err)
+
+		return promptConfig.ID
+	}
+
+	t.Run("CreatePromptConfig", func(t *testing.T) {
+		t.Run("creates the first version of the prompt config", func(t *testing.T) {
+			promptConfigID := createPromptConfig(t)
+			uuidID, _ := db.StringToUUID(promptConfigID)
+
+			versions := repositories.GetPromptConfigVersions(context.TODO(), *uuidID)
+			assert.Len(t, versions, 1)
+			assert.Equal(t, int32(1), versions[0].Version)
+			assert.Equal(t, db.UUIDToString(&userAccount.ID), *versions[0].CreatedByUserID)
+			assert.Equal(t, userAccount.DisplayName, *versions[0].CreatedByDisplayName)
+			assert.Equal(t, []string{"role"}, versions[0].ExpectedTemplateVariables)
+		})
+	})
+
+	t.Run("UpdatePromptConfig", func(t *testing.T) {
+		t.Run("creates a new version when the content changes", func(t *testing.T) {
+			promptConfigID := createPromptConfig(t)
+			uuidID, _ := db.StringToUUID(promptConfigID)
+
+			updatedPromptConfig, err := repositories.UpdatePromptConfig(
+				context.TODO(),
+				*uuidID,
+				userAccount,
+				dto.PromptConfigUpdateDTO{ModelType: ptr.To(models.ModelTypeGpt4)},
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, int32(2), updatedPromptConfig.Version)
+
+			versions := repositories.GetPromptConfigVersions(context.TODO(), *uuidID)
+			assert.Len(t, versions, 2)
+			assert.Equal(t, updatedPromptConfig.VersionID, versions[0].ID)
+			assert.Equal(t, models.ModelTypeGpt4, versions[0].ModelType)
+			assert.Equal(t, models.ModelTypeGpt35Turbo, versions[1].ModelType)
+		})
+
+		t.Run("does not create a new version when only the name changes", func(t *testing.T) {
+			promptConfigID := createPromptConfig(t)
+			uuidID, _ := db.StringToUUID(promptConfigID)
+
+			updatedPromptConfig, err := repositories.UpdatePromptConfig(
+				context.TODO(),
+				*uuidID,
+				userAccount,
+				dto.PromptConfigUpdateDTO{Name: ptr.To("new name")},
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, int32(1), updatedPromptConfig.Version)
+
+			versions := repositories.GetPromptConfigVersions(context.TODO(), *uuidID)
+			assert.Len(t, versions, 1)
+			assert.Equal(t, updatedPromptConfig.VersionID, versions[0].ID)
+		})
+
+		t.Run("creates distinct versions for concurrent updates", func(t *testing.T) {
+			promptConfigID := createPromptConfig(t)
+			uuidID, _ := db.StringToUUID(promptConfigID)
+
+			var wg sync.WaitGroup
+
+			for _, modelType := range []models.ModelType{models.ModelTypeGpt4, models.ModelTypeGpt35Turbo16k} {
+				wg.Add(1)
+
+				go func(modelType models.ModelType) {
+					defer wg.Done()
+
+					_, err := repositories.UpdatePromptConfig(
+						context.TODO(),
+						*uuidID,
+						userAccount,
+						dto.PromptConfigUpdateDTO{ModelType: ptr.To(modelType)},
+					)
+					assert.NoError(t, err)
+				}(modelType)
+			}
+
+			wg.Wait()
+
+			versions := repositories.GetPromptConfigVersions(context.TODO(), *uuidID)
+			assert.Len(t, versions, 3)
+			assert.Equal(t, int32(3), versions[0].Version)
+			assert.Equal(t, int32(2), versions[1].Version)
+		})
+	})
+
+	t.Run("DiffPromptConfigVersionDTOs", func(t *testing.T) {
+		t.Run("returns the changed fields", func(t *testing.T) {
+			diff := repositories.DiffPromptConfigVersionDTOs(
+				dto.PromptConfigVersionDTO{
+					Version:         1,
+					ModelType:       models.ModelTypeGpt35Turbo,
+					ModelVendor:     models.ModelVendorOPENAI,
+					ModelParameters: ptr.To(json.RawMessage(`{"temperature":1}`)),
+				},
+				dto.PromptConfigVersionDTO{
+					Version:         2,
+					ModelType:       models.ModelTypeGpt4,
+					ModelVendor:     models.ModelVendorOPENAI,
+					ModelParameters: ptr.To(json.RawMessage(`{"temperature":1}`)),
+				},
+			)
+			assert.Equal(t, int32(1), diff.FromVersion)
+			assert.Equal(t, int32(2), diff.ToVersion)
+			assert.Equal(t, []dto.PromptConfigVersionChangeDTO{
+				{
+					Field: "modelType",
+					From:  json.RawMessage(`"gpt-3.5-turbo"`),
+					To:    json.RawMessage(`"gpt-4"`),
+				},
+			}, diff.Changes)
+		})
+
+		t.Run("ignores JSON formatting and key order", func(t *testing.T) {
+			diff := repositories.DiffPromptConfigVersionDTOs(
+				dto.PromptConfigVersionDTO{
+					ModelParameters: ptr.To(json.RawMessage(`{"a": 1, "b": 2}`)),
+				},
+				dto.PromptConfigVersionDTO{
+					ModelParameters: ptr.To(json.RawMessage(`{"b":2,"a":1}`)),
+				},
+			)
+			assert.Empty(t, diff.Changes)
+		})
+	})
+
+	t.Run("DiffPromptConfigVersions", func(t *testing.T) {
+		t.Run("compares a version with the current version by default", func(t *testing.T) {
+			promptConfigID := createPromptConfig(t)
+			uuidID, _ := db.StringToUUID(promptConfigID)
+
+			_, err := repositories.UpdatePromptConfig(
+				context.TODO(),
+				*uuidID,
+				userAccount,
+				dto.PromptConfigUpdateDTO{ModelType: ptr.To(models.ModelTypeGpt4)},
+			)
+			assert.NoError(t, err)
+
+			versions := repositories.GetPromptConfigVersions(context.TODO(), *uuidID)
+			firstVersionID, _ := db.StringToUUID(versions[1].ID)
+
+			diff, err := repositories.DiffPromptConfigVersions(
+				context.TODO(),
+				*uuidID,
+				pgtype.UUID{},
+				*firstVersionID,
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, int32(2), diff.FromVersion)
+			assert.Equal(t, int32(1), diff.ToVersion)
+			assert.Len(t, diff.Changes, 1)
+			assert.Equal(t, "modelType", diff.Changes[0].Field)
+		})
+
+		t.Run("returns an error for a version of another prompt config", func(t *testing.T) {
+			promptConfigID := createPromptConfig(t)
+			uuidID, _ := db.StringToUUID(promptConfigID)
+			otherPromptConfigID := createPromptConfig(t)
+			otherUUIDID, _ := db.StringToUUID(otherPromptConfigID)
+
+			otherVersions := repositories.GetPromptConfigVersions(context.TODO(), *otherUUIDID)
+			otherVersionID, _ := db.StringToUUID(otherVersions[0].ID)
+
+			_, err := repositories.DiffPromptConfigVersions(
+				context.TODO(),
+				*uuidID,
+				pgtype.UUID{},
+				*otherVersionID,
+			)
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("RollbackPromptConfig", func(t *testing.T) {
+		t.Run("restores the content of a version as a new version", func(t *testing.T) {
+			promptConfigID := createPromptConfig(t)
+			uuidID, _ := db.StringToUUID(promptConfigID)
+
+			_, err := repositories.UpdatePromptConfig(
+				context.TODO(),
+				*uuidID,
+				userAccount,
+				dto.PromptConfigUpdateDTO{ModelType: ptr.To(models.ModelTypeGpt4)},
+			)
+			assert.NoError(t, err)
+
+			versions := repositories.GetPromptConfigVersions(context.TODO(), *uuidID)
+			firstVersionID, _ := db.StringToUUID(versions[1].ID)
+
+			rolledBackPromptConfig, err := repositories.RollbackPromptConfig(
+				context.TODO(),
+				*uuidID,
+				*firstVersionID,
+				userAccount,
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, int32(3), rolledBackPromptConfig.Version)
+			assert.Equal(t, models.ModelTypeGpt35Turbo, rolledBackPromptConfig.ModelType)
+
+			versions = repositories.GetPromptConfigVersions(context.TODO(), *uuidID)
+			assert.Len(t, versions, 3)
+			assert.Equal(t, rolledBackPromptConfig.VersionID, versions[0].ID)
+		})
+
+		t.Run("returns an error when rolling back to the current version", func(t *testing.T) {
+			promptConfigID := createPromptConfig(t)
+			uuidID, _ := db.StringToUUID(promptConfigID)
+
+			versions := repositories.GetPromptConfigVersions(context.TODO(), *uuidID)
+			versionID, _ := db.StringToUUID(versions[0].ID)
+
+			_, err := repositories.RollbackPromptConfig(
+				context.TODO(),
+				*uuidID,
+				*versionID,
+				userAccount,
+			)
+			assert.Error(t, err)
+		})
+	})
+}
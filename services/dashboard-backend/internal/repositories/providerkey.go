@@ -44,9 +44,9 @@ func CreateProviderKey(
 
 // DeleteProviderKey - deletes a provider key by ID and invalidates the redis cache.
 func DeleteProviderKey(ctx context.Context, projectID, providerKeyID pgtype.UUID) {
-	exc.Must(db.GetQueries().DeleteProviderKey(ctx, providerKeyID))
+	modelVendor := exc.MustResult(db.GetQueries().DeleteProviderKey(ctx, providerKeyID))
 
 	go func() {
-		rediscache.Invalidate(ctx, db.UUIDToString(&projectID))
+		rediscache.Invalidate(ctx, fmt.Sprintf("%s:%s", db.UUIDToString(&projectID), modelVendor))
 	}()
 }
@@ -2,6 +2,7 @@ package repositories_test
 
 import (
 	"context"
+	"fmt"
 	"github.com/basemind-ai/monorepo/e2e/factories"
 	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/dto"
 	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/repositories"
@@ -88,7 +89,8 @@ func TestProviderKeyRepository(t *testing.T) {
 
 			_, redisMock := testutils.CreateMockRedisClient(t)
 
-			redisMock.ExpectDel(db.UUIDToString(&project.ID)).SetVal(1)
+			redisMock.ExpectDel(fmt.Sprintf("%s:%s", db.UUIDToString(&project.ID), models.ModelVendorOPENAI)).
+				SetVal(1)
 
 			repositories.DeleteProviderKey(context.TODO(), project.ID, providerKey.ID)
 
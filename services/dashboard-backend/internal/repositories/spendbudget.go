@@ -0,0 +1,275 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/dto"
+	"github.com/basemind-ai/monorepo/shared/go/budgets"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/exc"
+	"github.com/basemind-ai/monorepo/shared/go/rediscache"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+// uniqueViolationErrorCode is the postgres error code raised when a unique constraint is violated.
+const uniqueViolationErrorCode = "23505"
+
+// ErrSpendBudgetNotFound is returned when a spend budget does not exist in the project.
+var ErrSpendBudgetNotFound = errors.New("spend budget not found")
+
+// ValidateSpendLimit - validates that the spend limit of a budget is positive.
+func ValidateSpendLimit(spendLimit decimal.Decimal) error {
+	if !spendLimit.IsPositive() {
+		return fmt.Errorf(
+			"invalid spend budget - the spend limit must be positive, got %s",
+			spendLimit.String(),
+		)
+	}
+
+	return nil
+}
+
+// GetSpendBudgets - returns the spend budgets of a project, with their spend in the current period.
+func GetSpendBudgets(ctx context.Context, projectID pgtype.UUID) []dto.SpendBudgetDTO {
+	rows := exc.MustResult(db.GetQueries().RetrieveProjectSpendBudgets(ctx, projectID))
+
+	now := time.Now()
+
+	spendBudgets := make([]dto.SpendBudgetDTO, len(rows))
+	for i, row := range rows {
+		spendBudget := models.SpendBudget{
+			ID:            row.ID,
+			Period:        row.Period,
+			Enforcement:   row.Enforcement,
+			SpendLimit:    row.SpendLimit,
+			CreatedAt:     row.CreatedAt,
+			UpdatedAt:     row.UpdatedAt,
+			ProjectID:     row.ProjectID,
+			ApplicationID: row.ApplicationID,
+		}
+
+		spendBudgets[i] = spendBudgetToDTO(ctx, spendBudget, row.ApplicationName, now)
+	}
+
+	return spendBudgets
+}
+
+// CreateSpendBudget - creates a spend budget for a project, or for one of its applications.
+// Note: a project and an application can each have a single budget per period.
+func CreateSpendBudget(
+	ctx context.Context,
+	projectID pgtype.UUID,
+	data dto.SpendBudgetDTO,
+) (*dto.SpendBudgetDTO, error) {
+	if validationErr := ValidateSpendLimit(data.SpendLimit); validationErr != nil {
+		return nil, validationErr
+	}
+
+	applicationID := pgtype.UUID{}
+	applicationName := ""
+
+	if data.ApplicationID != nil {
+		parsedID, uuidErr := db.StringToUUID(*data.ApplicationID)
+		if uuidErr != nil {
+			return nil, fmt.Errorf("invalid spend budget - %w", uuidErr)
+		}
+
+		application, retrievalErr := db.GetQueries().RetrieveApplication(ctx, *parsedID)
+		if retrievalErr != nil || application.ProjectID != projectID {
+			return nil, fmt.Errorf(
+				"invalid spend budget - application {%s} does not exist",
+				*data.ApplicationID,
+			)
+		}
+
+		applicationID = *parsedID
+		applicationName = application.Name
+	}
+
+	spendLimit, conversionErr := db.StringToNumeric(data.SpendLimit.String())
+	if conversionErr != nil {
+		return nil, fmt.Errorf("invalid spend budget - %w", conversionErr)
+	}
+
+	spendBudget, createErr := db.GetQueries().CreateSpendBudget(ctx, models.CreateSpendBudgetParams{
+		ProjectID:     projectID,
+		ApplicationID: applicationID,
+		Period:        models.SpendBudgetPeriod(data.Period),
+		Enforcement:   models.SpendBudgetEnforcement(data.Enforcement),
+		SpendLimit:    *spendLimit,
+	})
+	if createErr != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(createErr, &pgErr) && pgErr.Code == uniqueViolationErrorCode {
+			return nil, fmt.Errorf(
+				"invalid spend budget - a %s budget already exists for this scope",
+				data.Period,
+			)
+		}
+
+		return nil, fmt.Errorf("failed to create spend budget - %w", createErr)
+	}
+
+	invalidateSpendBudgets(ctx, projectID)
+
+	result := spendBudgetToDTO(ctx, spendBudget, applicationName, time.Now())
+
+	return &result, nil
+}
+
+// UpdateSpendBudget - updates the enforcement and limit of a spend budget of a project.
+func UpdateSpendBudget(
+	ctx context.Context,
+	projectID pgtype.UUID,
+	spendBudgetID pgtype.UUID,
+	data dto.SpendBudgetUpdateDTO,
+) (*dto.SpendBudgetDTO, error) {
+	if validationErr := ValidateSpendLimit(data.SpendLimit); validationErr != nil {
+		return nil, validationErr
+	}
+
+	spendLimit, conversionErr := db.StringToNumeric(data.SpendLimit.String())
+	if conversionErr != nil {
+		return nil, fmt.Errorf("invalid spend budget - %w", conversionErr)
+	}
+
+	spendBudget, updateErr := db.GetQueries().UpdateSpendBudget(ctx, models.UpdateSpendBudgetParams{
+		Enforcement: models.SpendBudgetEnforcement(data.Enforcement),
+		SpendLimit:  *spendLimit,
+		ID:          spendBudgetID,
+		ProjectID:   projectID,
+	})
+	if updateErr != nil {
+		if errors.Is(updateErr, pgx.ErrNoRows) {
+			return nil, ErrSpendBudgetNotFound
+		}
+
+		return nil, fmt.Errorf("failed to update spend budget - %w", updateErr)
+	}
+
+	invalidateSpendBudgets(ctx, projectID)
+
+	applicationName := ""
+	if spendBudget.ApplicationID.Valid {
+		application := exc.MustResult(
+			db.GetQueries().RetrieveApplication(ctx, spendBudget.ApplicationID),
+		)
+		applicationName = application.Name
+	}
+
+	result := spendBudgetToDTO(ctx, spendBudget, applicationName, time.Now())
+
+	return &result, nil
+}
+
+// DeleteSpendBudget - deletes a spend budget of a project, together with its alerts.
+func DeleteSpendBudget(ctx context.Context, projectID, spendBudgetID pgtype.UUID) error {
+	deletedRows, deleteErr := db.GetQueries().DeleteSpendBudget(ctx, models.DeleteSpendBudgetParams{
+		ID:        spendBudgetID,
+		ProjectID: projectID,
+	})
+	if deleteErr != nil {
+		return fmt.Errorf("failed to delete spend budget - %w", deleteErr)
+	}
+
+	if deletedRows == 0 {
+		return ErrSpendBudgetNotFound
+	}
+
+	invalidateSpendBudgets(ctx, projectID)
+
+	return nil
+}
+
+// GetSpendBudgetAlerts - returns the latest spend budget alerts of a project, newest first.
+func GetSpendBudgetAlerts(
+	ctx context.Context,
+	projectID pgtype.UUID,
+	limit int32,
+) []dto.SpendBudgetAlertDTO {
+	rows := exc.MustResult(db.GetQueries().RetrieveProjectSpendBudgetAlerts(
+		ctx,
+		models.RetrieveProjectSpendBudgetAlertsParams{
+			ProjectID: projectID,
+			PageLimit: limit,
+		},
+	))
+
+	alerts := make([]dto.SpendBudgetAlertDTO, len(rows))
+	for i, row := range rows {
+		alerts[i] = dto.SpendBudgetAlertDTO{
+			ID:              db.UUIDToString(&row.ID),
+			SpendBudgetID:   db.UUIDToString(&row.SpendBudgetID),
+			ApplicationID:   optionalUUIDToString(row.ApplicationID),
+			ApplicationName: row.ApplicationName,
+			Period:          string(row.Period),
+			Enforcement:     string(row.Enforcement),
+			SpendLimit:      *exc.MustResult(db.NumericToDecimal(row.SpendLimit)),
+			Threshold:       row.Threshold,
+			Spend:           *exc.MustResult(db.NumericToDecimal(row.Spend)),
+			PeriodStart:     row.PeriodStart.Time,
+			CreatedAt:       row.CreatedAt.Time,
+		}
+	}
+
+	return alerts
+}
+
+// spendBudgetToDTO - converts a spend budget to its DTO, with its spend in the period containing the given time.
+func spendBudgetToDTO(
+	ctx context.Context,
+	spendBudget models.SpendBudget,
+	applicationName string,
+	now time.Time,
+) dto.SpendBudgetDTO {
+	periodStart := budgets.PeriodStart(spendBudget.Period, now)
+
+	return dto.SpendBudgetDTO{
+		ID:              db.UUIDToString(&spendBudget.ID),
+		ApplicationID:   optionalUUIDToString(spendBudget.ApplicationID),
+		ApplicationName: applicationName,
+		Period:          string(spendBudget.Period),
+		Enforcement:     string(spendBudget.Enforcement),
+		SpendLimit:      *exc.MustResult(db.NumericToDecimal(spendBudget.SpendLimit)),
+		CurrentSpend:    exc.MustResult(budgets.RetrieveSpend(ctx, spendBudget, periodStart)),
+		PeriodStart:     periodStart,
+		CreatedAt:       spendBudget.CreatedAt.Time,
+		UpdatedAt:       spendBudget.UpdatedAt.Time,
+	}
+}
+
+// optionalUUIDToString - returns the string value of a nullable UUID, or nil if it is null.
+func optionalUUIDToString(value pgtype.UUID) *string {
+	if !value.Valid {
+		return nil
+	}
+
+	stringValue := db.UUIDToString(&value)
+
+	return &stringValue
+}
+
+// invalidateSpendBudgets - invalidates the cached spend budget status of all the applications of a project,
+// since project budgets apply to every application.
+func invalidateSpendBudgets(ctx context.Context, projectID pgtype.UUID) {
+	applications := exc.MustResult(db.GetQueries().RetrieveApplications(ctx, projectID))
+
+	cacheKeys := make([]string, len(applications))
+	for i, application := range applications {
+		cacheKeys[i] = budgets.CreateCacheKey(application.ID)
+	}
+
+	if len(cacheKeys) == 0 {
+		return
+	}
+
+	go func() {
+		rediscache.Invalidate(ctx, cacheKeys...)
+	}()
+}
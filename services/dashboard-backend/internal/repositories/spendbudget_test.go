@@ -0,0 +1,213 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/basemind-ai/monorepo/e2e/factories"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/dto"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/repositories"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/exc"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpendBudgetRepository(t *testing.T) { //nolint: revive
+	project, _ := factories.CreateProject(context.TODO())
+
+	t.Run("ValidateSpendLimit", func(t *testing.T) {
+		assert.NoError(t, repositories.ValidateSpendLimit(decimal.RequireFromString("0.01")))
+		assert.Error(t, repositories.ValidateSpendLimit(decimal.Zero))
+		assert.Error(t, repositories.ValidateSpendLimit(decimal.NewFromInt(-1)))
+	})
+
+	t.Run("CreateSpendBudget", func(t *testing.T) {
+		t.Run("creates a project spend budget", func(t *testing.T) {
+			newProject, _ := factories.CreateProject(context.TODO())
+
+			spendBudget, err := repositories.CreateSpendBudget(
+				context.TODO(),
+				newProject.ID,
+				dto.SpendBudgetDTO{
+					Period:      string(models.SpendBudgetPeriodDAILY),
+					Enforcement: string(models.SpendBudgetEnforcementHARD),
+					SpendLimit:  decimal.NewFromInt(10),
+				},
+			)
+			assert.NoError(t, err)
+			assert.NotEmpty(t, spendBudget.ID)
+			assert.Nil(t, spendBudget.ApplicationID)
+			assert.True(t, decimal.NewFromInt(10).Equal(spendBudget.SpendLimit))
+			assert.True(t, decimal.Zero.Equal(spendBudget.CurrentSpend))
+		})
+
+		t.Run("creates an application spend budget with its current spend", func(t *testing.T) {
+			newProject, _ := factories.CreateProject(context.TODO())
+			application, _ := factories.CreateApplication(context.TODO(), newProject.ID)
+			promptConfig, _ := factories.CreateOpenAIPromptConfig(context.TODO(), application.ID)
+			_, _ = factories.CreatePromptRequestRecord(context.TODO(), promptConfig.ID)
+
+			spendBudget, err := repositories.CreateSpendBudget(
+				context.TODO(),
+				newProject.ID,
+				dto.SpendBudgetDTO{
+					ApplicationID: ptr.To(db.UUIDToString(&application.ID)),
+					Period:        string(models.SpendBudgetPeriodMONTHLY),
+					Enforcement:   string(models.SpendBudgetEnforcementSOFT),
+					SpendLimit:    decimal.NewFromInt(10),
+				},
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, db.UUIDToString(&application.ID), *spendBudget.ApplicationID)
+			assert.Equal(t, application.Name, spendBudget.ApplicationName)
+			assert.True(t, decimal.RequireFromString("0.0000465").Equal(spendBudget.CurrentSpend))
+		})
+
+		t.Run("fails for a duplicate period", func(t *testing.T) {
+			newProject, _ := factories.CreateProject(context.TODO())
+			data := dto.SpendBudgetDTO{
+				Period:      string(models.SpendBudgetPeriodDAILY),
+				Enforcement: string(models.SpendBudgetEnforcementHARD),
+				SpendLimit:  decimal.NewFromInt(10),
+			}
+
+			_, err := repositories.CreateSpendBudget(context.TODO(), newProject.ID, data)
+			assert.NoError(t, err)
+
+			_, err = repositories.CreateSpendBudget(context.TODO(), newProject.ID, data)
+			assert.ErrorContains(t, err, "invalid spend budget")
+		})
+
+		t.Run("fails for an application of another project", func(t *testing.T) {
+			otherProject, _ := factories.CreateProject(context.TODO())
+			application, _ := factories.CreateApplication(context.TODO(), otherProject.ID)
+
+			_, err := repositories.CreateSpendBudget(
+				context.TODO(),
+				project.ID,
+				dto.SpendBudgetDTO{
+					ApplicationID: ptr.To(db.UUIDToString(&application.ID)),
+					Period:        string(models.SpendBudgetPeriodDAILY),
+					Enforcement:   string(models.SpendBudgetEnforcementHARD),
+					SpendLimit:    decimal.NewFromInt(10),
+				},
+			)
+			assert.ErrorContains(t, err, "invalid spend budget")
+		})
+	})
+
+	t.Run("UpdateSpendBudget", func(t *testing.T) {
+		t.Run("updates the enforcement and limit of a spend budget", func(t *testing.T) {
+			newProject, _ := factories.CreateProject(context.TODO())
+			spendBudget, _ := repositories.CreateSpendBudget(
+				context.TODO(),
+				newProject.ID,
+				dto.SpendBudgetDTO{
+					Period:      string(models.SpendBudgetPeriodDAILY),
+					Enforcement: string(models.SpendBudgetEnforcementHARD),
+					SpendLimit:  decimal.NewFromInt(10),
+				},
+			)
+			spendBudgetID, _ := db.StringToUUID(spendBudget.ID)
+
+			updated, err := repositories.UpdateSpendBudget(
+				context.TODO(),
+				newProject.ID,
+				*spendBudgetID,
+				dto.SpendBudgetUpdateDTO{
+					Enforcement: string(models.SpendBudgetEnforcementSOFT),
+					SpendLimit:  decimal.NewFromInt(20),
+				},
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, string(models.SpendBudgetEnforcementSOFT), updated.Enforcement)
+			assert.True(t, decimal.NewFromInt(20).Equal(updated.SpendLimit))
+		})
+
+		t.Run("fails for a spend budget of another project", func(t *testing.T) {
+			otherProject, _ := factories.CreateProject(context.TODO())
+			spendBudget, _ := repositories.CreateSpendBudget(
+				context.TODO(),
+				otherProject.ID,
+				dto.SpendBudgetDTO{
+					Period:      string(models.SpendBudgetPeriodDAILY),
+					Enforcement: string(models.SpendBudgetEnforcementHARD),
+					SpendLimit:  decimal.NewFromInt(10),
+				},
+			)
+			spendBudgetID, _ := db.StringToUUID(spendBudget.ID)
+
+			_, err := repositories.UpdateSpendBudget(
+				context.TODO(),
+				project.ID,
+				*spendBudgetID,
+				dto.SpendBudgetUpdateDTO{
+					Enforcement: string(models.SpendBudgetEnforcementSOFT),
+					SpendLimit:  decimal.NewFromInt(20),
+				},
+			)
+			assert.ErrorIs(t, err, repositories.ErrSpendBudgetNotFound)
+		})
+	})
+
+	t.Run("DeleteSpendBudget", func(t *testing.T) {
+		t.Run("deletes a spend budget", func(t *testing.T) {
+			newProject, _ := factories.CreateProject(context.TODO())
+			spendBudget, _ := repositories.CreateSpendBudget(
+				context.TODO(),
+				newProject.ID,
+				dto.SpendBudgetDTO{
+					Period:      string(models.SpendBudgetPeriodDAILY),
+					Enforcement: string(models.SpendBudgetEnforcementHARD),
+					SpendLimit:  decimal.NewFromInt(10),
+				},
+			)
+			spendBudgetID, _ := db.StringToUUID(spendBudget.ID)
+
+			assert.NoError(t, repositories.DeleteSpendBudget(context.TODO(), newProject.ID, *spendBudgetID))
+			assert.Empty(t, repositories.GetSpendBudgets(context.TODO(), newProject.ID))
+			assert.ErrorIs(
+				t,
+				repositories.DeleteSpendBudget(context.TODO(), newProject.ID, *spendBudgetID),
+				repositories.ErrSpendBudgetNotFound,
+			)
+		})
+	})
+
+	t.Run("GetSpendBudgetAlerts", func(t *testing.T) {
+		t.Run("returns the alerts of the project spend budgets", func(t *testing.T) {
+			newProject, _ := factories.CreateProject(context.TODO())
+			spendBudget, _ := repositories.CreateSpendBudget(
+				context.TODO(),
+				newProject.ID,
+				dto.SpendBudgetDTO{
+					Period:      string(models.SpendBudgetPeriodDAILY),
+					Enforcement: string(models.SpendBudgetEnforcementHARD),
+					SpendLimit:  decimal.NewFromInt(10),
+				},
+			)
+			spendBudgetID, _ := db.StringToUUID(spendBudget.ID)
+
+			_, createErr := db.GetQueries().CreateSpendBudgetAlert(
+				context.TODO(),
+				models.CreateSpendBudgetAlertParams{
+					SpendBudgetID: *spendBudgetID,
+					PeriodStart:   pgtype.Timestamptz{Time: spendBudget.PeriodStart, Valid: true},
+					Threshold:     50,
+					Spend:         *exc.MustResult(db.StringToNumeric("5")),
+				},
+			)
+			assert.NoError(t, createErr)
+
+			alerts := repositories.GetSpendBudgetAlerts(context.TODO(), newProject.ID, 10)
+			assert.Len(t, alerts, 1)
+			assert.Equal(t, spendBudget.ID, alerts[0].SpendBudgetID)
+			assert.Equal(t, int32(50), alerts[0].Threshold)
+			assert.True(t, decimal.NewFromInt(5).Equal(alerts[0].Spend))
+		})
+	})
+}
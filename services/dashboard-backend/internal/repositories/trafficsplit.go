@@ -0,0 +1,158 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/dto"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/exc"
+	"github.com/basemind-ai/monorepo/shared/go/rediscache"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+// trafficSplitTotalWeight is the total weight of the variants of a traffic split.
+const trafficSplitTotalWeight = 100
+
+// ValidateTrafficSplit - validates that the variants of a traffic split are unique prompt configs,
+// and that their weights add up to 100. An empty traffic split is valid.
+func ValidateTrafficSplit(trafficSplit dto.TrafficSplitDTO) error {
+	if len(trafficSplit.Variants) == 0 {
+		return nil
+	}
+
+	totalWeight := int32(0)
+	promptConfigIDs := make(map[string]struct{}, len(trafficSplit.Variants))
+
+	for _, variant := range trafficSplit.Variants {
+		if _, exists := promptConfigIDs[variant.PromptConfigID]; exists {
+			return fmt.Errorf(
+				"invalid traffic split - duplicate prompt config {%s}",
+				variant.PromptConfigID,
+			)
+		}
+
+		promptConfigIDs[variant.PromptConfigID] = struct{}{}
+		totalWeight += variant.Weight
+	}
+
+	if totalWeight != trafficSplitTotalWeight {
+		return fmt.Errorf(
+			"invalid traffic split - the weights must add up to %d, got %d",
+			trafficSplitTotalWeight,
+			totalWeight,
+		)
+	}
+
+	return nil
+}
+
+// GetTrafficSplit - returns the traffic split of an application.
+func GetTrafficSplit(ctx context.Context, applicationID pgtype.UUID) dto.TrafficSplitDTO {
+	promptConfigs := exc.MustResult(db.GetQueries().
+		RetrieveTrafficSplitPromptConfigs(ctx, applicationID))
+
+	variants := make([]dto.TrafficSplitVariantDTO, len(promptConfigs))
+	for i, promptConfig := range promptConfigs {
+		variants[i] = dto.TrafficSplitVariantDTO{
+			PromptConfigID: db.UUIDToString(&promptConfig.ID),
+			Weight:         promptConfig.TrafficWeight,
+		}
+	}
+
+	return dto.TrafficSplitDTO{Variants: variants}
+}
+
+// UpdateTrafficSplit - replaces the traffic split of an application.
+// The weights of all the prompt configs of the application are updated in a single transaction.
+func UpdateTrafficSplit(
+	ctx context.Context,
+	applicationID pgtype.UUID,
+	trafficSplit dto.TrafficSplitDTO,
+) error {
+	if validationErr := ValidateTrafficSplit(trafficSplit); validationErr != nil {
+		return validationErr
+	}
+
+	tx, txErr := db.GetOrCreateTx(ctx)
+	if txErr != nil {
+		return fmt.Errorf("failed to create transaction - %w", txErr)
+	}
+
+	if db.ShouldCommit(ctx) {
+		defer db.HandleRollback(ctx, tx)
+	}
+
+	queries := db.GetQueries().WithTx(tx)
+
+	if resetErr := queries.ResetApplicationTrafficWeights(ctx, applicationID); resetErr != nil {
+		return fmt.Errorf("failed to reset traffic weights - %w", resetErr)
+	}
+
+	for _, variant := range trafficSplit.Variants {
+		promptConfigID, uuidErr := db.StringToUUID(variant.PromptConfigID)
+		if uuidErr != nil {
+			return fmt.Errorf("invalid traffic split - %w", uuidErr)
+		}
+
+		updatedRows, updateErr := queries.UpdatePromptConfigTrafficWeight(
+			ctx,
+			models.UpdatePromptConfigTrafficWeightParams{
+				ID:            *promptConfigID,
+				ApplicationID: applicationID,
+				TrafficWeight: variant.Weight,
+			},
+		)
+		if updateErr != nil {
+			return fmt.Errorf("failed to update traffic weight - %w", updateErr)
+		}
+
+		if updatedRows == 0 {
+			return fmt.Errorf(
+				"invalid traffic split - prompt config {%s} does not exist",
+				variant.PromptConfigID,
+			)
+		}
+	}
+
+	db.CommitIfShouldCommit(ctx, tx)
+
+	go func() {
+		rediscache.Invalidate(ctx, db.UUIDToString(&applicationID))
+	}()
+
+	return nil
+}
+
+// GetTrafficSplitAnalyticsByDateRange - returns the analytics of the requests routed by the traffic split
+// of an application, per prompt config.
+func GetTrafficSplitAnalyticsByDateRange(
+	ctx context.Context,
+	applicationID pgtype.UUID,
+	fromDate, toDate time.Time,
+) []dto.TrafficSplitVariantAnalyticsDTO {
+	rows := exc.MustResult(db.GetQueries().RetrieveApplicationTrafficSplitAnalytics(
+		ctx,
+		models.RetrieveApplicationTrafficSplitAnalyticsParams{
+			ApplicationID: applicationID,
+			CreatedAt:     pgtype.Timestamptz{Time: fromDate, Valid: true},
+			CreatedAt_2:   pgtype.Timestamptz{Time: toDate, Valid: true},
+		},
+	))
+
+	analytics := make([]dto.TrafficSplitVariantAnalyticsDTO, len(rows))
+	for i, row := range rows {
+		analytics[i] = dto.TrafficSplitVariantAnalyticsDTO{
+			PromptConfigID:    db.UUIDToString(&row.PromptConfigID),
+			PromptConfigName:  row.Name,
+			Weight:            row.TrafficWeight,
+			TotalAPICalls:     row.TotalRequests,
+			TotalErrors:       row.TotalErrors,
+			TokenCost:         *exc.MustResult(db.NumericToDecimal(row.TokensCost)),
+			AverageDurationMs: row.AverageDurationMs,
+		}
+	}
+
+	return analytics
+}
@@ -0,0 +1,193 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/basemind-ai/monorepo/e2e/factories"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/dto"
+	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/repositories"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/exc"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrafficSplitRepository(t *testing.T) { //nolint: revive
+	project, _ := factories.CreateProject(context.TODO())
+
+	t.Run("ValidateTrafficSplit", func(t *testing.T) {
+		for _, testCase := range []struct {
+			Name         string
+			TrafficSplit dto.TrafficSplitDTO
+			IsValid      bool
+		}{
+			{Name: "allows an empty traffic split", TrafficSplit: dto.TrafficSplitDTO{}, IsValid: true},
+			{
+				Name: "allows weights that add up to 100",
+				TrafficSplit: dto.TrafficSplitDTO{Variants: []dto.TrafficSplitVariantDTO{
+					{PromptConfigID: "a", Weight: 90},
+					{PromptConfigID: "b", Weight: 10},
+				}},
+				IsValid: true,
+			},
+			{
+				Name: "rejects weights that do not add up to 100",
+				TrafficSplit: dto.TrafficSplitDTO{Variants: []dto.TrafficSplitVariantDTO{
+					{PromptConfigID: "a", Weight: 90},
+					{PromptConfigID: "b", Weight: 20},
+				}},
+				IsValid: false,
+			},
+			{
+				Name: "rejects duplicate prompt configs",
+				TrafficSplit: dto.TrafficSplitDTO{Variants: []dto.TrafficSplitVariantDTO{
+					{PromptConfigID: "a", Weight: 50},
+					{PromptConfigID: "a", Weight: 50},
+				}},
+				IsValid: false,
+			},
+		} {
+			t.Run(testCase.Name, func(t *testing.T) {
+				err := repositories.ValidateTrafficSplit(testCase.TrafficSplit)
+				if testCase.IsValid {
+					assert.NoError(t, err)
+				} else {
+					assert.Error(t, err)
+				}
+			})
+		}
+	})
+
+	t.Run("UpdateTrafficSplit", func(t *testing.T) {
+		t.Run("replaces the traffic split of the application", func(t *testing.T) {
+			application, _ := factories.CreateApplication(context.TODO(), project.ID)
+			firstPromptConfig, _ := factories.CreateOpenAIPromptConfig(context.TODO(), application.ID)
+			secondPromptConfig, _ := factories.CreateOpenAIPromptConfig(context.TODO(), application.ID)
+			thirdPromptConfig, _ := factories.CreateOpenAIPromptConfig(context.TODO(), application.ID)
+
+			err := repositories.UpdateTrafficSplit(context.TODO(), application.ID, dto.TrafficSplitDTO{
+				Variants: []dto.TrafficSplitVariantDTO{
+					{PromptConfigID: db.UUIDToString(&firstPromptConfig.ID), Weight: 50},
+					{PromptConfigID: db.UUIDToString(&secondPromptConfig.ID), Weight: 50},
+				},
+			})
+			assert.NoError(t, err)
+
+			err = repositories.UpdateTrafficSplit(context.TODO(), application.ID, dto.TrafficSplitDTO{
+				Variants: []dto.TrafficSplitVariantDTO{
+					{PromptConfigID: db.UUIDToString(&secondPromptConfig.ID), Weight: 90},
+					{PromptConfigID: db.UUIDToString(&thirdPromptConfig.ID), Weight: 10},
+				},
+			})
+			assert.NoError(t, err)
+
+			trafficSplit := repositories.GetTrafficSplit(context.TODO(), application.ID)
+			assert.Equal(t, []dto.TrafficSplitVariantDTO{
+				{PromptConfigID: db.UUIDToString(&secondPromptConfig.ID), Weight: 90},
+				{PromptConfigID: db.UUIDToString(&thirdPromptConfig.ID), Weight: 10},
+			}, trafficSplit.Variants)
+		})
+
+		t.Run("disables the traffic split", func(t *testing.T) {
+			application, _ := factories.CreateApplication(context.TODO(), project.ID)
+			promptConfig, _ := factories.CreateOpenAIPromptConfig(context.TODO(), application.ID)
+
+			err := repositories.UpdateTrafficSplit(context.TODO(), application.ID, dto.TrafficSplitDTO{
+				Variants: []dto.TrafficSplitVariantDTO{
+					{PromptConfigID: db.UUIDToString(&promptConfig.ID), Weight: 100},
+				},
+			})
+			assert.NoError(t, err)
+
+			err = repositories.UpdateTrafficSplit(context.TODO(), application.ID, dto.TrafficSplitDTO{})
+			assert.NoError(t, err)
+
+			trafficSplit := repositories.GetTrafficSplit(context.TODO(), application.ID)
+			assert.Empty(t, trafficSplit.Variants)
+		})
+
+		t.Run("rejects a prompt config of another application", func(t *testing.T) {
+			application, _ := factories.CreateApplication(context.TODO(), project.ID)
+			promptConfig, _ := factories.CreateOpenAIPromptConfig(context.TODO(), application.ID)
+			otherApplication, _ := factories.CreateApplication(context.TODO(), project.ID)
+			otherPromptConfig, _ := factories.CreateOpenAIPromptConfig(
+				context.TODO(),
+				otherApplication.ID,
+			)
+
+			err := repositories.UpdateTrafficSplit(context.TODO(), application.ID, dto.TrafficSplitDTO{
+				Variants: []dto.TrafficSplitVariantDTO{
+					{PromptConfigID: db.UUIDToString(&promptConfig.ID), Weight: 50},
+					{PromptConfigID: db.UUIDToString(&otherPromptConfig.ID), Weight: 50},
+				},
+			})
+			assert.ErrorContains(t, err, "invalid traffic split")
+
+			trafficSplit := repositories.GetTrafficSplit(context.TODO(), application.ID)
+			assert.Empty(t, trafficSplit.Variants)
+		})
+	})
+
+	t.Run("GetTrafficSplitAnalyticsByDateRange", func(t *testing.T) {
+		t.Run("returns the analytics of the traffic split requests per prompt config", func(t *testing.T) {
+			application, _ := factories.CreateApplication(context.TODO(), project.ID)
+			promptConfig, _ := factories.CreateOpenAIPromptConfig(context.TODO(), application.ID)
+
+			for _, testCase := range []struct {
+				Duration       time.Duration
+				IsCacheHit     bool
+				IsTrafficSplit bool
+			}{
+				{Duration: 100 * time.Millisecond, IsTrafficSplit: true},
+				{Duration: 300 * time.Millisecond, IsTrafficSplit: true},
+				{IsCacheHit: true, IsTrafficSplit: true},
+				{Duration: time.Second},
+			} {
+				startTime := time.Now()
+				finishTime := startTime.Add(testCase.Duration)
+
+				// cache hits are stored without a duration
+				durationMs := pgtype.Int4{}
+				if !testCase.IsCacheHit {
+					durationMs = pgtype.Int4{Int32: int32(testCase.Duration.Milliseconds()), Valid: true}
+				}
+
+				_, err := db.GetQueries().CreatePromptRequestRecord(
+					context.TODO(),
+					models.CreatePromptRequestRecordParams{
+						PromptConfigID:     promptConfig.ID,
+						RequestTokens:      10,
+						ResponseTokens:     20,
+						RequestTokensCost:  *exc.MustResult(db.StringToNumeric("0.1")),
+						ResponseTokensCost: *exc.MustResult(db.StringToNumeric("0.2")),
+						StartTime:          pgtype.Timestamptz{Time: startTime, Valid: true},
+						FinishTime:         pgtype.Timestamptz{Time: finishTime, Valid: true},
+						DurationMs:         durationMs,
+						FinishReason:       models.PromptFinishReasonDONE,
+						Attempts:           1,
+						IsCacheHit:         testCase.IsCacheHit,
+						IsTrafficSplit:     testCase.IsTrafficSplit,
+					},
+				)
+				assert.NoError(t, err)
+			}
+
+			analytics := repositories.GetTrafficSplitAnalyticsByDateRange(
+				context.TODO(),
+				application.ID,
+				time.Now().Add(-time.Hour),
+				time.Now().Add(time.Hour),
+			)
+			assert.Len(t, analytics, 1)
+			assert.Equal(t, db.UUIDToString(&promptConfig.ID), analytics[0].PromptConfigID)
+			assert.Equal(t, int64(3), analytics[0].TotalAPICalls)
+			assert.Equal(t, int64(0), analytics[0].TotalErrors)
+			assert.True(t, decimal.RequireFromString("0.9").Equal(analytics[0].TokenCost))
+			assert.Equal(t, float64(200), analytics[0].AverageDurationMs)
+		})
+	})
+}
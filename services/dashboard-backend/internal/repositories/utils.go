@@ -8,11 +8,14 @@ import (
 	"github.com/basemind-ai/monorepo/shared/go/ptr"
 	"github.com/basemind-ai/monorepo/shared/go/serialization"
 	"github.com/go-playground/validator/v10"
+	"github.com/xeipuuv/gojsonschema"
 	"regexp"
+	"slices"
 )
 
 var (
 	curlyBracesRegex = regexp.MustCompile(`\{([^}]+)\}`)
+	toolNameRegex    = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
 	vendorParsers    = map[models.ModelVendor]func(message *json.RawMessage) ([]string, *json.RawMessage, error){
 		models.ModelVendorOPENAI: parseOpenAIMessages,
 		models.ModelVendorCOHERE: parseCohereMessage,
@@ -122,3 +125,117 @@ func ParsePromptMessages( //nolint: revive
 
 	return parser(promptMessages)
 }
+
+// ParseFallbackModels - validates the fallback models of a prompt config and parses their prompt messages.
+// Fallback models of a vendor other than the prompt config vendor must define their own prompt messages,
+// which may only use the template variables expected by the prompt config.
+// Returns the serialized fallback models, or nil if there are none.
+func ParseFallbackModels(
+	fallbackModels []datatypes.FallbackModelDTO,
+	vendor models.ModelVendor,
+	expectedTemplateVariables []string,
+) ([]byte, error) {
+	if len(fallbackModels) == 0 {
+		return nil, nil
+	}
+
+	for i, fallbackModel := range fallbackModels {
+		if invalidVendorOrModelErr := models.ValidateModelType(fallbackModel.ModelVendor, fallbackModel.ModelType); invalidVendorOrModelErr != nil {
+			return nil, fmt.Errorf("invalid fallback model - %w", invalidVendorOrModelErr)
+		}
+
+		if fallbackModel.ProviderPromptMessages == nil {
+			if fallbackModel.ModelVendor != vendor {
+				return nil, fmt.Errorf(
+					"invalid fallback model - prompt messages are required for vendor {%s}",
+					fallbackModel.ModelVendor,
+				)
+			}
+
+			continue
+		}
+
+		fallbackVariables, promptMessages, parseErr := ParsePromptMessages(
+			fallbackModel.ProviderPromptMessages,
+			fallbackModel.ModelVendor,
+		)
+		if parseErr != nil {
+			return nil, fmt.Errorf("invalid fallback model - %w", parseErr)
+		}
+
+		for _, variable := range fallbackVariables {
+			if !slices.Contains(expectedTemplateVariables, variable) {
+				return nil, fmt.Errorf(
+					"invalid fallback model - unexpected template variable {%s}",
+					variable,
+				)
+			}
+		}
+
+		fallbackModels[i].ProviderPromptMessages = promptMessages
+	}
+
+	return serialization.SerializeJSON(fallbackModels), nil
+}
+
+// ParseTools - validates the tools of a prompt config.
+// Tool names must be unique and the tool parameters, if set, must be a JSON schema object.
+// Returns the serialized tools, or nil if there are none.
+func ParseTools(tools []datatypes.ToolDTO) ([]byte, error) {
+	if len(tools) == 0 {
+		return nil, nil
+	}
+
+	toolNames := make(map[string]struct{}, len(tools))
+
+	for _, tool := range tools {
+		if validationErr := validate.Struct(tool); validationErr != nil {
+			return nil, fmt.Errorf("invalid tool - %w", validationErr)
+		}
+
+		if !toolNameRegex.MatchString(tool.Name) {
+			return nil, fmt.Errorf(
+				"invalid tool - name {%s} may only contain letters, digits, underscores and dashes",
+				tool.Name,
+			)
+		}
+
+		if _, exists := toolNames[tool.Name]; exists {
+			return nil, fmt.Errorf("invalid tool - duplicate name {%s}", tool.Name)
+		}
+
+		toolNames[tool.Name] = struct{}{}
+
+		if tool.Parameters != nil {
+			var schema map[string]any
+			if unmarshalErr := json.Unmarshal(*tool.Parameters, &schema); unmarshalErr != nil || schema == nil {
+				return nil, fmt.Errorf(
+					"invalid tool - parameters of {%s} must be a JSON schema object",
+					tool.Name,
+				)
+			}
+		}
+	}
+
+	return serialization.SerializeJSON(tools), nil
+}
+
+// ParseResponseSchema - validates the JSON schema a prompt config declares for its responses.
+// The schema must be a JSON object that compiles as a JSON schema.
+// Returns the serialized schema, or nil if there is none or it is JSON null.
+func ParseResponseSchema(responseSchema json.RawMessage) ([]byte, error) {
+	if len(responseSchema) == 0 || string(responseSchema) == "null" {
+		return nil, nil
+	}
+
+	var schema map[string]any
+	if unmarshalErr := json.Unmarshal(responseSchema, &schema); unmarshalErr != nil || schema == nil {
+		return nil, fmt.Errorf("invalid response schema - must be a JSON schema object")
+	}
+
+	if _, schemaErr := gojsonschema.NewSchema(gojsonschema.NewGoLoader(schema)); schemaErr != nil {
+		return nil, fmt.Errorf("invalid response schema - %w", schemaErr)
+	}
+
+	return serialization.SerializeJSON(schema), nil
+}
@@ -3,6 +3,7 @@ package repositories_test
 import (
 	"encoding/json"
 	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/repositories"
+	"github.com/basemind-ai/monorepo/shared/go/datatypes"
 	"github.com/basemind-ai/monorepo/shared/go/db/models"
 	"github.com/basemind-ai/monorepo/shared/go/ptr"
 	"github.com/stretchr/testify/assert"
@@ -81,4 +82,191 @@ func TestUtils(t *testing.T) {
 			assert.Nil(t, parsedMessages)
 		})
 	})
+
+	t.Run("ParseFallbackModels", func(t *testing.T) {
+		modelParameters := ptr.To(json.RawMessage(`{"temperature": 1}`))
+
+		t.Run("returns nil for no fallback models", func(t *testing.T) {
+			serialized, err := repositories.ParseFallbackModels(
+				nil,
+				models.ModelVendorOPENAI,
+				[]string{"name"},
+			)
+
+			assert.NoError(t, err)
+			assert.Nil(t, serialized)
+		})
+
+		t.Run("parses a fallback model of the same vendor without prompt messages", func(t *testing.T) {
+			serialized, err := repositories.ParseFallbackModels(
+				[]datatypes.FallbackModelDTO{{
+					ModelParameters: modelParameters,
+					ModelType:       models.ModelTypeGpt35Turbo,
+					ModelVendor:     models.ModelVendorOPENAI,
+				}},
+				models.ModelVendorOPENAI,
+				[]string{"name"},
+			)
+
+			assert.NoError(t, err)
+
+			fallbackModels, unmarshalErr := datatypes.UnmarshalFallbackModels(serialized)
+			assert.NoError(t, unmarshalErr)
+			assert.Len(t, fallbackModels, 1)
+			assert.Equal(t, models.ModelTypeGpt35Turbo, fallbackModels[0].ModelType)
+			assert.Nil(t, fallbackModels[0].ProviderPromptMessages)
+		})
+
+		t.Run("parses a fallback model of another vendor with prompt messages", func(t *testing.T) {
+			serialized, err := repositories.ParseFallbackModels(
+				[]datatypes.FallbackModelDTO{{
+					ModelParameters:        modelParameters,
+					ModelType:              models.ModelTypeCommand,
+					ModelVendor:            models.ModelVendorCOHERE,
+					ProviderPromptMessages: ptr.To(json.RawMessage(`[{"message": "Hello {name}!"}]`)),
+				}},
+				models.ModelVendorOPENAI,
+				[]string{"name"},
+			)
+
+			assert.NoError(t, err)
+
+			fallbackModels, unmarshalErr := datatypes.UnmarshalFallbackModels(serialized)
+			assert.NoError(t, unmarshalErr)
+			assert.Len(t, fallbackModels, 1)
+			assert.Equal(t, models.ModelVendorCOHERE, fallbackModels[0].ModelVendor)
+			assert.NotNil(t, fallbackModels[0].ProviderPromptMessages)
+		})
+
+		t.Run("returns error for an invalid model type", func(t *testing.T) {
+			_, err := repositories.ParseFallbackModels(
+				[]datatypes.FallbackModelDTO{{
+					ModelParameters: modelParameters,
+					ModelType:       models.ModelTypeCommand,
+					ModelVendor:     models.ModelVendorOPENAI,
+				}},
+				models.ModelVendorOPENAI,
+				[]string{"name"},
+			)
+
+			assert.ErrorContains(t, err, "invalid fallback model")
+		})
+
+		t.Run("returns error for another vendor without prompt messages", func(t *testing.T) {
+			_, err := repositories.ParseFallbackModels(
+				[]datatypes.FallbackModelDTO{{
+					ModelParameters: modelParameters,
+					ModelType:       models.ModelTypeCommand,
+					ModelVendor:     models.ModelVendorCOHERE,
+				}},
+				models.ModelVendorOPENAI,
+				[]string{"name"},
+			)
+
+			assert.ErrorContains(t, err, "invalid fallback model")
+		})
+
+		t.Run("returns error for an unexpected template variable", func(t *testing.T) {
+			_, err := repositories.ParseFallbackModels(
+				[]datatypes.FallbackModelDTO{{
+					ModelParameters:        modelParameters,
+					ModelType:              models.ModelTypeCommand,
+					ModelVendor:            models.ModelVendorCOHERE,
+					ProviderPromptMessages: ptr.To(json.RawMessage(`[{"message": "Hello {user}!"}]`)),
+				}},
+				models.ModelVendorOPENAI,
+				[]string{"name"},
+			)
+
+			assert.ErrorContains(t, err, "invalid fallback model")
+		})
+	})
+
+	t.Run("ParseTools", func(t *testing.T) {
+		t.Run("returns nil for no tools", func(t *testing.T) {
+			serialized, err := repositories.ParseTools(nil)
+
+			assert.NoError(t, err)
+			assert.Nil(t, serialized)
+		})
+
+		t.Run("parses the tools", func(t *testing.T) {
+			serialized, err := repositories.ParseTools([]datatypes.ToolDTO{
+				{
+					Name:        "get_weather",
+					Description: ptr.To("Returns the weather of a city"),
+					Parameters:  ptr.To(json.RawMessage(`{"type": "object"}`)),
+				},
+				{Name: "get-time"},
+			})
+
+			assert.NoError(t, err)
+
+			tools, unmarshalErr := datatypes.UnmarshalTools(serialized)
+			assert.NoError(t, unmarshalErr)
+			assert.Len(t, tools, 2)
+			assert.Equal(t, "get_weather", tools[0].Name)
+			assert.Equal(t, "get-time", tools[1].Name)
+		})
+
+		for _, testCase := range []struct {
+			Name  string
+			Tools []datatypes.ToolDTO
+		}{
+			{Name: "returns error for a missing name", Tools: []datatypes.ToolDTO{{}}},
+			{Name: "returns error for an invalid name", Tools: []datatypes.ToolDTO{{Name: "get weather"}}},
+			{
+				Name:  "returns error for a duplicate name",
+				Tools: []datatypes.ToolDTO{{Name: "get_weather"}, {Name: "get_weather"}},
+			},
+			{
+				Name: "returns error for parameters that are not an object",
+				Tools: []datatypes.ToolDTO{{
+					Name:       "get_weather",
+					Parameters: ptr.To(json.RawMessage(`["city"]`)),
+				}},
+			},
+		} {
+			t.Run(testCase.Name, func(t *testing.T) {
+				_, err := repositories.ParseTools(testCase.Tools)
+
+				assert.ErrorContains(t, err, "invalid tool")
+			})
+		}
+	})
+
+	t.Run("ParseResponseSchema", func(t *testing.T) {
+		t.Run("returns nil without a response schema", func(t *testing.T) {
+			for _, responseSchema := range []json.RawMessage{nil, json.RawMessage("null")} {
+				serialized, err := repositories.ParseResponseSchema(responseSchema)
+
+				assert.NoError(t, err)
+				assert.Nil(t, serialized)
+			}
+		})
+
+		t.Run("returns the serialized response schema", func(t *testing.T) {
+			serialized, err := repositories.ParseResponseSchema(
+				json.RawMessage(`{"type": "object", "required": ["name"]}`),
+			)
+
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"type": "object", "required": ["name"]}`, string(serialized))
+		})
+
+		for _, testCase := range []struct {
+			Name           string
+			ResponseSchema json.RawMessage
+		}{
+			{Name: "returns error for a schema that is not an object", ResponseSchema: json.RawMessage(`["name"]`)},
+			{Name: "returns error for invalid JSON", ResponseSchema: json.RawMessage(`{"type": `)},
+			{Name: "returns error for an invalid schema", ResponseSchema: json.RawMessage(`{"type": 1}`)},
+		} {
+			t.Run(testCase.Name, func(t *testing.T) {
+				_, err := repositories.ParseResponseSchema(testCase.ResponseSchema)
+
+				assert.ErrorContains(t, err, "invalid response schema")
+			})
+		}
+	})
 }
@@ -8,15 +8,19 @@ import (
 	"github.com/basemind-ai/monorepo/services/dashboard-backend/internal/ptestingclient"
 	"github.com/basemind-ai/monorepo/shared/go/config"
 	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/exc"
 	"github.com/basemind-ai/monorepo/shared/go/logging"
+	"github.com/basemind-ai/monorepo/shared/go/metrics"
 	"github.com/basemind-ai/monorepo/shared/go/rediscache"
 	"github.com/basemind-ai/monorepo/shared/go/router"
+	"github.com/basemind-ai/monorepo/shared/go/tracing"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rs/zerolog/log"
 	"golang.org/x/sync/errgroup"
 )
@@ -38,6 +42,16 @@ func main() {
 
 	logging.Configure(cfg.Environment != "production")
 
+	shutdownTracing := exc.MustResult(tracing.Init(ctx, tracing.Options{
+		ServiceName: "dashboard-backend",
+		Exporter:    cfg.TracingExporter,
+		SampleRatio: cfg.TracingSampleRatio,
+	}))
+
+	defer func() {
+		exc.LogIfErr(shutdownTracing(context.Background()), "failed to shutdown tracing")
+	}()
+
 	rediscache.New(cfg.RedisURL)
 
 	conn, connErr := db.CreateConnection(ctx, cfg.DatabaseURL)
@@ -49,6 +63,8 @@ func main() {
 
 	defer conn.Close()
 
+	exc.Must(metrics.RegisterDBPool(conn, prometheus.DefaultRegisterer))
+
 	mux := router.New(router.Options{
 		Environment:      cfg.Environment,
 		ServiceName:      "dashboard-backend",
@@ -71,6 +87,10 @@ func main() {
 		return srv.ListenAndServe()
 	})
 
+	g.Go(func() error {
+		return metrics.Serve(gCtx, cfg.MetricsPort)
+	})
+
 	g.Go(func() error {
 		<-gCtx.Done()
 		return srv.Shutdown(ctx)
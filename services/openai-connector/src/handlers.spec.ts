@@ -6,6 +6,9 @@ import {
 	ServerWritableStream,
 } from '@grpc/grpc-js';
 import {
+	OpenAIEmbeddingModel,
+	OpenAIEmbeddingsRequest,
+	OpenAIEmbeddingsResponse,
 	OpenAIMessageRole,
 	OpenAIModel,
 	OpenAIPromptRequest,
@@ -17,7 +20,7 @@ import { createInternalGrpcError } from 'shared/grpc';
 import { Mock } from 'vitest';
 
 import { getOpenAIClient } from '@/client';
-import { openAIPrompt, openAIStream } from '@/handlers';
+import { openAIEmbeddings, openAIPrompt, openAIStream } from '@/handlers';
 
 describe('handlers tests', () => {
 	const openAPIKey = (process.env.OPEN_AI_API_KEY =
@@ -29,6 +32,7 @@ describe('handlers tests', () => {
 
 	const client = getOpenAIClient();
 	const completionsSpy = vi.spyOn(client.chat.completions, 'create');
+	const embeddingsSpy = vi.spyOn(client.embeddings, 'create');
 
 	describe('openAIPrompt', () => {
 		const makeMockUnaryCall = (
@@ -51,6 +55,7 @@ describe('handlers tests', () => {
 					{
 						content: 'test',
 						role: OpenAIMessageRole.OPEN_AI_MESSAGE_ROLE_USER,
+						toolCalls: [],
 					},
 				],
 				model: OpenAIModel.OPEN_AI_MODEL_GPT3_5_TURBO_4K,
@@ -61,6 +66,7 @@ describe('handlers tests', () => {
 					temperature: 0.8,
 					topP: 0.9,
 				},
+				tools: [],
 			});
 			const callback: sendUnaryData<OpenAIPromptResponse> = vi.fn();
 
@@ -110,6 +116,7 @@ describe('handlers tests', () => {
 				finishReason: StreamFinishReason.DONE,
 				requestTokensCount: 10,
 				responseTokensCount: 20,
+				toolCalls: [],
 			});
 		});
 
@@ -119,9 +126,11 @@ describe('handlers tests', () => {
 					{
 						content: 'test',
 						role: OpenAIMessageRole.OPEN_AI_MESSAGE_ROLE_USER,
+						toolCalls: [],
 					},
 				],
 				model: OpenAIModel.OPEN_AI_MODEL_GPT3_5_TURBO_4K,
+				tools: [],
 			});
 			const callback: sendUnaryData<OpenAIPromptResponse> = vi.fn();
 
@@ -155,6 +164,89 @@ describe('handlers tests', () => {
 				finishReason: StreamFinishReason.DONE,
 				requestTokensCount: 10,
 				responseTokensCount: 0,
+				toolCalls: [],
+			});
+		});
+
+		it('should return the tool calls requested by the model', async () => {
+			const call = makeMockUnaryCall({
+				messages: [
+					{
+						content: 'What is the weather in Paris?',
+						role: OpenAIMessageRole.OPEN_AI_MESSAGE_ROLE_USER,
+						toolCalls: [],
+					},
+				],
+				model: OpenAIModel.OPEN_AI_MODEL_GPT3_5_TURBO_4K,
+				tools: [
+					{
+						name: 'get_weather',
+						parameters: '{"type":"object"}',
+					},
+				],
+			});
+			const callback: sendUnaryData<OpenAIPromptResponse> = vi.fn();
+
+			completionsSpy.mockResolvedValueOnce({
+				choices: [
+					{
+						finish_reason: 'tool_calls',
+						index: 0,
+						logprobs: null,
+						message: {
+							content: null,
+							role: 'assistant',
+							tool_calls: [
+								{
+									function: {
+										arguments: '{"city":"Paris"}',
+										name: 'get_weather',
+									},
+									id: 'call-1',
+									type: 'function',
+								},
+							],
+						},
+					},
+				],
+				created: Date.now(),
+				id: 'abc',
+				model: 'gpt-3.5-turbo',
+				object: 'chat.completion',
+				usage: {
+					completion_tokens: 20,
+					prompt_tokens: 10,
+					total_tokens: 30,
+				},
+			});
+
+			await openAIPrompt(call, callback);
+			expect(completionsSpy).toHaveBeenCalledWith(
+				expect.objectContaining({
+					tools: [
+						{
+							function: {
+								name: 'get_weather',
+								parameters: { type: 'object' },
+							},
+							type: 'function',
+						},
+					],
+				}),
+			);
+
+			expect(callback).toHaveBeenCalledWith(null, {
+				content: '',
+				finishReason: StreamFinishReason.DONE,
+				requestTokensCount: 10,
+				responseTokensCount: 20,
+				toolCalls: [
+					{
+						arguments: '{"city":"Paris"}',
+						id: 'call-1',
+						name: 'get_weather',
+					},
+				],
 			});
 		});
 
@@ -164,9 +256,11 @@ describe('handlers tests', () => {
 					{
 						content: 'test',
 						role: OpenAIMessageRole.OPEN_AI_MESSAGE_ROLE_USER,
+						toolCalls: [],
 					},
 				],
 				model: OpenAIModel.OPEN_AI_MODEL_GPT3_5_TURBO_4K,
+				tools: [],
 			});
 			const callback: sendUnaryData<OpenAIPromptResponse> = vi.fn();
 
@@ -201,9 +295,11 @@ describe('handlers tests', () => {
 					{
 						content: 'test',
 						role: OpenAIMessageRole.OPEN_AI_MESSAGE_ROLE_USER,
+						toolCalls: [],
 					},
 				],
 				model: OpenAIModel.OPEN_AI_MODEL_GPT3_5_TURBO_4K,
+				tools: [],
 			});
 			const callback: sendUnaryData<OpenAIPromptResponse> = vi.fn();
 
@@ -247,6 +343,7 @@ describe('handlers tests', () => {
 				finishReason: StreamFinishReason.LIMIT,
 				requestTokensCount: 10,
 				responseTokensCount: 20,
+				toolCalls: [],
 			});
 		});
 	});
@@ -311,6 +408,7 @@ describe('handlers tests', () => {
 					{
 						content: 'test',
 						role: OpenAIMessageRole.OPEN_AI_MESSAGE_ROLE_USER,
+						toolCalls: [],
 					},
 				],
 				model: OpenAIModel.OPEN_AI_MODEL_GPT3_5_TURBO_4K,
@@ -321,6 +419,7 @@ describe('handlers tests', () => {
 					temperature: 0.8,
 					topP: 0.9,
 				},
+				tools: [],
 			});
 			completionsSpy.mockResolvedValueOnce(createReadableStream() as any);
 
@@ -383,7 +482,76 @@ describe('handlers tests', () => {
 						finishReason: 'DONE',
 						requestTokensCount: 1,
 						responseTokensCount: 4,
+						toolCalls: [],
+					},
+					expect.any(Function),
+				],
+			]);
+		});
+
+		it('should merge the streamed tool call fragments into the last message', async () => {
+			const call = makeServerWritableStream({
+				messages: [
+					{
+						content: 'What is the weather in Paris?',
+						role: OpenAIMessageRole.OPEN_AI_MESSAGE_ROLE_USER,
+						toolCalls: [],
 					},
+				],
+				model: OpenAIModel.OPEN_AI_MODEL_GPT3_5_TURBO_4K,
+				tools: [{ name: 'get_weather' }],
+			});
+			const deltas = [
+				{
+					tool_calls: [
+						{
+							function: { arguments: '', name: 'get_weather' },
+							id: 'call-1',
+							index: 0,
+						},
+					],
+				},
+				{
+					tool_calls: [
+						{ function: { arguments: '{"city":' }, index: 0 },
+					],
+				},
+				{
+					tool_calls: [
+						{ function: { arguments: '"Paris"}' }, index: 0 },
+					],
+				},
+			];
+			completionsSpy.mockResolvedValueOnce(
+				(async function* () {
+					for (const delta of deltas) {
+						yield {
+							choices: [{ delta, finish_reason: null, index: 0 }],
+						};
+					}
+					yield {
+						choices: [
+							{ delta: {}, finish_reason: 'tool_calls', index: 0 },
+						],
+					};
+				})() as any,
+			);
+
+			await openAIStream(call);
+
+			expect((call.write as Mock).mock.calls).toEqual([
+				[
+					expect.objectContaining({
+						content: '',
+						finishReason: 'DONE',
+						toolCalls: [
+							{
+								arguments: '{"city":"Paris"}',
+								id: 'call-1',
+								name: 'get_weather',
+							},
+						],
+					}),
 					expect.any(Function),
 				],
 			]);
@@ -396,6 +564,7 @@ describe('handlers tests', () => {
 					{
 						content: 'test',
 						role: OpenAIMessageRole.OPEN_AI_MESSAGE_ROLE_USER,
+						toolCalls: [],
 					},
 				],
 				model: OpenAIModel.OPEN_AI_MODEL_GPT3_5_TURBO_4K,
@@ -406,6 +575,7 @@ describe('handlers tests', () => {
 					temperature: 0.8,
 					topP: 0.9,
 				},
+				tools: [],
 			});
 			const error = new Error('test error');
 			completionsSpy.mockRejectedValueOnce(error);
@@ -420,4 +590,76 @@ describe('handlers tests', () => {
 			);
 		});
 	});
+
+	describe('openAIEmbeddings', () => {
+		const makeMockUnaryCall = (
+			request: OpenAIEmbeddingsRequest,
+		): ServerUnaryCall<
+			OpenAIEmbeddingsRequest,
+			OpenAIEmbeddingsResponse
+		> => {
+			return {
+				getPath: vi.fn(),
+				metadata: new Metadata(),
+				request,
+			} as unknown as ServerUnaryCall<
+				OpenAIEmbeddingsRequest,
+				OpenAIEmbeddingsResponse
+			>;
+		};
+
+		it('should successfully complete an OpenAI embeddings request and send the embeddings in the order of the inputs', async () => {
+			const call = makeMockUnaryCall({
+				applicationId: '123',
+				dimensions: 2,
+				inputs: ['The meaning of life', 'is 42'],
+				model: OpenAIEmbeddingModel.OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_3_SMALL,
+			});
+			const callback: sendUnaryData<OpenAIEmbeddingsResponse> = vi.fn();
+
+			embeddingsSpy.mockResolvedValueOnce({
+				data: [
+					{ embedding: [0.3, 0.4], index: 1, object: 'embedding' },
+					{ embedding: [0.1, 0.2], index: 0, object: 'embedding' },
+				],
+				model: 'text-embedding-3-small',
+				object: 'list',
+				usage: { prompt_tokens: 8, total_tokens: 8 },
+			});
+
+			await openAIEmbeddings(call, callback);
+
+			expect(embeddingsSpy).toHaveBeenCalledWith({
+				dimensions: 2,
+				input: ['The meaning of life', 'is 42'],
+				model: 'text-embedding-3-small',
+				user: '123',
+			});
+			expect(callback).toHaveBeenCalledWith(null, {
+				embeddings: [{ values: [0.1, 0.2] }, { values: [0.3, 0.4] }],
+				requestTokensCount: 8,
+			});
+		});
+
+		it('should send a GrpcError when an error occurs during the OpenAI embeddings request', async () => {
+			const call = makeMockUnaryCall({
+				inputs: ['test'],
+				model: OpenAIEmbeddingModel.OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_ADA_002,
+			});
+			const callback: sendUnaryData<OpenAIEmbeddingsResponse> = vi.fn();
+
+			embeddingsSpy.mockRejectedValueOnce(new Error('OpenAI error'));
+
+			await openAIEmbeddings(call, callback);
+
+			expect(callback).toHaveBeenCalledWith(
+				createInternalGrpcError(
+					new Error(
+						'Error: an error has occurred communicating with OpenAI',
+					),
+				),
+				null,
+			);
+		});
+	});
 });
@@ -5,9 +5,12 @@ import {
 	ServerWritableStream,
 } from '@grpc/grpc-js';
 import {
+	OpenAIEmbeddingsRequest,
+	OpenAIEmbeddingsResponse,
 	OpenAIPromptRequest,
 	OpenAIPromptResponse,
 	OpenAIStreamResponse,
+	OpenAIToolCall,
 } from 'gen/openai/v1/openai';
 import {
 	createInternalGrpcError,
@@ -17,11 +20,13 @@ import logger from 'shared/logger';
 
 import { createOrDefaultClient } from '@/client';
 import {
+	createOpenAIEmbeddingsRequest,
 	createOpenAIRequest,
 	getFinishReason,
 	getOpenAIModel,
 	getTokenCount,
 	OpenAIFinishReason,
+	parseOpenAIToolCalls,
 } from '@/utils';
 
 /**
@@ -75,6 +80,7 @@ export async function openAIPrompt(
 			finishReason: getFinishReason(choices[0]?.finish_reason),
 			requestTokensCount,
 			responseTokensCount,
+			toolCalls: parseOpenAIToolCalls(choices[0]?.message.tool_calls),
 		} satisfies OpenAIPromptResponse);
 	} catch (error: unknown) {
 		callback(createInternalGrpcError(error as Error), null);
@@ -110,6 +116,7 @@ export async function openAIStream(
 
 		let responseContent = '';
 		let finishReason: OpenAIFinishReason | undefined;
+		const toolCalls: OpenAIToolCall[] = [];
 
 		for await (const message of stream) {
 			const choice = message.choices[0];
@@ -119,6 +126,15 @@ export async function openAIStream(
 			responseContent += content;
 			finishReason = choice?.finish_reason ?? undefined;
 
+			// tool calls are streamed in fragments, which are merged by their index
+			for (const delta of choice?.delta?.tool_calls ?? []) {
+				toolCalls[delta.index] ??= { arguments: '', id: '', name: '' };
+				const toolCall = toolCalls[delta.index];
+				toolCall.id = delta.id ?? toolCall.id;
+				toolCall.name += delta.function?.name ?? '';
+				toolCall.arguments += delta.function?.arguments ?? '';
+			}
+
 			if (content) {
 				call.write({ content } satisfies OpenAIStreamResponse);
 			}
@@ -136,6 +152,7 @@ export async function openAIStream(
 					responseContent,
 					getOpenAIModel(call.request.model),
 				),
+				toolCalls,
 			},
 			() => {
 				const finishTime = Date.now();
@@ -153,3 +170,50 @@ export async function openAIStream(
 
 	call.end();
 }
+
+/**
+ * The openAIEmbeddings function is a gRPC handler function, requesting the embedding vectors of a batch of inputs.
+ *
+ * @param call ServerUnaryCall object, including the request and metadata
+ * @param callback sendUnaryData handler, allowing sending a response back to the client.
+ */
+export async function openAIEmbeddings(
+	call: ServerUnaryCall<OpenAIEmbeddingsRequest, OpenAIEmbeddingsResponse>,
+	callback: sendUnaryData<OpenAIEmbeddingsResponse>,
+) {
+	logger.debug(
+		{ inputs: call.request.inputs.length, path: call.getPath() },
+		'received OpenAI embeddings request',
+	);
+
+	const client = createOrDefaultClient(
+		extractProviderAPIKeyFromMetadata(call),
+	);
+
+	try {
+		logger.debug('making OpenAI embeddings request');
+		const startTime = Date.now();
+		const { data, usage } = await client.embeddings.create(
+			createOpenAIEmbeddingsRequest(call.request),
+		);
+		const finishTime = Date.now();
+
+		logger.debug(
+			{ finishTime, startTime },
+			'OpenAI embeddings request completed',
+		);
+
+		// the embeddings are ordered by the index of their input
+		const embeddings = [...data]
+			.sort((a, b) => a.index - b.index)
+			.map(({ embedding }) => ({ values: embedding }));
+
+		callback(null, {
+			embeddings,
+			requestTokensCount: usage.prompt_tokens,
+		} satisfies OpenAIEmbeddingsResponse);
+	} catch (error: unknown) {
+		callback(createInternalGrpcError(error as Error), null);
+		logger.error(error as Error, 'error communicating with OpenAI');
+	}
+}
@@ -4,9 +4,11 @@ import {
 } from 'gen/openai/v1/openai.grpc-server';
 import { createServer } from 'shared/grpc';
 
-import { openAIPrompt, openAIStream } from '@/handlers';
+import { openAIEmbeddings, openAIPrompt, openAIStream } from '@/handlers';
 
 const implementation = {
+	// eslint-disable-next-line @typescript-eslint/no-misused-promises
+	openAIEmbeddings,
 	// eslint-disable-next-line @typescript-eslint/no-misused-promises
 	openAIPrompt,
 	// eslint-disable-next-line @typescript-eslint/no-misused-promises
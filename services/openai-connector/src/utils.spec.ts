@@ -1,11 +1,15 @@
 import {
+	OpenAIEmbeddingModel,
 	OpenAIMessageRole,
 	OpenAIModel,
 	OpenAIPromptRequest,
 } from 'gen/openai/v1/openai';
 
 import {
+	createOpenAIEmbeddingsRequest,
 	createOpenAIRequest,
+	createOpenAITools,
+	getOpenAIEmbeddingModel,
 	getOpenAIMessageRole,
 	getOpenAIModel,
 } from '@/utils';
@@ -30,6 +34,42 @@ describe('utils tests', () => {
 			);
 		});
 	});
+	describe('getOpenAIEmbeddingModel', () => {
+		it('should return the correct embedding model', () => {
+			expect(
+				getOpenAIEmbeddingModel(
+					OpenAIEmbeddingModel.OPEN_AI_EMBEDDING_MODEL_UNSPECIFIED,
+				),
+			).toBe('text-embedding-3-small');
+			expect(
+				getOpenAIEmbeddingModel(
+					OpenAIEmbeddingModel.OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_3_LARGE,
+				),
+			).toBe('text-embedding-3-large');
+			expect(
+				getOpenAIEmbeddingModel(
+					OpenAIEmbeddingModel.OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_ADA_002,
+				),
+			).toBe('text-embedding-ada-002');
+		});
+	});
+	describe('createOpenAIEmbeddingsRequest', () => {
+		it('should map the embeddings request', () => {
+			expect(
+				createOpenAIEmbeddingsRequest({
+					applicationId: '123',
+					dimensions: 256,
+					inputs: ['test'],
+					model: OpenAIEmbeddingModel.OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_3_SMALL,
+				}),
+			).toEqual({
+				dimensions: 256,
+				input: ['test'],
+				model: 'text-embedding-3-small',
+				user: '123',
+			});
+		});
+	});
 	describe('getOpenAIMessageRole', () => {
 		it('should return the correct message role', () => {
 			expect(
@@ -57,6 +97,9 @@ describe('utils tests', () => {
 					OpenAIMessageRole.OPEN_AI_MESSAGE_ROLE_FUNCTION,
 				),
 			).toBe('function');
+			expect(
+				getOpenAIMessageRole(OpenAIMessageRole.OPEN_AI_MESSAGE_ROLE_TOOL),
+			).toBe('tool');
 		});
 	});
 	describe('createOpenAIRequest', () => {
@@ -66,9 +109,11 @@ describe('utils tests', () => {
 					{
 						content: 'test',
 						role: OpenAIMessageRole.OPEN_AI_MESSAGE_ROLE_USER,
+						toolCalls: [],
 					},
 				],
 				model: OpenAIModel.OPEN_AI_MODEL_GPT3_5_TURBO_4K,
+				tools: [],
 			};
 			const result = createOpenAIRequest(request, true);
 			expect(result.stream).toBeTruthy();
@@ -80,9 +125,11 @@ describe('utils tests', () => {
 					{
 						content: 'test',
 						role: OpenAIMessageRole.OPEN_AI_MESSAGE_ROLE_USER,
+						toolCalls: [],
 					},
 				],
 				model: OpenAIModel.OPEN_AI_MODEL_GPT3_5_TURBO_4K,
+				tools: [],
 			};
 			const result = createOpenAIRequest(request, true);
 			expect(result.model).toBe('gpt-3.5-turbo');
@@ -96,6 +143,7 @@ describe('utils tests', () => {
 					{
 						content: 'test',
 						role: OpenAIMessageRole.OPEN_AI_MESSAGE_ROLE_USER,
+						toolCalls: [],
 					},
 				],
 				model: OpenAIModel.OPEN_AI_MODEL_GPT3_5_TURBO_4K,
@@ -106,6 +154,7 @@ describe('utils tests', () => {
 					temperature: 0.8,
 					topP: 0.9,
 				},
+				tools: [],
 			};
 			const result = createOpenAIRequest(request, true);
 			expect(result.temperature).toBe(request.parameters?.temperature);
@@ -120,15 +169,34 @@ describe('utils tests', () => {
 			);
 		});
 
+		it('should request a JSON object response in JSON mode', () => {
+			const request: OpenAIPromptRequest = {
+				jsonMode: true,
+				messages: [
+					{
+						content: 'respond in JSON',
+						role: OpenAIMessageRole.OPEN_AI_MESSAGE_ROLE_USER,
+						toolCalls: [],
+					},
+				],
+				model: OpenAIModel.OPEN_AI_MODEL_GPT3_5_TURBO_4K,
+				tools: [],
+			};
+			const result = createOpenAIRequest(request, false);
+			expect(result.response_format).toEqual({ type: 'json_object' });
+		});
+
 		it('should handle default values for optional fields in the OpenAIPromptRequest object', () => {
 			const request: OpenAIPromptRequest = {
 				messages: [
 					{
 						content: 'test',
 						role: OpenAIMessageRole.OPEN_AI_MESSAGE_ROLE_USER,
+						toolCalls: [],
 					},
 				],
 				model: OpenAIModel.OPEN_AI_MODEL_GPT3_5_TURBO_4K,
+				tools: [],
 			};
 			const result = createOpenAIRequest(request, true);
 			expect(result.temperature).toBeUndefined();
@@ -137,6 +205,7 @@ describe('utils tests', () => {
 			expect(result.user).toBeUndefined();
 			expect(result.presence_penalty).toBeUndefined();
 			expect(result.frequency_penalty).toBeUndefined();
+			expect(result.response_format).toBeUndefined();
 		});
 
 		it('should handle empty names and whitespace names by changing them to undefined', () => {
@@ -146,19 +215,23 @@ describe('utils tests', () => {
 						content: 'test',
 						name: '',
 						role: OpenAIMessageRole.OPEN_AI_MESSAGE_ROLE_USER,
+						toolCalls: [],
 					},
 					{
 						content: 'test',
 						name: ' ',
 						role: OpenAIMessageRole.OPEN_AI_MESSAGE_ROLE_USER,
+						toolCalls: [],
 					},
 					{
 						content: 'test',
 						name: 'a',
 						role: OpenAIMessageRole.OPEN_AI_MESSAGE_ROLE_USER,
+						toolCalls: [],
 					},
 				],
 				model: OpenAIModel.OPEN_AI_MODEL_GPT3_5_TURBO_4K,
+				tools: [],
 			};
 
 			const result = createOpenAIRequest(request, true);
@@ -173,17 +246,21 @@ describe('utils tests', () => {
 					{
 						content: '',
 						role: OpenAIMessageRole.OPEN_AI_MESSAGE_ROLE_USER,
+						toolCalls: [],
 					},
 					{
 						content: ' ',
 						role: OpenAIMessageRole.OPEN_AI_MESSAGE_ROLE_USER,
+						toolCalls: [],
 					},
 					{
 						content: 'test',
 						role: OpenAIMessageRole.OPEN_AI_MESSAGE_ROLE_USER,
+						toolCalls: [],
 					},
 				],
 				model: OpenAIModel.OPEN_AI_MODEL_GPT3_5_TURBO_4K,
+				tools: [],
 			};
 
 			const result = createOpenAIRequest(request, true);
@@ -191,5 +268,73 @@ describe('utils tests', () => {
 			expect(Reflect.get(result.messages[1], 'content')).toBeNull();
 			expect(Reflect.get(result.messages[2], 'content')).toBe('test');
 		});
+
+		it('should map the tools and the tool calls of the messages', () => {
+			const request: OpenAIPromptRequest = {
+				messages: [
+					{
+						role: OpenAIMessageRole.OPEN_AI_MESSAGE_ROLE_ASSISTANT,
+						toolCalls: [
+							{
+								arguments: '{"city":"Paris"}',
+								id: 'call-1',
+								name: 'get_weather',
+							},
+						],
+					},
+					{
+						content: '{"temperature":20}',
+						role: OpenAIMessageRole.OPEN_AI_MESSAGE_ROLE_TOOL,
+						toolCallId: 'call-1',
+						toolCalls: [],
+					},
+				],
+				model: OpenAIModel.OPEN_AI_MODEL_GPT3_5_TURBO_4K,
+				tools: [
+					{
+						description: 'Returns the weather of a city',
+						name: 'get_weather',
+						parameters: '{"type":"object"}',
+					},
+				],
+			};
+
+			const result = createOpenAIRequest(request, false);
+			expect(result.tools).toEqual([
+				{
+					function: {
+						description: 'Returns the weather of a city',
+						name: 'get_weather',
+						parameters: { type: 'object' },
+					},
+					type: 'function',
+				},
+			]);
+			expect(result.messages).toEqual([
+				{
+					content: null,
+					role: 'assistant',
+					tool_calls: [
+						{
+							function: {
+								arguments: '{"city":"Paris"}',
+								name: 'get_weather',
+							},
+							id: 'call-1',
+							type: 'function',
+						},
+					],
+				},
+				{
+					content: '{"temperature":20}',
+					role: 'tool',
+					tool_call_id: 'call-1',
+				},
+			]);
+		});
+
+		it('should not set the tools if there are none', () => {
+			expect(createOpenAITools([])).toBeUndefined();
+		});
 	});
 });
@@ -1,13 +1,20 @@
 import {
+	OpenAIEmbeddingModel,
+	OpenAIEmbeddingsRequest,
 	OpenAIMessageRole,
 	OpenAIModel,
 	OpenAIPromptRequest,
+	OpenAITool,
+	OpenAIToolCall,
 } from 'gen/openai/v1/openai';
 import {
 	ChatCompletionCreateParamsNonStreaming,
 	ChatCompletionCreateParamsStreaming,
 	ChatCompletionMessageParam,
+	ChatCompletionMessageToolCall,
+	ChatCompletionTool,
 } from 'openai/src/resources/chat/completions';
+import { EmbeddingCreateParams } from 'openai/src/resources/embeddings';
 import { StreamFinishReason } from 'shared/constants';
 import { encoding_for_model as encodingForModel } from 'tiktoken';
 
@@ -25,6 +32,23 @@ const modelMap: Record<OpenAIModel, OpenAIModels> = {
 	[OpenAIModel.OPEN_AI_MODEL_GPT4_32K]: 'gpt-4-32k',
 };
 
+type OpenAIEmbeddingModels =
+	| 'text-embedding-3-small'
+	| 'text-embedding-3-large'
+	| 'text-embedding-ada-002';
+
+const embeddingModelMap: Record<OpenAIEmbeddingModel, OpenAIEmbeddingModels> =
+	{
+		[OpenAIEmbeddingModel.OPEN_AI_EMBEDDING_MODEL_UNSPECIFIED]:
+			'text-embedding-3-small',
+		[OpenAIEmbeddingModel.OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_3_SMALL]:
+			'text-embedding-3-small',
+		[OpenAIEmbeddingModel.OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_3_LARGE]:
+			'text-embedding-3-large',
+		[OpenAIEmbeddingModel.OPEN_AI_EMBEDDING_MODEL_TEXT_EMBEDDING_ADA_002]:
+			'text-embedding-ada-002',
+	};
+
 const messageRoleMap: Record<
 	OpenAIMessageRole,
 	ChatCompletionMessageParam['role']
@@ -34,6 +58,7 @@ const messageRoleMap: Record<
 	[OpenAIMessageRole.OPEN_AI_MESSAGE_ROLE_SYSTEM]: 'system',
 	[OpenAIMessageRole.OPEN_AI_MESSAGE_ROLE_ASSISTANT]: 'assistant',
 	[OpenAIMessageRole.OPEN_AI_MESSAGE_ROLE_FUNCTION]: 'function',
+	[OpenAIMessageRole.OPEN_AI_MESSAGE_ROLE_TOOL]: 'tool',
 };
 
 export type OpenAIFinishReason =
@@ -71,12 +96,60 @@ export function getOpenAIModel(requestModel: OpenAIModel): OpenAIModels {
 	return modelMap[requestModel];
 }
 
+/**
+ * The getOpenAIEmbeddingModel function returns the OpenAI API identifier of an embedding model.
+ *
+ * @param requestModel a member of the OpenAIEmbeddingModel enum
+ * @return A string identifier of the OpenAI embedding model to use
+ */
+export function getOpenAIEmbeddingModel(
+	requestModel: OpenAIEmbeddingModel,
+): OpenAIEmbeddingModels {
+	return embeddingModelMap[requestModel];
+}
+
 export function getOpenAIMessageRole(
 	requestRole: OpenAIMessageRole,
 ): ChatCompletionMessageParam['role'] {
 	return messageRoleMap[requestRole];
 }
 
+/*
+ * Map the tools of the prompt request to OpenAI function tools.
+ * */
+export function createOpenAITools(
+	tools: OpenAITool[],
+): ChatCompletionTool[] | undefined {
+	if (!tools.length) {
+		return undefined;
+	}
+	return tools.map(({ name, description, parameters }) => ({
+		function: {
+			description,
+			name,
+			parameters: parameters
+				? (JSON.parse(parameters) as Record<string, unknown>)
+				: undefined,
+		},
+		type: 'function',
+	}));
+}
+
+/*
+ * Map OpenAI tool calls to the tool calls of the connector responses.
+ * */
+export function parseOpenAIToolCalls(
+	toolCalls?: ChatCompletionMessageToolCall[],
+): OpenAIToolCall[] {
+	return (toolCalls ?? []).map(
+		({ id, function: { name, arguments: args } }) => ({
+			arguments: args,
+			id,
+			name,
+		}),
+	);
+}
+
 export function createOpenAIRequest(
 	request: OpenAIPromptRequest,
 	stream: true,
@@ -112,28 +185,61 @@ export function createOpenAIRequest(
 		messages,
 		model,
 		applicationId,
+		tools,
+		jsonMode,
 	} = request;
 	return {
 		frequency_penalty: frequencyPenalty,
 		max_tokens: !!maxTokens && maxTokens > 0 ? maxTokens : undefined,
 		messages: messages.map<ChatCompletionMessageParam>((msg) => {
-			const { content, role, name, ...rest } = msg;
+			const { content, role, name, toolCallId, toolCalls, ...rest } =
+				msg;
 			return {
 				content: content?.trim() ? content.trim() : null,
 				name: name?.trim() ? name.trim() : undefined,
 				role: getOpenAIMessageRole(role),
+				tool_call_id: toolCallId,
+				tool_calls: toolCalls.length
+					? toolCalls.map(({ id, name, arguments: args }) => ({
+							function: { arguments: args, name },
+							id,
+							type: 'function',
+						}))
+					: undefined,
 				...rest,
 			} as ChatCompletionMessageParam;
 		}),
 		model: getOpenAIModel(model),
 		presence_penalty: presencePenalty,
+		response_format: jsonMode ? { type: 'json_object' } : undefined,
 		stream,
 		temperature,
+		tools: createOpenAITools(tools),
 		top_p: topP,
 		user: applicationId,
 	};
 }
 
+/**
+ * The createOpenAIEmbeddingsRequest function takes in an embeddings request and returns an OpenAI API request body.
+ *
+ * @param request OpenAIEmbeddingsRequest the embeddings request of the gateway
+ * @return An EmbeddingCreateParams request object
+ */
+export function createOpenAIEmbeddingsRequest({
+	model,
+	inputs,
+	dimensions,
+	applicationId,
+}: OpenAIEmbeddingsRequest): EmbeddingCreateParams {
+	return {
+		dimensions,
+		input: inputs,
+		model: getOpenAIEmbeddingModel(model),
+		user: applicationId,
+	};
+}
+
 /*
  * Helper function to count the OpenAI tokens in a given string.
  * */
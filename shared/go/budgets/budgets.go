@@ -0,0 +1,73 @@
+package budgets
+
+import (
+	"context"
+	"fmt"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+// AlertThresholds are the percentages of a spend budget at which an alert is raised, once per period.
+var AlertThresholds = []int32{50, 80, 100}
+
+// CreateCacheKey returns the cache key of the spend budget status of an application.
+func CreateCacheKey(applicationID pgtype.UUID) string {
+	return fmt.Sprintf("%s:spend-budgets", db.UUIDToString(&applicationID))
+}
+
+// PeriodStart returns the start of the budget period containing the given time.
+// Periods are UTC calendar days and months.
+func PeriodStart(period models.SpendBudgetPeriod, t time.Time) time.Time {
+	t = t.UTC()
+
+	if period == models.SpendBudgetPeriodDAILY {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// RetrieveSpend returns the spend in the scope of the budget - its application, or its whole project
+// for a project budget - since the given period start.
+func RetrieveSpend(
+	ctx context.Context,
+	budget models.SpendBudget,
+	periodStart time.Time,
+) (decimal.Decimal, error) {
+	spend, retrievalErr := db.GetQueries().RetrieveSpendSince(ctx, models.RetrieveSpendSinceParams{
+		ProjectID:     budget.ProjectID,
+		ApplicationID: budget.ApplicationID,
+		FromDate:      pgtype.Timestamptz{Time: periodStart, Valid: true},
+	})
+	if retrievalErr != nil {
+		return decimal.Zero, fmt.Errorf("failed to retrieve spend - %w", retrievalErr)
+	}
+
+	decimalSpend, conversionErr := db.NumericToDecimal(spend)
+	if conversionErr != nil {
+		return decimal.Zero, conversionErr
+	}
+
+	return *decimalSpend, nil
+}
+
+// ReachedThresholds returns the alert thresholds reached by the spend of a budget with the given limit.
+func ReachedThresholds(spend, limit decimal.Decimal) []int32 {
+	var reached []int32
+
+	for _, threshold := range AlertThresholds {
+		if spend.GreaterThanOrEqual(limit.Mul(decimal.NewFromInt32(threshold)).Div(decimal.NewFromInt(100))) {
+			reached = append(reached, threshold)
+		}
+	}
+
+	return reached
+}
+
+// IsExceeded returns true if the spend has reached the limit of the budget.
+func IsExceeded(spend, limit decimal.Decimal) bool {
+	return spend.GreaterThanOrEqual(limit)
+}
@@ -0,0 +1,87 @@
+package budgets_test
+
+import (
+	"github.com/basemind-ai/monorepo/shared/go/budgets"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestBudgets(t *testing.T) {
+	t.Run("CreateCacheKey", func(t *testing.T) {
+		t.Run("returns the spend budgets key of the application", func(t *testing.T) {
+			applicationID := pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+			assert.Equal(
+				t,
+				"01000000-0000-0000-0000-000000000000:spend-budgets",
+				budgets.CreateCacheKey(applicationID),
+			)
+		})
+	})
+
+	t.Run("PeriodStart", func(t *testing.T) {
+		now := time.Date(2024, 3, 15, 13, 30, 0, 0, time.FixedZone("UTC+3", 3*60*60))
+
+		t.Run("returns the start of the UTC day for daily budgets", func(t *testing.T) {
+			assert.Equal(
+				t,
+				time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+				budgets.PeriodStart(models.SpendBudgetPeriodDAILY, now),
+			)
+		})
+
+		t.Run("returns the start of the UTC month for monthly budgets", func(t *testing.T) {
+			assert.Equal(
+				t,
+				time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+				budgets.PeriodStart(models.SpendBudgetPeriodMONTHLY, now),
+			)
+		})
+
+		t.Run("uses the UTC date of the given time", func(t *testing.T) {
+			earlyMorning := time.Date(2024, 3, 1, 1, 0, 0, 0, time.FixedZone("UTC+3", 3*60*60))
+			assert.Equal(
+				t,
+				time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+				budgets.PeriodStart(models.SpendBudgetPeriodDAILY, earlyMorning),
+			)
+			assert.Equal(
+				t,
+				time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+				budgets.PeriodStart(models.SpendBudgetPeriodMONTHLY, earlyMorning),
+			)
+		})
+	})
+
+	t.Run("ReachedThresholds", func(t *testing.T) {
+		limit := decimal.NewFromInt(10)
+
+		for _, testCase := range []struct {
+			Name     string
+			Spend    decimal.Decimal
+			Expected []int32
+		}{
+			{Name: "no spend", Spend: decimal.Zero, Expected: nil},
+			{Name: "below 50%", Spend: decimal.RequireFromString("4.99"), Expected: nil},
+			{Name: "at 50%", Spend: decimal.NewFromInt(5), Expected: []int32{50}},
+			{Name: "at 80%", Spend: decimal.NewFromInt(8), Expected: []int32{50, 80}},
+			{Name: "at 100%", Spend: decimal.NewFromInt(10), Expected: []int32{50, 80, 100}},
+			{Name: "over 100%", Spend: decimal.NewFromInt(12), Expected: []int32{50, 80, 100}},
+		} {
+			t.Run(testCase.Name, func(t *testing.T) {
+				assert.Equal(t, testCase.Expected, budgets.ReachedThresholds(testCase.Spend, limit))
+			})
+		}
+	})
+
+	t.Run("IsExceeded", func(t *testing.T) {
+		limit := decimal.NewFromInt(10)
+
+		assert.False(t, budgets.IsExceeded(decimal.RequireFromString("9.99"), limit))
+		assert.True(t, budgets.IsExceeded(decimal.NewFromInt(10), limit))
+		assert.True(t, budgets.IsExceeded(decimal.NewFromInt(11), limit))
+	})
+}
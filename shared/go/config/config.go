@@ -11,16 +11,25 @@ import (
 //
 //goland:noinspection GoUnnecessarilyExportedIdentifiers
 type Config struct {
-	DatabaseURL      string `env:"DATABASE_URL,required"`
-	Environment      string `env:"ENVIRONMENT,default=test"`
-	FrontendBaseURL  string `env:"FRONTEND_BASE_URL,required"`
-	GcpProjectID     string `env:"GCP_PROJECT_ID,required"`
-	JWTSecret        string `env:"JWT_SECRET,required"`
-	RedisURL         string `env:"REDIS_CONNECTION_STRING,required"`
-	ServerHost       string `env:"SERVER_HOST,required"`
-	ServerPort       int    `env:"SERVER_PORT,required"`
-	URLSigningSecret string `env:"URL_SIGNING_SECRET,required"`
-	CryptoPassKey    string `env:"CRYPTO_PASS_KEY,required"`
+	BatchJobWorkers            int      `env:"BATCH_JOB_WORKERS,default=5"`
+	BudgetAlertEmailTemplateID string   `env:"BUDGET_ALERT_EMAIL_TEMPLATE_ID"`
+	CORSAllowedOrigins         []string `env:"CORS_ALLOWED_ORIGINS,default=*"`
+	CreditHoldsEnabled         bool     `env:"CREDIT_HOLDS_ENABLED,default=true"`
+	DatabaseURL                string   `env:"DATABASE_URL,required"`
+	Environment                string   `env:"ENVIRONMENT,default=test"`
+	FrontendBaseURL            string   `env:"FRONTEND_BASE_URL,required"`
+	GcpProjectID               string   `env:"GCP_PROJECT_ID,required"`
+	JWTSecret                  string   `env:"JWT_SECRET,required"`
+	MetricsPort                int      `env:"METRICS_PORT,default=9090"`
+	OpenAIAPIPort              int      `env:"OPENAI_API_PORT,default=4002"`
+	PlatformAdminEmails        []string `env:"PLATFORM_ADMIN_EMAILS"`
+	RedisURL                   string   `env:"REDIS_CONNECTION_STRING,required"`
+	ServerHost                 string   `env:"SERVER_HOST,required"`
+	ServerPort                 int      `env:"SERVER_PORT,required"`
+	TracingExporter            string   `env:"TRACING_EXPORTER,default=none"`
+	TracingSampleRatio         float64  `env:"TRACING_SAMPLE_RATIO,default=1"`
+	URLSigningSecret           string   `env:"URL_SIGNING_SECRET,required"`
+	CryptoPassKey              string   `env:"CRYPTO_PASS_KEY,required"`
 }
 
 var (
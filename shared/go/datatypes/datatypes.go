@@ -2,6 +2,7 @@ package datatypes
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/basemind-ai/monorepo/shared/go/db/models"
 	"github.com/shopspring/decimal"
 	"time"
@@ -43,25 +44,181 @@ type CohereModelParametersDTO struct { // skipcq: TCV-001
 	MaxTokens        *int32   `json:"maxTokens,omitempty"`
 }
 
+// FallbackModelDTO - DTO for serializing and storing a prompt config fallback model.
+// ProviderPromptMessages are required when the fallback vendor differs from the prompt config vendor,
+// otherwise the prompt config messages are used.
+type FallbackModelDTO struct { // skipcq: TCV-001
+	ModelParameters        *json.RawMessage   `json:"modelParameters"          validate:"required"`
+	ModelType              models.ModelType   `json:"modelType"                validate:"required"`
+	ModelVendor            models.ModelVendor `json:"modelVendor"              validate:"oneof=OPEN_AI COHERE"`
+	ProviderPromptMessages *json.RawMessage   `json:"promptMessages,omitempty"`
+}
+
+// UnmarshalFallbackModels - deserializes the fallback models stored on a prompt config.
+// Returns nil if there are no fallback models.
+func UnmarshalFallbackModels(data []byte) ([]FallbackModelDTO, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var fallbackModels []FallbackModelDTO
+	if unmarshalErr := json.Unmarshal(data, &fallbackModels); unmarshalErr != nil {
+		return nil, fmt.Errorf("failed to unmarshal fallback models - %w", unmarshalErr)
+	}
+
+	if len(fallbackModels) == 0 {
+		return nil, nil
+	}
+
+	return fallbackModels, nil
+}
+
+// ToolDTO - DTO for serializing and storing a prompt config tool, which the model may request to call.
+// Parameters is the JSON schema of the tool arguments.
+type ToolDTO struct { // skipcq: TCV-001
+	Name        string           `json:"name"                  validate:"required,max=64"`
+	Description *string          `json:"description,omitempty"`
+	Parameters  *json.RawMessage `json:"parameters,omitempty"`
+}
+
+// UnmarshalTools - deserializes the tools stored on a prompt config.
+// Returns nil if there are no tools.
+func UnmarshalTools(data []byte) ([]ToolDTO, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var tools []ToolDTO
+	if unmarshalErr := json.Unmarshal(data, &tools); unmarshalErr != nil {
+		return nil, fmt.Errorf("failed to unmarshal tools - %w", unmarshalErr)
+	}
+
+	if len(tools) == 0 {
+		return nil, nil
+	}
+
+	return tools, nil
+}
+
+// UnmarshalResponseSchema - returns the JSON schema stored on a prompt config for its responses.
+// Returns nil if there is no response schema.
+func UnmarshalResponseSchema(data []byte) (*json.RawMessage, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("failed to unmarshal response schema - invalid JSON")
+	}
+
+	responseSchema := json.RawMessage(data)
+
+	return &responseSchema, nil
+}
+
+// PayloadRedactionRuleDTO - DTO for serializing and storing an application payload redaction rule.
+// A rule matches either a preset kind of personal data, or a regular expression pattern.
+// Matches are replaced with the replacement, which defaults to "[REDACTED]".
+type PayloadRedactionRuleDTO struct { // skipcq: TCV-001
+	Name        string  `json:"name"                  validate:"required,max=64"`
+	Preset      *string `json:"preset,omitempty"      validate:"omitempty,oneof=EMAIL PHONE_NUMBER CREDIT_CARD IP_ADDRESS"`
+	Pattern     *string `json:"pattern,omitempty"`
+	Replacement *string `json:"replacement,omitempty" validate:"omitempty,max=64"`
+}
+
+// UnmarshalPayloadRedactionRules - deserializes the payload redaction rules stored on an application.
+// Returns nil if there are no redaction rules.
+func UnmarshalPayloadRedactionRules(data []byte) ([]PayloadRedactionRuleDTO, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var redactionRules []PayloadRedactionRuleDTO
+	if unmarshalErr := json.Unmarshal(data, &redactionRules); unmarshalErr != nil {
+		return nil, fmt.Errorf("failed to unmarshal payload redaction rules - %w", unmarshalErr)
+	}
+
+	if len(redactionRules) == 0 {
+		return nil, nil
+	}
+
+	return redactionRules, nil
+}
+
 // PromptConfigDTO - DTO for serializing a prompt config.
 type PromptConfigDTO struct { // skipcq: TCV-001
 	ID                        string             `json:"id"`
-	Name                      string             `json:"name"                      validate:"required"`
-	ModelParameters           *json.RawMessage   `json:"modelParameters"           validate:"required"`
-	ModelType                 models.ModelType   `json:"modelType"                 validate:"required"`
-	ModelVendor               models.ModelVendor `json:"modelVendor"               validate:"oneof=OPEN_AI COHERE"`
-	ProviderPromptMessages    *json.RawMessage   `json:"providerPromptMessages"    validate:"required"`
+	Name                      string             `json:"name"                               validate:"required"`
+	ModelParameters           *json.RawMessage   `json:"modelParameters"                    validate:"required"`
+	ModelType                 models.ModelType   `json:"modelType"                          validate:"required"`
+	ModelVendor               models.ModelVendor `json:"modelVendor"                        validate:"oneof=OPEN_AI COHERE"`
+	ProviderPromptMessages    *json.RawMessage   `json:"providerPromptMessages"             validate:"required"`
 	ExpectedTemplateVariables []string           `json:"expectedTemplateVariables"`
+	FallbackModels            []FallbackModelDTO `json:"fallbackModels,omitempty"           validate:"omitempty,dive"`
+	ResponseCacheTTLSeconds   int32              `json:"responseCacheTtlSeconds,omitempty"`
+	Tools                     []ToolDTO          `json:"tools,omitempty"                    validate:"omitempty,dive"`
+	ResponseSchema            *json.RawMessage   `json:"responseSchema,omitempty"`
+	ResponseSchemaMaxRetries  int32              `json:"responseSchemaMaxRetries,omitempty"`
+	Version                   int32              `json:"version,omitempty"`
+	VersionID                 string             `json:"versionId,omitempty"`
+	TrafficWeight             int32              `json:"trafficWeight,omitempty"`
 	IsDefault                 bool               `json:"isDefault,omitempty"`
 	CreatedAt                 time.Time          `json:"createdAt,omitempty"`
 	UpdatedAt                 time.Time          `json:"updatedAt,omitempty"`
 }
 
+// TokenClass is a class of tokens that a provider bills at its own price.
+// Note: the classes are disjoint, e.g. the input tokens do not include the cached input tokens.
+type TokenClass string
+
+const (
+	TokenClassInput       TokenClass = "input"
+	TokenClassOutput      TokenClass = "output"
+	TokenClassCachedInput TokenClass = "cachedInput"
+	TokenClassReasoning   TokenClass = "reasoning"
+	TokenClassImage       TokenClass = "image"
+)
+
+// IsResponse returns true if the tokens of the class are generated by the model, rather than sent to it.
+func (c TokenClass) IsResponse() bool {
+	return c == TokenClassOutput || c == TokenClassReasoning
+}
+
 // ProviderModelPricingDTO is a data type used to encapsulate the pricing information for a model / type.
+// The prices of the cached input, reasoning and image tokens are nil if the model does not bill them separately.
 type ProviderModelPricingDTO struct { // skipcq: TCV-001
-	ID               string          `json:"id"`
-	InputTokenPrice  decimal.Decimal `json:"inputTokenPrice"`
-	OutputTokenPrice decimal.Decimal `json:"outputTokenPrice"`
-	TokenUnitSize    int32           `json:"tokenUnitSize"`
-	ActiveFromDate   time.Time       `json:"activeFromDate"`
+	ID                    string           `json:"id"`
+	InputTokenPrice       decimal.Decimal  `json:"inputTokenPrice"`
+	OutputTokenPrice      decimal.Decimal  `json:"outputTokenPrice"`
+	CachedInputTokenPrice *decimal.Decimal `json:"cachedInputTokenPrice,omitempty"`
+	ReasoningTokenPrice   *decimal.Decimal `json:"reasoningTokenPrice,omitempty"`
+	ImageTokenPrice       *decimal.Decimal `json:"imageTokenPrice,omitempty"`
+	TokenUnitSize         int32            `json:"tokenUnitSize"`
+	ActiveFromDate        time.Time        `json:"activeFromDate"`
+}
+
+// TokenPrice returns the price of TokenUnitSize tokens of the given class.
+// A class without its own price is billed as the tokens it is a kind of -
+// the cached input and image tokens at the input price, and the reasoning tokens at the output price.
+func (p ProviderModelPricingDTO) TokenPrice(class TokenClass) decimal.Decimal {
+	var classPrice *decimal.Decimal
+
+	switch class {
+	case TokenClassCachedInput:
+		classPrice = p.CachedInputTokenPrice
+	case TokenClassReasoning:
+		classPrice = p.ReasoningTokenPrice
+	case TokenClassImage:
+		classPrice = p.ImageTokenPrice
+	}
+
+	if classPrice != nil {
+		return *classPrice
+	}
+
+	if class.IsResponse() {
+		return p.OutputTokenPrice
+	}
+
+	return p.InputTokenPrice
 }
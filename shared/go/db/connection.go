@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/tracing"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"sync"
 	"time"
@@ -22,6 +23,7 @@ var (
 
 // CreateConnection - creates a connection to the database.
 // This function is idempotent and it has a retry mechanism, ensuring a connection is established.
+// Queries are traced with the global tracer provider.
 func CreateConnection(ctx context.Context, dbURL string) (*pgxpool.Pool, error) {
 	var err error
 
@@ -31,7 +33,13 @@ func CreateConnection(ctx context.Context, dbURL string) (*pgxpool.Pool, error)
 		exponentialBackoff.MaxElapsedTime = 20 * time.Second
 
 		if connErr := backoff.Retry(func() error { // skipcq: TCV-001
-			conn, pgxErr := pgxpool.New(ctx, dbURL)
+			poolConfig, parseErr := pgxpool.ParseConfig(dbURL)
+			if parseErr != nil {
+				return backoff.Permanent(parseErr)
+			}
+			poolConfig.ConnConfig.Tracer = tracing.QueryTracer{}
+
+			conn, pgxErr := pgxpool.NewWithConfig(ctx, poolConfig)
 			if pgxErr != nil {
 				return pgxErr
 			}
@@ -13,25 +13,41 @@ import (
 
 const createAPIKey = `-- name: CreateAPIKey :one
 
-INSERT INTO api_key (application_id, name, is_internal)
-VALUES ($1, $2, $3)
-RETURNING id, name, is_internal, created_at, deleted_at, application_id
+INSERT INTO api_key (
+    application_id,
+    name,
+    is_internal,
+    rate_limit_requests_per_minute,
+    rate_limit_tokens_per_minute
+)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, name, is_internal, rate_limit_requests_per_minute, rate_limit_tokens_per_minute, created_at, deleted_at, application_id
 `
 
 type CreateAPIKeyParams struct {
-	ApplicationID pgtype.UUID `json:"applicationId"`
-	Name          string      `json:"name"`
-	IsInternal    bool        `json:"isInternal"`
+	ApplicationID              pgtype.UUID `json:"applicationId"`
+	Name                       string      `json:"name"`
+	IsInternal                 bool        `json:"isInternal"`
+	RateLimitRequestsPerMinute int32       `json:"rateLimitRequestsPerMinute"`
+	RateLimitTokensPerMinute   int32       `json:"rateLimitTokensPerMinute"`
 }
 
 // -- api-key
 func (q *Queries) CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (ApiKey, error) {
-	row := q.db.QueryRow(ctx, createAPIKey, arg.ApplicationID, arg.Name, arg.IsInternal)
+	row := q.db.QueryRow(ctx, createAPIKey,
+		arg.ApplicationID,
+		arg.Name,
+		arg.IsInternal,
+		arg.RateLimitRequestsPerMinute,
+		arg.RateLimitTokensPerMinute,
+	)
 	var i ApiKey
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
 		&i.IsInternal,
+		&i.RateLimitRequestsPerMinute,
+		&i.RateLimitTokensPerMinute,
 		&i.CreatedAt,
 		&i.DeletedAt,
 		&i.ApplicationID,
@@ -54,6 +70,8 @@ const retrieveAPIKeys = `-- name: RetrieveAPIKeys :many
 SELECT
     t.id,
     t.name,
+    t.rate_limit_requests_per_minute,
+    t.rate_limit_tokens_per_minute,
     t.created_at
 FROM api_key AS t
 LEFT JOIN application AS app ON t.application_id = app.id
@@ -64,9 +82,11 @@ ORDER BY t.created_at
 `
 
 type RetrieveAPIKeysRow struct {
-	ID        pgtype.UUID        `json:"id"`
-	Name      string             `json:"name"`
-	CreatedAt pgtype.Timestamptz `json:"createdAt"`
+	ID                         pgtype.UUID        `json:"id"`
+	Name                       string             `json:"name"`
+	RateLimitRequestsPerMinute int32              `json:"rateLimitRequestsPerMinute"`
+	RateLimitTokensPerMinute   int32              `json:"rateLimitTokensPerMinute"`
+	CreatedAt                  pgtype.Timestamptz `json:"createdAt"`
 }
 
 func (q *Queries) RetrieveAPIKeys(ctx context.Context, id pgtype.UUID) ([]RetrieveAPIKeysRow, error) {
@@ -78,7 +98,13 @@ func (q *Queries) RetrieveAPIKeys(ctx context.Context, id pgtype.UUID) ([]Retrie
 	var items []RetrieveAPIKeysRow
 	for rows.Next() {
 		var i RetrieveAPIKeysRow
-		if err := rows.Scan(&i.ID, &i.Name, &i.CreatedAt); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.RateLimitRequestsPerMinute,
+			&i.RateLimitTokensPerMinute,
+			&i.CreatedAt,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -92,7 +118,12 @@ func (q *Queries) RetrieveAPIKeys(ctx context.Context, id pgtype.UUID) ([]Retrie
 const retrieveApplicationDataForAPIKey = `-- name: RetrieveApplicationDataForAPIKey :one
 SELECT
     app.id AS application_id,
-    app.project_id AS project_id
+    app.project_id,
+    t.is_internal,
+    t.rate_limit_requests_per_minute AS api_key_requests_per_minute,
+    t.rate_limit_tokens_per_minute AS api_key_tokens_per_minute,
+    app.rate_limit_requests_per_minute AS application_requests_per_minute,
+    app.rate_limit_tokens_per_minute AS application_tokens_per_minute
 FROM api_key AS t
 LEFT JOIN application AS app ON t.application_id = app.id
 WHERE
@@ -102,14 +133,27 @@ WHERE
 `
 
 type RetrieveApplicationDataForAPIKeyRow struct {
-	ApplicationID pgtype.UUID `json:"applicationId"`
-	ProjectID     pgtype.UUID `json:"projectId"`
+	ApplicationID                pgtype.UUID `json:"applicationId"`
+	ProjectID                    pgtype.UUID `json:"projectId"`
+	IsInternal                   bool        `json:"isInternal"`
+	ApiKeyRequestsPerMinute      int32       `json:"apiKeyRequestsPerMinute"`
+	ApiKeyTokensPerMinute        int32       `json:"apiKeyTokensPerMinute"`
+	ApplicationRequestsPerMinute pgtype.Int4 `json:"applicationRequestsPerMinute"`
+	ApplicationTokensPerMinute   pgtype.Int4 `json:"applicationTokensPerMinute"`
 }
 
 func (q *Queries) RetrieveApplicationDataForAPIKey(ctx context.Context, id pgtype.UUID) (RetrieveApplicationDataForAPIKeyRow, error) {
 	row := q.db.QueryRow(ctx, retrieveApplicationDataForAPIKey, id)
 	var i RetrieveApplicationDataForAPIKeyRow
-	err := row.Scan(&i.ApplicationID, &i.ProjectID)
+	err := row.Scan(
+		&i.ApplicationID,
+		&i.ProjectID,
+		&i.IsInternal,
+		&i.ApiKeyRequestsPerMinute,
+		&i.ApiKeyTokensPerMinute,
+		&i.ApplicationRequestsPerMinute,
+		&i.ApplicationTokensPerMinute,
+	)
 	return i, err
 }
 
@@ -129,3 +173,43 @@ func (q *Queries) RetrieveApplicationInternalAPIKeyID(ctx context.Context, id pg
 	err := row.Scan(&id)
 	return id, err
 }
+
+const updateAPIKeyRateLimits = `-- name: UpdateAPIKeyRateLimits :one
+UPDATE api_key
+SET
+    rate_limit_requests_per_minute = $3,
+    rate_limit_tokens_per_minute = $4
+WHERE
+    id = $1
+    AND application_id = $2
+    AND deleted_at IS NULL
+RETURNING id, name, is_internal, rate_limit_requests_per_minute, rate_limit_tokens_per_minute, created_at, deleted_at, application_id
+`
+
+type UpdateAPIKeyRateLimitsParams struct {
+	ID                         pgtype.UUID `json:"id"`
+	ApplicationID              pgtype.UUID `json:"applicationId"`
+	RateLimitRequestsPerMinute int32       `json:"rateLimitRequestsPerMinute"`
+	RateLimitTokensPerMinute   int32       `json:"rateLimitTokensPerMinute"`
+}
+
+func (q *Queries) UpdateAPIKeyRateLimits(ctx context.Context, arg UpdateAPIKeyRateLimitsParams) (ApiKey, error) {
+	row := q.db.QueryRow(ctx, updateAPIKeyRateLimits,
+		arg.ID,
+		arg.ApplicationID,
+		arg.RateLimitRequestsPerMinute,
+		arg.RateLimitTokensPerMinute,
+	)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.IsInternal,
+		&i.RateLimitRequestsPerMinute,
+		&i.RateLimitTokensPerMinute,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.ApplicationID,
+	)
+	return i, err
+}
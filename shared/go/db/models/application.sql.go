@@ -16,26 +16,41 @@ const createApplication = `-- name: CreateApplication :one
 INSERT INTO application (
     project_id,
     name,
-    description
+    description,
+    rate_limit_requests_per_minute,
+    rate_limit_tokens_per_minute
 )
-VALUES ($1, $2, $3)
-RETURNING id, description, name, created_at, updated_at, deleted_at, project_id
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, description, name, rate_limit_requests_per_minute, rate_limit_tokens_per_minute, payload_logging_enabled, payload_log_retention_days, payload_redaction_rules, created_at, updated_at, deleted_at, project_id
 `
 
 type CreateApplicationParams struct {
-	ProjectID   pgtype.UUID `json:"projectId"`
-	Name        string      `json:"name"`
-	Description string      `json:"description"`
+	ProjectID                  pgtype.UUID `json:"projectId"`
+	Name                       string      `json:"name"`
+	Description                string      `json:"description"`
+	RateLimitRequestsPerMinute int32       `json:"rateLimitRequestsPerMinute"`
+	RateLimitTokensPerMinute   int32       `json:"rateLimitTokensPerMinute"`
 }
 
 // -- application
 func (q *Queries) CreateApplication(ctx context.Context, arg CreateApplicationParams) (Application, error) {
-	row := q.db.QueryRow(ctx, createApplication, arg.ProjectID, arg.Name, arg.Description)
+	row := q.db.QueryRow(ctx, createApplication,
+		arg.ProjectID,
+		arg.Name,
+		arg.Description,
+		arg.RateLimitRequestsPerMinute,
+		arg.RateLimitTokensPerMinute,
+	)
 	var i Application
 	err := row.Scan(
 		&i.ID,
 		&i.Description,
 		&i.Name,
+		&i.RateLimitRequestsPerMinute,
+		&i.RateLimitTokensPerMinute,
+		&i.PayloadLoggingEnabled,
+		&i.PayloadLogRetentionDays,
+		&i.PayloadRedactionRules,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
@@ -60,6 +75,11 @@ SELECT
     id,
     description,
     name,
+    rate_limit_requests_per_minute,
+    rate_limit_tokens_per_minute,
+    payload_logging_enabled,
+    payload_log_retention_days,
+    payload_redaction_rules,
     created_at,
     updated_at,
     project_id
@@ -70,12 +90,17 @@ WHERE
 `
 
 type RetrieveApplicationRow struct {
-	ID          pgtype.UUID        `json:"id"`
-	Description string             `json:"description"`
-	Name        string             `json:"name"`
-	CreatedAt   pgtype.Timestamptz `json:"createdAt"`
-	UpdatedAt   pgtype.Timestamptz `json:"updatedAt"`
-	ProjectID   pgtype.UUID        `json:"projectId"`
+	ID                         pgtype.UUID        `json:"id"`
+	Description                string             `json:"description"`
+	Name                       string             `json:"name"`
+	RateLimitRequestsPerMinute int32              `json:"rateLimitRequestsPerMinute"`
+	RateLimitTokensPerMinute   int32              `json:"rateLimitTokensPerMinute"`
+	PayloadLoggingEnabled      bool               `json:"payloadLoggingEnabled"`
+	PayloadLogRetentionDays    int32              `json:"payloadLogRetentionDays"`
+	PayloadRedactionRules      []byte             `json:"payloadRedactionRules"`
+	CreatedAt                  pgtype.Timestamptz `json:"createdAt"`
+	UpdatedAt                  pgtype.Timestamptz `json:"updatedAt"`
+	ProjectID                  pgtype.UUID        `json:"projectId"`
 }
 
 func (q *Queries) RetrieveApplication(ctx context.Context, id pgtype.UUID) (RetrieveApplicationRow, error) {
@@ -85,6 +110,11 @@ func (q *Queries) RetrieveApplication(ctx context.Context, id pgtype.UUID) (Retr
 		&i.ID,
 		&i.Description,
 		&i.Name,
+		&i.RateLimitRequestsPerMinute,
+		&i.RateLimitTokensPerMinute,
+		&i.PayloadLoggingEnabled,
+		&i.PayloadLogRetentionDays,
+		&i.PayloadRedactionRules,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.ProjectID,
@@ -94,9 +124,9 @@ func (q *Queries) RetrieveApplication(ctx context.Context, id pgtype.UUID) (Retr
 
 const retrieveApplicationAPIRequestCount = `-- name: RetrieveApplicationAPIRequestCount :one
 SELECT COUNT(prr.id) AS total_requests
-FROM application AS a
-INNER JOIN prompt_config AS pc ON a.id = pc.application_id
-INNER JOIN prompt_request_record AS prr ON pc.id = prr.prompt_config_id
+FROM prompt_request_record AS prr
+LEFT JOIN prompt_config AS pc ON prr.prompt_config_id = pc.id
+INNER JOIN application AS a ON COALESCE(prr.application_id, pc.application_id) = a.id
 WHERE
     a.id = $1
     AND prr.created_at BETWEEN $2 AND $3
@@ -117,9 +147,9 @@ func (q *Queries) RetrieveApplicationAPIRequestCount(ctx context.Context, arg Re
 
 const retrieveApplicationTokensTotalCost = `-- name: RetrieveApplicationTokensTotalCost :one
 SELECT COALESCE(SUM(prr.request_tokens_cost + prr.response_tokens_cost), 0)
-FROM application AS app
-LEFT JOIN prompt_config AS pc ON app.id = pc.application_id
-LEFT JOIN prompt_request_record AS prr ON pc.id = prr.prompt_config_id
+FROM prompt_request_record AS prr
+LEFT JOIN prompt_config AS pc ON prr.prompt_config_id = pc.id
+INNER JOIN application AS app ON COALESCE(prr.application_id, pc.application_id) = app.id
 WHERE
     app.id = $1
     AND prr.created_at BETWEEN $2 AND $3
@@ -138,11 +168,76 @@ func (q *Queries) RetrieveApplicationTokensTotalCost(ctx context.Context, arg Re
 	return coalesce, err
 }
 
+const retrieveApplicationTrafficSplitAnalytics = `-- name: RetrieveApplicationTrafficSplitAnalytics :many
+SELECT
+    pc.id AS prompt_config_id,
+    pc.name,
+    pc.traffic_weight,
+    COUNT(prr.id) AS total_requests,
+    COUNT(prr.id) FILTER (WHERE prr.finish_reason = 'ERROR') AS total_errors,
+    COALESCE(SUM(prr.request_tokens_cost + prr.response_tokens_cost), 0)::numeric AS tokens_cost,
+    COALESCE(AVG(prr.duration_ms), 0)::float8 AS average_duration_ms
+FROM prompt_config AS pc
+INNER JOIN prompt_request_record AS prr ON pc.id = prr.prompt_config_id
+WHERE
+    pc.application_id = $1
+    AND prr.is_traffic_split = TRUE
+    AND prr.created_at BETWEEN $2 AND $3
+GROUP BY pc.id
+ORDER BY pc.created_at, pc.id
+`
+
+type RetrieveApplicationTrafficSplitAnalyticsParams struct {
+	ApplicationID pgtype.UUID        `json:"applicationId"`
+	CreatedAt     pgtype.Timestamptz `json:"createdAt"`
+	CreatedAt_2   pgtype.Timestamptz `json:"createdAt2"`
+}
+
+type RetrieveApplicationTrafficSplitAnalyticsRow struct {
+	PromptConfigID    pgtype.UUID    `json:"promptConfigId"`
+	Name              string         `json:"name"`
+	TrafficWeight     int32          `json:"trafficWeight"`
+	TotalRequests     int64          `json:"totalRequests"`
+	TotalErrors       int64          `json:"totalErrors"`
+	TokensCost        pgtype.Numeric `json:"tokensCost"`
+	AverageDurationMs float64        `json:"averageDurationMs"`
+}
+
+func (q *Queries) RetrieveApplicationTrafficSplitAnalytics(ctx context.Context, arg RetrieveApplicationTrafficSplitAnalyticsParams) ([]RetrieveApplicationTrafficSplitAnalyticsRow, error) {
+	rows, err := q.db.Query(ctx, retrieveApplicationTrafficSplitAnalytics, arg.ApplicationID, arg.CreatedAt, arg.CreatedAt_2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RetrieveApplicationTrafficSplitAnalyticsRow
+	for rows.Next() {
+		var i RetrieveApplicationTrafficSplitAnalyticsRow
+		if err := rows.Scan(
+			&i.PromptConfigID,
+			&i.Name,
+			&i.TrafficWeight,
+			&i.TotalRequests,
+			&i.TotalErrors,
+			&i.TokensCost,
+			&i.AverageDurationMs,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const retrieveApplications = `-- name: RetrieveApplications :many
 SELECT
     id,
     description,
     name,
+    rate_limit_requests_per_minute,
+    rate_limit_tokens_per_minute,
     created_at,
     updated_at,
     project_id
@@ -153,12 +248,14 @@ WHERE
 `
 
 type RetrieveApplicationsRow struct {
-	ID          pgtype.UUID        `json:"id"`
-	Description string             `json:"description"`
-	Name        string             `json:"name"`
-	CreatedAt   pgtype.Timestamptz `json:"createdAt"`
-	UpdatedAt   pgtype.Timestamptz `json:"updatedAt"`
-	ProjectID   pgtype.UUID        `json:"projectId"`
+	ID                         pgtype.UUID        `json:"id"`
+	Description                string             `json:"description"`
+	Name                       string             `json:"name"`
+	RateLimitRequestsPerMinute int32              `json:"rateLimitRequestsPerMinute"`
+	RateLimitTokensPerMinute   int32              `json:"rateLimitTokensPerMinute"`
+	CreatedAt                  pgtype.Timestamptz `json:"createdAt"`
+	UpdatedAt                  pgtype.Timestamptz `json:"updatedAt"`
+	ProjectID                  pgtype.UUID        `json:"projectId"`
 }
 
 func (q *Queries) RetrieveApplications(ctx context.Context, projectID pgtype.UUID) ([]RetrieveApplicationsRow, error) {
@@ -174,6 +271,8 @@ func (q *Queries) RetrieveApplications(ctx context.Context, projectID pgtype.UUI
 			&i.ID,
 			&i.Description,
 			&i.Name,
+			&i.RateLimitRequestsPerMinute,
+			&i.RateLimitTokensPerMinute,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.ProjectID,
@@ -193,26 +292,86 @@ UPDATE application
 SET
     name = $2,
     description = $3,
+    rate_limit_requests_per_minute = $4,
+    rate_limit_tokens_per_minute = $5,
     updated_at = NOW()
 WHERE
     id = $1
     AND deleted_at IS NULL
-RETURNING id, description, name, created_at, updated_at, deleted_at, project_id
+RETURNING id, description, name, rate_limit_requests_per_minute, rate_limit_tokens_per_minute, payload_logging_enabled, payload_log_retention_days, payload_redaction_rules, created_at, updated_at, deleted_at, project_id
 `
 
 type UpdateApplicationParams struct {
-	ID          pgtype.UUID `json:"id"`
-	Name        string      `json:"name"`
-	Description string      `json:"description"`
+	ID                         pgtype.UUID `json:"id"`
+	Name                       string      `json:"name"`
+	Description                string      `json:"description"`
+	RateLimitRequestsPerMinute int32       `json:"rateLimitRequestsPerMinute"`
+	RateLimitTokensPerMinute   int32       `json:"rateLimitTokensPerMinute"`
 }
 
 func (q *Queries) UpdateApplication(ctx context.Context, arg UpdateApplicationParams) (Application, error) {
-	row := q.db.QueryRow(ctx, updateApplication, arg.ID, arg.Name, arg.Description)
+	row := q.db.QueryRow(ctx, updateApplication,
+		arg.ID,
+		arg.Name,
+		arg.Description,
+		arg.RateLimitRequestsPerMinute,
+		arg.RateLimitTokensPerMinute,
+	)
+	var i Application
+	err := row.Scan(
+		&i.ID,
+		&i.Description,
+		&i.Name,
+		&i.RateLimitRequestsPerMinute,
+		&i.RateLimitTokensPerMinute,
+		&i.PayloadLoggingEnabled,
+		&i.PayloadLogRetentionDays,
+		&i.PayloadRedactionRules,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.ProjectID,
+	)
+	return i, err
+}
+
+const updateApplicationPayloadLogging = `-- name: UpdateApplicationPayloadLogging :one
+UPDATE application
+SET
+    payload_logging_enabled = $2,
+    payload_log_retention_days = $3,
+    payload_redaction_rules = $4,
+    updated_at = NOW()
+WHERE
+    id = $1
+    AND deleted_at IS NULL
+RETURNING id, description, name, rate_limit_requests_per_minute, rate_limit_tokens_per_minute, payload_logging_enabled, payload_log_retention_days, payload_redaction_rules, created_at, updated_at, deleted_at, project_id
+`
+
+type UpdateApplicationPayloadLoggingParams struct {
+	ID                      pgtype.UUID `json:"id"`
+	PayloadLoggingEnabled   bool        `json:"payloadLoggingEnabled"`
+	PayloadLogRetentionDays int32       `json:"payloadLogRetentionDays"`
+	PayloadRedactionRules   []byte      `json:"payloadRedactionRules"`
+}
+
+func (q *Queries) UpdateApplicationPayloadLogging(ctx context.Context, arg UpdateApplicationPayloadLoggingParams) (Application, error) {
+	row := q.db.QueryRow(ctx, updateApplicationPayloadLogging,
+		arg.ID,
+		arg.PayloadLoggingEnabled,
+		arg.PayloadLogRetentionDays,
+		arg.PayloadRedactionRules,
+	)
 	var i Application
 	err := row.Scan(
 		&i.ID,
 		&i.Description,
 		&i.Name,
+		&i.RateLimitRequestsPerMinute,
+		&i.RateLimitTokensPerMinute,
+		&i.PayloadLoggingEnabled,
+		&i.PayloadLogRetentionDays,
+		&i.PayloadRedactionRules,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
@@ -53,17 +53,197 @@ func (ns NullAccessPermissionType) Value() (driver.Value, error) {
 	return string(ns.AccessPermissionType), nil
 }
 
+type BatchJobItemStatus string
+
+const (
+	BatchJobItemStatusPENDING   BatchJobItemStatus = "PENDING"
+	BatchJobItemStatusRUNNING   BatchJobItemStatus = "RUNNING"
+	BatchJobItemStatusCOMPLETED BatchJobItemStatus = "COMPLETED"
+	BatchJobItemStatusFAILED    BatchJobItemStatus = "FAILED"
+)
+
+func (e *BatchJobItemStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = BatchJobItemStatus(s)
+	case string:
+		*e = BatchJobItemStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for BatchJobItemStatus: %T", src)
+	}
+	return nil
+}
+
+type NullBatchJobItemStatus struct {
+	BatchJobItemStatus BatchJobItemStatus `json:"batchJobItemStatus"`
+	Valid              bool               `json:"valid"` // Valid is true if BatchJobItemStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullBatchJobItemStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.BatchJobItemStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.BatchJobItemStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullBatchJobItemStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.BatchJobItemStatus), nil
+}
+
+type BatchJobStatus string
+
+const (
+	BatchJobStatusPENDING   BatchJobStatus = "PENDING"
+	BatchJobStatusRUNNING   BatchJobStatus = "RUNNING"
+	BatchJobStatusCOMPLETED BatchJobStatus = "COMPLETED"
+	BatchJobStatusFAILED    BatchJobStatus = "FAILED"
+)
+
+func (e *BatchJobStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = BatchJobStatus(s)
+	case string:
+		*e = BatchJobStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for BatchJobStatus: %T", src)
+	}
+	return nil
+}
+
+type NullBatchJobStatus struct {
+	BatchJobStatus BatchJobStatus `json:"batchJobStatus"`
+	Valid          bool           `json:"valid"` // Valid is true if BatchJobStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullBatchJobStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.BatchJobStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.BatchJobStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullBatchJobStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.BatchJobStatus), nil
+}
+
+type ConversationMessageRole string
+
+const (
+	ConversationMessageRoleSystem    ConversationMessageRole = "system"
+	ConversationMessageRoleUser      ConversationMessageRole = "user"
+	ConversationMessageRoleAssistant ConversationMessageRole = "assistant"
+	ConversationMessageRoleTool      ConversationMessageRole = "tool"
+)
+
+func (e *ConversationMessageRole) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ConversationMessageRole(s)
+	case string:
+		*e = ConversationMessageRole(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ConversationMessageRole: %T", src)
+	}
+	return nil
+}
+
+type NullConversationMessageRole struct {
+	ConversationMessageRole ConversationMessageRole `json:"conversationMessageRole"`
+	Valid                   bool                    `json:"valid"` // Valid is true if ConversationMessageRole is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullConversationMessageRole) Scan(value interface{}) error {
+	if value == nil {
+		ns.ConversationMessageRole, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ConversationMessageRole.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullConversationMessageRole) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ConversationMessageRole), nil
+}
+
+type CreditLedgerEntryType string
+
+const (
+	CreditLedgerEntryTypeDEBIT      CreditLedgerEntryType = "DEBIT"
+	CreditLedgerEntryTypeADJUSTMENT CreditLedgerEntryType = "ADJUSTMENT"
+	CreditLedgerEntryTypeGRANT      CreditLedgerEntryType = "GRANT"
+)
+
+func (e *CreditLedgerEntryType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = CreditLedgerEntryType(s)
+	case string:
+		*e = CreditLedgerEntryType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for CreditLedgerEntryType: %T", src)
+	}
+	return nil
+}
+
+type NullCreditLedgerEntryType struct {
+	CreditLedgerEntryType CreditLedgerEntryType `json:"creditLedgerEntryType"`
+	Valid                 bool                  `json:"valid"` // Valid is true if CreditLedgerEntryType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullCreditLedgerEntryType) Scan(value interface{}) error {
+	if value == nil {
+		ns.CreditLedgerEntryType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.CreditLedgerEntryType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullCreditLedgerEntryType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.CreditLedgerEntryType), nil
+}
+
 type ModelType string
 
 const (
-	ModelTypeGpt35Turbo          ModelType = "gpt-3.5-turbo"
-	ModelTypeGpt35Turbo16k       ModelType = "gpt-3.5-turbo-16k"
-	ModelTypeGpt4                ModelType = "gpt-4"
-	ModelTypeGpt432k             ModelType = "gpt-4-32k"
-	ModelTypeCommand             ModelType = "command"
-	ModelTypeCommandLight        ModelType = "command-light"
-	ModelTypeCommandNightly      ModelType = "command-nightly"
-	ModelTypeCommandLightNightly ModelType = "command-light-nightly"
+	ModelTypeGpt35Turbo           ModelType = "gpt-3.5-turbo"
+	ModelTypeGpt35Turbo16k        ModelType = "gpt-3.5-turbo-16k"
+	ModelTypeGpt4                 ModelType = "gpt-4"
+	ModelTypeGpt432k              ModelType = "gpt-4-32k"
+	ModelTypeCommand              ModelType = "command"
+	ModelTypeCommandLight         ModelType = "command-light"
+	ModelTypeCommandNightly       ModelType = "command-nightly"
+	ModelTypeCommandLightNightly  ModelType = "command-light-nightly"
+	ModelTypeTextEmbedding3Small  ModelType = "text-embedding-3-small"
+	ModelTypeTextEmbedding3Large  ModelType = "text-embedding-3-large"
+	ModelTypeTextEmbeddingAda002  ModelType = "text-embedding-ada-002"
+	ModelTypeEmbedEnglishV30      ModelType = "embed-english-v3.0"
+	ModelTypeEmbedMultilingualV30 ModelType = "embed-multilingual-v3.0"
 )
 
 func (e *ModelType) Scan(src interface{}) error {
@@ -146,9 +326,10 @@ func (ns NullModelVendor) Value() (driver.Value, error) {
 type PromptFinishReason string
 
 const (
-	PromptFinishReasonDONE  PromptFinishReason = "DONE"
-	PromptFinishReasonERROR PromptFinishReason = "ERROR"
-	PromptFinishReasonLIMIT PromptFinishReason = "LIMIT"
+	PromptFinishReasonDONE      PromptFinishReason = "DONE"
+	PromptFinishReasonERROR     PromptFinishReason = "ERROR"
+	PromptFinishReasonLIMIT     PromptFinishReason = "LIMIT"
+	PromptFinishReasonCANCELLED PromptFinishReason = "CANCELLED"
 )
 
 func (e *PromptFinishReason) Scan(src interface{}) error {
@@ -186,23 +367,177 @@ func (ns NullPromptFinishReason) Value() (driver.Value, error) {
 	return string(ns.PromptFinishReason), nil
 }
 
+type SpendBudgetEnforcement string
+
+const (
+	SpendBudgetEnforcementHARD SpendBudgetEnforcement = "HARD"
+	SpendBudgetEnforcementSOFT SpendBudgetEnforcement = "SOFT"
+)
+
+func (e *SpendBudgetEnforcement) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = SpendBudgetEnforcement(s)
+	case string:
+		*e = SpendBudgetEnforcement(s)
+	default:
+		return fmt.Errorf("unsupported scan type for SpendBudgetEnforcement: %T", src)
+	}
+	return nil
+}
+
+type NullSpendBudgetEnforcement struct {
+	SpendBudgetEnforcement SpendBudgetEnforcement `json:"spendBudgetEnforcement"`
+	Valid                  bool                   `json:"valid"` // Valid is true if SpendBudgetEnforcement is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullSpendBudgetEnforcement) Scan(value interface{}) error {
+	if value == nil {
+		ns.SpendBudgetEnforcement, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.SpendBudgetEnforcement.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullSpendBudgetEnforcement) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.SpendBudgetEnforcement), nil
+}
+
+type SpendBudgetPeriod string
+
+const (
+	SpendBudgetPeriodDAILY   SpendBudgetPeriod = "DAILY"
+	SpendBudgetPeriodMONTHLY SpendBudgetPeriod = "MONTHLY"
+)
+
+func (e *SpendBudgetPeriod) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = SpendBudgetPeriod(s)
+	case string:
+		*e = SpendBudgetPeriod(s)
+	default:
+		return fmt.Errorf("unsupported scan type for SpendBudgetPeriod: %T", src)
+	}
+	return nil
+}
+
+type NullSpendBudgetPeriod struct {
+	SpendBudgetPeriod SpendBudgetPeriod `json:"spendBudgetPeriod"`
+	Valid             bool              `json:"valid"` // Valid is true if SpendBudgetPeriod is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullSpendBudgetPeriod) Scan(value interface{}) error {
+	if value == nil {
+		ns.SpendBudgetPeriod, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.SpendBudgetPeriod.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullSpendBudgetPeriod) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.SpendBudgetPeriod), nil
+}
+
 type ApiKey struct {
-	ID            pgtype.UUID        `json:"id"`
-	Name          string             `json:"name"`
-	IsInternal    bool               `json:"isInternal"`
-	CreatedAt     pgtype.Timestamptz `json:"createdAt"`
-	DeletedAt     pgtype.Timestamptz `json:"deletedAt"`
-	ApplicationID pgtype.UUID        `json:"applicationId"`
+	ID                         pgtype.UUID        `json:"id"`
+	Name                       string             `json:"name"`
+	IsInternal                 bool               `json:"isInternal"`
+	RateLimitRequestsPerMinute int32              `json:"rateLimitRequestsPerMinute"`
+	RateLimitTokensPerMinute   int32              `json:"rateLimitTokensPerMinute"`
+	CreatedAt                  pgtype.Timestamptz `json:"createdAt"`
+	DeletedAt                  pgtype.Timestamptz `json:"deletedAt"`
+	ApplicationID              pgtype.UUID        `json:"applicationId"`
 }
 
 type Application struct {
-	ID          pgtype.UUID        `json:"id"`
-	Description string             `json:"description"`
-	Name        string             `json:"name"`
-	CreatedAt   pgtype.Timestamptz `json:"createdAt"`
-	UpdatedAt   pgtype.Timestamptz `json:"updatedAt"`
-	DeletedAt   pgtype.Timestamptz `json:"deletedAt"`
-	ProjectID   pgtype.UUID        `json:"projectId"`
+	ID                         pgtype.UUID        `json:"id"`
+	Description                string             `json:"description"`
+	Name                       string             `json:"name"`
+	RateLimitRequestsPerMinute int32              `json:"rateLimitRequestsPerMinute"`
+	RateLimitTokensPerMinute   int32              `json:"rateLimitTokensPerMinute"`
+	PayloadLoggingEnabled      bool               `json:"payloadLoggingEnabled"`
+	PayloadLogRetentionDays    int32              `json:"payloadLogRetentionDays"`
+	PayloadRedactionRules      []byte             `json:"payloadRedactionRules"`
+	CreatedAt                  pgtype.Timestamptz `json:"createdAt"`
+	UpdatedAt                  pgtype.Timestamptz `json:"updatedAt"`
+	DeletedAt                  pgtype.Timestamptz `json:"deletedAt"`
+	ProjectID                  pgtype.UUID        `json:"projectId"`
+}
+
+type BatchJob struct {
+	ID                      pgtype.UUID        `json:"id"`
+	Status                  BatchJobStatus     `json:"status"`
+	PromptConfigID          pgtype.UUID        `json:"promptConfigId"`
+	TotalItems              int32              `json:"totalItems"`
+	CallbackUrl             pgtype.Text        `json:"callbackUrl"`
+	EncryptedCallbackSecret pgtype.Text        `json:"encryptedCallbackSecret"`
+	CallbackAttempts        int32              `json:"callbackAttempts"`
+	CallbackAvailableAt     pgtype.Timestamptz `json:"callbackAvailableAt"`
+	CallbackDeliveredAt     pgtype.Timestamptz `json:"callbackDeliveredAt"`
+	CreatedAt               pgtype.Timestamptz `json:"createdAt"`
+	StartedAt               pgtype.Timestamptz `json:"startedAt"`
+	FinishedAt              pgtype.Timestamptz `json:"finishedAt"`
+	ApplicationID           pgtype.UUID        `json:"applicationId"`
+}
+
+type BatchJobItem struct {
+	ID                    pgtype.UUID        `json:"id"`
+	ItemIndex             int32              `json:"itemIndex"`
+	TemplateVariables     []byte             `json:"templateVariables"`
+	Status                BatchJobItemStatus `json:"status"`
+	Attempts              int32              `json:"attempts"`
+	AvailableAt           pgtype.Timestamptz `json:"availableAt"`
+	Content               pgtype.Text        `json:"content"`
+	ErrorLog              pgtype.Text        `json:"errorLog"`
+	CreatedAt             pgtype.Timestamptz `json:"createdAt"`
+	FinishedAt            pgtype.Timestamptz `json:"finishedAt"`
+	BatchJobID            pgtype.UUID        `json:"batchJobId"`
+	PromptRequestRecordID pgtype.UUID        `json:"promptRequestRecordId"`
+}
+
+type ConversationMessage struct {
+	ID             pgtype.UUID             `json:"id"`
+	ConversationID string                  `json:"conversationId"`
+	Role           ConversationMessageRole `json:"role"`
+	Content        string                  `json:"content"`
+	ToolCallID     pgtype.Text             `json:"toolCallId"`
+	ToolCalls      []byte                  `json:"toolCalls"`
+	CreatedAt      pgtype.Timestamptz      `json:"createdAt"`
+	ApplicationID  pgtype.UUID             `json:"applicationId"`
+}
+
+type CreditHold struct {
+	ID        pgtype.UUID        `json:"id"`
+	Amount    pgtype.Numeric     `json:"amount"`
+	CreatedAt pgtype.Timestamptz `json:"createdAt"`
+	ExpiresAt pgtype.Timestamptz `json:"expiresAt"`
+	ProjectID pgtype.UUID        `json:"projectId"`
+}
+
+type CreditLedgerEntry struct {
+	ID                    pgtype.UUID           `json:"id"`
+	EntryType             CreditLedgerEntryType `json:"entryType"`
+	Amount                pgtype.Numeric        `json:"amount"`
+	BalanceAfter          pgtype.Numeric        `json:"balanceAfter"`
+	Description           string                `json:"description"`
+	CreatedAt             pgtype.Timestamptz    `json:"createdAt"`
+	PaymentReference      string                `json:"paymentReference"`
+	ProjectID             pgtype.UUID           `json:"projectId"`
+	PromptRequestRecordID pgtype.UUID           `json:"promptRequestRecordId"`
+	UserAccountID         pgtype.UUID           `json:"userAccountId"`
 }
 
 type Project struct {
@@ -232,6 +567,13 @@ type PromptConfig struct {
 	ModelVendor               ModelVendor        `json:"modelVendor"`
 	ProviderPromptMessages    []byte             `json:"providerPromptMessages"`
 	ExpectedTemplateVariables []string           `json:"expectedTemplateVariables"`
+	FallbackModels            []byte             `json:"fallbackModels"`
+	ResponseCacheTtlSeconds   int32              `json:"responseCacheTtlSeconds"`
+	Tools                     []byte             `json:"tools"`
+	ResponseSchema            []byte             `json:"responseSchema"`
+	ResponseSchemaMaxRetries  int32              `json:"responseSchemaMaxRetries"`
+	Version                   int32              `json:"version"`
+	TrafficWeight             int32              `json:"trafficWeight"`
 	IsDefault                 bool               `json:"isDefault"`
 	IsTestConfig              bool               `json:"isTestConfig"`
 	CreatedAt                 pgtype.Timestamptz `json:"createdAt"`
@@ -240,6 +582,33 @@ type PromptConfig struct {
 	ApplicationID             pgtype.UUID        `json:"applicationId"`
 }
 
+type PromptConfigVersion struct {
+	ID                        pgtype.UUID        `json:"id"`
+	Version                   int32              `json:"version"`
+	ModelParameters           []byte             `json:"modelParameters"`
+	ModelType                 ModelType          `json:"modelType"`
+	ModelVendor               ModelVendor        `json:"modelVendor"`
+	ProviderPromptMessages    []byte             `json:"providerPromptMessages"`
+	ExpectedTemplateVariables []string           `json:"expectedTemplateVariables"`
+	FallbackModels            []byte             `json:"fallbackModels"`
+	Tools                     []byte             `json:"tools"`
+	ResponseSchema            []byte             `json:"responseSchema"`
+	ResponseSchemaMaxRetries  int32              `json:"responseSchemaMaxRetries"`
+	CreatedAt                 pgtype.Timestamptz `json:"createdAt"`
+	CreatedByUserID           pgtype.UUID        `json:"createdByUserId"`
+	PromptConfigID            pgtype.UUID        `json:"promptConfigId"`
+}
+
+type PromptRequestPayload struct {
+	ID                    pgtype.UUID        `json:"id"`
+	TemplateVariables     []byte             `json:"templateVariables"`
+	RequestMessages       []byte             `json:"requestMessages"`
+	ResponseContent       string             `json:"responseContent"`
+	CreatedAt             pgtype.Timestamptz `json:"createdAt"`
+	PromptRequestRecordID pgtype.UUID        `json:"promptRequestRecordId"`
+	ApplicationID         pgtype.UUID        `json:"applicationId"`
+}
+
 type PromptRequestRecord struct {
 	ID                     pgtype.UUID        `json:"id"`
 	IsStreamResponse       bool               `json:"isStreamResponse"`
@@ -253,9 +622,14 @@ type PromptRequestRecord struct {
 	DurationMs             pgtype.Int4        `json:"durationMs"`
 	PromptConfigID         pgtype.UUID        `json:"promptConfigId"`
 	ErrorLog               pgtype.Text        `json:"errorLog"`
+	Attempts               int32              `json:"attempts"`
+	IsCacheHit             bool               `json:"isCacheHit"`
 	CreatedAt              pgtype.Timestamptz `json:"createdAt"`
 	DeletedAt              pgtype.Timestamptz `json:"deletedAt"`
 	ProviderModelPricingID pgtype.UUID        `json:"providerModelPricingId"`
+	PromptConfigVersionID  pgtype.UUID        `json:"promptConfigVersionId"`
+	IsTrafficSplit         bool               `json:"isTrafficSplit"`
+	ApplicationID          pgtype.UUID        `json:"applicationId"`
 }
 
 type PromptTestRecord struct {
@@ -275,15 +649,39 @@ type ProviderKey struct {
 }
 
 type ProviderModelPricing struct {
-	ID               pgtype.UUID        `json:"id"`
-	ModelType        ModelType          `json:"modelType"`
-	ModelVendor      ModelVendor        `json:"modelVendor"`
-	InputTokenPrice  pgtype.Numeric     `json:"inputTokenPrice"`
-	OutputTokenPrice pgtype.Numeric     `json:"outputTokenPrice"`
-	TokenUnitSize    int32              `json:"tokenUnitSize"`
-	CreatedAt        pgtype.Timestamptz `json:"createdAt"`
-	ActiveFromDate   pgtype.Date        `json:"activeFromDate"`
-	ActiveToDate     pgtype.Date        `json:"activeToDate"`
+	ID                    pgtype.UUID        `json:"id"`
+	ModelType             ModelType          `json:"modelType"`
+	ModelVendor           ModelVendor        `json:"modelVendor"`
+	InputTokenPrice       pgtype.Numeric     `json:"inputTokenPrice"`
+	OutputTokenPrice      pgtype.Numeric     `json:"outputTokenPrice"`
+	CachedInputTokenPrice pgtype.Numeric     `json:"cachedInputTokenPrice"`
+	ReasoningTokenPrice   pgtype.Numeric     `json:"reasoningTokenPrice"`
+	ImageTokenPrice       pgtype.Numeric     `json:"imageTokenPrice"`
+	TokenUnitSize         int32              `json:"tokenUnitSize"`
+	CreatedAt             pgtype.Timestamptz `json:"createdAt"`
+	ActiveFromDate        pgtype.Date        `json:"activeFromDate"`
+	ActiveToDate          pgtype.Date        `json:"activeToDate"`
+	ProjectID             pgtype.UUID        `json:"projectId"`
+}
+
+type SpendBudget struct {
+	ID            pgtype.UUID            `json:"id"`
+	Period        SpendBudgetPeriod      `json:"period"`
+	Enforcement   SpendBudgetEnforcement `json:"enforcement"`
+	SpendLimit    pgtype.Numeric         `json:"spendLimit"`
+	CreatedAt     pgtype.Timestamptz     `json:"createdAt"`
+	UpdatedAt     pgtype.Timestamptz     `json:"updatedAt"`
+	ProjectID     pgtype.UUID            `json:"projectId"`
+	ApplicationID pgtype.UUID            `json:"applicationId"`
+}
+
+type SpendBudgetAlert struct {
+	ID            pgtype.UUID        `json:"id"`
+	Threshold     int32              `json:"threshold"`
+	Spend         pgtype.Numeric     `json:"spend"`
+	PeriodStart   pgtype.Timestamptz `json:"periodStart"`
+	CreatedAt     pgtype.Timestamptz `json:"createdAt"`
+	SpendBudgetID pgtype.UUID        `json:"spendBudgetId"`
 }
 
 type UserAccount struct {
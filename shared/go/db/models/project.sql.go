@@ -87,23 +87,22 @@ func (q *Queries) RetrieveProject(ctx context.Context, id pgtype.UUID) (Retrieve
 
 const retrieveProjectAPIRequestCount = `-- name: RetrieveProjectAPIRequestCount :one
 SELECT COUNT(prr.id) AS total_requests
-FROM project AS p
-INNER JOIN application AS a ON p.id = a.project_id
-INNER JOIN prompt_config AS pc ON a.id = pc.application_id
-INNER JOIN prompt_request_record AS prr ON pc.id = prr.prompt_config_id
+FROM prompt_request_record AS prr
+LEFT JOIN prompt_config AS pc ON prr.prompt_config_id = pc.id
+INNER JOIN application AS a ON COALESCE(prr.application_id, pc.application_id) = a.id
 WHERE
-    p.id = $1
+    a.project_id = $1
     AND prr.created_at BETWEEN $2 AND $3
 `
 
 type RetrieveProjectAPIRequestCountParams struct {
-	ID          pgtype.UUID        `json:"id"`
+	ProjectID   pgtype.UUID        `json:"projectId"`
 	CreatedAt   pgtype.Timestamptz `json:"createdAt"`
 	CreatedAt_2 pgtype.Timestamptz `json:"createdAt2"`
 }
 
 func (q *Queries) RetrieveProjectAPIRequestCount(ctx context.Context, arg RetrieveProjectAPIRequestCountParams) (int64, error) {
-	row := q.db.QueryRow(ctx, retrieveProjectAPIRequestCount, arg.ID, arg.CreatedAt, arg.CreatedAt_2)
+	row := q.db.QueryRow(ctx, retrieveProjectAPIRequestCount, arg.ProjectID, arg.CreatedAt, arg.CreatedAt_2)
 	var total_requests int64
 	err := row.Scan(&total_requests)
 	return total_requests, err
@@ -156,23 +155,22 @@ func (q *Queries) RetrieveProjectForUser(ctx context.Context, arg RetrieveProjec
 
 const retrieveProjectTokensTotalCost = `-- name: RetrieveProjectTokensTotalCost :one
 SELECT COALESCE(SUM(prr.request_tokens_cost + prr.response_tokens_cost), 0)
-FROM project AS p
-INNER JOIN application AS app ON p.id = app.project_id
-LEFT JOIN prompt_config AS pc ON app.id = pc.application_id
-LEFT JOIN prompt_request_record AS prr ON pc.id = prr.prompt_config_id
+FROM prompt_request_record AS prr
+LEFT JOIN prompt_config AS pc ON prr.prompt_config_id = pc.id
+INNER JOIN application AS app ON COALESCE(prr.application_id, pc.application_id) = app.id
 WHERE
-    p.id = $1
+    app.project_id = $1
     AND prr.created_at BETWEEN $2 AND $3
 `
 
 type RetrieveProjectTokensTotalCostParams struct {
-	ID          pgtype.UUID        `json:"id"`
+	ProjectID   pgtype.UUID        `json:"projectId"`
 	CreatedAt   pgtype.Timestamptz `json:"createdAt"`
 	CreatedAt_2 pgtype.Timestamptz `json:"createdAt2"`
 }
 
 func (q *Queries) RetrieveProjectTokensTotalCost(ctx context.Context, arg RetrieveProjectTokensTotalCostParams) (pgtype.Numeric, error) {
-	row := q.db.QueryRow(ctx, retrieveProjectTokensTotalCost, arg.ID, arg.CreatedAt, arg.CreatedAt_2)
+	row := q.db.QueryRow(ctx, retrieveProjectTokensTotalCost, arg.ProjectID, arg.CreatedAt, arg.CreatedAt_2)
 	var coalesce pgtype.Numeric
 	err := row.Scan(&coalesce)
 	return coalesce, err
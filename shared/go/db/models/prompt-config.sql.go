@@ -40,10 +40,15 @@ INSERT INTO prompt_config (
     expected_template_variables,
     is_default,
     application_id,
-    is_test_config
+    is_test_config,
+    fallback_models,
+    response_cache_ttl_seconds,
+    tools,
+    response_schema,
+    response_schema_max_retries
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-RETURNING id, name, model_parameters, model_type, model_vendor, provider_prompt_messages, expected_template_variables, is_default, is_test_config, created_at, updated_at, deleted_at, application_id
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+RETURNING id, name, model_parameters, model_type, model_vendor, provider_prompt_messages, expected_template_variables, fallback_models, response_cache_ttl_seconds, tools, response_schema, response_schema_max_retries, version, traffic_weight, is_default, is_test_config, created_at, updated_at, deleted_at, application_id
 `
 
 type CreatePromptConfigParams struct {
@@ -56,6 +61,11 @@ type CreatePromptConfigParams struct {
 	IsDefault                 bool        `json:"isDefault"`
 	ApplicationID             pgtype.UUID `json:"applicationId"`
 	IsTestConfig              bool        `json:"isTestConfig"`
+	FallbackModels            []byte      `json:"fallbackModels"`
+	ResponseCacheTtlSeconds   int32       `json:"responseCacheTtlSeconds"`
+	Tools                     []byte      `json:"tools"`
+	ResponseSchema            []byte      `json:"responseSchema"`
+	ResponseSchemaMaxRetries  int32       `json:"responseSchemaMaxRetries"`
 }
 
 // -- prompt config
@@ -70,6 +80,11 @@ func (q *Queries) CreatePromptConfig(ctx context.Context, arg CreatePromptConfig
 		arg.IsDefault,
 		arg.ApplicationID,
 		arg.IsTestConfig,
+		arg.FallbackModels,
+		arg.ResponseCacheTtlSeconds,
+		arg.Tools,
+		arg.ResponseSchema,
+		arg.ResponseSchemaMaxRetries,
 	)
 	var i PromptConfig
 	err := row.Scan(
@@ -80,6 +95,13 @@ func (q *Queries) CreatePromptConfig(ctx context.Context, arg CreatePromptConfig
 		&i.ModelVendor,
 		&i.ProviderPromptMessages,
 		&i.ExpectedTemplateVariables,
+		&i.FallbackModels,
+		&i.ResponseCacheTtlSeconds,
+		&i.Tools,
+		&i.ResponseSchema,
+		&i.ResponseSchemaMaxRetries,
+		&i.Version,
+		&i.TrafficWeight,
 		&i.IsDefault,
 		&i.IsTestConfig,
 		&i.CreatedAt,
@@ -101,25 +123,50 @@ func (q *Queries) DeletePromptConfig(ctx context.Context, id pgtype.UUID) error
 	return err
 }
 
-const retrieveDefaultPromptConfig = `-- name: RetrieveDefaultPromptConfig :one
-SELECT
-    id,
-    name,
-    model_parameters,
-    model_type,
-    model_vendor,
-    provider_prompt_messages,
-    expected_template_variables,
-    is_default,
-    created_at,
-    updated_at,
-    application_id
-FROM prompt_config
+const resetApplicationTrafficWeights = `-- name: ResetApplicationTrafficWeights :exec
+UPDATE prompt_config
+SET
+    traffic_weight = 0,
+    updated_at = NOW()
 WHERE
     application_id = $1
     AND deleted_at IS NULL
-    AND is_default = TRUE
-    AND is_test_config = FALSE
+    AND traffic_weight > 0
+`
+
+func (q *Queries) ResetApplicationTrafficWeights(ctx context.Context, applicationID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, resetApplicationTrafficWeights, applicationID)
+	return err
+}
+
+const retrieveDefaultPromptConfig = `-- name: RetrieveDefaultPromptConfig :one
+SELECT
+    pc.id,
+    pc.name,
+    pc.model_parameters,
+    pc.model_type,
+    pc.model_vendor,
+    pc.provider_prompt_messages,
+    pc.expected_template_variables,
+    pc.fallback_models,
+    pc.response_cache_ttl_seconds,
+    pc.tools,
+    pc.response_schema,
+    pc.response_schema_max_retries,
+    pc.version,
+    pcv.id AS version_id,
+    pc.traffic_weight,
+    pc.is_default,
+    pc.created_at,
+    pc.updated_at,
+    pc.application_id
+FROM prompt_config AS pc
+LEFT JOIN prompt_config_version AS pcv ON pc.id = pcv.prompt_config_id AND pc.version = pcv.version
+WHERE
+    pc.application_id = $1
+    AND pc.deleted_at IS NULL
+    AND pc.is_default = TRUE
+    AND pc.is_test_config = FALSE
 `
 
 type RetrieveDefaultPromptConfigRow struct {
@@ -130,6 +177,14 @@ type RetrieveDefaultPromptConfigRow struct {
 	ModelVendor               ModelVendor        `json:"modelVendor"`
 	ProviderPromptMessages    []byte             `json:"providerPromptMessages"`
 	ExpectedTemplateVariables []string           `json:"expectedTemplateVariables"`
+	FallbackModels            []byte             `json:"fallbackModels"`
+	ResponseCacheTtlSeconds   int32              `json:"responseCacheTtlSeconds"`
+	Tools                     []byte             `json:"tools"`
+	ResponseSchema            []byte             `json:"responseSchema"`
+	ResponseSchemaMaxRetries  int32              `json:"responseSchemaMaxRetries"`
+	Version                   int32              `json:"version"`
+	VersionID                 pgtype.UUID        `json:"versionId"`
+	TrafficWeight             int32              `json:"trafficWeight"`
 	IsDefault                 bool               `json:"isDefault"`
 	CreatedAt                 pgtype.Timestamptz `json:"createdAt"`
 	UpdatedAt                 pgtype.Timestamptz `json:"updatedAt"`
@@ -147,6 +202,14 @@ func (q *Queries) RetrieveDefaultPromptConfig(ctx context.Context, applicationID
 		&i.ModelVendor,
 		&i.ProviderPromptMessages,
 		&i.ExpectedTemplateVariables,
+		&i.FallbackModels,
+		&i.ResponseCacheTtlSeconds,
+		&i.Tools,
+		&i.ResponseSchema,
+		&i.ResponseSchemaMaxRetries,
+		&i.Version,
+		&i.VersionID,
+		&i.TrafficWeight,
 		&i.IsDefault,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -157,22 +220,31 @@ func (q *Queries) RetrieveDefaultPromptConfig(ctx context.Context, applicationID
 
 const retrievePromptConfig = `-- name: RetrievePromptConfig :one
 SELECT
-    id,
-    name,
-    model_parameters,
-    model_type,
-    model_vendor,
-    provider_prompt_messages,
-    expected_template_variables,
-    is_default,
-    created_at,
-    updated_at,
-    application_id,
-    is_test_config
-FROM prompt_config
+    pc.id,
+    pc.name,
+    pc.model_parameters,
+    pc.model_type,
+    pc.model_vendor,
+    pc.provider_prompt_messages,
+    pc.expected_template_variables,
+    pc.fallback_models,
+    pc.response_cache_ttl_seconds,
+    pc.tools,
+    pc.response_schema,
+    pc.response_schema_max_retries,
+    pc.version,
+    pcv.id AS version_id,
+    pc.traffic_weight,
+    pc.is_default,
+    pc.created_at,
+    pc.updated_at,
+    pc.application_id,
+    pc.is_test_config
+FROM prompt_config AS pc
+LEFT JOIN prompt_config_version AS pcv ON pc.id = pcv.prompt_config_id AND pc.version = pcv.version
 WHERE
-    id = $1
-    AND deleted_at IS NULL
+    pc.id = $1
+    AND pc.deleted_at IS NULL
 `
 
 type RetrievePromptConfigRow struct {
@@ -183,6 +255,14 @@ type RetrievePromptConfigRow struct {
 	ModelVendor               ModelVendor        `json:"modelVendor"`
 	ProviderPromptMessages    []byte             `json:"providerPromptMessages"`
 	ExpectedTemplateVariables []string           `json:"expectedTemplateVariables"`
+	FallbackModels            []byte             `json:"fallbackModels"`
+	ResponseCacheTtlSeconds   int32              `json:"responseCacheTtlSeconds"`
+	Tools                     []byte             `json:"tools"`
+	ResponseSchema            []byte             `json:"responseSchema"`
+	ResponseSchemaMaxRetries  int32              `json:"responseSchemaMaxRetries"`
+	Version                   int32              `json:"version"`
+	VersionID                 pgtype.UUID        `json:"versionId"`
+	TrafficWeight             int32              `json:"trafficWeight"`
 	IsDefault                 bool               `json:"isDefault"`
 	CreatedAt                 pgtype.Timestamptz `json:"createdAt"`
 	UpdatedAt                 pgtype.Timestamptz `json:"updatedAt"`
@@ -201,6 +281,14 @@ func (q *Queries) RetrievePromptConfig(ctx context.Context, id pgtype.UUID) (Ret
 		&i.ModelVendor,
 		&i.ProviderPromptMessages,
 		&i.ExpectedTemplateVariables,
+		&i.FallbackModels,
+		&i.ResponseCacheTtlSeconds,
+		&i.Tools,
+		&i.ResponseSchema,
+		&i.ResponseSchemaMaxRetries,
+		&i.Version,
+		&i.VersionID,
+		&i.TrafficWeight,
 		&i.IsDefault,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -263,6 +351,13 @@ SELECT
     model_vendor,
     provider_prompt_messages,
     expected_template_variables,
+    fallback_models,
+    response_cache_ttl_seconds,
+    tools,
+    response_schema,
+    response_schema_max_retries,
+    version,
+    traffic_weight,
     is_default,
     created_at,
     updated_at,
@@ -282,6 +377,13 @@ type RetrievePromptConfigsRow struct {
 	ModelVendor               ModelVendor        `json:"modelVendor"`
 	ProviderPromptMessages    []byte             `json:"providerPromptMessages"`
 	ExpectedTemplateVariables []string           `json:"expectedTemplateVariables"`
+	FallbackModels            []byte             `json:"fallbackModels"`
+	ResponseCacheTtlSeconds   int32              `json:"responseCacheTtlSeconds"`
+	Tools                     []byte             `json:"tools"`
+	ResponseSchema            []byte             `json:"responseSchema"`
+	ResponseSchemaMaxRetries  int32              `json:"responseSchemaMaxRetries"`
+	Version                   int32              `json:"version"`
+	TrafficWeight             int32              `json:"trafficWeight"`
 	IsDefault                 bool               `json:"isDefault"`
 	CreatedAt                 pgtype.Timestamptz `json:"createdAt"`
 	UpdatedAt                 pgtype.Timestamptz `json:"updatedAt"`
@@ -305,6 +407,13 @@ func (q *Queries) RetrievePromptConfigs(ctx context.Context, applicationID pgtyp
 			&i.ModelVendor,
 			&i.ProviderPromptMessages,
 			&i.ExpectedTemplateVariables,
+			&i.FallbackModels,
+			&i.ResponseCacheTtlSeconds,
+			&i.Tools,
+			&i.ResponseSchema,
+			&i.ResponseSchemaMaxRetries,
+			&i.Version,
+			&i.TrafficWeight,
 			&i.IsDefault,
 			&i.CreatedAt,
 			&i.UpdatedAt,
@@ -320,6 +429,44 @@ func (q *Queries) RetrievePromptConfigs(ctx context.Context, applicationID pgtyp
 	return items, nil
 }
 
+const retrieveTrafficSplitPromptConfigs = `-- name: RetrieveTrafficSplitPromptConfigs :many
+SELECT
+    id,
+    traffic_weight
+FROM prompt_config
+WHERE
+    application_id = $1
+    AND deleted_at IS NULL
+    AND is_test_config = FALSE
+    AND traffic_weight > 0
+ORDER BY created_at, id
+`
+
+type RetrieveTrafficSplitPromptConfigsRow struct {
+	ID            pgtype.UUID `json:"id"`
+	TrafficWeight int32       `json:"trafficWeight"`
+}
+
+func (q *Queries) RetrieveTrafficSplitPromptConfigs(ctx context.Context, applicationID pgtype.UUID) ([]RetrieveTrafficSplitPromptConfigsRow, error) {
+	rows, err := q.db.Query(ctx, retrieveTrafficSplitPromptConfigs, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RetrieveTrafficSplitPromptConfigsRow
+	for rows.Next() {
+		var i RetrieveTrafficSplitPromptConfigsRow
+		if err := rows.Scan(&i.ID, &i.TrafficWeight); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateDefaultPromptConfig = `-- name: UpdateDefaultPromptConfig :exec
 UPDATE prompt_config
 SET
@@ -351,11 +498,17 @@ SET
     provider_prompt_messages = $6,
     expected_template_variables = $7,
     is_test_config = $8,
+    fallback_models = $9,
+    response_cache_ttl_seconds = $10,
+    tools = $11,
+    response_schema = $12,
+    response_schema_max_retries = $13,
+    version = version + $14::int,
     updated_at = NOW()
 WHERE
     id = $1
     AND deleted_at IS NULL
-RETURNING id, name, model_parameters, model_type, model_vendor, provider_prompt_messages, expected_template_variables, is_default, is_test_config, created_at, updated_at, deleted_at, application_id
+RETURNING id, name, model_parameters, model_type, model_vendor, provider_prompt_messages, expected_template_variables, fallback_models, response_cache_ttl_seconds, tools, response_schema, response_schema_max_retries, version, traffic_weight, is_default, is_test_config, created_at, updated_at, deleted_at, application_id
 `
 
 type UpdatePromptConfigParams struct {
@@ -367,6 +520,12 @@ type UpdatePromptConfigParams struct {
 	ProviderPromptMessages    []byte      `json:"providerPromptMessages"`
 	ExpectedTemplateVariables []string    `json:"expectedTemplateVariables"`
 	IsTestConfig              bool        `json:"isTestConfig"`
+	FallbackModels            []byte      `json:"fallbackModels"`
+	ResponseCacheTtlSeconds   int32       `json:"responseCacheTtlSeconds"`
+	Tools                     []byte      `json:"tools"`
+	ResponseSchema            []byte      `json:"responseSchema"`
+	ResponseSchemaMaxRetries  int32       `json:"responseSchemaMaxRetries"`
+	VersionIncrement          int32       `json:"versionIncrement"`
 }
 
 func (q *Queries) UpdatePromptConfig(ctx context.Context, arg UpdatePromptConfigParams) (PromptConfig, error) {
@@ -379,6 +538,12 @@ func (q *Queries) UpdatePromptConfig(ctx context.Context, arg UpdatePromptConfig
 		arg.ProviderPromptMessages,
 		arg.ExpectedTemplateVariables,
 		arg.IsTestConfig,
+		arg.FallbackModels,
+		arg.ResponseCacheTtlSeconds,
+		arg.Tools,
+		arg.ResponseSchema,
+		arg.ResponseSchemaMaxRetries,
+		arg.VersionIncrement,
 	)
 	var i PromptConfig
 	err := row.Scan(
@@ -389,6 +554,13 @@ func (q *Queries) UpdatePromptConfig(ctx context.Context, arg UpdatePromptConfig
 		&i.ModelVendor,
 		&i.ProviderPromptMessages,
 		&i.ExpectedTemplateVariables,
+		&i.FallbackModels,
+		&i.ResponseCacheTtlSeconds,
+		&i.Tools,
+		&i.ResponseSchema,
+		&i.ResponseSchemaMaxRetries,
+		&i.Version,
+		&i.TrafficWeight,
 		&i.IsDefault,
 		&i.IsTestConfig,
 		&i.CreatedAt,
@@ -398,3 +570,29 @@ func (q *Queries) UpdatePromptConfig(ctx context.Context, arg UpdatePromptConfig
 	)
 	return i, err
 }
+
+const updatePromptConfigTrafficWeight = `-- name: UpdatePromptConfigTrafficWeight :execrows
+UPDATE prompt_config
+SET
+    traffic_weight = $3,
+    updated_at = NOW()
+WHERE
+    id = $1
+    AND application_id = $2
+    AND deleted_at IS NULL
+    AND is_test_config = FALSE
+`
+
+type UpdatePromptConfigTrafficWeightParams struct {
+	ID            pgtype.UUID `json:"id"`
+	ApplicationID pgtype.UUID `json:"applicationId"`
+	TrafficWeight int32       `json:"trafficWeight"`
+}
+
+func (q *Queries) UpdatePromptConfigTrafficWeight(ctx context.Context, arg UpdatePromptConfigTrafficWeightParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updatePromptConfigTrafficWeight, arg.ID, arg.ApplicationID, arg.TrafficWeight)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -25,10 +25,15 @@ INSERT INTO prompt_request_record (
     prompt_config_id,
     provider_model_pricing_id,
     error_log,
-    finish_reason
+    finish_reason,
+    attempts,
+    is_cache_hit,
+    prompt_config_version_id,
+    is_traffic_split,
+    application_id
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
-RETURNING id, is_stream_response, request_tokens, response_tokens, request_tokens_cost, response_tokens_cost, start_time, finish_time, finish_reason, duration_ms, prompt_config_id, error_log, created_at, deleted_at, provider_model_pricing_id
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+RETURNING id, is_stream_response, request_tokens, response_tokens, request_tokens_cost, response_tokens_cost, start_time, finish_time, finish_reason, duration_ms, prompt_config_id, error_log, attempts, is_cache_hit, created_at, deleted_at, provider_model_pricing_id, prompt_config_version_id, is_traffic_split, application_id
 `
 
 type CreatePromptRequestRecordParams struct {
@@ -44,6 +49,11 @@ type CreatePromptRequestRecordParams struct {
 	ProviderModelPricingID pgtype.UUID        `json:"providerModelPricingId"`
 	ErrorLog               pgtype.Text        `json:"errorLog"`
 	FinishReason           PromptFinishReason `json:"finishReason"`
+	Attempts               int32              `json:"attempts"`
+	IsCacheHit             bool               `json:"isCacheHit"`
+	PromptConfigVersionID  pgtype.UUID        `json:"promptConfigVersionId"`
+	IsTrafficSplit         bool               `json:"isTrafficSplit"`
+	ApplicationID          pgtype.UUID        `json:"applicationId"`
 }
 
 // -- prompt request record
@@ -61,6 +71,11 @@ func (q *Queries) CreatePromptRequestRecord(ctx context.Context, arg CreatePromp
 		arg.ProviderModelPricingID,
 		arg.ErrorLog,
 		arg.FinishReason,
+		arg.Attempts,
+		arg.IsCacheHit,
+		arg.PromptConfigVersionID,
+		arg.IsTrafficSplit,
+		arg.ApplicationID,
 	)
 	var i PromptRequestRecord
 	err := row.Scan(
@@ -76,9 +91,113 @@ func (q *Queries) CreatePromptRequestRecord(ctx context.Context, arg CreatePromp
 		&i.DurationMs,
 		&i.PromptConfigID,
 		&i.ErrorLog,
+		&i.Attempts,
+		&i.IsCacheHit,
 		&i.CreatedAt,
 		&i.DeletedAt,
 		&i.ProviderModelPricingID,
+		&i.PromptConfigVersionID,
+		&i.IsTrafficSplit,
+		&i.ApplicationID,
 	)
 	return i, err
 }
+
+const retrievePromptRequestTimeSeries = `-- name: RetrievePromptRequestTimeSeries :many
+SELECT
+    date_trunc($1::text, prr.created_at)::timestamptz AS bucket,
+    COALESCE(CASE $2::text
+        WHEN 'application' THEN a.id::text
+        WHEN 'promptConfig' THEN pc.id::text
+        WHEN 'modelType' THEN COALESCE(pmp.model_type, pc.model_type)::text
+        WHEN 'modelVendor' THEN COALESCE(pmp.model_vendor, pc.model_vendor)::text
+        WHEN 'finishReason' THEN prr.finish_reason::text
+    END, '')::text AS group_key,
+    COALESCE(CASE $2::text
+        WHEN 'application' THEN a.name
+        WHEN 'promptConfig' THEN pc.name
+    END, '')::text AS group_name,
+    COUNT(prr.id) AS total_requests,
+    COUNT(prr.id) FILTER (WHERE prr.finish_reason = 'ERROR') AS total_errors,
+    COALESCE(SUM(prr.request_tokens), 0)::bigint AS request_tokens,
+    COALESCE(SUM(prr.response_tokens), 0)::bigint AS response_tokens,
+    COALESCE(SUM(prr.request_tokens_cost + prr.response_tokens_cost), 0)::numeric AS tokens_cost,
+    COALESCE(PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY prr.duration_ms), 0)::float8 AS p50_duration_ms,
+    COALESCE(PERCENTILE_CONT(0.95) WITHIN GROUP (ORDER BY prr.duration_ms), 0)::float8 AS p95_duration_ms,
+    COALESCE(PERCENTILE_CONT(0.99) WITHIN GROUP (ORDER BY prr.duration_ms), 0)::float8 AS p99_duration_ms
+FROM prompt_request_record AS prr
+LEFT JOIN prompt_config AS pc ON prr.prompt_config_id = pc.id
+INNER JOIN application AS a ON COALESCE(prr.application_id, pc.application_id) = a.id
+LEFT JOIN provider_model_pricing AS pmp ON prr.provider_model_pricing_id = pmp.id
+WHERE
+    a.project_id = $3
+    AND ($4::uuid IS NULL OR a.id = $4)
+    AND ($5::uuid IS NULL OR pc.id = $5)
+    AND prr.created_at BETWEEN $6 AND $7
+GROUP BY bucket, group_key, group_name
+ORDER BY bucket, group_key
+`
+
+type RetrievePromptRequestTimeSeriesParams struct {
+	BucketSize     string             `json:"bucketSize"`
+	GroupBy        string             `json:"groupBy"`
+	ProjectID      pgtype.UUID        `json:"projectId"`
+	ApplicationID  pgtype.UUID        `json:"applicationId"`
+	PromptConfigID pgtype.UUID        `json:"promptConfigId"`
+	FromDate       pgtype.Timestamptz `json:"fromDate"`
+	ToDate         pgtype.Timestamptz `json:"toDate"`
+}
+
+type RetrievePromptRequestTimeSeriesRow struct {
+	Bucket         pgtype.Timestamptz `json:"bucket"`
+	GroupKey       string             `json:"groupKey"`
+	GroupName      string             `json:"groupName"`
+	TotalRequests  int64              `json:"totalRequests"`
+	TotalErrors    int64              `json:"totalErrors"`
+	RequestTokens  int64              `json:"requestTokens"`
+	ResponseTokens int64              `json:"responseTokens"`
+	TokensCost     pgtype.Numeric     `json:"tokensCost"`
+	P50DurationMs  float64            `json:"p50DurationMs"`
+	P95DurationMs  float64            `json:"p95DurationMs"`
+	P99DurationMs  float64            `json:"p99DurationMs"`
+}
+
+func (q *Queries) RetrievePromptRequestTimeSeries(ctx context.Context, arg RetrievePromptRequestTimeSeriesParams) ([]RetrievePromptRequestTimeSeriesRow, error) {
+	rows, err := q.db.Query(ctx, retrievePromptRequestTimeSeries,
+		arg.BucketSize,
+		arg.GroupBy,
+		arg.ProjectID,
+		arg.ApplicationID,
+		arg.PromptConfigID,
+		arg.FromDate,
+		arg.ToDate,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RetrievePromptRequestTimeSeriesRow
+	for rows.Next() {
+		var i RetrievePromptRequestTimeSeriesRow
+		if err := rows.Scan(
+			&i.Bucket,
+			&i.GroupKey,
+			&i.GroupName,
+			&i.TotalRequests,
+			&i.TotalErrors,
+			&i.RequestTokens,
+			&i.ResponseTokens,
+			&i.TokensCost,
+			&i.P50DurationMs,
+			&i.P95DurationMs,
+			&i.P99DurationMs,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
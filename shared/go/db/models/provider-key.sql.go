@@ -49,13 +49,16 @@ func (q *Queries) CreateProviderKey(ctx context.Context, arg CreateProviderKeyPa
 	return i, err
 }
 
-const deleteProviderKey = `-- name: DeleteProviderKey :exec
+const deleteProviderKey = `-- name: DeleteProviderKey :one
 DELETE FROM provider_key WHERE id = $1
+RETURNING model_vendor
 `
 
-func (q *Queries) DeleteProviderKey(ctx context.Context, id pgtype.UUID) error {
-	_, err := q.db.Exec(ctx, deleteProviderKey, id)
-	return err
+func (q *Queries) DeleteProviderKey(ctx context.Context, id pgtype.UUID) (ModelVendor, error) {
+	row := q.db.QueryRow(ctx, deleteProviderKey, id)
+	var model_vendor ModelVendor
+	err := row.Scan(&model_vendor)
+	return model_vendor, err
 }
 
 const retrieveProjectProviderKeys = `-- name: RetrieveProjectProviderKeys :many
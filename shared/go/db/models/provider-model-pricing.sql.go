@@ -18,24 +18,32 @@ INSERT INTO provider_model_pricing (
     model_vendor,
     input_token_price,
     output_token_price,
+    cached_input_token_price,
+    reasoning_token_price,
+    image_token_price,
     token_unit_size,
     active_from_date,
-    active_to_date
+    active_to_date,
+    project_id
 )
 VALUES (
     $1,
-    $2, $3, $4, $5, $6, $7
-) RETURNING id, model_type, model_vendor, input_token_price, output_token_price, token_unit_size, created_at, active_from_date, active_to_date
+    $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+) RETURNING id, model_type, model_vendor, input_token_price, output_token_price, cached_input_token_price, reasoning_token_price, image_token_price, token_unit_size, created_at, active_from_date, active_to_date, project_id
 `
 
 type CreateProviderModelPricingParams struct {
-	ModelType        ModelType      `json:"modelType"`
-	ModelVendor      ModelVendor    `json:"modelVendor"`
-	InputTokenPrice  pgtype.Numeric `json:"inputTokenPrice"`
-	OutputTokenPrice pgtype.Numeric `json:"outputTokenPrice"`
-	TokenUnitSize    int32          `json:"tokenUnitSize"`
-	ActiveFromDate   pgtype.Date    `json:"activeFromDate"`
-	ActiveToDate     pgtype.Date    `json:"activeToDate"`
+	ModelType             ModelType      `json:"modelType"`
+	ModelVendor           ModelVendor    `json:"modelVendor"`
+	InputTokenPrice       pgtype.Numeric `json:"inputTokenPrice"`
+	OutputTokenPrice      pgtype.Numeric `json:"outputTokenPrice"`
+	CachedInputTokenPrice pgtype.Numeric `json:"cachedInputTokenPrice"`
+	ReasoningTokenPrice   pgtype.Numeric `json:"reasoningTokenPrice"`
+	ImageTokenPrice       pgtype.Numeric `json:"imageTokenPrice"`
+	TokenUnitSize         int32          `json:"tokenUnitSize"`
+	ActiveFromDate        pgtype.Date    `json:"activeFromDate"`
+	ActiveToDate          pgtype.Date    `json:"activeToDate"`
+	ProjectID             pgtype.UUID    `json:"projectId"`
 }
 
 // provider model pricing
@@ -45,9 +53,13 @@ func (q *Queries) CreateProviderModelPricing(ctx context.Context, arg CreateProv
 		arg.ModelVendor,
 		arg.InputTokenPrice,
 		arg.OutputTokenPrice,
+		arg.CachedInputTokenPrice,
+		arg.ReasoningTokenPrice,
+		arg.ImageTokenPrice,
 		arg.TokenUnitSize,
 		arg.ActiveFromDate,
 		arg.ActiveToDate,
+		arg.ProjectID,
 	)
 	var i ProviderModelPricing
 	err := row.Scan(
@@ -56,14 +68,37 @@ func (q *Queries) CreateProviderModelPricing(ctx context.Context, arg CreateProv
 		&i.ModelVendor,
 		&i.InputTokenPrice,
 		&i.OutputTokenPrice,
+		&i.CachedInputTokenPrice,
+		&i.ReasoningTokenPrice,
+		&i.ImageTokenPrice,
 		&i.TokenUnitSize,
 		&i.CreatedAt,
 		&i.ActiveFromDate,
 		&i.ActiveToDate,
+		&i.ProjectID,
 	)
 	return i, err
 }
 
+const deleteProviderModelPricing = `-- name: DeleteProviderModelPricing :exec
+DELETE FROM provider_model_pricing
+WHERE id = $1
+`
+
+func (q *Queries) DeleteProviderModelPricing(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteProviderModelPricing, id)
+	return err
+}
+
+const lockProviderModelPricings = `-- name: LockProviderModelPricings :exec
+SELECT pg_advisory_xact_lock(hashtext('provider_model_pricing'))
+`
+
+func (q *Queries) LockProviderModelPricings(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, lockProviderModelPricings)
+	return err
+}
+
 const retrieveActiveProviderModelPricing = `-- name: RetrieveActiveProviderModelPricing :one
 SELECT
     id,
@@ -71,6 +106,9 @@ SELECT
     model_vendor,
     input_token_price,
     output_token_price,
+    cached_input_token_price,
+    reasoning_token_price,
+    image_token_price,
     token_unit_size,
     active_from_date,
     active_to_date
@@ -78,30 +116,35 @@ FROM provider_model_pricing
 WHERE
     model_type = $1
     AND model_vendor = $2
+    AND (project_id IS NULL OR project_id = $3)
     AND active_from_date <= CURRENT_DATE
-    AND active_to_date IS NULL OR active_to_date >= CURRENT_DATE
-ORDER BY active_from_date DESC
+    AND (active_to_date IS NULL OR active_to_date >= CURRENT_DATE)
+ORDER BY project_id IS NULL, active_from_date DESC
 LIMIT 1
 `
 
 type RetrieveActiveProviderModelPricingParams struct {
 	ModelType   ModelType   `json:"modelType"`
 	ModelVendor ModelVendor `json:"modelVendor"`
+	ProjectID   pgtype.UUID `json:"projectId"`
 }
 
 type RetrieveActiveProviderModelPricingRow struct {
-	ID               pgtype.UUID    `json:"id"`
-	ModelType        ModelType      `json:"modelType"`
-	ModelVendor      ModelVendor    `json:"modelVendor"`
-	InputTokenPrice  pgtype.Numeric `json:"inputTokenPrice"`
-	OutputTokenPrice pgtype.Numeric `json:"outputTokenPrice"`
-	TokenUnitSize    int32          `json:"tokenUnitSize"`
-	ActiveFromDate   pgtype.Date    `json:"activeFromDate"`
-	ActiveToDate     pgtype.Date    `json:"activeToDate"`
+	ID                    pgtype.UUID    `json:"id"`
+	ModelType             ModelType      `json:"modelType"`
+	ModelVendor           ModelVendor    `json:"modelVendor"`
+	InputTokenPrice       pgtype.Numeric `json:"inputTokenPrice"`
+	OutputTokenPrice      pgtype.Numeric `json:"outputTokenPrice"`
+	CachedInputTokenPrice pgtype.Numeric `json:"cachedInputTokenPrice"`
+	ReasoningTokenPrice   pgtype.Numeric `json:"reasoningTokenPrice"`
+	ImageTokenPrice       pgtype.Numeric `json:"imageTokenPrice"`
+	TokenUnitSize         int32          `json:"tokenUnitSize"`
+	ActiveFromDate        pgtype.Date    `json:"activeFromDate"`
+	ActiveToDate          pgtype.Date    `json:"activeToDate"`
 }
 
 func (q *Queries) RetrieveActiveProviderModelPricing(ctx context.Context, arg RetrieveActiveProviderModelPricingParams) (RetrieveActiveProviderModelPricingRow, error) {
-	row := q.db.QueryRow(ctx, retrieveActiveProviderModelPricing, arg.ModelType, arg.ModelVendor)
+	row := q.db.QueryRow(ctx, retrieveActiveProviderModelPricing, arg.ModelType, arg.ModelVendor, arg.ProjectID)
 	var i RetrieveActiveProviderModelPricingRow
 	err := row.Scan(
 		&i.ID,
@@ -109,9 +152,246 @@ func (q *Queries) RetrieveActiveProviderModelPricing(ctx context.Context, arg Re
 		&i.ModelVendor,
 		&i.InputTokenPrice,
 		&i.OutputTokenPrice,
+		&i.CachedInputTokenPrice,
+		&i.ReasoningTokenPrice,
+		&i.ImageTokenPrice,
 		&i.TokenUnitSize,
 		&i.ActiveFromDate,
 		&i.ActiveToDate,
 	)
 	return i, err
 }
+
+const retrieveActiveProviderModelPricings = `-- name: RetrieveActiveProviderModelPricings :many
+SELECT DISTINCT ON (model_vendor, model_type)
+    id,
+    model_type,
+    model_vendor,
+    input_token_price,
+    output_token_price,
+    cached_input_token_price,
+    reasoning_token_price,
+    image_token_price,
+    token_unit_size,
+    active_from_date,
+    active_to_date,
+    project_id
+FROM provider_model_pricing
+WHERE
+    (project_id IS NULL OR project_id = $1)
+    AND active_from_date <= CURRENT_DATE
+    AND (active_to_date IS NULL OR active_to_date >= CURRENT_DATE)
+ORDER BY model_vendor, model_type, project_id IS NULL, active_from_date DESC
+`
+
+type RetrieveActiveProviderModelPricingsRow struct {
+	ID                    pgtype.UUID    `json:"id"`
+	ModelType             ModelType      `json:"modelType"`
+	ModelVendor           ModelVendor    `json:"modelVendor"`
+	InputTokenPrice       pgtype.Numeric `json:"inputTokenPrice"`
+	OutputTokenPrice      pgtype.Numeric `json:"outputTokenPrice"`
+	CachedInputTokenPrice pgtype.Numeric `json:"cachedInputTokenPrice"`
+	ReasoningTokenPrice   pgtype.Numeric `json:"reasoningTokenPrice"`
+	ImageTokenPrice       pgtype.Numeric `json:"imageTokenPrice"`
+	TokenUnitSize         int32          `json:"tokenUnitSize"`
+	ActiveFromDate        pgtype.Date    `json:"activeFromDate"`
+	ActiveToDate          pgtype.Date    `json:"activeToDate"`
+	ProjectID             pgtype.UUID    `json:"projectId"`
+}
+
+func (q *Queries) RetrieveActiveProviderModelPricings(ctx context.Context, projectID pgtype.UUID) ([]RetrieveActiveProviderModelPricingsRow, error) {
+	rows, err := q.db.Query(ctx, retrieveActiveProviderModelPricings, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RetrieveActiveProviderModelPricingsRow
+	for rows.Next() {
+		var i RetrieveActiveProviderModelPricingsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ModelType,
+			&i.ModelVendor,
+			&i.InputTokenPrice,
+			&i.OutputTokenPrice,
+			&i.CachedInputTokenPrice,
+			&i.ReasoningTokenPrice,
+			&i.ImageTokenPrice,
+			&i.TokenUnitSize,
+			&i.ActiveFromDate,
+			&i.ActiveToDate,
+			&i.ProjectID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const retrieveOverlappingProviderModelPricings = `-- name: RetrieveOverlappingProviderModelPricings :many
+SELECT id, model_type, model_vendor, input_token_price, output_token_price, cached_input_token_price, reasoning_token_price, image_token_price, token_unit_size, created_at, active_from_date, active_to_date, project_id
+FROM provider_model_pricing
+WHERE
+    model_type = $1
+    AND model_vendor = $2
+    AND project_id IS NOT DISTINCT FROM $3::uuid
+    AND ($4::uuid IS NULL OR id <> $4::uuid)
+    AND active_from_date <= COALESCE($5::date, 'infinity'::date)
+    AND COALESCE(active_to_date, 'infinity'::date) >= $6::date
+`
+
+type RetrieveOverlappingProviderModelPricingsParams struct {
+	ModelType      ModelType   `json:"modelType"`
+	ModelVendor    ModelVendor `json:"modelVendor"`
+	ProjectID      pgtype.UUID `json:"projectId"`
+	ExcludedID     pgtype.UUID `json:"excludedId"`
+	ActiveToDate   pgtype.Date `json:"activeToDate"`
+	ActiveFromDate pgtype.Date `json:"activeFromDate"`
+}
+
+func (q *Queries) RetrieveOverlappingProviderModelPricings(ctx context.Context, arg RetrieveOverlappingProviderModelPricingsParams) ([]ProviderModelPricing, error) {
+	rows, err := q.db.Query(ctx, retrieveOverlappingProviderModelPricings,
+		arg.ModelType,
+		arg.ModelVendor,
+		arg.ProjectID,
+		arg.ExcludedID,
+		arg.ActiveToDate,
+		arg.ActiveFromDate,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ProviderModelPricing
+	for rows.Next() {
+		var i ProviderModelPricing
+		if err := rows.Scan(
+			&i.ID,
+			&i.ModelType,
+			&i.ModelVendor,
+			&i.InputTokenPrice,
+			&i.OutputTokenPrice,
+			&i.CachedInputTokenPrice,
+			&i.ReasoningTokenPrice,
+			&i.ImageTokenPrice,
+			&i.TokenUnitSize,
+			&i.CreatedAt,
+			&i.ActiveFromDate,
+			&i.ActiveToDate,
+			&i.ProjectID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const retrieveProviderModelPricing = `-- name: RetrieveProviderModelPricing :one
+SELECT id, model_type, model_vendor, input_token_price, output_token_price, cached_input_token_price, reasoning_token_price, image_token_price, token_unit_size, created_at, active_from_date, active_to_date, project_id
+FROM provider_model_pricing
+WHERE id = $1
+`
+
+func (q *Queries) RetrieveProviderModelPricing(ctx context.Context, id pgtype.UUID) (ProviderModelPricing, error) {
+	row := q.db.QueryRow(ctx, retrieveProviderModelPricing, id)
+	var i ProviderModelPricing
+	err := row.Scan(
+		&i.ID,
+		&i.ModelType,
+		&i.ModelVendor,
+		&i.InputTokenPrice,
+		&i.OutputTokenPrice,
+		&i.CachedInputTokenPrice,
+		&i.ReasoningTokenPrice,
+		&i.ImageTokenPrice,
+		&i.TokenUnitSize,
+		&i.CreatedAt,
+		&i.ActiveFromDate,
+		&i.ActiveToDate,
+		&i.ProjectID,
+	)
+	return i, err
+}
+
+const retrieveProviderModelPricings = `-- name: RetrieveProviderModelPricings :many
+SELECT id, model_type, model_vendor, input_token_price, output_token_price, cached_input_token_price, reasoning_token_price, image_token_price, token_unit_size, created_at, active_from_date, active_to_date, project_id
+FROM provider_model_pricing
+WHERE
+    ($1::uuid IS NULL OR project_id = $1::uuid)
+ORDER BY model_vendor, model_type, project_id NULLS FIRST, active_from_date DESC
+`
+
+func (q *Queries) RetrieveProviderModelPricings(ctx context.Context, projectID pgtype.UUID) ([]ProviderModelPricing, error) {
+	rows, err := q.db.Query(ctx, retrieveProviderModelPricings, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ProviderModelPricing
+	for rows.Next() {
+		var i ProviderModelPricing
+		if err := rows.Scan(
+			&i.ID,
+			&i.ModelType,
+			&i.ModelVendor,
+			&i.InputTokenPrice,
+			&i.OutputTokenPrice,
+			&i.CachedInputTokenPrice,
+			&i.ReasoningTokenPrice,
+			&i.ImageTokenPrice,
+			&i.TokenUnitSize,
+			&i.CreatedAt,
+			&i.ActiveFromDate,
+			&i.ActiveToDate,
+			&i.ProjectID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateProviderModelPricingActiveToDate = `-- name: UpdateProviderModelPricingActiveToDate :one
+UPDATE provider_model_pricing
+SET active_to_date = $2
+WHERE id = $1
+RETURNING id, model_type, model_vendor, input_token_price, output_token_price, cached_input_token_price, reasoning_token_price, image_token_price, token_unit_size, created_at, active_from_date, active_to_date, project_id
+`
+
+type UpdateProviderModelPricingActiveToDateParams struct {
+	ID           pgtype.UUID `json:"id"`
+	ActiveToDate pgtype.Date `json:"activeToDate"`
+}
+
+func (q *Queries) UpdateProviderModelPricingActiveToDate(ctx context.Context, arg UpdateProviderModelPricingActiveToDateParams) (ProviderModelPricing, error) {
+	row := q.db.QueryRow(ctx, updateProviderModelPricingActiveToDate, arg.ID, arg.ActiveToDate)
+	var i ProviderModelPricing
+	err := row.Scan(
+		&i.ID,
+		&i.ModelType,
+		&i.ModelVendor,
+		&i.InputTokenPrice,
+		&i.OutputTokenPrice,
+		&i.CachedInputTokenPrice,
+		&i.ReasoningTokenPrice,
+		&i.ImageTokenPrice,
+		&i.TokenUnitSize,
+		&i.CreatedAt,
+		&i.ActiveFromDate,
+		&i.ActiveToDate,
+		&i.ProjectID,
+	)
+	return i, err
+}
@@ -18,6 +18,15 @@ var (
 		string(ModelTypeCommandNightly),
 		string(ModelTypeCommandLightNightly),
 	}
+	openAIEmbeddingModels = []string{
+		string(ModelTypeTextEmbedding3Small),
+		string(ModelTypeTextEmbedding3Large),
+		string(ModelTypeTextEmbeddingAda002),
+	}
+	cohereEmbeddingModels = []string{
+		string(ModelTypeEmbedEnglishV30),
+		string(ModelTypeEmbedMultilingualV30),
+	}
 )
 
 func ValidateModelType(vendor ModelVendor, modelType ModelType) error {
@@ -30,3 +39,15 @@ func ValidateModelType(vendor ModelVendor, modelType ModelType) error {
 
 	return fmt.Errorf("unsupported model type {%s}", modelType)
 }
+
+// ValidateEmbeddingModelType validates that the model type is an embedding model of the vendor.
+func ValidateEmbeddingModelType(vendor ModelVendor, modelType ModelType) error {
+	if vendor == ModelVendorOPENAI && slices.Contains(openAIEmbeddingModels, string(modelType)) {
+		return nil
+	}
+	if vendor == ModelVendorCOHERE && slices.Contains(cohereEmbeddingModels, string(modelType)) {
+		return nil
+	}
+
+	return fmt.Errorf("unsupported embedding model type {%s}", modelType)
+}
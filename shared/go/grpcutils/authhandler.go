@@ -52,5 +52,19 @@ func (handler *AuthHandler) HandleAuth(ctx context.Context) (context.Context, er
 		)
 	}
 	applicationIDContext := context.WithValue(ctx, ApplicationIDContextKey, ids.ApplicationID)
-	return context.WithValue(applicationIDContext, ProjectIDContextKey, ids.ProjectID), nil
+	projectIDContext := context.WithValue(applicationIDContext, ProjectIDContextKey, ids.ProjectID)
+
+	if ids.IsInternal {
+		// internal API keys are used by the dashboard backend, and are not rate limited
+		return projectIDContext, nil
+	}
+
+	return context.WithValue(projectIDContext, RateLimitsContextKey, RateLimits{
+		ApplicationID:                db.UUIDToString(&ids.ApplicationID),
+		APIKeyID:                     sub,
+		ApplicationRequestsPerMinute: ids.ApplicationRequestsPerMinute.Int32,
+		ApplicationTokensPerMinute:   ids.ApplicationTokensPerMinute.Int32,
+		APIKeyRequestsPerMinute:      ids.ApiKeyRequestsPerMinute,
+		APIKeyTokensPerMinute:        ids.ApiKeyTokensPerMinute,
+	}), nil
 }
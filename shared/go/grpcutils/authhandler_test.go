@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"github.com/basemind-ai/monorepo/e2e/factories"
 	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/exc"
 	"github.com/basemind-ai/monorepo/shared/go/grpcutils"
 	"github.com/basemind-ai/monorepo/shared/go/jwtutils"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -41,6 +43,51 @@ func TestAuthHandler(t *testing.T) {
 			projectContextValue, ok := newCtx.Value(grpcutils.ProjectIDContextKey).(pgtype.UUID)
 			assert.True(t, ok)
 			assert.Equal(t, projectContextValue, project.ID)
+
+			_, ok = newCtx.Value(grpcutils.RateLimitsContextKey).(grpcutils.RateLimits)
+			assert.False(t, ok)
+		})
+
+		t.Run("sets the rate limits in context for a non internal apiKey", func(t *testing.T) {
+			limitedApplication := exc.MustResult(db.GetQueries().
+				CreateApplication(context.TODO(), models.CreateApplicationParams{
+					ProjectID:                  project.ID,
+					Name:                       "limited application",
+					RateLimitRequestsPerMinute: 100,
+					RateLimitTokensPerMinute:   10000,
+				}))
+			limitedAPIKey := exc.MustResult(db.GetQueries().
+				CreateAPIKey(context.TODO(), models.CreateAPIKeyParams{
+					ApplicationID:              limitedApplication.ID,
+					Name:                       "limited apiKey",
+					RateLimitRequestsPerMinute: 10,
+				}))
+			limitedAPIKeyID := db.UUIDToString(&limitedAPIKey.ID)
+
+			encodedToken, apiKeyErr := jwtutils.CreateJWT(
+				5*time.Minute,
+				[]byte(secret),
+				limitedAPIKeyID,
+			)
+			assert.NoError(t, apiKeyErr)
+
+			handler := grpcutils.NewAuthHandler(secret)
+			ctx := metadata.NewIncomingContext(
+				context.TODO(),
+				metadata.Pairs("authorization", fmt.Sprintf("bearer %s", encodedToken)),
+			)
+			newCtx, err := handler.HandleAuth(ctx)
+			assert.NoError(t, err)
+
+			rateLimits, ok := newCtx.Value(grpcutils.RateLimitsContextKey).(grpcutils.RateLimits)
+			assert.True(t, ok)
+			assert.Equal(t, grpcutils.RateLimits{
+				ApplicationID:                db.UUIDToString(&limitedApplication.ID),
+				APIKeyID:                     limitedAPIKeyID,
+				ApplicationRequestsPerMinute: 100,
+				ApplicationTokensPerMinute:   10000,
+				APIKeyRequestsPerMinute:      10,
+			}, rateLimits)
 		})
 
 		t.Run("returns unauthenticated status for missing bearer metadata", func(t *testing.T) {
@@ -6,4 +6,6 @@ const (
 	// ApplicationIDContextKey is the key used to store the application id in the context.
 	ApplicationIDContextKey contextKeyType = iota
 	ProjectIDContextKey     contextKeyType = iota
+	// RateLimitsContextKey is the key used to store the RateLimits of the request API key in the context.
+	RateLimitsContextKey contextKeyType = iota
 )
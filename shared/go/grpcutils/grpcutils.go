@@ -6,6 +6,7 @@ import (
 	"crypto/x509"
 	"fmt"
 	"github.com/basemind-ai/monorepo/shared/go/exc"
+	"github.com/basemind-ai/monorepo/shared/go/metrics"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/health"
@@ -17,6 +18,7 @@ import (
 	loggingmiddleware "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/recovery"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -71,10 +73,12 @@ type Options struct {
 	Environment string
 	// ServiceRegistrars is a list of functions that register services with the grpc server.
 	ServiceRegistrars []ServiceRegistrar
-	// ServiceName is the name of the service. Used for logging.
+	// ServiceName is the name of the service. Used for logging and metrics.
 	ServiceName string
 	// AuthHandler is the auth handler function for the service.
 	AuthHandler auth.AuthFunc
+	// RateLimiter enforces the rate limits set in the context by the AuthHandler. Optional.
+	RateLimiter *RateLimiter
 }
 
 // RecoveryHandler is a handler for the grpc recovery interceptor.
@@ -90,17 +94,39 @@ func CreateGRPCServer(opts Options, serverOpts ...grpc.ServerOption) *grpc.Serve
 			opts.Environment != "production",
 		)
 
+		unaryInterceptors := []grpc.UnaryServerInterceptor{
+			metrics.UnaryServerInterceptor(opts.ServiceName),
+			loggingmiddleware.UnaryServerInterceptor(interceptorLogger, loggingOptions...),
+			auth.UnaryServerInterceptor(opts.AuthHandler),
+		}
+		streamInterceptors := []grpc.StreamServerInterceptor{
+			metrics.StreamServerInterceptor(opts.ServiceName),
+			loggingmiddleware.StreamServerInterceptor(interceptorLogger, loggingOptions...),
+			auth.StreamServerInterceptor(opts.AuthHandler),
+		}
+
+		if opts.RateLimiter != nil {
+			unaryInterceptors = append(unaryInterceptors, opts.RateLimiter.UnaryServerInterceptor())
+			streamInterceptors = append(
+				streamInterceptors,
+				opts.RateLimiter.StreamServerInterceptor(),
+			)
+		}
+
 		serverOpts = append(
 			serverOpts,
+			grpc.StatsHandler(otelgrpc.NewServerHandler()),
 			grpc.ChainUnaryInterceptor(
-				loggingmiddleware.UnaryServerInterceptor(interceptorLogger, loggingOptions...),
-				auth.UnaryServerInterceptor(opts.AuthHandler),
-				recovery.UnaryServerInterceptor(recovery.WithRecoveryHandler(RecoveryHandler)),
+				append(
+					unaryInterceptors,
+					recovery.UnaryServerInterceptor(recovery.WithRecoveryHandler(RecoveryHandler)),
+				)...,
 			),
 			grpc.ChainStreamInterceptor(
-				loggingmiddleware.StreamServerInterceptor(interceptorLogger, loggingOptions...),
-				auth.StreamServerInterceptor(opts.AuthHandler),
-				recovery.StreamServerInterceptor(recovery.WithRecoveryHandler(RecoveryHandler)),
+				append(
+					streamInterceptors,
+					recovery.StreamServerInterceptor(recovery.WithRecoveryHandler(RecoveryHandler)),
+				)...,
 			),
 		)
 	}
@@ -120,8 +146,13 @@ func CreateGRPCServer(opts Options, serverOpts ...grpc.ServerOption) *grpc.Serve
 // NewConnection creates a new grpc connection.
 // if the GRPC_USE_TLS environment variable is set, a TLS connection is used.
 // otherwise, an insecure connection is used.
+// Calls are traced, and the trace context is propagated to the server in the request metadata.
 func NewConnection(host string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
-	opts = append(opts, grpc.WithAuthority(host))
+	opts = append(
+		opts,
+		grpc.WithAuthority(host),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
 
 	if os.Getenv("GRPC_USE_TLS") != "" {
 		log.Info().Msg("using TLS for gRPC connection")
@@ -0,0 +1,307 @@
+package grpcutils
+
+import (
+	"context"
+	"fmt"
+	"github.com/basemind-ai/monorepo/shared/go/exc"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"strconv"
+	"time"
+)
+
+// RetryAfterMetadataKey is the metadata key holding the number of seconds to wait before retrying a rate limited call.
+const RetryAfterMetadataKey = "retry-after"
+
+const rateLimitWindow = time.Minute
+
+// checkRateLimitsScript checks a set of sliding window limits atomically.
+// KEYS are pairs of the current and previous window keys of every limit.
+// ARGV[1] is the weight of the previous window, ARGV[2] is the window keys TTL in milliseconds,
+// followed by the limit, the required capacity and the increment of every limit.
+// Returns the (1 based) index of the first exceeded limit, or 0 if the counters were incremented.
+var checkRateLimitsScript = redis.NewScript(`
+local weight = tonumber(ARGV[1])
+local limitsCount = #KEYS / 2
+
+for i = 1, limitsCount do
+	local current = tonumber(redis.call('GET', KEYS[i * 2 - 1]) or '0')
+	local previous = tonumber(redis.call('GET', KEYS[i * 2]) or '0')
+	local limit = tonumber(ARGV[i * 3])
+	local required = tonumber(ARGV[i * 3 + 1])
+	if math.floor(previous * weight) + current + required > limit then
+		return i
+	end
+end
+
+for i = 1, limitsCount do
+	local increment = tonumber(ARGV[i * 3 + 2])
+	if increment > 0 then
+		redis.call('INCRBY', KEYS[i * 2 - 1], increment)
+		redis.call('PEXPIRE', KEYS[i * 2 - 1], ARGV[2])
+	end
+end
+
+return 0
+`)
+
+// consumeTokensScript increments the current window keys by ARGV[1] and sets their TTL to ARGV[2] milliseconds.
+var consumeTokensScript = redis.NewScript(`
+for i = 1, #KEYS do
+	redis.call('INCRBY', KEYS[i], ARGV[1])
+	redis.call('PEXPIRE', KEYS[i], ARGV[2])
+end
+
+return 0
+`)
+
+// RateLimits - the per minute request and token limits of an API key and its application. Zero means unlimited.
+type RateLimits struct {
+	ApplicationID                string
+	APIKeyID                     string
+	ApplicationRequestsPerMinute int32
+	ApplicationTokensPerMinute   int32
+	APIKeyRequestsPerMinute      int32
+	APIKeyTokensPerMinute        int32
+}
+
+// requestTokenUsage is implemented by responses that report the request tokens they consumed.
+type requestTokenUsage interface {
+	GetRequestTokens() uint32
+}
+
+// responseTokenUsage is implemented by responses that report the response tokens they consumed.
+type responseTokenUsage interface {
+	GetResponseTokens() uint32
+}
+
+// countTokens returns the tokens reported by the given response, e.g. embeddings responses report only
+// request tokens.
+func countTokens(response any) int64 {
+	var tokens int64
+
+	if usage, isUsage := response.(requestTokenUsage); isUsage {
+		tokens += int64(usage.GetRequestTokens())
+	}
+
+	if usage, isUsage := response.(responseTokenUsage); isUsage {
+		tokens += int64(usage.GetResponseTokens())
+	}
+
+	return tokens
+}
+
+type rateLimit struct {
+	scope     string
+	id        string
+	metric    string
+	limit     int32
+	required  int64
+	increment int64
+}
+
+// RateLimiter enforces RateLimits using sliding window counters stored in redis,
+// so that the limits are shared by all the replicas of a service.
+type RateLimiter struct {
+	client redis.Scripter
+	now    func() time.Time
+}
+
+// NewRateLimiter creates a new RateLimiter instance using the given redis client.
+func NewRateLimiter(client redis.Scripter) *RateLimiter {
+	return &RateLimiter{client: client, now: time.Now}
+}
+
+// windowKey returns the counter key of a limit for the given window.
+// The keys are hash tagged with the application ID, so that all the keys of a request share a cluster slot.
+func windowKey(limits RateLimits, limit rateLimit, window int64) string {
+	return fmt.Sprintf(
+		"ratelimit:{%s}:%s:%s:%s:%d",
+		limits.ApplicationID,
+		limit.scope,
+		limit.id,
+		limit.metric,
+		window,
+	)
+}
+
+// Allow checks the request and token limits and counts the request against the request limits.
+// Token limits are only checked here, the tokens are counted by ConsumeTokens once they are known.
+// Returns false and the duration to wait before retrying if a limit is exceeded.
+// Redis errors are logged and the request is allowed.
+func (l *RateLimiter) Allow(ctx context.Context, limits RateLimits) (time.Duration, bool) {
+	activeLimits := make([]rateLimit, 0, 4)
+	for _, limit := range []rateLimit{
+		{"application", limits.ApplicationID, "requests", limits.ApplicationRequestsPerMinute, 1, 1},
+		{"api-key", limits.APIKeyID, "requests", limits.APIKeyRequestsPerMinute, 1, 1},
+		{"application", limits.ApplicationID, "tokens", limits.ApplicationTokensPerMinute, 1, 0},
+		{"api-key", limits.APIKeyID, "tokens", limits.APIKeyTokensPerMinute, 1, 0},
+	} {
+		if limit.limit > 0 {
+			activeLimits = append(activeLimits, limit)
+		}
+	}
+
+	if len(activeLimits) == 0 {
+		return 0, true
+	}
+
+	now := l.now()
+	window := now.UnixMilli() / rateLimitWindow.Milliseconds()
+	elapsed := now.UnixMilli() % rateLimitWindow.Milliseconds()
+	weight := 1 - float64(elapsed)/float64(rateLimitWindow.Milliseconds())
+
+	keys := make([]string, 0, len(activeLimits)*2)
+	args := []any{
+		strconv.FormatFloat(weight, 'f', 6, 64),
+		(2 * rateLimitWindow).Milliseconds(),
+	}
+
+	for _, limit := range activeLimits {
+		keys = append(keys, windowKey(limits, limit, window), windowKey(limits, limit, window-1))
+		args = append(args, limit.limit, limit.required, limit.increment)
+	}
+
+	exceeded, err := checkRateLimitsScript.Run(ctx, l.client, keys, args...).Int()
+	if err != nil {
+		log.Error().Err(err).Msg("failed to check rate limits")
+		return 0, true
+	}
+
+	if exceeded == 0 {
+		return 0, true
+	}
+
+	return time.Duration(rateLimitWindow.Milliseconds()-elapsed) * time.Millisecond, false
+}
+
+// ConsumeTokens counts the given tokens against the token limits.
+func (l *RateLimiter) ConsumeTokens(ctx context.Context, limits RateLimits, tokens int64) {
+	if tokens <= 0 {
+		return
+	}
+
+	window := l.now().UnixMilli() / rateLimitWindow.Milliseconds()
+
+	keys := make([]string, 0, 2)
+	for _, limit := range []rateLimit{
+		{
+			scope:  "application",
+			id:     limits.ApplicationID,
+			metric: "tokens",
+			limit:  limits.ApplicationTokensPerMinute,
+		},
+		{
+			scope:  "api-key",
+			id:     limits.APIKeyID,
+			metric: "tokens",
+			limit:  limits.APIKeyTokensPerMinute,
+		},
+	} {
+		if limit.limit > 0 {
+			keys = append(keys, windowKey(limits, limit, window))
+		}
+	}
+
+	if len(keys) == 0 {
+		return
+	}
+
+	exc.LogIfErr(
+		consumeTokensScript.Run(
+			ctx,
+			l.client,
+			keys,
+			tokens,
+			(2*rateLimitWindow).Milliseconds(),
+		).Err(),
+		"failed to consume rate limit tokens",
+	)
+}
+
+// RetryAfterSeconds returns the given retry duration rounded up to whole seconds, and at least one second.
+func RetryAfterSeconds(retryAfter time.Duration) int64 {
+	return max(int64((retryAfter+time.Second-1)/time.Second), 1)
+}
+
+// retryAfterMetadata returns the metadata informing the client when to retry.
+func retryAfterMetadata(retryAfter time.Duration) metadata.MD {
+	return metadata.Pairs(
+		RetryAfterMetadataKey,
+		strconv.FormatInt(RetryAfterSeconds(retryAfter), 10),
+	)
+}
+
+// UnaryServerInterceptor returns a unary interceptor enforcing the RateLimits set in the context by the AuthHandler.
+func (l *RateLimiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		_ *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		limits, ok := ctx.Value(RateLimitsContextKey).(RateLimits)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		if retryAfter, allowed := l.Allow(ctx, limits); !allowed {
+			exc.LogIfErr(
+				grpc.SetHeader(ctx, retryAfterMetadata(retryAfter)),
+				"failed to set retry-after header",
+			)
+			return nil, status.Error(codes.ResourceExhausted, "rate limit exceeded")
+		}
+
+		response, err := handler(ctx, req)
+		l.ConsumeTokens(ctx, limits, countTokens(response))
+
+		return response, err
+	}
+}
+
+// tokenCountingStream counts the tokens reported by the messages sent on a server stream.
+type tokenCountingStream struct {
+	grpc.ServerStream
+	tokens int64
+}
+
+func (s *tokenCountingStream) SendMsg(m any) error {
+	s.tokens += countTokens(m)
+	return s.ServerStream.SendMsg(m)
+}
+
+// StreamServerInterceptor returns a stream interceptor enforcing the RateLimits set in the context by the AuthHandler.
+func (l *RateLimiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		_ *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx := ss.Context()
+
+		limits, ok := ctx.Value(RateLimitsContextKey).(RateLimits)
+		if !ok {
+			return handler(srv, ss)
+		}
+
+		if retryAfter, allowed := l.Allow(ctx, limits); !allowed {
+			exc.LogIfErr(
+				ss.SetHeader(retryAfterMetadata(retryAfter)),
+				"failed to set retry-after header",
+			)
+			return status.Error(codes.ResourceExhausted, "rate limit exceeded")
+		}
+
+		countingStream := &tokenCountingStream{ServerStream: ss}
+		err := handler(srv, countingStream)
+		l.ConsumeTokens(ctx, limits, countingStream.tokens)
+
+		return err
+	}
+}
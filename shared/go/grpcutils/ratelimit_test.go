@@ -0,0 +1,319 @@
+package grpcutils_test
+
+import (
+	"context"
+	"errors"
+	"github.com/basemind-ai/monorepo/gen/go/gateway/v1"
+	"github.com/basemind-ai/monorepo/shared/go/grpcutils"
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// scriptSHAPattern matches the sha of the rate limiter scripts, which are unexported.
+const scriptSHAPattern = "^[0-9a-f]{40}$"
+
+type mockTransportStream struct {
+	header metadata.MD
+}
+
+func (m *mockTransportStream) Method() string {
+	return "/gateway.v1.APIGatewayService/RequestPrompt"
+}
+
+func (m *mockTransportStream) SetHeader(md metadata.MD) error {
+	m.header = metadata.Join(m.header, md)
+	return nil
+}
+
+func (m *mockTransportStream) SendHeader(md metadata.MD) error {
+	return m.SetHeader(md)
+}
+
+func (m *mockTransportStream) SetTrailer(metadata.MD) error {
+	return nil
+}
+
+type mockServerStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	header metadata.MD
+	sent   []any
+}
+
+func (m *mockServerStream) Context() context.Context {
+	return m.ctx
+}
+
+func (m *mockServerStream) SetHeader(md metadata.MD) error {
+	m.header = metadata.Join(m.header, md)
+	return nil
+}
+
+func (m *mockServerStream) SendMsg(msg any) error {
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+func windowKeyPattern(scope, id, metric string) string {
+	return "^ratelimit:\\{application-id\\}:" + scope + ":" + id + ":" + metric + ":\\d+$"
+}
+
+func TestRateLimiter(t *testing.T) { //nolint: revive
+	limits := grpcutils.RateLimits{
+		ApplicationID:           "application-id",
+		APIKeyID:                "api-key-id",
+		APIKeyRequestsPerMinute: 10,
+		APIKeyTokensPerMinute:   100,
+	}
+	limitsCtx := context.WithValue(context.TODO(), grpcutils.RateLimitsContextKey, limits)
+
+	expectCheck := func(mockRedis redismock.ClientMock) *redismock.ExpectedCmd {
+		return mockRedis.Regexp().ExpectEvalSha(
+			scriptSHAPattern,
+			[]string{
+				windowKeyPattern("api-key", "api-key-id", "requests"),
+				windowKeyPattern("api-key", "api-key-id", "requests"),
+				windowKeyPattern("api-key", "api-key-id", "tokens"),
+				windowKeyPattern("api-key", "api-key-id", "tokens"),
+			},
+			"^[01]\\.\\d{6}$",
+			int64(120000),
+			int32(10), int64(1), int64(1),
+			int32(100), int64(1), int64(0),
+		)
+	}
+
+	expectConsume := func(mockRedis redismock.ClientMock, tokens int64) *redismock.ExpectedCmd {
+		return mockRedis.Regexp().ExpectEvalSha(
+			scriptSHAPattern,
+			[]string{windowKeyPattern("api-key", "api-key-id", "tokens")},
+			tokens,
+			int64(120000),
+		)
+	}
+
+	t.Run("RetryAfterSeconds", func(t *testing.T) {
+		assert.Equal(t, int64(1), grpcutils.RetryAfterSeconds(0))
+		assert.Equal(t, int64(1), grpcutils.RetryAfterSeconds(time.Millisecond))
+		assert.Equal(t, int64(2), grpcutils.RetryAfterSeconds(time.Second+time.Millisecond))
+		assert.Equal(t, int64(60), grpcutils.RetryAfterSeconds(time.Minute))
+	})
+
+	t.Run("Allow", func(t *testing.T) {
+		t.Run("allows requests without limits", func(t *testing.T) {
+			redisClient, mockRedis := redismock.NewClientMock()
+			rateLimiter := grpcutils.NewRateLimiter(redisClient)
+
+			_, allowed := rateLimiter.Allow(
+				context.TODO(),
+				grpcutils.RateLimits{ApplicationID: "application-id", APIKeyID: "api-key-id"},
+			)
+			assert.True(t, allowed)
+			assert.NoError(t, mockRedis.ExpectationsWereMet())
+		})
+
+		t.Run("allows requests within the limits", func(t *testing.T) {
+			redisClient, mockRedis := redismock.NewClientMock()
+			rateLimiter := grpcutils.NewRateLimiter(redisClient)
+			expectCheck(mockRedis).SetVal(int64(0))
+
+			_, allowed := rateLimiter.Allow(context.TODO(), limits)
+			assert.True(t, allowed)
+			assert.NoError(t, mockRedis.ExpectationsWereMet())
+		})
+
+		t.Run("rejects requests exceeding a limit", func(t *testing.T) {
+			redisClient, mockRedis := redismock.NewClientMock()
+			rateLimiter := grpcutils.NewRateLimiter(redisClient)
+			expectCheck(mockRedis).SetVal(int64(1))
+
+			retryAfter, allowed := rateLimiter.Allow(context.TODO(), limits)
+			assert.False(t, allowed)
+			assert.Greater(t, retryAfter, time.Duration(0))
+			assert.LessOrEqual(t, retryAfter, time.Minute)
+			assert.NoError(t, mockRedis.ExpectationsWereMet())
+		})
+
+		t.Run("allows requests when redis fails", func(t *testing.T) {
+			redisClient, mockRedis := redismock.NewClientMock()
+			rateLimiter := grpcutils.NewRateLimiter(redisClient)
+			expectCheck(mockRedis).SetErr(errors.New("redis error"))
+
+			_, allowed := rateLimiter.Allow(context.TODO(), limits)
+			assert.True(t, allowed)
+			assert.NoError(t, mockRedis.ExpectationsWereMet())
+		})
+	})
+
+	t.Run("ConsumeTokens", func(t *testing.T) {
+		t.Run("counts the tokens against the token limits", func(t *testing.T) {
+			redisClient, mockRedis := redismock.NewClientMock()
+			rateLimiter := grpcutils.NewRateLimiter(redisClient)
+			expectConsume(mockRedis, 30).SetVal(int64(0))
+
+			rateLimiter.ConsumeTokens(context.TODO(), limits, 30)
+			assert.NoError(t, mockRedis.ExpectationsWereMet())
+		})
+
+		t.Run("does nothing without token limits", func(t *testing.T) {
+			redisClient, mockRedis := redismock.NewClientMock()
+			rateLimiter := grpcutils.NewRateLimiter(redisClient)
+
+			rateLimiter.ConsumeTokens(
+				context.TODO(),
+				grpcutils.RateLimits{ApplicationID: "application-id", APIKeyID: "api-key-id"},
+				30,
+			)
+			assert.NoError(t, mockRedis.ExpectationsWereMet())
+		})
+	})
+
+	t.Run("UnaryServerInterceptor", func(t *testing.T) {
+		t.Run("calls the handler when no limits are set in the context", func(t *testing.T) {
+			redisClient, mockRedis := redismock.NewClientMock()
+			interceptor := grpcutils.NewRateLimiter(redisClient).UnaryServerInterceptor()
+
+			response, err := interceptor(
+				context.TODO(),
+				nil,
+				&grpc.UnaryServerInfo{},
+				func(context.Context, any) (any, error) {
+					return "response", nil
+				},
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, "response", response)
+			assert.NoError(t, mockRedis.ExpectationsWereMet())
+		})
+
+		t.Run("consumes the tokens of the response", func(t *testing.T) {
+			redisClient, mockRedis := redismock.NewClientMock()
+			interceptor := grpcutils.NewRateLimiter(redisClient).UnaryServerInterceptor()
+			expectCheck(mockRedis).SetVal(int64(0))
+			expectConsume(mockRedis, 30).SetVal(int64(0))
+
+			_, err := interceptor(
+				limitsCtx,
+				nil,
+				&grpc.UnaryServerInfo{},
+				func(context.Context, any) (any, error) {
+					return &gateway.PromptResponse{RequestTokens: 10, ResponseTokens: 20}, nil
+				},
+			)
+			assert.NoError(t, err)
+			assert.NoError(t, mockRedis.ExpectationsWereMet())
+		})
+
+		t.Run("consumes the request tokens of an embeddings response", func(t *testing.T) {
+			redisClient, mockRedis := redismock.NewClientMock()
+			interceptor := grpcutils.NewRateLimiter(redisClient).UnaryServerInterceptor()
+			expectCheck(mockRedis).SetVal(int64(0))
+			expectConsume(mockRedis, 15).SetVal(int64(0))
+
+			_, err := interceptor(
+				limitsCtx,
+				nil,
+				&grpc.UnaryServerInfo{},
+				func(context.Context, any) (any, error) {
+					return &gateway.EmbeddingsResponse{RequestTokens: 15}, nil
+				},
+			)
+			assert.NoError(t, err)
+			assert.NoError(t, mockRedis.ExpectationsWereMet())
+		})
+
+		t.Run(
+			"returns resource exhausted with retry-after metadata when rate limited",
+			func(t *testing.T) {
+				redisClient, mockRedis := redismock.NewClientMock()
+				interceptor := grpcutils.NewRateLimiter(redisClient).UnaryServerInterceptor()
+				expectCheck(mockRedis).SetVal(int64(1))
+
+				transportStream := &mockTransportStream{}
+				handlerCalled := false
+
+				_, err := interceptor(
+					grpc.NewContextWithServerTransportStream(limitsCtx, transportStream),
+					nil,
+					&grpc.UnaryServerInfo{},
+					func(context.Context, any) (any, error) {
+						handlerCalled = true
+						return nil, nil
+					},
+				)
+				assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+				assert.False(t, handlerCalled)
+
+				retryAfter := transportStream.header.Get(grpcutils.RetryAfterMetadataKey)
+				assert.Len(t, retryAfter, 1)
+
+				seconds, parseErr := strconv.Atoi(retryAfter[0])
+				assert.NoError(t, parseErr)
+				assert.GreaterOrEqual(t, seconds, 1)
+				assert.LessOrEqual(t, seconds, 60)
+				assert.NoError(t, mockRedis.ExpectationsWereMet())
+			},
+		)
+	})
+
+	t.Run("StreamServerInterceptor", func(t *testing.T) {
+		t.Run("consumes the tokens of the streamed messages", func(t *testing.T) {
+			redisClient, mockRedis := redismock.NewClientMock()
+			interceptor := grpcutils.NewRateLimiter(redisClient).StreamServerInterceptor()
+			expectCheck(mockRedis).SetVal(int64(0))
+			expectConsume(mockRedis, 30).SetVal(int64(0))
+
+			serverStream := &mockServerStream{ctx: limitsCtx}
+
+			err := interceptor(
+				nil,
+				serverStream,
+				&grpc.StreamServerInfo{},
+				func(_ any, stream grpc.ServerStream) error {
+					requestTokens, responseTokens := uint32(10), uint32(20)
+					assert.NoError(
+						t,
+						stream.SendMsg(&gateway.StreamingPromptResponse{Content: "a"}),
+					)
+					return stream.SendMsg(&gateway.StreamingPromptResponse{
+						RequestTokens:  &requestTokens,
+						ResponseTokens: &responseTokens,
+					})
+				},
+			)
+			assert.NoError(t, err)
+			assert.Len(t, serverStream.sent, 2)
+			assert.NoError(t, mockRedis.ExpectationsWereMet())
+		})
+
+		t.Run(
+			"returns resource exhausted with retry-after metadata when rate limited",
+			func(t *testing.T) {
+				redisClient, mockRedis := redismock.NewClientMock()
+				interceptor := grpcutils.NewRateLimiter(redisClient).StreamServerInterceptor()
+				expectCheck(mockRedis).SetVal(int64(1))
+
+				serverStream := &mockServerStream{ctx: limitsCtx}
+
+				err := interceptor(
+					nil,
+					serverStream,
+					&grpc.StreamServerInfo{},
+					func(any, grpc.ServerStream) error {
+						return nil
+					},
+				)
+				assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+				assert.Len(t, serverStream.header.Get(grpcutils.RetryAfterMetadataKey), 1)
+				assert.NoError(t, mockRedis.ExpectationsWereMet())
+			},
+		)
+	})
+}
@@ -0,0 +1,46 @@
+package grpcutils
+
+import (
+	"connectrpc.com/cors"
+	"connectrpc.com/vanguard/vanguardgrpc"
+	chicors "github.com/go-chi/cors"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
+	"net/http"
+	"slices"
+)
+
+// WebOptions is a struct that contains options for serving a grpc server to browser clients.
+type WebOptions struct {
+	// AllowedOrigins is the list of origins allowed to make cross-origin requests. Supports "*" wildcards.
+	// Cross-origin requests are rejected by the browser when empty.
+	AllowedOrigins []string
+	// AllowedHeaders is a list of additional headers browser clients can send, e.g. custom metadata headers.
+	AllowedHeaders []string
+}
+
+// CreateWebHandler creates an http handler that serves the services of the grpc server using the gRPC, gRPC-Web
+// and Connect protocols. gRPC-Web and Connect requests are transcoded into gRPC requests, and are therefore handled
+// by the interceptors of the server.
+// The handler supports HTTP/2 without TLS (h2c), so native gRPC clients and browser clients can share the same port.
+func CreateWebHandler(server *grpc.Server, opts WebOptions) (http.Handler, error) {
+	transcoder, transcoderErr := vanguardgrpc.NewTranscoder(server)
+	if transcoderErr != nil {
+		return nil, transcoderErr
+	}
+
+	corsHandler := chicors.Handler(chicors.Options{
+		AllowedOrigins: opts.AllowedOrigins,
+		AllowedMethods: cors.AllowedMethods(),
+		AllowedHeaders: slices.Concat(
+			cors.AllowedHeaders(),
+			[]string{"Authorization"},
+			opts.AllowedHeaders,
+		),
+		ExposedHeaders: cors.ExposedHeaders(),
+		MaxAge:         7200,
+	})
+
+	return h2c.NewHandler(corsHandler(transcoder), &http2.Server{}), nil
+}
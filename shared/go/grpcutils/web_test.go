@@ -0,0 +1,141 @@
+package grpcutils_test
+
+import (
+	"connectrpc.com/connect"
+	"context"
+	"github.com/basemind-ai/monorepo/shared/go/grpcutils"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWebHandler(t *testing.T) { //nolint: revive
+	server := grpcutils.CreateGRPCServer(grpcutils.Options{Environment: "test"})
+
+	handler, handlerErr := grpcutils.CreateWebHandler(server, grpcutils.WebOptions{
+		AllowedOrigins: []string{"https://app.example.com"},
+		AllowedHeaders: []string{"X-Custom-Header"},
+	})
+	assert.NoError(t, handlerErr)
+
+	testServer := httptest.NewServer(handler)
+	t.Cleanup(testServer.Close)
+
+	for protocol, clientOption := range map[string]connect.ClientOption{
+		"Connect":  connect.WithProtoJSON(),
+		"gRPC-Web": connect.WithGRPCWeb(),
+	} {
+		t.Run("handles unary requests using the "+protocol+" protocol", func(t *testing.T) {
+			client := connect.NewClient[grpc_health_v1.HealthCheckRequest, grpc_health_v1.HealthCheckResponse](
+				testServer.Client(),
+				testServer.URL+"/grpc.health.v1.Health/Check",
+				clientOption,
+			)
+
+			response, err := client.CallUnary(
+				context.TODO(),
+				connect.NewRequest(&grpc_health_v1.HealthCheckRequest{}),
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, response.Msg.GetStatus())
+		})
+
+		t.Run("handles server streaming requests using the "+protocol+" protocol", func(t *testing.T) {
+			client := connect.NewClient[grpc_health_v1.HealthCheckRequest, grpc_health_v1.HealthCheckResponse](
+				testServer.Client(),
+				testServer.URL+"/grpc.health.v1.Health/Watch",
+				clientOption,
+			)
+
+			// the watch stream does not end, hence the request is cancelled once a message is received
+			ctx, cancel := context.WithCancel(context.TODO())
+			defer cancel()
+
+			stream, err := client.CallServerStream(
+				ctx,
+				connect.NewRequest(&grpc_health_v1.HealthCheckRequest{}),
+			)
+			assert.NoError(t, err)
+
+			assert.True(t, stream.Receive())
+			assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, stream.Msg().GetStatus())
+		})
+	}
+
+	t.Run("handles requests using the gRPC protocol", func(t *testing.T) {
+		conn, dialErr := grpc.Dial(
+			strings.TrimPrefix(testServer.URL, "http://"),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		assert.NoError(t, dialErr)
+
+		defer func() {
+			_ = conn.Close()
+		}()
+
+		response, err := grpc_health_v1.NewHealthClient(conn).
+			Check(context.TODO(), &grpc_health_v1.HealthCheckRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, response.GetStatus())
+	})
+
+	t.Run("returns the status of failed requests", func(t *testing.T) {
+		client := connect.NewClient[grpc_health_v1.HealthCheckRequest, grpc_health_v1.HealthCheckResponse](
+			testServer.Client(),
+			testServer.URL+"/grpc.health.v1.Health/Check",
+			connect.WithGRPCWeb(),
+		)
+
+		_, err := client.CallUnary(
+			context.TODO(),
+			connect.NewRequest(&grpc_health_v1.HealthCheckRequest{Service: "unknown"}),
+		)
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+
+	t.Run("handles CORS preflight requests", func(t *testing.T) {
+		createPreflightRequest := func(origin string) *http.Request {
+			request := httptest.NewRequest(
+				http.MethodOptions,
+				"/grpc.health.v1.Health/Check",
+				nil,
+			)
+			request.Header.Set("Origin", origin)
+			request.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			request.Header.Set(
+				"Access-Control-Request-Headers",
+				"authorization,content-type,x-grpc-web,x-custom-header",
+			)
+
+			return request
+		}
+
+		t.Run("allows requests from the allowed origins", func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, createPreflightRequest("https://app.example.com"))
+
+			assert.Equal(
+				t,
+				"https://app.example.com",
+				recorder.Header().Get("Access-Control-Allow-Origin"),
+			)
+			assert.Equal(
+				t,
+				"authorization, content-type, x-grpc-web, x-custom-header",
+				strings.ToLower(recorder.Header().Get("Access-Control-Allow-Headers")),
+			)
+		})
+
+		t.Run("does not allow requests from other origins", func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, createPreflightRequest("https://other.example.com"))
+
+			assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Origin"))
+		})
+	})
+}
@@ -0,0 +1,299 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+// HoldTTL is the time after which a hold that was not released stops reserving credits.
+const HoldTTL = 5 * time.Minute
+
+var (
+	// ErrAlreadyDebited is returned when the cost of a request record was already debited.
+	ErrAlreadyDebited = errors.New("request record already debited")
+	// ErrInsufficientCredits is returned when the available credits of a project do not cover a hold.
+	ErrInsufficientCredits = errors.New("insufficient credits")
+)
+
+// decimalToNumeric converts a decimal to a numeric DB value.
+func decimalToNumeric(value decimal.Decimal) (pgtype.Numeric, error) {
+	numeric, conversionErr := db.StringToNumeric(value.String())
+	if conversionErr != nil {
+		return pgtype.Numeric{}, conversionErr
+	}
+
+	return *numeric, nil
+}
+
+// commitIfShouldCommit commits a transaction if it is not part of an outer transaction.
+// Unlike db.CommitIfShouldCommit it returns the commit error, since ledger writes must not panic.
+func commitIfShouldCommit(ctx context.Context, tx pgx.Tx) error {
+	if !db.ShouldCommit(ctx) {
+		return nil
+	}
+
+	if commitErr := tx.Commit(ctx); commitErr != nil {
+		return fmt.Errorf("failed to commit transaction - %w", commitErr)
+	}
+
+	return nil
+}
+
+// RequestRecordCost returns the total cost of a prompt request record.
+func RequestRecordCost(requestTokensCost, responseTokensCost pgtype.Numeric) (decimal.Decimal, error) {
+	requestCost, requestConversionErr := db.NumericToDecimal(requestTokensCost)
+	if requestConversionErr != nil {
+		return decimal.Zero, requestConversionErr
+	}
+
+	responseCost, responseConversionErr := db.NumericToDecimal(responseTokensCost)
+	if responseConversionErr != nil {
+		return decimal.Zero, responseConversionErr
+	}
+
+	return requestCost.Add(*responseCost), nil
+}
+
+// RecordDebit debits the cost of a prompt request record from the credits of its project, and records the debit in
+// the ledger. The project row is locked for the transaction, so concurrent debits are applied one after the other.
+// A request record is debited at most once - ErrAlreadyDebited is returned if it was already debited.
+// The hold with the given ID, if valid, is released in the same transaction.
+func RecordDebit(
+	ctx context.Context,
+	projectID pgtype.UUID,
+	promptRequestRecordID pgtype.UUID,
+	cost decimal.Decimal,
+	holdID pgtype.UUID,
+) (*models.CreditLedgerEntry, error) {
+	tx, txErr := db.GetOrCreateTx(ctx)
+	if txErr != nil {
+		return nil, fmt.Errorf("failed to create transaction - %w", txErr)
+	}
+
+	if db.ShouldCommit(ctx) {
+		defer db.HandleRollback(ctx, tx)
+	}
+
+	queries := db.GetQueries().WithTx(tx)
+
+	credits, lockErr := queries.RetrieveProjectCreditsForUpdate(ctx, projectID)
+	if lockErr != nil {
+		return nil, fmt.Errorf("failed to retrieve project credits - %w", lockErr)
+	}
+
+	balance, conversionErr := db.NumericToDecimal(credits)
+	if conversionErr != nil {
+		return nil, conversionErr
+	}
+
+	if holdID.Valid {
+		if releaseErr := queries.DeleteCreditHold(ctx, holdID); releaseErr != nil {
+			return nil, fmt.Errorf("failed to release credit hold - %w", releaseErr)
+		}
+	}
+
+	amount, amountErr := decimalToNumeric(cost.Neg())
+	if amountErr != nil {
+		return nil, amountErr
+	}
+
+	newBalance := balance.Sub(cost)
+
+	balanceAfter, balanceErr := decimalToNumeric(newBalance)
+	if balanceErr != nil {
+		return nil, balanceErr
+	}
+
+	entry, createErr := queries.CreateCreditLedgerEntry(ctx, models.CreateCreditLedgerEntryParams{
+		ProjectID:             projectID,
+		PromptRequestRecordID: promptRequestRecordID,
+		EntryType:             models.CreditLedgerEntryTypeDEBIT,
+		Amount:                amount,
+		BalanceAfter:          balanceAfter,
+	})
+	if createErr != nil {
+		if !errors.Is(createErr, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("failed to create ledger entry - %w", createErr)
+		}
+
+		// the hold is released even if the request record was already debited
+		if commitErr := commitIfShouldCommit(ctx, tx); commitErr != nil {
+			return nil, commitErr
+		}
+
+		return nil, ErrAlreadyDebited
+	}
+
+	if updateErr := queries.SetProjectCredits(ctx, models.SetProjectCreditsParams{
+		ID:      projectID,
+		Credits: balanceAfter,
+	}); updateErr != nil {
+		return nil, fmt.Errorf("failed to update project credits - %w", updateErr)
+	}
+
+	if commitErr := commitIfShouldCommit(ctx, tx); commitErr != nil {
+		return nil, commitErr
+	}
+
+	return &entry, nil
+}
+
+// RecordGrant adds the given amount to the credits of a project, and records the grant in the ledger.
+// The user account ID is the ID of the user who granted the credits, and the payment reference identifies
+// the payment of a top-up - both may be empty.
+func RecordGrant(
+	ctx context.Context,
+	projectID pgtype.UUID,
+	userAccountID pgtype.UUID,
+	amount decimal.Decimal,
+	description string,
+	paymentReference string,
+) (*models.CreditLedgerEntry, error) {
+	tx, txErr := db.GetOrCreateTx(ctx)
+	if txErr != nil {
+		return nil, fmt.Errorf("failed to create transaction - %w", txErr)
+	}
+
+	if db.ShouldCommit(ctx) {
+		defer db.HandleRollback(ctx, tx)
+	}
+
+	queries := db.GetQueries().WithTx(tx)
+
+	credits, lockErr := queries.RetrieveProjectCreditsForUpdate(ctx, projectID)
+	if lockErr != nil {
+		return nil, fmt.Errorf("failed to retrieve project credits - %w", lockErr)
+	}
+
+	balance, conversionErr := db.NumericToDecimal(credits)
+	if conversionErr != nil {
+		return nil, conversionErr
+	}
+
+	numericAmount, amountErr := decimalToNumeric(amount)
+	if amountErr != nil {
+		return nil, amountErr
+	}
+
+	balanceAfter, balanceErr := decimalToNumeric(balance.Add(amount))
+	if balanceErr != nil {
+		return nil, balanceErr
+	}
+
+	entry, createErr := queries.CreateCreditGrantLedgerEntry(ctx, models.CreateCreditGrantLedgerEntryParams{
+		ProjectID:        projectID,
+		UserAccountID:    userAccountID,
+		Amount:           numericAmount,
+		BalanceAfter:     balanceAfter,
+		Description:      description,
+		PaymentReference: paymentReference,
+	})
+	if createErr != nil {
+		return nil, fmt.Errorf("failed to create ledger entry - %w", createErr)
+	}
+
+	if updateErr := queries.SetProjectCredits(ctx, models.SetProjectCreditsParams{
+		ID:      projectID,
+		Credits: balanceAfter,
+	}); updateErr != nil {
+		return nil, fmt.Errorf("failed to update project credits - %w", updateErr)
+	}
+
+	if commitErr := commitIfShouldCommit(ctx, tx); commitErr != nil {
+		return nil, commitErr
+	}
+
+	return &entry, nil
+}
+
+// CreateHold reserves the given amount from the available credits of a project - its credits minus its active holds.
+// Returns ErrInsufficientCredits if the available credits do not cover the amount.
+// The hold is released by the debit of the request, or by ReleaseHold, and expires after HoldTTL.
+func CreateHold(
+	ctx context.Context,
+	projectID pgtype.UUID,
+	amount decimal.Decimal,
+) (*models.CreditHold, error) {
+	tx, txErr := db.GetOrCreateTx(ctx)
+	if txErr != nil {
+		return nil, fmt.Errorf("failed to create transaction - %w", txErr)
+	}
+
+	if db.ShouldCommit(ctx) {
+		defer db.HandleRollback(ctx, tx)
+	}
+
+	queries := db.GetQueries().WithTx(tx)
+
+	credits, lockErr := queries.RetrieveProjectCreditsForUpdate(ctx, projectID)
+	if lockErr != nil {
+		return nil, fmt.Errorf("failed to retrieve project credits - %w", lockErr)
+	}
+
+	heldTotal, holdsErr := queries.RetrieveProjectActiveCreditHoldsTotal(ctx, projectID)
+	if holdsErr != nil {
+		return nil, fmt.Errorf("failed to retrieve credit holds - %w", holdsErr)
+	}
+
+	balance, balanceErr := db.NumericToDecimal(credits)
+	if balanceErr != nil {
+		return nil, balanceErr
+	}
+
+	held, heldErr := db.NumericToDecimal(heldTotal)
+	if heldErr != nil {
+		return nil, heldErr
+	}
+
+	available := balance.Sub(*held)
+	if !available.IsPositive() || available.LessThan(amount) {
+		return nil, ErrInsufficientCredits
+	}
+
+	numericAmount, amountErr := decimalToNumeric(amount)
+	if amountErr != nil {
+		return nil, amountErr
+	}
+
+	hold, createErr := queries.CreateCreditHold(ctx, models.CreateCreditHoldParams{
+		ProjectID: projectID,
+		Amount:    numericAmount,
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(HoldTTL), Valid: true},
+	})
+	if createErr != nil {
+		return nil, fmt.Errorf("failed to create credit hold - %w", createErr)
+	}
+
+	if commitErr := commitIfShouldCommit(ctx, tx); commitErr != nil {
+		return nil, commitErr
+	}
+
+	return &hold, nil
+}
+
+// ReleaseHold releases a hold without a debit, e.g. when the request failed.
+// Releasing a hold that was already released is a no-op.
+func ReleaseHold(ctx context.Context, holdID pgtype.UUID) error {
+	if releaseErr := db.GetQueries().DeleteCreditHold(ctx, holdID); releaseErr != nil {
+		return fmt.Errorf("failed to release credit hold - %w", releaseErr)
+	}
+
+	return nil
+}
+
+// DeleteExpiredHolds deletes the holds that expired without being released.
+func DeleteExpiredHolds(ctx context.Context) (int64, error) {
+	deletedRows, deleteErr := db.GetQueries().DeleteExpiredCreditHolds(ctx)
+	if deleteErr != nil {
+		return 0, fmt.Errorf("failed to delete expired credit holds - %w", deleteErr)
+	}
+
+	return deletedRows, nil
+}
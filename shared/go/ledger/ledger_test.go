@@ -0,0 +1,319 @@
+package ledger_test
+
+import (
+	"context"
+	"github.com/basemind-ai/monorepo/e2e/factories"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/basemind-ai/monorepo/shared/go/exc"
+	"github.com/basemind-ai/monorepo/shared/go/ledger"
+	"github.com/basemind-ai/monorepo/shared/go/testutils"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	cleanup := testutils.CreateNamespaceTestDBModule("ledger-test")
+	defer cleanup()
+	m.Run()
+}
+
+func retrieveCredits(t *testing.T, projectID pgtype.UUID) decimal.Decimal {
+	t.Helper()
+
+	project, retrievalErr := db.GetQueries().RetrieveProject(context.TODO(), projectID)
+	assert.NoError(t, retrievalErr)
+
+	return *exc.MustResult(db.NumericToDecimal(project.Credits))
+}
+
+// createRequestRecord creates a request record costing 0.0000465 credits.
+func createRequestRecord(t *testing.T, projectID pgtype.UUID) *models.PromptRequestRecord {
+	t.Helper()
+
+	application, _ := factories.CreateApplication(context.TODO(), projectID)
+	promptConfig, _ := factories.CreateOpenAIPromptConfig(context.TODO(), application.ID)
+	requestRecord, createErr := factories.CreatePromptRequestRecord(context.TODO(), promptConfig.ID)
+	assert.NoError(t, createErr)
+
+	return requestRecord
+}
+
+func openLedger(t *testing.T, projectID pgtype.UUID) {
+	t.Helper()
+
+	credits := exc.MustResult(db.StringToNumeric(retrieveCredits(t, projectID).String()))
+	_, createErr := db.GetQueries().CreateCreditLedgerEntry(
+		context.TODO(),
+		models.CreateCreditLedgerEntryParams{
+			ProjectID:    projectID,
+			EntryType:    models.CreditLedgerEntryTypeADJUSTMENT,
+			Amount:       *credits,
+			BalanceAfter: *credits,
+			Description:  "opening balance",
+		},
+	)
+	assert.NoError(t, createErr)
+}
+
+func TestLedger(t *testing.T) { //nolint: revive
+	cost := decimal.RequireFromString("0.0000465")
+
+	t.Run("RequestRecordCost", func(t *testing.T) {
+		project, _ := factories.CreateProject(context.TODO())
+		requestRecord := createRequestRecord(t, project.ID)
+
+		recordCost, costErr := ledger.RequestRecordCost(
+			requestRecord.RequestTokensCost,
+			requestRecord.ResponseTokensCost,
+		)
+		assert.NoError(t, costErr)
+		assert.True(t, cost.Equal(recordCost))
+	})
+
+	t.Run("RecordDebit", func(t *testing.T) {
+		t.Run("debits the credits and records the debit", func(t *testing.T) {
+			project, _ := factories.CreateProject(context.TODO())
+			requestRecord := createRequestRecord(t, project.ID)
+
+			entry, debitErr := ledger.RecordDebit(
+				context.TODO(),
+				project.ID,
+				requestRecord.ID,
+				cost,
+				pgtype.UUID{},
+			)
+			assert.NoError(t, debitErr)
+			assert.Equal(t, models.CreditLedgerEntryTypeDEBIT, entry.EntryType)
+			assert.Equal(t, "-0.0000465", exc.MustResult(db.NumericToDecimal(entry.Amount)).String())
+			assert.Equal(t, "0.9999535", exc.MustResult(db.NumericToDecimal(entry.BalanceAfter)).String())
+			assert.Equal(t, "0.9999535", retrieveCredits(t, project.ID).String())
+		})
+
+		t.Run("debits a request record once", func(t *testing.T) {
+			project, _ := factories.CreateProject(context.TODO())
+			requestRecord := createRequestRecord(t, project.ID)
+
+			_, debitErr := ledger.RecordDebit(
+				context.TODO(),
+				project.ID,
+				requestRecord.ID,
+				cost,
+				pgtype.UUID{},
+			)
+			assert.NoError(t, debitErr)
+
+			_, debitErr = ledger.RecordDebit(
+				context.TODO(),
+				project.ID,
+				requestRecord.ID,
+				cost,
+				pgtype.UUID{},
+			)
+			assert.ErrorIs(t, debitErr, ledger.ErrAlreadyDebited)
+			assert.Equal(t, "0.9999535", retrieveCredits(t, project.ID).String())
+		})
+
+		t.Run("applies concurrent debits", func(t *testing.T) {
+			project, _ := factories.CreateProject(context.TODO())
+
+			requestRecords := make([]*models.PromptRequestRecord, 10)
+			for i := range requestRecords {
+				requestRecords[i] = createRequestRecord(t, project.ID)
+			}
+
+			var wg sync.WaitGroup
+			for _, requestRecord := range requestRecords {
+				wg.Add(1)
+
+				go func(recordID pgtype.UUID) {
+					defer wg.Done()
+
+					_, debitErr := ledger.RecordDebit(
+						context.TODO(),
+						project.ID,
+						recordID,
+						cost,
+						pgtype.UUID{},
+					)
+					assert.NoError(t, debitErr)
+				}(requestRecord.ID)
+			}
+
+			wg.Wait()
+
+			assert.Equal(t, "0.999535", retrieveCredits(t, project.ID).String())
+		})
+
+		t.Run("releases the hold of the request", func(t *testing.T) {
+			project, _ := factories.CreateProject(context.TODO())
+			requestRecord := createRequestRecord(t, project.ID)
+
+			hold, holdErr := ledger.CreateHold(context.TODO(), project.ID, decimal.NewFromInt(1))
+			assert.NoError(t, holdErr)
+
+			_, debitErr := ledger.RecordDebit(
+				context.TODO(),
+				project.ID,
+				requestRecord.ID,
+				cost,
+				hold.ID,
+			)
+			assert.NoError(t, debitErr)
+
+			heldTotal, retrievalErr := db.GetQueries().
+				RetrieveProjectActiveCreditHoldsTotal(context.TODO(), project.ID)
+			assert.NoError(t, retrievalErr)
+			assert.True(t, exc.MustResult(db.NumericToDecimal(heldTotal)).IsZero())
+		})
+	})
+
+	t.Run("RecordGrant", func(t *testing.T) {
+		t.Run("adds the credits and records the grant", func(t *testing.T) {
+			project, _ := factories.CreateProject(context.TODO())
+			userAccount, _ := factories.CreateUserAccount(context.TODO())
+
+			entry, grantErr := ledger.RecordGrant(
+				context.TODO(),
+				project.ID,
+				userAccount.ID,
+				decimal.NewFromInt(10),
+				"top-up",
+				"payment-1",
+			)
+			assert.NoError(t, grantErr)
+			assert.Equal(t, models.CreditLedgerEntryTypeGRANT, entry.EntryType)
+			assert.Equal(t, "top-up", entry.Description)
+			assert.Equal(t, "payment-1", entry.PaymentReference)
+			assert.Equal(t, userAccount.ID, entry.UserAccountID)
+			assert.Equal(t, "11", exc.MustResult(db.NumericToDecimal(entry.BalanceAfter)).String())
+			assert.Equal(t, "11", retrieveCredits(t, project.ID).String())
+		})
+	})
+
+	t.Run("CreateHold", func(t *testing.T) {
+		t.Run("reserves the available credits", func(t *testing.T) {
+			project, _ := factories.CreateProject(context.TODO())
+
+			_, holdErr := ledger.CreateHold(context.TODO(), project.ID, decimal.RequireFromString("0.6"))
+			assert.NoError(t, holdErr)
+
+			_, holdErr = ledger.CreateHold(context.TODO(), project.ID, decimal.RequireFromString("0.6"))
+			assert.ErrorIs(t, holdErr, ledger.ErrInsufficientCredits)
+
+			_, holdErr = ledger.CreateHold(context.TODO(), project.ID, decimal.RequireFromString("0.4"))
+			assert.NoError(t, holdErr)
+		})
+
+		t.Run("does not count released holds", func(t *testing.T) {
+			project, _ := factories.CreateProject(context.TODO())
+
+			hold, holdErr := ledger.CreateHold(context.TODO(), project.ID, decimal.NewFromInt(1))
+			assert.NoError(t, holdErr)
+			assert.NoError(t, ledger.ReleaseHold(context.TODO(), hold.ID))
+
+			_, holdErr = ledger.CreateHold(context.TODO(), project.ID, decimal.NewFromInt(1))
+			assert.NoError(t, holdErr)
+		})
+
+		t.Run("fails for a project without credits", func(t *testing.T) {
+			project, _ := factories.CreateProject(context.TODO())
+			assert.NoError(t, db.GetQueries().SetProjectCredits(
+				context.TODO(),
+				models.SetProjectCreditsParams{
+					ID:      project.ID,
+					Credits: *exc.MustResult(db.StringToNumeric("0")),
+				},
+			))
+
+			_, holdErr := ledger.CreateHold(context.TODO(), project.ID, decimal.Zero)
+			assert.ErrorIs(t, holdErr, ledger.ErrInsufficientCredits)
+		})
+	})
+
+	t.Run("RecordUnrecordedDebits", func(t *testing.T) {
+		t.Run("debits the request records created after the ledger was opened", func(t *testing.T) {
+			project, _ := factories.CreateProject(context.TODO())
+			_ = createRequestRecord(t, project.ID)
+
+			openLedger(t, project.ID)
+
+			_ = createRequestRecord(t, project.ID)
+			_ = createRequestRecord(t, project.ID)
+
+			recorded, recordErr := ledger.RecordUnrecordedDebits(
+				context.TODO(),
+				project.ID,
+				time.Now(),
+			)
+			assert.NoError(t, recordErr)
+			assert.Equal(t, 2, recorded)
+			assert.Equal(t, "0.999907", retrieveCredits(t, project.ID).String())
+
+			recorded, recordErr = ledger.RecordUnrecordedDebits(
+				context.TODO(),
+				project.ID,
+				time.Now(),
+			)
+			assert.NoError(t, recordErr)
+			assert.Equal(t, 0, recorded)
+		})
+	})
+
+	t.Run("Reconcile", func(t *testing.T) {
+		t.Run("reports the unrecorded debits and the drift", func(t *testing.T) {
+			project, _ := factories.CreateProject(context.TODO())
+			openLedger(t, project.ID)
+			_ = createRequestRecord(t, project.ID)
+
+			assert.NoError(t, db.GetQueries().UpdateProjectCredits(
+				context.TODO(),
+				models.UpdateProjectCreditsParams{
+					ID:      project.ID,
+					Credits: *exc.MustResult(db.StringToNumeric("2")),
+				},
+			))
+
+			report, reconciliationErr := ledger.Reconcile(context.TODO(), project.ID, false)
+			assert.NoError(t, reconciliationErr)
+			assert.False(t, report.IsApplied)
+			assert.Equal(t, 1, report.UnrecordedDebits)
+			assert.True(t, cost.Equal(report.UnrecordedAmount))
+			assert.Equal(t, "3", report.Credits.String())
+			assert.Equal(t, "1", report.LedgerBalance.String())
+			assert.Equal(t, "2", report.Drift.String())
+			assert.Equal(t, "3", retrieveCredits(t, project.ID).String())
+		})
+
+		t.Run("debits the unrecorded debits and records the drift", func(t *testing.T) {
+			project, _ := factories.CreateProject(context.TODO())
+			openLedger(t, project.ID)
+			_ = createRequestRecord(t, project.ID)
+
+			assert.NoError(t, db.GetQueries().UpdateProjectCredits(
+				context.TODO(),
+				models.UpdateProjectCreditsParams{
+					ID:      project.ID,
+					Credits: *exc.MustResult(db.StringToNumeric("2")),
+				},
+			))
+
+			report, reconciliationErr := ledger.Reconcile(context.TODO(), project.ID, true)
+			assert.NoError(t, reconciliationErr)
+			assert.True(t, report.IsApplied)
+			assert.Equal(t, 1, report.UnrecordedDebits)
+			assert.Equal(t, "2", report.Drift.String())
+			assert.Equal(t, "2.9999535", retrieveCredits(t, project.ID).String())
+
+			report, reconciliationErr = ledger.Reconcile(context.TODO(), project.ID, false)
+			assert.NoError(t, reconciliationErr)
+			assert.Equal(t, 0, report.UnrecordedDebits)
+			assert.True(t, report.Drift.IsZero())
+			assert.True(t, report.Credits.Equal(report.LedgerBalance))
+		})
+	})
+}
@@ -0,0 +1,181 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/basemind-ai/monorepo/shared/go/db"
+	"github.com/basemind-ai/monorepo/shared/go/db/models"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"math"
+	"time"
+)
+
+// reconciliationPageSize is the number of request records debited per query when recording unrecorded debits.
+const reconciliationPageSize = 100
+
+// ReconciliationReport is the result of reconciling the credits of a project with its ledger.
+type ReconciliationReport struct {
+	// Credits is the credit balance of the project.
+	Credits decimal.Decimal
+	// LedgerBalance is the sum of the ledger entries of the project.
+	LedgerBalance decimal.Decimal
+	// Drift is the credits minus the ledger balance - credit changes that were made outside the ledger.
+	Drift decimal.Decimal
+	// UnrecordedDebits is the number of request records whose cost was not debited.
+	UnrecordedDebits int
+	// UnrecordedAmount is the total cost of the request records whose cost was not debited.
+	UnrecordedAmount decimal.Decimal
+	// IsApplied is true if the unrecorded debits were debited, and the drift recorded as an adjustment.
+	IsApplied bool
+}
+
+// RecordUnrecordedDebits debits the request records created before the given time whose cost was not debited,
+// e.g. because the debit after the request failed. Only request records created after the project ledger was opened
+// are debited. An invalid project ID debits the request records of all projects.
+// Returns the number of debited request records.
+func RecordUnrecordedDebits(
+	ctx context.Context,
+	projectID pgtype.UUID,
+	createdBefore time.Time,
+) (int, error) {
+	recorded := 0
+
+	for {
+		records, retrievalErr := db.GetQueries().RetrieveUnrecordedPromptRequestRecords(
+			ctx,
+			models.RetrieveUnrecordedPromptRequestRecordsParams{
+				ProjectID:     projectID,
+				CreatedBefore: pgtype.Timestamptz{Time: createdBefore, Valid: true},
+				PageLimit:     reconciliationPageSize,
+			},
+		)
+		if retrievalErr != nil {
+			return recorded, fmt.Errorf("failed to retrieve unrecorded request records - %w", retrievalErr)
+		}
+
+		for _, record := range records {
+			cost, costErr := RequestRecordCost(record.RequestTokensCost, record.ResponseTokensCost)
+			if costErr != nil {
+				return recorded, costErr
+			}
+
+			if _, debitErr := RecordDebit(
+				ctx,
+				record.ProjectID,
+				record.ID,
+				cost,
+				pgtype.UUID{},
+			); debitErr != nil && !errors.Is(debitErr, ErrAlreadyDebited) {
+				return recorded, debitErr
+			}
+
+			recorded++
+		}
+
+		if len(records) < reconciliationPageSize {
+			return recorded, nil
+		}
+	}
+}
+
+// Reconcile compares the credits of a project with its ledger. If apply is true, the unrecorded debits are debited,
+// and the remaining drift is recorded as an adjustment, so that the ledger balance equals the credits afterwards.
+// The credits are not changed by the adjustment.
+func Reconcile(
+	ctx context.Context,
+	projectID pgtype.UUID,
+	apply bool,
+) (*ReconciliationReport, error) {
+	report := &ReconciliationReport{IsApplied: apply}
+
+	records, retrievalErr := db.GetQueries().RetrieveUnrecordedPromptRequestRecords(
+		ctx,
+		models.RetrieveUnrecordedPromptRequestRecordsParams{
+			ProjectID:     projectID,
+			CreatedBefore: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+			PageLimit:     math.MaxInt32,
+		},
+	)
+	if retrievalErr != nil {
+		return nil, fmt.Errorf("failed to retrieve unrecorded request records - %w", retrievalErr)
+	}
+
+	report.UnrecordedDebits = len(records)
+	for _, record := range records {
+		cost, costErr := RequestRecordCost(record.RequestTokensCost, record.ResponseTokensCost)
+		if costErr != nil {
+			return nil, costErr
+		}
+
+		report.UnrecordedAmount = report.UnrecordedAmount.Add(cost)
+	}
+
+	if apply {
+		if _, recordErr := RecordUnrecordedDebits(ctx, projectID, time.Now()); recordErr != nil {
+			return nil, recordErr
+		}
+	}
+
+	tx, txErr := db.GetOrCreateTx(ctx)
+	if txErr != nil {
+		return nil, fmt.Errorf("failed to create transaction - %w", txErr)
+	}
+
+	if db.ShouldCommit(ctx) {
+		defer db.HandleRollback(ctx, tx)
+	}
+
+	queries := db.GetQueries().WithTx(tx)
+
+	// the project is locked, so the credits and the ledger balance are read without concurrent debits
+	credits, lockErr := queries.RetrieveProjectCreditsForUpdate(ctx, projectID)
+	if lockErr != nil {
+		return nil, fmt.Errorf("failed to retrieve project credits - %w", lockErr)
+	}
+
+	ledgerBalance, balanceErr := queries.RetrieveProjectCreditLedgerBalance(ctx, projectID)
+	if balanceErr != nil {
+		return nil, fmt.Errorf("failed to retrieve ledger balance - %w", balanceErr)
+	}
+
+	decimalCredits, creditsConversionErr := db.NumericToDecimal(credits)
+	if creditsConversionErr != nil {
+		return nil, creditsConversionErr
+	}
+
+	decimalLedgerBalance, balanceConversionErr := db.NumericToDecimal(ledgerBalance)
+	if balanceConversionErr != nil {
+		return nil, balanceConversionErr
+	}
+
+	report.Credits = *decimalCredits
+	report.LedgerBalance = *decimalLedgerBalance
+	report.Drift = decimalCredits.Sub(*decimalLedgerBalance)
+
+	if !apply || report.Drift.IsZero() {
+		return report, nil
+	}
+
+	amount, amountErr := decimalToNumeric(report.Drift)
+	if amountErr != nil {
+		return nil, amountErr
+	}
+
+	if _, createErr := queries.CreateCreditLedgerEntry(ctx, models.CreateCreditLedgerEntryParams{
+		ProjectID:    projectID,
+		EntryType:    models.CreditLedgerEntryTypeADJUSTMENT,
+		Amount:       amount,
+		BalanceAfter: credits,
+		Description:  "reconciliation",
+	}); createErr != nil {
+		return nil, fmt.Errorf("failed to create ledger adjustment - %w", createErr)
+	}
+
+	if commitErr := commitIfShouldCommit(ctx, tx); commitErr != nil {
+		return nil, commitErr
+	}
+
+	return report, nil
+}
@@ -0,0 +1,169 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog/log"
+	"net/http"
+	"time"
+)
+
+const namespace = "basemind"
+
+// connectorBuckets are the latency buckets of model provider requests, which take up to minutes.
+var connectorBuckets = prometheus.ExponentialBuckets(0.1, 2, 12)
+
+var (
+	// GRPCRequestsTotal counts the handled gRPC requests by service, method and status code.
+	GRPCRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grpc_requests_total",
+		Help:      "The total number of handled gRPC requests.",
+	}, []string{"service", "method", "code"})
+
+	// GRPCRequestDuration observes the duration of the handled gRPC requests by service and method.
+	GRPCRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "grpc_request_duration_seconds",
+		Help:      "The duration of handled gRPC requests.",
+		Buckets:   connectorBuckets,
+	}, []string{"service", "method"})
+
+	// HTTPRequestsTotal counts the handled HTTP requests by service, method, route pattern and status code.
+	HTTPRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "The total number of handled HTTP requests.",
+	}, []string{"service", "method", "route", "status"})
+
+	// HTTPRequestDuration observes the duration of the handled HTTP requests by service, method and route pattern.
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "The duration of handled HTTP requests.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"service", "method", "route"})
+
+	// ConnectorRequestDuration observes the duration of model provider requests by vendor, model, response type
+	// and outcome.
+	ConnectorRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "connector_request_duration_seconds",
+		Help:      "The duration of model provider requests.",
+		Buckets:   connectorBuckets,
+	}, []string{"model_vendor", "model_type", "stream", "outcome"})
+
+	// StreamTimeToFirstToken observes the time until the first content of a streaming response, by vendor and model.
+	StreamTimeToFirstToken = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "stream_time_to_first_token_seconds",
+		Help:      "The time until the first content of a streaming model provider response.",
+		Buckets:   prometheus.ExponentialBuckets(0.05, 2, 10),
+	}, []string{"model_vendor", "model_type"})
+
+	// TokensTotal counts the tokens of prompt requests by project, application, vendor, model and direction.
+	TokensTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tokens_total",
+		Help:      "The total number of request and response tokens of prompt requests.",
+	}, []string{"project_id", "application_id", "model_vendor", "model_type", "direction"})
+
+	// CacheRequestsTotal counts the cache lookups by cache name and result - hit or miss.
+	CacheRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_requests_total",
+		Help:      "The total number of cache lookups.",
+	}, []string{"cache", "result"})
+
+	// CreditDeductionFailuresTotal counts the failed project credit deductions by project.
+	CreditDeductionFailuresTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "credit_deduction_failures_total",
+		Help:      "The total number of failed project credit deductions.",
+	}, []string{"project_id"})
+)
+
+// RegisterDBPool registers gauges and counters reporting the statistics of the given DB connection pool.
+// The statistics are read from the pool when metrics are collected.
+func RegisterDBPool(pool *pgxpool.Pool, registerer prometheus.Registerer) error {
+	stat := func(name, help string, value func(*pgxpool.Stat) float64, isCounter bool) prometheus.Collector {
+		opts := prometheus.Opts{
+			Namespace: namespace,
+			Subsystem: "db_pool",
+			Name:      name,
+			Help:      help,
+		}
+
+		if isCounter {
+			return prometheus.NewCounterFunc(prometheus.CounterOpts(opts), func() float64 {
+				return value(pool.Stat())
+			})
+		}
+
+		return prometheus.NewGaugeFunc(prometheus.GaugeOpts(opts), func() float64 {
+			return value(pool.Stat())
+		})
+	}
+
+	for _, collector := range []prometheus.Collector{
+		stat("acquired_connections", "The number of currently acquired connections.",
+			func(s *pgxpool.Stat) float64 { return float64(s.AcquiredConns()) }, false),
+		stat("idle_connections", "The number of currently idle connections.",
+			func(s *pgxpool.Stat) float64 { return float64(s.IdleConns()) }, false),
+		stat("total_connections", "The total number of connections in the pool.",
+			func(s *pgxpool.Stat) float64 { return float64(s.TotalConns()) }, false),
+		stat("max_connections", "The maximum size of the pool.",
+			func(s *pgxpool.Stat) float64 { return float64(s.MaxConns()) }, false),
+		stat("acquires_total", "The total number of successful connection acquires.",
+			func(s *pgxpool.Stat) float64 { return float64(s.AcquireCount()) }, true),
+		stat("empty_acquires_total", "The total number of acquires that waited for a connection.",
+			func(s *pgxpool.Stat) float64 { return float64(s.EmptyAcquireCount()) }, true),
+		stat("canceled_acquires_total", "The total number of acquires canceled by their context.",
+			func(s *pgxpool.Stat) float64 { return float64(s.CanceledAcquireCount()) }, true),
+		stat("acquire_duration_seconds_total", "The total duration of successful connection acquires.",
+			func(s *pgxpool.Stat) float64 { return s.AcquireDuration().Seconds() }, true),
+	} {
+		if registerErr := registerer.Register(collector); registerErr != nil {
+			return fmt.Errorf("failed to register db pool metrics - %w", registerErr)
+		}
+	}
+
+	return nil
+}
+
+// Serve serves the /metrics endpoint on the given port until the context is done.
+// Metrics are served separately from the service, so they are not exposed through its public port.
+func Serve(ctx context.Context, port int) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           mux,
+		ReadHeaderTimeout: 2 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if shutdownErr := srv.Shutdown(shutdownCtx); shutdownErr != nil {
+			log.Error().Err(shutdownErr).Msg("failed to shutdown metrics server")
+		}
+	}()
+
+	log.Info().Msgf("metrics server starting, listening on port %d", port)
+
+	if serveErr := srv.ListenAndServe(); !errors.Is(serveErr, http.ErrServerClosed) {
+		return serveErr
+	}
+
+	return nil
+}
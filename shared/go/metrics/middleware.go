@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// UnaryServerInterceptor returns a gRPC interceptor recording the count and duration of unary requests.
+func UnaryServerInterceptor(serviceName string) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		startTime := time.Now()
+		res, err := handler(ctx, req)
+		observeGRPCRequest(serviceName, info.FullMethod, startTime, err)
+
+		return res, err
+	}
+}
+
+// StreamServerInterceptor returns a gRPC interceptor recording the count and duration of streaming requests.
+func StreamServerInterceptor(serviceName string) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		startTime := time.Now()
+		err := handler(srv, ss)
+		observeGRPCRequest(serviceName, info.FullMethod, startTime, err)
+
+		return err
+	}
+}
+
+func observeGRPCRequest(serviceName, method string, startTime time.Time, err error) {
+	GRPCRequestsTotal.WithLabelValues(serviceName, method, status.Code(err).String()).Inc()
+	GRPCRequestDuration.WithLabelValues(serviceName, method).Observe(time.Since(startTime).Seconds())
+}
+
+// HTTPMiddleware returns a chi middleware recording the count and duration of HTTP requests.
+// Requests are labeled by their route pattern rather than their path, to keep the cardinality of the labels bounded.
+func HTTPMiddleware(serviceName string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			startTime := time.Now()
+			ww := chimiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+			next.ServeHTTP(ww, r)
+
+			route := "unmatched"
+			if routeContext := chi.RouteContext(r.Context()); routeContext != nil {
+				if pattern := routeContext.RoutePattern(); pattern != "" {
+					route = pattern
+				}
+			}
+
+			statusCode := ww.Status()
+			if statusCode == 0 {
+				statusCode = http.StatusOK
+			}
+
+			HTTPRequestsTotal.WithLabelValues(
+				serviceName,
+				r.Method,
+				route,
+				strconv.Itoa(statusCode),
+			).Inc()
+			HTTPRequestDuration.WithLabelValues(serviceName, r.Method, route).
+				Observe(time.Since(startTime).Seconds())
+		})
+	}
+}
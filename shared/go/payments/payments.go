@@ -0,0 +1,62 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrPaymentDeclined is returned by a payment provider when the payment of a top-up was declined.
+var ErrPaymentDeclined = errors.New("payment declined")
+
+// TopUp - a request to add credits to a project.
+type TopUp struct {
+	// ProjectID is the ID of the project receiving the credits.
+	ProjectID string
+	// UserAccountID is the ID of the user requesting the top-up.
+	UserAccountID string
+	// Amount is the amount of credits.
+	Amount decimal.Decimal
+	// Reason is the reason given for the top-up.
+	Reason string
+}
+
+// Provider - an interface that must be implemented by payment providers.
+// Self-hosted deployments can plug their own billing by calling SetProvider on startup.
+type Provider interface {
+	// Charge - charges the payment of a top-up, returning a reference identifying the payment.
+	// The credits are granted only if Charge returns without an error.
+	Charge(ctx context.Context, topUp TopUp) (string, error)
+}
+
+// ManualProvider - a payment provider that grants top-ups without a payment, for credits granted by operators.
+// Callers must restrict the top-ups of this provider to the platform admins.
+type ManualProvider struct{}
+
+// Charge - grants the top-up without a payment.
+func (ManualProvider) Charge(_ context.Context, _ TopUp) (string, error) {
+	return "", nil
+}
+
+var (
+	mutex    sync.RWMutex
+	provider Provider = ManualProvider{}
+)
+
+// GetProvider - returns the payment provider, ManualProvider unless another provider was set.
+func GetProvider() Provider {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	return provider
+}
+
+// SetProvider - sets the payment provider.
+func SetProvider(p Provider) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	provider = p
+}
@@ -0,0 +1,38 @@
+package payments_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/basemind-ai/monorepo/shared/go/payments"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+type decliningProvider struct{}
+
+func (decliningProvider) Charge(_ context.Context, _ payments.TopUp) (string, error) {
+	return "", payments.ErrPaymentDeclined
+}
+
+func TestGetProvider(t *testing.T) {
+	assert.IsType(t, payments.ManualProvider{}, payments.GetProvider())
+
+	reference, chargeErr := payments.GetProvider().Charge(context.TODO(), payments.TopUp{
+		Amount: decimal.NewFromInt(10),
+	})
+	assert.NoError(t, chargeErr)
+	assert.Empty(t, reference)
+}
+
+func TestSetProvider(t *testing.T) {
+	payments.SetProvider(decliningProvider{})
+	t.Cleanup(func() {
+		payments.SetProvider(payments.ManualProvider{})
+	})
+
+	_, chargeErr := payments.GetProvider().Charge(context.TODO(), payments.TopUp{
+		Amount: decimal.NewFromInt(10),
+	})
+	assert.ErrorIs(t, chargeErr, payments.ErrPaymentDeclined)
+}
@@ -0,0 +1,98 @@
+package redaction
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/basemind-ai/monorepo/shared/go/datatypes"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+)
+
+// DefaultReplacement is the text that replaces the matches of a redaction rule without a replacement.
+const DefaultReplacement = "[REDACTED]"
+
+// Redaction rule presets.
+const (
+	PresetEmail       = "EMAIL"
+	PresetPhoneNumber = "PHONE_NUMBER"
+	PresetCreditCard  = "CREDIT_CARD"
+	PresetIPAddress   = "IP_ADDRESS"
+)
+
+var presetPatterns = map[string]*regexp.Regexp{
+	PresetEmail: regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`),
+	PresetPhoneNumber: regexp.MustCompile(
+		`(?:\+\d{1,3}[\s.\-]?)?(?:\(\d{2,4}\)|\b\d{2,4})[\s.\-]?\d{3,4}[\s.\-]?\d{3,4}\b`,
+	),
+	PresetCreditCard: regexp.MustCompile(`\b(?:\d[ \-]?){12,18}\d\b`),
+	PresetIPAddress: regexp.MustCompile(
+		`\b(?:(?:25[0-5]|2[0-4]\d|1?\d?\d)\.){3}(?:25[0-5]|2[0-4]\d|1?\d?\d)\b`,
+	),
+}
+
+type compiledRule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// Redactor applies a list of redaction rules, in order, to text.
+// Patterns are compiled with the RE2 syntax of the regexp package, so matching runs in linear time.
+type Redactor struct {
+	rules []compiledRule
+}
+
+// Compile validates and compiles the given redaction rules.
+// Every rule must set either a preset or a pattern, but not both.
+func Compile(rules []datatypes.PayloadRedactionRuleDTO) (*Redactor, error) {
+	redactor := &Redactor{rules: make([]compiledRule, 0, len(rules))}
+
+	for _, rule := range rules {
+		if (rule.Preset == nil) == (rule.Pattern == nil) {
+			return nil, fmt.Errorf(
+				"invalid redaction rule {%s} - either a preset or a pattern must be set",
+				rule.Name,
+			)
+		}
+
+		var pattern *regexp.Regexp
+		if rule.Preset != nil {
+			presetPattern, ok := presetPatterns[*rule.Preset]
+			if !ok {
+				return nil, fmt.Errorf(
+					"invalid redaction rule {%s} - unknown preset %s",
+					rule.Name,
+					*rule.Preset,
+				)
+			}
+
+			pattern = presetPattern
+		} else {
+			compiledPattern, compileErr := regexp.Compile(*rule.Pattern)
+			if compileErr != nil {
+				return nil, fmt.Errorf("invalid redaction rule {%s} - %w", rule.Name, compileErr)
+			}
+
+			pattern = compiledPattern
+		}
+
+		redactor.rules = append(redactor.rules, compiledRule{
+			pattern:     pattern,
+			replacement: ptr.Deref(rule.Replacement, DefaultReplacement),
+		})
+	}
+
+	return redactor, nil
+}
+
+// Redact replaces the matches of the redaction rules in the given text. A nil redactor returns the text as is.
+func (r *Redactor) Redact(text string) string {
+	if r == nil {
+		return text
+	}
+
+	for _, rule := range r.rules {
+		text = rule.pattern.ReplaceAllLiteralString(text, rule.replacement)
+	}
+
+	return text
+}
@@ -0,0 +1,127 @@
+package redaction_test
+
+import (
+	"testing"
+
+	"github.com/basemind-ai/monorepo/shared/go/datatypes"
+	"github.com/basemind-ai/monorepo/shared/go/ptr"
+	"github.com/basemind-ai/monorepo/shared/go/redaction"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompile(t *testing.T) {
+	for _, testCase := range []struct {
+		Name    string
+		Rule    datatypes.PayloadRedactionRuleDTO
+		IsValid bool
+	}{
+		{
+			Name:    "allows a preset",
+			Rule:    datatypes.PayloadRedactionRuleDTO{Name: "email", Preset: ptr.To(redaction.PresetEmail)},
+			IsValid: true,
+		},
+		{
+			Name:    "allows a pattern",
+			Rule:    datatypes.PayloadRedactionRuleDTO{Name: "ssn", Pattern: ptr.To(`\d{3}-\d{2}-\d{4}`)},
+			IsValid: true,
+		},
+		{
+			Name:    "rejects a rule without a preset or a pattern",
+			Rule:    datatypes.PayloadRedactionRuleDTO{Name: "empty"},
+			IsValid: false,
+		},
+		{
+			Name: "rejects a rule with both a preset and a pattern",
+			Rule: datatypes.PayloadRedactionRuleDTO{
+				Name:    "both",
+				Preset:  ptr.To(redaction.PresetEmail),
+				Pattern: ptr.To(`\d+`),
+			},
+			IsValid: false,
+		},
+		{
+			Name:    "rejects an unknown preset",
+			Rule:    datatypes.PayloadRedactionRuleDTO{Name: "unknown", Preset: ptr.To("UNKNOWN")},
+			IsValid: false,
+		},
+		{
+			Name:    "rejects an invalid pattern",
+			Rule:    datatypes.PayloadRedactionRuleDTO{Name: "invalid", Pattern: ptr.To(`(\d+`)},
+			IsValid: false,
+		},
+	} {
+		t.Run(testCase.Name, func(t *testing.T) {
+			_, err := redaction.Compile([]datatypes.PayloadRedactionRuleDTO{testCase.Rule})
+			if testCase.IsValid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, "invalid redaction rule")
+			}
+		})
+	}
+}
+
+func TestRedact(t *testing.T) {
+	t.Run("redacts the presets", func(t *testing.T) {
+		for _, testCase := range []struct {
+			Preset   string
+			Text     string
+			Expected string
+		}{
+			{
+				Preset:   redaction.PresetEmail,
+				Text:     "contact me at jane.doe+test@example.com please",
+				Expected: "contact me at [REDACTED] please",
+			},
+			{
+				Preset:   redaction.PresetPhoneNumber,
+				Text:     "call +1 (555) 123-4567 now",
+				Expected: "call [REDACTED] now",
+			},
+			{
+				Preset:   redaction.PresetCreditCard,
+				Text:     "card 4111 1111 1111 1111 expires soon",
+				Expected: "card [REDACTED] expires soon",
+			},
+			{
+				Preset:   redaction.PresetIPAddress,
+				Text:     "request from 192.168.0.1 blocked",
+				Expected: "request from [REDACTED] blocked",
+			},
+		} {
+			t.Run(testCase.Preset, func(t *testing.T) {
+				redactor, err := redaction.Compile([]datatypes.PayloadRedactionRuleDTO{
+					{Name: testCase.Preset, Preset: ptr.To(testCase.Preset)},
+				})
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.Expected, redactor.Redact(testCase.Text))
+			})
+		}
+	})
+
+	t.Run("applies the rules in order with their replacements", func(t *testing.T) {
+		redactor, err := redaction.Compile([]datatypes.PayloadRedactionRuleDTO{
+			{Name: "email", Preset: ptr.To(redaction.PresetEmail), Replacement: ptr.To("<email>")},
+			{Name: "ticket", Pattern: ptr.To(`TICKET-\d+`)},
+		})
+		assert.NoError(t, err)
+		assert.Equal(
+			t,
+			"<email> opened [REDACTED]",
+			redactor.Redact("jane@example.com opened TICKET-123"),
+		)
+	})
+
+	t.Run("does not expand replacement templates", func(t *testing.T) {
+		redactor, err := redaction.Compile([]datatypes.PayloadRedactionRuleDTO{
+			{Name: "digits", Pattern: ptr.To(`(\d+)`), Replacement: ptr.To("$1")},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "code $1", redactor.Redact("code 1234"))
+	})
+
+	t.Run("returns the text as is for a nil redactor", func(t *testing.T) {
+		var redactor *redaction.Redactor
+		assert.Equal(t, "jane@example.com", redactor.Redact("jane@example.com"))
+	})
+}
@@ -4,17 +4,22 @@ import (
 	"context"
 	"fmt"
 	"github.com/basemind-ai/monorepo/shared/go/exc"
+	"github.com/basemind-ai/monorepo/shared/go/metrics"
+	"github.com/basemind-ai/monorepo/shared/go/tracing"
 	"github.com/go-redis/cache/v9"
 	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"os"
 	"sync"
 	"time"
 )
 
 var (
-	once   sync.Once
-	client *cache.Cache
+	once        sync.Once
+	client      *cache.Cache
+	redisClient *redis.Client
 )
 
 // SetClient is a helper function that allows you to set the redis client. This is useful for testing.
@@ -35,8 +40,9 @@ func New(redisURL string) *cache.Cache {
 			log.Info().Msg("connected to redis")
 			return nil
 		}
+		redisClient = redis.NewClient(opt)
 		SetClient(cache.New(&cache.Options{
-			Redis:      redis.NewClient(opt),
+			Redis:      redisClient,
 			LocalCache: cache.NewTinyLFU(1000, time.Minute),
 		}))
 	})
@@ -47,19 +53,39 @@ func GetClient() *cache.Cache {
 	return exc.ReturnNotNil(client, "redis client is not initialized")
 }
 
+// GetRedisClient returns the underlying redis client, for operations that the cache does not support.
+func GetRedisClient() *redis.Client {
+	return exc.ReturnNotNil(redisClient, "redis client is not initialized")
+}
+
 // With is a helper function that will check if a key exists in redis, and if it does, it will return the value. If it
 // does not exist, it will call the fallback function, set the value in redis, and return the value.
+// Lookups are counted as cache hits or misses in the metrics, labeled by the cache name, and traced with a span
+// that includes the fallback.
 func With[T any](
 	ctx context.Context,
+	cacheName string,
 	key string,
 	target *T,
 	ttl time.Duration,
 	fallback func() (*T, error),
 ) (*T, error) {
+	ctx, span := tracing.Tracer().Start(
+		ctx,
+		"rediscache.With",
+		trace.WithAttributes(attribute.String("cache", cacheName)),
+	)
+	defer span.End()
+
 	if getErr := client.Get(ctx, key, target); getErr == nil {
+		metrics.CacheRequestsTotal.WithLabelValues(cacheName, "hit").Inc()
+		span.SetAttributes(attribute.Bool("cache.hit", true))
 		return target, nil
 	}
 
+	metrics.CacheRequestsTotal.WithLabelValues(cacheName, "miss").Inc()
+	span.SetAttributes(attribute.Bool("cache.hit", false))
+
 	retrieved, retrieveErr := fallback()
 	if retrieveErr != nil {
 		return nil, fmt.Errorf("failed to retrieve value from database: %w", retrieveErr)
@@ -2,10 +2,12 @@ package rediscache_test
 
 import (
 	"context"
+	"github.com/basemind-ai/monorepo/shared/go/metrics"
 	"github.com/basemind-ai/monorepo/shared/go/rediscache"
 	"github.com/basemind-ai/monorepo/shared/go/testutils"
 	"github.com/go-redis/cache/v9"
 	"github.com/go-redis/redismock/v9"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"testing"
 	"time"
@@ -43,10 +45,12 @@ func TestRedisClient(t *testing.T) {
 
 			db.Set(context.TODO(), key, val, time.Second)
 			mockRedis.ExpectGet(key).SetVal("doe")
+			hits := testutil.ToFloat64(metrics.CacheRequestsTotal.WithLabelValues("test", "hit"))
 
 			var target string
 			retrieved, retrieveErr := rediscache.With(
 				context.TODO(),
+				"test",
 				key,
 				&target,
 				time.Minute,
@@ -54,6 +58,11 @@ func TestRedisClient(t *testing.T) {
 			)
 			assert.NoError(t, retrieveErr)
 			assert.Equal(t, "doe", *retrieved)
+			assert.Equal(
+				t,
+				hits+1,
+				testutil.ToFloat64(metrics.CacheRequestsTotal.WithLabelValues("test", "hit")),
+			)
 		})
 
 		t.Run(
@@ -70,10 +79,14 @@ func TestRedisClient(t *testing.T) {
 				expected, _ := client.Marshal("zuchmir")
 
 				mockRedis.ExpectSet(key, expected, time.Minute).SetVal("OK")
+				misses := testutil.ToFloat64(
+					metrics.CacheRequestsTotal.WithLabelValues("test", "miss"),
+				)
 
 				var target string
 				retrieved, retrieveErr := rediscache.With(
 					context.TODO(),
+					"test",
 					key,
 					&target,
 					time.Minute,
@@ -81,6 +94,11 @@ func TestRedisClient(t *testing.T) {
 				)
 				assert.NoError(t, retrieveErr)
 				assert.Equal(t, "zuchmir", *retrieved)
+				assert.Equal(
+					t,
+					misses+1,
+					testutil.ToFloat64(metrics.CacheRequestsTotal.WithLabelValues("test", "miss")),
+				)
 			},
 		)
 
@@ -99,6 +117,7 @@ func TestRedisClient(t *testing.T) {
 			var target string
 			retrieved, retrieveErr := rediscache.With(
 				context.TODO(),
+				"test",
 				key,
 				&target,
 				time.Minute,
@@ -126,6 +145,7 @@ func TestRedisClient(t *testing.T) {
 			var target string
 			retrieved, retrieveErr := rediscache.With(
 				context.TODO(),
+				"test",
 				key,
 				&target,
 				time.Minute,
@@ -1,6 +1,8 @@
 package router
 
 import (
+	"github.com/basemind-ai/monorepo/shared/go/metrics"
+	"github.com/basemind-ai/monorepo/shared/go/tracing"
 	"github.com/go-chi/cors"
 	"net/http"
 
@@ -33,6 +35,8 @@ func New(opts Options) chi.Router {
 		}))
 		router.Use(httplog.RequestLogger(log.With().Str("service", opts.ServiceName).Logger()))
 		router.Use(chimiddleware.Heartbeat("/health-check"))
+		router.Use(metrics.HTTPMiddleware(opts.ServiceName))
+		router.Use(tracing.HTTPMiddleware(opts.ServiceName))
 	}
 
 	for _, middleware := range opts.Middlewares {
@@ -18,7 +18,7 @@ func TestNew(t *testing.T) {
 			RegisterHandlers: func(mux *chi.Mux) {},
 		})
 
-		assert.Equal(t, len(r.Middlewares()), 4)
+		assert.Equal(t, len(r.Middlewares()), 6)
 	})
 
 	t.Run("Does not set middleware when environment is 'test'", func(t *testing.T) {
@@ -7,6 +7,9 @@ import (
 	"time"
 )
 
+// PlatformAdminEmail - the email of the platform admin configured by SetTestEnv.
+const PlatformAdminEmail = "platform-admin@basemind.ai"
+
 func SetTestEnv(t *testing.T) {
 	t.Helper()
 	t.Setenv("DATABASE_URL", "postgresql://basemind:basemind@db:5432/basemind")
@@ -14,6 +17,7 @@ func SetTestEnv(t *testing.T) {
 	t.Setenv("FRONTEND_BASE_URL", "http://localhost:3000")
 	t.Setenv("GCP_PROJECT_ID", "basemind-ai-development")
 	t.Setenv("JWT_SECRET", factories.RandomString(24))
+	t.Setenv("PLATFORM_ADMIN_EMAILS", PlatformAdminEmail)
 	t.Setenv("REDIS_CONNECTION_STRING", "redis://redis:6379")
 	t.Setenv("SERVER_HOST", "localhost")
 	t.Setenv("SERVER_PORT", "3000")
@@ -28,6 +32,7 @@ func UnsetTestEnv(t *testing.T) {
 	_ = os.Unsetenv("FRONTEND_BASE_URL")
 	_ = os.Unsetenv("GCP_PROJECT_ID")
 	_ = os.Unsetenv("JWT_SECRET")
+	_ = os.Unsetenv("PLATFORM_ADMIN_EMAILS")
 	_ = os.Unsetenv("REDIS_CONNECTION_STRING")
 	_ = os.Unsetenv("SERVER_HOST")
 	_ = os.Unsetenv("SERVER_PORT")
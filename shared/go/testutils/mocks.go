@@ -13,12 +13,14 @@ import (
 
 type MockOpenAIService struct {
 	openaiconnector.UnimplementedOpenAIServiceServer
-	Context         context.Context
-	Error           error
-	ExpectedRequest *openaiconnector.OpenAIPromptRequest
-	Response        *openaiconnector.OpenAIPromptResponse
-	Stream          []*openaiconnector.OpenAIStreamResponse
-	T               *testing.T
+	Context                   context.Context
+	Error                     error
+	ExpectedRequest           *openaiconnector.OpenAIPromptRequest
+	Response                  *openaiconnector.OpenAIPromptResponse
+	Stream                    []*openaiconnector.OpenAIStreamResponse
+	ExpectedEmbeddingsRequest *openaiconnector.OpenAIEmbeddingsRequest
+	EmbeddingsResponse        *openaiconnector.OpenAIEmbeddingsResponse
+	T                         *testing.T
 }
 
 func (m MockOpenAIService) OpenAIPrompt(
@@ -78,6 +80,26 @@ func (m MockOpenAIService) OpenAIStream(
 	return nil
 }
 
+func (m MockOpenAIService) OpenAIEmbeddings(
+	_ context.Context,
+	request *openaiconnector.OpenAIEmbeddingsRequest,
+) (*openaiconnector.OpenAIEmbeddingsResponse, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+
+	assert.NotNil(m.T, m.EmbeddingsResponse)
+
+	if m.ExpectedEmbeddingsRequest != nil {
+		assert.Equal(m.T, m.ExpectedEmbeddingsRequest.Model, request.Model)
+		assert.Equal(m.T, m.ExpectedEmbeddingsRequest.Inputs, request.Inputs)
+		assert.Equal(m.T, m.ExpectedEmbeddingsRequest.Dimensions, request.Dimensions)
+		assert.Equal(m.T, m.ExpectedEmbeddingsRequest.ApplicationId, request.ApplicationId)
+	}
+
+	return m.EmbeddingsResponse, nil
+}
+
 // Prompt Testing Service
 
 type MockPromptTestingService struct {
@@ -119,12 +141,14 @@ func (m MockPromptTestingService) TestPrompt(
 
 type MockCohereService struct {
 	cohereconnector.UnimplementedCohereServiceServer
-	Context         context.Context
-	Error           error
-	ExpectedRequest *cohereconnector.CoherePromptRequest
-	Response        *cohereconnector.CoherePromptResponse
-	Stream          []*cohereconnector.CohereStreamResponse
-	T               *testing.T
+	Context                   context.Context
+	Error                     error
+	ExpectedRequest           *cohereconnector.CoherePromptRequest
+	Response                  *cohereconnector.CoherePromptResponse
+	Stream                    []*cohereconnector.CohereStreamResponse
+	ExpectedEmbeddingsRequest *cohereconnector.CohereEmbeddingsRequest
+	EmbeddingsResponse        *cohereconnector.CohereEmbeddingsResponse
+	T                         *testing.T
 }
 
 func (m MockCohereService) CoherePrompt(
@@ -168,3 +192,22 @@ func (m MockCohereService) CohereStream(
 
 	return nil
 }
+
+func (m MockCohereService) CohereEmbeddings(
+	_ context.Context,
+	request *cohereconnector.CohereEmbeddingsRequest,
+) (*cohereconnector.CohereEmbeddingsResponse, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+
+	assert.NotNil(m.T, m.EmbeddingsResponse)
+
+	if m.ExpectedEmbeddingsRequest != nil {
+		assert.Equal(m.T, m.ExpectedEmbeddingsRequest.Model, request.Model)
+		assert.Equal(m.T, m.ExpectedEmbeddingsRequest.Inputs, request.Inputs)
+		assert.Equal(m.T, m.ExpectedEmbeddingsRequest.InputType, request.InputType)
+	}
+
+	return m.EmbeddingsResponse, nil
+}
@@ -0,0 +1,62 @@
+package tracing
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"strings"
+)
+
+// QueryTracer is a pgx query tracer creating a client span for every query.
+type QueryTracer struct{}
+
+var _ pgx.QueryTracer = QueryTracer{}
+
+// QueryName returns the name of a sqlc generated query, read from its "-- name: X :kind" comment.
+// Returns "query" for SQL without a name comment.
+func QueryName(sql string) string {
+	if name, isNamed := strings.CutPrefix(strings.TrimSpace(sql), "-- name: "); isNamed {
+		if fields := strings.Fields(name); len(fields) > 0 {
+			return fields[0]
+		}
+	}
+
+	return "query"
+}
+
+// TraceQueryStart starts the span of a query. The span is named by the sqlc query name,
+// so spans of the same query are grouped regardless of their arguments.
+func (QueryTracer) TraceQueryStart(
+	ctx context.Context,
+	_ *pgx.Conn,
+	data pgx.TraceQueryStartData,
+) context.Context {
+	ctx, _ = Tracer().Start(
+		ctx,
+		"db."+QueryName(data.SQL),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBStatement(data.SQL),
+		),
+	)
+
+	return ctx
+}
+
+// TraceQueryEnd ends the span of a query, recording its error if it failed.
+func (QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	defer span.End()
+
+	if data.Err != nil {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+		return
+	}
+
+	span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+}
@@ -0,0 +1,113 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+)
+
+const (
+	// ExporterNone disables the export of spans. Trace context is still propagated.
+	ExporterNone = "none"
+	// ExporterStdout writes spans to stdout, for local use.
+	ExporterStdout = "stdout"
+	// ExporterOTLP exports spans over OTLP gRPC. The endpoint is configured with the standard
+	// OTEL_EXPORTER_OTLP_ENDPOINT environment variables.
+	ExporterOTLP = "otlp"
+)
+
+const instrumentationName = "github.com/basemind-ai/monorepo"
+
+// Options is a struct that contains options for initializing tracing.
+type Options struct {
+	// ServiceName is the name of the service, set as the service.name resource attribute.
+	ServiceName string
+	// Exporter is the span exporter - one of ExporterNone, ExporterStdout or ExporterOTLP.
+	Exporter string
+	// SampleRatio is the ratio of root spans that are sampled, between 0 and 1.
+	// Child spans follow the sampling decision of their parent.
+	SampleRatio float64
+}
+
+// Init sets the global tracer provider and the W3C trace context propagator.
+// Returns a function that flushes and stops the exporter, which should be called before the service exits.
+func Init(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var (
+		exporter    sdktrace.SpanExporter
+		exporterErr error
+	)
+
+	switch opts.Exporter {
+	case ExporterNone, "":
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		exporter, exporterErr = stdouttrace.New()
+	case ExporterOTLP:
+		exporter, exporterErr = otlptracegrpc.New(ctx)
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %s", opts.Exporter)
+	}
+
+	if exporterErr != nil {
+		return nil, fmt.Errorf("failed to create tracing exporter - %w", exporterErr)
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(
+			sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio)),
+		),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName(opts.ServiceName),
+		)),
+	)
+	otel.SetTracerProvider(tracerProvider)
+
+	return tracerProvider.Shutdown, nil
+}
+
+// Tracer returns the tracer used for the spans created by the monorepo packages.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// HTTPMiddleware returns a chi middleware creating a server span for every request.
+// Spans are named by the route pattern of the request, once it has been routed.
+func HTTPMiddleware(serviceName string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return otelhttp.NewHandler(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				next.ServeHTTP(w, r)
+
+				if routeContext := chi.RouteContext(r.Context()); routeContext != nil {
+					if pattern := routeContext.RoutePattern(); pattern != "" {
+						trace.SpanFromContext(r.Context()).SetName(r.Method + " " + pattern)
+					}
+				}
+			}),
+			serviceName,
+		)
+	}
+}
+
+// NewHTTPTransport returns an HTTP transport creating a client span for every request,
+// and propagating the trace context in the request headers.
+func NewHTTPTransport(base http.RoundTripper) http.RoundTripper {
+	return otelhttp.NewTransport(base)
+}
@@ -0,0 +1,110 @@
+package tracing_test
+
+import (
+	"context"
+	"github.com/basemind-ai/monorepo/shared/go/tracing"
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func setTestTracerProvider(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+	})
+
+	return recorder
+}
+
+func TestTracing(t *testing.T) {
+	t.Run("Init", func(t *testing.T) {
+		for _, exporter := range []string{tracing.ExporterNone, tracing.ExporterStdout, ""} {
+			t.Run("initializes the "+exporter+" exporter", func(t *testing.T) {
+				shutdown, err := tracing.Init(context.TODO(), tracing.Options{
+					ServiceName: "test-service",
+					Exporter:    exporter,
+					SampleRatio: 1,
+				})
+				assert.NoError(t, err)
+				assert.NoError(t, shutdown(context.TODO()))
+			})
+		}
+
+		t.Run("returns an error for an unknown exporter", func(t *testing.T) {
+			_, err := tracing.Init(context.TODO(), tracing.Options{Exporter: "zipkin"})
+			assert.ErrorContains(t, err, "unknown tracing exporter")
+		})
+	})
+
+	t.Run("QueryName", func(t *testing.T) {
+		assert.Equal(
+			t,
+			"RetrieveApplication",
+			tracing.QueryName("-- name: RetrieveApplication :one\nSELECT * FROM application"),
+		)
+		assert.Equal(t, "query", tracing.QueryName("SELECT 1"))
+	})
+
+	t.Run("QueryTracer", func(t *testing.T) {
+		t.Run("creates a span named by the query", func(t *testing.T) {
+			recorder := setTestTracerProvider(t)
+			tracer := tracing.QueryTracer{}
+
+			ctx := tracer.TraceQueryStart(context.TODO(), nil, pgx.TraceQueryStartData{
+				SQL: "-- name: RetrieveProject :one\nSELECT * FROM project",
+			})
+			tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{
+				CommandTag: pgconn.NewCommandTag("SELECT 1"),
+			})
+
+			spans := recorder.Ended()
+			assert.Len(t, spans, 1)
+			assert.Equal(t, "db.RetrieveProject", spans[0].Name())
+			assert.Equal(t, codes.Unset, spans[0].Status().Code)
+		})
+
+		t.Run("records the error of a failed query", func(t *testing.T) {
+			recorder := setTestTracerProvider(t)
+			tracer := tracing.QueryTracer{}
+
+			ctx := tracer.TraceQueryStart(context.TODO(), nil, pgx.TraceQueryStartData{SQL: "SELECT 1"})
+			tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: assert.AnError})
+
+			spans := recorder.Ended()
+			assert.Len(t, spans, 1)
+			assert.Equal(t, codes.Error, spans[0].Status().Code)
+		})
+	})
+
+	t.Run("HTTPMiddleware", func(t *testing.T) {
+		t.Run("names the span by the route pattern", func(t *testing.T) {
+			recorder := setTestTracerProvider(t)
+
+			router := chi.NewRouter()
+			router.Use(tracing.HTTPMiddleware("test-service"))
+			router.Get("/projects/{projectId}", func(w http.ResponseWriter, r *http.Request) {})
+
+			router.ServeHTTP(
+				httptest.NewRecorder(),
+				httptest.NewRequest(http.MethodGet, "/projects/1", nil),
+			)
+
+			spans := recorder.Ended()
+			assert.Len(t, spans, 1)
+			assert.Equal(t, "GET /projects/{projectId}", spans[0].Name())
+		})
+	})
+}
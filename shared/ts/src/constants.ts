@@ -6,4 +6,6 @@ export enum StreamFinishReason {
 	ERROR = 'ERROR',
 	// Stream finished because it reached the token limit of the model
 	LIMIT = 'LIMIT',
+	// Stream was cancelled by the client before it finished
+	CANCELLED = 'CANCELLED',
 }
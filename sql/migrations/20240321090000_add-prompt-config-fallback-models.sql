@@ -0,0 +1,2 @@
+-- Modify "prompt_config" table
+ALTER TABLE "prompt_config" ADD COLUMN "fallback_models" json NULL;
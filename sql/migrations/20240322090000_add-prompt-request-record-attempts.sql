@@ -0,0 +1,2 @@
+-- Modify "prompt_request_record" table
+ALTER TABLE "prompt_request_record" ADD COLUMN "attempts" integer NOT NULL DEFAULT 1;
@@ -0,0 +1,4 @@
+-- Modify "prompt_config" table
+ALTER TABLE "prompt_config" ADD COLUMN "response_cache_ttl_seconds" integer NOT NULL DEFAULT 0;
+-- Modify "prompt_request_record" table
+ALTER TABLE "prompt_request_record" ADD COLUMN "is_cache_hit" boolean NOT NULL DEFAULT false;
@@ -0,0 +1,4 @@
+-- Modify "api_key" table
+ALTER TABLE "api_key" ADD COLUMN "rate_limit_requests_per_minute" integer NOT NULL DEFAULT 0, ADD COLUMN "rate_limit_tokens_per_minute" integer NOT NULL DEFAULT 0;
+-- Modify "application" table
+ALTER TABLE "application" ADD COLUMN "rate_limit_requests_per_minute" integer NOT NULL DEFAULT 0, ADD COLUMN "rate_limit_tokens_per_minute" integer NOT NULL DEFAULT 0;
@@ -0,0 +1,6 @@
+-- Create enum type "conversation_message_role"
+CREATE TYPE "conversation_message_role" AS ENUM ('system', 'user', 'assistant');
+-- Create "conversation_message" table
+CREATE TABLE "conversation_message" ("id" uuid NOT NULL DEFAULT gen_random_uuid(), "conversation_id" character varying(255) NOT NULL, "role" "conversation_message_role" NOT NULL, "content" text NOT NULL, "created_at" timestamptz NOT NULL DEFAULT now(), "application_id" uuid NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "conversation_message_application_id_fkey" FOREIGN KEY ("application_id") REFERENCES "application" ("id") ON UPDATE NO ACTION ON DELETE CASCADE);
+-- Create index "idx_conversation_message_application_id_conversation_id" to table: "conversation_message"
+CREATE INDEX "idx_conversation_message_application_id_conversation_id" ON "conversation_message" ("application_id", "conversation_id", "created_at");
@@ -0,0 +1,6 @@
+-- Add value to enum type: "conversation_message_role"
+ALTER TYPE "conversation_message_role" ADD VALUE 'tool';
+-- Modify "conversation_message" table
+ALTER TABLE "conversation_message" ADD COLUMN "tool_call_id" character varying(255) NULL, ADD COLUMN "tool_calls" json NULL;
+-- Modify "prompt_config" table
+ALTER TABLE "prompt_config" ADD COLUMN "tools" json NULL;
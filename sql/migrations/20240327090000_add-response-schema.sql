@@ -0,0 +1,2 @@
+-- Modify "prompt_config" table
+ALTER TABLE "prompt_config" ADD COLUMN "response_schema" json NULL, ADD COLUMN "response_schema_max_retries" integer NOT NULL DEFAULT 0;
@@ -0,0 +1,12 @@
+-- Modify "prompt_config" table
+ALTER TABLE "prompt_config" ADD COLUMN "version" integer NOT NULL DEFAULT 1;
+-- Create "prompt_config_version" table
+CREATE TABLE "prompt_config_version" ("id" uuid NOT NULL DEFAULT gen_random_uuid(), "version" integer NOT NULL, "model_parameters" json NOT NULL, "model_type" "model_type" NOT NULL, "model_vendor" "model_vendor" NOT NULL, "provider_prompt_messages" json NOT NULL, "expected_template_variables" character varying(255)[] NOT NULL, "fallback_models" json NULL, "tools" json NULL, "response_schema" json NULL, "response_schema_max_retries" integer NOT NULL DEFAULT 0, "created_at" timestamptz NOT NULL DEFAULT now(), "created_by_user_id" uuid NULL, "prompt_config_id" uuid NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "prompt_config_version_created_by_user_id_fkey" FOREIGN KEY ("created_by_user_id") REFERENCES "user_account" ("id") ON UPDATE NO ACTION ON DELETE SET NULL, CONSTRAINT "prompt_config_version_prompt_config_id_fkey" FOREIGN KEY ("prompt_config_id") REFERENCES "prompt_config" ("id") ON UPDATE NO ACTION ON DELETE CASCADE);
+-- Create index "prompt_config_version_prompt_config_id_version_key" to table: "prompt_config_version"
+CREATE UNIQUE INDEX "prompt_config_version_prompt_config_id_version_key" ON "prompt_config_version" ("prompt_config_id", "version");
+-- Modify "prompt_request_record" table
+ALTER TABLE "prompt_request_record" ADD COLUMN "prompt_config_version_id" uuid NULL, ADD CONSTRAINT "prompt_request_record_prompt_config_version_id_fkey" FOREIGN KEY ("prompt_config_version_id") REFERENCES "prompt_config_version" ("id") ON UPDATE NO ACTION ON DELETE CASCADE;
+-- Create index "idx_prompt_request_record_prompt_config_version_id" to table: "prompt_request_record"
+CREATE INDEX "idx_prompt_request_record_prompt_config_version_id" ON "prompt_request_record" ("prompt_config_version_id") WHERE (deleted_at IS NULL);
+-- Create the first version of the existing prompt configs
+INSERT INTO "prompt_config_version" ("version", "model_parameters", "model_type", "model_vendor", "provider_prompt_messages", "expected_template_variables", "fallback_models", "tools", "response_schema", "response_schema_max_retries", "prompt_config_id") SELECT "version", "model_parameters", "model_type", "model_vendor", "provider_prompt_messages", "expected_template_variables", "fallback_models", "tools", "response_schema", "response_schema_max_retries", "id" FROM "prompt_config";
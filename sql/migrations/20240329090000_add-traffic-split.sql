@@ -0,0 +1,4 @@
+-- Modify "prompt_config" table
+ALTER TABLE "prompt_config" ADD COLUMN "traffic_weight" integer NOT NULL DEFAULT 0;
+-- Modify "prompt_request_record" table
+ALTER TABLE "prompt_request_record" ADD COLUMN "is_traffic_split" boolean NOT NULL DEFAULT false;
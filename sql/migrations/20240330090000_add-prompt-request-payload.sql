@@ -0,0 +1,8 @@
+-- Modify "application" table
+ALTER TABLE "application" ADD COLUMN "payload_logging_enabled" boolean NOT NULL DEFAULT false, ADD COLUMN "payload_log_retention_days" integer NOT NULL DEFAULT 30, ADD COLUMN "payload_redaction_rules" json NULL;
+-- Create "prompt_request_payload" table
+CREATE TABLE "prompt_request_payload" ("id" uuid NOT NULL DEFAULT gen_random_uuid(), "template_variables" json NOT NULL, "request_messages" json NOT NULL, "response_content" text NOT NULL, "created_at" timestamptz NOT NULL DEFAULT now(), "prompt_request_record_id" uuid NOT NULL, "application_id" uuid NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "prompt_request_payload_application_id_fkey" FOREIGN KEY ("application_id") REFERENCES "application" ("id") ON UPDATE NO ACTION ON DELETE CASCADE, CONSTRAINT "prompt_request_payload_prompt_request_record_id_fkey" FOREIGN KEY ("prompt_request_record_id") REFERENCES "prompt_request_record" ("id") ON UPDATE NO ACTION ON DELETE CASCADE);
+-- Create index "idx_prompt_request_payload_application_id_created_at" to table: "prompt_request_payload"
+CREATE INDEX "idx_prompt_request_payload_application_id_created_at" ON "prompt_request_payload" ("application_id", "created_at");
+-- Create index "idx_prompt_request_payload_prompt_request_record_id" to table: "prompt_request_payload"
+CREATE INDEX "idx_prompt_request_payload_prompt_request_record_id" ON "prompt_request_payload" ("prompt_request_record_id");
@@ -0,0 +1,16 @@
+-- Create enum type "spend_budget_period"
+CREATE TYPE "spend_budget_period" AS ENUM ('DAILY', 'MONTHLY');
+-- Create enum type "spend_budget_enforcement"
+CREATE TYPE "spend_budget_enforcement" AS ENUM ('HARD', 'SOFT');
+-- Create "spend_budget" table
+CREATE TABLE "spend_budget" ("id" uuid NOT NULL DEFAULT gen_random_uuid(), "period" "spend_budget_period" NOT NULL, "enforcement" "spend_budget_enforcement" NOT NULL, "spend_limit" numeric NOT NULL, "created_at" timestamptz NOT NULL DEFAULT now(), "updated_at" timestamptz NOT NULL DEFAULT now(), "project_id" uuid NOT NULL, "application_id" uuid NULL, PRIMARY KEY ("id"), CONSTRAINT "spend_budget_application_id_fkey" FOREIGN KEY ("application_id") REFERENCES "application" ("id") ON UPDATE NO ACTION ON DELETE CASCADE, CONSTRAINT "spend_budget_project_id_fkey" FOREIGN KEY ("project_id") REFERENCES "project" ("id") ON UPDATE NO ACTION ON DELETE CASCADE);
+-- Create index "idx_spend_budget_application_id_period" to table: "spend_budget"
+CREATE UNIQUE INDEX "idx_spend_budget_application_id_period" ON "spend_budget" ("application_id", "period") WHERE (application_id IS NOT NULL);
+-- Create index "idx_spend_budget_project_id" to table: "spend_budget"
+CREATE INDEX "idx_spend_budget_project_id" ON "spend_budget" ("project_id");
+-- Create index "idx_spend_budget_project_id_period" to table: "spend_budget"
+CREATE UNIQUE INDEX "idx_spend_budget_project_id_period" ON "spend_budget" ("project_id", "period") WHERE (application_id IS NULL);
+-- Create "spend_budget_alert" table
+CREATE TABLE "spend_budget_alert" ("id" uuid NOT NULL DEFAULT gen_random_uuid(), "threshold" integer NOT NULL, "spend" numeric NOT NULL, "period_start" timestamptz NOT NULL, "created_at" timestamptz NOT NULL DEFAULT now(), "spend_budget_id" uuid NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "spend_budget_alert_spend_budget_id_period_start_threshold_key" UNIQUE ("spend_budget_id", "period_start", "threshold"), CONSTRAINT "spend_budget_alert_spend_budget_id_fkey" FOREIGN KEY ("spend_budget_id") REFERENCES "spend_budget" ("id") ON UPDATE NO ACTION ON DELETE CASCADE);
+-- Create index "idx_spend_budget_alert_spend_budget_id_created_at" to table: "spend_budget_alert"
+CREATE INDEX "idx_spend_budget_alert_spend_budget_id_created_at" ON "spend_budget_alert" ("spend_budget_id", "created_at");
@@ -0,0 +1,12 @@
+-- Create enum type "credit_ledger_entry_type"
+CREATE TYPE "credit_ledger_entry_type" AS ENUM ('DEBIT', 'ADJUSTMENT');
+-- Create "credit_ledger_entry" table
+CREATE TABLE "credit_ledger_entry" ("id" uuid NOT NULL DEFAULT gen_random_uuid(), "entry_type" "credit_ledger_entry_type" NOT NULL, "amount" numeric NOT NULL, "balance_after" numeric NOT NULL, "description" character varying(255) NOT NULL DEFAULT '', "created_at" timestamptz NOT NULL DEFAULT now(), "project_id" uuid NOT NULL, "prompt_request_record_id" uuid NULL, PRIMARY KEY ("id"), CONSTRAINT "credit_ledger_entry_prompt_request_record_id_key" UNIQUE ("prompt_request_record_id"), CONSTRAINT "credit_ledger_entry_project_id_fkey" FOREIGN KEY ("project_id") REFERENCES "project" ("id") ON UPDATE NO ACTION ON DELETE CASCADE, CONSTRAINT "credit_ledger_entry_prompt_request_record_id_fkey" FOREIGN KEY ("prompt_request_record_id") REFERENCES "prompt_request_record" ("id") ON UPDATE NO ACTION ON DELETE SET NULL);
+-- Create index "idx_credit_ledger_entry_project_id_created_at" to table: "credit_ledger_entry"
+CREATE INDEX "idx_credit_ledger_entry_project_id_created_at" ON "credit_ledger_entry" ("project_id", "created_at");
+-- Create "credit_hold" table
+CREATE TABLE "credit_hold" ("id" uuid NOT NULL DEFAULT gen_random_uuid(), "amount" numeric NOT NULL, "created_at" timestamptz NOT NULL DEFAULT now(), "expires_at" timestamptz NOT NULL, "project_id" uuid NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "credit_hold_project_id_fkey" FOREIGN KEY ("project_id") REFERENCES "project" ("id") ON UPDATE NO ACTION ON DELETE CASCADE);
+-- Create index "idx_credit_hold_project_id_expires_at" to table: "credit_hold"
+CREATE INDEX "idx_credit_hold_project_id_expires_at" ON "credit_hold" ("project_id", "expires_at");
+-- Open the ledger of the existing projects with their current credits
+INSERT INTO "credit_ledger_entry" ("entry_type", "amount", "balance_after", "description", "project_id") SELECT 'ADJUSTMENT', "credits", "credits", 'opening balance', "id" FROM "project";
@@ -0,0 +1,4 @@
+-- Add value to enum type: "credit_ledger_entry_type"
+ALTER TYPE "credit_ledger_entry_type" ADD VALUE 'GRANT';
+-- Modify "credit_ledger_entry" table
+ALTER TABLE "credit_ledger_entry" ADD COLUMN "payment_reference" character varying(255) NOT NULL DEFAULT '', ADD COLUMN "user_account_id" uuid NULL, ADD CONSTRAINT "credit_ledger_entry_user_account_id_fkey" FOREIGN KEY ("user_account_id") REFERENCES "user_account" ("id") ON UPDATE NO ACTION ON DELETE SET NULL;
@@ -0,0 +1,4 @@
+-- Modify "provider_model_pricing" table
+ALTER TABLE "provider_model_pricing" ADD COLUMN "project_id" uuid NULL, ADD CONSTRAINT "provider_model_pricing_project_id_fkey" FOREIGN KEY ("project_id") REFERENCES "project" ("id") ON UPDATE NO ACTION ON DELETE CASCADE;
+-- Create index "idx_provider_model_pricing_project_id" to table: "provider_model_pricing"
+CREATE INDEX "idx_provider_model_pricing_project_id" ON "provider_model_pricing" ("project_id");
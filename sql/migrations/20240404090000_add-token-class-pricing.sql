@@ -0,0 +1,2 @@
+-- Modify "provider_model_pricing" table
+ALTER TABLE "provider_model_pricing" ADD COLUMN "cached_input_token_price" numeric NULL, ADD COLUMN "reasoning_token_price" numeric NULL, ADD COLUMN "image_token_price" numeric NULL;
@@ -0,0 +1,10 @@
+-- Add value to enum type: "model_type"
+ALTER TYPE "model_type" ADD VALUE 'text-embedding-3-small';
+ALTER TYPE "model_type" ADD VALUE 'text-embedding-3-large';
+ALTER TYPE "model_type" ADD VALUE 'text-embedding-ada-002';
+ALTER TYPE "model_type" ADD VALUE 'embed-english-v3.0';
+ALTER TYPE "model_type" ADD VALUE 'embed-multilingual-v3.0';
+-- Modify "prompt_request_record" table
+ALTER TABLE "prompt_request_record" ADD COLUMN "application_id" uuid NULL, ADD CONSTRAINT "prompt_request_record_application_id_fkey" FOREIGN KEY ("application_id") REFERENCES "application" ("id") ON UPDATE NO ACTION ON DELETE CASCADE;
+-- Create index "idx_prompt_request_record_application_id" to table: "prompt_request_record"
+CREATE INDEX "idx_prompt_request_record_application_id" ON "prompt_request_record" ("application_id") WHERE (deleted_at IS NULL);
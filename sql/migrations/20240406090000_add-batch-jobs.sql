@@ -0,0 +1,14 @@
+-- Create enum type "batch_job_status"
+CREATE TYPE "batch_job_status" AS ENUM ('PENDING', 'RUNNING', 'COMPLETED', 'FAILED');
+-- Create "batch_job" table
+CREATE TABLE "batch_job" ("id" uuid NOT NULL DEFAULT gen_random_uuid(), "status" "batch_job_status" NOT NULL DEFAULT 'PENDING', "prompt_config_id" uuid NULL, "total_items" integer NOT NULL, "callback_url" character varying(2048) NULL, "encrypted_callback_secret" character varying(255) NULL, "callback_attempts" integer NOT NULL DEFAULT 0, "callback_available_at" timestamptz NULL, "callback_delivered_at" timestamptz NULL, "created_at" timestamptz NOT NULL DEFAULT now(), "started_at" timestamptz NULL, "finished_at" timestamptz NULL, "application_id" uuid NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "batch_job_application_id_fkey" FOREIGN KEY ("application_id") REFERENCES "application" ("id") ON UPDATE NO ACTION ON DELETE CASCADE, CONSTRAINT "batch_job_prompt_config_id_fkey" FOREIGN KEY ("prompt_config_id") REFERENCES "prompt_config" ("id") ON UPDATE NO ACTION ON DELETE SET NULL);
+-- Create index "idx_batch_job_application_id_created_at" to table: "batch_job"
+CREATE INDEX "idx_batch_job_application_id_created_at" ON "batch_job" ("application_id", "created_at");
+-- Create index "idx_batch_job_callback_available_at" to table: "batch_job"
+CREATE INDEX "idx_batch_job_callback_available_at" ON "batch_job" ("callback_available_at") WHERE (callback_delivered_at IS NULL);
+-- Create enum type "batch_job_item_status"
+CREATE TYPE "batch_job_item_status" AS ENUM ('PENDING', 'RUNNING', 'COMPLETED', 'FAILED');
+-- Create "batch_job_item" table
+CREATE TABLE "batch_job_item" ("id" uuid NOT NULL DEFAULT gen_random_uuid(), "item_index" integer NOT NULL, "template_variables" json NOT NULL, "status" "batch_job_item_status" NOT NULL DEFAULT 'PENDING', "attempts" integer NOT NULL DEFAULT 0, "available_at" timestamptz NOT NULL DEFAULT now(), "content" text NULL, "error_log" text NULL, "created_at" timestamptz NOT NULL DEFAULT now(), "finished_at" timestamptz NULL, "batch_job_id" uuid NOT NULL, "prompt_request_record_id" uuid NULL, PRIMARY KEY ("id"), CONSTRAINT "batch_job_item_batch_job_id_item_index_key" UNIQUE ("batch_job_id", "item_index"), CONSTRAINT "batch_job_item_batch_job_id_fkey" FOREIGN KEY ("batch_job_id") REFERENCES "batch_job" ("id") ON UPDATE NO ACTION ON DELETE CASCADE, CONSTRAINT "batch_job_item_prompt_request_record_id_fkey" FOREIGN KEY ("prompt_request_record_id") REFERENCES "prompt_request_record" ("id") ON UPDATE NO ACTION ON DELETE SET NULL);
+-- Create index "idx_batch_job_item_status_available_at" to table: "batch_job_item"
+CREATE INDEX "idx_batch_job_item_status_available_at" ON "batch_job_item" ("available_at") WHERE (status = ANY (ARRAY['PENDING'::batch_job_item_status, 'RUNNING'::batch_job_item_status]));
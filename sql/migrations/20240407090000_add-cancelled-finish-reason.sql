@@ -0,0 +1,2 @@
+-- Add value to enum type: "prompt_finish_reason"
+ALTER TYPE "prompt_finish_reason" ADD VALUE 'CANCELLED';
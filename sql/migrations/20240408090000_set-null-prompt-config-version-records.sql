@@ -0,0 +1,2 @@
+-- Modify "prompt_request_record" table
+ALTER TABLE "prompt_request_record" DROP CONSTRAINT "prompt_request_record_prompt_config_version_id_fkey", ADD CONSTRAINT "prompt_request_record_prompt_config_version_id_fkey" FOREIGN KEY ("prompt_config_version_id") REFERENCES "prompt_config_version" ("id") ON UPDATE NO ACTION ON DELETE SET NULL;
@@ -0,0 +1,2 @@
+-- Backfill "duration_ms" of "prompt_request_record" from its start and finish times
+UPDATE "prompt_request_record" SET "duration_ms" = CASE WHEN "is_cache_hit" THEN NULL ELSE (EXTRACT(EPOCH FROM ("finish_time" - "start_time")) * 1000)::integer END;
@@ -1,14 +1,22 @@
 ---- api-key
 
 -- name: CreateAPIKey :one
-INSERT INTO api_key (application_id, name, is_internal)
-VALUES ($1, $2, $3)
+INSERT INTO api_key (
+    application_id,
+    name,
+    is_internal,
+    rate_limit_requests_per_minute,
+    rate_limit_tokens_per_minute
+)
+VALUES ($1, $2, $3, $4, $5)
 RETURNING *;
 
 -- name: RetrieveAPIKeys :many
 SELECT
     t.id,
     t.name,
+    t.rate_limit_requests_per_minute,
+    t.rate_limit_tokens_per_minute,
     t.created_at
 FROM api_key AS t
 LEFT JOIN application AS app ON t.application_id = app.id
@@ -17,6 +25,17 @@ WHERE
     AND t.deleted_at IS NULL AND app.deleted_at IS NULL AND t.is_internal = FALSE
 ORDER BY t.created_at;
 
+-- name: UpdateAPIKeyRateLimits :one
+UPDATE api_key
+SET
+    rate_limit_requests_per_minute = $3,
+    rate_limit_tokens_per_minute = $4
+WHERE
+    id = $1
+    AND application_id = $2
+    AND deleted_at IS NULL
+RETURNING *;
+
 -- name: DeleteAPIKey :exec
 UPDATE api_key
 SET deleted_at = NOW()
@@ -25,7 +44,12 @@ WHERE id = $1;
 -- name: RetrieveApplicationDataForAPIKey :one
 SELECT
     app.id AS application_id,
-    app.project_id
+    app.project_id,
+    t.is_internal,
+    t.rate_limit_requests_per_minute AS api_key_requests_per_minute,
+    t.rate_limit_tokens_per_minute AS api_key_tokens_per_minute,
+    app.rate_limit_requests_per_minute AS application_requests_per_minute,
+    app.rate_limit_tokens_per_minute AS application_tokens_per_minute
 FROM api_key AS t
 LEFT JOIN application AS app ON t.application_id = app.id
 WHERE
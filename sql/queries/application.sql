@@ -4,9 +4,11 @@
 INSERT INTO application (
     project_id,
     name,
-    description
+    description,
+    rate_limit_requests_per_minute,
+    rate_limit_tokens_per_minute
 )
-VALUES ($1, $2, $3)
+VALUES ($1, $2, $3, $4, $5)
 RETURNING *;
 
 -- name: UpdateApplication :one
@@ -14,6 +16,20 @@ UPDATE application
 SET
     name = $2,
     description = $3,
+    rate_limit_requests_per_minute = $4,
+    rate_limit_tokens_per_minute = $5,
+    updated_at = NOW()
+WHERE
+    id = $1
+    AND deleted_at IS NULL
+RETURNING *;
+
+-- name: UpdateApplicationPayloadLogging :one
+UPDATE application
+SET
+    payload_logging_enabled = $2,
+    payload_log_retention_days = $3,
+    payload_redaction_rules = $4,
     updated_at = NOW()
 WHERE
     id = $1
@@ -30,6 +46,11 @@ SELECT
     id,
     description,
     name,
+    rate_limit_requests_per_minute,
+    rate_limit_tokens_per_minute,
+    payload_logging_enabled,
+    payload_log_retention_days,
+    payload_redaction_rules,
     created_at,
     updated_at,
     project_id
@@ -43,6 +64,8 @@ SELECT
     id,
     description,
     name,
+    rate_limit_requests_per_minute,
+    rate_limit_tokens_per_minute,
     created_at,
     updated_at,
     project_id
@@ -53,18 +76,36 @@ WHERE
 
 -- name: RetrieveApplicationAPIRequestCount :one
 SELECT COUNT(prr.id) AS total_requests
-FROM application AS a
-INNER JOIN prompt_config AS pc ON a.id = pc.application_id
-INNER JOIN prompt_request_record AS prr ON pc.id = prr.prompt_config_id
+FROM prompt_request_record AS prr
+LEFT JOIN prompt_config AS pc ON prr.prompt_config_id = pc.id
+INNER JOIN application AS a ON COALESCE(prr.application_id, pc.application_id) = a.id
 WHERE
     a.id = $1
     AND prr.created_at BETWEEN $2 AND $3;
 
 -- name: RetrieveApplicationTokensTotalCost :one
 SELECT COALESCE(SUM(prr.request_tokens_cost + prr.response_tokens_cost), 0)
-FROM application AS app
-LEFT JOIN prompt_config AS pc ON app.id = pc.application_id
-LEFT JOIN prompt_request_record AS prr ON pc.id = prr.prompt_config_id
+FROM prompt_request_record AS prr
+LEFT JOIN prompt_config AS pc ON prr.prompt_config_id = pc.id
+INNER JOIN application AS app ON COALESCE(prr.application_id, pc.application_id) = app.id
 WHERE
     app.id = $1
     AND prr.created_at BETWEEN $2 AND $3;
+
+-- name: RetrieveApplicationTrafficSplitAnalytics :many
+SELECT
+    pc.id AS prompt_config_id,
+    pc.name,
+    pc.traffic_weight,
+    COUNT(prr.id) AS total_requests,
+    COUNT(prr.id) FILTER (WHERE prr.finish_reason = 'ERROR') AS total_errors,
+    COALESCE(SUM(prr.request_tokens_cost + prr.response_tokens_cost), 0)::numeric AS tokens_cost,
+    COALESCE(AVG(prr.duration_ms), 0)::float8 AS average_duration_ms
+FROM prompt_config AS pc
+INNER JOIN prompt_request_record AS prr ON pc.id = prr.prompt_config_id
+WHERE
+    pc.application_id = $1
+    AND prr.is_traffic_split = TRUE
+    AND prr.created_at BETWEEN $2 AND $3
+GROUP BY pc.id
+ORDER BY pc.created_at, pc.id;
@@ -0,0 +1,171 @@
+---- batch_job
+
+-- name: CreateBatchJob :one
+INSERT INTO batch_job (
+    application_id,
+    prompt_config_id,
+    total_items,
+    callback_url,
+    encrypted_callback_secret
+)
+VALUES (
+    sqlc.arg(application_id),
+    sqlc.narg(prompt_config_id),
+    sqlc.arg(total_items),
+    sqlc.narg(callback_url),
+    sqlc.narg(encrypted_callback_secret)
+)
+RETURNING *;
+
+-- name: RetrieveBatchJob :one
+SELECT *
+FROM batch_job
+WHERE id = $1;
+
+-- name: RetrieveBatchJobProgress :one
+SELECT
+    COUNT(*) FILTER (WHERE status = 'COMPLETED') AS completed_items,
+    COUNT(*) FILTER (WHERE status = 'FAILED') AS failed_items
+FROM batch_job_item
+WHERE batch_job_id = $1;
+
+-- name: StartBatchJob :exec
+UPDATE batch_job
+SET
+    status = 'RUNNING',
+    started_at = now()
+WHERE id = $1 AND status = 'PENDING';
+
+-- name: FinishBatchJob :one
+UPDATE batch_job
+SET
+    status = (
+        CASE
+            WHEN EXISTS (
+                SELECT 1
+                FROM batch_job_item AS bji
+                WHERE bji.batch_job_id = sqlc.arg(id) AND bji.status = 'COMPLETED'
+            ) THEN 'COMPLETED'
+            ELSE 'FAILED'
+        END
+    )::batch_job_status,
+    finished_at = now(),
+    callback_available_at = (
+        CASE WHEN callback_url IS NOT NULL THEN now() END
+    )
+WHERE
+    id = sqlc.arg(id)
+    AND status IN ('PENDING', 'RUNNING')
+    AND NOT EXISTS (
+        SELECT 1
+        FROM batch_job_item AS bji
+        WHERE
+            bji.batch_job_id = sqlc.arg(id)
+            AND bji.status IN ('PENDING', 'RUNNING')
+    )
+RETURNING *;
+
+-- name: ClaimBatchJobCallback :one
+UPDATE batch_job
+SET
+    callback_attempts = callback_attempts + 1,
+    callback_available_at = now()
+    + make_interval(secs => sqlc.arg(retry_delay_seconds)::float)
+WHERE id = (
+    SELECT bj.id
+    FROM batch_job AS bj
+    WHERE
+        bj.callback_delivered_at IS NULL
+        AND bj.callback_available_at <= now()
+        AND bj.callback_attempts < sqlc.arg(max_attempts)
+    ORDER BY bj.callback_available_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: SetBatchJobCallbackDelivered :exec
+UPDATE batch_job
+SET callback_delivered_at = now()
+WHERE id = $1;
+
+---- batch_job_item
+
+-- name: CreateBatchJobItems :copyfrom
+INSERT INTO batch_job_item (batch_job_id, item_index, template_variables)
+VALUES ($1, $2, $3);
+
+-- name: ClaimBatchJobItem :one
+UPDATE batch_job_item AS bji
+SET
+    status = 'RUNNING',
+    attempts = bji.attempts + 1,
+    available_at = now()
+    + make_interval(secs => sqlc.arg(lease_seconds)::float)
+FROM batch_job AS bj
+INNER JOIN application AS a ON bj.application_id = a.id
+WHERE
+    bji.batch_job_id = bj.id
+    AND bji.id = (
+        SELECT i.id
+        FROM batch_job_item AS i
+        WHERE
+            i.status IN ('PENDING', 'RUNNING')
+            AND i.available_at <= now()
+        ORDER BY i.available_at
+        LIMIT 1
+        FOR UPDATE SKIP LOCKED
+    )
+RETURNING
+    bji.id,
+    bji.item_index,
+    bji.template_variables,
+    bji.attempts,
+    bji.batch_job_id,
+    bj.prompt_config_id,
+    bj.application_id,
+    a.project_id;
+
+-- name: CompleteBatchJobItem :exec
+UPDATE batch_job_item
+SET
+    status = 'COMPLETED',
+    content = sqlc.arg(content),
+    error_log = NULL,
+    prompt_request_record_id = sqlc.arg(prompt_request_record_id),
+    finished_at = now()
+WHERE id = sqlc.arg(id);
+
+-- name: FailBatchJobItem :exec
+UPDATE batch_job_item
+SET
+    status = 'FAILED',
+    error_log = sqlc.arg(error_log),
+    prompt_request_record_id = sqlc.narg(prompt_request_record_id),
+    finished_at = now()
+WHERE id = sqlc.arg(id);
+
+-- name: RetryBatchJobItem :exec
+UPDATE batch_job_item
+SET
+    status = 'PENDING',
+    error_log = sqlc.arg(error_log),
+    available_at = now()
+    + make_interval(secs => sqlc.arg(retry_delay_seconds)::float)
+WHERE id = sqlc.arg(id);
+
+-- name: RetrieveBatchJobItems :many
+SELECT
+    bji.item_index,
+    bji.status,
+    bji.content,
+    bji.error_log,
+    bji.attempts,
+    COALESCE(prr.request_tokens, 0)::int AS request_tokens,
+    COALESCE(prr.response_tokens, 0)::int AS response_tokens
+FROM batch_job_item AS bji
+LEFT JOIN prompt_request_record AS prr ON bji.prompt_request_record_id = prr.id
+WHERE bji.batch_job_id = sqlc.arg(batch_job_id)
+ORDER BY bji.item_index
+LIMIT sqlc.arg(page_limit)
+OFFSET sqlc.arg(page_offset);
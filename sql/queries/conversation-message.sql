@@ -0,0 +1,27 @@
+---- conversation message
+
+-- name: CreateConversationMessage :exec
+INSERT INTO conversation_message (
+    application_id,
+    conversation_id,
+    role,
+    content,
+    tool_call_id,
+    tool_calls,
+    created_at
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: RetrieveLatestConversationMessages :many
+SELECT
+    cm.role,
+    cm.content,
+    cm.tool_call_id,
+    cm.tool_calls,
+    cm.created_at
+FROM conversation_message AS cm
+WHERE
+    cm.application_id = $1
+    AND cm.conversation_id = $2
+ORDER BY cm.created_at DESC
+LIMIT $3;
@@ -0,0 +1,161 @@
+---- credit_ledger_entry
+
+-- name: RetrieveProjectCreditsForUpdate :one
+SELECT credits
+FROM project
+WHERE id = $1
+FOR UPDATE;
+
+-- name: SetProjectCredits :exec
+UPDATE project
+SET credits = $2
+WHERE id = $1;
+
+-- name: CreateCreditLedgerEntry :one
+INSERT INTO credit_ledger_entry (
+    project_id,
+    prompt_request_record_id,
+    entry_type,
+    amount,
+    balance_after,
+    description
+)
+VALUES (
+    sqlc.arg(project_id),
+    sqlc.narg(prompt_request_record_id),
+    sqlc.arg(entry_type),
+    sqlc.arg(amount),
+    sqlc.arg(balance_after),
+    sqlc.arg(description)
+)
+ON CONFLICT (prompt_request_record_id) DO NOTHING
+RETURNING *;
+
+-- name: CreateCreditGrantLedgerEntry :one
+INSERT INTO credit_ledger_entry (
+    project_id,
+    user_account_id,
+    entry_type,
+    amount,
+    balance_after,
+    description,
+    payment_reference
+)
+VALUES (
+    sqlc.arg(project_id),
+    sqlc.narg(user_account_id),
+    'GRANT',
+    sqlc.arg(amount),
+    sqlc.arg(balance_after),
+    sqlc.arg(description),
+    sqlc.arg(payment_reference)
+)
+RETURNING *;
+
+-- name: RetrieveProjectCreditLedgerBalance :one
+SELECT COALESCE(SUM(amount), 0)::numeric AS balance
+FROM credit_ledger_entry
+WHERE project_id = $1;
+
+-- name: RetrieveProjectCreditLedgerBalanceBefore :one
+SELECT COALESCE(SUM(amount), 0)::numeric AS balance
+FROM credit_ledger_entry
+WHERE project_id = $1 AND created_at < $2;
+
+-- name: RetrieveProjectCreditLedger :many
+SELECT
+    cle.id,
+    cle.entry_type,
+    cle.amount,
+    cle.description,
+    cle.payment_reference,
+    ua.display_name AS created_by,
+    cle.created_at,
+    1::bigint AS entries_count
+FROM credit_ledger_entry AS cle
+LEFT JOIN user_account AS ua ON cle.user_account_id = ua.id
+WHERE
+    cle.project_id = sqlc.arg(project_id)
+    AND cle.entry_type <> 'DEBIT'
+    AND cle.created_at >= sqlc.arg(from_date)
+    AND cle.created_at < sqlc.arg(to_date)
+UNION ALL
+SELECT
+    NULL::uuid AS id,
+    'DEBIT'::credit_ledger_entry_type AS entry_type,
+    SUM(cle.amount)::numeric AS amount,
+    '' AS description,
+    '' AS payment_reference,
+    NULL::varchar AS created_by,
+    date_trunc('day', cle.created_at)::timestamptz AS created_at,
+    COUNT(*) AS entries_count
+FROM credit_ledger_entry AS cle
+WHERE
+    cle.project_id = sqlc.arg(project_id)
+    AND cle.entry_type = 'DEBIT'
+    AND cle.created_at >= sqlc.arg(from_date)
+    AND cle.created_at < sqlc.arg(to_date)
+GROUP BY date_trunc('day', cle.created_at)
+ORDER BY created_at DESC, entry_type
+LIMIT sqlc.arg(page_limit)
+OFFSET sqlc.arg(page_offset);
+
+-- name: RetrieveProjectDailyCreditConsumption :many
+SELECT
+    date_trunc('day', created_at)::timestamptz AS date,
+    (-SUM(amount))::numeric AS consumption,
+    COUNT(*) AS requests_count
+FROM credit_ledger_entry
+WHERE
+    project_id = sqlc.arg(project_id)
+    AND entry_type = 'DEBIT'
+    AND created_at >= sqlc.arg(from_date)
+    AND created_at < sqlc.arg(to_date)
+GROUP BY date_trunc('day', created_at)
+ORDER BY date;
+
+-- name: RetrieveUnrecordedPromptRequestRecords :many
+SELECT
+    prr.id,
+    prr.request_tokens_cost,
+    prr.response_tokens_cost,
+    a.project_id
+FROM prompt_request_record AS prr
+LEFT JOIN prompt_config AS pc ON prr.prompt_config_id = pc.id
+INNER JOIN application AS a ON COALESCE(prr.application_id, pc.application_id) = a.id
+WHERE
+    (sqlc.narg(project_id)::uuid IS NULL OR a.project_id = sqlc.narg(project_id)::uuid)
+    AND prr.created_at < sqlc.arg(created_before)
+    AND prr.request_tokens_cost + prr.response_tokens_cost > 0
+    AND prr.created_at >= (
+        SELECT MIN(cle.created_at)
+        FROM credit_ledger_entry AS cle
+        WHERE cle.project_id = a.project_id
+    )
+    AND NOT EXISTS (
+        SELECT 1
+        FROM credit_ledger_entry AS cle
+        WHERE cle.prompt_request_record_id = prr.id
+    )
+ORDER BY prr.created_at
+LIMIT sqlc.arg(page_limit);
+
+---- credit_hold
+
+-- name: CreateCreditHold :one
+INSERT INTO credit_hold (project_id, amount, expires_at)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: RetrieveProjectActiveCreditHoldsTotal :one
+SELECT COALESCE(SUM(amount), 0)::numeric AS total
+FROM credit_hold
+WHERE project_id = $1 AND expires_at > NOW();
+
+-- name: DeleteCreditHold :exec
+DELETE FROM credit_hold
+WHERE id = $1;
+
+-- name: DeleteExpiredCreditHolds :execrows
+DELETE FROM credit_hold
+WHERE expires_at <= NOW();
@@ -63,22 +63,20 @@ WHERE
 
 -- name: RetrieveProjectAPIRequestCount :one
 SELECT COUNT(prr.id) AS total_requests
-FROM project AS p
-INNER JOIN application AS a ON p.id = a.project_id
-INNER JOIN prompt_config AS pc ON a.id = pc.application_id
-INNER JOIN prompt_request_record AS prr ON pc.id = prr.prompt_config_id
+FROM prompt_request_record AS prr
+LEFT JOIN prompt_config AS pc ON prr.prompt_config_id = pc.id
+INNER JOIN application AS a ON COALESCE(prr.application_id, pc.application_id) = a.id
 WHERE
-    p.id = $1
+    a.project_id = $1
     AND prr.created_at BETWEEN $2 AND $3;
 
 -- name: RetrieveProjectTokensTotalCost :one
 SELECT COALESCE(SUM(prr.request_tokens_cost + prr.response_tokens_cost), 0)
-FROM project AS p
-INNER JOIN application AS app ON p.id = app.project_id
-LEFT JOIN prompt_config AS pc ON app.id = pc.application_id
-LEFT JOIN prompt_request_record AS prr ON pc.id = prr.prompt_config_id
+FROM prompt_request_record AS prr
+LEFT JOIN prompt_config AS pc ON prr.prompt_config_id = pc.id
+INNER JOIN application AS app ON COALESCE(prr.application_id, pc.application_id) = app.id
 WHERE
-    p.id = $1
+    app.project_id = $1
     AND prr.created_at BETWEEN $2 AND $3;
 
 -- name: UpdateProjectCredits :exec
@@ -0,0 +1,62 @@
+---- prompt config version
+
+-- name: CreatePromptConfigVersion :one
+INSERT INTO prompt_config_version (
+    version,
+    model_parameters,
+    model_type,
+    model_vendor,
+    provider_prompt_messages,
+    expected_template_variables,
+    fallback_models,
+    tools,
+    response_schema,
+    response_schema_max_retries,
+    created_by_user_id,
+    prompt_config_id
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+RETURNING *;
+
+-- name: RetrievePromptConfigVersions :many
+SELECT
+    pcv.id,
+    pcv.version,
+    pcv.model_parameters,
+    pcv.model_type,
+    pcv.model_vendor,
+    pcv.provider_prompt_messages,
+    pcv.expected_template_variables,
+    pcv.fallback_models,
+    pcv.tools,
+    pcv.response_schema,
+    pcv.response_schema_max_retries,
+    pcv.created_at,
+    pcv.created_by_user_id,
+    ua.display_name AS created_by_display_name
+FROM prompt_config_version AS pcv
+LEFT JOIN user_account AS ua ON pcv.created_by_user_id = ua.id
+WHERE pcv.prompt_config_id = $1
+ORDER BY pcv.version DESC;
+
+-- name: RetrievePromptConfigVersion :one
+SELECT
+    pcv.id,
+    pcv.version,
+    pcv.model_parameters,
+    pcv.model_type,
+    pcv.model_vendor,
+    pcv.provider_prompt_messages,
+    pcv.expected_template_variables,
+    pcv.fallback_models,
+    pcv.tools,
+    pcv.response_schema,
+    pcv.response_schema_max_retries,
+    pcv.created_at,
+    pcv.created_by_user_id,
+    ua.display_name AS created_by_display_name
+FROM prompt_config_version AS pcv
+LEFT JOIN user_account AS ua ON pcv.created_by_user_id = ua.id
+WHERE
+    pcv.id = $1
+    AND pcv.prompt_config_id = $2;
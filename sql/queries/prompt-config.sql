@@ -10,9 +10,14 @@ INSERT INTO prompt_config (
     expected_template_variables,
     is_default,
     application_id,
-    is_test_config
+    is_test_config,
+    fallback_models,
+    response_cache_ttl_seconds,
+    tools,
+    response_schema,
+    response_schema_max_retries
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 RETURNING *;
 
 -- name: CheckDefaultPromptConfigExists :one
@@ -45,12 +50,39 @@ SET
     provider_prompt_messages = $6,
     expected_template_variables = $7,
     is_test_config = $8,
+    fallback_models = $9,
+    response_cache_ttl_seconds = $10,
+    tools = $11,
+    response_schema = $12,
+    response_schema_max_retries = $13,
+    version = version + sqlc.arg(version_increment)::int,
     updated_at = NOW()
 WHERE
     id = $1
     AND deleted_at IS NULL
 RETURNING *;
 
+-- name: ResetApplicationTrafficWeights :exec
+UPDATE prompt_config
+SET
+    traffic_weight = 0,
+    updated_at = NOW()
+WHERE
+    application_id = $1
+    AND deleted_at IS NULL
+    AND traffic_weight > 0;
+
+-- name: UpdatePromptConfigTrafficWeight :execrows
+UPDATE prompt_config
+SET
+    traffic_weight = $3,
+    updated_at = NOW()
+WHERE
+    id = $1
+    AND application_id = $2
+    AND deleted_at IS NULL
+    AND is_test_config = FALSE;
+
 -- name: DeletePromptConfig :exec
 UPDATE prompt_config
 SET deleted_at = NOW()
@@ -58,22 +90,31 @@ WHERE id = $1;
 
 -- name: RetrievePromptConfig :one
 SELECT
-    id,
-    name,
-    model_parameters,
-    model_type,
-    model_vendor,
-    provider_prompt_messages,
-    expected_template_variables,
-    is_default,
-    created_at,
-    updated_at,
-    application_id,
-    is_test_config
-FROM prompt_config
+    pc.id,
+    pc.name,
+    pc.model_parameters,
+    pc.model_type,
+    pc.model_vendor,
+    pc.provider_prompt_messages,
+    pc.expected_template_variables,
+    pc.fallback_models,
+    pc.response_cache_ttl_seconds,
+    pc.tools,
+    pc.response_schema,
+    pc.response_schema_max_retries,
+    pc.version,
+    pcv.id AS version_id,
+    pc.traffic_weight,
+    pc.is_default,
+    pc.created_at,
+    pc.updated_at,
+    pc.application_id,
+    pc.is_test_config
+FROM prompt_config AS pc
+LEFT JOIN prompt_config_version AS pcv ON pc.id = pcv.prompt_config_id AND pc.version = pcv.version
 WHERE
-    id = $1
-    AND deleted_at IS NULL;
+    pc.id = $1
+    AND pc.deleted_at IS NULL;
 
 -- name: RetrievePromptConfigs :many
 SELECT
@@ -84,6 +125,13 @@ SELECT
     model_vendor,
     provider_prompt_messages,
     expected_template_variables,
+    fallback_models,
+    response_cache_ttl_seconds,
+    tools,
+    response_schema,
+    response_schema_max_retries,
+    version,
+    traffic_weight,
     is_default,
     created_at,
     updated_at,
@@ -95,24 +143,45 @@ WHERE
     AND is_test_config = FALSE;
 
 -- name: RetrieveDefaultPromptConfig :one
+SELECT
+    pc.id,
+    pc.name,
+    pc.model_parameters,
+    pc.model_type,
+    pc.model_vendor,
+    pc.provider_prompt_messages,
+    pc.expected_template_variables,
+    pc.fallback_models,
+    pc.response_cache_ttl_seconds,
+    pc.tools,
+    pc.response_schema,
+    pc.response_schema_max_retries,
+    pc.version,
+    pcv.id AS version_id,
+    pc.traffic_weight,
+    pc.is_default,
+    pc.created_at,
+    pc.updated_at,
+    pc.application_id
+FROM prompt_config AS pc
+LEFT JOIN prompt_config_version AS pcv ON pc.id = pcv.prompt_config_id AND pc.version = pcv.version
+WHERE
+    pc.application_id = $1
+    AND pc.deleted_at IS NULL
+    AND pc.is_default = TRUE
+    AND pc.is_test_config = FALSE;
+
+-- name: RetrieveTrafficSplitPromptConfigs :many
 SELECT
     id,
-    name,
-    model_parameters,
-    model_type,
-    model_vendor,
-    provider_prompt_messages,
-    expected_template_variables,
-    is_default,
-    created_at,
-    updated_at,
-    application_id
+    traffic_weight
 FROM prompt_config
 WHERE
     application_id = $1
     AND deleted_at IS NULL
-    AND is_default = TRUE
-    AND is_test_config = FALSE;
+    AND is_test_config = FALSE
+    AND traffic_weight > 0
+ORDER BY created_at, id;
 
 -- name: RetrievePromptConfigAPIRequestCount :one
 SELECT COUNT(prr.id) AS total_requests
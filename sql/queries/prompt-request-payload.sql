@@ -0,0 +1,50 @@
+---- prompt request payload
+
+-- name: CreatePromptRequestPayload :exec
+INSERT INTO prompt_request_payload (
+    template_variables,
+    request_messages,
+    response_content,
+    prompt_request_record_id,
+    application_id
+)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: RetrievePromptRequestPayloads :many
+SELECT
+    prp.id,
+    prp.template_variables,
+    prp.request_messages,
+    prp.response_content,
+    prp.created_at,
+    prr.id AS prompt_request_record_id,
+    prr.finish_reason,
+    prr.request_tokens,
+    prr.response_tokens,
+    prr.duration_ms,
+    prr.is_stream_response,
+    prr.is_cache_hit,
+    pc.id AS prompt_config_id,
+    pc.name AS prompt_config_name
+FROM prompt_request_payload AS prp
+INNER JOIN prompt_request_record AS prr ON prp.prompt_request_record_id = prr.id
+INNER JOIN prompt_config AS pc ON prr.prompt_config_id = pc.id
+WHERE
+    prp.application_id = sqlc.arg(application_id)
+    AND prp.created_at BETWEEN sqlc.arg(from_date) AND sqlc.arg(to_date)
+    AND (
+        sqlc.arg(search)::text = ''
+        OR strpos(lower(prp.response_content), lower(sqlc.arg(search)::text)) > 0
+        OR strpos(lower(prp.request_messages::text), lower(sqlc.arg(search)::text)) > 0
+        OR strpos(lower(prp.template_variables::text), lower(sqlc.arg(search)::text)) > 0
+    )
+ORDER BY prp.created_at DESC, prp.id DESC
+LIMIT sqlc.arg(page_limit)
+OFFSET sqlc.arg(page_offset);
+
+-- name: DeleteExpiredPromptRequestPayloads :execrows
+DELETE FROM prompt_request_payload AS prp
+USING application AS a
+WHERE
+    prp.application_id = a.id
+    AND prp.created_at < NOW() - make_interval(days => a.payload_log_retention_days);
@@ -13,7 +13,46 @@ INSERT INTO prompt_request_record (
     prompt_config_id,
     provider_model_pricing_id,
     error_log,
-    finish_reason
+    finish_reason,
+    attempts,
+    is_cache_hit,
+    prompt_config_version_id,
+    is_traffic_split,
+    application_id
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
 RETURNING *;
+
+-- name: RetrievePromptRequestTimeSeries :many
+SELECT
+    date_trunc(sqlc.arg(bucket_size)::text, prr.created_at)::timestamptz AS bucket,
+    COALESCE(CASE sqlc.arg(group_by)::text
+        WHEN 'application' THEN a.id::text
+        WHEN 'promptConfig' THEN pc.id::text
+        WHEN 'modelType' THEN COALESCE(pmp.model_type, pc.model_type)::text
+        WHEN 'modelVendor' THEN COALESCE(pmp.model_vendor, pc.model_vendor)::text
+        WHEN 'finishReason' THEN prr.finish_reason::text
+    END, '')::text AS group_key,
+    COALESCE(CASE sqlc.arg(group_by)::text
+        WHEN 'application' THEN a.name
+        WHEN 'promptConfig' THEN pc.name
+    END, '')::text AS group_name,
+    COUNT(prr.id) AS total_requests,
+    COUNT(prr.id) FILTER (WHERE prr.finish_reason = 'ERROR') AS total_errors,
+    COALESCE(SUM(prr.request_tokens), 0)::bigint AS request_tokens,
+    COALESCE(SUM(prr.response_tokens), 0)::bigint AS response_tokens,
+    COALESCE(SUM(prr.request_tokens_cost + prr.response_tokens_cost), 0)::numeric AS tokens_cost,
+    COALESCE(PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY prr.duration_ms), 0)::float8 AS p50_duration_ms,
+    COALESCE(PERCENTILE_CONT(0.95) WITHIN GROUP (ORDER BY prr.duration_ms), 0)::float8 AS p95_duration_ms,
+    COALESCE(PERCENTILE_CONT(0.99) WITHIN GROUP (ORDER BY prr.duration_ms), 0)::float8 AS p99_duration_ms
+FROM prompt_request_record AS prr
+LEFT JOIN prompt_config AS pc ON prr.prompt_config_id = pc.id
+INNER JOIN application AS a ON COALESCE(prr.application_id, pc.application_id) = a.id
+LEFT JOIN provider_model_pricing AS pmp ON prr.provider_model_pricing_id = pmp.id
+WHERE
+    a.project_id = sqlc.arg(project_id)
+    AND (sqlc.narg(application_id)::uuid IS NULL OR a.id = sqlc.narg(application_id))
+    AND (sqlc.narg(prompt_config_id)::uuid IS NULL OR pc.id = sqlc.narg(prompt_config_id))
+    AND prr.created_at BETWEEN sqlc.arg(from_date) AND sqlc.arg(to_date)
+GROUP BY bucket, group_key, group_name
+ORDER BY bucket, group_key;
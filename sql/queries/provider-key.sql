@@ -15,8 +15,9 @@ FROM provider_key WHERE project_id = $1 AND model_vendor = $2;
 -- name: CheckProviderKeyExists :one
 SELECT EXISTS(SELECT 1 FROM provider_key WHERE id = $1);
 
--- name: DeleteProviderKey :exec
-DELETE FROM provider_key WHERE id = $1;
+-- name: DeleteProviderKey :one
+DELETE FROM provider_key WHERE id = $1
+RETURNING model_vendor;
 
 -- name: RetrieveProjectProviderKeys :many
 SELECT
@@ -6,13 +6,17 @@ INSERT INTO provider_model_pricing (
     model_vendor,
     input_token_price,
     output_token_price,
+    cached_input_token_price,
+    reasoning_token_price,
+    image_token_price,
     token_unit_size,
     active_from_date,
-    active_to_date
+    active_to_date,
+    project_id
 )
 VALUES (
     $1,
-    $2, $3, $4, $5, $6, $7
+    $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
 ) RETURNING *;
 
 -- name: RetrieveActiveProviderModelPricing :one
@@ -22,14 +26,75 @@ SELECT
     model_vendor,
     input_token_price,
     output_token_price,
+    cached_input_token_price,
+    reasoning_token_price,
+    image_token_price,
     token_unit_size,
     active_from_date,
     active_to_date
 FROM provider_model_pricing
 WHERE
-    model_type = $1
-    AND model_vendor = $2
+    model_type = sqlc.arg(model_type)
+    AND model_vendor = sqlc.arg(model_vendor)
+    AND (project_id IS NULL OR project_id = sqlc.narg(project_id))
     AND active_from_date <= CURRENT_DATE
-    AND active_to_date IS NULL OR active_to_date >= CURRENT_DATE
-ORDER BY active_from_date DESC
+    AND (active_to_date IS NULL OR active_to_date >= CURRENT_DATE)
+ORDER BY project_id IS NULL, active_from_date DESC
 LIMIT 1;
+
+-- name: RetrieveActiveProviderModelPricings :many
+SELECT DISTINCT ON (model_vendor, model_type)
+    id,
+    model_type,
+    model_vendor,
+    input_token_price,
+    output_token_price,
+    cached_input_token_price,
+    reasoning_token_price,
+    image_token_price,
+    token_unit_size,
+    active_from_date,
+    active_to_date,
+    project_id
+FROM provider_model_pricing
+WHERE
+    (project_id IS NULL OR project_id = sqlc.narg(project_id))
+    AND active_from_date <= CURRENT_DATE
+    AND (active_to_date IS NULL OR active_to_date >= CURRENT_DATE)
+ORDER BY model_vendor, model_type, project_id IS NULL, active_from_date DESC;
+
+-- name: RetrieveProviderModelPricings :many
+SELECT *
+FROM provider_model_pricing
+WHERE
+    (sqlc.narg(project_id)::uuid IS NULL OR project_id = sqlc.narg(project_id)::uuid)
+ORDER BY model_vendor, model_type, project_id NULLS FIRST, active_from_date DESC;
+
+-- name: RetrieveProviderModelPricing :one
+SELECT *
+FROM provider_model_pricing
+WHERE id = $1;
+
+-- name: RetrieveOverlappingProviderModelPricings :many
+SELECT *
+FROM provider_model_pricing
+WHERE
+    model_type = sqlc.arg(model_type)
+    AND model_vendor = sqlc.arg(model_vendor)
+    AND project_id IS NOT DISTINCT FROM sqlc.narg(project_id)::uuid
+    AND (sqlc.narg(excluded_id)::uuid IS NULL OR id <> sqlc.narg(excluded_id)::uuid)
+    AND active_from_date <= COALESCE(sqlc.narg(active_to_date)::date, 'infinity'::date)
+    AND COALESCE(active_to_date, 'infinity'::date) >= sqlc.arg(active_from_date)::date;
+
+-- name: LockProviderModelPricings :exec
+SELECT pg_advisory_xact_lock(hashtext('provider_model_pricing'));
+
+-- name: UpdateProviderModelPricingActiveToDate :one
+UPDATE provider_model_pricing
+SET active_to_date = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteProviderModelPricing :exec
+DELETE FROM provider_model_pricing
+WHERE id = $1;
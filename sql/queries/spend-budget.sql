@@ -0,0 +1,101 @@
+---- spend_budget
+
+-- name: CreateSpendBudget :one
+INSERT INTO spend_budget (
+    project_id,
+    application_id,
+    period,
+    enforcement,
+    spend_limit
+)
+VALUES (
+    sqlc.arg(project_id),
+    sqlc.narg(application_id),
+    sqlc.arg(period),
+    sqlc.arg(enforcement),
+    sqlc.arg(spend_limit)
+)
+RETURNING *;
+
+-- name: UpdateSpendBudget :one
+UPDATE spend_budget
+SET
+    enforcement = sqlc.arg(enforcement),
+    spend_limit = sqlc.arg(spend_limit),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id) AND project_id = sqlc.arg(project_id)
+RETURNING *;
+
+-- name: DeleteSpendBudget :execrows
+DELETE FROM spend_budget
+WHERE id = sqlc.arg(id) AND project_id = sqlc.arg(project_id);
+
+-- name: RetrieveProjectSpendBudgets :many
+SELECT
+    sb.id,
+    sb.period,
+    sb.enforcement,
+    sb.spend_limit,
+    sb.created_at,
+    sb.updated_at,
+    sb.project_id,
+    sb.application_id,
+    COALESCE(a.name, '')::text AS application_name
+FROM spend_budget AS sb
+LEFT JOIN application AS a ON sb.application_id = a.id
+WHERE sb.project_id = $1
+ORDER BY sb.application_id NULLS FIRST, sb.period;
+
+-- name: RetrieveApplicationSpendBudgets :many
+SELECT
+    sb.id,
+    sb.period,
+    sb.enforcement,
+    sb.spend_limit,
+    sb.created_at,
+    sb.updated_at,
+    sb.project_id,
+    sb.application_id
+FROM spend_budget AS sb
+WHERE
+    sb.project_id = sqlc.arg(project_id)
+    AND (sb.application_id IS NULL OR sb.application_id = sqlc.arg(application_id))
+ORDER BY sb.application_id NULLS FIRST, sb.period;
+
+-- name: RetrieveSpendSince :one
+SELECT COALESCE(SUM(prr.request_tokens_cost + prr.response_tokens_cost), 0)::numeric AS total_cost
+FROM prompt_request_record AS prr
+LEFT JOIN prompt_config AS pc ON prr.prompt_config_id = pc.id
+INNER JOIN application AS a ON COALESCE(prr.application_id, pc.application_id) = a.id
+WHERE
+    a.project_id = sqlc.arg(project_id)
+    AND (sqlc.narg(application_id)::uuid IS NULL OR a.id = sqlc.narg(application_id)::uuid)
+    AND prr.created_at >= sqlc.arg(from_date);
+
+---- spend_budget_alert
+
+-- name: CreateSpendBudgetAlert :one
+INSERT INTO spend_budget_alert (spend_budget_id, period_start, threshold, spend)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (spend_budget_id, period_start, threshold) DO NOTHING
+RETURNING *;
+
+-- name: RetrieveProjectSpendBudgetAlerts :many
+SELECT
+    sba.id,
+    sba.threshold,
+    sba.spend,
+    sba.period_start,
+    sba.created_at,
+    sb.id AS spend_budget_id,
+    sb.period,
+    sb.enforcement,
+    sb.spend_limit,
+    sb.application_id,
+    COALESCE(a.name, '')::text AS application_name
+FROM spend_budget_alert AS sba
+INNER JOIN spend_budget AS sb ON sba.spend_budget_id = sb.id
+LEFT JOIN application AS a ON sb.application_id = a.id
+WHERE sb.project_id = sqlc.arg(project_id)
+ORDER BY sba.created_at DESC
+LIMIT sqlc.arg(page_limit);
@@ -67,6 +67,11 @@ CREATE TABLE application
     id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
     description text NOT NULL,
     name varchar(255) NOT NULL,
+    rate_limit_requests_per_minute int NOT NULL DEFAULT 0,
+    rate_limit_tokens_per_minute int NOT NULL DEFAULT 0,
+    payload_logging_enabled boolean NOT NULL DEFAULT FALSE,
+    payload_log_retention_days int NOT NULL DEFAULT 30,
+    payload_redaction_rules json NULL,
     created_at timestamptz NOT NULL DEFAULT now(),
     updated_at timestamptz NOT NULL DEFAULT now(),
     deleted_at timestamptz NULL,
@@ -91,7 +96,12 @@ CREATE TYPE model_type AS ENUM (
     'command',
     'command-light',
     'command-nightly',
-    'command-light-nightly'
+    'command-light-nightly',
+    'text-embedding-3-small',
+    'text-embedding-3-large',
+    'text-embedding-ada-002',
+    'embed-english-v3.0',
+    'embed-multilingual-v3.0'
 );
 
 -- prompt-config
@@ -104,6 +114,13 @@ CREATE TABLE prompt_config
     model_vendor model_vendor NOT NULL,
     provider_prompt_messages json NOT NULL,
     expected_template_variables varchar(255) [] NOT NULL,
+    fallback_models json NULL,
+    response_cache_ttl_seconds int NOT NULL DEFAULT 0,
+    tools json NULL,
+    response_schema json NULL,
+    response_schema_max_retries int NOT NULL DEFAULT 0,
+    version int NOT NULL DEFAULT 1,
+    traffic_weight int NOT NULL DEFAULT 0,
     is_default boolean NOT NULL DEFAULT TRUE,
     is_test_config boolean NOT NULL DEFAULT FALSE,
     created_at timestamptz NOT NULL DEFAULT now(),
@@ -118,6 +135,30 @@ CREATE INDEX idx_prompt_config_application_id ON prompt_config (application_id)
 CREATE INDEX idx_prompt_config_is_default ON prompt_config (is_default) WHERE deleted_at IS NULL;
 CREATE INDEX idx_prompt_config_created_at ON prompt_config (created_at) WHERE deleted_at IS NULL;
 
+-- prompt-config-version
+-- prompt config versions are immutable snapshots of the content of a prompt config.
+-- a new version is created whenever the content of a prompt config changes, including on rollback.
+CREATE TABLE prompt_config_version
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    version int NOT NULL,
+    model_parameters json NOT NULL,
+    model_type model_type NOT NULL,
+    model_vendor model_vendor NOT NULL,
+    provider_prompt_messages json NOT NULL,
+    expected_template_variables varchar(255) [] NOT NULL,
+    fallback_models json NULL,
+    tools json NULL,
+    response_schema json NULL,
+    response_schema_max_retries int NOT NULL DEFAULT 0,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    created_by_user_id uuid NULL,
+    prompt_config_id uuid NOT NULL,
+    FOREIGN KEY (created_by_user_id) REFERENCES user_account (id) ON DELETE SET NULL,
+    FOREIGN KEY (prompt_config_id) REFERENCES prompt_config (id) ON DELETE CASCADE,
+    UNIQUE (prompt_config_id, version)
+);
+
 -- provider-model-pricing
 -- we intentionally keep this model denormalized because providers can and will change their prices over time.
 -- therefore, the pricing of model use are time specific.
@@ -128,22 +169,29 @@ CREATE TABLE provider_model_pricing
     model_vendor model_vendor NOT NULL,
     input_token_price numeric NOT NULL,
     output_token_price numeric NOT NULL,
+    cached_input_token_price numeric NULL,
+    reasoning_token_price numeric NULL,
+    image_token_price numeric NULL,
     token_unit_size int NOT NULL,
     created_at timestamptz NOT NULL DEFAULT now(),
     active_from_date date NOT NULL DEFAULT current_date,
-    active_to_date date NULL
+    active_to_date date NULL,
+    project_id uuid NULL,
+    FOREIGN KEY (project_id) REFERENCES project (id) ON DELETE CASCADE
 );
 
 CREATE INDEX idx_provider_model_pricing_active_from_date ON provider_model_pricing (active_from_date);
 CREATE INDEX idx_provider_model_pricing_active_to_date ON provider_model_pricing (active_to_date);
 CREATE INDEX idx_provider_model_pricing_model_type ON provider_model_pricing (model_type);
 CREATE INDEX idx_provider_model_pricing_model_vendor ON provider_model_pricing (model_vendor);
+CREATE INDEX idx_provider_model_pricing_project_id ON provider_model_pricing (project_id);
 
 -- prompt-finish-reason
 CREATE TYPE prompt_finish_reason AS ENUM (
     'DONE',
     'ERROR',
-    'LIMIT'
+    'LIMIT',
+    'CANCELLED'
 );
 
 -- prompt-request-record
@@ -161,11 +209,18 @@ CREATE TABLE prompt_request_record
     duration_ms int NULL,
     prompt_config_id uuid NULL,
     error_log text NULL,
+    attempts int NOT NULL DEFAULT 1,
+    is_cache_hit boolean NOT NULL DEFAULT FALSE,
     created_at timestamptz NOT NULL DEFAULT now(),
     deleted_at timestamptz NULL,
     provider_model_pricing_id uuid NULL,
+    prompt_config_version_id uuid NULL,
+    is_traffic_split boolean NOT NULL DEFAULT FALSE,
+    application_id uuid NULL,
     FOREIGN KEY (provider_model_pricing_id) REFERENCES provider_model_pricing (id) ON DELETE CASCADE,
-    FOREIGN KEY (prompt_config_id) REFERENCES prompt_config (id) ON DELETE CASCADE
+    FOREIGN KEY (prompt_config_id) REFERENCES prompt_config (id) ON DELETE CASCADE,
+    FOREIGN KEY (prompt_config_version_id) REFERENCES prompt_config_version (id) ON DELETE SET NULL,
+    FOREIGN KEY (application_id) REFERENCES application (id) ON DELETE CASCADE
 );
 
 CREATE INDEX idx_prompt_request_record_prompt_config_id ON prompt_request_record (
@@ -174,12 +229,18 @@ CREATE INDEX idx_prompt_request_record_prompt_config_id ON prompt_request_record
 CREATE INDEX idx_prompt_request_record_pricing_id ON prompt_request_record (
     provider_model_pricing_id
 ) WHERE deleted_at IS NULL;
+CREATE INDEX idx_prompt_request_record_prompt_config_version_id ON prompt_request_record (
+    prompt_config_version_id
+) WHERE deleted_at IS NULL;
 CREATE INDEX idx_prompt_request_record_start_time ON prompt_request_record (
     start_time
 ) WHERE deleted_at IS NULL;
 CREATE INDEX idx_prompt_request_record_finish_time ON prompt_request_record (
     finish_time
 ) WHERE deleted_at IS NULL;
+CREATE INDEX idx_prompt_request_record_application_id ON prompt_request_record (
+    application_id
+) WHERE deleted_at IS NULL;
 
 -- prompt-test-record
 CREATE TABLE prompt_test_record
@@ -203,6 +264,8 @@ CREATE TABLE api_key
     id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
     name varchar(255) NOT NULL,
     is_internal boolean NOT NULL DEFAULT FALSE,
+    rate_limit_requests_per_minute int NOT NULL DEFAULT 0,
+    rate_limit_tokens_per_minute int NOT NULL DEFAULT 0,
     created_at timestamptz NOT NULL DEFAULT now(),
     deleted_at timestamptz NULL,
     application_id uuid NOT NULL,
@@ -222,3 +285,205 @@ CREATE TABLE provider_key
 );
 CREATE INDEX idx_provider_key_project_id ON provider_key (project_id);
 CREATE UNIQUE INDEX idx_provider_key_model_vendor_project_id ON provider_key (model_vendor, project_id);
+
+-- conversation-message-role
+CREATE TYPE conversation_message_role AS ENUM (
+    'system',
+    'user',
+    'assistant',
+    'tool'
+);
+
+-- conversation-message
+CREATE TABLE conversation_message
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    conversation_id varchar(255) NOT NULL,
+    role conversation_message_role NOT NULL,
+    content text NOT NULL,
+    tool_call_id varchar(255) NULL,
+    tool_calls json NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    application_id uuid NOT NULL,
+    FOREIGN KEY (application_id) REFERENCES application (id) ON DELETE CASCADE
+);
+CREATE INDEX idx_conversation_message_application_id_conversation_id ON conversation_message (
+    application_id, conversation_id, created_at
+);
+
+-- prompt-request-payload
+CREATE TABLE prompt_request_payload
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    template_variables json NOT NULL,
+    request_messages json NOT NULL,
+    response_content text NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    prompt_request_record_id uuid NOT NULL,
+    application_id uuid NOT NULL,
+    FOREIGN KEY (prompt_request_record_id) REFERENCES prompt_request_record (id) ON DELETE CASCADE,
+    FOREIGN KEY (application_id) REFERENCES application (id) ON DELETE CASCADE
+);
+CREATE INDEX idx_prompt_request_payload_prompt_request_record_id ON prompt_request_payload (
+    prompt_request_record_id
+);
+CREATE INDEX idx_prompt_request_payload_application_id_created_at ON prompt_request_payload (
+    application_id, created_at
+);
+
+-- spend-budget
+CREATE TYPE spend_budget_period AS ENUM (
+    'DAILY',
+    'MONTHLY'
+);
+
+CREATE TYPE spend_budget_enforcement AS ENUM (
+    'HARD',
+    'SOFT'
+);
+
+CREATE TABLE spend_budget
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    period spend_budget_period NOT NULL,
+    enforcement spend_budget_enforcement NOT NULL,
+    spend_limit numeric NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    project_id uuid NOT NULL,
+    application_id uuid NULL,
+    FOREIGN KEY (project_id) REFERENCES project (id) ON DELETE CASCADE,
+    FOREIGN KEY (application_id) REFERENCES application (id) ON DELETE CASCADE
+);
+CREATE INDEX idx_spend_budget_project_id ON spend_budget (project_id);
+CREATE UNIQUE INDEX idx_spend_budget_project_id_period ON spend_budget (
+    project_id, period
+) WHERE application_id IS NULL;
+CREATE UNIQUE INDEX idx_spend_budget_application_id_period ON spend_budget (
+    application_id, period
+) WHERE application_id IS NOT NULL;
+
+-- spend-budget-alert
+CREATE TABLE spend_budget_alert
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    threshold int NOT NULL,
+    spend numeric NOT NULL,
+    period_start timestamptz NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    spend_budget_id uuid NOT NULL,
+    FOREIGN KEY (spend_budget_id) REFERENCES spend_budget (id) ON DELETE CASCADE,
+    UNIQUE (spend_budget_id, period_start, threshold)
+);
+CREATE INDEX idx_spend_budget_alert_spend_budget_id_created_at ON spend_budget_alert (
+    spend_budget_id, created_at
+);
+
+-- credit-ledger-entry
+CREATE TYPE credit_ledger_entry_type AS ENUM (
+    'DEBIT',
+    'ADJUSTMENT',
+    'GRANT'
+);
+
+CREATE TABLE credit_ledger_entry
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    entry_type credit_ledger_entry_type NOT NULL,
+    amount numeric NOT NULL,
+    balance_after numeric NOT NULL,
+    description varchar(255) NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT now(),
+    payment_reference varchar(255) NOT NULL DEFAULT '',
+    project_id uuid NOT NULL,
+    prompt_request_record_id uuid NULL,
+    user_account_id uuid NULL,
+    FOREIGN KEY (project_id) REFERENCES project (id) ON DELETE CASCADE,
+    FOREIGN KEY (
+        prompt_request_record_id
+    ) REFERENCES prompt_request_record (id) ON DELETE SET NULL,
+    FOREIGN KEY (user_account_id) REFERENCES user_account (id) ON DELETE SET NULL,
+    UNIQUE (prompt_request_record_id)
+);
+CREATE INDEX idx_credit_ledger_entry_project_id_created_at ON credit_ledger_entry (
+    project_id, created_at
+);
+
+-- credit-hold
+CREATE TABLE credit_hold
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    amount numeric NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    expires_at timestamptz NOT NULL,
+    project_id uuid NOT NULL,
+    FOREIGN KEY (project_id) REFERENCES project (id) ON DELETE CASCADE
+);
+CREATE INDEX idx_credit_hold_project_id_expires_at ON credit_hold (
+    project_id, expires_at
+);
+
+-- batch-job
+CREATE TYPE batch_job_status AS ENUM (
+    'PENDING',
+    'RUNNING',
+    'COMPLETED',
+    'FAILED'
+);
+
+CREATE TABLE batch_job
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    status batch_job_status NOT NULL DEFAULT 'PENDING',
+    prompt_config_id uuid NULL,
+    total_items int NOT NULL,
+    callback_url varchar(2048) NULL,
+    encrypted_callback_secret varchar(255) NULL,
+    callback_attempts int NOT NULL DEFAULT 0,
+    callback_available_at timestamptz NULL,
+    callback_delivered_at timestamptz NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    started_at timestamptz NULL,
+    finished_at timestamptz NULL,
+    application_id uuid NOT NULL,
+    FOREIGN KEY (application_id) REFERENCES application (id) ON DELETE CASCADE,
+    FOREIGN KEY (prompt_config_id) REFERENCES prompt_config (id) ON DELETE SET NULL
+);
+CREATE INDEX idx_batch_job_application_id_created_at ON batch_job (
+    application_id, created_at
+);
+CREATE INDEX idx_batch_job_callback_available_at ON batch_job (
+    callback_available_at
+) WHERE callback_delivered_at IS NULL;
+
+-- batch-job-item
+CREATE TYPE batch_job_item_status AS ENUM (
+    'PENDING',
+    'RUNNING',
+    'COMPLETED',
+    'FAILED'
+);
+
+CREATE TABLE batch_job_item
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    item_index int NOT NULL,
+    template_variables json NOT NULL,
+    status batch_job_item_status NOT NULL DEFAULT 'PENDING',
+    attempts int NOT NULL DEFAULT 0,
+    available_at timestamptz NOT NULL DEFAULT now(),
+    content text NULL,
+    error_log text NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    finished_at timestamptz NULL,
+    batch_job_id uuid NOT NULL,
+    prompt_request_record_id uuid NULL,
+    FOREIGN KEY (batch_job_id) REFERENCES batch_job (id) ON DELETE CASCADE,
+    FOREIGN KEY (
+        prompt_request_record_id
+    ) REFERENCES prompt_request_record (id) ON DELETE SET NULL,
+    UNIQUE (batch_job_id, item_index)
+);
+CREATE INDEX idx_batch_job_item_status_available_at ON batch_job_item (
+    available_at
+) WHERE status IN ('PENDING', 'RUNNING');